@@ -0,0 +1,570 @@
+// Package api exposes the conversion engine as a local JSON-over-HTTP API, so
+// test rigs and other tools can call hexview programmatically.
+//
+// All conversion endpoints accept POST requests with a JSON body and respond
+// with the same models the GUI uses. Failed requests return {"error": "..."}
+// with status 400 (bad input) or 413 (body too large). Input errors add
+// "details" with an error code and the position of the invalid characters.
+//
+//	GET  /api/v1/health
+//	POST /api/v1/convert/hex     {"input": "0x41424344"}
+//	POST /api/v1/convert/int     {"input": "513", "type": "uint16"}
+//	POST /api/v1/convert/float   {"input": "1.5", "type": "float32"}
+//	POST /api/v1/convert/binary  {"input": "00000001"}
+//	POST /api/v1/convert/auto    {"input": "-42"}
+//	POST /api/v1/convert/encoded {"input": "Gr=C3=BC=C3=9Fe", "type": "quoted-printable"}
+//	POST /api/v1/convert/array   {"input": "0100 0200", "type": "int16", "order": "LE"}
+//	POST /api/v1/convert/delta   {"input": "0a00 0100 feff", "type": "int16", "order": "LE", "mode": "decode"}
+//	POST /api/v1/convert/string  {"input": "Grüße"}
+//	POST /api/v1/convert/pcm     {"input": "0040 0000 00c0 0000", "format": "s16le", "channels": 2}
+//	POST /api/v1/convert/fixed   {"input": "4000 8000", "format": "Q15", "order": "BE"}
+//	POST /api/v1/plot            {"input": "0100 0200", "type": "int16", "order": "LE", "maxPoints": 500}
+//	POST /api/v1/hexdump         {"input": "48656c6c6f", "width": 16, "startRow": 0, "maxRows": 64}
+//	POST /api/v1/dump            {"input": "00000000: 4865 6c6c 6f0a  Hello."}
+//	POST /api/v1/modbus          {"input": "0x4248 0x0000"}
+//	POST /api/v1/modbus/frame    {"input": "01 03 00 00 00 0a c5 cd"}
+//	POST /api/v1/modbus/decode   {"input": "00 01 00 00 00 06 11 03 00 6b 00 03", "framing": "tcp"}
+//	POST /api/v1/cipher          {"input": "55727279", "cipher": "caesar", "shift": -13}
+//	POST /api/v1/cast            {"input": "40000", "from": "int32", "to": "int16"}
+//	POST /api/v1/alu             {"a": "127", "b": "1", "op": "add", "bits": 8}
+//	POST /api/v1/offset          {"op": "index", "base": "0x20000000", "index": "3", "stride": "12"}
+//	POST /api/v1/address         {"address": "0x20001044", "base": "0x20000000"}
+//	POST /api/v1/struct          {"input": "cafebabe0100", "layout": "magic u32 be\nversion u16"}
+//	POST /api/v1/pmbus           {"input": "6606", "format": "linear16", "order": "LE", "voutMode": 23}
+//	POST /api/v1/crash           {"input": "00000000 ... 03000061", "layout": "cortex-m"}
+//	POST /api/v1/triage          {"input": "00847ad2e15b3f9c 801e2a4cfd7f0000", "arch": "x86-64"}
+//	POST /api/v1/checksum        {"input": "01 03 00 00 00 0a"}
+//	POST /api/v1/hash            {"input": "616263"}
+//	POST /api/v1/diff            {"a": "0102", "b": "01ff"}
+//	POST /api/v1/swaps           {"observed": "56781234", "expected": "12345678"}
+//	POST /api/v1/bulk            {"inputs": ["0102", "ff"], "mode": "hex", "compact": true}
+//
+// The conversion endpoints are also available under /api/v2/convert/, which
+// returns the values grouped by type and byte order
+// (models.ConversionResultV2), e.g. {"int32": {"LE": {"value": "1", "hex": "01000000"}}}.
+// With "compact": true in the request, /api/v1/convert/ returns only the
+// non-empty values, e.g. {"int32LE": {"value": "1", "hex": "01000000"}}.
+//
+// Example usage:
+//
+//	srv := api.NewServer(service.NewConverter())
+//	addr, _ := srv.Start("127.0.0.1:8787")
+//	defer srv.Stop(context.Background())
+//	fmt.Println("listening on", addr)
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"hexview/models"
+	"hexview/service"
+)
+
+// DefaultAddr is the default listen address. It only accepts local connections.
+const DefaultAddr = "127.0.0.1:8787"
+
+// MaxRequestSize is the largest accepted request body.
+const MaxRequestSize = 16 << 20
+
+// ErrServerRunning indicates Start was called on a running server
+var ErrServerRunning = errors.New("API server already running")
+
+// convertRequest is the body of the conversion endpoints.
+type convertRequest struct {
+	Input   string `json:"input"`
+	Type    string `json:"type,omitempty"`
+	Compact bool   `json:"compact,omitempty"` // return only the non-empty values as name → {value, hex}
+}
+
+// bulkRequest is the body of the bulk endpoint.
+type bulkRequest struct {
+	Inputs []string `json:"inputs"`
+	Mode   string   `json:"mode"`
+	models.BulkOptions
+}
+
+// encodeRequest is the body of the Modbus encode endpoint.
+type encodeRequest struct {
+	Value string `json:"value"`
+	Type  string `json:"type"`
+	Order string `json:"order,omitempty"` // BE if empty
+}
+
+// modbusDecodeRequest is the body of the Modbus decode endpoint.
+type modbusDecodeRequest struct {
+	Input   string `json:"input"`
+	Framing string `json:"framing,omitempty"` // rtu, tcp or pdu; detected if empty
+}
+
+// swapsRequest is the body of the swaps endpoint.
+type swapsRequest struct {
+	Observed string `json:"observed"`
+	Expected string `json:"expected"`
+}
+
+// arrayRequest is the body of the array endpoint.
+type arrayRequest struct {
+	Input string `json:"input"`
+	Type  string `json:"type"`
+	Order string `json:"order,omitempty"` // BE if empty
+}
+
+// fixedPointRequest is the body of the fixed-point endpoint.
+type fixedPointRequest struct {
+	Input  string `json:"input"`
+	Format string `json:"format"`          // e.g. Q15, Q1.14 or UQ8.8
+	Order  string `json:"order,omitempty"` // BE if empty
+}
+
+// deltaRequest is the body of the delta endpoint.
+type deltaRequest struct {
+	arrayRequest
+	Mode string `json:"mode"` // decode or encode
+}
+
+// pcmRequest is the body of the PCM endpoint.
+type pcmRequest struct {
+	Input    string `json:"input"`
+	Format   string `json:"format"`             // e.g. s16le, u8 or s24be
+	Channels int    `json:"channels,omitempty"` // interleaved channels, 1 if zero
+}
+
+// castRequest is the body of the cast endpoint.
+type castRequest struct {
+	Input string `json:"input"`
+	From  string `json:"from"` // integer or float type, e.g. int32
+	To    string `json:"to"`   // integer type, e.g. int16
+}
+
+// aluRequest is the body of the ALU endpoint.
+type aluRequest struct {
+	A    string `json:"a"`
+	B    string `json:"b"`
+	Op   string `json:"op"`   // add, sub or mul
+	Bits int    `json:"bits"` // 8, 16, 32 or 64
+}
+
+// addressRequest is the body of the address endpoint.
+type addressRequest struct {
+	Address string `json:"address"`
+	Base    string `json:"base,omitempty"` // e.g. the start of a section
+}
+
+// structRequest is the body of the struct endpoint.
+type structRequest struct {
+	Input  string `json:"input"`
+	Layout string `json:"layout"` // JSON or one field per line
+}
+
+// cipherRequest is the body of the cipher endpoint.
+type cipherRequest struct {
+	Input  string `json:"input"`
+	Cipher string `json:"cipher"`
+	Shift  int    `json:"shift"` // of the Caesar cipher
+}
+
+// pmbusRequest is the body of the PMBus endpoint.
+type pmbusRequest struct {
+	Input    string `json:"input"`
+	Format   string `json:"format"`             // linear11, linear16 or block
+	Order    string `json:"order,omitempty"`    // byte order of the words, default LE
+	VoutMode int    `json:"voutMode,omitempty"` // VOUT_MODE value for linear16
+}
+
+// crashRequest is the body of the crash dump endpoint.
+type crashRequest struct {
+	Input  string `json:"input"`
+	Layout string `json:"layout"` // cortex-m, cortex-m-fpu, freertos or threadx
+}
+
+// triageRequest is the body of the triage endpoint.
+type triageRequest struct {
+	Input string `json:"input"`
+	Arch  string `json:"arch"` // x86-32, x86-64, arm or arm64
+}
+
+// plotRequest is the body of the plot endpoint.
+type plotRequest struct {
+	Input string `json:"input"`
+	models.PlotOptions
+}
+
+// hexDumpRequest is the body of the hex dump endpoint.
+type hexDumpRequest struct {
+	Input string `json:"input"`
+	models.HexDumpOptions
+}
+
+// diffRequest is the body of the diff endpoint.
+type diffRequest struct {
+	A string `json:"a"`
+	B string `json:"b"`
+}
+
+// errorResponse is returned for failed requests.
+type errorResponse struct {
+	Error   string             `json:"error"`
+	Details *models.InputError `json:"details,omitempty"`
+}
+
+// NewHandler returns an http.Handler serving the API on top of conv.
+func NewHandler(conv *service.Converter) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/v1/health", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+
+	convert := func(fn func(req convertRequest) (any, error)) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var req convertRequest
+			if !decode(w, r, &req) {
+				return
+			}
+			result, err := fn(req)
+			respond(w, result, err)
+		}
+	}
+
+	conversions := map[string]func(req convertRequest) (*models.ConversionResult, error){
+		"hex": func(req convertRequest) (*models.ConversionResult, error) {
+			return conv.ConvertHex(req.Input)
+		},
+		"int": func(req convertRequest) (*models.ConversionResult, error) {
+			return conv.ConvertInt(req.Input, service.OrDefault(models.IntType(req.Type), models.Int32))
+		},
+		"float": func(req convertRequest) (*models.ConversionResult, error) {
+			return conv.ConvertFloat(req.Input, service.OrDefault(models.FloatType(req.Type), models.Float32))
+		},
+		"binary": func(req convertRequest) (*models.ConversionResult, error) {
+			return conv.ConvertBinary(req.Input)
+		},
+		"auto": func(req convertRequest) (*models.ConversionResult, error) {
+			return conv.ConvertIntAuto(req.Input)
+		},
+		"encoded": func(req convertRequest) (*models.ConversionResult, error) {
+			return conv.ConvertEncoded(req.Input, req.Type)
+		},
+	}
+	for name, fn := range conversions {
+		mux.Handle("POST /api/v1/convert/"+name, convert(func(req convertRequest) (any, error) {
+			result, err := fn(req)
+			if err != nil || !req.Compact {
+				return result, err
+			}
+			return service.CompactResult(result), nil
+		}))
+		mux.Handle("POST /api/v2/convert/"+name, convert(func(req convertRequest) (any, error) {
+			result, err := fn(req)
+			if err != nil {
+				return nil, err
+			}
+			return service.GroupResult(result), nil
+		}))
+	}
+	mux.HandleFunc("POST /api/v1/convert/array", func(w http.ResponseWriter, r *http.Request) {
+		var req arrayRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		result, err := conv.ConvertArray(req.Input, req.Type, service.OrDefault(req.Order, "BE"))
+		respond(w, result, err)
+	})
+	mux.HandleFunc("POST /api/v1/convert/delta", func(w http.ResponseWriter, r *http.Request) {
+		var req deltaRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		result, err := conv.ConvertDelta(req.Input, req.Type, service.OrDefault(req.Order, "BE"), req.Mode)
+		respond(w, result, err)
+	})
+	mux.Handle("POST /api/v1/convert/string", convert(func(req convertRequest) (any, error) {
+		return conv.ConvertString(req.Input)
+	}))
+	mux.HandleFunc("POST /api/v1/convert/pcm", func(w http.ResponseWriter, r *http.Request) {
+		var req pcmRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		result, err := conv.ConvertPCM(req.Input, req.Format, req.Channels)
+		respond(w, result, err)
+	})
+	mux.HandleFunc("POST /api/v1/convert/fixed", func(w http.ResponseWriter, r *http.Request) {
+		var req fixedPointRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		result, err := conv.ConvertFixedPoint(req.Input, req.Format, service.OrDefault(req.Order, "BE"))
+		respond(w, result, err)
+	})
+	mux.HandleFunc("POST /api/v1/cipher", func(w http.ResponseWriter, r *http.Request) {
+		var req cipherRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		result, err := conv.ApplyCipher(req.Input, req.Cipher, req.Shift)
+		respond(w, result, err)
+	})
+	mux.HandleFunc("POST /api/v1/cast", func(w http.ResponseWriter, r *http.Request) {
+		var req castRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		result, err := conv.SimulateCast(req.Input, req.From, req.To)
+		respond(w, result, err)
+	})
+	mux.HandleFunc("POST /api/v1/alu", func(w http.ResponseWriter, r *http.Request) {
+		var req aluRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		result, err := conv.ComputeALU(req.A, req.B, req.Op, req.Bits)
+		respond(w, result, err)
+	})
+	mux.HandleFunc("POST /api/v1/offset", func(w http.ResponseWriter, r *http.Request) {
+		var req models.OffsetRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		result, err := conv.CalculateOffset(req)
+		respond(w, result, err)
+	})
+	mux.HandleFunc("POST /api/v1/address", func(w http.ResponseWriter, r *http.Request) {
+		var req addressRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		result, err := conv.AnalyzeAddress(req.Address, req.Base)
+		respond(w, result, err)
+	})
+	mux.HandleFunc("POST /api/v1/struct", func(w http.ResponseWriter, r *http.Request) {
+		var req structRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		result, err := conv.DecodeStruct(req.Input, req.Layout)
+		respond(w, result, err)
+	})
+	mux.HandleFunc("POST /api/v1/pmbus", func(w http.ResponseWriter, r *http.Request) {
+		var req pmbusRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		result, err := conv.ConvertPMBus(req.Input, req.Format, service.OrDefault(req.Order, "LE"), req.VoutMode)
+		respond(w, result, err)
+	})
+	mux.HandleFunc("POST /api/v1/crash", func(w http.ResponseWriter, r *http.Request) {
+		var req crashRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		result, err := conv.DecodeCrashDump(req.Input, req.Layout)
+		respond(w, result, err)
+	})
+	mux.HandleFunc("POST /api/v1/triage", func(w http.ResponseWriter, r *http.Request) {
+		var req triageRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		result, err := conv.TriageBuffer(req.Input, req.Arch)
+		respond(w, result, err)
+	})
+	mux.HandleFunc("POST /api/v1/plot", func(w http.ResponseWriter, r *http.Request) {
+		var req plotRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		result, err := conv.PlotArray(req.Input, req.PlotOptions)
+		respond(w, result, err)
+	})
+	mux.HandleFunc("POST /api/v1/hexdump", func(w http.ResponseWriter, r *http.Request) {
+		var req hexDumpRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		result, err := conv.HexDump(req.Input, req.HexDumpOptions)
+		respond(w, result, err)
+	})
+	mux.HandleFunc("POST /api/v1/dump", func(w http.ResponseWriter, r *http.Request) {
+		var req convertRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		result, err := conv.ParseDump(req.Input)
+		respond(w, result, err)
+	})
+	mux.HandleFunc("POST /api/v1/modbus", func(w http.ResponseWriter, r *http.Request) {
+		var req convertRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		// Large register lists stop converting when the client disconnects
+		result, err := conv.ConvertModbusRegistersContext(r.Context(), req.Input)
+		respond(w, result, err)
+	})
+	mux.HandleFunc("POST /api/v1/modbus/encode", func(w http.ResponseWriter, r *http.Request) {
+		var req encodeRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		result, err := conv.EncodeModbusRegisters(req.Value, req.Type, service.OrDefault(req.Order, "BE"))
+		respond(w, result, err)
+	})
+	mux.Handle("POST /api/v1/modbus/frame", convert(func(req convertRequest) (any, error) {
+		return conv.ValidateModbusFrame(req.Input)
+	}))
+	mux.HandleFunc("POST /api/v1/modbus/decode", func(w http.ResponseWriter, r *http.Request) {
+		var req modbusDecodeRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		result, err := conv.DecodeModbusPDU(req.Input, req.Framing)
+		respond(w, result, err)
+	})
+	mux.Handle("POST /api/v1/checksum", convert(func(req convertRequest) (any, error) {
+		return conv.Checksum(req.Input)
+	}))
+	mux.Handle("POST /api/v1/hash", convert(func(req convertRequest) (any, error) {
+		return conv.Hash(req.Input)
+	}))
+
+	mux.HandleFunc("POST /api/v1/bulk", func(w http.ResponseWriter, r *http.Request) {
+		var req bulkRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		result, err := conv.BulkConvert(r.Context(), req.Inputs, req.Mode, req.BulkOptions, service.DefaultSettings())
+		respond(w, result, err)
+	})
+
+	mux.HandleFunc("POST /api/v1/diff", func(w http.ResponseWriter, r *http.Request) {
+		var req diffRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		result, err := conv.Diff(req.A, req.B)
+		respond(w, result, err)
+	})
+
+	mux.HandleFunc("POST /api/v1/swaps", func(w http.ResponseWriter, r *http.Request) {
+		var req swapsRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		result, err := conv.CompareSwaps(req.Observed, req.Expected)
+		respond(w, result, err)
+	})
+
+	return mux
+}
+
+// decode parses the JSON request body into v, writing an error response on failure.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxRequestSize)
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		status := http.StatusBadRequest
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		writeJSON(w, status, errorResponse{Error: "invalid request body: " + err.Error()})
+		return false
+	}
+	return true
+}
+
+// respond writes result, or err as a 400 response.
+func respond(w http.ResponseWriter, result any, err error) {
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error(), Details: service.ErrorDetails(err)})
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// writeJSON writes v as a JSON response with the given status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// Server runs the API on a TCP address and can be started and stopped repeatedly.
+type Server struct {
+	handler http.Handler
+
+	mu   sync.Mutex
+	srv  *http.Server
+	addr string
+}
+
+// NewServer creates a stopped Server for conv.
+func NewServer(conv *service.Converter) *Server {
+	return &Server{handler: NewHandler(conv)}
+}
+
+// Start listens on addr (DefaultAddr if empty) and serves the API in the
+// background. It returns the actual listen address.
+func (s *Server) Start(addr string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.srv != nil {
+		return "", fmt.Errorf("%w on %s", ErrServerRunning, s.addr)
+	}
+	if addr == "" {
+		addr = DefaultAddr
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("cannot listen on %s: %w", addr, err)
+	}
+	s.srv = &http.Server{Handler: s.handler, ReadHeaderTimeout: 10 * time.Second}
+	s.addr = ln.Addr().String()
+
+	go s.srv.Serve(ln)
+	return s.addr, nil
+}
+
+// Serve listens on addr and serves the API until ctx is cancelled.
+func (s *Server) Serve(ctx context.Context, addr string) error {
+	if _, err := s.Start(addr); err != nil {
+		return err
+	}
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.Stop(shutdownCtx)
+}
+
+// Stop gracefully shuts the server down. Stopping a stopped server is a no-op.
+func (s *Server) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	srv := s.srv
+	s.srv = nil
+	s.addr = ""
+	s.mu.Unlock()
+
+	if srv == nil {
+		return nil
+	}
+	return srv.Shutdown(ctx)
+}
+
+// Addr returns the listen address of the running server, or "" if stopped.
+func (s *Server) Addr() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addr
+}
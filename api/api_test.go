@@ -0,0 +1,231 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hexview/models"
+	"hexview/service"
+)
+
+func TestHandler(t *testing.T) {
+	h := NewHandler(service.NewConverter())
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantKey    string
+		wantValue  any
+	}{
+		{"health", "GET", "/api/v1/health", "", 200, "status", "ok"},
+		{"convert hex", "POST", "/api/v1/convert/hex", `{"input": "0x0102"}`, 200, "uint16BE", 258.0},
+		{"convert int", "POST", "/api/v1/convert/int", `{"input": "513", "type": "uint16"}`, 200, "bytes", "0201"},
+		{"convert float default type", "POST", "/api/v1/convert/float", `{"input": "1.5"}`, 200, "float32BEHex", "3fc00000"},
+		{"convert binary", "POST", "/api/v1/convert/binary", `{"input": "11111111"}`, 200, "bytes", "ff"},
+		{"convert auto", "POST", "/api/v1/convert/auto", `{"input": "255"}`, 200, "int16BEHex", "00ff"},
+		{"convert hex v2", "POST", "/api/v2/convert/hex", `{"input": "0x0102"}`, 200, "version", 2.0},
+		{"invalid hex v2", "POST", "/api/v2/convert/hex", `{"input": "zz"}`, 400, "", nil},
+		{"convert string", "POST", "/api/v1/convert/string", `{"input": "Grüße"}`, 200, "characters", 5.0},
+		{"alu", "POST", "/api/v1/alu", `{"a": "127", "b": "1", "op": "add", "bits": 8}`, 200, "signedExact", "128"},
+		{"offset", "POST", "/api/v1/offset", `{"op": "alignUp", "base": "0x1001", "align": "16"}`, 200, "aligned", false},
+		{"address", "POST", "/api/v1/address", `{"address": "0x1040"}`, 200, "alignment", float64(64)},
+		{"hash", "POST", "/api/v1/hash", `{"input": "616263"}`, 200, "crc32", "352441c2"},
+		{"struct", "POST", "/api/v1/struct", `{"input": "cafebabe0100", "layout": "magic u32 be\nversion u16"}`, 200, "size", float64(6)},
+		{"fixed point", "POST", "/api/v1/convert/fixed", `{"input": "c000", "format": "Q15"}`, 200, "resolution", "0.000030517578125"},
+		{"fixed point bad format", "POST", "/api/v1/convert/fixed", `{"input": "c000", "format": "Q14"}`, 400, "", nil},
+		{"crash", "POST", "/api/v1/crash", `{"input": "0100000002000000030000000400000005000000350200084002000803000061", "layout": "cortex-m"}`, 200, "exception", "HardFault"},
+		{"crash short", "POST", "/api/v1/crash", `{"input": "01000000", "layout": "freertos"}`, 400, "", nil},
+		{"dump", "POST", "/api/v1/dump", `{"input": "00000010: 4865 6c6c 6f0a  Hello."}`, 200, "base", "0x10"},
+		{"dump escaped string", "POST", "/api/v1/dump", `{"input": "\"\\x48\\x69\""}`, 200, "hex", "4869"},
+		{"hexdump of a dump with a gap", "POST", "/api/v1/hexdump", `{"input": "00000000: 0102  ..\n00001000: 0304  ..", "format": "dump"}`, 200, "gaps", float64(1)},
+		{"dump plain hex", "POST", "/api/v1/dump", `{"input": "48656c6c6f"}`, 400, "", nil},
+		{"triage", "POST", "/api/v1/triage", `{"input": "00847ad2e15b3f9c", "arch": "x86-64"}`, 200, "words", float64(1)},
+		{"triage bad arch", "POST", "/api/v1/triage", `{"input": "00847ad2e15b3f9c", "arch": "x86-16"}`, 400, "", nil},
+		{"struct bad layout", "POST", "/api/v1/struct", `{"input": "01", "layout": "x u12"}`, 400, "", nil},
+		{"cast", "POST", "/api/v1/cast", `{"input": "40000", "from": "int32", "to": "int16"}`, 200, "exact", false},
+		{"modbus decode", "POST", "/api/v1/modbus/decode", `{"input": "00 01 00 00 00 06 11 03 00 6b 00 03"}`, 200, "count", 3.0},
+		{"modbus frame", "POST", "/api/v1/modbus/frame", `{"input": "01 03 00 00 00 0a c5 cd"}`, 200, "valid", true},
+		{"checksum", "POST", "/api/v1/checksum", `{"input": "313233343536373839"}`, 200, "length", 9.0},
+		{"swaps", "POST", "/api/v1/swaps", `{"observed": "56781234", "expected": "12345678"}`, 200, "expected", "12345678"},
+		{"diff", "POST", "/api/v1/diff", `{"a": "0102", "b": "01ff"}`, 200, "diffBytes", 1.0},
+		{"bulk", "POST", "/api/v1/bulk", `{"inputs": ["0102", "zz"], "mode": "hex", "workers": 2}`, 200, "failed", 1.0},
+		{"bulk unknown mode", "POST", "/api/v1/bulk", `{"inputs": ["01"], "mode": "octal"}`, 400, "", nil},
+		{"invalid hex", "POST", "/api/v1/convert/hex", `{"input": "zz"}`, 400, "", nil},
+		{"malformed body", "POST", "/api/v1/convert/hex", `{"input":`, 400, "", nil},
+		{"unknown field", "POST", "/api/v1/convert/hex", `{"value": "01"}`, 400, "", nil},
+		{"wrong method", "GET", "/api/v1/convert/hex", "", 405, "", nil},
+		{"unknown path", "GET", "/api/v1/nope", "", 404, "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus == 400 {
+				if !strings.Contains(rec.Body.String(), `"error"`) {
+					t.Errorf("expected JSON error body, got %s", rec.Body)
+				}
+				return
+			}
+			if tt.wantKey == "" {
+				return
+			}
+			var body map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			if body[tt.wantKey] != tt.wantValue {
+				t.Errorf("%s = %v, want %v", tt.wantKey, body[tt.wantKey], tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestCompact(t *testing.T) {
+	h := NewHandler(service.NewConverter())
+	req := httptest.NewRequest("POST", "/api/v1/convert/hex", strings.NewReader(`{"input": "0x0102", "compact": true}`))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var body map[string]struct{ Value, Hex string }
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if v := body["uint16BE"]; v.Value != "258" || v.Hex != "0102" {
+		t.Errorf("uint16BE = %+v", v)
+	}
+	if _, ok := body["int8BE"]; ok {
+		t.Error("Expected no int8 values for two bytes")
+	}
+}
+
+func TestErrorDetails(t *testing.T) {
+	h := NewHandler(service.NewConverter())
+	req := httptest.NewRequest("POST", "/api/v1/convert/hex", strings.NewReader(`{"input": "12 zz"}`))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var body struct {
+		Error   string `json:"error"`
+		Details struct {
+			Code     string `json:"code"`
+			Position int    `json:"position"`
+			Length   int    `json:"length"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if body.Details.Code != "invalid_hex_char" || body.Details.Position != 3 || body.Details.Length != 1 {
+		t.Errorf("unexpected details: %+v", body.Details)
+	}
+}
+
+func TestModbusEndpoint(t *testing.T) {
+	h := NewHandler(service.NewConverter())
+	req := httptest.NewRequest("POST", "/api/v1/modbus", strings.NewReader(`{"input": "0x4248 0x0000"}`))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var result struct {
+		Registers []struct {
+			Hex string `json:"hex"`
+		} `json:"registers"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(result.Registers) != 2 || result.Registers[0].Hex != "4248" {
+		t.Errorf("unexpected registers: %+v", result.Registers)
+	}
+}
+
+func TestModbusEncodeEndpoint(t *testing.T) {
+	h := NewHandler(service.NewConverter())
+	req := httptest.NewRequest("POST", "/api/v1/modbus/encode", strings.NewReader(`{"value": "50", "type": "float32", "order": "CDAB"}`))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var result models.ModbusEncoding
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if result.RawHex != "0000 4248" || len(result.Registers) != 2 || result.Registers[1].Unsigned != 0x4248 {
+		t.Errorf("unexpected encoding: %+v", result)
+	}
+}
+
+func TestArrayEndpoint(t *testing.T) {
+	h := NewHandler(service.NewConverter())
+	req := httptest.NewRequest("POST", "/api/v1/convert/array", strings.NewReader(`{"input": "0100 ffff 02", "type": "int16", "order": "LE"}`))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var result models.ArrayResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if result.Count != 2 || result.Remainder != 1 || strings.Join(result.Values, " ") != "1 -1" {
+		t.Errorf("unexpected array: %+v", result)
+	}
+}
+
+func TestHexDumpEndpoint(t *testing.T) {
+	h := NewHandler(service.NewConverter())
+	req := httptest.NewRequest("POST", "/api/v1/hexdump", strings.NewReader(`{"input": "48656c6c6f", "width": 4, "baseAddress": 256}`))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var result models.HexDump
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if result.TotalRows != 2 || len(result.Rows) != 2 || result.Rows[1].Address != "00000104" || result.Rows[0].ASCII != "Hell" {
+		t.Errorf("unexpected dump: %+v", result)
+	}
+}
+
+func TestServerStartStop(t *testing.T) {
+	srv := NewServer(service.NewConverter())
+	addr, err := srv.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if srv.Addr() != addr {
+		t.Errorf("Addr() = %q, want %q", srv.Addr(), addr)
+	}
+	if _, err := srv.Start("127.0.0.1:0"); !errors.Is(err, ErrServerRunning) {
+		t.Errorf("second Start() error = %v, want ErrServerRunning", err)
+	}
+
+	resp, err := http.Get("http://" + addr + "/api/v1/health")
+	if err != nil {
+		t.Fatalf("GET health error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Errorf("health status = %d", resp.StatusCode)
+	}
+
+	if err := srv.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if srv.Addr() != "" {
+		t.Error("Addr() should be empty after Stop")
+	}
+	if err := srv.Stop(context.Background()); err != nil {
+		t.Errorf("second Stop() error = %v", err)
+	}
+}
@@ -2,42 +2,219 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"time"
 
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"hexview/api"
 	"hexview/models"
 	"hexview/service"
 )
 
+// fileWatchInterval is how often opened files are checked for external modifications.
+const fileWatchInterval = time.Second
+
+// clipboardPollInterval is how often the clipboard watcher checks for new content.
+const clipboardPollInterval = 500 * time.Millisecond
+
+// EventFileChanged is emitted with a models.FileChangeEvent when an opened file
+// is modified or deleted outside of hexview.
+const EventFileChanged = "file:changed"
+
+// EventCaptureData is emitted with a models.CaptureChunk whenever a capture session receives data.
+const EventCaptureData = "capture:data"
+
+// EventCaptureStopped is emitted with a models.CaptureInfo when a capture session ends.
+const EventCaptureStopped = "capture:stopped"
+
+// EventPollSamples is emitted with the []models.PollSample of every answered
+// read of a Modbus poll.
+const EventPollSamples = "poll:samples"
+
+// EventPollStopped is emitted with a models.PollInfo when a Modbus poll ends.
+const EventPollStopped = "poll:stopped"
+
+// EventAlert is emitted with a models.Alert when an alert rule starts or
+// stops matching the values of a Modbus poll or the bytes received by a
+// capture session.
+const EventAlert = "alert:changed"
+
+// EventStream is emitted with a models.StreamEvent for every batch of results
+// of a streamed operation and once more when it finishes.
+const EventStream = "stream:data"
+
+// EventClipboardConverted is emitted with a models.ClipboardConversion when the
+// clipboard watcher converts a newly copied value.
+const EventClipboardConverted = "clipboard:converted"
+
+// EventFileDropped is emitted with a models.DroppedFile for every file dropped
+// onto the window.
+const EventFileDropped = "file:dropped"
+
+// EventOperation is emitted with a models.Operation when a cancellable
+// operation starts and when it ends.
+const EventOperation = "operation:changed"
+
 // App struct holds the Wails application context and service dependencies.
 // It acts as a thin glue layer between the frontend bindings and the service layer.
 type App struct {
 	ctx       context.Context
 	converter *service.Converter
+	files     *service.FileService
+	captures  *service.CaptureService
+	polls     *service.PollService
+	streams   *service.StreamService
+	ops       *service.OperationService
+	clipboard *service.ClipboardWatcher
+	settings  *service.SettingsService
+	profiles  *service.ProfileService
+	history   *service.HistoryService
+	sessions  *service.SessionService
+	favorites *service.FavoritesService
+	alerts    *service.AlertService
+	decoders  *service.DecoderService
+	scripts   *service.ScriptService
+	svd       *service.SVDService
+	linkerMap *service.LinkerMapService
+	apiServer *api.Server
 }
 
 // NewApp creates a new App application struct with initialized services.
 func NewApp() *App {
-	return &App{
-		converter: service.NewConverter(),
+	app := &App{
+		converter: service.NewCachedConverter(service.DefaultCacheSize),
+		files:     service.NewFileService(),
+		captures:  service.NewCaptureService(),
+		streams:   service.NewStreamService(),
+		ops:       service.NewOperationService(),
+		settings:  service.NewSettingsService(configPath("settings.json")),
+		profiles:  service.NewProfileService(configPath("profiles.json")),
+		history:   service.NewHistoryService(configPath("history.json")),
+		sessions:  service.NewSessionService(configPath("sessions.json")),
+		favorites: service.NewFavoritesService(configPath("favorites.json")),
+		alerts:    service.NewAlertService(configPath("alerts.json")),
+		decoders:  service.NewDecoderService(configPath("plugins")),
+		scripts:   service.NewScriptService(configPath("scripts")),
+		svd:       service.NewSVDService(),
+		linkerMap: service.NewLinkerMapService(),
+	}
+	app.polls = service.NewPollService(app.captures)
+	app.clipboard = service.NewClipboardWatcher(app.converter, func() (string, error) {
+		return runtime.ClipboardGetText(app.ctx)
+	})
+	app.apiServer = api.NewServer(app.converter)
+	return app
+}
+
+// configPath returns the path of a file in the user config dir, or "" if
+// there is none; stores then keep their data in memory only.
+func configPath(name string) string {
+	path, err := service.ConfigPath(name)
+	if err != nil {
+		return ""
 	}
+	return path
 }
 
 // startup is called when the app starts. The context is saved
 // so we can call the runtime methods.
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
+
+	if err := a.settings.Load(); err != nil {
+		runtime.LogErrorf(ctx, "cannot load settings, using defaults: %v", err)
+	}
+	if err := a.profiles.Load(); err != nil {
+		runtime.LogErrorf(ctx, "cannot load profiles: %v", err)
+	}
+	if err := a.history.Load(); err != nil {
+		runtime.LogErrorf(ctx, "cannot load history: %v", err)
+	}
+	if err := a.favorites.Load(); err != nil {
+		runtime.LogErrorf(ctx, "cannot load favorites: %v", err)
+	}
+	if err := a.alerts.Load(); err != nil {
+		runtime.LogErrorf(ctx, "cannot load alert rules: %v", err)
+	}
+	if err := a.restoreSessions(); err != nil {
+		runtime.LogErrorf(ctx, "cannot restore sessions: %v", err)
+	}
+	for _, err := range a.decoders.LoadPlugins() {
+		runtime.LogErrorf(ctx, "cannot load decoder plugin: %v", err)
+	}
+	for _, err := range a.scripts.Load() {
+		runtime.LogErrorf(ctx, "cannot load script: %v", err)
+	}
+
+	go a.files.Watch(ctx, fileWatchInterval, func(ev models.FileChangeEvent) {
+		runtime.EventsEmit(a.ctx, EventFileChanged, ev)
+	})
+
+	a.captures.SetHandlers(
+		func(c models.CaptureChunk) {
+			runtime.EventsEmit(a.ctx, EventCaptureData, c)
+			for _, alert := range a.alerts.EvaluateCapture(c) {
+				runtime.EventsEmit(a.ctx, EventAlert, alert)
+			}
+		},
+		func(info models.CaptureInfo) { runtime.EventsEmit(a.ctx, EventCaptureStopped, info) },
+	)
+	a.polls.SetHandlers(
+		func(samples []models.PollSample) {
+			runtime.EventsEmit(a.ctx, EventPollSamples, samples)
+			for _, alert := range a.alerts.Evaluate(samples) {
+				runtime.EventsEmit(a.ctx, EventAlert, alert)
+			}
+		},
+		func(info models.PollInfo) { runtime.EventsEmit(a.ctx, EventPollStopped, info) },
+	)
+
+	a.streams.SetHandler(func(ev models.StreamEvent) {
+		runtime.EventsEmit(a.ctx, EventStream, ev)
+	})
+	a.ops.SetHandler(func(op models.Operation) {
+		runtime.EventsEmit(a.ctx, EventOperation, op)
+	})
+	runtime.OnFileDrop(ctx, a.handleFileDrop)
+}
+
+// shutdown is called when the app is closing. Background watchers, streams and
+// operations and polls are stopped and open capture connections and the API
+// server are released.
+func (a *App) shutdown(ctx context.Context) {
+	a.clipboard.Stop()
+	a.streams.CancelAll()
+	a.ops.CancelAll()
+	a.polls.StopAll()
+	a.captures.StopAll()
+	_ = a.apiServer.Stop(ctx)
 }
 
 // ConvertHex performs all possible conversions on hex input.
+// Input longer than the maximum input size is rejected, or with the
+// truncate setting converted as a preview of its first bytes.
 // This method is exported to the frontend via Wails bindings.
 func (a *App) ConvertHex(hexInput string) (*models.ConversionResult, error) {
-	return a.converter.ConvertHex(hexInput)
+	result, _, err := a.converter.ConvertModeLimited(context.Background(), service.ModeHex, hexInput, "", a.settings.Get())
+	a.finishConversion(service.ModeHex, hexInput, "", result, err)
+	return result, err
 }
 
 // ConvertInt performs conversions from integer input to hex and binary.
-// intType specifies the integer type: int8, int16, int32, int64, uint8, uint16, uint32, uint64.
+// intType is one of the IntType constants, available in the frontend as the
+// generated IntType enum. Unknown types return an unsupported_type error.
 // This method is exported to the frontend via Wails bindings.
-func (a *App) ConvertInt(intInput string, intType string) (*models.ConversionResult, error) {
-	return a.converter.ConvertInt(intInput, intType)
+func (a *App) ConvertInt(intInput string, intType models.IntType) (*models.ConversionResult, error) {
+	if err := a.checkInputSize(intInput); err != nil {
+		return nil, err
+	}
+	result, err := a.converter.ConvertInt(intInput, intType)
+	a.finishConversion(service.ModeInt, intInput, string(intType), result, err)
+	return result, err
 }
 
 // ConvertIntAuto performs auto-detection of integer types from decimal input.
@@ -45,26 +222,1599 @@ func (a *App) ConvertInt(intInput string, intType string) (*models.ConversionRes
 // all valid representations (e.g., int8, uint8, int16, etc.) in a single result.
 // This method is exported to the frontend via Wails bindings.
 func (a *App) ConvertIntAuto(intInput string) (*models.ConversionResult, error) {
-	return a.converter.ConvertIntAuto(intInput)
+	if err := a.checkInputSize(intInput); err != nil {
+		return nil, err
+	}
+	result, err := a.converter.ConvertIntAuto(intInput)
+	a.finishConversion(service.ModeIntAuto, intInput, "", result, err)
+	return result, err
 }
 
 // ConvertBinary performs all possible conversions on binary input.
+// Large input is limited like in ConvertHex.
 // This method is exported to the frontend via Wails bindings.
 func (a *App) ConvertBinary(binaryInput string) (*models.ConversionResult, error) {
-	return a.converter.ConvertBinary(binaryInput)
+	result, _, err := a.converter.ConvertModeLimited(context.Background(), service.ModeBinary, binaryInput, "", a.settings.Get())
+	a.finishConversion(service.ModeBinary, binaryInput, "", result, err)
+	return result, err
+}
+
+// ConvertEncoded decodes quoted-printable, percent-encoded, base64 or
+// base32 input, e.g. from email or HTTP payload dumps, and converts the
+// bytes like ConvertHex. encoding is "quoted-printable", "url", "base64",
+// "base32" or empty to detect one of the first two.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ConvertEncoded(input, encoding string) (*models.ConversionResult, error) {
+	result, _, err := a.converter.ConvertModeLimited(context.Background(), service.ModeEncoded, input, encoding, a.settings.Get())
+	a.finishConversion(service.ModeEncoded, input, encoding, result, err)
+	return result, err
 }
 
 // ConvertFloat performs conversions from float input to hex and binary.
-// floatType specifies the float type: float32 or float64.
+// floatType is one of the FloatType constants, available in the frontend as
+// the generated FloatType enum. Unknown types return an unsupported_type error.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ConvertFloat(floatInput string, floatType models.FloatType) (*models.ConversionResult, error) {
+	if err := a.checkInputSize(floatInput); err != nil {
+		return nil, err
+	}
+	result, err := a.converter.ConvertFloat(floatInput, floatType)
+	a.finishConversion(service.ModeFloat, floatInput, string(floatType), result, err)
+	return result, err
+}
+
+// ConvertString encodes text as hex and binary UTF-8 and as UTF-16BE and
+// UTF-16LE, and lists its code points, the reverse of hex to ASCII.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ConvertString(text string) (*models.StringConversion, error) {
+	if err := a.checkInputSize(text); err != nil {
+		return nil, err
+	}
+	return a.converter.ConvertString(text)
+}
+
+// SimulateCast shows what value of type from (an integer or float type)
+// becomes when converted to the integer type to: truncated like a C cast,
+// saturated, and for floats rounded, e.g. 40000 as int32 → int16 is -25536.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) SimulateCast(value, from, to string) (*models.CastResult, error) {
+	return a.converter.SimulateCast(value, from, to)
+}
+
+// ComputeALU computes a op b (add, sub or mul) in a register of bits bits
+// and reports the wrapped result with the carry, overflow, zero and
+// negative flags a CPU would set.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ComputeALU(x, y, op string, bits int) (*models.ALUResult, error) {
+	return a.converter.ComputeALU(x, y, op, bits)
+}
+
+// CalculateOffset computes an element address (base + index × stride),
+// aligns base up or down, or gives the distance from base to a target, in
+// hex and decimal. If req.Base is empty the cursor of the file fileID is
+// the base.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) CalculateOffset(fileID string, req models.OffsetRequest) (*models.OffsetResult, error) {
+	if req.Base == "" && fileID != "" {
+		cursor, err := a.files.Cursor(fileID)
+		if err != nil {
+			return nil, err
+		}
+		req.Base = strconv.FormatInt(cursor, 10)
+	}
+	return a.converter.CalculateOffset(req)
+}
+
+// AnalyzeAddress reports the alignment of an address and its offset in
+// words, cache lines and pages, and from base if it is not empty.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) AnalyzeAddress(address, base string) (*models.AddressAlignment, error) {
+	return a.converter.AnalyzeAddress(address, base)
+}
+
+// DecodeStruct applies a user-defined layout, written in JSON or one
+// "name type" field per line, to hex input and returns the named, typed
+// fields.
 // This method is exported to the frontend via Wails bindings.
-func (a *App) ConvertFloat(floatInput string, floatType string) (*models.ConversionResult, error) {
-	return a.converter.ConvertFloat(floatInput, floatType)
+func (a *App) DecodeStruct(hexInput, layout string) (*models.StructResult, error) {
+	if err := a.checkInputSize(hexInput); err != nil {
+		return nil, err
+	}
+	return a.converter.DecodeStruct(hexInput, layout)
 }
 
 // ConvertModbusRegisters converts an array of 16-bit register values.
 // Input can be space/comma separated hex values (e.g., "1234 5678" or "0x1234, 0x5678")
 // or decimal values with 'd' prefix (e.g., "d1000 d2000").
+// The registers are numbered as configured in the Modbus settings, and the
+// register map of the active profile is decoded into the Mapped field.
+// Large inputs can be aborted with CancelOperation.
 // This method is exported to the frontend via Wails bindings.
 func (a *App) ConvertModbusRegisters(input string) (*models.ModbusResult, error) {
-	return a.converter.ConvertModbusRegisters(input)
+	return a.ConvertModbusRegistersAt(input, service.AddressingFromSettings(a.settings.Get()))
+}
+
+// ConvertModbusRegistersAt converts registers like ConvertModbusRegisters
+// but numbers them with the given addressing, e.g. from start address 100
+// in 4xxxx notation to match the documentation of a device.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ConvertModbusRegistersAt(input string, addressing models.ModbusAddressing) (*models.ModbusResult, error) {
+	if err := a.checkInputSize(input); err != nil {
+		return nil, err
+	}
+	result, err := service.RunOperation(a.ops, service.OpModbus, func(ctx context.Context) (*models.ModbusResult, error) {
+		return a.converter.ConvertModbusRegistersContext(ctx, input)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := a.converter.ApplyAddressing(result, addressing); err != nil {
+		return nil, err
+	}
+	a.converter.ApplyProfile(result, a.profiles.Active())
+	a.scripts.ApplyModbus(result)
+	if err := a.history.RecordModbus(input, result); err != nil {
+		runtime.LogErrorf(a.ctx, "cannot record history: %v", err)
+	}
+	return result, nil
+}
+
+// BulkConvert converts many inputs of one mode (hex, int, intAuto, binary,
+// float or modbus) in parallel, e.g. frames exported from a test bench.
+// The items of the result are in the order of the inputs; inputs that fail
+// report their error in their item. Bulk conversions are not recorded in
+// the history and can be aborted with CancelOperation.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) BulkConvert(inputs []string, mode string, options models.BulkOptions) (*models.BulkResult, error) {
+	return service.RunOperation(a.ops, service.OpBulk, func(ctx context.Context) (*models.BulkResult, error) {
+		return a.converter.BulkConvert(ctx, inputs, mode, options, a.settings.Get())
+	})
+}
+
+// ConvertField is the reverse conversion behind editing a value in the
+// result: it builds the bytes for which field (a result JSON name such as
+// "float32LE", or "bytes" or "binary") has value and converts them, so all
+// other representations update.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ConvertField(field, value string) (*models.ConversionResult, error) {
+	if err := a.checkInputSize(value); err != nil {
+		return nil, err
+	}
+	result, err := a.converter.ConvertField(field, value)
+	if err == nil {
+		a.finishConversion(service.ModeHex, result.Bytes, "", result, nil)
+	}
+	return result, err
+}
+
+// HexDump renders hex or binary input as a hex editor dump (offset column,
+// hex cells and ASCII gutter). Large inputs are returned a page of rows at a
+// time, selected with opts.StartRow and opts.MaxRows.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) HexDump(input string, opts models.HexDumpOptions) (*models.HexDump, error) {
+	if err := a.checkInputSize(input); err != nil {
+		return nil, err
+	}
+	return a.converter.HexDump(input, opts)
+}
+
+// ParseDump reads the bytes back from a text hex dump of xxd, hexdump -C,
+// od, Wireshark or a Windows debugger, with the address of the first byte
+// and the gaps of the dump, or from Wireshark's C array and escaped string
+// exports.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ParseDump(text string) (*models.ParsedDump, error) {
+	if err := a.checkInputSize(text); err != nil {
+		return nil, err
+	}
+	return a.converter.ParseDump(text)
+}
+
+// ConvertArray decodes the whole hex input as consecutive values of one type
+// and byte order, e.g. 256 × int16 LE for an ADC capture.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ConvertArray(hexInput, valueType, order string) (*models.ArrayResult, error) {
+	if err := a.checkInputSize(hexInput); err != nil {
+		return nil, err
+	}
+	return a.converter.ConvertArray(hexInput, valueType, order)
+}
+
+// ConvertDelta decodes hex input as an array like ConvertArray and delta
+// decodes it (mode "decode", values are differences from the previous one)
+// or delta encodes it (mode "encode").
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ConvertDelta(hexInput, valueType, order, mode string) (*models.DeltaResult, error) {
+	if err := a.checkInputSize(hexInput); err != nil {
+		return nil, err
+	}
+	return a.converter.ConvertDelta(hexInput, valueType, order, mode)
+}
+
+// ConvertPCM decodes hex input as interleaved PCM audio samples of format
+// (s8, u8, s16le, s16be, u16le, ..., s24be) with channels channels and
+// reports min, max, peak, RMS and glitch statistics per channel.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ConvertPCM(hexInput, format string, channels int) (*models.PCMResult, error) {
+	if err := a.checkInputSize(hexInput); err != nil {
+		return nil, err
+	}
+	return a.converter.ConvertPCM(hexInput, format, channels)
+}
+
+// ApplyCipher applies a classical cipher (rot13, rot47, caesar with shift
+// or atbash) to the ASCII interpretation of hex input.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ApplyCipher(hexInput, cipher string, shift int) (*models.CipherResult, error) {
+	if err := a.checkInputSize(hexInput); err != nil {
+		return nil, err
+	}
+	return a.converter.ApplyCipher(hexInput, cipher, shift)
+}
+
+// DecodeCrashDump decodes a Cortex-M exception stack frame (R0-R3, R12, LR,
+// PC, xPSR, optionally with the FPU registers) or a FreeRTOS or ThreadX task
+// control block from a pasted memory dump. Addresses are named with the
+// symbols of the loaded linker map or ELF file.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) DecodeCrashDump(hexInput, layout string) (*models.CrashDump, error) {
+	if err := a.checkInputSize(hexInput); err != nil {
+		return nil, err
+	}
+	dump, err := a.converter.DecodeCrashDump(hexInput, layout)
+	if err != nil {
+		return nil, err
+	}
+	a.linkerMap.ApplyDump(dump)
+	return dump, nil
+}
+
+// ConvertFixedPoint decodes hex input as consecutive Qm.n fixed-point
+// numbers, e.g. Q15 or Q31 DSP registers, in the given byte order.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ConvertFixedPoint(hexInput, format, order string) (*models.FixedPointResult, error) {
+	if err := a.checkInputSize(hexInput); err != nil {
+		return nil, err
+	}
+	return a.converter.ConvertFixedPoint(hexInput, format, order)
+}
+
+// EncodeFixedPoint returns the bytes of a decimal value in a Qm.n
+// fixed-point format and byte order, the inverse of ConvertFixedPoint.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) EncodeFixedPoint(value, format, order string) (*models.FixedPointResult, error) {
+	return a.converter.EncodeFixedPoint(value, format, order)
+}
+
+// TriageBuffer flags the words of a little-endian memory dump that look
+// like stack canaries, ASLR'd pointers or arm64 pointers with a PAC or tag,
+// for arch x86-32, x86-64, arm or arm64.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) TriageBuffer(hexInput, arch string) (*models.TriageResult, error) {
+	if err := a.checkInputSize(hexInput); err != nil {
+		return nil, err
+	}
+	return a.converter.TriageBuffer(hexInput, arch)
+}
+
+// ConvertPMBus decodes PMBus LINEAR11 or LINEAR16 telemetry words (byte
+// order LE as sent on the bus, or BE) or an SMBus block read from hex input.
+// LINEAR16 takes its exponent from the VOUT_MODE value voutMode.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ConvertPMBus(hexInput, format, order string, voutMode int) (*models.PMBusResult, error) {
+	if err := a.checkInputSize(hexInput); err != nil {
+		return nil, err
+	}
+	return a.converter.ConvertPMBus(hexInput, format, order, voutMode)
+}
+
+// PlotArray decodes hex input as an array like ConvertArray and returns it as
+// a downsampled series for charting.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) PlotArray(hexInput string, opts models.PlotOptions) (*models.PlotSeries, error) {
+	if err := a.checkInputSize(hexInput); err != nil {
+		return nil, err
+	}
+	return a.converter.PlotArray(hexInput, opts)
+}
+
+// PlotFileRange decodes a byte range of an opened file as an array and
+// returns it as a downsampled series for charting, e.g. an ADC capture
+// embedded in a memory dump. The offsets of the points are file offsets.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) PlotFileRange(fileID string, offset int64, length int, opts models.PlotOptions) (*models.PlotSeries, error) {
+	data, err := a.files.Bytes(fileID, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	return a.converter.PlotBytes(data, offset, opts)
+}
+
+// PreviewPixels decodes hex input as pixel data (rgb565, rgb888, gray8, ...)
+// of a given width and returns a small preview bitmap as a PNG data URL.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) PreviewPixels(hexInput string, opts models.PixelOptions) (*models.PixelPreview, error) {
+	if err := a.checkInputSize(hexInput); err != nil {
+		return nil, err
+	}
+	return a.converter.PreviewPixels(hexInput, opts)
+}
+
+// PreviewFilePixels decodes a byte range of an opened file as pixel data,
+// e.g. a framebuffer in a RAM dump, and returns a small preview bitmap.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) PreviewFilePixels(fileID string, offset int64, length int, opts models.PixelOptions) (*models.PixelPreview, error) {
+	data, err := a.files.Bytes(fileID, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	return a.converter.PreviewPixelBytes(data, opts)
+}
+
+// ConvertHexSections converts hex input like ConvertHex but computes only the
+// requested on-demand sections (midEndian, float, legacySigned, bitFlags).
+// The result lists the computed sections; call again with more sections
+// when they are shown.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ConvertHexSections(hexInput string, sections []string) (*models.ConversionResult, error) {
+	if err := a.checkInputSize(hexInput); err != nil {
+		return nil, err
+	}
+	result, err := a.converter.ConvertHexSections(hexInput, sections)
+	a.finishConversion(service.ModeHex, hexInput, "", result, err)
+	return result, err
+}
+
+// ConvertModbusCSV converts registers from CSV address/value pairs exported
+// by modpoll or PLC tools. The registers keep their addresses.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ConvertModbusCSV(data string) (*models.ModbusResult, error) {
+	if err := a.checkInputSize(data); err != nil {
+		return nil, err
+	}
+	result, err := a.converter.ConvertModbusCSV(data)
+	if err != nil {
+		return nil, err
+	}
+	a.scripts.ApplyModbus(result)
+	return result, nil
+}
+
+// EncodeModbusRegisters returns the register values (hex and decimal) to
+// write to a device for value as typ (e.g. "float32") in the given byte and
+// word order (BE, LE, BADC or CDAB), the inverse of ConvertModbusRegisters.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) EncodeModbusRegisters(value, typ, order string) (*models.ModbusEncoding, error) {
+	return a.converter.EncodeModbusRegisters(value, typ, order)
+}
+
+// ValidateModbusFrame splits a Modbus RTU frame in hex into address,
+// function code, data and CRC and checks the CRC.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ValidateModbusFrame(hexInput string) (*models.ModbusRTUFrame, error) {
+	if err := a.checkInputSize(hexInput); err != nil {
+		return nil, err
+	}
+	return a.converter.ValidateModbusFrame(hexInput)
+}
+
+// DecodeModbusPDU decodes a Modbus request or response in hex: slave ID,
+// function, register address and count, values and exception codes.
+// framing is rtu, tcp, pdu or empty to detect it.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) DecodeModbusPDU(hexInput, framing string) (*models.ModbusFrameResult, error) {
+	if err := a.checkInputSize(hexInput); err != nil {
+		return nil, err
+	}
+	return a.converter.DecodeModbusPDU(hexInput, framing)
+}
+
+// ConvertModbusSections converts registers like ConvertModbusRegisters but
+// computes the 32- and 64-bit combinations (sections modbus32, modbus64)
+// only when requested.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ConvertModbusSections(input string, sections []string) (*models.ModbusResult, error) {
+	if err := a.checkInputSize(input); err != nil {
+		return nil, err
+	}
+	result, err := a.converter.ConvertModbusSections(input, sections)
+	if err == nil {
+		a.converter.ApplyProfile(result, a.profiles.Active())
+		a.scripts.ApplyModbus(result)
+		if err := a.history.RecordModbus(input, result); err != nil {
+			runtime.LogErrorf(a.ctx, "cannot record history: %v", err)
+		}
+	}
+	return result, err
+}
+
+// ConvertV2 converts input in one of the conversion modes except modbus
+// (hex, int, intAuto, binary or float) and returns the result grouped by
+// type and byte order, e.g. result.int32["LE"].value. valueType is the
+// integer or float type of the int and float modes. The other Convert
+// methods keep returning the flat schema.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ConvertV2(mode, input, valueType string) (*models.ConversionResultV2, error) {
+	if mode == service.ModeModbus {
+		return nil, service.ErrModbusV2
+	}
+	result, _, err := a.converter.ConvertModeLimited(context.Background(), mode, input, valueType, a.settings.Get())
+	a.finishConversion(mode, input, valueType, result, err)
+	if err != nil {
+		return nil, err
+	}
+	return service.GroupResult(result), nil
+}
+
+// checkInputSize rejects input longer than the maximum input size setting.
+func (a *App) checkInputSize(input string) error {
+	return service.CheckInputSize(input, a.settings.Get().MaxInputSize)
+}
+
+// finishConversion adds enum names, memory regions, linker map symbols, NaN
+// payloads and the sections of user scripts to a successful conversion and
+// records it in the history.
+func (a *App) finishConversion(mode, input, typ string, result *models.ConversionResult, err error) {
+	if err != nil {
+		return
+	}
+	profile := a.profiles.Active()
+	a.converter.ApplyEnums(result, profile)
+	a.converter.ApplyMemoryMap(result, profile)
+	a.linkerMap.Apply(result)
+	if a.settings.Get().NaNPayloads {
+		a.converter.ApplyNaNs(result)
+	}
+	a.scripts.Apply(result)
+	if err := a.history.RecordConversion(mode, input, typ, result); err != nil {
+		runtime.LogErrorf(a.ctx, "cannot record history: %v", err)
+	}
+}
+
+// ValidateInput checks input for a conversion mode (hex, int, intAuto, binary,
+// float or modbus) without converting it and returns the detected format and
+// a normalized preview. It is cheap enough to call on every keystroke.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ValidateInput(input string, mode string) (*models.InputValidation, error) {
+	return a.converter.ValidateInput(input, mode)
+}
+
+// StreamModbusRegisters converts register values like ConvertModbusRegisters but
+// delivers the result in batches via EventStream. It returns the stream ID.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) StreamModbusRegisters(input string) string {
+	return a.streams.Start(service.StreamModbus, func(ctx context.Context, emit service.Emit) error {
+		return a.converter.StreamModbusRegisters(ctx, input, emit)
+	})
+}
+
+// Checksum computes common CRCs (Modbus, CCITT, CRC-32, ...) and simple checksums of hex input.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) Checksum(hexInput string) (*models.ChecksumResult, error) {
+	return a.converter.Checksum(hexInput)
+}
+
+// Hash computes the MD5, SHA-1, SHA-256, SHA-512 and CRC-32 digests of hex
+// input, e.g. to verify a firmware blob.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) Hash(hexInput string) (*models.HashResult, error) {
+	if err := a.checkInputSize(hexInput); err != nil {
+		return nil, err
+	}
+	return a.converter.Hash(hexInput)
+}
+
+// Diff compares two hex inputs byte by byte and lists the differing ranges.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) Diff(hexA, hexB string) (*models.DiffResult, error) {
+	return a.converter.Diff(hexA, hexB)
+}
+
+// CompareSwaps reports which byte-order transforms (swap16, swap32, swap64,
+// CDAB word swap, bit reversal, ...) turn the observed hex value into the
+// expected one.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) CompareSwaps(observedHex, expectedHex string) (*models.SwapComparison, error) {
+	return a.converter.CompareSwaps(observedHex, expectedHex)
+}
+
+// Disassemble decodes hex input as machine code and returns a short instruction preview.
+// arch specifies the architecture: x86-16, x86-32, x86-64, arm, arm64, thumb, riscv64.
+// baseAddress is optional (hex with 0x prefix or decimal) and offsets the shown addresses.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) Disassemble(hexInput string, arch string, baseAddress string) (*models.DisassemblyResult, error) {
+	return a.converter.Disassemble(hexInput, arch, baseAddress)
+}
+
+// AssembleInstruction encodes a single ARM Thumb or RISC-V instruction.
+// arch specifies the architecture: thumb or riscv64.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) AssembleInstruction(arch string, text string) (*models.InstructionEncoding, error) {
+	return a.converter.AssembleInstruction(arch, text)
+}
+
+// DecodeInstruction decodes the ARM Thumb or RISC-V instruction at the start of hex input.
+// arch specifies the architecture: thumb or riscv64.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) DecodeInstruction(arch string, hexInput string) (*models.InstructionEncoding, error) {
+	if err := a.checkInputSize(hexInput); err != nil {
+		return nil, err
+	}
+	return a.converter.DecodeInstruction(arch, hexInput)
+}
+
+// OpenFile opens a binary file from disk for viewing and returns its descriptor.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) OpenFile(path string) (*models.FileInfo, error) {
+	return a.openFile(path)
+}
+
+// openFile opens a file and, if it is an ELF file with a symbol table,
+// makes its symbols the ones addresses in conversion results resolve to.
+func (a *App) openFile(path string) (*models.FileInfo, error) {
+	file, err := a.files.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := a.linkerMap.LoadELF(path); err != nil && !errors.Is(err, service.ErrNoELFSymbols) {
+		runtime.LogErrorf(a.ctx, "cannot read symbols of %s: %v", path, err)
+	}
+	return file, nil
+}
+
+// OpenFileDialog lets the user pick a binary file and opens it like
+// OpenFile. It returns nil if the dialog was cancelled.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) OpenFileDialog() (*models.FileInfo, error) {
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Open Binary File",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "All Files (*.*)", Pattern: "*.*"},
+			{DisplayName: "Firmware (*.bin, *.hex, *.elf)", Pattern: "*.bin;*.hex;*.elf"},
+		},
+	})
+	if err != nil || path == "" {
+		return nil, err
+	}
+	return a.openFile(path)
+}
+
+// IngestFile loads a file as if it was dropped onto the window: files of up
+// to the drop threshold of the settings are converted like hex input, larger
+// ones are opened for the hex dump view.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) IngestFile(path string) (*models.DroppedFile, error) {
+	result, err := service.IngestFile(a.files, a.converter, path, a.settings.Get().DropThreshold)
+	if err == nil && result.Conversion != nil {
+		a.finishConversion(service.ModeHex, result.Conversion.Bytes, "", result.Conversion, nil)
+	}
+	return result, err
+}
+
+// handleFileDrop ingests the files dropped onto the window and emits an
+// EventFileDropped for each of them.
+func (a *App) handleFileDrop(_, _ int, paths []string) {
+	for _, path := range paths {
+		result, err := a.IngestFile(path)
+		if err != nil {
+			result = &models.DroppedFile{Path: path, Name: filepath.Base(path), Error: err.Error()}
+		}
+		runtime.EventsEmit(a.ctx, EventFileDropped, result)
+	}
+}
+
+// CloseFile releases a previously opened file.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) CloseFile(fileID string) error {
+	return a.files.Close(fileID)
+}
+
+// ListFiles returns all currently opened files.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ListFiles() []models.FileInfo {
+	return a.files.List()
+}
+
+// SetFileCursor moves the cursor of an opened file, the base of CalculateOffset.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) SetFileCursor(fileID string, offset int64) (*models.FileInfo, error) {
+	return a.files.SetCursor(fileID, offset)
+}
+
+// ReadFileRange returns a slice of an opened file, e.g. to navigate to a section offset.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ReadFileRange(fileID string, offset int64, length int) (*models.FileRange, error) {
+	return a.files.ReadRange(fileID, offset, length)
+}
+
+// GetFileHexDump returns a page of rows of an opened file as a hex dump
+// (offset column, hex cells and ASCII gutter) for scrolling through it.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) GetFileHexDump(fileID string, opts models.HexDumpOptions) (*models.HexDump, error) {
+	return a.files.HexDump(fileID, opts)
+}
+
+// ConvertFileRange runs a selected byte range of an opened file through the
+// hex converter. Ranges longer than MaxInputSize bytes are rejected or, with
+// TruncateInput, converted as a preview like hex input.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ConvertFileRange(fileID string, offset int64, length int) (*models.ConversionResult, error) {
+	settings := a.settings.Get()
+	if !settings.TruncateInput {
+		if err := service.CheckSize(length, settings.MaxInputSize); err != nil {
+			return nil, err
+		}
+	}
+	data, err := a.files.Bytes(fileID, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	result, err := a.converter.ConvertBytesLimited(data, settings)
+	if err == nil {
+		a.finishConversion(service.ModeHex, result.Bytes, "", result, nil)
+	}
+	return result, err
+}
+
+// GetExecutableInfo parses the ELF/PE/Mach-O header of an opened file.
+// Section and segment offsets can be used with ReadFileRange as navigation targets.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) GetExecutableInfo(fileID string) (*models.ExecutableInfo, error) {
+	return a.files.ExecutableInfo(fileID)
+}
+
+// OverwriteFileBytes replaces bytes of an opened file at offset with hex data.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) OverwriteFileBytes(fileID string, offset int, hexData string) (*models.FileInfo, error) {
+	return a.files.Overwrite(fileID, offset, hexData)
+}
+
+// InsertFileBytes inserts hex data into an opened file at offset.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) InsertFileBytes(fileID string, offset int, hexData string) (*models.FileInfo, error) {
+	return a.files.Insert(fileID, offset, hexData)
+}
+
+// DeleteFileBytes removes length bytes from an opened file starting at offset.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) DeleteFileBytes(fileID string, offset int, length int) (*models.FileInfo, error) {
+	return a.files.Delete(fileID, offset, length)
+}
+
+// FillFileBytes overwrites a range of an opened file with a repeating hex pattern.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) FillFileBytes(fileID string, offset int, length int, hexPattern string) (*models.FileInfo, error) {
+	return a.files.Fill(fileID, offset, length, hexPattern)
+}
+
+// ReplaceFileBytes replaces all occurrences of a hex pattern in an opened file.
+// All replacements are undone as a single step.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ReplaceFileBytes(fileID string, findHex string, replaceHex string) (*models.ReplaceResult, error) {
+	return a.files.Replace(fileID, findHex, replaceHex)
+}
+
+// SearchFile scans an opened file for a hex pattern. Matches are delivered in
+// batches via EventStream; the returned stream ID can be passed to CancelStream.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) SearchFile(fileID string, patternHex string) string {
+	return a.streams.Start(service.StreamFileSearch, func(ctx context.Context, emit service.Emit) error {
+		return a.files.Search(ctx, fileID, patternHex, emit)
+	})
+}
+
+// CancelStream stops a running streamed operation.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) CancelStream(streamID string) error {
+	return a.streams.Cancel(streamID)
+}
+
+// ListOperations returns the running cancellable operations. Their start and
+// end are also announced via EventOperation.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ListOperations() []models.Operation {
+	return a.ops.List()
+}
+
+// CancelOperation aborts a running operation such as a large Modbus
+// conversion or a frame replay; the aborted call returns an error.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) CancelOperation(operationID string) error {
+	return a.ops.Cancel(operationID)
+}
+
+// UndoFileEdit reverts the most recent edit of an opened file.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) UndoFileEdit(fileID string) (*models.FileInfo, error) {
+	return a.files.Undo(fileID)
+}
+
+// RedoFileEdit re-applies the most recently undone edit of an opened file.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) RedoFileEdit(fileID string) (*models.FileInfo, error) {
+	return a.files.Redo(fileID)
+}
+
+// SaveFile writes the edited content of an opened file back to disk.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) SaveFile(fileID string) (*models.FileInfo, error) {
+	return a.files.Save(fileID)
+}
+
+// ReloadFile re-reads an opened file from disk after an external modification,
+// discarding unsaved edits and the undo history.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ReloadFile(fileID string) (*models.FileInfo, error) {
+	return a.files.Reload(fileID)
+}
+
+// DecodeContainer lists the chunks of PNG/ZIP/TAR/RIFF (WAV) data given as hex input.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) DecodeContainer(hexInput string) (*models.ContainerLayout, error) {
+	return a.converter.DecodeContainer(hexInput)
+}
+
+// GetContainerLayout lists the chunks of an opened PNG/ZIP/TAR/RIFF (WAV) file.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) GetContainerLayout(fileID string) (*models.ContainerLayout, error) {
+	return a.files.ContainerLayout(fileID)
+}
+
+// DecodeEXIF decodes TIFF/EXIF IFD tags from hex input (TIFF header or JPEG APP1 segment).
+// This method is exported to the frontend via Wails bindings.
+func (a *App) DecodeEXIF(hexInput string) (*models.ExifResult, error) {
+	return a.converter.DecodeEXIF(hexInput)
+}
+
+// GetFileEXIF decodes TIFF/EXIF IFD tags from a region of an opened file.
+// A length of 0 decodes up to the end of the file.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) GetFileEXIF(fileID string, offset int64, length int) (*models.ExifResult, error) {
+	return a.files.EXIF(fileID, offset, length)
+}
+
+// DecodeSQLiteHeader decodes the 100-byte SQLite database header from hex input.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) DecodeSQLiteHeader(hexInput string) (*models.SQLiteHeader, error) {
+	return a.converter.DecodeSQLiteHeader(hexInput)
+}
+
+// DecodeSQLitePage decodes an SQLite b-tree page header from hex input starting at a page boundary.
+// headerOffset is 100 for page 1 and 0 for all other pages.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) DecodeSQLitePage(hexInput string, headerOffset int) (*models.SQLitePage, error) {
+	return a.converter.DecodeSQLitePage(hexInput, headerOffset)
+}
+
+// GetSQLiteHeader decodes the database header of an opened SQLite file.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) GetSQLiteHeader(fileID string) (*models.SQLiteHeader, error) {
+	return a.files.SQLiteHeader(fileID)
+}
+
+// GetSQLitePage decodes the b-tree page header of a 1-based page of an opened SQLite file.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) GetSQLitePage(fileID string, pageNumber uint32) (*models.SQLitePage, error) {
+	return a.files.SQLitePage(fileID, pageNumber)
+}
+
+// LoadSVD loads a CMSIS-SVD file whose registers DecodeSVDRegister decodes.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) LoadSVD(path string) (*models.SVDDevice, error) {
+	return a.svd.Load(path)
+}
+
+// GetSVDDevice returns the peripherals and registers of the loaded SVD file.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) GetSVDDevice() (*models.SVDDevice, error) {
+	return a.svd.Device()
+}
+
+// DecodeSVDRegister splits a register value into the named bitfields of
+// the register in the loaded SVD file.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) DecodeSVDRegister(peripheral, register, value string) (*models.SVDRegisterValue, error) {
+	return a.svd.DecodeRegister(peripheral, register, value)
+}
+
+// LoadLinkerMap loads a GNU ld or armlink map file, so conversion results
+// name the symbols address-like values point into. The returned regions
+// can be added to the memory map of a profile.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) LoadLinkerMap(path string) (*models.LinkerMapInfo, error) {
+	return a.linkerMap.Load(path)
+}
+
+// LoadELFSymbols loads the symbol table of an ELF file, e.g. of the firmware
+// a RAM dump was taken from, so conversion results name the functions and
+// objects address-like values point into. Opened ELF files are indexed
+// automatically.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) LoadELFSymbols(path string) (*models.LinkerMapInfo, error) {
+	return a.linkerMap.LoadELF(path)
+}
+
+// GetLinkerMap returns the format, symbol count and regions of the loaded
+// linker map file or ELF symbol table.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) GetLinkerMap() (*models.LinkerMapInfo, error) {
+	return a.linkerMap.Info()
+}
+
+// LookupSymbol returns the symbol of the loaded linker map file or ELF
+// symbol table an address points into, e.g. "g_buffer + 0x10" for
+// 0x20000014.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) LookupSymbol(address string) (*models.SymbolMatch, error) {
+	return a.linkerMap.Lookup(address)
+}
+
+// ListSerialPorts returns the serial ports available for capturing.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ListSerialPorts() ([]string, error) {
+	return a.captures.SerialPorts()
+}
+
+// StartSerialCapture opens a serial port and streams received bytes as capture:data events.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) StartSerialCapture(cfg models.SerialConfig) (*models.CaptureInfo, error) {
+	return a.captures.StartSerial(cfg)
+}
+
+// StartTCPClientCapture connects to a TCP server and streams received bytes as capture:data events.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) StartTCPClientCapture(address string) (*models.CaptureInfo, error) {
+	return a.captures.StartTCPClient(address)
+}
+
+// StartTCPServerCapture listens on address (e.g. ":5020") and captures bytes from connecting clients.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) StartTCPServerCapture(address string) (*models.CaptureInfo, error) {
+	return a.captures.StartTCPServer(address)
+}
+
+// StartUDPCapture binds a UDP socket and captures received datagrams.
+// remoteAddress is optional; without it, sends go to the last sender.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) StartUDPCapture(localAddress, remoteAddress string) (*models.CaptureInfo, error) {
+	return a.captures.StartUDP(localAddress, remoteAddress)
+}
+
+// SendCapture transmits hex-encoded bytes over the connection of a running capture session.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) SendCapture(captureID, hexInput string) (int, error) {
+	return a.captures.Send(captureID, hexInput)
+}
+
+// TransmitFrame sends hex-encoded bytes (e.g. a frame built in the converter) over a running
+// capture session and returns the response, which is also logged in the capture.
+// Waiting for the response can be aborted with CancelOperation.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) TransmitFrame(captureID, hexInput string, opts models.TransmitOptions) (*models.TransmitResult, error) {
+	return service.RunOperation(a.ops, service.OpTransmit, func(ctx context.Context) (*models.TransmitResult, error) {
+		return a.captures.Transmit(ctx, captureID, hexInput, opts)
+	})
+}
+
+// ReplayFrames sends several hex-encoded frames in order and returns the response to each.
+// The replay can be aborted with CancelOperation.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ReplayFrames(captureID string, frames []string, opts models.TransmitOptions) ([]models.TransmitResult, error) {
+	return service.RunOperation(a.ops, service.OpReplay, func(ctx context.Context) ([]models.TransmitResult, error) {
+		return a.captures.Replay(ctx, captureID, frames, opts)
+	})
+}
+
+// StopCapture closes the connection of a capture session. Recorded data remains available.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) StopCapture(captureID string) (*models.CaptureInfo, error) {
+	return a.captures.Stop(captureID)
+}
+
+// RemoveCapture stops a capture session and discards its data.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) RemoveCapture(captureID string) error {
+	if err := a.captures.Remove(captureID); err != nil {
+		return err
+	}
+	a.alerts.ClearCapture(captureID)
+	return nil
+}
+
+// ClearCapture discards the recorded data of a capture session.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ClearCapture(captureID string) error {
+	return a.captures.Clear(captureID)
+}
+
+// ListCaptures returns descriptors of all capture sessions.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ListCaptures() []models.CaptureInfo {
+	return a.captures.List()
+}
+
+// GetCaptureChunks returns the timestamped chunks of a capture session with sequence number >= since.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) GetCaptureChunks(captureID string, since int) ([]models.CaptureChunk, error) {
+	return a.captures.Chunks(captureID, since)
+}
+
+// ReadCaptureRange returns a byte range of a capture stream, possibly spanning several chunks.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ReadCaptureRange(captureID string, offset int64, length int) (*models.FileRange, error) {
+	return a.captures.ReadRange(captureID, offset, length)
+}
+
+// ConvertCaptureRange runs a byte range of a capture stream through the hex converter.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ConvertCaptureRange(captureID string, offset int64, length int) (*models.ConversionResult, error) {
+	data, err := a.captures.Bytes(captureID, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	return a.converter.ConvertBytes(data)
+}
+
+// StartModbusPoll reads Modbus registers periodically over a running capture
+// session and records the values as time series, e.g. to watch a register
+// trend during commissioning. Every answered read is emitted as poll:samples.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) StartModbusPoll(cfg models.PollConfig) (*models.PollInfo, error) {
+	return a.polls.Start(cfg)
+}
+
+// StopModbusPoll ends a Modbus poll. Recorded samples remain available.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) StopModbusPoll(pollID string) (*models.PollInfo, error) {
+	return a.polls.Stop(pollID)
+}
+
+// RemoveModbusPoll stops a Modbus poll and discards its samples.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) RemoveModbusPoll(pollID string) error {
+	if err := a.polls.Remove(pollID); err != nil {
+		return err
+	}
+	a.alerts.ClearPoll(pollID)
+	return nil
+}
+
+// ListModbusPolls returns descriptors of all Modbus polls.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ListModbusPolls() []models.PollInfo {
+	return a.polls.List()
+}
+
+// QueryPollSamples returns the recorded samples of a Modbus poll, filtered by
+// value name and time range.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) QueryPollSamples(pollID string, query models.SampleQuery) ([]models.PollSample, error) {
+	return a.polls.Samples(pollID, query)
+}
+
+// ExportPollSamples asks for a file name and writes the samples of a Modbus
+// poll matching query as CSV, JSON or Markdown. It returns the path written,
+// or "" if the dialog was cancelled.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ExportPollSamples(pollID string, query models.SampleQuery) (string, error) {
+	path, err := a.exportDialog(pollID)
+	if err != nil || path == "" {
+		return "", err
+	}
+	return path, a.polls.ExportSamples(pollID, query, path)
+}
+
+// ListAlertRules returns all alert rules.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ListAlertRules() []models.AlertRule {
+	return a.alerts.List()
+}
+
+// AddAlertRule stores a rule evaluated on every read of the Modbus polls,
+// e.g. {"label": "Fault", "value": "status", "condition": "bitSet", "bit": 3},
+// or with "source": "capture" on the integer at "offset" of every chunk
+// received by a capture session.
+// Matching values emit alert:changed once when the rule starts matching and
+// once when it stops.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) AddAlertRule(rule models.AlertRule) (*models.AlertRule, error) {
+	return a.alerts.Add(rule)
+}
+
+// UpdateAlertRule replaces an alert rule.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) UpdateAlertRule(rule models.AlertRule) (*models.AlertRule, error) {
+	return a.alerts.Update(rule)
+}
+
+// RemoveAlertRule deletes an alert rule.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) RemoveAlertRule(id int64) error {
+	return a.alerts.Remove(id)
+}
+
+// ListActiveAlerts returns the alerts currently raised.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ListActiveAlerts() []models.Alert {
+	return a.alerts.Active()
+}
+
+// StartAPIServer starts the local REST API on addr (127.0.0.1:8787 if empty)
+// and returns the address it listens on.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) StartAPIServer(addr string) (string, error) {
+	return a.apiServer.Start(addr)
+}
+
+// StopAPIServer stops the local REST API server.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) StopAPIServer() error {
+	return a.apiServer.Stop(a.ctx)
+}
+
+// GetAPIServerAddress returns the address of the running REST API server, or "" if stopped.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) GetAPIServerAddress() string {
+	return a.apiServer.Addr()
+}
+
+// StartClipboardWatch enables automatic conversion of copied hex and decimal
+// values. Results are delivered via EventClipboardConverted.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) StartClipboardWatch() {
+	a.clipboard.Start(clipboardPollInterval, func(c models.ClipboardConversion) {
+		runtime.EventsEmit(a.ctx, EventClipboardConverted, c)
+	})
+}
+
+// StopClipboardWatch disables automatic clipboard conversion.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) StopClipboardWatch() {
+	a.clipboard.Stop()
+}
+
+// IsClipboardWatchActive reports whether the clipboard watcher is enabled.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) IsClipboardWatchActive() bool {
+	return a.clipboard.Running()
+}
+
+// CopyAs formats hex input in the given style and places it on the clipboard.
+// The formatted text is returned for display.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) CopyAs(hexInput string, opts models.FormatOptions) (string, error) {
+	text, err := a.converter.FormatBytes(hexInput, opts)
+	if err != nil {
+		return "", err
+	}
+	return text, runtime.ClipboardSetText(a.ctx, text)
+}
+
+// CopyFileRangeAs formats a range of an opened file and places it on the clipboard.
+// The formatted text is returned for display.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) CopyFileRangeAs(fileID string, offset int64, length int, opts models.FormatOptions) (string, error) {
+	text, err := a.files.FormatRange(fileID, offset, length, opts)
+	if err != nil {
+		return "", err
+	}
+	return text, runtime.ClipboardSetText(a.ctx, text)
+}
+
+// GetCopyFormats lists the styles accepted by CopyAs and CopyFileRangeAs.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) GetCopyFormats() []models.FormatStyle {
+	return a.converter.FormatStyles()
+}
+
+// ExportConversionResult asks for a file name and writes the result as CSV,
+// JSON or Markdown, depending on the chosen extension. It returns the path
+// written, or "" if the dialog was cancelled.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ExportConversionResult(result models.ConversionResult) (string, error) {
+	path, err := a.exportDialog("conversion")
+	if err != nil || path == "" {
+		return "", err
+	}
+	return path, a.converter.ExportConversion(&result, path)
+}
+
+// ExportModbusResult asks for a file name and writes the register tables as
+// CSV, JSON or Markdown, depending on the chosen extension. It returns the
+// path written, or "" if the dialog was cancelled.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ExportModbusResult(result models.ModbusResult) (string, error) {
+	path, err := a.exportDialog("modbus-registers")
+	if err != nil || path == "" {
+		return "", err
+	}
+	return path, a.converter.ExportModbus(&result, path)
+}
+
+// exportDialog shows a save dialog for an export file. A name without
+// extension is saved as CSV.
+func (a *App) exportDialog(name string) (string, error) {
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export Result",
+		DefaultFilename: name + ".csv",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "CSV (*.csv)", Pattern: "*.csv"},
+			{DisplayName: "JSON (*.json)", Pattern: "*.json"},
+			{DisplayName: "Markdown (*.md)", Pattern: "*.md"},
+		},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+	if filepath.Ext(path) == "" {
+		path += ".csv"
+	}
+	return path, nil
+}
+
+// GetDiagnostics returns the metrics of the conversion cache and the memory
+// and goroutine figures of the app, for troubleshooting.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) GetDiagnostics() models.Diagnostics {
+	return service.Diagnose(a.converter, a.ops)
+}
+
+// ClearCache removes all cached conversion results and resets the cache
+// metrics.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ClearCache() {
+	a.converter.ClearCache()
+}
+
+// GetSettings returns the user preferences.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) GetSettings() models.Settings {
+	return a.settings.Get()
+}
+
+// UpdateSettings validates and persists new user preferences.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) UpdateSettings(settings models.Settings) (models.Settings, error) {
+	return a.settings.Update(settings)
+}
+
+// ResetSettings restores the default preferences.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ResetSettings() (models.Settings, error) {
+	return a.settings.Reset()
+}
+
+// ListProfiles returns all conversion profiles and the name of the active one.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ListProfiles() models.ProfileList {
+	return a.profiles.List()
+}
+
+// SaveProfile validates and stores a profile, replacing one with the same name.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) SaveProfile(profile models.Profile) (*models.Profile, error) {
+	return a.profiles.Save(profile)
+}
+
+// DeleteProfile removes a profile.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) DeleteProfile(name string) error {
+	return a.profiles.Delete(name)
+}
+
+// ParseEnumValues reads the values of an enum map from text, e.g. a C enum
+// pasted from a header file or "value,name" lines, for adding it to a profile.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ParseEnumValues(text string) ([]models.EnumValue, error) {
+	return service.ParseEnumValues(text)
+}
+
+// ParseMemoryMap reads the regions of a memory map from text, e.g. a table
+// of "name start end" lines or the MEMORY command of a linker script, for
+// adding it to a profile.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ParseMemoryMap(text string) ([]models.MemoryRegion, error) {
+	return service.ParseMemoryMap(text)
+}
+
+// SelectProfile activates a profile and makes its settings the current
+// settings. An empty name deactivates the profile and keeps the settings.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) SelectProfile(name string) (*models.Profile, error) {
+	profile, err := a.profiles.Activate(name)
+	if err != nil || profile == nil {
+		return profile, err
+	}
+	if _, err := a.settings.Update(profile.Settings); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// SearchHistory returns up to limit recorded conversions, newest first, whose
+// input or values contain query. An empty query lists all entries.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) SearchHistory(query string, limit int) []models.HistoryEntry {
+	return a.history.Search(query, limit)
+}
+
+// DeleteHistoryEntry removes a recorded conversion.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) DeleteHistoryEntry(id int64) error {
+	return a.history.Delete(id)
+}
+
+// ClearHistory removes all recorded conversions.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ClearHistory() error {
+	return a.history.Clear()
+}
+
+// restoreSessions loads the sessions of the previous run and reopens their
+// files. Without any session a first one is created.
+func (a *App) restoreSessions() error {
+	if err := a.sessions.Load(); err != nil {
+		return err
+	}
+	for _, session := range a.sessions.List() {
+		if session.FilePath == "" {
+			continue
+		}
+		file, err := a.openFile(session.FilePath)
+		if err != nil {
+			runtime.LogErrorf(a.ctx, "cannot reopen %s: %v", session.FilePath, err)
+		}
+		if _, err := a.sessions.SetFile(session.ID, file); err != nil {
+			return err
+		}
+	}
+	if len(a.sessions.List()) == 0 {
+		if _, err := a.sessions.Create("", a.settings.Get()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListSessions returns the conversion tabs in tab order.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ListSessions() []models.Session {
+	return a.sessions.List()
+}
+
+// GetSession returns the state of a tab.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) GetSession(sessionID string) (*models.Session, error) {
+	return a.sessions.Get(sessionID)
+}
+
+// CreateSession opens a new tab starting with the current settings. An empty
+// name is replaced by "Tab N".
+// This method is exported to the frontend via Wails bindings.
+func (a *App) CreateSession(name string) (*models.Session, error) {
+	return a.sessions.Create(name, a.settings.Get())
+}
+
+// UpdateSession stores the name, mode, input, type and settings of a tab.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) UpdateSession(session models.Session) (*models.Session, error) {
+	return a.sessions.Update(session)
+}
+
+// UndoSessionInput restores the input of a tab before its last change, e.g.
+// after a register string was accidentally overwritten. Consecutive
+// keystrokes are undone together.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) UndoSessionInput(sessionID string) (*models.Session, error) {
+	return a.sessions.UndoInput(sessionID)
+}
+
+// RedoSessionInput reapplies the input change last reverted by UndoSessionInput.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) RedoSessionInput(sessionID string) (*models.Session, error) {
+	return a.sessions.RedoInput(sessionID)
+}
+
+// MoveSession places a tab at index in the tab order.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) MoveSession(sessionID string, index int) error {
+	return a.sessions.Move(sessionID, index)
+}
+
+// CloseSession closes a tab and the file opened in it.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) CloseSession(sessionID string) error {
+	session, err := a.sessions.Close(sessionID)
+	if err != nil {
+		return err
+	}
+	if session.FileID != "" {
+		_ = a.files.Close(session.FileID)
+	}
+	return nil
+}
+
+// ConvertInSession converts input in a mode (hex, int, intAuto, binary, float
+// or modbus) within a tab. The input is stored in the tab and successful
+// conversions are added to both its history and the global history.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ConvertInSession(sessionID, mode, input, valueType string) (*models.SessionResult, error) {
+	if _, err := a.sessions.SetInput(sessionID, mode, input, valueType); err != nil {
+		return nil, err
+	}
+
+	var result models.SessionResult
+	var err error
+	if mode == service.ModeModbus {
+		result.Modbus, err = a.ConvertModbusRegisters(input)
+	} else {
+		result.Conversion, _, err = a.converter.ConvertModeLimited(context.Background(), mode, input, valueType, a.settings.Get())
+		a.finishConversion(mode, input, valueType, result.Conversion, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var session *models.Session
+	if result.Modbus != nil {
+		session, err = a.sessions.RecordModbus(sessionID, input, result.Modbus)
+	} else {
+		session, err = a.sessions.RecordConversion(sessionID, mode, input, valueType, result.Conversion)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Session = *session
+	return &result, nil
+}
+
+// OpenSessionFile opens a file in a tab, replacing and closing the file
+// opened in it before.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) OpenSessionFile(sessionID string, path string) (*models.FileInfo, error) {
+	prev, err := a.sessions.Get(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	file, err := a.openFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := a.sessions.SetFile(sessionID, file); err != nil {
+		_ = a.files.Close(file.ID)
+		return nil, err
+	}
+	if prev.FileID != "" {
+		_ = a.files.Close(prev.FileID)
+	}
+	return file, nil
+}
+
+// CloseSessionFile closes the file opened in a tab.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) CloseSessionFile(sessionID string) error {
+	session, err := a.sessions.Get(sessionID)
+	if err != nil {
+		return err
+	}
+	if session.FileID == "" {
+		return nil
+	}
+	if _, err := a.sessions.SetFile(sessionID, nil); err != nil {
+		return err
+	}
+	return a.files.Close(session.FileID)
+}
+
+// ListFavorites returns all pinned conversions.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ListFavorites() []models.Favorite {
+	return a.favorites.List()
+}
+
+// AddFavorite pins a conversion under a label. mode is one of hex, int,
+// intAuto, binary, float or modbus; valueType is the int or float type.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) AddFavorite(label, mode, input, valueType string) (*models.Favorite, error) {
+	return a.favorites.Add(label, mode, input, valueType)
+}
+
+// UpdateFavorite changes the label, mode, input or type of a favorite.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) UpdateFavorite(fav models.Favorite) (*models.Favorite, error) {
+	return a.favorites.Update(fav)
+}
+
+// RemoveFavorite unpins a conversion.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) RemoveFavorite(id int64) error {
+	return a.favorites.Remove(id)
+}
+
+// RunFavorite converts the input of a favorite again.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) RunFavorite(id int64) (*models.FavoriteResult, error) {
+	return a.favorites.Run(context.Background(), a.converter, id)
+}
+
+// RunAllFavorites converts the inputs of all favorites. It can be aborted
+// with CancelOperation.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) RunAllFavorites() ([]models.FavoriteResult, error) {
+	return service.RunOperation(a.ops, service.OpFavorites, func(ctx context.Context) ([]models.FavoriteResult, error) {
+		return a.favorites.RunAll(ctx, a.converter)
+	})
+}
+
+// CurrentProject returns a project holding the current state: the files
+// opened in tabs, the loaded SVD file and linker map or ELF symbols, the
+// favorites as bookmarks and all profiles. The frontend adds its struct
+// layouts as schemas before saving it with SaveProject.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) CurrentProject(name, description string) models.Project {
+	project := models.Project{Name: name, Description: description, Bookmarks: a.favorites.List()}
+	for _, session := range a.sessions.List() {
+		if session.FilePath != "" {
+			project.Files = append(project.Files, models.ProjectFile{Path: session.FilePath, Name: session.Name})
+		}
+	}
+	if dev, err := a.svd.Device(); err == nil {
+		project.SVD = dev.Path
+	}
+	if info, err := a.linkerMap.Info(); err == nil {
+		if info.Format == service.FormatELF {
+			project.ELFSymbols = info.Path
+		} else {
+			project.LinkerMap = info.Path
+		}
+	}
+	profiles := a.profiles.List()
+	project.Profiles, project.ActiveProfile = profiles.Profiles, profiles.Active
+	return project
+}
+
+// SaveProject writes a project file, usually with the extension .hexproj.
+// Paths below its directory are stored relative to it.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) SaveProject(path string, project models.Project) error {
+	return service.SaveProject(path, project)
+}
+
+// OpenProject reads a project file and restores its artifacts: profiles
+// replace those with the same name, bookmarks are added to the favorites,
+// the SVD file and linker map are loaded and every file is opened in a new
+// tab. Artifacts that cannot be restored are skipped with a warning.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) OpenProject(path string) (*models.ProjectOpenResult, error) {
+	project, err := service.LoadProject(path)
+	if err != nil {
+		return nil, err
+	}
+	result := &models.ProjectOpenResult{Project: *project, Sessions: []models.Session{}}
+	warn := func(format string, args ...any) {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(format, args...))
+	}
+
+	for _, profile := range project.Profiles {
+		if _, err := a.profiles.Save(profile); err != nil {
+			return nil, err
+		}
+	}
+	if project.ActiveProfile != "" {
+		if _, err := a.SelectProfile(project.ActiveProfile); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := a.favorites.Import(project.Bookmarks); err != nil {
+		return nil, err
+	}
+
+	if project.SVD != "" {
+		if _, err := a.svd.Load(project.SVD); err != nil {
+			warn("cannot load SVD file %s: %v", project.SVD, err)
+		}
+	}
+	if project.LinkerMap != "" {
+		if _, err := a.linkerMap.Load(project.LinkerMap); err != nil {
+			warn("cannot load linker map %s: %v", project.LinkerMap, err)
+		}
+	}
+	if project.ELFSymbols != "" {
+		if _, err := a.linkerMap.LoadELF(project.ELFSymbols); err != nil {
+			warn("cannot load symbols of %s: %v", project.ELFSymbols, err)
+		}
+	}
+
+	for _, f := range project.Files {
+		name := f.Name
+		if name == "" {
+			name = filepath.Base(f.Path)
+		}
+		file, err := a.openFile(f.Path)
+		if err != nil {
+			warn("cannot open %s: %v", f.Path, err)
+			continue
+		}
+		session, err := a.sessions.Create(name, a.settings.Get())
+		if err == nil {
+			session, err = a.sessions.SetFile(session.ID, file)
+		}
+		if err != nil {
+			_ = a.files.Close(file.ID)
+			return nil, err
+		}
+		result.Sessions = append(result.Sessions, *session)
+	}
+	return result, nil
+}
+
+// ListDecoders returns the built-in decoders and loaded plugins.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ListDecoders() []models.DecoderInfo {
+	return a.decoders.List()
+}
+
+// DetectDecoders returns the names of the decoders recognizing hex input.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) DetectDecoders(hexInput string) ([]string, error) {
+	return a.decoders.Detect(hexInput)
+}
+
+// DecodeWith decodes hex input into a tree with the named decoder.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) DecodeWith(decoderName string, hexInput string) (*models.DecodeNode, error) {
+	return a.decoders.Decode(decoderName, hexInput)
+}
+
+// ReloadDecoderPlugins reloads the plugin directory and returns the errors of
+// plugins that could not be loaded.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ReloadDecoderPlugins() []string {
+	errs := make([]string, 0)
+	for _, err := range a.decoders.LoadPlugins() {
+		errs = append(errs, err.Error())
+	}
+	return errs
+}
+
+// ListScripts returns the user scripts and their compile errors.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ListScripts() []models.ScriptInfo {
+	return a.scripts.List()
+}
+
+// GetScriptSource returns the source code of a user script.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) GetScriptSource(name string) (string, error) {
+	return a.scripts.Source(name)
+}
+
+// SaveScript compiles and stores a user script. Its decode(data) function
+// then runs on every conversion and adds a section to the result.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) SaveScript(name string, source string) (*models.ScriptInfo, error) {
+	return a.scripts.Save(name, source)
+}
+
+// DeleteScript removes a user script.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) DeleteScript(name string) error {
+	return a.scripts.Delete(name)
+}
+
+// RunScript runs a single user script on hex input, e.g. to test it while editing.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) RunScript(name string, hexInput string) (*models.ScriptSection, error) {
+	return a.scripts.Run(name, hexInput)
+}
+
+// ReloadScripts rereads the script directory and returns the errors of
+// scripts that could not be loaded.
+// This method is exported to the frontend via Wails bindings.
+func (a *App) ReloadScripts() []string {
+	errs := make([]string, 0)
+	for _, err := range a.scripts.Load() {
+		errs = append(errs, err.Error())
+	}
+	return errs
 }
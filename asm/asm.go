@@ -0,0 +1,144 @@
+// Package asm encodes and decodes single ARM Thumb and RISC-V instructions,
+// for patching firmware by hand.
+//
+// Thumb covers the 16-bit instruction set of ARMv6-M (Cortex-M0) plus CBZ,
+// CBNZ and the 32-bit BL, B.W, DMB, DSB and ISB instructions. RISC-V covers
+// RV64I with the M extension and the common pseudo-instructions (li, mv, j,
+// ret, beqz, ...); decoding also reads compressed instructions.
+//
+// Branch targets are written relative to the address of the instruction,
+// e.g. "b .+8" or "beq x10,x11,8", so no load address is needed.
+//
+// Example usage:
+//
+//	code, _ := asm.Encode(asm.Thumb, "movs r0, #1")   // 01 20
+//	text, size, _ := asm.Decode(asm.Thumb, code)      // "movs r0, #1", 2
+//	code, _ = asm.Encode(asm.RISCV, "addi a0, a0, 1") // 13 05 15 00
+package asm
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Arch identifies an instruction set supported by Encode and Decode.
+type Arch string
+
+// Supported instruction sets
+const (
+	Thumb Arch = "thumb"
+	RISCV Arch = "riscv64"
+)
+
+var (
+	// ErrUnsupportedArch indicates an instruction set other than Thumb or RISC-V
+	ErrUnsupportedArch = errors.New("unsupported architecture")
+
+	// ErrUnknownInstruction indicates a mnemonic or encoding that is not supported
+	ErrUnknownInstruction = errors.New("unknown instruction")
+
+	// ErrInvalidOperands indicates operands that do not fit the instruction
+	ErrInvalidOperands = errors.New("invalid operands")
+)
+
+// ParseArch converts an architecture name to an Arch. Names are
+// case-insensitive; "t16", "t32", "cortex-m", "riscv", "rv32" and "rv64" are
+// accepted as aliases.
+func ParseArch(name string) (Arch, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "thumb", "thumb2", "t16", "t32", "cortex-m":
+		return Thumb, nil
+	case "riscv64", "riscv", "riscv32", "rv32", "rv64":
+		return RISCV, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedArch, name)
+}
+
+// Encode assembles one instruction and returns its bytes in memory order
+// (little-endian).
+func Encode(arch Arch, text string) ([]byte, error) {
+	switch arch {
+	case Thumb:
+		return EncodeThumb(text)
+	case RISCV:
+		return EncodeRISCV(text)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedArch, arch)
+}
+
+// Decode disassembles the instruction at the start of src and returns its
+// text and size in bytes.
+func Decode(arch Arch, src []byte) (string, int, error) {
+	switch arch {
+	case Thumb:
+		return DecodeThumb(src)
+	case RISCV:
+		return DecodeRISCV(src)
+	}
+	return "", 0, fmt.Errorf("%w: %s", ErrUnsupportedArch, arch)
+}
+
+// splitInstruction splits assembly text into the lower-case mnemonic and
+// the operands.
+func splitInstruction(text string) (string, string) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if i := strings.IndexAny(text, ";@"); i >= 0 {
+		text = strings.TrimSpace(text[:i]) // trailing comment
+	}
+	mnemonic, operands, _ := strings.Cut(text, " ")
+	return mnemonic, strings.TrimSpace(operands)
+}
+
+// parseImmediate parses a signed immediate in decimal, hex (0x) or binary
+// (0b) notation, with an optional leading '#'.
+func parseImmediate(s string) (int64, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+	v, err := strconv.ParseInt(strings.ReplaceAll(s, "_", ""), 0, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid immediate %q", ErrInvalidOperands, s)
+	}
+	return v, nil
+}
+
+// parseOffset parses a branch offset relative to the instruction, written
+// as a number or as ".+8" / ".-4".
+func parseOffset(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if rest, ok := strings.CutPrefix(s, "."); ok {
+		s = strings.TrimPrefix(rest, "+")
+	}
+	return parseImmediate(s)
+}
+
+// formatOffset formats a branch offset relative to the instruction.
+func formatOffset(off int64) string {
+	if off < 0 {
+		return fmt.Sprintf(".%d", off)
+	}
+	return fmt.Sprintf(".+%d", off)
+}
+
+// signExtend interprets the low width bits of v as a two's complement number.
+func signExtend(v uint32, width int) int64 {
+	shift := 64 - width
+	return int64(uint64(v)<<shift) >> shift
+}
+
+// checkRange reports an error if v does not fit width bits (signed or
+// unsigned) or is not a multiple of scale.
+func checkRange(v int64, width int, signed bool, scale int64, what string) error {
+	if v%scale != 0 {
+		return fmt.Errorf("%w: %s %d is not a multiple of %d", ErrInvalidOperands, what, v, scale)
+	}
+	v /= scale
+	lo, hi := int64(0), int64(1)<<width-1
+	if signed {
+		lo, hi = -(int64(1) << (width - 1)), int64(1)<<(width-1)-1
+	}
+	if v < lo || v > hi {
+		return fmt.Errorf("%w: %s %d out of range %d to %d", ErrInvalidOperands, what, v*scale, lo*scale, hi*scale)
+	}
+	return nil
+}
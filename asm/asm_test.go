@@ -0,0 +1,185 @@
+package asm
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestThumb(t *testing.T) {
+	tests := []struct {
+		text    string
+		hex     string
+		decoded string // "" if the same as text
+	}{
+		{"movs r0, #1", "0120", ""},
+		{"mov r0, #1", "0120", "movs r0, #1"},
+		{"movs r2, r3", "1a00", ""},
+		{"lsls r1, r2, #3", "d100", ""},
+		{"adds r0, r1, r2", "8818", ""},
+		{"subs r3, #255", "ff3b", ""},
+		{"cmp r0, r1", "8842", ""},
+		{"cmp r8, r1", "8845", ""},
+		{"muls r0, r1", "4843", ""},
+		{"negs r0, r1", "4842", "rsbs r0, r1, #0"},
+		{"mov r8, lr", "f046", ""},
+		{"bx lr", "7047", ""},
+		{"blx r3", "9847", ""},
+		{"ldr r0, [pc, #8]", "0248", ""},
+		{"ldr r1, [r2, r3]", "d158", ""},
+		{"ldr r0, [r1]", "0868", ""},
+		{"ldr r0, [r1, #4]", "4868", ""},
+		{"strb r0, [r1, #31]", "c877", ""},
+		{"strh r0, [r1, #2]", "4880", ""},
+		{"str r0, [sp, #1020]", "ff90", ""},
+		{"add r0, sp, #8", "02a8", ""},
+		{"sub sp, sp, #16", "84b0", ""},
+		{"cbz r0, .+8", "10b1", ""},
+		{"cbnz r1, .+130", "f9bb", ""},
+		{"uxtb r0, r1", "c8b2", ""},
+		{"push {r4-r7, lr}", "f0b5", "push {r4, r5, r6, r7, lr}"},
+		{"pop {r4, pc}", "10bd", ""},
+		{"cpsid i", "72b6", ""},
+		{"rev r0, r1", "08ba", ""},
+		{"bkpt #0", "00be", ""},
+		{"nop", "00bf", ""},
+		{"wfi", "30bf", ""},
+		{"stmia r0!, {r1, r2}", "06c0", ""},
+		{"beq .+8", "02d0", ""},
+		{"bne .-4", "fcd1", ""},
+		{"bhs .+4", "00d2", "bcs .+4"},
+		{"svc #1", "01df", ""},
+		{"udf #0", "00de", ""},
+		{"b .+4", "00e0", ""},
+		{"b .-2044", "00e4", ""},
+		{"bl .+4", "00f000f8", ""},
+		{"bl .-4", "fff7fcff", ""},
+		{"b.w .+0x1000", "00f0febf", "b.w .+4096"},
+		{"dmb sy", "bff35f8f", ""},
+		{"isb", "bff36f8f", "isb sy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			code, err := Encode(Thumb, tt.text)
+			if err != nil {
+				t.Fatalf("Encode() error: %v", err)
+			}
+			if got := hex.EncodeToString(code); got != tt.hex {
+				t.Errorf("Encode() = %s, want %s", got, tt.hex)
+			}
+			want := tt.decoded
+			if want == "" {
+				want = tt.text
+			}
+			text, size, err := Decode(Thumb, code)
+			if err != nil || text != want || size != len(code) {
+				t.Errorf("Decode() = %q, %d, %v, want %q", text, size, err, want)
+			}
+		})
+	}
+}
+
+func TestThumb_Errors(t *testing.T) {
+	tests := []struct {
+		text string
+		want error
+	}{
+		{"frob r0", ErrUnknownInstruction},
+		{"movs r0, #256", ErrInvalidOperands},
+		{"adds r8, r1, r2", ErrInvalidOperands},
+		{"ldr r0, [r1, #3]", ErrInvalidOperands},
+		{"beq .+300", ErrInvalidOperands},
+		{"push {r8}", ErrInvalidOperands},
+		{"movs r0", ErrInvalidOperands},
+	}
+	for _, tt := range tests {
+		if _, err := EncodeThumb(tt.text); !errors.Is(err, tt.want) {
+			t.Errorf("EncodeThumb(%q) error = %v, want %v", tt.text, err, tt.want)
+		}
+	}
+	if _, _, err := DecodeThumb([]byte{0x00, 0xf0}); !errors.Is(err, ErrUnknownInstruction) {
+		t.Errorf("DecodeThumb(half of bl) error = %v", err)
+	}
+}
+
+func TestRISCV(t *testing.T) {
+	tests := []struct {
+		text    string
+		hex     string
+		decoded string
+	}{
+		{"addi a0, a0, 1", "13051500", "addi x10,x10,1"},
+		{"nop", "13000000", "nop"},
+		{"li x11, 10", "9305a000", "li x11,10"},
+		{"li a0, -1", "1305f0ff", "li x10,-1"},
+		{"mv a0, a1", "13850500", "mv x10,x11"},
+		{"not a0, a0", "1345f5ff", "not x10,x10"},
+		{"sub a0, a0, a1", "3305b540", "sub x10,x10,x11"},
+		{"mul a0, a0, a1", "3305b502", "mul x10,x10,x11"},
+		{"slli a0, a0, 1", "13151500", "slli x10,x10,0x1"},
+		{"srai a0, a0, 63", "1355f543", "srai x10,x10,0x3f"},
+		{"sext.w a0, a0", "1b050500", "sext.w x10,x10"},
+		{"lw a0, 0(a0)", "03250500", "lw x10,0(x10)"},
+		{"ld ra, -8(sp)", "833081ff", "ld x1,-8(x2)"},
+		{"sw a0, 0(a1)", "23a0a500", "sw x10,0(x11)"},
+		{"sd ra, 24(sp)", "233c1100", "sd x1,24(x2)"},
+		{"beq a0, a1, 8", "6304b500", "beq x10,x11,8"},
+		{"bnez a0, -4", "e31e05fe", "bnez x10,-4"},
+		{"bgt a0, a1, .+16", "63c8a500", "blt x11,x10,16"},
+		{"jal 8", "ef008000", "jal 8"},
+		{"j 12", "6f00c000", "j 12"},
+		{"j -2048", "6ff01f80", "j -2048"},
+		{"ret", "67800000", "ret"},
+		{"jr a0", "67000500", "jr x10"},
+		{"lui a0, 0x12345", "37553412", "lui x10,0x12345"},
+		{"ecall", "73000000", "ecall"},
+		{"fence", "0f00f00f", "fence"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			code, err := Encode(RISCV, tt.text)
+			if err != nil {
+				t.Fatalf("Encode() error: %v", err)
+			}
+			if got := hex.EncodeToString(code); got != tt.hex {
+				t.Errorf("Encode() = %s, want %s", got, tt.hex)
+			}
+			text, size, err := Decode(RISCV, code)
+			if err != nil || text != tt.decoded || size != 4 {
+				t.Errorf("Decode() = %q, %d, %v, want %q", text, size, err, tt.decoded)
+			}
+		})
+	}
+}
+
+func TestRISCV_Errors(t *testing.T) {
+	tests := []struct {
+		text string
+		want error
+	}{
+		{"frob a0", ErrUnknownInstruction},
+		{"addi a0, a0, 4096", ErrInvalidOperands},
+		{"li a0, 0x12345", ErrInvalidOperands},
+		{"add a0, a1", ErrInvalidOperands},
+		{"add a0, a1, x32", ErrInvalidOperands},
+		{"beq a0, a1, 3", ErrInvalidOperands},
+		{"lw a0, a1", ErrInvalidOperands},
+		{"slliw a0, a0, 32", ErrInvalidOperands},
+	}
+	for _, tt := range tests {
+		if _, err := EncodeRISCV(tt.text); !errors.Is(err, tt.want) {
+			t.Errorf("EncodeRISCV(%q) error = %v, want %v", tt.text, err, tt.want)
+		}
+	}
+}
+
+func TestParseArch(t *testing.T) {
+	for name, want := range map[string]Arch{"Thumb": Thumb, "cortex-m": Thumb, "rv32": RISCV, "riscv64": RISCV} {
+		if got, err := ParseArch(name); err != nil || got != want {
+			t.Errorf("ParseArch(%q) = %q, %v, want %q", name, got, err, want)
+		}
+	}
+	if _, err := ParseArch("mips"); !errors.Is(err, ErrUnsupportedArch) {
+		t.Errorf("ParseArch(mips) error = %v", err)
+	}
+}
@@ -0,0 +1,362 @@
+package asm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/arch/riscv64/riscv64asm"
+)
+
+// RISC-V instruction formats
+const (
+	rvR      = iota // rd, rs1, rs2
+	rvI             // rd, rs1, imm
+	rvShift         // rd, rs1, shamt
+	rvShiftW        // rd, rs1, shamt of 32-bit shifts
+	rvLoad          // rd, imm(rs1)
+	rvStore         // rs2, imm(rs1)
+	rvBranch        // rs1, rs2, offset
+	rvU             // rd, imm20
+	rvJ             // rd, offset
+	rvJALR          // rd, imm(rs1)
+)
+
+// rvOp is a RISC-V base or M extension instruction.
+type rvOp struct {
+	format int
+	opcode uint32
+	funct3 uint32
+	funct7 uint32 // funct6 << 1 for 64-bit shifts
+}
+
+var rvOps = map[string]rvOp{
+	"add": {rvR, 0x33, 0, 0x00}, "sub": {rvR, 0x33, 0, 0x20}, "sll": {rvR, 0x33, 1, 0x00},
+	"slt": {rvR, 0x33, 2, 0x00}, "sltu": {rvR, 0x33, 3, 0x00}, "xor": {rvR, 0x33, 4, 0x00},
+	"srl": {rvR, 0x33, 5, 0x00}, "sra": {rvR, 0x33, 5, 0x20}, "or": {rvR, 0x33, 6, 0x00},
+	"and": {rvR, 0x33, 7, 0x00},
+	"mul": {rvR, 0x33, 0, 0x01}, "mulh": {rvR, 0x33, 1, 0x01}, "mulhsu": {rvR, 0x33, 2, 0x01},
+	"mulhu": {rvR, 0x33, 3, 0x01}, "div": {rvR, 0x33, 4, 0x01}, "divu": {rvR, 0x33, 5, 0x01},
+	"rem": {rvR, 0x33, 6, 0x01}, "remu": {rvR, 0x33, 7, 0x01},
+	"addw": {rvR, 0x3b, 0, 0x00}, "subw": {rvR, 0x3b, 0, 0x20}, "sllw": {rvR, 0x3b, 1, 0x00},
+	"srlw": {rvR, 0x3b, 5, 0x00}, "sraw": {rvR, 0x3b, 5, 0x20}, "mulw": {rvR, 0x3b, 0, 0x01},
+	"divw": {rvR, 0x3b, 4, 0x01}, "divuw": {rvR, 0x3b, 5, 0x01}, "remw": {rvR, 0x3b, 6, 0x01},
+	"remuw": {rvR, 0x3b, 7, 0x01},
+	"addi":  {rvI, 0x13, 0, 0}, "slti": {rvI, 0x13, 2, 0}, "sltiu": {rvI, 0x13, 3, 0},
+	"xori": {rvI, 0x13, 4, 0}, "ori": {rvI, 0x13, 6, 0}, "andi": {rvI, 0x13, 7, 0},
+	"addiw": {rvI, 0x1b, 0, 0},
+	"slli":  {rvShift, 0x13, 1, 0x00}, "srli": {rvShift, 0x13, 5, 0x00}, "srai": {rvShift, 0x13, 5, 0x20},
+	"slliw": {rvShiftW, 0x1b, 1, 0x00}, "srliw": {rvShiftW, 0x1b, 5, 0x00}, "sraiw": {rvShiftW, 0x1b, 5, 0x20},
+	"lb": {rvLoad, 0x03, 0, 0}, "lh": {rvLoad, 0x03, 1, 0}, "lw": {rvLoad, 0x03, 2, 0},
+	"ld": {rvLoad, 0x03, 3, 0}, "lbu": {rvLoad, 0x03, 4, 0}, "lhu": {rvLoad, 0x03, 5, 0},
+	"lwu": {rvLoad, 0x03, 6, 0},
+	"sb":  {rvStore, 0x23, 0, 0}, "sh": {rvStore, 0x23, 1, 0}, "sw": {rvStore, 0x23, 2, 0},
+	"sd":  {rvStore, 0x23, 3, 0},
+	"beq": {rvBranch, 0x63, 0, 0}, "bne": {rvBranch, 0x63, 1, 0}, "blt": {rvBranch, 0x63, 4, 0},
+	"bge": {rvBranch, 0x63, 5, 0}, "bltu": {rvBranch, 0x63, 6, 0}, "bgeu": {rvBranch, 0x63, 7, 0},
+	"lui": {rvU, 0x37, 0, 0}, "auipc": {rvU, 0x17, 0, 0},
+	"jal":  {rvJ, 0x6f, 0, 0},
+	"jalr": {rvJALR, 0x67, 0, 0},
+}
+
+// rvFixed are instructions without operands.
+var rvFixed = map[string]uint32{
+	"nop":     0x00000013,
+	"ret":     0x00008067,
+	"ecall":   0x00000073,
+	"ebreak":  0x00100073,
+	"fence":   0x0ff0000f,
+	"fence.i": 0x0000100f,
+	"wfi":     0x10500073,
+	"mret":    0x30200073,
+}
+
+// rvABINames maps ABI register names to register numbers.
+var rvABINames = map[string]int{
+	"zero": 0, "ra": 1, "sp": 2, "gp": 3, "tp": 4, "t0": 5, "t1": 6, "t2": 7,
+	"s0": 8, "fp": 8, "s1": 9, "a0": 10, "a1": 11, "a2": 12, "a3": 13, "a4": 14, "a5": 15,
+	"a6": 16, "a7": 17, "s2": 18, "s3": 19, "s4": 20, "s5": 21, "s6": 22, "s7": 23,
+	"s8": 24, "s9": 25, "s10": 26, "s11": 27, "t3": 28, "t4": 29, "t5": 30, "t6": 31,
+}
+
+// rvMemory matches a memory operand "imm(rs1)"; the offset may be left out.
+var rvMemory = regexp.MustCompile(`^(.*)\((\w+)\)$`)
+
+// DecodeRISCV decodes the RV64 instruction at the start of src, including
+// compressed instructions, in GNU syntax with x0-x31 register names.
+func DecodeRISCV(src []byte) (string, int, error) {
+	inst, err := riscv64asm.Decode(src)
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: %w", ErrUnknownInstruction, err)
+	}
+	return riscv64asm.GNUSyntax(inst), inst.Len, nil
+}
+
+// EncodeRISCV assembles one RV64IM instruction or pseudo-instruction (nop,
+// li, mv, not, neg, negw, sext.w, seqz, snez, j, jr, ret, beqz, bnez, blez,
+// bgez, bltz, bgtz, bgt, ble, bgtu, bleu). Registers are x0-x31 or ABI
+// names; branch offsets are relative to the instruction.
+func EncodeRISCV(text string) ([]byte, error) {
+	mnemonic, operands := splitInstruction(text)
+	if word, ok := rvFixed[mnemonic]; ok {
+		if operands != "" {
+			return nil, fmt.Errorf("%w: %s takes no operands", ErrInvalidOperands, mnemonic)
+		}
+		return binary.LittleEndian.AppendUint32(nil, word), nil
+	}
+	var ops []string
+	if operands != "" {
+		ops = strings.Split(operands, ",")
+		for i := range ops {
+			ops[i] = strings.TrimSpace(ops[i])
+		}
+	}
+
+	mnemonic, ops, err := expandPseudo(mnemonic, ops)
+	if err != nil {
+		return nil, err
+	}
+	op, ok := rvOps[mnemonic]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownInstruction, mnemonic)
+	}
+	word, err := op.encode(mnemonic, ops)
+	if err != nil {
+		return nil, err
+	}
+	return binary.LittleEndian.AppendUint32(nil, word), nil
+}
+
+// expandPseudo rewrites pseudo-instructions and short forms to base
+// instructions.
+func expandPseudo(mnemonic string, ops []string) (string, []string, error) {
+	want := func(n int) error {
+		if len(ops) != n {
+			return fmt.Errorf("%w: %s takes %d operands", ErrInvalidOperands, mnemonic, n)
+		}
+		return nil
+	}
+	switch mnemonic {
+	case "li":
+		if err := want(2); err != nil {
+			return "", nil, err
+		}
+		return "addi", []string{ops[0], "x0", ops[1]}, nil
+	case "mv":
+		if err := want(2); err != nil {
+			return "", nil, err
+		}
+		return "addi", []string{ops[0], ops[1], "0"}, nil
+	case "not":
+		if err := want(2); err != nil {
+			return "", nil, err
+		}
+		return "xori", []string{ops[0], ops[1], "-1"}, nil
+	case "neg", "negw":
+		if err := want(2); err != nil {
+			return "", nil, err
+		}
+		return strings.Replace(mnemonic, "neg", "sub", 1), []string{ops[0], "x0", ops[1]}, nil
+	case "sext.w":
+		if err := want(2); err != nil {
+			return "", nil, err
+		}
+		return "addiw", []string{ops[0], ops[1], "0"}, nil
+	case "seqz":
+		if err := want(2); err != nil {
+			return "", nil, err
+		}
+		return "sltiu", []string{ops[0], ops[1], "1"}, nil
+	case "snez":
+		if err := want(2); err != nil {
+			return "", nil, err
+		}
+		return "sltu", []string{ops[0], "x0", ops[1]}, nil
+	case "j":
+		if err := want(1); err != nil {
+			return "", nil, err
+		}
+		return "jal", []string{"x0", ops[0]}, nil
+	case "jal":
+		if len(ops) == 1 {
+			return "jal", []string{"ra", ops[0]}, nil
+		}
+	case "jr":
+		if err := want(1); err != nil {
+			return "", nil, err
+		}
+		return "jalr", []string{"x0", "0(" + ops[0] + ")"}, nil
+	case "jalr":
+		switch len(ops) {
+		case 1:
+			if !rvMemory.MatchString(ops[0]) {
+				ops[0] = "0(" + ops[0] + ")"
+			}
+			return "jalr", []string{"ra", ops[0]}, nil
+		case 3: // jalr rd, rs1, imm
+			return "jalr", []string{ops[0], ops[2] + "(" + ops[1] + ")"}, nil
+		}
+	case "beqz", "bnez", "bgez", "bltz":
+		if err := want(2); err != nil {
+			return "", nil, err
+		}
+		return map[string]string{"beqz": "beq", "bnez": "bne", "bgez": "bge", "bltz": "blt"}[mnemonic], []string{ops[0], "x0", ops[1]}, nil
+	case "blez", "bgtz":
+		if err := want(2); err != nil {
+			return "", nil, err
+		}
+		return map[string]string{"blez": "bge", "bgtz": "blt"}[mnemonic], []string{"x0", ops[0], ops[1]}, nil
+	case "bgt", "ble", "bgtu", "bleu":
+		if err := want(3); err != nil {
+			return "", nil, err
+		}
+		base := map[string]string{"bgt": "blt", "ble": "bge", "bgtu": "bltu", "bleu": "bgeu"}[mnemonic]
+		return base, []string{ops[1], ops[0], ops[2]}, nil
+	}
+	return mnemonic, ops, nil
+}
+
+// encode assembles the operands of a base instruction.
+func (op rvOp) encode(mnemonic string, ops []string) (uint32, error) {
+	count := map[int]int{rvR: 3, rvI: 3, rvShift: 3, rvShiftW: 3, rvLoad: 2, rvStore: 2, rvBranch: 3, rvU: 2, rvJ: 2, rvJALR: 2}[op.format]
+	if len(ops) != count {
+		return 0, fmt.Errorf("%w: %s takes %d operands", ErrInvalidOperands, mnemonic, count)
+	}
+	var regs [3]uint32
+	reg := func(i, slot int) error {
+		n, err := parseRISCVRegister(ops[i])
+		regs[slot] = uint32(n)
+		return err
+	}
+	base := op.opcode | op.funct3<<12
+
+	switch op.format {
+	case rvR:
+		for i := range 3 {
+			if err := reg(i, i); err != nil {
+				return 0, err
+			}
+		}
+		return base | regs[0]<<7 | regs[1]<<15 | regs[2]<<20 | op.funct7<<25, nil
+
+	case rvI, rvShift, rvShiftW:
+		if err := reg(0, 0); err != nil {
+			return 0, err
+		}
+		if err := reg(1, 1); err != nil {
+			return 0, err
+		}
+		imm, err := parseImmediate(ops[2])
+		if err != nil {
+			return 0, err
+		}
+		word := base | regs[0]<<7 | regs[1]<<15
+		switch op.format {
+		case rvShift:
+			err = checkRange(imm, 6, false, 1, "shift amount")
+			word |= uint32(imm)<<20 | op.funct7<<25
+		case rvShiftW:
+			err = checkRange(imm, 5, false, 1, "shift amount")
+			word |= uint32(imm)<<20 | op.funct7<<25
+		default:
+			err = checkRange(imm, 12, true, 1, "immediate")
+			word |= uint32(imm) & 0xfff << 20
+		}
+		if err != nil {
+			if mnemonic == "addi" && ops[1] == "x0" {
+				return 0, fmt.Errorf("%w (li takes 12-bit values; use lui and addi for larger ones)", err)
+			}
+			return 0, err
+		}
+		return word, nil
+
+	case rvLoad, rvStore, rvJALR:
+		if err := reg(0, 0); err != nil {
+			return 0, err
+		}
+		m := rvMemory.FindStringSubmatch(ops[1])
+		if m == nil {
+			return 0, fmt.Errorf("%w: %s needs a memory operand like 8(sp), got %q", ErrInvalidOperands, mnemonic, ops[1])
+		}
+		n, err := parseRISCVRegister(m[2])
+		if err != nil {
+			return 0, err
+		}
+		imm := int64(0)
+		if s := strings.TrimSpace(m[1]); s != "" {
+			if imm, err = parseImmediate(s); err != nil {
+				return 0, err
+			}
+		}
+		if err := checkRange(imm, 12, true, 1, "offset"); err != nil {
+			return 0, err
+		}
+		u := uint32(imm) & 0xfff
+		if op.format == rvStore {
+			return base | uint32(n)<<15 | regs[0]<<20 | u&0x1f<<7 | u>>5<<25, nil
+		}
+		return base | regs[0]<<7 | uint32(n)<<15 | u<<20, nil
+
+	case rvBranch:
+		if err := reg(0, 0); err != nil {
+			return 0, err
+		}
+		if err := reg(1, 1); err != nil {
+			return 0, err
+		}
+		off, err := parseOffset(ops[2])
+		if err != nil {
+			return 0, err
+		}
+		if err := checkRange(off, 12, true, 2, "branch offset"); err != nil {
+			return 0, err
+		}
+		u := uint32(off)
+		return base | regs[0]<<15 | regs[1]<<20 |
+			u>>11&1<<7 | u>>1&0xf<<8 | u>>5&0x3f<<25 | u>>12&1<<31, nil
+
+	case rvU:
+		if err := reg(0, 0); err != nil {
+			return 0, err
+		}
+		imm, err := parseImmediate(ops[1])
+		if err != nil {
+			return 0, err
+		}
+		if imm < -(1<<19) || imm >= 1<<20 {
+			return 0, fmt.Errorf("%w: upper immediate %d out of range", ErrInvalidOperands, imm)
+		}
+		return base | regs[0]<<7 | uint32(imm)&0xfffff<<12, nil
+
+	case rvJ:
+		if err := reg(0, 0); err != nil {
+			return 0, err
+		}
+		off, err := parseOffset(ops[1])
+		if err != nil {
+			return 0, err
+		}
+		if err := checkRange(off, 20, true, 2, "jump offset"); err != nil {
+			return 0, err
+		}
+		u := uint32(off)
+		return base | regs[0]<<7 |
+			u>>12&0xff<<12 | u>>11&1<<20 | u>>1&0x3ff<<21 | u>>20&1<<31, nil
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownInstruction, mnemonic)
+}
+
+// parseRISCVRegister parses x0-x31 or an ABI register name.
+func parseRISCVRegister(s string) (int, error) {
+	if n, ok := rvABINames[s]; ok {
+		return n, nil
+	}
+	if rest, ok := strings.CutPrefix(s, "x"); ok {
+		if n, err := strconv.Atoi(rest); err == nil && n >= 0 && n <= 31 {
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown register %q", ErrInvalidOperands, s)
+}
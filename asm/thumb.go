@@ -0,0 +1,485 @@
+package asm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// thumbForm is a 16-bit Thumb instruction. operands is a template whose
+// fields in braces map to instruction bits:
+//
+//	{rN}      low register r0-r7 in bits N to N+2
+//	{RN}      register r0-r15 in bits N to N+3
+//	{h}       register r0-r15 with bit 3 in bit 7 and bits 0-2 (Rdn of high-register forms)
+//	{uN:W*S}  unsigned immediate of W bits at bit N, scaled by S
+//	{bN:W}    branch offset of W bits at bit N, in halfwords from the instruction + 4
+//	{z}       CBZ/CBNZ offset: bit 9 and bits 3-7, in halfwords from the instruction + 4
+//	{l} {l+lr} {l+pc}  register list r0-r7 in bits 0-7, with lr or pc in bit 8
+type thumbForm struct {
+	mnemonic string
+	operands string
+	bits     uint16
+	mask     uint16
+	pattern  *regexp.Regexp // operand regexp for encoding
+	fields   []string       // fields in order of the pattern's groups
+}
+
+// thumbConditions are the condition codes of conditional branches.
+var thumbConditions = []string{"eq", "ne", "cs", "cc", "mi", "pl", "vs", "vc", "hi", "ls", "ge", "lt", "gt", "le"}
+
+// thumbForms lists the instructions in decoding order: special cases such as
+// movs (lsls #0) come before the general form.
+var thumbForms = buildThumbForms()
+
+func buildThumbForms() []*thumbForm {
+	forms := []*thumbForm{
+		{mnemonic: "movs", operands: "{r0}, {r3}", bits: 0x0000, mask: 0xffc0},
+		{mnemonic: "lsls", operands: "{r0}, {r3}, #{u6:5}", bits: 0x0000, mask: 0xf800},
+		{mnemonic: "lsrs", operands: "{r0}, {r3}, #{u6:5}", bits: 0x0800, mask: 0xf800},
+		{mnemonic: "asrs", operands: "{r0}, {r3}, #{u6:5}", bits: 0x1000, mask: 0xf800},
+		{mnemonic: "adds", operands: "{r0}, {r3}, {r6}", bits: 0x1800, mask: 0xfe00},
+		{mnemonic: "subs", operands: "{r0}, {r3}, {r6}", bits: 0x1a00, mask: 0xfe00},
+		{mnemonic: "adds", operands: "{r0}, {r3}, #{u6:3}", bits: 0x1c00, mask: 0xfe00},
+		{mnemonic: "subs", operands: "{r0}, {r3}, #{u6:3}", bits: 0x1e00, mask: 0xfe00},
+		{mnemonic: "movs", operands: "{r8}, #{u0:8}", bits: 0x2000, mask: 0xf800},
+		{mnemonic: "cmp", operands: "{r8}, #{u0:8}", bits: 0x2800, mask: 0xf800},
+		{mnemonic: "adds", operands: "{r8}, #{u0:8}", bits: 0x3000, mask: 0xf800},
+		{mnemonic: "subs", operands: "{r8}, #{u0:8}", bits: 0x3800, mask: 0xf800},
+	}
+	for i, name := range []string{"ands", "eors", "lsls", "lsrs", "asrs", "adcs", "sbcs", "rors", "tst", "rsbs", "cmp", "cmn", "orrs", "muls", "bics", "mvns"} {
+		operands := "{r0}, {r3}"
+		if name == "rsbs" {
+			operands = "{r0}, {r3}, #0"
+		}
+		forms = append(forms, &thumbForm{mnemonic: name, operands: operands, bits: 0x4000 | uint16(i)<<6, mask: 0xffc0})
+	}
+	forms = append(forms,
+		&thumbForm{mnemonic: "add", operands: "{h}, {R3}", bits: 0x4400, mask: 0xff00},
+		&thumbForm{mnemonic: "cmp", operands: "{h}, {R3}", bits: 0x4500, mask: 0xff00},
+		&thumbForm{mnemonic: "mov", operands: "{h}, {R3}", bits: 0x4600, mask: 0xff00},
+		&thumbForm{mnemonic: "bx", operands: "{R3}", bits: 0x4700, mask: 0xff87},
+		&thumbForm{mnemonic: "blx", operands: "{R3}", bits: 0x4780, mask: 0xff87},
+		&thumbForm{mnemonic: "ldr", operands: "{r8}, [pc, #{u0:8*4}]", bits: 0x4800, mask: 0xf800},
+	)
+	for i, name := range []string{"str", "strh", "strb", "ldrsb", "ldr", "ldrh", "ldrb", "ldrsh"} {
+		forms = append(forms, &thumbForm{mnemonic: name, operands: "{r0}, [{r3}, {r6}]", bits: 0x5000 | uint16(i)<<9, mask: 0xfe00})
+	}
+	forms = append(forms,
+		&thumbForm{mnemonic: "str", operands: "{r0}, [{r3}, #{u6:5*4}]", bits: 0x6000, mask: 0xf800},
+		&thumbForm{mnemonic: "ldr", operands: "{r0}, [{r3}, #{u6:5*4}]", bits: 0x6800, mask: 0xf800},
+		&thumbForm{mnemonic: "strb", operands: "{r0}, [{r3}, #{u6:5}]", bits: 0x7000, mask: 0xf800},
+		&thumbForm{mnemonic: "ldrb", operands: "{r0}, [{r3}, #{u6:5}]", bits: 0x7800, mask: 0xf800},
+		&thumbForm{mnemonic: "strh", operands: "{r0}, [{r3}, #{u6:5*2}]", bits: 0x8000, mask: 0xf800},
+		&thumbForm{mnemonic: "ldrh", operands: "{r0}, [{r3}, #{u6:5*2}]", bits: 0x8800, mask: 0xf800},
+		&thumbForm{mnemonic: "str", operands: "{r8}, [sp, #{u0:8*4}]", bits: 0x9000, mask: 0xf800},
+		&thumbForm{mnemonic: "ldr", operands: "{r8}, [sp, #{u0:8*4}]", bits: 0x9800, mask: 0xf800},
+		&thumbForm{mnemonic: "adr", operands: "{r8}, #{u0:8*4}", bits: 0xa000, mask: 0xf800},
+		&thumbForm{mnemonic: "add", operands: "{r8}, sp, #{u0:8*4}", bits: 0xa800, mask: 0xf800},
+		&thumbForm{mnemonic: "add", operands: "sp, sp, #{u0:7*4}", bits: 0xb000, mask: 0xff80},
+		&thumbForm{mnemonic: "sub", operands: "sp, sp, #{u0:7*4}", bits: 0xb080, mask: 0xff80},
+		&thumbForm{mnemonic: "cbz", operands: "{r0}, {z}", bits: 0xb100, mask: 0xfd00},
+		&thumbForm{mnemonic: "cbnz", operands: "{r0}, {z}", bits: 0xb900, mask: 0xfd00},
+		&thumbForm{mnemonic: "sxth", operands: "{r0}, {r3}", bits: 0xb200, mask: 0xffc0},
+		&thumbForm{mnemonic: "sxtb", operands: "{r0}, {r3}", bits: 0xb240, mask: 0xffc0},
+		&thumbForm{mnemonic: "uxth", operands: "{r0}, {r3}", bits: 0xb280, mask: 0xffc0},
+		&thumbForm{mnemonic: "uxtb", operands: "{r0}, {r3}", bits: 0xb2c0, mask: 0xffc0},
+		&thumbForm{mnemonic: "push", operands: "{l+lr}", bits: 0xb400, mask: 0xfe00},
+		&thumbForm{mnemonic: "cpsie", operands: "i", bits: 0xb662, mask: 0xffff},
+		&thumbForm{mnemonic: "cpsid", operands: "i", bits: 0xb672, mask: 0xffff},
+		&thumbForm{mnemonic: "cpsie", operands: "f", bits: 0xb661, mask: 0xffff},
+		&thumbForm{mnemonic: "cpsid", operands: "f", bits: 0xb671, mask: 0xffff},
+		&thumbForm{mnemonic: "rev", operands: "{r0}, {r3}", bits: 0xba00, mask: 0xffc0},
+		&thumbForm{mnemonic: "rev16", operands: "{r0}, {r3}", bits: 0xba40, mask: 0xffc0},
+		&thumbForm{mnemonic: "revsh", operands: "{r0}, {r3}", bits: 0xbac0, mask: 0xffc0},
+		&thumbForm{mnemonic: "pop", operands: "{l+pc}", bits: 0xbc00, mask: 0xfe00},
+		&thumbForm{mnemonic: "bkpt", operands: "#{u0:8}", bits: 0xbe00, mask: 0xff00},
+		&thumbForm{mnemonic: "nop", bits: 0xbf00, mask: 0xffff},
+		&thumbForm{mnemonic: "yield", bits: 0xbf10, mask: 0xffff},
+		&thumbForm{mnemonic: "wfe", bits: 0xbf20, mask: 0xffff},
+		&thumbForm{mnemonic: "wfi", bits: 0xbf30, mask: 0xffff},
+		&thumbForm{mnemonic: "sev", bits: 0xbf40, mask: 0xffff},
+		&thumbForm{mnemonic: "stmia", operands: "{r8}!, {l}", bits: 0xc000, mask: 0xf800},
+		&thumbForm{mnemonic: "ldmia", operands: "{r8}!, {l}", bits: 0xc800, mask: 0xf800},
+	)
+	for i, cond := range thumbConditions {
+		forms = append(forms, &thumbForm{mnemonic: "b" + cond, operands: "{b0:8}", bits: 0xd000 | uint16(i)<<8, mask: 0xff00})
+	}
+	forms = append(forms,
+		&thumbForm{mnemonic: "udf", operands: "#{u0:8}", bits: 0xde00, mask: 0xff00},
+		&thumbForm{mnemonic: "svc", operands: "#{u0:8}", bits: 0xdf00, mask: 0xff00},
+		&thumbForm{mnemonic: "b", operands: "{b0:11}", bits: 0xe000, mask: 0xf800},
+	)
+	for _, f := range forms {
+		f.compile()
+	}
+	return forms
+}
+
+var (
+	thumbField    = regexp.MustCompile(`\{[^}]+\}`)
+	thumbRegister = `([a-z]+[0-9]*)`
+	thumbNumber   = `([-+]?(?:0x[0-9a-f]+|0b[01]+|[0-9]+))`
+	thumbTarget   = `(\.?[-+]?(?:0x[0-9a-f]+|[0-9]+))`
+)
+
+// compile builds the operand regexp of a form. Operands are matched
+// without whitespace; '#' is optional and a zero offset in brackets may be
+// left out ("[r1]").
+func (f *thumbForm) compile() {
+	tmpl := strings.ReplaceAll(f.operands, " ", "")
+	var re strings.Builder
+	re.WriteString("^")
+	last := 0
+	for _, loc := range thumbField.FindAllStringIndex(tmpl, -1) {
+		literal := tmpl[last:loc[0]]
+		field := tmpl[loc[0]+1 : loc[1]-1]
+		f.fields = append(f.fields, field)
+		last = loc[1]
+
+		group := thumbRegister
+		switch field[0] {
+		case 'u':
+			group = thumbNumber
+			if strings.HasSuffix(literal, ",#") && strings.HasPrefix(tmpl[last:], "]") {
+				literal = strings.TrimSuffix(literal, ",#")
+				group = `(?:,#?` + thumbNumber + `)?`
+			}
+		case 'b', 'z':
+			group = thumbTarget
+		case 'l':
+			group = `\{([^}]*)\}`
+		}
+		re.WriteString(literalPattern(literal))
+		re.WriteString(group)
+	}
+	re.WriteString(literalPattern(tmpl[last:]))
+	re.WriteString("$")
+	f.pattern = regexp.MustCompile(re.String())
+}
+
+// literalPattern quotes template text, making '#' optional.
+func literalPattern(s string) string {
+	return strings.ReplaceAll(regexp.QuoteMeta(s), "#", "#?")
+}
+
+// thumbRegNames are the register names used when decoding.
+var thumbRegNames = []string{"r0", "r1", "r2", "r3", "r4", "r5", "r6", "r7", "r8", "r9", "r10", "r11", "r12", "sp", "lr", "pc"}
+
+// parseThumbRegister parses a register name, including the aliases fp, ip, sb
+// and sl.
+func parseThumbRegister(s string) (int, error) {
+	switch s {
+	case "sp":
+		return 13, nil
+	case "lr":
+		return 14, nil
+	case "pc":
+		return 15, nil
+	case "ip":
+		return 12, nil
+	case "fp":
+		return 11, nil
+	case "sl":
+		return 10, nil
+	case "sb":
+		return 9, nil
+	}
+	if n, err := strconv.Atoi(strings.TrimPrefix(s, "r")); err == nil && strings.HasPrefix(s, "r") && n >= 0 && n <= 15 {
+		return n, nil
+	}
+	return 0, fmt.Errorf("%w: unknown register %q", ErrInvalidOperands, s)
+}
+
+// fieldSpec splits an immediate field "u6:5*4" into bit position, width and scale.
+func fieldSpec(field string) (pos, width int, scale int64) {
+	spec, s, ok := strings.Cut(field[1:], "*")
+	scale = 1
+	if ok {
+		scale, _ = strconv.ParseInt(s, 10, 64)
+	}
+	p, w, _ := strings.Cut(spec, ":")
+	pos, _ = strconv.Atoi(p)
+	width, _ = strconv.Atoi(w)
+	return pos, width, scale
+}
+
+// DecodeThumb decodes the Thumb instruction at the start of src, a 16-bit
+// instruction or the 32-bit BL, B.W, DMB, DSB or ISB.
+func DecodeThumb(src []byte) (string, int, error) {
+	if len(src) < 2 {
+		return "", 0, fmt.Errorf("%w: need 2 bytes", ErrUnknownInstruction)
+	}
+	hw := binary.LittleEndian.Uint16(src)
+	if hw>>11 >= 0x1d {
+		if len(src) < 4 {
+			return "", 0, fmt.Errorf("%w: 32-bit instruction 0x%04x needs 4 bytes", ErrUnknownInstruction, hw)
+		}
+		text, err := decodeThumb32(hw, binary.LittleEndian.Uint16(src[2:]))
+		return text, 4, err
+	}
+	for _, f := range thumbForms {
+		if hw&f.mask == f.bits {
+			return f.format(hw), 2, nil
+		}
+	}
+	return "", 2, fmt.Errorf("%w: 0x%04x", ErrUnknownInstruction, hw)
+}
+
+// format fills the operand template of f with the fields of hw.
+func (f *thumbForm) format(hw uint16) string {
+	if f.operands == "" {
+		return f.mnemonic
+	}
+	w := uint32(hw)
+	operands := thumbField.ReplaceAllStringFunc(f.operands, func(m string) string {
+		field := m[1 : len(m)-1]
+		switch field[0] {
+		case 'r':
+			pos, _ := strconv.Atoi(field[1:])
+			return thumbRegNames[w>>pos&7]
+		case 'R':
+			pos, _ := strconv.Atoi(field[1:])
+			return thumbRegNames[w>>pos&15]
+		case 'h':
+			return thumbRegNames[w>>4&8|w&7]
+		case 'u':
+			pos, width, scale := fieldSpec(field)
+			return strconv.FormatInt(int64(w>>pos&(1<<width-1))*scale, 10)
+		case 'b':
+			pos, width, _ := fieldSpec(field)
+			return formatOffset(signExtend(w>>pos, width)*2 + 4)
+		case 'z':
+			return formatOffset(int64(w>>4&0x20|w>>3&0x1f)*2 + 4)
+		case 'l':
+			var regs []string
+			for i := range 8 {
+				if w&(1<<i) != 0 {
+					regs = append(regs, thumbRegNames[i])
+				}
+			}
+			if field != "l" && w&0x100 != 0 {
+				regs = append(regs, strings.TrimPrefix(field, "l+"))
+			}
+			return "{" + strings.Join(regs, ", ") + "}"
+		}
+		return m
+	})
+	// Loads and stores show a zero offset as "[r1]"
+	operands = strings.Replace(operands, ", #0]", "]", 1)
+	return f.mnemonic + " " + operands
+}
+
+// decodeThumb32 decodes the supported 32-bit Thumb instructions.
+func decodeThumb32(hw1, hw2 uint16) (string, error) {
+	switch uint32(hw1)<<16 | uint32(hw2) {
+	case 0xf3bf8f5f:
+		return "dmb sy", nil
+	case 0xf3bf8f4f:
+		return "dsb sy", nil
+	case 0xf3bf8f6f:
+		return "isb sy", nil
+	}
+	if hw1>>11 == 0x1e && (hw2&0xd000 == 0xd000 || hw2&0xd000 == 0x9000) {
+		s := uint32(hw1 >> 10 & 1)
+		j1, j2 := uint32(hw2>>13&1), uint32(hw2>>11&1)
+		i1, i2 := ^(j1^s)&1, ^(j2^s)&1
+		imm := s<<24 | i1<<23 | i2<<22 | uint32(hw1&0x3ff)<<12 | uint32(hw2&0x7ff)<<1
+		mnemonic := "bl"
+		if hw2&0x4000 == 0 {
+			mnemonic = "b.w"
+		}
+		return mnemonic + " " + formatOffset(signExtend(imm, 25)+4), nil
+	}
+	return "", fmt.Errorf("%w: 0x%04x%04x", ErrUnknownInstruction, hw1, hw2)
+}
+
+// EncodeThumb assembles one Thumb instruction. Mnemonics may omit the "s"
+// of flag-setting instructions ("mov r0, #1") and the ".n" width suffix.
+func EncodeThumb(text string) ([]byte, error) {
+	mnemonic, operands := splitInstruction(text)
+	mnemonic = strings.TrimSuffix(mnemonic, ".n")
+	switch mnemonic {
+	case "bhs":
+		mnemonic = "bcs"
+	case "blo":
+		mnemonic = "bcc"
+	case "neg", "negs":
+		mnemonic, operands = "rsbs", operands+", #0"
+	case "bl", "b.w":
+		return encodeThumbBranch32(mnemonic, operands)
+	case "dmb", "dsb", "isb":
+		if operands != "" && operands != "sy" {
+			return nil, fmt.Errorf("%w: only the sy option is supported", ErrInvalidOperands)
+		}
+		word := map[string]uint32{"dmb": 0xf3bf8f5f, "dsb": 0xf3bf8f4f, "isb": 0xf3bf8f6f}[mnemonic]
+		return thumbBytes(uint16(word>>16), uint16(word)), nil
+	}
+	compact := strings.Join(strings.Fields(operands), "")
+
+	var lastErr error
+	found := false
+	for _, name := range []string{mnemonic, mnemonic + "s"} {
+		for _, f := range thumbForms {
+			if f.mnemonic != name {
+				continue
+			}
+			found = true
+			m := f.pattern.FindStringSubmatch(compact)
+			if m == nil {
+				continue
+			}
+			hw, err := f.encode(m[1:])
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			return thumbBytes(hw), nil
+		}
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownInstruction, mnemonic)
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrInvalidOperands, mnemonic, operands)
+}
+
+// encode sets the fields of f from the operands matched by its pattern.
+func (f *thumbForm) encode(values []string) (uint16, error) {
+	w := uint32(f.bits)
+	for i, field := range f.fields {
+		v := values[i]
+		switch field[0] {
+		case 'r', 'R', 'h':
+			n, err := parseThumbRegister(v)
+			if err != nil {
+				return 0, err
+			}
+			if field[0] == 'r' && n > 7 {
+				return 0, fmt.Errorf("%w: %s needs a low register r0-r7, got %s", ErrInvalidOperands, f.mnemonic, v)
+			}
+			switch field[0] {
+			case 'h':
+				w |= uint32(n&8)<<4 | uint32(n&7)
+			default:
+				pos, _ := strconv.Atoi(field[1:])
+				w |= uint32(n) << pos
+			}
+		case 'u':
+			pos, width, scale := fieldSpec(field)
+			n := int64(0)
+			if v != "" {
+				var err error
+				if n, err = parseImmediate(v); err != nil {
+					return 0, err
+				}
+			}
+			if err := checkRange(n, width, false, scale, "immediate"); err != nil {
+				return 0, err
+			}
+			w |= uint32(n/scale) << pos
+		case 'b':
+			pos, width, _ := fieldSpec(field)
+			off, err := parseOffset(v)
+			if err != nil {
+				return 0, err
+			}
+			if err := checkRange(off-4, width, true, 2, "branch offset - 4"); err != nil {
+				return 0, err
+			}
+			w |= uint32((off-4)/2) & (1<<width - 1) << pos
+		case 'z':
+			off, err := parseOffset(v)
+			if err != nil {
+				return 0, err
+			}
+			if err := checkRange(off-4, 6, false, 2, "branch offset - 4"); err != nil {
+				return 0, err
+			}
+			n := uint32(off-4) / 2
+			w |= n&0x20<<4 | n&0x1f<<3
+		case 'l':
+			extra := strings.TrimPrefix(field, "l+")
+			count := 0
+			for _, name := range strings.Split(v, ",") {
+				if name == "" {
+					continue
+				}
+				count++
+				if field != "l" && name == extra {
+					w |= 0x100
+					continue
+				}
+				regs, err := registerRange(name)
+				if err != nil {
+					return 0, err
+				}
+				for _, n := range regs {
+					if n > 7 {
+						return 0, fmt.Errorf("%w: %s cannot use %s", ErrInvalidOperands, f.mnemonic, thumbRegNames[n])
+					}
+					w |= 1 << n
+				}
+			}
+			if count == 0 {
+				return 0, fmt.Errorf("%w: empty register list", ErrInvalidOperands)
+			}
+		}
+	}
+	return uint16(w), nil
+}
+
+// registerRange parses a register list entry, a register or a range like "r4-r7".
+func registerRange(s string) ([]int, error) {
+	from, to, isRange := strings.Cut(s, "-")
+	first, err := parseThumbRegister(from)
+	if err != nil || !isRange {
+		return []int{first}, err
+	}
+	last, err := parseThumbRegister(to)
+	if err != nil {
+		return nil, err
+	}
+	if last < first {
+		return nil, fmt.Errorf("%w: register range %s", ErrInvalidOperands, s)
+	}
+	var regs []int
+	for n := first; n <= last; n++ {
+		regs = append(regs, n)
+	}
+	return regs, nil
+}
+
+// encodeThumbBranch32 assembles BL and B.W with a 25-bit signed offset.
+func encodeThumbBranch32(mnemonic, operands string) ([]byte, error) {
+	off, err := parseOffset(operands)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRange(off-4, 24, true, 2, "branch offset - 4"); err != nil {
+		return nil, err
+	}
+	imm := uint32(off-4) & 0x1ffffff
+	s := imm >> 24 & 1
+	i1, i2 := imm>>23&1, imm>>22&1
+	j1, j2 := ^(i1^s)&1, ^(i2^s)&1
+	hw1 := uint16(0xf000 | s<<10 | imm>>12&0x3ff)
+	hw2 := uint16(0x9000 | j1<<13 | j2<<11 | imm>>1&0x7ff)
+	if mnemonic == "bl" {
+		hw2 |= 0x4000
+	}
+	return thumbBytes(hw1, hw2), nil
+}
+
+// thumbBytes returns halfwords in memory order.
+func thumbBytes(halfwords ...uint16) []byte {
+	out := make([]byte, 0, 2*len(halfwords))
+	for _, hw := range halfwords {
+		out = binary.LittleEndian.AppendUint16(out, hw)
+	}
+	return out
+}
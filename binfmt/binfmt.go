@@ -0,0 +1,401 @@
+// Package binfmt provides quick header summaries for executable file formats.
+//
+// It detects ELF, PE (Windows) and Mach-O (including universal/fat) binaries
+// and reports architecture, entry point and the section/segment layout with
+// file offsets, so those offsets can be used as navigation targets in a hex view.
+//
+// Parsing is delegated to the standard library debug/elf, debug/pe and
+// debug/macho packages; this package only normalizes their output.
+//
+// Example usage:
+//
+//	f, _ := os.Open("firmware.elf")
+//	info, _ := binfmt.Parse(f)
+//	fmt.Println(info.Format, info.Arch, info.Entry)
+package binfmt
+
+import (
+	"bytes"
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format identifies an executable file format.
+type Format string
+
+// Supported executable formats
+const (
+	FormatELF   Format = "ELF"
+	FormatPE    Format = "PE"
+	FormatMachO Format = "Mach-O"
+)
+
+// ErrUnknownFormat indicates the data does not start with a known executable header
+var ErrUnknownFormat = errors.New("unknown executable format")
+
+// Section describes a named region of the file.
+type Section struct {
+	Name    string
+	Offset  uint64 // File offset
+	Size    uint64 // Size in the file
+	Address uint64 // Virtual address when loaded
+	Flags   string
+}
+
+// Segment describes a loadable region (ELF program header or Mach-O segment).
+type Segment struct {
+	Name     string
+	Type     string
+	Offset   uint64
+	FileSize uint64
+	Address  uint64
+	MemSize  uint64
+	Flags    string
+}
+
+// Info is a normalized summary of an executable header.
+type Info struct {
+	Format    Format
+	Type      string // e.g. EXEC, DYN, DLL
+	Arch      string // e.g. x86-64, arm, arm64
+	Bits      int
+	Endian    string // "little" or "big"
+	Entry     uint64
+	Sections  []Section
+	Segments  []Segment
+	FatArches []string // Architectures contained in a Mach-O universal binary
+}
+
+// Detect returns the executable format of the given header bytes.
+// At least the first 4 bytes are required.
+func Detect(header []byte) (Format, error) {
+	if len(header) < 4 {
+		return "", ErrUnknownFormat
+	}
+	switch {
+	case bytes.HasPrefix(header, []byte(elf.ELFMAG)):
+		return FormatELF, nil
+	case header[0] == 'M' && header[1] == 'Z':
+		return FormatPE, nil
+	}
+
+	magicBE := binary.BigEndian.Uint32(header)
+	magicLE := binary.LittleEndian.Uint32(header)
+	switch {
+	case magicBE == macho.MagicFat,
+		magicLE == macho.Magic32, magicLE == macho.Magic64,
+		magicBE == macho.Magic32, magicBE == macho.Magic64:
+		return FormatMachO, nil
+	}
+
+	return "", ErrUnknownFormat
+}
+
+// Parse detects the format of r and returns its header summary.
+func Parse(r io.ReaderAt) (*Info, error) {
+	header := make([]byte, 4)
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	format, err := Detect(header)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case FormatELF:
+		return parseELF(r)
+	case FormatPE:
+		return parsePE(r)
+	default:
+		return parseMachO(r)
+	}
+}
+
+func parseELF(r io.ReaderAt) (*Info, error) {
+	f, err := elf.NewFile(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ELF file: %w", err)
+	}
+	defer f.Close()
+
+	info := &Info{
+		Format: FormatELF,
+		Type:   strings.TrimPrefix(f.Type.String(), "ET_"),
+		Arch:   elfArch(f.Machine),
+		Bits:   32,
+		Endian: endianName(f.ByteOrder),
+		Entry:  f.Entry,
+	}
+	if f.Class == elf.ELFCLASS64 {
+		info.Bits = 64
+	}
+
+	for _, s := range f.Sections {
+		if s.Type == elf.SHT_NULL {
+			continue
+		}
+		size := s.Size
+		if s.Type == elf.SHT_NOBITS {
+			size = 0
+		}
+		info.Sections = append(info.Sections, Section{
+			Name:    s.Name,
+			Offset:  s.Offset,
+			Size:    size,
+			Address: s.Addr,
+			Flags:   elfSectionFlags(s.Flags),
+		})
+	}
+
+	for _, p := range f.Progs {
+		info.Segments = append(info.Segments, Segment{
+			Type:     strings.TrimPrefix(p.Type.String(), "PT_"),
+			Offset:   p.Off,
+			FileSize: p.Filesz,
+			Address:  p.Vaddr,
+			MemSize:  p.Memsz,
+			Flags:    elfProgFlags(p.Flags),
+		})
+	}
+
+	return info, nil
+}
+
+func parsePE(r io.ReaderAt) (*Info, error) {
+	f, err := pe.NewFile(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PE file: %w", err)
+	}
+	defer f.Close()
+
+	info := &Info{
+		Format: FormatPE,
+		Type:   "EXEC",
+		Arch:   peArch(f.Machine),
+		Endian: "little",
+	}
+	if f.Characteristics&pe.IMAGE_FILE_DLL != 0 {
+		info.Type = "DLL"
+	}
+
+	var imageBase uint64
+	switch oh := f.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		info.Bits = 32
+		imageBase = uint64(oh.ImageBase)
+		info.Entry = imageBase + uint64(oh.AddressOfEntryPoint)
+	case *pe.OptionalHeader64:
+		info.Bits = 64
+		imageBase = oh.ImageBase
+		info.Entry = imageBase + uint64(oh.AddressOfEntryPoint)
+	}
+
+	for _, s := range f.Sections {
+		info.Sections = append(info.Sections, Section{
+			Name:    s.Name,
+			Offset:  uint64(s.Offset),
+			Size:    uint64(s.Size),
+			Address: imageBase + uint64(s.VirtualAddress),
+			Flags:   peSectionFlags(s.Characteristics),
+		})
+	}
+
+	return info, nil
+}
+
+// parseMachO parses a thin Mach-O file or the first architecture of a
+// universal binary. Offsets of the sections and segments of a universal
+// binary are converted from its slice to the whole file.
+func parseMachO(r io.ReaderAt) (*Info, error) {
+	var fatArches []string
+	var f *macho.File
+	var base uint64 // file offset of the parsed slice
+
+	if fat, err := macho.NewFatFile(r); err == nil {
+		defer fat.Close()
+		for _, a := range fat.Arches {
+			fatArches = append(fatArches, machoArch(a.Cpu))
+		}
+		if len(fat.Arches) == 0 {
+			return nil, fmt.Errorf("invalid Mach-O file: empty universal binary")
+		}
+		f, base = fat.Arches[0].File, uint64(fat.Arches[0].Offset)
+	} else {
+		f, err = macho.NewFile(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Mach-O file: %w", err)
+		}
+		defer f.Close()
+	}
+
+	info := &Info{
+		Format:    FormatMachO,
+		Type:      strings.ToUpper(f.Type.String()),
+		Arch:      machoArch(f.Cpu),
+		Bits:      32,
+		Endian:    endianName(f.ByteOrder),
+		FatArches: fatArches,
+	}
+	if f.Magic == macho.Magic64 {
+		info.Bits = 64
+	}
+
+	var textAddr uint64
+	for _, l := range f.Loads {
+		seg, ok := l.(*macho.Segment)
+		if !ok {
+			continue
+		}
+		if seg.Name == "__TEXT" {
+			textAddr = seg.Addr
+		}
+		info.Segments = append(info.Segments, Segment{
+			Name:     seg.Name,
+			Type:     "SEGMENT",
+			Offset:   sliceOffset(base, seg.Offset, seg.Filesz > 0),
+			FileSize: seg.Filesz,
+			Address:  seg.Addr,
+			MemSize:  seg.Memsz,
+			Flags:    machoProt(seg.Prot),
+		})
+	}
+
+	// LC_MAIN holds the entry point as an offset relative to the __TEXT segment
+	const lcMain = 0x80000028
+	for _, l := range f.Loads {
+		raw := l.Raw()
+		if len(raw) >= 16 && f.ByteOrder.Uint32(raw) == lcMain {
+			info.Entry = textAddr + f.ByteOrder.Uint64(raw[8:16])
+		}
+	}
+
+	for _, s := range f.Sections {
+		info.Sections = append(info.Sections, Section{
+			Name:    s.Seg + "," + s.Name,
+			Offset:  sliceOffset(base, uint64(s.Offset), s.Offset > 0),
+			Size:    s.Size,
+			Address: s.Addr,
+		})
+	}
+
+	return info, nil
+}
+
+// sliceOffset converts the offset of a section or segment in the slice at
+// base to a file offset. Ranges without file data, such as zero-fill
+// sections (offset 0) and __PAGEZERO (no file size), keep their offset.
+func sliceOffset(base, offset uint64, inFile bool) uint64 {
+	if !inFile {
+		return offset
+	}
+	return base + offset
+}
+
+// elfArch returns a short architecture name for an ELF machine type.
+func elfArch(m elf.Machine) string {
+	switch m {
+	case elf.EM_X86_64:
+		return "x86-64"
+	case elf.EM_386:
+		return "x86-32"
+	case elf.EM_AARCH64:
+		return "arm64"
+	case elf.EM_ARM:
+		return "arm"
+	case elf.EM_RISCV:
+		return "riscv"
+	}
+	return strings.TrimPrefix(m.String(), "EM_")
+}
+
+// peArch returns a short architecture name for a PE machine type.
+func peArch(m uint16) string {
+	switch m {
+	case pe.IMAGE_FILE_MACHINE_AMD64:
+		return "x86-64"
+	case pe.IMAGE_FILE_MACHINE_I386:
+		return "x86-32"
+	case pe.IMAGE_FILE_MACHINE_ARM64:
+		return "arm64"
+	case pe.IMAGE_FILE_MACHINE_ARMNT, pe.IMAGE_FILE_MACHINE_ARM, pe.IMAGE_FILE_MACHINE_THUMB:
+		return "arm"
+	case pe.IMAGE_FILE_MACHINE_RISCV64:
+		return "riscv64"
+	}
+	return fmt.Sprintf("0x%04x", m)
+}
+
+// machoArch returns a short architecture name for a Mach-O CPU type.
+func machoArch(c macho.Cpu) string {
+	switch c {
+	case macho.CpuAmd64:
+		return "x86-64"
+	case macho.Cpu386:
+		return "x86-32"
+	case macho.CpuArm64:
+		return "arm64"
+	case macho.CpuArm:
+		return "arm"
+	}
+	return c.String()
+}
+
+func endianName(bo binary.ByteOrder) string {
+	if bo == binary.BigEndian {
+		return "big"
+	}
+	return "little"
+}
+
+func elfSectionFlags(f elf.SectionFlag) string {
+	var sb strings.Builder
+	if f&elf.SHF_ALLOC != 0 {
+		sb.WriteByte('A')
+	}
+	if f&elf.SHF_WRITE != 0 {
+		sb.WriteByte('W')
+	}
+	if f&elf.SHF_EXECINSTR != 0 {
+		sb.WriteByte('X')
+	}
+	return sb.String()
+}
+
+func elfProgFlags(f elf.ProgFlag) string {
+	return permString(f&elf.PF_R != 0, f&elf.PF_W != 0, f&elf.PF_X != 0)
+}
+
+func peSectionFlags(c uint32) string {
+	return permString(
+		c&pe.IMAGE_SCN_MEM_READ != 0,
+		c&pe.IMAGE_SCN_MEM_WRITE != 0,
+		c&pe.IMAGE_SCN_MEM_EXECUTE != 0,
+	)
+}
+
+func machoProt(p uint32) string {
+	return permString(p&1 != 0, p&2 != 0, p&4 != 0)
+}
+
+// permString formats read/write/execute permissions as "rwx" with '-' for missing bits.
+func permString(r, w, x bool) string {
+	b := []byte("---")
+	if r {
+		b[0] = 'r'
+	}
+	if w {
+		b[1] = 'w'
+	}
+	if x {
+		b[2] = 'x'
+	}
+	return string(b)
+}
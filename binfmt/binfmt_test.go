@@ -0,0 +1,255 @@
+package binfmt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"testing"
+)
+
+// minimalELF64 builds an ELF64 little-endian header for an x86-64 executable
+// without sections or program headers.
+func minimalELF64(entry uint64) []byte {
+	buf := make([]byte, 64)
+	copy(buf, []byte{0x7f, 'E', 'L', 'F', 2, 1, 1})
+	binary.LittleEndian.PutUint16(buf[16:], 2)    // e_type: ET_EXEC
+	binary.LittleEndian.PutUint16(buf[18:], 0x3e) // e_machine: EM_X86_64
+	binary.LittleEndian.PutUint32(buf[20:], 1)    // e_version
+	binary.LittleEndian.PutUint64(buf[24:], entry)
+	binary.LittleEndian.PutUint16(buf[52:], 64) // e_ehsize
+	return buf
+}
+
+// fatMachO builds a universal binary holding one x86-64 executable at
+// offset 0x1000 with a __TEXT segment and a __text section at 0x100 of its
+// slice.
+func fatMachO() []byte {
+	le := binary.LittleEndian
+	thin := make([]byte, 0x200)
+	le.PutUint32(thin[0:], 0xfeedfacf) // magic
+	le.PutUint32(thin[4:], 0x01000007) // cputype: x86-64
+	le.PutUint32(thin[8:], 3)          // cpusubtype
+	le.PutUint32(thin[12:], 2)         // filetype: MH_EXECUTE
+	le.PutUint32(thin[16:], 1)         // ncmds
+	le.PutUint32(thin[20:], 72+80)     // sizeofcmds
+
+	seg := thin[32:]
+	le.PutUint32(seg[0:], 0x19) // LC_SEGMENT_64
+	le.PutUint32(seg[4:], 72+80)
+	copy(seg[8:], "__TEXT")
+	le.PutUint64(seg[24:], 0x100000000) // vmaddr
+	le.PutUint64(seg[32:], 0x1000)      // vmsize
+	le.PutUint64(seg[40:], 0)           // fileoff
+	le.PutUint64(seg[48:], 0x200)       // filesize
+	le.PutUint32(seg[56:], 5)           // maxprot
+	le.PutUint32(seg[60:], 5)           // initprot
+	le.PutUint32(seg[64:], 1)           // nsects
+
+	sect := seg[72:]
+	copy(sect[0:], "__text")
+	copy(sect[16:], "__TEXT")
+	le.PutUint64(sect[32:], 0x100000100) // addr
+	le.PutUint64(sect[40:], 0x10)        // size
+	le.PutUint32(sect[48:], 0x100)       // offset
+
+	fat := make([]byte, 0x1000, 0x1000+len(thin))
+	be := binary.BigEndian
+	be.PutUint32(fat[0:], 0xcafebabe)
+	be.PutUint32(fat[4:], 1)          // nfat_arch
+	be.PutUint32(fat[8:], 0x01000007) // cputype
+	be.PutUint32(fat[12:], 3)         // cpusubtype
+	be.PutUint32(fat[16:], 0x1000)    // offset
+	be.PutUint32(fat[20:], uint32(len(thin)))
+	be.PutUint32(fat[24:], 12) // align
+	return append(fat, thin...)
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  []byte
+		want    Format
+		wantErr bool
+	}{
+		{"elf", []byte{0x7f, 'E', 'L', 'F'}, FormatELF, false},
+		{"pe", []byte{'M', 'Z', 0x90, 0x00}, FormatPE, false},
+		{"macho 64 LE", []byte{0xcf, 0xfa, 0xed, 0xfe}, FormatMachO, false},
+		{"macho fat", []byte{0xca, 0xfe, 0xba, 0xbe}, FormatMachO, false},
+		{"unknown", []byte{0x00, 0x01, 0x02, 0x03}, "", true},
+		{"too short", []byte{0x7f}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Detect(tt.header)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Detect() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Detect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_MinimalELF(t *testing.T) {
+	info, err := Parse(bytes.NewReader(minimalELF64(0x401000)))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if info.Format != FormatELF {
+		t.Errorf("Format = %v, want ELF", info.Format)
+	}
+	if info.Arch != "x86-64" || info.Bits != 64 || info.Endian != "little" {
+		t.Errorf("unexpected header summary: %+v", info)
+	}
+	if info.Entry != 0x401000 {
+		t.Errorf("Entry = %#x, want 0x401000", info.Entry)
+	}
+	if info.Type != "EXEC" {
+		t.Errorf("Type = %q, want EXEC", info.Type)
+	}
+}
+
+func TestParse_FatMachO(t *testing.T) {
+	info, err := Parse(bytes.NewReader(fatMachO()))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if info.Format != FormatMachO || len(info.FatArches) != 1 || info.Arch != "x86-64" {
+		t.Errorf("unexpected header summary: %+v", info)
+	}
+	if len(info.Segments) != 1 || info.Segments[0].Offset != 0x1000 {
+		t.Errorf("Segments = %+v, want __TEXT at file offset 0x1000", info.Segments)
+	}
+	if len(info.Sections) != 1 || info.Sections[0].Name != "__TEXT,__text" || info.Sections[0].Offset != 0x1100 {
+		t.Errorf("Sections = %+v, want __TEXT,__text at file offset 0x1100", info.Sections)
+	}
+}
+
+func TestParse_OwnExecutable(t *testing.T) {
+	path, err := os.Executable()
+	if err != nil {
+		t.Skipf("cannot locate test binary: %v", err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Skipf("cannot open test binary: %v", err)
+	}
+	defer f.Close()
+
+	info, err := Parse(f)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(info.Sections) == 0 {
+		t.Error("expected sections in test binary")
+	}
+	if info.Entry == 0 {
+		t.Error("expected non-zero entry point")
+	}
+}
+
+func TestParse_Unknown(t *testing.T) {
+	_, err := Parse(bytes.NewReader([]byte("not an executable")))
+	if !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("expected ErrUnknownFormat, got %v", err)
+	}
+}
+
+// armELF32 builds an ELF32 little-endian ARM executable with a .text and a
+// .bss section and a symbol table holding the Thumb function main, the
+// object g_buffer, the undefined function printf and the mapping symbol $t.
+func armELF32() []byte {
+	le := binary.LittleEndian
+	text := make([]byte, 16)
+	shstrtab := []byte("\x00.text\x00.bss\x00.symtab\x00.strtab\x00.shstrtab\x00")
+	strtab := []byte("\x00main\x00g_buffer\x00printf\x00$t\x00")
+
+	sym := func(name, value, size uint32, info byte, shndx uint16) []byte {
+		b := make([]byte, 16)
+		le.PutUint32(b, name)
+		le.PutUint32(b[4:], value)
+		le.PutUint32(b[8:], size)
+		b[12] = info
+		le.PutUint16(b[14:], shndx)
+		return b
+	}
+	symtab := bytes.Join([][]byte{
+		make([]byte, 16),
+		sym(15, 0x08000008, 0, 0x00, 1),    // $t, local NOTYPE
+		sym(1, 0x08000001, 16, 0x12, 1),    // main, global FUNC
+		sym(6, 0x20000000, 0x100, 0x11, 2), // g_buffer, global OBJECT
+		sym(8, 0, 0, 0x12, 0),              // printf, undefined
+	}, nil)
+
+	buf := make([]byte, 52)
+	textOff := len(buf)
+	buf = append(buf, text...)
+	shstrOff := len(buf)
+	buf = append(buf, shstrtab...)
+	strOff := len(buf)
+	buf = append(buf, strtab...)
+	for len(buf)%4 != 0 {
+		buf = append(buf, 0)
+	}
+	symOff := len(buf)
+	buf = append(buf, symtab...)
+	shOff := len(buf)
+
+	section := func(name, typ, flags, addr, off, size, link, info, entsize uint32) {
+		b := make([]byte, 40)
+		for i, v := range []uint32{name, typ, flags, addr, off, size, link, info, 4, entsize} {
+			le.PutUint32(b[4*i:], v)
+		}
+		buf = append(buf, b...)
+	}
+	section(0, 0, 0, 0, 0, 0, 0, 0, 0)
+	section(1, 1, 0x6, 0x08000000, uint32(textOff), 16, 0, 0, 0)           // .text, PROGBITS AX
+	section(7, 8, 0x3, 0x20000000, uint32(symOff), 0x100, 0, 0, 0)         // .bss, NOBITS WA
+	section(12, 2, 0, 0, uint32(symOff), uint32(len(symtab)), 4, 2, 16)    // .symtab
+	section(20, 3, 0, 0, uint32(strOff), uint32(len(strtab)), 0, 0, 0)     // .strtab
+	section(28, 3, 0, 0, uint32(shstrOff), uint32(len(shstrtab)), 0, 0, 0) // .shstrtab
+
+	copy(buf, []byte{0x7f, 'E', 'L', 'F', 1, 1, 1})
+	le.PutUint16(buf[16:], 2)  // e_type: ET_EXEC
+	le.PutUint16(buf[18:], 40) // e_machine: EM_ARM
+	le.PutUint32(buf[20:], 1)  // e_version
+	le.PutUint32(buf[24:], 0x08000001)
+	le.PutUint32(buf[32:], uint32(shOff))
+	le.PutUint16(buf[40:], 52) // e_ehsize
+	le.PutUint16(buf[46:], 40) // e_shentsize
+	le.PutUint16(buf[48:], 6)  // e_shnum
+	le.PutUint16(buf[50:], 5)  // e_shstrndx
+	return buf
+}
+
+func TestELFSymbols(t *testing.T) {
+	syms, err := ELFSymbols(bytes.NewReader(armELF32()))
+	if err != nil {
+		t.Fatalf("ELFSymbols() error = %v", err)
+	}
+	want := []Symbol{
+		{Name: "main", Address: 0x08000000, Size: 16, Type: "FUNC", Section: ".text"},
+		{Name: "g_buffer", Address: 0x20000000, Size: 0x100, Type: "OBJECT", Section: ".bss"},
+	}
+	if len(syms) != len(want) {
+		t.Fatalf("ELFSymbols() = %+v, want %+v", syms, want)
+	}
+	for i := range want {
+		if syms[i] != want[i] {
+			t.Errorf("symbol %d = %+v, want %+v", i, syms[i], want[i])
+		}
+	}
+}
+
+func TestELFSymbols_NoSymbols(t *testing.T) {
+	if _, err := ELFSymbols(bytes.NewReader(minimalELF64(0x401000))); !errors.Is(err, ErrNoSymbols) {
+		t.Errorf("ELFSymbols() error = %v, want ErrNoSymbols", err)
+	}
+	if _, err := ELFSymbols(bytes.NewReader([]byte("not an executable"))); err == nil {
+		t.Error("ELFSymbols() of non-ELF data succeeded")
+	}
+}
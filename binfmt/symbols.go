@@ -0,0 +1,71 @@
+package binfmt
+
+import (
+	"debug/elf"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrNoSymbols indicates an executable without a symbol table, e.g. a stripped one
+var ErrNoSymbols = errors.New("no symbol table")
+
+// Symbol is a function or data object of a symbol table.
+type Symbol struct {
+	Name    string
+	Address uint64
+	Size    uint64
+	Type    string // FUNC or OBJECT
+	Section string // e.g. .text
+}
+
+// ELFSymbols returns the functions and data objects of the symbol table of
+// an ELF file, or of its dynamic symbol table if it is stripped. ARM Thumb
+// functions have bit 0 of their address cleared, so the address is the
+// first instruction.
+func ELFSymbols(r io.ReaderAt) ([]Symbol, error) {
+	f, err := elf.NewFile(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ELF file: %w", err)
+	}
+	defer f.Close()
+
+	syms, err := f.Symbols()
+	if errors.Is(err, elf.ErrNoSymbols) {
+		syms, err = f.DynamicSymbols()
+	}
+	if errors.Is(err, elf.ErrNoSymbols) {
+		return nil, ErrNoSymbols
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid ELF symbol table: %w", err)
+	}
+
+	var out []Symbol
+	for _, s := range syms {
+		typ := elf.ST_TYPE(s.Info)
+		if typ != elf.STT_FUNC && typ != elf.STT_OBJECT || s.Section == elf.SHN_UNDEF || s.Name == "" {
+			continue
+		}
+		sym := Symbol{
+			Name:    s.Name,
+			Address: s.Value,
+			Size:    s.Size,
+			Type:    "OBJECT",
+		}
+		if typ == elf.STT_FUNC {
+			sym.Type = "FUNC"
+			if f.Machine == elf.EM_ARM {
+				sym.Address &^= 1
+			}
+		}
+		if i := int(s.Section); s.Section < elf.SHN_LORESERVE && i < len(f.Sections) {
+			sym.Section = f.Sections[i].Name
+		}
+		out = append(out, sym)
+	}
+	if len(out) == 0 {
+		return nil, ErrNoSymbols
+	}
+	return out, nil
+}
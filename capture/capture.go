@@ -0,0 +1,394 @@
+// Package capture records byte streams from live connections as timestamped chunks.
+//
+// A Session reads from any io.ReadWriteCloser (serial port, socket, pipe) in a
+// background goroutine. Every successful read becomes a Chunk carrying its
+// receive time and its offset in the received stream, so ranges spanning
+// several reads can be extracted later. Data written through the session is
+// logged as transmit chunks in the same timeline, and Transact sends a frame
+// and collects the response. Memory is bounded: once the retained data exceeds
+// the configured limit, the oldest chunks are discarded.
+//
+// Example usage:
+//
+//	port, _ := capture.OpenSerial(capture.SerialConfig{Port: "/dev/ttyUSB0", BaudRate: 115200})
+//	sess := capture.Start(port, capture.Options{
+//		OnChunk: func(c capture.Chunk) { fmt.Printf("%s % x\n", c.Time.Format(time.StampMilli), c.Data) },
+//	})
+//	defer sess.Stop()
+package capture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// MaxResponseSize is the number of bytes after which Transact ends a
+// response that never pauses for idle.
+const MaxResponseSize = 64 << 10
+
+// ResponseTimeouts is the number of timeouts after sending after which
+// Transact ends a response that is still arriving.
+const ResponseTimeouts = 4
+
+// DefaultMaxBytes is the default amount of received data retained per session.
+const DefaultMaxBytes = 16 << 20
+
+// defaultReadSize is the default buffer size for a single read.
+const defaultReadSize = 4096
+
+// ErrOutOfRange indicates a requested range is not (or no longer) retained
+var ErrOutOfRange = errors.New("range not available")
+
+// Direction tells whether a chunk was received or transmitted.
+type Direction string
+
+// Chunk directions
+const (
+	RX Direction = "rx"
+	TX Direction = "tx"
+)
+
+// Chunk is the data of a single read from or write to the connection.
+type Chunk struct {
+	Seq       int       // sequence number, starting at 0
+	Time      time.Time // time the read or write completed
+	Direction Direction
+	Offset    int64 // offset of the first byte in the received or transmitted stream
+	Data      []byte
+}
+
+// Response is the result of a Transact call.
+type Response struct {
+	Sent     Chunk
+	Data     []byte        // bytes received after sending
+	Offset   int64         // offset of the first response byte in the received stream
+	Latency  time.Duration // time from sending to the first response byte
+	TimedOut bool          // no response arrived within the timeout
+}
+
+// Options configures a capture session.
+type Options struct {
+	MaxBytes int         // retained data limit; DefaultMaxBytes if zero
+	ReadSize int         // buffer size per read; 4096 if zero
+	OnChunk  func(Chunk) // called from the reader goroutine for every chunk
+	OnStop   func(error) // called once when reading ends; nil error on Stop or EOF
+}
+
+// Session records data read from a connection.
+type Session struct {
+	conn io.ReadWriteCloser
+	opts Options
+	done chan struct{}
+
+	txMu sync.Mutex // serializes writes and transactions
+
+	mu       sync.RWMutex
+	chunks   []Chunk       // retained chunks, oldest first
+	retained int           // bytes held by chunks
+	total    int64         // bytes received since start
+	sent     int64         // bytes transmitted since start
+	received chan struct{} // closed and replaced whenever data arrives
+	nextSeq  int
+	stopped  bool // reading has ended or Stop was called
+	closed   bool // conn has been closed
+	err      error
+	started  time.Time
+}
+
+// Start begins capturing from conn in a background goroutine.
+func Start(conn io.ReadWriteCloser, opts Options) *Session {
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = DefaultMaxBytes
+	}
+	if opts.ReadSize <= 0 {
+		opts.ReadSize = defaultReadSize
+	}
+
+	s := &Session{
+		conn:     conn,
+		opts:     opts,
+		done:     make(chan struct{}),
+		received: make(chan struct{}),
+		started:  time.Now(),
+	}
+	go s.read()
+	return s
+}
+
+// read is the reader goroutine.
+func (s *Session) read() {
+	defer close(s.done)
+
+	buf := make([]byte, s.opts.ReadSize)
+	for {
+		n, err := s.conn.Read(buf)
+		if n > 0 {
+			c := s.append(RX, buf[:n])
+			if s.opts.OnChunk != nil {
+				s.opts.OnChunk(c)
+			}
+		}
+		if err != nil {
+			s.mu.Lock()
+			if !s.stopped && !errors.Is(err, io.EOF) {
+				s.err = err
+			}
+			s.stopped = true
+			readErr := s.err
+			s.mu.Unlock()
+
+			if s.opts.OnStop != nil {
+				s.opts.OnStop(readErr)
+			}
+			return
+		}
+	}
+}
+
+// append records data as a new chunk and enforces the retention limit.
+func (s *Session) append(dir Direction, data []byte) Chunk {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := Chunk{
+		Seq:       s.nextSeq,
+		Time:      time.Now(),
+		Direction: dir,
+		Data:      append([]byte(nil), data...),
+	}
+	s.nextSeq++
+	if dir == RX {
+		c.Offset = s.total
+		s.total += int64(len(data))
+		close(s.received)
+		s.received = make(chan struct{})
+	} else {
+		c.Offset = s.sent
+		s.sent += int64(len(data))
+	}
+	s.chunks = append(s.chunks, c)
+	s.retained += len(data)
+
+	drop := 0
+	for s.retained > s.opts.MaxBytes && drop < len(s.chunks)-1 {
+		s.retained -= len(s.chunks[drop].Data)
+		drop++
+	}
+	if drop > 0 {
+		s.chunks = append([]Chunk(nil), s.chunks[drop:]...)
+	}
+	return c
+}
+
+// Write sends data over the connection and logs it as a transmit chunk.
+func (s *Session) Write(data []byte) (int, error) {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	_, n, err := s.write(data)
+	return n, err
+}
+
+// write sends data and logs the written part. The caller must hold txMu.
+func (s *Session) write(data []byte) (Chunk, int, error) {
+	n, err := s.conn.Write(data)
+	var c Chunk
+	if n > 0 {
+		c = s.append(TX, data[:n])
+		if s.opts.OnChunk != nil {
+			s.opts.OnChunk(c)
+		}
+	}
+	return c, n, err
+}
+
+// Transact sends data and collects the bytes received in response. It waits up
+// to timeout for the first response byte; the response then ends once no
+// further data arrives for idle, so a slow device may still be sending when
+// timeout expires. To bound the time other writes are held back, a response
+// ends at the latest ResponseTimeouts × timeout after sending, or once it
+// holds MaxResponseSize bytes, e.g. when the peer streams without pause.
+// With idle == 0 everything received until timeout is collected. Other
+// writes are held back until the transaction completes. Cancelling ctx stops
+// waiting and returns its error.
+func (s *Session) Transact(ctx context.Context, data []byte, timeout, idle time.Duration) (*Response, error) {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	s.mu.RLock()
+	start, received := s.total, s.received
+	s.mu.RUnlock()
+
+	sent, _, err := s.write(data)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	extended := false
+	var idleTimer <-chan time.Time
+
+wait:
+	for {
+		select {
+		case <-received:
+			s.mu.RLock()
+			received = s.received
+			n := s.total - start
+			s.mu.RUnlock()
+			if n >= MaxResponseSize {
+				break wait
+			}
+			if idle > 0 {
+				if !extended {
+					// From the first byte on idle ends the response, within
+					// the hard limit.
+					deadline.Reset(time.Until(sent.Time.Add(ResponseTimeouts * timeout)))
+					extended = true
+				}
+				idleTimer = time.After(idle)
+			}
+		case <-idleTimer:
+			break wait
+		case <-deadline.C:
+			break wait
+		case <-s.done:
+			break wait
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	resp := &Response{Sent: sent, Offset: start}
+	for _, c := range s.chunks {
+		if c.Direction != RX || c.Offset+int64(len(c.Data)) <= start {
+			continue
+		}
+		if len(resp.Data) == 0 {
+			resp.Latency = c.Time.Sub(sent.Time)
+		}
+		lo := max(start-c.Offset, 0)
+		resp.Data = append(resp.Data, c.Data[lo:]...)
+	}
+	resp.TimedOut = len(resp.Data) == 0
+	return resp, nil
+}
+
+// Stop closes the connection and waits for the reader goroutine to exit.
+func (s *Session) Stop() error {
+	s.mu.Lock()
+	s.stopped = true
+	closed := s.closed
+	s.closed = true
+	s.mu.Unlock()
+
+	var err error
+	if !closed {
+		err = s.conn.Close()
+	}
+	<-s.done
+	return err
+}
+
+// Done returns a channel that is closed when reading has ended.
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+// Running reports whether the session is still reading.
+func (s *Session) Running() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return !s.stopped
+}
+
+// Err returns the read error that ended the session, if any.
+func (s *Session) Err() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.err
+}
+
+// Started returns the time the session was started.
+func (s *Session) Started() time.Time {
+	return s.started
+}
+
+// Stats returns the number of bytes received in total, the number of bytes
+// still retained (both directions) and the number of retained chunks.
+func (s *Session) Stats() (total int64, retained int, chunks int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.total, s.retained, len(s.chunks)
+}
+
+// SentBytes returns the number of bytes transmitted since start.
+func (s *Session) SentBytes() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sent
+}
+
+// ChunksSince returns copies of all retained chunks with Seq >= seq.
+func (s *Session) ChunksSince(seq int) []Chunk {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []Chunk
+	for _, c := range s.chunks {
+		if c.Seq >= seq {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Range returns up to length bytes of the received stream starting at offset.
+// The range may span several chunks but must start within retained data.
+func (s *Session) Range(offset int64, length int) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if length < 0 {
+		return nil, fmt.Errorf("invalid length: %d", length)
+	}
+	first := s.total
+	for _, c := range s.chunks {
+		if c.Direction == RX {
+			first = c.Offset
+			break
+		}
+	}
+	if offset < first || offset > s.total {
+		return nil, fmt.Errorf("%w: offset %d (retained %d-%d)", ErrOutOfRange, offset, first, s.total)
+	}
+
+	end := min(offset+int64(length), s.total)
+	out := make([]byte, 0, end-offset)
+	for _, c := range s.chunks {
+		cEnd := c.Offset + int64(len(c.Data))
+		if c.Direction != RX || cEnd <= offset || c.Offset >= end {
+			continue
+		}
+		lo := max(offset, c.Offset) - c.Offset
+		hi := min(end, cEnd) - c.Offset
+		out = append(out, c.Data[lo:hi]...)
+	}
+	return out, nil
+}
+
+// Clear discards all retained chunks. Stream offsets keep counting.
+func (s *Session) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chunks = nil
+	s.retained = 0
+}
@@ -0,0 +1,316 @@
+package capture
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// waitFor polls cond until it is true or the test times out.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSessionCapture(t *testing.T) {
+	local, remote := net.Pipe()
+	defer remote.Close()
+
+	chunks := make(chan Chunk, 8)
+	s := Start(local, Options{OnChunk: func(c Chunk) { chunks <- c }})
+
+	for _, msg := range []string{"\x01\x02\x03", "\x04\x05"} {
+		if _, err := remote.Write([]byte(msg)); err != nil {
+			t.Fatal(err)
+		}
+		c := <-chunks
+		if string(c.Data) != msg {
+			t.Errorf("chunk data = %x, want %x", c.Data, msg)
+		}
+	}
+
+	total, retained, n := s.Stats()
+	if total != 5 || retained != 5 || n != 2 {
+		t.Errorf("Stats() = %d, %d, %d; want 5, 5, 2", total, retained, n)
+	}
+
+	got, err := s.Range(2, 2)
+	if err != nil {
+		t.Fatalf("Range() error = %v", err)
+	}
+	if !bytes.Equal(got, []byte{0x03, 0x04}) {
+		t.Errorf("Range(2, 2) = %x, want 0304", got)
+	}
+
+	since := s.ChunksSince(1)
+	if len(since) != 1 || since[0].Offset != 3 {
+		t.Errorf("ChunksSince(1) = %+v", since)
+	}
+
+	if err := s.Stop(); err != nil {
+		t.Errorf("Stop() error = %v", err)
+	}
+	if s.Running() || s.Err() != nil {
+		t.Errorf("after Stop: Running() = %v, Err() = %v", s.Running(), s.Err())
+	}
+}
+
+func TestSessionRetentionLimit(t *testing.T) {
+	local, remote := net.Pipe()
+	defer remote.Close()
+
+	s := Start(local, Options{MaxBytes: 4})
+	defer s.Stop()
+
+	for _, msg := range []string{"ab", "cd", "ef"} {
+		remote.Write([]byte(msg))
+	}
+	waitFor(t, func() bool { total, _, _ := s.Stats(); return total == 6 })
+
+	_, retained, n := s.Stats()
+	if retained != 4 || n != 2 {
+		t.Errorf("retained %d bytes in %d chunks, want 4 in 2", retained, n)
+	}
+	if _, err := s.Range(0, 2); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Range(0) error = %v, want ErrOutOfRange", err)
+	}
+	got, _ := s.Range(2, 10)
+	if string(got) != "cdef" {
+		t.Errorf("Range(2, 10) = %q, want cdef", got)
+	}
+
+	s.Clear()
+	if _, err := s.Range(2, 1); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Range after Clear error = %v, want ErrOutOfRange", err)
+	}
+}
+
+// failingConn returns an error on the first read.
+type failingConn struct{ io.ReadWriteCloser }
+
+func (failingConn) Read([]byte) (int, error) { return 0, errors.New("device unplugged") }
+func (failingConn) Close() error             { return nil }
+
+func TestSessionReadError(t *testing.T) {
+	stopped := make(chan error, 1)
+	s := Start(failingConn{}, Options{OnStop: func(err error) { stopped <- err }})
+
+	if err := <-stopped; err == nil || err.Error() != "device unplugged" {
+		t.Errorf("OnStop error = %v", err)
+	}
+	<-s.Done()
+	if s.Running() || s.Err() == nil {
+		t.Error("expected session to end with an error")
+	}
+	if err := s.Stop(); err != nil {
+		t.Errorf("Stop() after read error = %v", err)
+	}
+}
+
+func TestSerialConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     SerialConfig
+		want    string
+		wantErr bool
+	}{
+		{"defaults", SerialConfig{Port: "COM1", BaudRate: 115200}, "115200 8N1", false},
+		{"7E2", SerialConfig{Port: "COM1", BaudRate: 9600, DataBits: 7, Parity: "even", StopBits: "2"}, "9600 7E2", false},
+		{"no port", SerialConfig{BaudRate: 9600}, "", true},
+		{"bad baud", SerialConfig{Port: "COM1"}, "", true},
+		{"bad data bits", SerialConfig{Port: "COM1", BaudRate: 9600, DataBits: 9}, "", true},
+		{"bad parity", SerialConfig{Port: "COM1", BaudRate: 9600, Parity: "weird"}, "", true},
+		{"bad stop bits", SerialConfig{Port: "COM1", BaudRate: 9600, StopBits: "3"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.cfg.mode()
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSerialConfig) {
+					t.Errorf("mode() error = %v, want ErrInvalidSerialConfig", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("mode() error = %v", err)
+			}
+			if got := tt.cfg.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// echoPeer answers every read on conn with reply, split into two writes.
+func echoPeer(conn net.Conn, reply []byte) {
+	buf := make([]byte, 64)
+	for {
+		if _, err := conn.Read(buf); err != nil {
+			return
+		}
+		conn.Write(reply[:1])
+		time.Sleep(5 * time.Millisecond)
+		conn.Write(reply[1:])
+	}
+}
+
+func TestSessionTransact(t *testing.T) {
+	local, remote := net.Pipe()
+	defer remote.Close()
+	go echoPeer(remote, []byte{0x01, 0x03, 0x02})
+
+	s := Start(local, Options{})
+	defer s.Stop()
+
+	resp, err := s.Transact(context.Background(), []byte{0x01, 0x03, 0x00, 0x00}, time.Second, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Transact() error = %v", err)
+	}
+	if !bytes.Equal(resp.Data, []byte{0x01, 0x03, 0x02}) || resp.TimedOut {
+		t.Errorf("response = %x (timed out %v), want 010302", resp.Data, resp.TimedOut)
+	}
+	if resp.Sent.Direction != TX || len(resp.Sent.Data) != 4 || resp.Offset != 0 {
+		t.Errorf("unexpected sent chunk %+v / offset %d", resp.Sent, resp.Offset)
+	}
+
+	// The log holds the TX chunk followed by the RX chunks; Range only covers received data
+	chunks := s.ChunksSince(0)
+	if len(chunks) != 3 || chunks[0].Direction != TX || chunks[1].Direction != RX {
+		t.Errorf("unexpected chunk log: %+v", chunks)
+	}
+	got, _ := s.Range(0, 10)
+	if !bytes.Equal(got, resp.Data) {
+		t.Errorf("Range() = %x, want %x", got, resp.Data)
+	}
+	if s.SentBytes() != 4 {
+		t.Errorf("SentBytes() = %d, want 4", s.SentBytes())
+	}
+
+	// A second transaction only returns its own response
+	resp, err = s.Transact(context.Background(), []byte{0xff}, time.Second, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Transact() error = %v", err)
+	}
+	if resp.Offset != 3 || len(resp.Data) != 3 {
+		t.Errorf("second response = %x at %d", resp.Data, resp.Offset)
+	}
+}
+
+func TestSessionTransactSlowResponse(t *testing.T) {
+	local, remote := net.Pipe()
+	defer remote.Close()
+	frame := []byte{0x01, 0x03, 0x06, 0x00, 0x01, 0x00, 0x02, 0x00, 0x03, 0xaa}
+	go func() {
+		buf := make([]byte, 64)
+		if _, err := remote.Read(buf); err != nil {
+			return
+		}
+		// The response starts shortly before the timeout and is still
+		// arriving when it expires.
+		time.Sleep(150 * time.Millisecond)
+		for _, b := range frame {
+			remote.Write([]byte{b})
+			time.Sleep(30 * time.Millisecond)
+		}
+		io.Copy(io.Discard, remote)
+	}()
+
+	s := Start(local, Options{})
+	defer s.Stop()
+
+	resp, err := s.Transact(context.Background(), []byte{0x01, 0x03}, 200*time.Millisecond, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Transact() error = %v", err)
+	}
+	if !bytes.Equal(resp.Data, frame) || resp.TimedOut {
+		t.Errorf("response = %x (timed out %v), want %x", resp.Data, resp.TimedOut, frame)
+	}
+}
+
+func TestSessionTransactStreamingPeer(t *testing.T) {
+	local, remote := net.Pipe()
+	defer remote.Close()
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		buf := make([]byte, 64)
+		if _, err := remote.Read(buf); err != nil {
+			return
+		}
+		// Telemetry that never pauses for the idle time
+		go io.Copy(io.Discard, remote)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if _, err := remote.Write([]byte{0x55}); err != nil {
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+
+	s := Start(local, Options{})
+	defer s.Stop()
+
+	timeout := 50 * time.Millisecond
+	start := time.Now()
+	resp, err := s.Transact(context.Background(), []byte{0x01}, timeout, 30*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Transact() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > ResponseTimeouts*timeout+time.Second {
+		t.Errorf("Transact() took %v, want at most about %v", elapsed, ResponseTimeouts*timeout)
+	}
+	if len(resp.Data) == 0 || resp.TimedOut {
+		t.Errorf("response = %x (timed out %v), want the streamed bytes", resp.Data, resp.TimedOut)
+	}
+}
+
+func TestSessionTransactTimeout(t *testing.T) {
+	local, remote := net.Pipe()
+	defer remote.Close()
+	go io.Copy(io.Discard, remote)
+
+	s := Start(local, Options{})
+	defer s.Stop()
+
+	resp, err := s.Transact(context.Background(), []byte{0x01}, 20*time.Millisecond, 0)
+	if err != nil {
+		t.Fatalf("Transact() error = %v", err)
+	}
+	if !resp.TimedOut || len(resp.Data) != 0 {
+		t.Errorf("expected timeout, got %+v", resp)
+	}
+}
+
+func TestSessionTransactCancel(t *testing.T) {
+	local, remote := net.Pipe()
+	defer remote.Close()
+	go io.Copy(io.Discard, remote)
+
+	s := Start(local, Options{})
+	defer s.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	start := time.Now()
+	if _, err := s.Transact(ctx, []byte{0x01}, 10*time.Second, 0); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Transact() error = %v, want context.Canceled", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("Transact() did not return after cancel")
+	}
+}
@@ -0,0 +1,193 @@
+package capture
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// ErrNotConnected indicates a write without a connected peer
+var ErrNotConnected = errors.New("no connected peer")
+
+// DialTCP connects to a TCP server as a client.
+func DialTCP(address string, timeout time.Duration) (io.ReadWriteCloser, error) {
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to %s: %w", address, err)
+	}
+	return conn, nil
+}
+
+// TCPServer listens for TCP clients and serves one client at a time, like
+// "nc -lk". Read blocks until a client connects; when the client disconnects
+// the next one is accepted. Writes go to the current client.
+type TCPServer struct {
+	ln net.Listener
+
+	mu     sync.Mutex
+	client net.Conn
+}
+
+// ListenTCP starts a TCP server on address, e.g. ":5020" or "127.0.0.1:0".
+func ListenTCP(address string) (*TCPServer, error) {
+	ln, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("cannot listen on %s: %w", address, err)
+	}
+	return &TCPServer{ln: ln}, nil
+}
+
+// Addr returns the address the server is listening on.
+func (s *TCPServer) Addr() string {
+	return s.ln.Addr().String()
+}
+
+// Peer returns the address of the connected client, or "" if none.
+func (s *TCPServer) Peer() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.client == nil {
+		return ""
+	}
+	return s.client.RemoteAddr().String()
+}
+
+// Read reads from the current client, accepting a new one if necessary.
+func (s *TCPServer) Read(p []byte) (int, error) {
+	for {
+		s.mu.Lock()
+		client := s.client
+		s.mu.Unlock()
+
+		if client == nil {
+			c, err := s.ln.Accept()
+			if err != nil {
+				return 0, err
+			}
+			s.mu.Lock()
+			s.client = c
+			s.mu.Unlock()
+			client = c
+		}
+
+		n, err := client.Read(p)
+		if err != nil {
+			// Client went away; drop it and wait for the next one
+			s.mu.Lock()
+			s.client = nil
+			s.mu.Unlock()
+			client.Close()
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+		return n, nil
+	}
+}
+
+// Write sends data to the current client.
+func (s *TCPServer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	client := s.client
+	s.mu.Unlock()
+
+	if client == nil {
+		return 0, ErrNotConnected
+	}
+	return client.Write(p)
+}
+
+// Close stops listening and disconnects the current client.
+func (s *TCPServer) Close() error {
+	err := s.ln.Close()
+	s.mu.Lock()
+	if s.client != nil {
+		s.client.Close()
+		s.client = nil
+	}
+	s.mu.Unlock()
+	return err
+}
+
+// UDPEndpoint receives UDP datagrams on a local address. Each datagram is
+// returned by a single Read. Writes go to the configured remote address or,
+// if none was given, to the sender of the most recent datagram.
+type UDPEndpoint struct {
+	conn *net.UDPConn
+
+	mu     sync.Mutex
+	remote *net.UDPAddr
+	fixed  bool // remote was configured and is not replaced by incoming senders
+}
+
+// ListenUDP binds a UDP socket to localAddress. remoteAddress is optional.
+func ListenUDP(localAddress, remoteAddress string) (*UDPEndpoint, error) {
+	laddr, err := net.ResolveUDPAddr("udp", localAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid local address %q: %w", localAddress, err)
+	}
+
+	e := &UDPEndpoint{}
+	if remoteAddress != "" {
+		e.remote, err = net.ResolveUDPAddr("udp", remoteAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid remote address %q: %w", remoteAddress, err)
+		}
+		e.fixed = true
+	}
+
+	e.conn, err = net.ListenUDP("udp", laddr)
+	if err != nil {
+		return nil, fmt.Errorf("cannot listen on %s: %w", localAddress, err)
+	}
+	return e, nil
+}
+
+// Addr returns the local address of the socket.
+func (e *UDPEndpoint) Addr() string {
+	return e.conn.LocalAddr().String()
+}
+
+// Peer returns the address writes are sent to, or "" if unknown.
+func (e *UDPEndpoint) Peer() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.remote == nil {
+		return ""
+	}
+	return e.remote.String()
+}
+
+// Read receives one datagram.
+func (e *UDPEndpoint) Read(p []byte) (int, error) {
+	n, addr, err := e.conn.ReadFromUDP(p)
+	if err == nil && addr != nil {
+		e.mu.Lock()
+		if !e.fixed {
+			e.remote = addr
+		}
+		e.mu.Unlock()
+	}
+	return n, err
+}
+
+// Write sends data as one datagram to the current peer.
+func (e *UDPEndpoint) Write(p []byte) (int, error) {
+	e.mu.Lock()
+	remote := e.remote
+	e.mu.Unlock()
+
+	if remote == nil {
+		return 0, ErrNotConnected
+	}
+	return e.conn.WriteToUDP(p, remote)
+}
+
+// Close closes the socket.
+func (e *UDPEndpoint) Close() error {
+	return e.conn.Close()
+}
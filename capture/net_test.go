@@ -0,0 +1,103 @@
+package capture
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestTCPServer(t *testing.T) {
+	srv, err := ListenTCP("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenTCP() error = %v", err)
+	}
+	chunks := make(chan Chunk, 4)
+	s := Start(srv, Options{OnChunk: func(c Chunk) { chunks <- c }})
+	defer s.Stop()
+
+	if _, err := srv.Write([]byte{0x00}); !errors.Is(err, ErrNotConnected) {
+		t.Errorf("Write() without client error = %v, want ErrNotConnected", err)
+	}
+
+	// First client sends data, then disconnects
+	c1, err := net.Dial("tcp", srv.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c1.Write([]byte("one"))
+	if c := <-chunks; string(c.Data) != "one" {
+		t.Errorf("chunk = %q, want one", c.Data)
+	}
+	c1.Close()
+
+	// Second client is accepted and receives replies
+	c2, err := net.Dial("tcp", srv.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c2.Close()
+	c2.Write([]byte("two"))
+	if c := <-chunks; string(c.Data) != "two" || c.Offset != 3 {
+		t.Errorf("chunk = %q at %d, want two at 3", c.Data, c.Offset)
+	}
+
+	if _, err := s.Write([]byte("ack")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	buf := make([]byte, 3)
+	c2.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, err := c2.Read(buf); err != nil || string(buf) != "ack" {
+		t.Errorf("client read %q, %v", buf, err)
+	}
+}
+
+func TestUDPEndpoint(t *testing.T) {
+	e, err := ListenUDP("127.0.0.1:0", "")
+	if err != nil {
+		t.Fatalf("ListenUDP() error = %v", err)
+	}
+	chunks := make(chan Chunk, 4)
+	s := Start(e, Options{OnChunk: func(c Chunk) { chunks <- c }})
+	defer s.Stop()
+
+	if _, err := e.Write([]byte{0x00}); !errors.Is(err, ErrNotConnected) {
+		t.Errorf("Write() without peer error = %v, want ErrNotConnected", err)
+	}
+
+	peer, err := net.Dial("udp", e.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer peer.Close()
+	peer.Write([]byte{0xde, 0xad})
+	if c := <-chunks; len(c.Data) != 2 || c.Data[0] != 0xde {
+		t.Errorf("chunk = %x, want dead", c.Data)
+	}
+	if e.Peer() != peer.LocalAddr().String() {
+		t.Errorf("Peer() = %q, want %q", e.Peer(), peer.LocalAddr())
+	}
+
+	// Replies go back to the last sender
+	if _, err := s.Write([]byte{0xbe, 0xef}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	buf := make([]byte, 16)
+	peer.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, err := peer.Read(buf)
+	if err != nil || n != 2 || buf[0] != 0xbe {
+		t.Errorf("peer read %x, %v", buf[:n], err)
+	}
+}
+
+func TestListenErrors(t *testing.T) {
+	if _, err := ListenUDP("127.0.0.1:0", "not an address"); err == nil {
+		t.Error("expected error for invalid remote address")
+	}
+	if _, err := ListenTCP("256.0.0.1:0"); err == nil {
+		t.Error("expected error for invalid listen address")
+	}
+	if _, err := DialTCP("127.0.0.1:1", 100*time.Millisecond); err == nil {
+		t.Error("expected error connecting to a closed port")
+	}
+}
@@ -0,0 +1,102 @@
+package capture
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.bug.st/serial"
+)
+
+// ErrInvalidSerialConfig indicates an unsupported serial port setting
+var ErrInvalidSerialConfig = errors.New("invalid serial configuration")
+
+// SerialConfig describes how to open a serial port.
+type SerialConfig struct {
+	Port     string // e.g. "/dev/ttyUSB0" or "COM3"
+	BaudRate int    // e.g. 9600, 115200
+	DataBits int    // 5-8; 8 if zero
+	Parity   string // none, odd, even, mark, space; none if empty
+	StopBits string // 1, 1.5, 2; 1 if empty
+}
+
+// String formats the line settings in the usual "115200 8N1" notation.
+func (c SerialConfig) String() string {
+	parity := "N"
+	if c.Parity != "" {
+		parity = strings.ToUpper(c.Parity[:1])
+	}
+	dataBits := c.DataBits
+	if dataBits == 0 {
+		dataBits = 8
+	}
+	stopBits := c.StopBits
+	if stopBits == "" {
+		stopBits = "1"
+	}
+	return fmt.Sprintf("%d %d%s%s", c.BaudRate, dataBits, parity, stopBits)
+}
+
+// mode validates the configuration and converts it to a serial.Mode.
+func (c SerialConfig) mode() (*serial.Mode, error) {
+	if c.Port == "" {
+		return nil, fmt.Errorf("%w: no port given", ErrInvalidSerialConfig)
+	}
+	if c.BaudRate <= 0 {
+		return nil, fmt.Errorf("%w: baud rate %d", ErrInvalidSerialConfig, c.BaudRate)
+	}
+
+	m := &serial.Mode{BaudRate: c.BaudRate, DataBits: c.DataBits}
+	if m.DataBits == 0 {
+		m.DataBits = 8
+	}
+	if m.DataBits < 5 || m.DataBits > 8 {
+		return nil, fmt.Errorf("%w: data bits %d", ErrInvalidSerialConfig, c.DataBits)
+	}
+
+	switch strings.ToLower(c.Parity) {
+	case "", "none", "n":
+		m.Parity = serial.NoParity
+	case "odd", "o":
+		m.Parity = serial.OddParity
+	case "even", "e":
+		m.Parity = serial.EvenParity
+	case "mark", "m":
+		m.Parity = serial.MarkParity
+	case "space", "s":
+		m.Parity = serial.SpaceParity
+	default:
+		return nil, fmt.Errorf("%w: parity %q", ErrInvalidSerialConfig, c.Parity)
+	}
+
+	switch c.StopBits {
+	case "", "1":
+		m.StopBits = serial.OneStopBit
+	case "1.5":
+		m.StopBits = serial.OnePointFiveStopBits
+	case "2":
+		m.StopBits = serial.TwoStopBits
+	default:
+		return nil, fmt.Errorf("%w: stop bits %q", ErrInvalidSerialConfig, c.StopBits)
+	}
+	return m, nil
+}
+
+// SerialPorts returns the names of the serial ports available on the system.
+func SerialPorts() ([]string, error) {
+	return serial.GetPortsList()
+}
+
+// OpenSerial opens a serial port with the given configuration.
+func OpenSerial(cfg SerialConfig) (io.ReadWriteCloser, error) {
+	m, err := cfg.mode()
+	if err != nil {
+		return nil, err
+	}
+	port, err := serial.Open(cfg.Port, m)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open %s: %w", cfg.Port, err)
+	}
+	return port, nil
+}
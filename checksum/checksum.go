@@ -0,0 +1,166 @@
+// Package checksum computes CRCs and simple checksums used by serial protocols,
+// file formats and embedded firmware.
+//
+// CRCs are described by the Rocksoft parameter model (width, polynomial, initial
+// value, input/output reflection, final XOR), so any CRC catalogue entry can be
+// expressed as a CRC value. The most common variants are predefined.
+//
+// Example usage:
+//
+//	frame := []byte{0x01, 0x03, 0x00, 0x00, 0x00, 0x0a}
+//	crc := checksum.CRC16Modbus.Checksum(frame) // 0xcdc5, sent as c5 cd
+//	fmt.Printf("%04x\n", crc)
+package checksum
+
+import (
+	"hash/adler32"
+)
+
+// CRC describes a CRC algorithm in the Rocksoft parameter model.
+type CRC struct {
+	Name   string
+	Width  int // 8 to 64 bits
+	Poly   uint64
+	Init   uint64
+	RefIn  bool
+	RefOut bool
+	XorOut uint64
+	Check  uint64 // checksum of the ASCII string "123456789"
+}
+
+// Predefined CRC algorithms
+var (
+	CRC8            = CRC{Name: "CRC-8", Width: 8, Poly: 0x07, Check: 0xf4}
+	CRC8Maxim       = CRC{Name: "CRC-8/MAXIM", Width: 8, Poly: 0x31, RefIn: true, RefOut: true, Check: 0xa1}
+	CRC16Modbus     = CRC{Name: "CRC-16/MODBUS", Width: 16, Poly: 0x8005, Init: 0xffff, RefIn: true, RefOut: true, Check: 0x4b37}
+	CRC16CCITTFalse = CRC{Name: "CRC-16/CCITT-FALSE", Width: 16, Poly: 0x1021, Init: 0xffff, Check: 0x29b1}
+	CRC16XModem     = CRC{Name: "CRC-16/XMODEM", Width: 16, Poly: 0x1021, Check: 0x31c3}
+	CRC16Kermit     = CRC{Name: "CRC-16/KERMIT", Width: 16, Poly: 0x1021, RefIn: true, RefOut: true, Check: 0x2189}
+	CRC32           = CRC{Name: "CRC-32", Width: 32, Poly: 0x04c11db7, Init: 0xffffffff, RefIn: true, RefOut: true, XorOut: 0xffffffff, Check: 0xcbf43926}
+	CRC32C          = CRC{Name: "CRC-32C", Width: 32, Poly: 0x1edc6f41, Init: 0xffffffff, RefIn: true, RefOut: true, XorOut: 0xffffffff, Check: 0xe3069283}
+	CRC32MPEG2      = CRC{Name: "CRC-32/MPEG-2", Width: 32, Poly: 0x04c11db7, Init: 0xffffffff, Check: 0x0376e6e7}
+)
+
+// CRCs returns all predefined CRC algorithms.
+func CRCs() []CRC {
+	return []CRC{CRC8, CRC8Maxim, CRC16Modbus, CRC16CCITTFalse, CRC16XModem, CRC16Kermit, CRC32, CRC32C, CRC32MPEG2}
+}
+
+// Checksum computes the CRC of data.
+func (c CRC) Checksum(data []byte) uint64 {
+	mask := widthMask(c.Width)
+	table := c.table()
+
+	var crc uint64
+	if c.RefIn {
+		crc = reflect(c.Init, c.Width)
+		for _, b := range data {
+			crc = table[byte(crc)^b] ^ (crc >> 8)
+		}
+	} else {
+		shift := c.Width - 8
+		crc = c.Init
+		for _, b := range data {
+			crc = (table[byte(crc>>shift)^b] ^ (crc << 8)) & mask
+		}
+	}
+
+	if c.RefIn != c.RefOut {
+		crc = reflect(crc, c.Width)
+	}
+	return (crc ^ c.XorOut) & mask
+}
+
+// table builds the byte-wise lookup table for the CRC.
+func (c CRC) table() *[256]uint64 {
+	var t [256]uint64
+	mask := widthMask(c.Width)
+
+	if c.RefIn {
+		poly := reflect(c.Poly, c.Width)
+		for i := range t {
+			crc := uint64(i)
+			for range 8 {
+				if crc&1 != 0 {
+					crc = (crc >> 1) ^ poly
+				} else {
+					crc >>= 1
+				}
+			}
+			t[i] = crc
+		}
+		return &t
+	}
+
+	top := uint64(1) << (c.Width - 1)
+	for i := range t {
+		crc := uint64(i) << (c.Width - 8)
+		for range 8 {
+			if crc&top != 0 {
+				crc = (crc << 1) ^ c.Poly
+			} else {
+				crc <<= 1
+			}
+		}
+		t[i] = crc & mask
+	}
+	return &t
+}
+
+// widthMask returns a mask with the low width bits set.
+func widthMask(width int) uint64 {
+	if width >= 64 {
+		return ^uint64(0)
+	}
+	return uint64(1)<<width - 1
+}
+
+// reflect mirrors the low width bits of v.
+func reflect(v uint64, width int) uint64 {
+	var r uint64
+	for i := 0; i < width; i++ {
+		if v&(1<<i) != 0 {
+			r |= 1 << (width - 1 - i)
+		}
+	}
+	return r
+}
+
+// Sum8 returns the sum of all bytes modulo 256.
+func Sum8(data []byte) uint8 {
+	var s uint8
+	for _, b := range data {
+		s += b
+	}
+	return s
+}
+
+// XOR8 returns the XOR of all bytes (block check character).
+func XOR8(data []byte) uint8 {
+	var x uint8
+	for _, b := range data {
+		x ^= b
+	}
+	return x
+}
+
+// LRC returns the longitudinal redundancy check used by Modbus ASCII:
+// the two's complement of the 8-bit sum.
+func LRC(data []byte) uint8 {
+	return -Sum8(data)
+}
+
+// Fletcher16 returns the Fletcher-16 checksum.
+func Fletcher16(data []byte) uint16 {
+	var a, b uint16
+	for _, v := range data {
+		a = (a + uint16(v)) % 255
+		b = (b + a) % 255
+	}
+	return b<<8 | a
+}
+
+// Adler32 returns the Adler-32 checksum used by zlib.
+func Adler32(data []byte) uint32 {
+	return adler32.Checksum(data)
+}
@@ -0,0 +1,53 @@
+package checksum
+
+import (
+	"testing"
+)
+
+var checkInput = []byte("123456789")
+
+func TestCRCCheckValues(t *testing.T) {
+	for _, c := range CRCs() {
+		t.Run(c.Name, func(t *testing.T) {
+			if got := c.Checksum(checkInput); got != c.Check {
+				t.Errorf("Checksum(\"123456789\") = %#x, want %#x", got, c.Check)
+			}
+		})
+	}
+}
+
+func TestCRC16ModbusFrame(t *testing.T) {
+	// Read holding registers request from the Modbus specification examples
+	frame := []byte{0x01, 0x03, 0x00, 0x00, 0x00, 0x0a}
+	if got := CRC16Modbus.Checksum(frame); got != 0xcdc5 {
+		t.Errorf("CRC16Modbus = %#04x, want 0xcdc5", got)
+	}
+}
+
+func TestCRCEmptyInput(t *testing.T) {
+	if got := CRC32.Checksum(nil); got != 0 {
+		t.Errorf("CRC32(nil) = %#x, want 0", got)
+	}
+	if got := CRC16Modbus.Checksum(nil); got != 0xffff {
+		t.Errorf("CRC16Modbus(nil) = %#x, want 0xffff", got)
+	}
+}
+
+func TestSimpleChecksums(t *testing.T) {
+	tests := []struct {
+		name string
+		got  uint64
+		want uint64
+	}{
+		{"Sum8", uint64(Sum8(checkInput)), 0xdd},
+		{"XOR8", uint64(XOR8(checkInput)), 0x31},
+		{"LRC", uint64(LRC(checkInput)), 0x23},
+		{"Fletcher16", uint64(Fletcher16([]byte("abcde"))), 0xc8f0},
+		{"Adler32", uint64(Adler32([]byte("Wikipedia"))), 0x11e60398},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %#x, want %#x", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+// Package cli implements hexview's command-line interface, which runs the
+// conversion engine without starting the Wails GUI.
+//
+// Subcommands reuse the service layer, so every conversion is scriptable:
+//
+//	hexview convert 0x41424344
+//	hexview convert --from int --type uint16 513
+//	hexview convert --compact 0x0102
+//	hexview modbus "0x4248 0x0000"
+//	hexview modbus --csv --out csv registers.csv
+//	hexview dump firmware.bin
+//	hexview crc --hex "01 03 00 00 00 0a"
+//	hexview diff old.bin new.bin
+//	hexview profile use inverter
+//	hexview serve --addr 127.0.0.1:8787
+//
+// The original headless flags read raw bytes or hex text from stdin:
+//
+//	cat dump.bin | hexview --stdin --dump
+//	echo "41 42 43 44" | hexview --stdin
+package cli
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"hexview/convert"
+	"hexview/service"
+)
+
+// MaxStdinSize is the largest input accepted on stdin or from a file.
+const MaxStdinSize = service.MaxFileSize
+
+// Input modes for the --input flag
+const (
+	InputAuto = "auto" // hex text if the input parses as hex, raw bytes otherwise
+	InputHex  = "hex"
+	InputRaw  = "raw"
+)
+
+// Exit codes
+const (
+	ExitOK    = 0
+	ExitError = 1
+	ExitUsage = 2
+)
+
+// ErrInputTooLarge indicates the input exceeded MaxStdinSize
+var ErrInputTooLarge = errors.New("input too large")
+
+// env bundles the standard streams of a CLI invocation.
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// Headless reports whether the command-line arguments request the CLI
+// instead of the GUI.
+func Headless(args []string) bool {
+	if len(args) > 0 {
+		if _, ok := findCommand(args[0]); ok || args[0] == "help" {
+			return true
+		}
+	}
+	for _, a := range args {
+		if a == "--stdin" || a == "-stdin" || strings.HasPrefix(a, "--stdin=") {
+			return true
+		}
+	}
+	return false
+}
+
+// Run executes the CLI with the given arguments (without the program name)
+// and returns the process exit code.
+func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	e := &env{stdin: stdin, stdout: stdout, stderr: stderr}
+
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		if args[0] == "help" {
+			printUsage(stdout)
+			return ExitOK
+		}
+		cmd, ok := findCommand(args[0])
+		if !ok {
+			fmt.Fprintf(stderr, "hexview: unknown command %q\n\n", args[0])
+			printUsage(stderr)
+			return ExitUsage
+		}
+		return cmd.run(args[1:], e)
+	}
+	return runStdin(args, e)
+}
+
+// runStdin implements the --stdin headless mode.
+func runStdin(args []string, e *env) int {
+	fs := flag.NewFlagSet("hexview", flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	useStdin := fs.Bool("stdin", false, "read input from stdin")
+	dump := fs.Bool("dump", false, "print a hex dump instead of conversions")
+	input := fs.String("input", InputAuto, "input format: auto, hex or raw")
+	fs.Usage = func() {
+		fmt.Fprintln(e.stderr, "Usage: hexview --stdin [--dump] [--input auto|hex|raw]")
+		fs.PrintDefaults()
+		fmt.Fprintln(e.stderr)
+		printUsage(e.stderr)
+	}
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if !*useStdin {
+		fs.Usage()
+		return ExitUsage
+	}
+
+	data, err := readInput(e.stdin, *input)
+	if err != nil {
+		return fail(e, err)
+	}
+
+	if *dump {
+		_, err = io.WriteString(e.stdout, hex.Dump(data))
+	} else {
+		// Like the app, convert only a preview of inputs above the input
+		// size limit, so large files do not produce gigabytes of JSON.
+		settings := service.DefaultSettings()
+		settings.TruncateInput = true
+		var result any
+		result, err = service.NewConverter().ConvertBytesLimited(data, settings)
+		if err == nil {
+			err = writeJSON(e.stdout, result)
+		}
+	}
+	if err != nil {
+		return fail(e, err)
+	}
+	return ExitOK
+}
+
+// readSource reads bytes from a file path, or from stdin if path is "" or "-".
+// An empty mode defaults to auto for stdin and raw for files.
+func readSource(path, mode string, e *env) ([]byte, error) {
+	if path == "" || path == "-" {
+		if mode == "" {
+			mode = InputAuto
+		}
+		return readInput(e.stdin, mode)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if mode == "" {
+		mode = InputRaw
+	}
+	return readInput(f, mode)
+}
+
+// readInput reads all of r and decodes it according to mode.
+func readInput(r io.Reader, mode string) ([]byte, error) {
+	raw, err := io.ReadAll(io.LimitReader(r, MaxStdinSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read input: %w", err)
+	}
+	if len(raw) > MaxStdinSize {
+		return nil, fmt.Errorf("%w: limit is %d bytes", ErrInputTooLarge, MaxStdinSize)
+	}
+
+	switch mode {
+	case InputRaw:
+		return raw, nil
+	case InputHex:
+		data, err := convert.HexToBytes(string(bytes.TrimSpace(raw)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex input: %w", err)
+		}
+		return data, nil
+	case InputAuto:
+		if data, err := convert.HexToBytes(string(bytes.TrimSpace(raw))); err == nil {
+			return data, nil
+		}
+		return raw, nil
+	default:
+		return nil, fmt.Errorf("unknown input format %q (want auto, hex or raw)", mode)
+	}
+}
+
+// readText returns the positional arguments joined by spaces, or the trimmed
+// content of stdin if there are none.
+func readText(args []string, e *env) (string, error) {
+	if len(args) > 0 {
+		return strings.Join(args, " "), nil
+	}
+	raw, err := io.ReadAll(io.LimitReader(e.stdin, MaxStdinSize+1))
+	if err != nil {
+		return "", fmt.Errorf("cannot read input: %w", err)
+	}
+	if len(raw) > MaxStdinSize {
+		return "", fmt.Errorf("%w: limit is %d bytes", ErrInputTooLarge, MaxStdinSize)
+	}
+	return strings.TrimSpace(string(raw)), nil
+}
+
+// writeJSON writes v as indented JSON.
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// fail reports err on stderr and returns ExitError.
+func fail(e *env, err error) int {
+	fmt.Fprintf(e.stderr, "hexview: %v\n", err)
+	return ExitError
+}
+
+// printUsage lists the available subcommands.
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: hexview <command> [flags] [arguments]")
+	fmt.Fprintln(w, "       hexview --stdin [--dump] [--input auto|hex|raw]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands() {
+		fmt.Fprintf(w, "  %-8s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'hexview <command> -h' for command flags. Without arguments the GUI starts.")
+}
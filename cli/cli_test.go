@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestHeadless(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{nil, false},
+		{[]string{"--dump"}, false},
+		{[]string{"--stdin"}, true},
+		{[]string{"--dump", "-stdin"}, true},
+		{[]string{"--stdin=true"}, true},
+		{[]string{"convert", "0x01"}, true},
+		{[]string{"help"}, true},
+		{[]string{"frobnicate"}, false},
+	}
+	for _, tt := range tests {
+		if got := Headless(tt.args); got != tt.want {
+			t.Errorf("Headless(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestRunDump(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"--stdin", "--dump"}, strings.NewReader("ABC\x00"), &stdout, &stderr)
+	if code != ExitOK {
+		t.Fatalf("Run() = %d, stderr %q", code, stderr.String())
+	}
+	want := "00000000  41 42 43 00                                       |ABC.|\n"
+	if stdout.String() != want {
+		t.Errorf("dump = %q, want %q", stdout.String(), want)
+	}
+}
+
+func TestRunConvert(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		stdin     string
+		wantBytes string
+	}{
+		{"auto detects hex text", []string{"--stdin"}, "de ad be ef\n", "deadbeef"},
+		{"auto falls back to raw", []string{"--stdin"}, "AB\x01", "414201"},
+		{"forced raw", []string{"--stdin", "--input", "raw"}, "cafe", "63616665"},
+		{"forced hex", []string{"--stdin", "--input=hex"}, "0x1234", "1234"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := Run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr); code != ExitOK {
+				t.Fatalf("Run() = %d, stderr %q", code, stderr.String())
+			}
+			var result struct {
+				Bytes string `json:"bytes"`
+			}
+			if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+				t.Fatalf("invalid JSON output: %v\n%s", err, stdout.String())
+			}
+			if result.Bytes != tt.wantBytes {
+				t.Errorf("bytes = %q, want %q", result.Bytes, tt.wantBytes)
+			}
+		})
+	}
+}
+
+func TestRunConvertLargeInput(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	input := strings.Repeat("\x01", 1<<20+1)
+	if code := Run([]string{"--stdin", "--input", "raw"}, strings.NewReader(input), &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Run() = %d, stderr %q", code, stderr.String())
+	}
+	var result struct {
+		Bytes       string `json:"bytes"`
+		Truncated   bool   `json:"truncated"`
+		TotalLength int    `json:"totalLength"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	if len(result.Bytes) != 2*4096 || !result.Truncated || result.TotalLength != len(input) {
+		t.Errorf("got %d hex digits, truncated %v, total %d", len(result.Bytes), result.Truncated, result.TotalLength)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		stdin string
+		want  int
+	}{
+		{"missing --stdin", []string{"--dump"}, "", ExitUsage},
+		{"unknown flag", []string{"--stdin", "--bogus"}, "", ExitUsage},
+		{"invalid hex", []string{"--stdin", "--input", "hex"}, "xyz", ExitError},
+		{"unknown input format", []string{"--stdin", "--input", "octal"}, "00", ExitError},
+		{"empty input", []string{"--stdin"}, "", ExitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if got := Run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr); got != tt.want {
+				t.Errorf("Run() = %d, want %d (stderr %q)", got, tt.want, stderr.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,403 @@
+package cli
+
+import (
+	"context"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+
+	"hexview/api"
+	"hexview/models"
+	"hexview/rpc"
+	"hexview/service"
+)
+
+// command is a CLI subcommand.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, e *env) int
+}
+
+// commands returns all subcommands in usage order.
+func commands() []command {
+	return []command{
+		{"convert", "convert a hex, integer, float or binary value", runConvert},
+		{"modbus", "interpret 16-bit Modbus register values", runModbus},
+		{"dump", "print a hex dump of a file or stdin", runDump},
+		{"crc", "compute CRCs and checksums of a file, stdin or hex value", runCRC},
+		{"diff", "compare two files or hex values byte by byte", runDiff},
+		{"profile", "list, show or select conversion profiles", runProfile},
+		{"serve", "run the local REST API server", runServe},
+	}
+}
+
+// findCommand looks up a subcommand by name.
+func findCommand(name string) (command, bool) {
+	for _, c := range commands() {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+// newFlagSet creates the flag set of a subcommand with a usage line.
+func newFlagSet(name, usage string, e *env) *flag.FlagSet {
+	fs := flag.NewFlagSet("hexview "+name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(e.stderr, "Usage: hexview %s %s\n", name, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// runConvert implements "hexview convert".
+func runConvert(args []string, e *env) int {
+	fs := newFlagSet("convert", "[--from hex|int|float|binary|auto] [--type TYPE] [--compact] VALUE", e)
+	from := fs.String("from", "hex", "input kind: hex, int, float, binary or auto")
+	typ := fs.String("type", "", "integer type (int8..uint64, default int32) or float type (float32, float64)")
+	compact := fs.Bool("compact", false, "print only the non-empty values as name → {value, hex}")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+
+	value, err := readText(fs.Args(), e)
+	if err != nil {
+		return fail(e, err)
+	}
+
+	c := service.NewConverter()
+	var result *models.ConversionResult
+	switch *from {
+	case "hex":
+		result, err = c.ConvertHex(value)
+	case "int":
+		result, err = c.ConvertInt(value, service.OrDefault(models.IntType(*typ), models.Int32))
+	case "float":
+		result, err = c.ConvertFloat(value, service.OrDefault(models.FloatType(*typ), models.Float32))
+	case "binary":
+		result, err = c.ConvertBinary(value)
+	case "auto":
+		result, err = c.ConvertIntAuto(value)
+	default:
+		fmt.Fprintf(e.stderr, "hexview: unknown input kind %q\n", *from)
+		return ExitUsage
+	}
+	if err != nil {
+		return fail(e, err)
+	}
+	if *compact {
+		err = writeJSON(e.stdout, service.CompactResult(result))
+	} else {
+		err = writeJSON(e.stdout, result)
+	}
+	if err != nil {
+		return fail(e, err)
+	}
+	return ExitOK
+}
+
+// runModbus implements "hexview modbus".
+func runModbus(args []string, e *env) int {
+	fs := newFlagSet("modbus", "[--profile NAME] [--out json|csv] [--start ADDR] [--base 0|1] [--notation 4x] REGISTERS | --csv [FILE]", e)
+	profileName := fs.String("profile", "", "decode the register map of this profile")
+	csvInput := fs.Bool("csv", false, "read address,value pairs as CSV from FILE or stdin")
+	out := fs.String("out", "json", "output format: json or csv")
+	var addressing models.ModbusAddressing
+	fs.IntVar(&addressing.Start, "start", 0, "protocol address of the first register")
+	fs.IntVar(&addressing.Base, "base", 1, "number of protocol address 0: 0 or 1")
+	fs.StringVar(&addressing.Notation, "notation", "", `register numbers: plain, or "4x" for 40001`)
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if *out != "json" && *out != "csv" {
+		fs.Usage()
+		return ExitUsage
+	}
+
+	var profile *models.Profile
+	if *profileName != "" {
+		profiles, err := loadProfiles()
+		if err != nil {
+			return fail(e, err)
+		}
+		if profile, err = profiles.Get(*profileName); err != nil {
+			return fail(e, err)
+		}
+	}
+
+	c := service.NewConverter()
+	var result *models.ModbusResult
+	if *csvInput {
+		if fs.NArg() > 1 {
+			fs.Usage()
+			return ExitUsage
+		}
+		data, err := readSource(fs.Arg(0), InputRaw, e)
+		if err != nil {
+			return fail(e, err)
+		}
+		if result, err = c.ConvertModbusCSV(string(data)); err != nil {
+			return fail(e, err)
+		}
+	} else {
+		input, err := readText(fs.Args(), e)
+		if err != nil {
+			return fail(e, err)
+		}
+		if result, err = c.ConvertModbusRegisters(input); err != nil {
+			return fail(e, err)
+		}
+		if err := c.ApplyAddressing(result, addressing); err != nil {
+			return fail(e, err)
+		}
+	}
+	c.ApplyProfile(result, profile)
+
+	var err error
+	if *out == "csv" {
+		err = service.WriteModbusCSV(e.stdout, result)
+	} else {
+		err = writeJSON(e.stdout, result)
+	}
+	if err != nil {
+		return fail(e, err)
+	}
+	return ExitOK
+}
+
+// runDump implements "hexview dump".
+func runDump(args []string, e *env) int {
+	fs := newFlagSet("dump", "[--input auto|hex|raw] [FILE]", e)
+	input := fs.String("input", "", "input format: auto, hex or raw (default raw for files, auto for stdin)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return ExitUsage
+	}
+
+	data, err := readSource(fs.Arg(0), *input, e)
+	if err != nil {
+		return fail(e, err)
+	}
+	if _, err := io.WriteString(e.stdout, hex.Dump(data)); err != nil {
+		return fail(e, err)
+	}
+	return ExitOK
+}
+
+// runCRC implements "hexview crc".
+func runCRC(args []string, e *env) int {
+	fs := newFlagSet("crc", "[--json] [--input auto|hex|raw] [--hex VALUE | FILE]", e)
+	hexValue := fs.String("hex", "", "compute checksums of this hex value instead of a file")
+	input := fs.String("input", "", "input format: auto, hex or raw (default raw for files, auto for stdin)")
+	asJSON := fs.Bool("json", false, "print the result as JSON")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if fs.NArg() > 1 || (*hexValue != "" && fs.NArg() > 0) {
+		fs.Usage()
+		return ExitUsage
+	}
+
+	var result *models.ChecksumResult
+	var err error
+	if *hexValue != "" {
+		result, err = service.NewConverter().Checksum(*hexValue)
+	} else {
+		var data []byte
+		data, err = readSource(fs.Arg(0), *input, e)
+		if err == nil {
+			result, err = service.NewConverter().ChecksumBytes(data)
+		}
+	}
+	if err != nil {
+		return fail(e, err)
+	}
+
+	if *asJSON {
+		err = writeJSON(e.stdout, result)
+	} else {
+		tw := tabwriter.NewWriter(e.stdout, 0, 0, 2, ' ', 0)
+		for _, cs := range result.Checksums {
+			fmt.Fprintf(tw, "%s\t0x%s\t%d\n", cs.Name, cs.Hex, cs.Value)
+		}
+		err = tw.Flush()
+	}
+	if err != nil {
+		return fail(e, err)
+	}
+	return ExitOK
+}
+
+// runDiff implements "hexview diff".
+func runDiff(args []string, e *env) int {
+	fs := newFlagSet("diff", "[--hex] [--json] [--exit-code] A B", e)
+	hexArgs := fs.Bool("hex", false, "A and B are hex values instead of file names")
+	asJSON := fs.Bool("json", false, "print the result as JSON")
+	exitCode := fs.Bool("exit-code", false, "exit with status 1 if the inputs differ")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return ExitUsage
+	}
+
+	var result *models.DiffResult
+	var err error
+	if *hexArgs {
+		result, err = service.NewConverter().Diff(fs.Arg(0), fs.Arg(1))
+	} else {
+		var data [2][]byte
+		for i := range data {
+			if data[i], err = readSource(fs.Arg(i), InputRaw, e); err != nil {
+				return fail(e, err)
+			}
+		}
+		result, err = service.NewConverter().DiffBytes(data[0], data[1])
+	}
+	if err != nil {
+		return fail(e, err)
+	}
+
+	if *asJSON {
+		err = writeJSON(e.stdout, result)
+	} else {
+		fmt.Fprintf(e.stdout, "--- %s (%d bytes)\n+++ %s (%d bytes)\n", fs.Arg(0), result.LengthA, fs.Arg(1), result.LengthB)
+		for _, r := range result.Ranges {
+			fmt.Fprintf(e.stdout, "0x%08x  %d bytes  %s -> %s\n", r.Offset, r.Length, orNone(r.A), orNone(r.B))
+		}
+		if result.RangesTrimmed {
+			fmt.Fprintf(e.stdout, "... more than %d ranges, output trimmed\n", service.MaxDiffRanges)
+		}
+		if result.Equal {
+			_, err = fmt.Fprintln(e.stdout, "inputs are identical")
+		} else {
+			_, err = fmt.Fprintf(e.stdout, "%d differing bytes, first at 0x%x\n", result.DiffBytes, result.FirstDiff)
+		}
+	}
+	if err != nil {
+		return fail(e, err)
+	}
+	if *exitCode && !result.Equal {
+		return ExitError
+	}
+	return ExitOK
+}
+
+// orNone returns s, or "(none)" if s is empty.
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+// runProfile implements "hexview profile". Profiles are shared with the GUI.
+func runProfile(args []string, e *env) int {
+	fs := newFlagSet("profile", "[list | show NAME | use NAME | off]", e)
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	action, name := fs.Arg(0), fs.Arg(1)
+	if action == "" {
+		action = "list"
+	}
+	wantArgs := map[string]int{"list": 1, "show": 2, "use": 2, "off": 1}
+	if n, ok := wantArgs[action]; !ok || fs.NArg() > n || (n == 2 && name == "") {
+		fs.Usage()
+		return ExitUsage
+	}
+
+	profiles, err := loadProfiles()
+	if err != nil {
+		return fail(e, err)
+	}
+	switch action {
+	case "list":
+		list := profiles.List()
+		tw := tabwriter.NewWriter(e.stdout, 0, 0, 2, ' ', 0)
+		for _, p := range list.Profiles {
+			marker := " "
+			if p.Name == list.Active {
+				marker = "*"
+			}
+			fmt.Fprintf(tw, "%s %s\t%s\n", marker, p.Name, p.Description)
+		}
+		err = tw.Flush()
+	case "show":
+		var p *models.Profile
+		if p, err = profiles.Get(name); err == nil {
+			err = writeJSON(e.stdout, p)
+		}
+	case "use":
+		_, err = profiles.Activate(name)
+	case "off":
+		_, err = profiles.Activate("")
+	}
+	if err != nil {
+		return fail(e, err)
+	}
+	return ExitOK
+}
+
+// loadProfiles reads the profiles file in the user config dir.
+func loadProfiles() (*service.ProfileService, error) {
+	path, err := service.ConfigPath("profiles.json")
+	if err != nil {
+		return nil, fmt.Errorf("cannot locate profiles: %w", err)
+	}
+	profiles := service.NewProfileService(path)
+	if err := profiles.Load(); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// runServe implements "hexview serve". It runs until interrupted.
+func runServe(args []string, e *env) int {
+	fs := newFlagSet("serve", "[--addr HOST:PORT] [--grpc HOST:PORT]", e)
+	addr := fs.String("addr", api.DefaultAddr, "REST API listen address")
+	grpcAddr := fs.String("grpc", "", "also serve gRPC on this address (e.g. "+rpc.DefaultAddr+")")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	conv := service.NewConverter()
+	if *grpcAddr != "" {
+		ln, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			return fail(e, fmt.Errorf("cannot listen on %s: %w", *grpcAddr, err))
+		}
+		grpcServer := rpc.NewServer(conv)
+		go grpcServer.Serve(ln)
+		defer grpcServer.GracefulStop()
+		fmt.Fprintf(e.stderr, "hexview: gRPC listening on %s\n", ln.Addr())
+	}
+
+	srv := api.NewServer(conv)
+	listening, err := srv.Start(*addr)
+	if err != nil {
+		return fail(e, err)
+	}
+	fmt.Fprintf(e.stderr, "hexview: API listening on http://%s/api/v1/\n", listening)
+
+	<-ctx.Done()
+	if err := srv.Stop(context.Background()); err != nil {
+		return fail(e, err)
+	}
+	return ExitOK
+}
@@ -0,0 +1,239 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"hexview/models"
+	"hexview/service"
+)
+
+// run executes the CLI and returns exit code, stdout and stderr.
+func run(stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := Run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+// writeFile creates a file with data in a temporary directory.
+func writeFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestConvertCommand(t *testing.T) {
+	tests := []struct {
+		name  string
+		stdin string
+		args  []string
+		key   string
+		want  any
+	}{
+		{"hex argument", "", []string{"convert", "0x0102"}, "uint16BE", 258.0},
+		{"hex from stdin", "ff ff\n", []string{"convert"}, "int16BE", -1.0},
+		{"int with type", "", []string{"convert", "--from", "int", "--type", "uint16", "513"}, "bytes", "0201"},
+		{"float default type", "", []string{"convert", "--from", "float", "1.5"}, "float32BEHex", "3fc00000"},
+		{"binary", "", []string{"convert", "--from", "binary", "00000001"}, "bytes", "01"},
+		{"compact", "", []string{"convert", "--compact", "0x0102"}, "bytes", map[string]any{"hex": "0102"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := run(tt.stdin, tt.args...)
+			if code != ExitOK {
+				t.Fatalf("exit %d, stderr %q", code, stderr)
+			}
+			var result map[string]any
+			if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+				t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+			}
+			if !reflect.DeepEqual(result[tt.key], tt.want) {
+				t.Errorf("%s = %v, want %v", tt.key, result[tt.key], tt.want)
+			}
+		})
+	}
+
+	if code, _, _ := run("", "convert", "--from", "octal", "17"); code != ExitUsage {
+		t.Errorf("unknown --from: exit %d, want %d", code, ExitUsage)
+	}
+	if code, _, _ := run("", "convert", "zz"); code != ExitError {
+		t.Errorf("invalid hex: exit %d, want %d", code, ExitError)
+	}
+}
+
+func TestModbusCommand(t *testing.T) {
+	code, stdout, stderr := run("", "modbus", "0x4248", "0x0000")
+	if code != ExitOK {
+		t.Fatalf("exit %d, stderr %q", code, stderr)
+	}
+	if !strings.Contains(stdout, `"float32BE": "50"`) {
+		t.Errorf("expected float32BE 50 in output:\n%s", stdout)
+	}
+
+	code, stdout, _ = run("", "modbus", "--start", "99", "--notation", "4x", "0x4248", "0x0000")
+	if code != ExitOK || !strings.Contains(stdout, `"address": 40101`) {
+		t.Errorf("addressing: exit %d, output %s", code, stdout)
+	}
+	if code, _, _ := run("", "modbus", "--base", "2", "0x0001"); code != ExitError {
+		t.Errorf("invalid base: exit %d, want %d", code, ExitError)
+	}
+}
+
+func TestModbusCSV(t *testing.T) {
+	path := writeFile(t, "registers.csv", []byte("address,value\n40001,16968\n40002,0\n"))
+	code, stdout, stderr := run("", "modbus", "--csv", "--out", "csv", path)
+	if code != ExitOK {
+		t.Fatalf("exit %d, stderr %q", code, stderr)
+	}
+	for _, want := range []string{"40001,1,4248,16968", "40001-40002,42480000"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("missing %q in output:\n%s", want, stdout)
+		}
+	}
+
+	code, stdout, _ = run("40001,16968\n", "modbus", "--csv")
+	if code != ExitOK || !strings.Contains(stdout, `"address": 40001`) {
+		t.Errorf("CSV from stdin: exit %d, output %s", code, stdout)
+	}
+	if code, _, _ := run("", "modbus", "--out", "xml", "0x0001"); code != ExitUsage {
+		t.Errorf("unknown --out: exit %d, want %d", code, ExitUsage)
+	}
+}
+
+func TestProfileCommand(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AppData", dir)
+	path, err := service.ConfigPath("profiles.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	profiles := service.NewProfileService(path)
+	p := models.Profile{Name: "meter", Description: "energy meter", Settings: service.DefaultSettings(),
+		Registers: []models.RegisterMapping{{Register: 1, Name: "voltage", Type: "uint16", Scale: 0.1, Unit: "V"}}}
+	if _, err := profiles.Save(p); err != nil {
+		t.Fatal(err)
+	}
+
+	if code, stdout, stderr := run("", "profile", "use", "meter"); code != ExitOK {
+		t.Fatalf("use: exit %d, stderr %q, stdout %q", code, stderr, stdout)
+	}
+	code, stdout, _ := run("", "profile")
+	if code != ExitOK || !strings.Contains(stdout, "* meter") || !strings.Contains(stdout, "energy meter") {
+		t.Errorf("list: exit %d, output %q", code, stdout)
+	}
+	if code, stdout, _ := run("", "profile", "show", "meter"); code != ExitOK || !strings.Contains(stdout, `"voltage"`) {
+		t.Errorf("show: exit %d, output %q", code, stdout)
+	}
+	if code, _, _ := run("", "profile", "use", "nope"); code != ExitError {
+		t.Errorf("use unknown: exit %d, want %d", code, ExitError)
+	}
+	if code, _, _ := run("", "profile", "rename", "meter"); code != ExitUsage {
+		t.Errorf("unknown action: exit %d, want %d", code, ExitUsage)
+	}
+
+	code, stdout, stderr := run("", "modbus", "--profile", "meter", "d2301")
+	if code != ExitOK {
+		t.Fatalf("modbus: exit %d, stderr %q", code, stderr)
+	}
+	if !strings.Contains(stdout, `"value": "230.1"`) {
+		t.Errorf("expected scaled voltage in output:\n%s", stdout)
+	}
+}
+
+func TestDumpCommand(t *testing.T) {
+	path := writeFile(t, "data.bin", []byte("cafe"))
+
+	// Files are dumped raw even if they look like hex text
+	code, stdout, _ := run("", "dump", path)
+	if code != ExitOK || !strings.HasPrefix(stdout, "00000000  63 61 66 65") {
+		t.Errorf("dump file: exit %d, output %q", code, stdout)
+	}
+
+	code, stdout, _ = run("cafe", "dump")
+	if code != ExitOK || !strings.HasPrefix(stdout, "00000000  ca fe") {
+		t.Errorf("dump stdin: exit %d, output %q", code, stdout)
+	}
+
+	if code, _, _ := run("", "dump", filepath.Join(t.TempDir(), "missing")); code != ExitError {
+		t.Errorf("missing file: exit %d, want %d", code, ExitError)
+	}
+}
+
+func TestCRCCommand(t *testing.T) {
+	code, stdout, stderr := run("", "crc", "--hex", "31 32 33 34 35 36 37 38 39")
+	if code != ExitOK {
+		t.Fatalf("exit %d, stderr %q", code, stderr)
+	}
+	if !strings.Contains(stdout, "CRC-16/MODBUS") || !strings.Contains(stdout, "0x4b37") {
+		t.Errorf("unexpected output:\n%s", stdout)
+	}
+
+	path := writeFile(t, "check.txt", []byte("123456789"))
+	code, stdout, _ = run("", "crc", "--json", path)
+	if code != ExitOK || !strings.Contains(stdout, `"hex": "cbf43926"`) {
+		t.Errorf("crc file: exit %d, output %s", code, stdout)
+	}
+
+	if code, _, _ := run("", "crc", "--hex", "01", path); code != ExitUsage {
+		t.Errorf("--hex with file: exit %d, want %d", code, ExitUsage)
+	}
+}
+
+func TestDiffCommand(t *testing.T) {
+	a := writeFile(t, "a.bin", []byte{0x01, 0x02, 0x03})
+	b := writeFile(t, "b.bin", []byte{0x01, 0xff, 0x03, 0x04})
+
+	code, stdout, _ := run("", "diff", a, b)
+	if code != ExitOK {
+		t.Fatalf("exit %d", code)
+	}
+	for _, want := range []string{"0x00000001  1 bytes  02 -> ff", "0x00000003  1 bytes  (none) -> 04", "2 differing bytes"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("missing %q in output:\n%s", want, stdout)
+		}
+	}
+
+	if code, _, _ := run("", "diff", "--exit-code", a, b); code != ExitError {
+		t.Errorf("--exit-code with differences: exit %d, want %d", code, ExitError)
+	}
+	code, stdout, _ = run("", "diff", "--hex", "--exit-code", "0102", "01 02")
+	if code != ExitOK || !strings.Contains(stdout, "identical") {
+		t.Errorf("equal hex inputs: exit %d, output %q", code, stdout)
+	}
+	if code, _, _ := run("", "diff", a); code != ExitUsage {
+		t.Errorf("single argument: exit %d, want %d", code, ExitUsage)
+	}
+}
+
+func TestUnknownCommand(t *testing.T) {
+	code, _, stderr := run("", "frobnicate")
+	if code != ExitUsage || !strings.Contains(stderr, "unknown command") {
+		t.Errorf("exit %d, stderr %q", code, stderr)
+	}
+	code, stdout, _ := run("", "help")
+	if code != ExitOK || !strings.Contains(stdout, "diff") {
+		t.Errorf("help: exit %d, output %q", code, stdout)
+	}
+}
+
+func TestServeCommandErrors(t *testing.T) {
+	if code, _, stderr := run("", "serve", "--addr", "256.0.0.1:bad"); code != ExitError || !strings.Contains(stderr, "cannot listen") {
+		t.Errorf("invalid address: exit %d, stderr %q", code, stderr)
+	}
+	if code, _, stderr := run("", "serve", "--grpc", "256.0.0.1:bad"); code != ExitError || !strings.Contains(stderr, "cannot listen") {
+		t.Errorf("invalid gRPC address: exit %d, stderr %q", code, stderr)
+	}
+	if code, _, _ := run("", "serve", "--bogus"); code != ExitUsage {
+		t.Errorf("unknown flag: exit %d, want %d", code, ExitUsage)
+	}
+}
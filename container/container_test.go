@@ -0,0 +1,205 @@
+package container
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func buildPNG(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 3, 2))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func buildZIP(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range []string{"a.txt", "b.txt"} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte("hello " + name))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func buildTAR(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	content := []byte("firmware")
+	if err := tw.WriteHeader(&tar.Header{Name: "fw.bin", Mode: 0o644, Size: int64(len(content)), Format: tar.FormatUSTAR}); err != nil {
+		t.Fatal(err)
+	}
+	tw.Write(content)
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func buildWAV() []byte {
+	fmtChunk := make([]byte, 16)
+	binary.LittleEndian.PutUint16(fmtChunk[0:], 1)      // PCM
+	binary.LittleEndian.PutUint16(fmtChunk[2:], 2)      // channels
+	binary.LittleEndian.PutUint32(fmtChunk[4:], 44100)  // sample rate
+	binary.LittleEndian.PutUint32(fmtChunk[8:], 176400) // byte rate
+	binary.LittleEndian.PutUint16(fmtChunk[12:], 4)     // block align
+	binary.LittleEndian.PutUint16(fmtChunk[14:], 16)    // bits per sample
+	samples := []byte{0, 0, 0, 0, 1, 0, 1, 0}
+
+	var body bytes.Buffer
+	body.WriteString("WAVE")
+	body.WriteString("fmt ")
+	binary.Write(&body, binary.LittleEndian, uint32(len(fmtChunk)))
+	body.Write(fmtChunk)
+	body.WriteString("data")
+	binary.Write(&body, binary.LittleEndian, uint32(len(samples)))
+	body.Write(samples)
+
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(body.Len()))
+	buf.Write(body.Bytes())
+	return buf.Bytes()
+}
+
+func chunkNames(l *Layout) []string {
+	names := make([]string, len(l.Chunks))
+	for i, c := range l.Chunks {
+		names[i] = c.Name
+	}
+	return names
+}
+
+func TestParsePNG(t *testing.T) {
+	data := buildPNG(t)
+	layout, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if layout.Format != FormatPNG {
+		t.Fatalf("Format = %v, want PNG", layout.Format)
+	}
+	names := chunkNames(layout)
+	if names[1] != "IHDR" || names[len(names)-1] != "IEND" {
+		t.Errorf("unexpected chunks: %v", names)
+	}
+	if layout.Chunks[1].Details != "3x2, bit depth 8, color type 0" {
+		t.Errorf("IHDR details = %q", layout.Chunks[1].Details)
+	}
+	if len(layout.Warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", layout.Warnings)
+	}
+
+	// Corrupt the IHDR payload to trigger a CRC mismatch
+	data[16] ^= 0xff
+	layout, err = ParsePNG(data)
+	if err != nil {
+		t.Fatalf("ParsePNG() error = %v", err)
+	}
+	if layout.Chunks[1].Valid || len(layout.Warnings) == 0 {
+		t.Error("expected CRC warning for corrupted IHDR")
+	}
+}
+
+func TestParseZIP(t *testing.T) {
+	layout, err := Parse(buildZIP(t))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	counts := map[string]int{}
+	for _, c := range layout.Chunks {
+		counts[c.Name]++
+	}
+	if counts["local file header"] != 2 || counts["central directory header"] != 2 || counts["end of central directory"] != 1 {
+		t.Errorf("unexpected ZIP records: %v (warnings %v)", chunkNames(layout), layout.Warnings)
+	}
+	if !strings.HasPrefix(layout.Chunks[0].Details, "a.txt") {
+		t.Errorf("first entry details = %q", layout.Chunks[0].Details)
+	}
+}
+
+func TestParseTAR(t *testing.T) {
+	data := buildTAR(t)
+	layout, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if layout.Format != FormatTAR {
+		t.Fatalf("Format = %v, want TAR", layout.Format)
+	}
+	hdr := layout.Chunks[0]
+	if hdr.DataSize != 8 || hdr.Size != 1024 || !hdr.Valid {
+		t.Errorf("unexpected header chunk: %+v", hdr)
+	}
+	if hdr.Details != "fw.bin (file, 8 bytes)" {
+		t.Errorf("details = %q", hdr.Details)
+	}
+
+	data[0] = 'X' // breaks the checksum
+	layout, _ = ParseTAR(data)
+	if layout.Chunks[0].Valid {
+		t.Error("expected checksum failure")
+	}
+
+	// A negative size with a valid checksum must not stall or rewind the walk
+	data = buildTAR(t)
+	copy(data[124:136], "-0000001750\x00")
+	copy(data[148:156], fmt.Sprintf("%06o\x00 ", tarChecksum(data[:512])))
+	layout, err = ParseTAR(data)
+	if err != nil {
+		t.Fatalf("ParseTAR() error = %v", err)
+	}
+	if len(layout.Chunks) != 1 || layout.Chunks[0].Valid || layout.Chunks[0].Size != int64(len(data)) {
+		t.Errorf("negative size: chunks %+v, warnings %v", layout.Chunks, layout.Warnings)
+	}
+}
+
+func TestParseRIFF(t *testing.T) {
+	layout, err := Parse(buildWAV())
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	names := chunkNames(layout)
+	if strings.Join(names, ",") != "RIFF,fmt ,data" {
+		t.Errorf("chunks = %v", names)
+	}
+	if layout.Chunks[1].Details != "format 1, 2 ch, 44100 Hz, 16 bit" {
+		t.Errorf("fmt details = %q", layout.Chunks[1].Details)
+	}
+	if layout.Chunks[2].Offset != 36 || layout.Chunks[2].DataSize != 8 {
+		t.Errorf("data chunk = %+v", layout.Chunks[2])
+	}
+
+	truncated := buildWAV()[:40]
+	layout, err = ParseRIFF(truncated)
+	if err != nil {
+		t.Fatalf("ParseRIFF() error = %v", err)
+	}
+	if len(layout.Warnings) == 0 {
+		t.Error("expected warnings for truncated WAV")
+	}
+}
+
+func TestParseUnknown(t *testing.T) {
+	if _, err := Parse([]byte("hello world")); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("expected ErrUnknownFormat, got %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package convert
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// ============================================================================
+// Base64 and Base32
+// ============================================================================
+
+var (
+	// ErrInvalidBase64 indicates input that is not valid base64
+	ErrInvalidBase64 = errors.New("invalid base64")
+
+	// ErrInvalidBase32 indicates input that is not valid base32
+	ErrInvalidBase32 = errors.New("invalid base32")
+)
+
+// Base64ToBytes decodes standard or URL-safe base64, with or without
+// padding. Whitespace, e.g. line breaks of MIME bodies, is ignored.
+func Base64ToBytes(s string) ([]byte, error) {
+	clean, offsets := stripSpace(s)
+	if clean == "" {
+		return nil, NewInputError(CodeEmptyInput, ErrEmptyInput, "empty base64 string")
+	}
+	enc := base64.StdEncoding
+	if strings.ContainsAny(clean, "-_") {
+		enc = base64.URLEncoding
+	}
+	if !strings.HasSuffix(clean, "=") {
+		enc = enc.WithPadding(base64.NoPadding)
+	}
+	data, err := enc.DecodeString(clean)
+	if err != nil {
+		return nil, encodingError(s, offsets, err, ErrInvalidBase64)
+	}
+	return data, nil
+}
+
+// Base32ToBytes decodes RFC 4648 base32 in upper or lower case, with or
+// without padding. Whitespace is ignored.
+func Base32ToBytes(s string) ([]byte, error) {
+	clean, offsets := stripSpace(s)
+	if clean == "" {
+		return nil, NewInputError(CodeEmptyInput, ErrEmptyInput, "empty base32 string")
+	}
+	enc := base32.StdEncoding
+	if !strings.HasSuffix(clean, "=") {
+		enc = enc.WithPadding(base32.NoPadding)
+	}
+	data, err := enc.DecodeString(strings.ToUpper(clean))
+	if err != nil {
+		return nil, encodingError(s, offsets, err, ErrInvalidBase32)
+	}
+	return data, nil
+}
+
+// BytesToBase64 encodes b as standard base64 with padding.
+func BytesToBase64(b []byte) string {
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// BytesToBase64URL encodes b as URL-safe base64 without padding, as used in
+// URLs and JWTs.
+func BytesToBase64URL(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// BytesToBase32 encodes b as standard base32 with padding.
+func BytesToBase32(b []byte) string {
+	return base32.StdEncoding.EncodeToString(b)
+}
+
+// stripSpace removes whitespace from s and returns the byte offset in s of
+// every byte of the result.
+func stripSpace(s string) (string, []int) {
+	var sb strings.Builder
+	offsets := make([]int, 0, len(s))
+	for i, r := range s {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		sb.WriteRune(r)
+		for j := range len(string(r)) {
+			offsets = append(offsets, i+j)
+		}
+	}
+	return sb.String(), offsets
+}
+
+// encodingError returns the InputError for a base64 or base32 decoding
+// error, located at the offending character of the original input.
+func encodingError(input string, offsets []int, err, sentinel error) error {
+	e := NewInputError(CodeInvalidEncoding, sentinel, sentinel.Error()+": "+err.Error())
+	var b64 base64.CorruptInputError
+	var b32 base32.CorruptInputError
+	pos := -1
+	switch {
+	case errors.As(err, &b64):
+		pos = int(b64)
+	case errors.As(err, &b32):
+		pos = int(b32)
+	}
+	switch {
+	case pos < 0:
+		return e
+	case pos >= len(offsets):
+		// Missing characters at the end
+		return e.At(input, len(input), 0)
+	}
+	return e.At(input, offsets[pos], 1)
+}
@@ -0,0 +1,68 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBase64ToBytes(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string // hex
+		wantErr error
+		pos     int
+	}{
+		{"padded", "AQID", "010203", nil, 0},
+		{"padding", "AQI=", "0102", nil, 0},
+		{"unpadded", "AQI", "0102", nil, 0},
+		{"url-safe", "-_8=", "fbff", nil, 0},
+		{"standard alphabet", "+/8", "fbff", nil, 0},
+		{"line breaks", "AQ\r\nID\n", "010203", nil, 0},
+		{"invalid char", "AQ ID*A", "", ErrInvalidBase64, 5},
+		{"mixed alphabets", "+-8=", "", ErrInvalidBase64, 0},
+		{"empty", " \n", "", ErrEmptyInput, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Base64ToBytes(tt.input)
+			if tt.wantErr != nil {
+				var inErr *InputError
+				if !errors.Is(err, tt.wantErr) || !errors.As(err, &inErr) {
+					t.Fatalf("Base64ToBytes(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+				}
+				if inErr.Position != tt.pos {
+					t.Errorf("Position = %d, want %d", inErr.Position, tt.pos)
+				}
+				return
+			}
+			if err != nil || BytesToHex(got) != tt.want {
+				t.Errorf("Base64ToBytes(%q) = %x, %v, want %s", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBase32ToBytes(t *testing.T) {
+	for _, input := range []string{"AEBAG===", "aebag", "AEBA G"} {
+		if got, err := Base32ToBytes(input); err != nil || BytesToHex(got) != "010203" {
+			t.Errorf("Base32ToBytes(%q) = %x, %v, want 010203", input, got, err)
+		}
+	}
+	if _, err := Base32ToBytes("AEB1"); !errors.Is(err, ErrInvalidBase32) {
+		t.Errorf("Base32ToBytes(AEB1) error = %v, want ErrInvalidBase32", err)
+	}
+}
+
+func TestBytesToBase64(t *testing.T) {
+	b := []byte{0xfb, 0xff}
+	if got := BytesToBase64(b); got != "+/8=" {
+		t.Errorf("BytesToBase64() = %s, want +/8=", got)
+	}
+	if got := BytesToBase64URL(b); got != "-_8" {
+		t.Errorf("BytesToBase64URL() = %s, want -_8", got)
+	}
+	if got := BytesToBase32([]byte{1, 2, 3}); got != "AEBAG===" {
+		t.Errorf("BytesToBase32() = %s, want AEBAG===", got)
+	}
+}
@@ -0,0 +1,114 @@
+package convert
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ============================================================================
+// BCD (binary-coded decimal)
+// ============================================================================
+
+// ErrInvalidBCD indicates bytes with a nibble or byte above 9, or decimal
+// input with a character that is not a digit
+var ErrInvalidBCD = errors.New("invalid BCD")
+
+// HexToBCD decodes hex input as packed BCD, two digits per byte with the
+// most significant digit in the high nibble, e.g. "0x1234" → "1234".
+// Leading zeros are kept, as RTC and meter registers have fixed widths.
+func HexToBCD(hexStr string) (string, error) {
+	b, err := ParseHex(hexStr)
+	if err != nil {
+		return "", err
+	}
+	return BytesToBCD(b)
+}
+
+// HexToBCDUnpacked decodes hex input as unpacked BCD, one digit per byte,
+// e.g. "0x010203" → "123" with leading zeros kept.
+func HexToBCDUnpacked(hexStr string) (string, error) {
+	b, err := ParseHex(hexStr)
+	if err != nil {
+		return "", err
+	}
+	return BytesToBCDUnpacked(b)
+}
+
+// BytesToBCD decodes packed BCD bytes to their decimal digits.
+func BytesToBCD(b []byte) (string, error) {
+	if len(b) == 0 {
+		return "", errEmptyInput()
+	}
+	digits := make([]byte, 0, 2*len(b))
+	for i, v := range b {
+		if v>>4 > 9 || v&0x0f > 9 {
+			return "", NewInputError(CodeInvalidNumber, ErrInvalidBCD,
+				fmt.Sprintf("%v: byte %d (0x%02x) has a nibble above 9", ErrInvalidBCD, i, v))
+		}
+		digits = append(digits, '0'+v>>4, '0'+v&0x0f)
+	}
+	return string(digits), nil
+}
+
+// BytesToBCDUnpacked decodes unpacked BCD bytes to their decimal digits.
+func BytesToBCDUnpacked(b []byte) (string, error) {
+	if len(b) == 0 {
+		return "", errEmptyInput()
+	}
+	digits := make([]byte, len(b))
+	for i, v := range b {
+		if v > 9 {
+			return "", NewInputError(CodeInvalidNumber, ErrInvalidBCD,
+				fmt.Sprintf("%v: byte %d (0x%02x) is above 9", ErrInvalidBCD, i, v))
+		}
+		digits[i] = '0' + v
+	}
+	return string(digits), nil
+}
+
+// BCDToHex encodes a decimal number as packed BCD hex, e.g. "1234" →
+// "1234" and "123" → "0123". Spaces and underscores between digits are
+// ignored.
+func BCDToHex(decimal string) (string, error) {
+	digits, err := bcdDigits(decimal)
+	if err != nil {
+		return "", err
+	}
+	if len(digits)%2 != 0 {
+		digits = append([]byte{0}, digits...)
+	}
+	b := make([]byte, len(digits)/2)
+	for i := range b {
+		b[i] = digits[2*i]<<4 | digits[2*i+1]
+	}
+	return BytesToHex(b), nil
+}
+
+// BCDToHexUnpacked encodes a decimal number as unpacked BCD hex, e.g.
+// "123" → "010203".
+func BCDToHexUnpacked(decimal string) (string, error) {
+	digits, err := bcdDigits(decimal)
+	if err != nil {
+		return "", err
+	}
+	return BytesToHex(digits), nil
+}
+
+// bcdDigits returns the values of the decimal digits of s.
+func bcdDigits(s string) ([]byte, error) {
+	digits := make([]byte, 0, len(s))
+	for i, ch := range s {
+		switch {
+		case ch >= '0' && ch <= '9':
+			digits = append(digits, byte(ch-'0'))
+		case ch == ' ' || ch == '_':
+		default:
+			return nil, NewInputError(CodeInvalidNumber, ErrInvalidBCD,
+				fmt.Sprintf("%v: '%c' is not a decimal digit", ErrInvalidBCD, ch)).At(s, i, len(string(ch)))
+		}
+	}
+	if len(digits) == 0 {
+		return nil, errEmptyInput()
+	}
+	return digits, nil
+}
@@ -0,0 +1,103 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHexToBCD(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		packed   string
+		unpacked string
+		wantErr  error // of the packed decoding; unpacked fails for nibbles above 0
+	}{
+		{"rtc seconds", "59", "59", "", nil},
+		{"leading zeros", "0x0012", "0012", "", nil},
+		{"unpacked digits", "01 02 03", "010203", "123", nil},
+		{"nibble above 9", "1a", "", "", ErrInvalidBCD},
+		{"high nibble above 9", "0xa1", "", "", ErrInvalidBCD},
+		{"empty", "", "", "", ErrEmptyInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HexToBCD(tt.input)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("HexToBCD(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.packed {
+				t.Errorf("HexToBCD(%q) = %q, %v, want %q", tt.input, got, err, tt.packed)
+			}
+			got, err = HexToBCDUnpacked(tt.input)
+			if tt.unpacked == "" {
+				if !errors.Is(err, ErrInvalidBCD) {
+					t.Errorf("HexToBCDUnpacked(%q) error = %v, want ErrInvalidBCD", tt.input, err)
+				}
+				return
+			}
+			if err != nil || got != tt.unpacked {
+				t.Errorf("HexToBCDUnpacked(%q) = %q, %v, want %q", tt.input, got, err, tt.unpacked)
+			}
+		})
+	}
+}
+
+func TestBCDToHex(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		packed   string
+		unpacked string
+		wantErr  error
+		pos      int
+	}{
+		{"even digits", "1234", "1234", "01020304", nil, 0},
+		{"odd digits", "123", "0123", "010203", nil, 0},
+		{"leading zeros", "0059", "0059", "00000509", nil, 0},
+		{"separators", "12_34 56", "123456", "010203040506", nil, 0},
+		{"sign", "-12", "", "", ErrInvalidBCD, 0},
+		{"hex digit", "12a", "", "", ErrInvalidBCD, 2},
+		{"empty", " ", "", "", ErrEmptyInput, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BCDToHex(tt.input)
+			if tt.wantErr != nil {
+				var inErr *InputError
+				if !errors.Is(err, tt.wantErr) || !errors.As(err, &inErr) {
+					t.Fatalf("BCDToHex(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+				}
+				if inErr.Position != tt.pos {
+					t.Errorf("Position = %d, want %d", inErr.Position, tt.pos)
+				}
+				return
+			}
+			if err != nil || got != tt.packed {
+				t.Errorf("BCDToHex(%q) = %q, %v, want %q", tt.input, got, err, tt.packed)
+			}
+			if got, err := BCDToHexUnpacked(tt.input); err != nil || got != tt.unpacked {
+				t.Errorf("BCDToHexUnpacked(%q) = %q, %v, want %q", tt.input, got, err, tt.unpacked)
+			}
+
+			// Decoding the encoded digits gives them back with a leading zero for odd counts
+			back, err := HexToBCDUnpacked(tt.unpacked)
+			if err != nil || back != digitsOnly(tt.input) {
+				t.Errorf("HexToBCDUnpacked(%q) = %q, %v", tt.unpacked, back, err)
+			}
+		})
+	}
+}
+
+func digitsOnly(s string) string {
+	out := []byte{}
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			out = append(out, s[i])
+		}
+	}
+	return string(out)
+}
@@ -0,0 +1,43 @@
+package convert
+
+import (
+	"strings"
+	"testing"
+)
+
+var benchInputs = []struct {
+	name  string
+	input string
+}{
+	{"continuous", "42480000"},
+	{"prefixed", "0x4248 0x0000 0x41bc 0x0000"},
+	{"dump", strings.Repeat("de:ad:be:ef:", 64)},
+}
+
+func BenchmarkParseHex(b *testing.B) {
+	for _, in := range benchInputs {
+		b.Run(in.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := ParseHex(in.input); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkHexToFloat32(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		HexToFloat32("0x42480000")
+	}
+}
+
+func BenchmarkBytesToBinary(b *testing.B) {
+	data := []byte{0x42, 0x48, 0x00, 0x00, 0x41, 0xbc, 0x00, 0x00}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		BytesToBinary(data)
+	}
+}
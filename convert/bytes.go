@@ -0,0 +1,261 @@
+package convert
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// ByteOrder selects how the bytes of a multi-byte value are arranged
+type ByteOrder int
+
+// Byte orders, shown for the bytes A B C D of a 32-bit value
+const (
+	BigEndian       ByteOrder = iota // ABCD
+	LittleEndian                     // DCBA
+	MidBigEndian                     // BADC, bytes swapped within 16-bit words
+	MidLittleEndian                  // CDAB, 16-bit words swapped
+)
+
+// String returns the short name of the byte order: BE, LE, BADC or CDAB.
+func (o ByteOrder) String() string {
+	switch o {
+	case BigEndian:
+		return "BE"
+	case LittleEndian:
+		return "LE"
+	case MidBigEndian:
+		return "BADC"
+	case MidLittleEndian:
+		return "CDAB"
+	}
+	return "unknown"
+}
+
+// The BytesTo functions read a value from already parsed bytes, so callers
+// converting one input to many types parse it only once. Like the HexTo
+// functions they accept fewer bytes than the type holds: big-endian and BADC
+// values are padded with leading zeros, little-endian and CDAB values with
+// trailing zeros. More bytes than the type holds are an ErrInvalidLength.
+
+// BytesToInt8 reads an int8 from b.
+func BytesToInt8(b []byte) (int8, error) {
+	return bytesToInt[int8](b, 1, BigEndian)
+}
+
+// BytesToInt16 reads an int16 from b in the given byte order.
+func BytesToInt16(b []byte, order ByteOrder) (int16, error) {
+	return bytesToInt[int16](b, 2, order)
+}
+
+// BytesToInt32 reads an int32 from b in the given byte order.
+func BytesToInt32(b []byte, order ByteOrder) (int32, error) {
+	return bytesToInt[int32](b, 4, order)
+}
+
+// BytesToInt64 reads an int64 from b in the given byte order.
+func BytesToInt64(b []byte, order ByteOrder) (int64, error) {
+	return bytesToInt[int64](b, 8, order)
+}
+
+// BytesToUint8 reads a uint8 from b.
+func BytesToUint8(b []byte) (uint8, error) {
+	return bytesToInt[uint8](b, 1, BigEndian)
+}
+
+// BytesToUint16 reads a uint16 from b in the given byte order.
+func BytesToUint16(b []byte, order ByteOrder) (uint16, error) {
+	return bytesToInt[uint16](b, 2, order)
+}
+
+// BytesToUint32 reads a uint32 from b in the given byte order.
+func BytesToUint32(b []byte, order ByteOrder) (uint32, error) {
+	return bytesToInt[uint32](b, 4, order)
+}
+
+// BytesToUint64 reads a uint64 from b in the given byte order.
+func BytesToUint64(b []byte, order ByteOrder) (uint64, error) {
+	return bytesToInt[uint64](b, 8, order)
+}
+
+// BytesToFloat16 reads an IEEE 754 half-precision float from b in the given
+// byte order.
+func BytesToFloat16(b []byte, order ByteOrder) (float32, error) {
+	bits, err := bytesToInt[uint16](b, 2, order)
+	if err != nil {
+		return 0, err
+	}
+	return Float16frombits(bits), nil
+}
+
+// BytesToFloat32 reads a float32 from b in the given byte order.
+func BytesToFloat32(b []byte, order ByteOrder) (float32, error) {
+	bits, err := bytesToInt[uint32](b, 4, order)
+	if err != nil {
+		return 0, err
+	}
+	return math.Float32frombits(bits), nil
+}
+
+// BytesToFloat64 reads a float64 from b in the given byte order.
+func BytesToFloat64(b []byte, order ByteOrder) (float64, error) {
+	bits, err := bytesToInt[uint64](b, 8, order)
+	if err != nil {
+		return 0, err
+	}
+	return math.Float64frombits(bits), nil
+}
+
+// bytesToInt reads an integer of byteSize bytes from b in the given order
+// without allocating.
+func bytesToInt[T integer](b []byte, byteSize int, order ByteOrder) (T, error) {
+	if len(b) == 0 {
+		return 0, errEmptyInput()
+	}
+	// Reject overflow: input has more bytes than target type can hold
+	if len(b) > byteSize {
+		return 0, errLength(byteSize, len(b))
+	}
+
+	var buf [8]byte
+	v := buf[:byteSize]
+	if order == LittleEndian || order == MidLittleEndian {
+		copy(v, b)
+	} else {
+		copy(v[byteSize-len(b):], b)
+	}
+
+	switch order {
+	case MidBigEndian:
+		swapBADC(v)
+	case MidLittleEndian:
+		swapCDAB(v)
+	}
+
+	// binary.ByteOrder is not used as an interface here so buf stays on the stack
+	if order == LittleEndian {
+		switch byteSize {
+		case 1:
+			return T(v[0]), nil
+		case 2:
+			return T(binary.LittleEndian.Uint16(v)), nil
+		case 4:
+			return T(binary.LittleEndian.Uint32(v)), nil
+		default:
+			return T(binary.LittleEndian.Uint64(v)), nil
+		}
+	}
+	switch byteSize {
+	case 1:
+		return T(v[0]), nil
+	case 2:
+		return T(binary.BigEndian.Uint16(v)), nil
+	case 4:
+		return T(binary.BigEndian.Uint32(v)), nil
+	default:
+		return T(binary.BigEndian.Uint64(v)), nil
+	}
+}
+
+// swapBADC converts b between big-endian and BADC order in place by
+// swapping the bytes within each 16-bit word. 16-bit values are not swapped.
+func swapBADC(b []byte) {
+	if len(b) < 4 {
+		return
+	}
+	for i := 0; i+1 < len(b); i += 2 {
+		b[i], b[i+1] = b[i+1], b[i]
+	}
+}
+
+// swapCDAB converts b between big-endian and CDAB order in place by
+// swapping the 16-bit words within each 32-bit half. 16-bit values are
+// byte-swapped like little-endian.
+func swapCDAB(b []byte) {
+	switch len(b) {
+	case 2:
+		b[0], b[1] = b[1], b[0]
+	case 4, 8:
+		for i := 0; i+3 < len(b); i += 4 {
+			b[i], b[i+1], b[i+2], b[i+3] = b[i+2], b[i+3], b[i], b[i+1]
+		}
+	}
+}
+
+// The ToBytes functions are the inverse of the BytesTo functions: they
+// return the bytes of a value as stored in the given byte order, e.g. the
+// bytes to write so that BytesToFloat32(b, LittleEndian) reads the value.
+
+// Int8ToBytes returns the byte of n.
+func Int8ToBytes(n int8) []byte {
+	return intToBytes(n, 1, BigEndian)
+}
+
+// Int16ToBytes returns the bytes of n in the given byte order.
+func Int16ToBytes(n int16, order ByteOrder) []byte {
+	return intToBytes(n, 2, order)
+}
+
+// Int32ToBytes returns the bytes of n in the given byte order.
+func Int32ToBytes(n int32, order ByteOrder) []byte {
+	return intToBytes(n, 4, order)
+}
+
+// Int64ToBytes returns the bytes of n in the given byte order.
+func Int64ToBytes(n int64, order ByteOrder) []byte {
+	return intToBytes(n, 8, order)
+}
+
+// Uint8ToBytes returns the byte of n.
+func Uint8ToBytes(n uint8) []byte {
+	return intToBytes(n, 1, BigEndian)
+}
+
+// Uint16ToBytes returns the bytes of n in the given byte order.
+func Uint16ToBytes(n uint16, order ByteOrder) []byte {
+	return intToBytes(n, 2, order)
+}
+
+// Uint32ToBytes returns the bytes of n in the given byte order.
+func Uint32ToBytes(n uint32, order ByteOrder) []byte {
+	return intToBytes(n, 4, order)
+}
+
+// Uint64ToBytes returns the bytes of n in the given byte order.
+func Uint64ToBytes(n uint64, order ByteOrder) []byte {
+	return intToBytes(n, 8, order)
+}
+
+// Float16ToBytes returns the half-precision bytes of f in the given byte
+// order, rounded like Float16bits.
+func Float16ToBytes(f float32, order ByteOrder) []byte {
+	return intToBytes(Float16bits(f), 2, order)
+}
+
+// Float32ToBytes returns the bytes of f in the given byte order.
+func Float32ToBytes(f float32, order ByteOrder) []byte {
+	return intToBytes(math.Float32bits(f), 4, order)
+}
+
+// Float64ToBytes returns the bytes of f in the given byte order.
+func Float64ToBytes(f float64, order ByteOrder) []byte {
+	return intToBytes(math.Float64bits(f), 8, order)
+}
+
+// intToBytes returns the byteSize bytes of n in the given order.
+func intToBytes[T integer](n T, byteSize int, order ByteOrder) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(n))
+	b := append([]byte(nil), buf[8-byteSize:]...)
+
+	switch order {
+	case LittleEndian:
+		for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+			b[i], b[j] = b[j], b[i]
+		}
+	case MidBigEndian:
+		swapBADC(b)
+	case MidLittleEndian:
+		swapCDAB(b)
+	}
+	return b
+}
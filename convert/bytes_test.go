@@ -0,0 +1,112 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestBytesToMatchesHex checks that reading parsed bytes gives the same
+// values as the HexTo functions for all types and byte orders.
+func TestBytesToMatchesHex(t *testing.T) {
+	for _, input := range []string{"01", "0102", "010203", "01020304", "0102030405060708", "ff7f"} {
+		b, err := ParseHex(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check := func(name string, got, want any, gotErr, wantErr error) {
+			t.Helper()
+			if (gotErr != nil) != (wantErr != nil) || (gotErr == nil && got != want) {
+				t.Errorf("%s(%s) = %v, %v; HexTo gives %v, %v", name, input, got, gotErr, want, wantErr)
+			}
+		}
+
+		g8, e1 := BytesToInt8(b)
+		w8, e2 := HexToInt8(input)
+		check("BytesToInt8", g8, w8, e1, e2)
+		gu8, e1 := BytesToUint8(b)
+		wu8, e2 := HexToUint8(input)
+		check("BytesToUint8", gu8, wu8, e1, e2)
+
+		hex16 := map[ByteOrder]func(string) (uint16, error){
+			BigEndian: HexToUint16, LittleEndian: HexToUint16LE, MidBigEndian: HexToUint16BADC, MidLittleEndian: HexToUint16CDAB,
+		}
+		hex32 := map[ByteOrder]func(string) (int32, error){
+			BigEndian: HexToInt32, LittleEndian: HexToInt32LE, MidBigEndian: HexToInt32BADC, MidLittleEndian: HexToInt32CDAB,
+		}
+		hex64 := map[ByteOrder]func(string) (uint64, error){
+			BigEndian: HexToUint64, LittleEndian: HexToUint64LE, MidBigEndian: HexToUint64BADC, MidLittleEndian: HexToUint64CDAB,
+		}
+		hexF32 := map[ByteOrder]func(string) (float32, error){
+			BigEndian: HexToFloat32, LittleEndian: HexToFloat32LE, MidBigEndian: HexToFloat32BADC, MidLittleEndian: HexToFloat32CDAB,
+		}
+		for _, order := range []ByteOrder{BigEndian, LittleEndian, MidBigEndian, MidLittleEndian} {
+			g16, e1 := BytesToUint16(b, order)
+			w16, e2 := hex16[order](input)
+			check("BytesToUint16 "+order.String(), g16, w16, e1, e2)
+			g32, e1 := BytesToInt32(b, order)
+			w32, e2 := hex32[order](input)
+			check("BytesToInt32 "+order.String(), g32, w32, e1, e2)
+			g64, e1 := BytesToUint64(b, order)
+			w64, e2 := hex64[order](input)
+			check("BytesToUint64 "+order.String(), g64, w64, e1, e2)
+			gf, e1 := BytesToFloat32(b, order)
+			wf, e2 := hexF32[order](input)
+			check("BytesToFloat32 "+order.String(), gf, wf, e1, e2)
+		}
+	}
+}
+
+func TestBytesToErrors(t *testing.T) {
+	if _, err := BytesToInt16(nil, BigEndian); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("BytesToInt16(nil) error = %v, want ErrEmptyInput", err)
+	}
+	if _, err := BytesToUint32([]byte{1, 2, 3, 4, 5}, LittleEndian); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("BytesToUint32(5 bytes) error = %v, want ErrInvalidLength", err)
+	}
+	if got := ByteOrder(9).String(); got != "unknown" {
+		t.Errorf("ByteOrder(9).String() = %q", got)
+	}
+}
+
+func TestToBytes(t *testing.T) {
+	tests := []struct {
+		name string
+		got  []byte
+		want string
+	}{
+		{"int8", Int8ToBytes(-2), "fe"},
+		{"uint8", Uint8ToBytes(0x7f), "7f"},
+		{"int16 BE", Int16ToBytes(-2, BigEndian), "fffe"},
+		{"uint16 LE", Uint16ToBytes(0x0102, LittleEndian), "0201"},
+		{"uint16 BADC", Uint16ToBytes(0x0102, MidBigEndian), "0102"},
+		{"uint16 CDAB", Uint16ToBytes(0x0102, MidLittleEndian), "0201"},
+		{"uint32 BADC", Uint32ToBytes(0x01020304, MidBigEndian), "02010403"},
+		{"int32 CDAB", Int32ToBytes(0x01020304, MidLittleEndian), "03040102"},
+		{"uint64 LE", Uint64ToBytes(0x0102030405060708, LittleEndian), "0807060504030201"},
+		{"int64 CDAB", Int64ToBytes(0x0102030405060708, MidLittleEndian), "0304010207080506"},
+		{"float32 BE", Float32ToBytes(50, BigEndian), "42480000"},
+		{"float32 CDAB", Float32ToBytes(50, MidLittleEndian), "00004248"},
+		{"float64 LE", Float64ToBytes(1, LittleEndian), "000000000000f03f"},
+	}
+	for _, tt := range tests {
+		if got := BytesToHex(tt.got); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	// Reading the bytes back in the same order gives the value
+	for _, order := range []ByteOrder{BigEndian, LittleEndian, MidBigEndian, MidLittleEndian} {
+		if v, _ := BytesToInt16(Int16ToBytes(-300, order), order); v != -300 {
+			t.Errorf("int16 %s round trip: %d", order, v)
+		}
+		if v, _ := BytesToUint32(Uint32ToBytes(0xdeadbeef, order), order); v != 0xdeadbeef {
+			t.Errorf("uint32 %s round trip: %#x", order, v)
+		}
+		if v, _ := BytesToInt64(Int64ToBytes(-1234567890123, order), order); v != -1234567890123 {
+			t.Errorf("int64 %s round trip: %d", order, v)
+		}
+		if v, _ := BytesToFloat64(Float64ToBytes(3.25, order), order); v != 3.25 {
+			t.Errorf("float64 %s round trip: %g", order, v)
+		}
+	}
+}
@@ -3,7 +3,7 @@
 //
 // The package supports:
 //   - Flexible hex string parsing (with/without prefixes, various separators)
-//   - Multiple numeric types (int8-64, uint8-64, float32/64, bytes)
+//   - Multiple numeric types (int8-64, uint8-64, float16/32/64, bytes)
 //   - Both big-endian (default) and little-endian conversions
 //   - Bidirectional conversions (hex ↔ numeric)
 //   - Binary string conversions (e.g., "0001" ↔ numeric)
@@ -29,6 +29,7 @@ import (
 	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 // Error definitions for conversion operations
@@ -49,6 +50,17 @@ var (
 	ErrInvalidBinaryChar = errors.New("invalid binary character")
 )
 
+// errEmptyInput returns the InputError for an empty input.
+func errEmptyInput() error {
+	return NewInputError(CodeEmptyInput, ErrEmptyInput, "")
+}
+
+// errLength returns the InputError for a value of the wrong size.
+func errLength(want, got int) error {
+	return NewInputError(CodeInvalidLength, ErrInvalidLength,
+		fmt.Sprintf("%v: expected %d bytes, got %d", ErrInvalidLength, want, got))
+}
+
 // ParseHex parses a hex string in various formats and returns the byte representation.
 // Supported formats include:
 //   - "0x123456" (standard prefix)
@@ -56,22 +68,34 @@ var (
 //   - "11abcd" (continuous)
 //   - "0xab 0xff" (multiple prefixed values)
 //   - "xAB xCF" (x prefix without 0)
-//   - Mixed case and various separators (spaces, commas, colons)
+//   - Mixed case and various separators (spaces, commas, colons, dashes)
+//   - Unicode spaces and dashes, e.g. "12\u00a034\u201356" from a PDF
 func ParseHex(input string) ([]byte, error) {
 	if len(input) == 0 {
-		return nil, ErrEmptyInput
+		return nil, errEmptyInput()
 	}
 
-	// Remove common separators and whitespace
-	cleaned := strings.Builder{}
-	cleaned.Grow(len(input))
+	// Collect the nibble values first, then pack them in place. A single
+	// buffer is allocated and the packed bytes never overtake the nibbles.
+	buf := make([]byte, 0, len(input))
 
 	i := 0
 	for i < len(input) {
 		ch := input[i]
 
+		// Non-ASCII characters can only be separators, e.g. a no-break
+		// space or an en dash pasted from a PDF
+		if ch >= utf8.RuneSelf {
+			r, size := utf8.DecodeRuneInString(input[i:])
+			if size > 1 && isUnicodeSeparator(r) {
+				i += size
+				continue
+			}
+			return nil, errHexChar(input, i, r, size)
+		}
+
 		// Skip whitespace and common separators
-		if unicode.IsSpace(rune(ch)) || ch == ',' || ch == ':' || ch == '-' {
+		if isSeparator(ch) {
 			i++
 			continue
 		}
@@ -87,31 +111,73 @@ func ParseHex(input string) ([]byte, error) {
 		}
 
 		// Validate hex character
-		if !isHexChar(ch) {
-			return nil, fmt.Errorf("%w: '%c' at position %d", ErrInvalidHexChar, ch, i)
+		n, ok := hexNibble(ch)
+		if !ok {
+			return nil, errHexChar(input, i, rune(ch), 1)
 		}
 
-		cleaned.WriteByte(ch)
+		buf = append(buf, n)
 		i++
 	}
 
-	hexStr := cleaned.String()
-	if len(hexStr) == 0 {
-		return nil, ErrEmptyInput
+	if len(buf) == 0 {
+		return nil, errEmptyInput()
 	}
 
-	// Ensure even length for proper byte decoding
-	if len(hexStr)%2 != 0 {
-		hexStr = "0" + hexStr
+	// An odd number of digits is read as if prefixed by a zero
+	odd := len(buf) % 2
+	out := buf[:(len(buf)+1)/2]
+	if odd == 1 {
+		out[0] = buf[0]
+	}
+	for j := odd; j < len(out); j++ {
+		k := 2*j - odd
+		out[j] = buf[k]<<4 | buf[k+1]
 	}
+	return out, nil
+}
 
-	// Decode hex string to bytes
-	result, err := hex.DecodeString(hexStr)
-	if err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrInvalidHexChar, err)
+// errHexChar returns the InputError for the invalid character r of size
+// bytes at byte offset i of input.
+func errHexChar(input string, i int, r rune, size int) error {
+	inErr := NewInputError(CodeInvalidHexChar, ErrInvalidHexChar, "").At(input, i, size)
+	inErr.msg = fmt.Sprintf("%v: '%c' at position %d", ErrInvalidHexChar, r, inErr.Position)
+	return inErr
+}
+
+// isSeparator reports whether ParseHex skips the ASCII character b between
+// hex digits.
+func isSeparator(b byte) bool {
+	switch b {
+	case ' ', '\t', '\n', '\v', '\f', '\r', ',', ':', '-':
+		return true
 	}
+	return false
+}
 
-	return result, nil
+// isUnicodeSeparator reports whether ParseHex skips the non-ASCII character
+// r between hex digits: Unicode spaces (no-break, thin, ideographic, ...),
+// zero-width spaces, dashes and minus signs, and full-width commas and
+// colons.
+func isUnicodeSeparator(r rune) bool {
+	switch r {
+	case '\u200b', '\ufeff', '\u2212', '\uff0c', '\uff1a', '\u3001':
+		return true
+	}
+	return unicode.IsSpace(r) || unicode.Is(unicode.Pd, r)
+}
+
+// hexNibble returns the value of a hexadecimal digit.
+func hexNibble(b byte) (byte, bool) {
+	switch {
+	case b >= '0' && b <= '9':
+		return b - '0', true
+	case b >= 'a' && b <= 'f':
+		return b - 'a' + 10, true
+	case b >= 'A' && b <= 'F':
+		return b - 'A' + 10, true
+	}
+	return 0, false
 }
 
 // isHexChar checks if a byte represents a valid hexadecimal character
@@ -123,26 +189,28 @@ func isHexChar(b byte) bool {
 // Supports formats like "1010", "0000 1111", etc.
 func ParseBinary(input string) ([]byte, error) {
 	if len(input) == 0 {
-		return nil, ErrEmptyInput
+		return nil, errEmptyInput()
 	}
 
 	// Remove whitespace and separators
 	cleaned := strings.Builder{}
 	cleaned.Grow(len(input))
 
-	for _, ch := range input {
+	for i, ch := range input {
 		if unicode.IsSpace(ch) || ch == ',' || ch == ':' || ch == '-' || ch == '_' {
 			continue
 		}
 		if ch != '0' && ch != '1' {
-			return nil, fmt.Errorf("%w: '%c'", ErrInvalidBinaryChar, ch)
+			_, size := utf8.DecodeRuneInString(input[i:])
+			return nil, NewInputError(CodeInvalidBinaryChar, ErrInvalidBinaryChar,
+				fmt.Sprintf("%v: '%c'", ErrInvalidBinaryChar, ch)).At(input, i, size)
 		}
 		cleaned.WriteRune(ch)
 	}
 
 	binStr := cleaned.String()
 	if len(binStr) == 0 {
-		return nil, ErrEmptyInput
+		return nil, errEmptyInput()
 	}
 
 	// Pad to multiple of 8
@@ -176,17 +244,20 @@ func BytesToHex(b []byte) string {
 
 // BytesToBinary converts a byte slice to a binary string representation.
 func BytesToBinary(b []byte) string {
-	var result strings.Builder
-	result.Grow(len(b) * 8)
-
+	if len(b) == 0 {
+		return ""
+	}
+	var out strings.Builder
+	out.Grow(len(b)*9 - 1)
 	for i, bt := range b {
 		if i > 0 {
-			result.WriteByte(' ')
+			out.WriteByte(' ')
+		}
+		for bit := 7; bit >= 0; bit-- {
+			out.WriteByte('0' + (bt>>bit)&1)
 		}
-		result.WriteString(fmt.Sprintf("%08b", bt))
 	}
-
-	return result.String()
+	return out.String()
 }
 
 // Generic constraint for integer types
@@ -195,49 +266,19 @@ type integer interface {
 }
 
 // hexToInt is a generic helper for converting hex strings to integer types.
-func hexToInt[T integer](hexStr string, byteSize int, endian binary.ByteOrder) (T, error) {
+func hexToInt[T integer](hexStr string, byteSize int, order ByteOrder) (T, error) {
 	bytes, err := ParseHex(hexStr)
 	if err != nil {
 		return 0, err
 	}
-
-	// Reject overflow: input has more bytes than target type can hold
-	if len(bytes) > byteSize {
-		return 0, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidLength, byteSize, len(bytes))
-	}
-
-	// Auto-pad with leading zeros for big-endian or trailing zeros for little-endian
-	if len(bytes) < byteSize {
-		if endian == binary.BigEndian {
-			// Prepend zeros (big-endian: MSB first)
-			padding := make([]byte, byteSize-len(bytes))
-			bytes = append(padding, bytes...)
-		} else {
-			// Append zeros (little-endian: LSB first)
-			padding := make([]byte, byteSize-len(bytes))
-			bytes = append(bytes, padding...)
-		}
-	}
-
-	var result T
-	switch byteSize {
-	case 1:
-		result = T(bytes[0])
-	case 2:
-		result = T(endian.Uint16(bytes))
-	case 4:
-		result = T(endian.Uint32(bytes))
-	case 8:
-		result = T(endian.Uint64(bytes))
-	}
-
-	return result, nil
+	return bytesToInt[T](bytes, byteSize, order)
 }
 
 // intToHex is a generic helper for converting integer types to hex strings.
 // Always returns hex in big-endian format for display consistency.
 func intToHex[T integer](n T, byteSize int, endian binary.ByteOrder) string {
-	bytes := make([]byte, byteSize)
+	var buf [8]byte
+	bytes := buf[:byteSize]
 
 	// Always write as big-endian for hex display
 	switch byteSize {
@@ -262,7 +303,7 @@ func binaryToInt[T integer](binStr string, byteSize int, endian binary.ByteOrder
 	}
 
 	if len(bytes) != byteSize {
-		return 0, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidLength, byteSize, len(bytes))
+		return 0, errLength(byteSize, len(bytes))
 	}
 
 	var result T
@@ -282,7 +323,8 @@ func binaryToInt[T integer](binStr string, byteSize int, endian binary.ByteOrder
 
 // intToBinary converts an integer type to a binary string.
 func intToBinary[T integer](n T, byteSize int, endian binary.ByteOrder) string {
-	bytes := make([]byte, byteSize)
+	var buf [8]byte
+	bytes := buf[:byteSize]
 
 	switch byteSize {
 	case 1:
@@ -305,23 +347,7 @@ func intToBinary[T integer](n T, byteSize int, endian binary.ByteOrder) string {
 func swapToBADC(bytes []byte) []byte {
 	result := make([]byte, len(bytes))
 	copy(result, bytes)
-
-	switch len(bytes) {
-	case 2:
-		// No swap for 16-bit (equivalent to big-endian)
-		return result
-	case 4:
-		// Swap bytes within each 16-bit word
-		result[0], result[1] = bytes[1], bytes[0]
-		result[2], result[3] = bytes[3], bytes[2]
-	case 8:
-		// Swap bytes within each 16-bit word
-		result[0], result[1] = bytes[1], bytes[0]
-		result[2], result[3] = bytes[3], bytes[2]
-		result[4], result[5] = bytes[5], bytes[4]
-		result[6], result[7] = bytes[7], bytes[6]
-	}
-
+	swapBADC(result)
 	return result
 }
 
@@ -332,102 +358,15 @@ func swapToBADC(bytes []byte) []byte {
 func swapToCDAB(bytes []byte) []byte {
 	result := make([]byte, len(bytes))
 	copy(result, bytes)
-
-	switch len(bytes) {
-	case 2:
-		// Equivalent to little-endian
-		result[0], result[1] = bytes[1], bytes[0]
-	case 4:
-		// Swap 16-bit word pairs
-		result[0], result[1] = bytes[2], bytes[3]
-		result[2], result[3] = bytes[0], bytes[1]
-	case 8:
-		// Swap 16-bit words within each 32-bit half
-		result[0], result[1] = bytes[2], bytes[3]
-		result[2], result[3] = bytes[0], bytes[1]
-		result[4], result[5] = bytes[6], bytes[7]
-		result[6], result[7] = bytes[4], bytes[5]
-	}
-
+	swapCDAB(result)
 	return result
 }
 
-// hexToIntBADC is a helper for converting hex strings to integer types using BADC byte order.
-func hexToIntBADC[T integer](hexStr string, byteSize int) (T, error) {
-	bytes, err := ParseHex(hexStr)
-	if err != nil {
-		return 0, err
-	}
-
-	// Reject overflow: input has more bytes than target type can hold
-	if len(bytes) > byteSize {
-		return 0, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidLength, byteSize, len(bytes))
-	}
-
-	// Auto-pad with leading zeros (big-endian style before BADC swap)
-	if len(bytes) < byteSize {
-		padding := make([]byte, byteSize-len(bytes))
-		bytes = append(padding, bytes...)
-	}
-
-	// Convert to big-endian first, then swap to BADC
-	swapped := swapToBADC(bytes)
-
-	var result T
-	switch byteSize {
-	case 1:
-		result = T(swapped[0])
-	case 2:
-		result = T(binary.BigEndian.Uint16(swapped))
-	case 4:
-		result = T(binary.BigEndian.Uint32(swapped))
-	case 8:
-		result = T(binary.BigEndian.Uint64(swapped))
-	}
-
-	return result, nil
-}
-
-// hexToIntCDAB is a helper for converting hex strings to integer types using CDAB byte order.
-func hexToIntCDAB[T integer](hexStr string, byteSize int) (T, error) {
-	bytes, err := ParseHex(hexStr)
-	if err != nil {
-		return 0, err
-	}
-
-	// Reject overflow: input has more bytes than target type can hold
-	if len(bytes) > byteSize {
-		return 0, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidLength, byteSize, len(bytes))
-	}
-
-	// Auto-pad with trailing zeros (little-endian style before CDAB swap)
-	if len(bytes) < byteSize {
-		padding := make([]byte, byteSize-len(bytes))
-		bytes = append(bytes, padding...)
-	}
-
-	// Convert to big-endian first, then swap to CDAB
-	swapped := swapToCDAB(bytes)
-
-	var result T
-	switch byteSize {
-	case 1:
-		result = T(swapped[0])
-	case 2:
-		result = T(binary.BigEndian.Uint16(swapped))
-	case 4:
-		result = T(binary.BigEndian.Uint32(swapped))
-	case 8:
-		result = T(binary.BigEndian.Uint64(swapped))
-	}
-
-	return result, nil
-}
-
 // intToHexBADC is a helper for converting integer types to hex strings using BADC byte order.
 // Returns hex in big-endian format to show the numeric value.
 func intToHexBADC[T integer](n T, byteSize int) string {
-	bytes := make([]byte, byteSize)
+	var buf [8]byte
+	bytes := buf[:byteSize]
 
 	// Write as big-endian for hex display (value was already read with BADC interpretation)
 	switch byteSize {
@@ -447,7 +386,8 @@ func intToHexBADC[T integer](n T, byteSize int) string {
 // intToHexCDAB is a helper for converting integer types to hex strings using CDAB byte order.
 // Returns hex in big-endian format to show the numeric value.
 func intToHexCDAB[T integer](n T, byteSize int) string {
-	bytes := make([]byte, byteSize)
+	var buf [8]byte
+	bytes := buf[:byteSize]
 
 	// Write as big-endian for hex display (value was already read with CDAB interpretation)
 	switch byteSize {
@@ -472,7 +412,7 @@ func binaryToIntBADC[T integer](binStr string, byteSize int) (T, error) {
 	}
 
 	if len(bytes) != byteSize {
-		return 0, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidLength, byteSize, len(bytes))
+		return 0, errLength(byteSize, len(bytes))
 	}
 
 	swapped := swapToBADC(bytes)
@@ -500,7 +440,7 @@ func binaryToIntCDAB[T integer](binStr string, byteSize int) (T, error) {
 	}
 
 	if len(bytes) != byteSize {
-		return 0, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidLength, byteSize, len(bytes))
+		return 0, errLength(byteSize, len(bytes))
 	}
 
 	swapped := swapToCDAB(bytes)
@@ -522,7 +462,8 @@ func binaryToIntCDAB[T integer](binStr string, byteSize int) (T, error) {
 
 // intToBinaryBADC converts an integer type to a binary string using BADC byte order.
 func intToBinaryBADC[T integer](n T, byteSize int) string {
-	bytes := make([]byte, byteSize)
+	var buf [8]byte
+	bytes := buf[:byteSize]
 
 	switch byteSize {
 	case 1:
@@ -541,7 +482,8 @@ func intToBinaryBADC[T integer](n T, byteSize int) string {
 
 // intToBinaryCDAB converts an integer type to a binary string using CDAB byte order.
 func intToBinaryCDAB[T integer](n T, byteSize int) string {
-	bytes := make([]byte, byteSize)
+	var buf [8]byte
+	bytes := buf[:byteSize]
 
 	switch byteSize {
 	case 1:
@@ -564,42 +506,42 @@ func intToBinaryCDAB[T integer](n T, byteSize int) string {
 
 // HexToInt8 converts a hex string to an int8 (big-endian).
 func HexToInt8(hexStr string) (int8, error) {
-	return hexToInt[int8](hexStr, 1, binary.BigEndian)
+	return hexToInt[int8](hexStr, 1, BigEndian)
 }
 
 // HexToInt16 converts a hex string to an int16 (big-endian).
 func HexToInt16(hexStr string) (int16, error) {
-	return hexToInt[int16](hexStr, 2, binary.BigEndian)
+	return hexToInt[int16](hexStr, 2, BigEndian)
 }
 
 // HexToInt32 converts a hex string to an int32 (big-endian).
 func HexToInt32(hexStr string) (int32, error) {
-	return hexToInt[int32](hexStr, 4, binary.BigEndian)
+	return hexToInt[int32](hexStr, 4, BigEndian)
 }
 
 // HexToInt64 converts a hex string to an int64 (big-endian).
 func HexToInt64(hexStr string) (int64, error) {
-	return hexToInt[int64](hexStr, 8, binary.BigEndian)
+	return hexToInt[int64](hexStr, 8, BigEndian)
 }
 
 // HexToInt8LE converts a hex string to an int8 (little-endian).
 func HexToInt8LE(hexStr string) (int8, error) {
-	return hexToInt[int8](hexStr, 1, binary.LittleEndian)
+	return hexToInt[int8](hexStr, 1, LittleEndian)
 }
 
 // HexToInt16LE converts a hex string to an int16 (little-endian).
 func HexToInt16LE(hexStr string) (int16, error) {
-	return hexToInt[int16](hexStr, 2, binary.LittleEndian)
+	return hexToInt[int16](hexStr, 2, LittleEndian)
 }
 
 // HexToInt32LE converts a hex string to an int32 (little-endian).
 func HexToInt32LE(hexStr string) (int32, error) {
-	return hexToInt[int32](hexStr, 4, binary.LittleEndian)
+	return hexToInt[int32](hexStr, 4, LittleEndian)
 }
 
 // HexToInt64LE converts a hex string to an int64 (little-endian).
 func HexToInt64LE(hexStr string) (int64, error) {
-	return hexToInt[int64](hexStr, 8, binary.LittleEndian)
+	return hexToInt[int64](hexStr, 8, LittleEndian)
 }
 
 // Int8ToHex converts an int8 to a hex string (big-endian).
@@ -644,32 +586,32 @@ func Int64ToHexLE(n int64) string {
 
 // HexToInt16BADC converts a hex string to an int16 (mid-big-endian/BADC).
 func HexToInt16BADC(hexStr string) (int16, error) {
-	return hexToIntBADC[int16](hexStr, 2)
+	return hexToInt[int16](hexStr, 2, MidBigEndian)
 }
 
 // HexToInt32BADC converts a hex string to an int32 (mid-big-endian/BADC).
 func HexToInt32BADC(hexStr string) (int32, error) {
-	return hexToIntBADC[int32](hexStr, 4)
+	return hexToInt[int32](hexStr, 4, MidBigEndian)
 }
 
 // HexToInt64BADC converts a hex string to an int64 (mid-big-endian/BADC).
 func HexToInt64BADC(hexStr string) (int64, error) {
-	return hexToIntBADC[int64](hexStr, 8)
+	return hexToInt[int64](hexStr, 8, MidBigEndian)
 }
 
 // HexToInt16CDAB converts a hex string to an int16 (mid-little-endian/CDAB).
 func HexToInt16CDAB(hexStr string) (int16, error) {
-	return hexToIntCDAB[int16](hexStr, 2)
+	return hexToInt[int16](hexStr, 2, MidLittleEndian)
 }
 
 // HexToInt32CDAB converts a hex string to an int32 (mid-little-endian/CDAB).
 func HexToInt32CDAB(hexStr string) (int32, error) {
-	return hexToIntCDAB[int32](hexStr, 4)
+	return hexToInt[int32](hexStr, 4, MidLittleEndian)
 }
 
 // HexToInt64CDAB converts a hex string to an int64 (mid-little-endian/CDAB).
 func HexToInt64CDAB(hexStr string) (int64, error) {
-	return hexToIntCDAB[int64](hexStr, 8)
+	return hexToInt[int64](hexStr, 8, MidLittleEndian)
 }
 
 // Int16ToHexBADC converts an int16 to a hex string (mid-big-endian/BADC).
@@ -708,42 +650,42 @@ func Int64ToHexCDAB(n int64) string {
 
 // HexToUint8 converts a hex string to a uint8 (big-endian).
 func HexToUint8(hexStr string) (uint8, error) {
-	return hexToInt[uint8](hexStr, 1, binary.BigEndian)
+	return hexToInt[uint8](hexStr, 1, BigEndian)
 }
 
 // HexToUint16 converts a hex string to a uint16 (big-endian).
 func HexToUint16(hexStr string) (uint16, error) {
-	return hexToInt[uint16](hexStr, 2, binary.BigEndian)
+	return hexToInt[uint16](hexStr, 2, BigEndian)
 }
 
 // HexToUint32 converts a hex string to a uint32 (big-endian).
 func HexToUint32(hexStr string) (uint32, error) {
-	return hexToInt[uint32](hexStr, 4, binary.BigEndian)
+	return hexToInt[uint32](hexStr, 4, BigEndian)
 }
 
 // HexToUint64 converts a hex string to a uint64 (big-endian).
 func HexToUint64(hexStr string) (uint64, error) {
-	return hexToInt[uint64](hexStr, 8, binary.BigEndian)
+	return hexToInt[uint64](hexStr, 8, BigEndian)
 }
 
 // HexToUint8LE converts a hex string to a uint8 (little-endian).
 func HexToUint8LE(hexStr string) (uint8, error) {
-	return hexToInt[uint8](hexStr, 1, binary.LittleEndian)
+	return hexToInt[uint8](hexStr, 1, LittleEndian)
 }
 
 // HexToUint16LE converts a hex string to a uint16 (little-endian).
 func HexToUint16LE(hexStr string) (uint16, error) {
-	return hexToInt[uint16](hexStr, 2, binary.LittleEndian)
+	return hexToInt[uint16](hexStr, 2, LittleEndian)
 }
 
 // HexToUint32LE converts a hex string to a uint32 (little-endian).
 func HexToUint32LE(hexStr string) (uint32, error) {
-	return hexToInt[uint32](hexStr, 4, binary.LittleEndian)
+	return hexToInt[uint32](hexStr, 4, LittleEndian)
 }
 
 // HexToUint64LE converts a hex string to a uint64 (little-endian).
 func HexToUint64LE(hexStr string) (uint64, error) {
-	return hexToInt[uint64](hexStr, 8, binary.LittleEndian)
+	return hexToInt[uint64](hexStr, 8, LittleEndian)
 }
 
 // Uint8ToHex converts a uint8 to a hex string (big-endian).
@@ -788,32 +730,32 @@ func Uint64ToHexLE(n uint64) string {
 
 // HexToUint16BADC converts a hex string to a uint16 (mid-big-endian/BADC).
 func HexToUint16BADC(hexStr string) (uint16, error) {
-	return hexToIntBADC[uint16](hexStr, 2)
+	return hexToInt[uint16](hexStr, 2, MidBigEndian)
 }
 
 // HexToUint32BADC converts a hex string to a uint32 (mid-big-endian/BADC).
 func HexToUint32BADC(hexStr string) (uint32, error) {
-	return hexToIntBADC[uint32](hexStr, 4)
+	return hexToInt[uint32](hexStr, 4, MidBigEndian)
 }
 
 // HexToUint64BADC converts a hex string to a uint64 (mid-big-endian/BADC).
 func HexToUint64BADC(hexStr string) (uint64, error) {
-	return hexToIntBADC[uint64](hexStr, 8)
+	return hexToInt[uint64](hexStr, 8, MidBigEndian)
 }
 
 // HexToUint16CDAB converts a hex string to a uint16 (mid-little-endian/CDAB).
 func HexToUint16CDAB(hexStr string) (uint16, error) {
-	return hexToIntCDAB[uint16](hexStr, 2)
+	return hexToInt[uint16](hexStr, 2, MidLittleEndian)
 }
 
 // HexToUint32CDAB converts a hex string to a uint32 (mid-little-endian/CDAB).
 func HexToUint32CDAB(hexStr string) (uint32, error) {
-	return hexToIntCDAB[uint32](hexStr, 4)
+	return hexToInt[uint32](hexStr, 4, MidLittleEndian)
 }
 
 // HexToUint64CDAB converts a hex string to a uint64 (mid-little-endian/CDAB).
 func HexToUint64CDAB(hexStr string) (uint64, error) {
-	return hexToIntCDAB[uint64](hexStr, 8)
+	return hexToInt[uint64](hexStr, 8, MidLittleEndian)
 }
 
 // Uint16ToHexBADC converts a uint16 to a hex string (mid-big-endian/BADC).
@@ -852,7 +794,7 @@ func Uint64ToHexCDAB(n uint64) string {
 
 // HexToFloat32 converts a hex string to a float32 (big-endian).
 func HexToFloat32(hexStr string) (float32, error) {
-	bits, err := hexToInt[uint32](hexStr, 4, binary.BigEndian)
+	bits, err := hexToInt[uint32](hexStr, 4, BigEndian)
 	if err != nil {
 		return 0, err
 	}
@@ -861,7 +803,7 @@ func HexToFloat32(hexStr string) (float32, error) {
 
 // HexToFloat64 converts a hex string to a float64 (big-endian).
 func HexToFloat64(hexStr string) (float64, error) {
-	bits, err := hexToInt[uint64](hexStr, 8, binary.BigEndian)
+	bits, err := hexToInt[uint64](hexStr, 8, BigEndian)
 	if err != nil {
 		return 0, err
 	}
@@ -870,7 +812,7 @@ func HexToFloat64(hexStr string) (float64, error) {
 
 // HexToFloat32LE converts a hex string to a float32 (little-endian).
 func HexToFloat32LE(hexStr string) (float32, error) {
-	bits, err := hexToInt[uint32](hexStr, 4, binary.LittleEndian)
+	bits, err := hexToInt[uint32](hexStr, 4, LittleEndian)
 	if err != nil {
 		return 0, err
 	}
@@ -879,7 +821,7 @@ func HexToFloat32LE(hexStr string) (float32, error) {
 
 // HexToFloat64LE converts a hex string to a float64 (little-endian).
 func HexToFloat64LE(hexStr string) (float64, error) {
-	bits, err := hexToInt[uint64](hexStr, 8, binary.LittleEndian)
+	bits, err := hexToInt[uint64](hexStr, 8, LittleEndian)
 	if err != nil {
 		return 0, err
 	}
@@ -912,7 +854,7 @@ func Float64ToHexLE(f float64) string {
 
 // HexToFloat32BADC converts a hex string to a float32 (mid-big-endian/BADC).
 func HexToFloat32BADC(hexStr string) (float32, error) {
-	bits, err := hexToIntBADC[uint32](hexStr, 4)
+	bits, err := hexToInt[uint32](hexStr, 4, MidBigEndian)
 	if err != nil {
 		return 0, err
 	}
@@ -921,7 +863,7 @@ func HexToFloat32BADC(hexStr string) (float32, error) {
 
 // HexToFloat64BADC converts a hex string to a float64 (mid-big-endian/BADC).
 func HexToFloat64BADC(hexStr string) (float64, error) {
-	bits, err := hexToIntBADC[uint64](hexStr, 8)
+	bits, err := hexToInt[uint64](hexStr, 8, MidBigEndian)
 	if err != nil {
 		return 0, err
 	}
@@ -930,7 +872,7 @@ func HexToFloat64BADC(hexStr string) (float64, error) {
 
 // HexToFloat32CDAB converts a hex string to a float32 (mid-little-endian/CDAB).
 func HexToFloat32CDAB(hexStr string) (float32, error) {
-	bits, err := hexToIntCDAB[uint32](hexStr, 4)
+	bits, err := hexToInt[uint32](hexStr, 4, MidLittleEndian)
 	if err != nil {
 		return 0, err
 	}
@@ -939,7 +881,7 @@ func HexToFloat32CDAB(hexStr string) (float32, error) {
 
 // HexToFloat64CDAB converts a hex string to a float64 (mid-little-endian/CDAB).
 func HexToFloat64CDAB(hexStr string) (float64, error) {
-	bits, err := hexToIntCDAB[uint64](hexStr, 8)
+	bits, err := hexToInt[uint64](hexStr, 8, MidLittleEndian)
 	if err != nil {
 		return 0, err
 	}
@@ -26,7 +26,17 @@ func TestParseHex(t *testing.T) {
 		{"mixed case", "aAbBcC", []byte{0xaa, 0xbb, 0xcc}, false},
 		{"single byte", "ff", []byte{0xff}, false},
 		{"odd length", "123", []byte{0x01, 0x23}, false},
+		{"odd length with separators", "1 23 45", []byte{0x01, 0x23, 0x45}, false},
+		{"dash and tab separated", "de-ad\tbe\nef", []byte{0xde, 0xad, 0xbe, 0xef}, false},
+		{"no-break spaces", "12\u00a034\u202f56", []byte{0x12, 0x34, 0x56}, false},
+		{"thin and ideographic spaces", "12\u200934\u300056", []byte{0x12, 0x34, 0x56}, false},
+		{"zero-width space and BOM", "\ufeff12\u200b34", []byte{0x12, 0x34}, false},
+		{"typographic dashes", "de\u2013ad\u2014be\u2010ef\u2212aa", []byte{0xde, 0xad, 0xbe, 0xef, 0xaa}, false},
+		{"full-width separators", "12\uff0c34\uff1a56", []byte{0x12, 0x34, 0x56}, false},
+		{"invalid UTF-8 byte", "12\xa034", nil, true},
+		{"non-hex letter", "12\u00e434", nil, true},
 		{"empty", "", nil, true},
+		{"only unicode separators", "\u00a0\u2013", nil, true},
 		{"invalid char", "0xGG", nil, true},
 		{"only prefix", "0x", nil, true},
 	}
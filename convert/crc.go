@@ -0,0 +1,13 @@
+package convert
+
+import "hexview/checksum"
+
+// ============================================================================
+// Checksums
+// ============================================================================
+
+// CRC16Modbus returns the CRC-16/MODBUS of data, the checksum of Modbus RTU
+// frames. It is sent low byte first, e.g. 0xcdc5 as c5 cd.
+func CRC16Modbus(data []byte) uint16 {
+	return uint16(checksum.CRC16Modbus.Checksum(data))
+}
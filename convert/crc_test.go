@@ -0,0 +1,22 @@
+package convert
+
+import "testing"
+
+func TestCRC16Modbus(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want uint16
+	}{
+		{"empty", nil, 0xffff},
+		{"check", []byte("123456789"), 0x4b37},
+		{"read holding registers", []byte{0x01, 0x03, 0x00, 0x00, 0x00, 0x0a}, 0xcdc5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CRC16Modbus(tt.data); got != tt.want {
+				t.Errorf("CRC16Modbus(% x) = %04x, want %04x", tt.data, got, tt.want)
+			}
+		})
+	}
+}
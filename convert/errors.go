@@ -0,0 +1,72 @@
+package convert
+
+import (
+	"unicode/utf8"
+)
+
+// ErrorCode classifies an input error so callers can react to it without
+// parsing the message.
+type ErrorCode string
+
+// Error codes of InputError
+const (
+	CodeEmptyInput        ErrorCode = "empty_input"
+	CodeInvalidHexChar    ErrorCode = "invalid_hex_char"
+	CodeInvalidBinaryChar ErrorCode = "invalid_binary_char"
+	CodeInvalidLength     ErrorCode = "invalid_length"
+	CodeInvalidNumber     ErrorCode = "invalid_number"
+	CodeOutOfRange        ErrorCode = "out_of_range"
+	CodeUnsupportedType   ErrorCode = "unsupported_type"
+	CodeInputTooLarge     ErrorCode = "input_too_large"
+	CodeInvalidEncoding   ErrorCode = "invalid_encoding"
+)
+
+// InputError describes a problem with user input. When the problem can be
+// located, Offset and Position point at the offending part of the input so
+// a UI can highlight it; otherwise both are -1.
+//
+// InputError unwraps to one of the sentinel errors of this package, so
+// errors.Is(err, ErrInvalidHexChar) keeps working:
+//
+//	_, err := convert.ParseHex("12zz")
+//	var inErr *convert.InputError
+//	if errors.As(err, &inErr) {
+//		fmt.Println(inErr.Code, inErr.Position, inErr.Length) // invalid_hex_char 2 1
+//	}
+type InputError struct {
+	Code     ErrorCode
+	Err      error // sentinel error, e.g. ErrInvalidHexChar
+	Offset   int   // byte offset in the input, -1 if unknown
+	Position int   // character (rune) offset in the input, -1 if unknown
+	Length   int   // number of characters affected
+	msg      string
+}
+
+// NewInputError creates an InputError without a position.
+func NewInputError(code ErrorCode, err error, msg string) *InputError {
+	return &InputError{Code: code, Err: err, Offset: -1, Position: -1, msg: msg}
+}
+
+// At sets the location of the error to length bytes starting at byte offset
+// in input and returns e. Offsets outside input are clamped.
+func (e *InputError) At(input string, offset, length int) *InputError {
+	offset = min(max(offset, 0), len(input))
+	end := min(max(offset+length, offset), len(input))
+	e.Offset = offset
+	e.Position = utf8.RuneCountInString(input[:offset])
+	e.Length = utf8.RuneCountInString(input[offset:end])
+	return e
+}
+
+// Error returns the error message.
+func (e *InputError) Error() string {
+	if e.msg == "" && e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.msg
+}
+
+// Unwrap returns the sentinel error.
+func (e *InputError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,64 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInputErrorPositions(t *testing.T) {
+	tests := []struct {
+		name     string
+		parse    func(string) ([]byte, error)
+		input    string
+		code     ErrorCode
+		sentinel error
+		offset   int
+		position int
+		length   int
+	}{
+		{"empty hex", ParseHex, "", CodeEmptyInput, ErrEmptyInput, -1, -1, 0},
+		{"only separators", ParseHex, " : ", CodeEmptyInput, ErrEmptyInput, -1, -1, 0},
+		{"invalid hex char", ParseHex, "12 zz", CodeInvalidHexChar, ErrInvalidHexChar, 3, 3, 1},
+		{"after multibyte char", ParseHex, "ä1g", CodeInvalidHexChar, ErrInvalidHexChar, 0, 0, 1},
+		{"multibyte char after valid", ParseHex, "12 ä", CodeInvalidHexChar, ErrInvalidHexChar, 3, 3, 1},
+		{"invalid UTF-8 byte", ParseHex, "12\xa034", CodeInvalidHexChar, ErrInvalidHexChar, 2, 2, 1},
+		{"after unicode separators", ParseHex, "12\u00a034\u2013zz", CodeInvalidHexChar, ErrInvalidHexChar, 9, 6, 1},
+		{"invalid binary char", ParseBinary, "0101 2", CodeInvalidBinaryChar, ErrInvalidBinaryChar, 5, 5, 1},
+		{"binary after multibyte char", ParseBinary, "µ1", CodeInvalidBinaryChar, ErrInvalidBinaryChar, 0, 0, 1},
+		{"binary multibyte char after valid", ParseBinary, "01 µ", CodeInvalidBinaryChar, ErrInvalidBinaryChar, 3, 3, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.parse(tt.input)
+			var inErr *InputError
+			if !errors.As(err, &inErr) {
+				t.Fatalf("error %v is not an *InputError", err)
+			}
+			if !errors.Is(err, tt.sentinel) {
+				t.Errorf("errors.Is(%v, %v) = false", err, tt.sentinel)
+			}
+			if inErr.Code != tt.code || inErr.Offset != tt.offset || inErr.Position != tt.position || inErr.Length != tt.length {
+				t.Errorf("got code %s offset %d position %d length %d, want %s %d %d %d",
+					inErr.Code, inErr.Offset, inErr.Position, inErr.Length, tt.code, tt.offset, tt.position, tt.length)
+			}
+		})
+	}
+}
+
+func TestInputErrorLength(t *testing.T) {
+	_, err := HexToInt16("010203")
+	var inErr *InputError
+	if !errors.As(err, &inErr) || inErr.Code != CodeInvalidLength || !errors.Is(err, ErrInvalidLength) {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if got, want := err.Error(), "invalid hex string length for type: expected 2 bytes, got 3"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestInputErrorAt(t *testing.T) {
+	e := NewInputError(CodeInvalidNumber, nil, "bad").At("€1x", 4, 10)
+	if e.Offset != 4 || e.Position != 2 || e.Length != 1 {
+		t.Errorf("At() = offset %d position %d length %d, want 4 2 1", e.Offset, e.Position, e.Length)
+	}
+}
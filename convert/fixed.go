@@ -0,0 +1,180 @@
+package convert
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// ============================================================================
+// Fixed-Point (Qm.n) Numbers
+// ============================================================================
+
+// ErrInvalidQFormat indicates a Qm.n format that does not fill whole bytes
+// of up to 64 bits, or a byte order its width cannot be stored in
+var ErrInvalidQFormat = errors.New("invalid Q format")
+
+// Fixed-point numbers are integers scaled by 2^-fracBits, as DSP and motor
+// control registers store fractions. The format Qm.n has m integer bits and
+// n fraction bits; signed formats (Q) add a sign bit in two's complement,
+// unsigned formats (UQ) do not. Q15 (Q0.15) and Q31 (Q0.31) are the 16- and
+// 32-bit fractions from -1 to just below 1. The width, 1+m+n or m+n bits,
+// must be a whole number of bytes up to 64 bits; BADC and CDAB orders need
+// 16, 32 or 64 bits. Like the BytesTo functions the readers accept fewer
+// bytes than the width.
+
+// HexToFixedPoint converts a hex string to a Qm.n fixed-point number with
+// intBits integer and fracBits fraction bits in the given byte order, e.g.
+// "4000" → 0.5 as Q15 (0, 15, true, BigEndian).
+func HexToFixedPoint(hexStr string, intBits, fracBits int, signed bool, order ByteOrder) (float64, error) {
+	b, err := ParseHex(hexStr)
+	if err != nil {
+		return 0, err
+	}
+	return BytesToFixedPoint(b, intBits, fracBits, signed, order)
+}
+
+// BytesToFixedPoint reads a Qm.n fixed-point number from b. Values of more
+// than 53 significant bits are rounded to the nearest float64; see
+// FormatFixedPoint for the exact value.
+func BytesToFixedPoint(b []byte, intBits, fracBits int, signed bool, order ByteOrder) (float64, error) {
+	raw, err := fixedPointRaw(b, intBits, fracBits, signed, order)
+	if err != nil {
+		return 0, err
+	}
+	if signed {
+		return math.Ldexp(float64(int64(raw)), -fracBits), nil
+	}
+	return math.Ldexp(float64(raw), -fracBits), nil
+}
+
+// FormatFixedPoint reads a Qm.n fixed-point number from b and returns its
+// exact decimal value, e.g. "0.000030517578125" for Q15 0001.
+func FormatFixedPoint(b []byte, intBits, fracBits int, signed bool, order ByteOrder) (string, error) {
+	raw, err := fixedPointRaw(b, intBits, fracBits, signed, order)
+	if err != nil {
+		return "", err
+	}
+	f := new(big.Float)
+	if signed {
+		f.SetInt64(int64(raw))
+	} else {
+		f.SetUint64(raw)
+	}
+	// 2^-n has n decimal places, so n digits are exact
+	s := f.SetMantExp(f, -fracBits).Text('f', fracBits)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s, nil
+}
+
+// FixedPointToHex converts v to a Qm.n fixed-point number in the given byte
+// order, rounded to the nearest multiple of 2^-fracBits. Values outside
+// the range of the format and NaN are an ErrOverflow.
+func FixedPointToHex(v float64, intBits, fracBits int, signed bool, order ByteOrder) (string, error) {
+	size, err := fixedPointSize(intBits, fracBits, signed, order)
+	if err != nil {
+		return "", err
+	}
+	bits := 8 * size
+	scaled := math.Round(math.Ldexp(v, fracBits))
+	lo, hi := 0.0, math.Ldexp(1, bits)
+	if signed {
+		lo, hi = -math.Ldexp(1, bits-1), math.Ldexp(1, bits-1)
+	}
+	if math.IsNaN(scaled) || scaled < lo || scaled >= hi {
+		return "", NewInputError(CodeOutOfRange, ErrOverflow,
+			fmt.Sprintf("%v: %v is outside %s", ErrOverflow, v, QFormatName(intBits, fracBits, signed)))
+	}
+
+	var raw uint64
+	if signed {
+		raw = uint64(int64(scaled))
+	} else {
+		raw = uint64(scaled)
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], raw)
+	b := buf[8-size:]
+	swapOrder(b, order)
+	return BytesToHex(b), nil
+}
+
+// QFormatName returns the name of a Qm.n format, e.g. Q15 for a signed
+// format without integer bits, Q1.14 or UQ8.8.
+func QFormatName(intBits, fracBits int, signed bool) string {
+	if !signed {
+		return fmt.Sprintf("UQ%d.%d", intBits, fracBits)
+	}
+	if intBits == 0 {
+		return fmt.Sprintf("Q%d", fracBits)
+	}
+	return fmt.Sprintf("Q%d.%d", intBits, fracBits)
+}
+
+// fixedPointSize returns the width of a Qm.n format in bytes.
+func fixedPointSize(intBits, fracBits int, signed bool, order ByteOrder) (int, error) {
+	bits := intBits + fracBits
+	if signed {
+		bits++
+	}
+	if intBits < 0 || fracBits < 0 || bits == 0 || bits > 64 || bits%8 != 0 {
+		return 0, NewInputError(CodeUnsupportedType, ErrInvalidQFormat,
+			fmt.Sprintf("%v: %s has %d bits, want 8, 16, 24 ... 64", ErrInvalidQFormat, QFormatName(intBits, fracBits, signed), bits))
+	}
+	size := bits / 8
+	if (order == MidBigEndian || order == MidLittleEndian) && size != 2 && size != 4 && size != 8 {
+		return 0, NewInputError(CodeUnsupportedType, ErrInvalidQFormat,
+			fmt.Sprintf("%v: %d-bit values cannot be stored in %s order", ErrInvalidQFormat, bits, order))
+	}
+	return size, nil
+}
+
+// fixedPointRaw reads the integer of a Qm.n number from b, sign-extended
+// to 64 bits for signed formats.
+func fixedPointRaw(b []byte, intBits, fracBits int, signed bool, order ByteOrder) (uint64, error) {
+	size, err := fixedPointSize(intBits, fracBits, signed, order)
+	if err != nil {
+		return 0, err
+	}
+	if len(b) == 0 {
+		return 0, errEmptyInput()
+	}
+	if len(b) > size {
+		return 0, errLength(size, len(b))
+	}
+
+	var buf [8]byte
+	v := buf[8-size:]
+	if order == LittleEndian || order == MidLittleEndian {
+		copy(v, b)
+	} else {
+		copy(v[size-len(b):], b)
+	}
+	swapOrder(v, order)
+	raw := binary.BigEndian.Uint64(buf[:])
+
+	bits := 8 * size
+	if signed && bits < 64 && raw>>(bits-1) != 0 {
+		raw |= math.MaxUint64 << bits
+	}
+	return raw, nil
+}
+
+// swapOrder converts b between big-endian and order in place.
+func swapOrder(b []byte, order ByteOrder) {
+	switch order {
+	case LittleEndian:
+		for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+			b[i], b[j] = b[j], b[i]
+		}
+	case MidBigEndian:
+		swapBADC(b)
+	case MidLittleEndian:
+		swapCDAB(b)
+	}
+}
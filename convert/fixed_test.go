@@ -0,0 +1,118 @@
+package convert
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestHexToFixedPoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		hex      string
+		intBits  int
+		fracBits int
+		signed   bool
+		order    ByteOrder
+		want     float64
+		exact    string
+	}{
+		{"Q15 half", "4000", 0, 15, true, BigEndian, 0.5, "0.5"},
+		{"Q15 minus one", "8000", 0, 15, true, BigEndian, -1, "-1"},
+		{"Q15 max", "7fff", 0, 15, true, BigEndian, 0.999969482421875, "0.999969482421875"},
+		{"Q15 LSB", "0001", 0, 15, true, BigEndian, 0.000030517578125, "0.000030517578125"},
+		{"Q15 little-endian", "00c0", 0, 15, true, LittleEndian, -0.5, "-0.5"},
+		{"Q31", "c0000000", 0, 31, true, BigEndian, -0.5, "-0.5"},
+		{"Q31 CDAB", "00004000", 0, 31, true, MidLittleEndian, 0.5, "0.5"},
+		{"Q1.14", "6000", 1, 14, true, BigEndian, 1.5, "1.5"},
+		{"UQ8.8", "ff80", 8, 8, false, BigEndian, 255.5, "255.5"},
+		{"UQ0.8", "01", 0, 8, false, BigEndian, 0.00390625, "0.00390625"},
+		{"Q23 24-bit", "c00000", 0, 23, true, BigEndian, -0.5, "-0.5"},
+		{"Q16.15 integer", "00050000", 16, 15, true, BigEndian, 10, "10"},
+		{"Q63 min", "8000000000000000", 0, 63, true, BigEndian, -1, "-1"},
+		{"UQ64.0", "ffffffffffffffff", 64, 0, false, BigEndian, math.MaxUint64, "18446744073709551615"},
+		{"short input", "40", 0, 15, true, BigEndian, 0.001953125, "0.001953125"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HexToFixedPoint(tt.hex, tt.intBits, tt.fracBits, tt.signed, tt.order)
+			if err != nil {
+				t.Fatalf("HexToFixedPoint(%s) error: %v", tt.hex, err)
+			}
+			if got != tt.want {
+				t.Errorf("HexToFixedPoint(%s) = %v, want %v", tt.hex, got, tt.want)
+			}
+			b, _ := ParseHex(tt.hex)
+			exact, err := FormatFixedPoint(b, tt.intBits, tt.fracBits, tt.signed, tt.order)
+			if err != nil || exact != tt.exact {
+				t.Errorf("FormatFixedPoint(%s) = %q, %v, want %q", tt.hex, exact, err, tt.exact)
+			}
+		})
+	}
+}
+
+func TestFixedPointToHex(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		intBits  int
+		fracBits int
+		signed   bool
+		order    ByteOrder
+		want     string
+	}{
+		{"Q15 half", 0.5, 0, 15, true, BigEndian, "4000"},
+		{"Q15 minus one", -1, 0, 15, true, BigEndian, "8000"},
+		{"Q15 rounded", 0.1, 0, 15, true, BigEndian, "0ccd"},
+		{"Q15 little-endian", -0.5, 0, 15, true, LittleEndian, "00c0"},
+		{"Q31 CDAB", 0.5, 0, 31, true, MidLittleEndian, "00004000"},
+		{"UQ8.8", 255.5, 8, 8, false, BigEndian, "ff80"},
+		{"Q23", -0.5, 0, 23, true, BigEndian, "c00000"},
+		{"Q63 float precision", 0.9999, 0, 63, true, BigEndian, "7ffcb923a29c7800"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FixedPointToHex(tt.value, tt.intBits, tt.fracBits, tt.signed, tt.order)
+			if err != nil {
+				t.Fatalf("FixedPointToHex(%v) error: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("FixedPointToHex(%v) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFixedPointErrors(t *testing.T) {
+	var inErr *InputError
+	if _, err := FixedPointToHex(1, 0, 15, true, BigEndian); !errors.Is(err, ErrOverflow) || !errors.As(err, &inErr) || inErr.Code != CodeOutOfRange {
+		t.Errorf("Q15 1.0 error = %v, want ErrOverflow", err)
+	}
+	if _, err := FixedPointToHex(-0.5, 8, 8, false, BigEndian); !errors.Is(err, ErrOverflow) {
+		t.Errorf("UQ8.8 -0.5 error = %v, want ErrOverflow", err)
+	}
+	if _, err := FixedPointToHex(math.NaN(), 0, 15, true, BigEndian); !errors.Is(err, ErrOverflow) {
+		t.Errorf("NaN error = %v, want ErrOverflow", err)
+	}
+	if _, err := HexToFixedPoint("4000", 0, 14, true, BigEndian); !errors.Is(err, ErrInvalidQFormat) || !errors.As(err, &inErr) || inErr.Code != CodeUnsupportedType {
+		t.Errorf("15-bit format error = %v, want ErrInvalidQFormat", err)
+	}
+	if _, err := HexToFixedPoint("400000", 0, 23, true, MidBigEndian); !errors.Is(err, ErrInvalidQFormat) {
+		t.Errorf("24-bit BADC error = %v, want ErrInvalidQFormat", err)
+	}
+	if _, err := HexToFixedPoint("400000", 0, 15, true, BigEndian); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("3 bytes as Q15 error = %v, want ErrInvalidLength", err)
+	}
+}
+
+func TestQFormatName(t *testing.T) {
+	for _, tt := range []struct {
+		intBits, fracBits int
+		signed            bool
+		want              string
+	}{{0, 15, true, "Q15"}, {1, 14, true, "Q1.14"}, {8, 8, false, "UQ8.8"}} {
+		if got := QFormatName(tt.intBits, tt.fracBits, tt.signed); got != tt.want {
+			t.Errorf("QFormatName(%d, %d, %v) = %s, want %s", tt.intBits, tt.fracBits, tt.signed, got, tt.want)
+		}
+	}
+}
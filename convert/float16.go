@@ -0,0 +1,142 @@
+package convert
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// ============================================================================
+// Half-Precision Float Conversions
+// ============================================================================
+
+// Go has no float16 type, so half-precision values are returned as float32,
+// which holds every float16 value exactly.
+
+// Float16frombits returns the value of the IEEE 754 half-precision number
+// with the given bits. NaN payloads are kept.
+func Float16frombits(h uint16) float32 {
+	sign := uint32(h&0x8000) << 16
+	exp := uint32(h>>10) & 0x1f
+	mant := uint32(h & 0x3ff)
+
+	switch exp {
+	case 0:
+		// Zero or subnormal: mant × 2^-24
+		v := float32(math.Ldexp(float64(mant), -24))
+		if sign != 0 {
+			v = -v
+		}
+		return v
+	case 0x1f:
+		// Inf or NaN
+		return math.Float32frombits(sign | 0xff<<23 | mant<<13)
+	}
+	return math.Float32frombits(sign | (exp-15+127)<<23 | mant<<13)
+}
+
+// Float16bits returns the IEEE 754 half-precision bits of f, rounded to the
+// nearest value (ties to even). Values beyond ±65504 become ±Inf, values
+// below the smallest subnormal become ±0. NaN payloads keep their upper bits.
+func Float16bits(f float32) uint16 {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & 0x8000
+	exp := int(bits>>23) & 0xff
+	mant := bits & 0x7fffff
+
+	if exp == 0xff {
+		if mant == 0 {
+			return sign | 0x7c00
+		}
+		payload := uint16(mant >> 13)
+		if payload == 0 {
+			payload = 0x200 // keep it a NaN
+		}
+		return sign | 0x7c00 | payload
+	}
+
+	e := exp - 127 + 15
+	if e >= 0x1f {
+		return sign | 0x7c00
+	}
+	if e <= 0 {
+		// Subnormal in half precision: shift the mantissa with its implicit
+		// leading one down to units of 2^-24
+		shift := uint(14 - e)
+		if shift > 24 {
+			return sign
+		}
+		return sign | uint16(roundShift(mant|0x800000, shift))
+	}
+	// A carry out of the mantissa correctly increments the exponent, up to Inf
+	return sign | uint16(uint32(e)<<10+roundShift(mant, 13))
+}
+
+// roundShift returns v >> shift, rounded to the nearest integer with ties to
+// even.
+func roundShift(v uint32, shift uint) uint32 {
+	q := v >> shift
+	rem := v & (1<<shift - 1)
+	half := uint32(1) << (shift - 1)
+	if rem > half || (rem == half && q&1 == 1) {
+		q++
+	}
+	return q
+}
+
+// HexToFloat16 converts a hex string to a float16 (big-endian).
+func HexToFloat16(hexStr string) (float32, error) {
+	bits, err := hexToInt[uint16](hexStr, 2, BigEndian)
+	if err != nil {
+		return 0, err
+	}
+	return Float16frombits(bits), nil
+}
+
+// HexToFloat16LE converts a hex string to a float16 (little-endian).
+func HexToFloat16LE(hexStr string) (float32, error) {
+	bits, err := hexToInt[uint16](hexStr, 2, LittleEndian)
+	if err != nil {
+		return 0, err
+	}
+	return Float16frombits(bits), nil
+}
+
+// HexToFloat16BADC converts a hex string to a float16 (mid-big-endian/BADC).
+// For 2-byte values this is the same as big-endian.
+func HexToFloat16BADC(hexStr string) (float32, error) {
+	bits, err := hexToInt[uint16](hexStr, 2, MidBigEndian)
+	if err != nil {
+		return 0, err
+	}
+	return Float16frombits(bits), nil
+}
+
+// HexToFloat16CDAB converts a hex string to a float16 (mid-little-endian/CDAB).
+// For 2-byte values this is the same as little-endian.
+func HexToFloat16CDAB(hexStr string) (float32, error) {
+	bits, err := hexToInt[uint16](hexStr, 2, MidLittleEndian)
+	if err != nil {
+		return 0, err
+	}
+	return Float16frombits(bits), nil
+}
+
+// Float16ToHex converts a value to a float16 hex string (big-endian).
+func Float16ToHex(f float32) string {
+	return intToHex(Float16bits(f), 2, binary.BigEndian)
+}
+
+// Float16ToHexLE converts a value to a float16 hex string (little-endian).
+func Float16ToHexLE(f float32) string {
+	return intToHex(Float16bits(f), 2, binary.LittleEndian)
+}
+
+// Float16ToHexBADC converts a value to a float16 hex string (mid-big-endian/BADC).
+func Float16ToHexBADC(f float32) string {
+	return intToHexBADC(Float16bits(f), 2)
+}
+
+// Float16ToHexCDAB converts a value to a float16 hex string (mid-little-endian/CDAB).
+func Float16ToHexCDAB(f float32) string {
+	return intToHexCDAB(Float16bits(f), 2)
+}
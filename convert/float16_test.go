@@ -0,0 +1,122 @@
+package convert
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHexToFloat16(t *testing.T) {
+	tests := []struct {
+		name    string
+		hex     string
+		want    float32
+		wantErr bool
+	}{
+		{"zero", "0000", 0, false},
+		{"one", "3c00", 1, false},
+		{"negative two", "c000", -2, false},
+		{"one third", "3555", 0.33325195, false},
+		{"max", "7bff", 65504, false},
+		{"smallest normal", "0400", 6.1035156e-05, false},
+		{"smallest subnormal", "0001", 5.9604645e-08, false},
+		{"largest subnormal", "03ff", 6.097555e-05, false},
+		{"auto-pad 1 byte", "01", 5.9604645e-08, false},
+		{"overflow - too many bytes", "3c0000", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HexToFloat16(tt.hex)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("HexToFloat16() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("HexToFloat16() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHexToFloat16Special(t *testing.T) {
+	if v, _ := HexToFloat16("7c00"); !math.IsInf(float64(v), 1) {
+		t.Errorf("7c00 = %v, want +Inf", v)
+	}
+	if v, _ := HexToFloat16("fc00"); !math.IsInf(float64(v), -1) {
+		t.Errorf("fc00 = %v, want -Inf", v)
+	}
+	if v, _ := HexToFloat16("8000"); v != 0 || !math.Signbit(float64(v)) {
+		t.Errorf("8000 = %v, want -0", v)
+	}
+	v, _ := HexToFloat16("7e01")
+	if !math.IsNaN(float64(v)) {
+		t.Fatalf("7e01 = %v, want NaN", v)
+	}
+	if got := Float16bits(v); got != 0x7e01 {
+		t.Errorf("NaN payload round trip = %04x, want 7e01", got)
+	}
+}
+
+func TestFloat16ByteOrders(t *testing.T) {
+	if v, _ := HexToFloat16LE("003c"); v != 1 {
+		t.Errorf("HexToFloat16LE(003c) = %v, want 1", v)
+	}
+	if v, _ := HexToFloat16BADC("3c00"); v != 1 {
+		t.Errorf("HexToFloat16BADC(3c00) = %v, want 1", v)
+	}
+	if v, _ := HexToFloat16CDAB("003c"); v != 1 {
+		t.Errorf("HexToFloat16CDAB(003c) = %v, want 1", v)
+	}
+	for name, got := range map[string]string{
+		"Float16ToHex":     Float16ToHex(1.5),
+		"Float16ToHexLE":   Float16ToHexLE(1.5),
+		"Float16ToHexBADC": Float16ToHexBADC(1.5),
+		"Float16ToHexCDAB": Float16ToHexCDAB(1.5),
+	} {
+		// Like the other ToHex functions, the value is shown big-endian
+		if got != "3e00" {
+			t.Errorf("%s(1.5) = %s, want 3e00", name, got)
+		}
+	}
+}
+
+func TestFloat16bits(t *testing.T) {
+	tests := []struct {
+		name string
+		f    float32
+		want uint16
+	}{
+		{"one", 1, 0x3c00},
+		{"negative zero", float32(math.Copysign(0, -1)), 0x8000},
+		{"0.1 rounds down", 0.1, 0x2e66},
+		{"max", 65504, 0x7bff},
+		{"rounds up to max", 65519, 0x7bff},
+		{"overflows to Inf", 65520, 0x7c00},
+		{"-Inf", float32(math.Inf(-1)), 0xfc00},
+		{"tie to even down", 2049, 0x6800},
+		{"tie to even up", 2051, 0x6802},
+		{"smallest subnormal", 5.9604645e-08, 0x0001},
+		{"half subnormal ties to zero", 2.9802322e-08, 0x0000},
+		{"above half subnormal", 3e-08, 0x0001},
+		{"subnormal rounds to normal", 6.1033e-05, 0x0400},
+		{"underflow", 1e-10, 0x0000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Float16bits(tt.f); got != tt.want {
+				t.Errorf("Float16bits(%v) = %04x, want %04x", tt.f, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFloat16RoundTrip(t *testing.T) {
+	for h := 0; h <= 0xffff; h++ {
+		v := Float16frombits(uint16(h))
+		if math.IsNaN(float64(v)) {
+			continue
+		}
+		if got := Float16bits(v); got != uint16(h) {
+			t.Fatalf("Float16bits(Float16frombits(%04x)) = %04x", h, got)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package convert
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+	"testing/quick"
+	"unicode/utf8"
+)
+
+// checkInputError checks that err is an InputError whose location lies
+// within input.
+func checkInputError(t *testing.T, input string, err error) {
+	t.Helper()
+	var inErr *InputError
+	if !errors.As(err, &inErr) {
+		t.Fatalf("error %v (%T) is not an InputError", err, err)
+	}
+	if inErr.Offset == -1 {
+		return
+	}
+	atChar := inErr.Offset == len(input)
+	for i := range input {
+		atChar = atChar || i == inErr.Offset
+	}
+	if !atChar {
+		t.Errorf("offset %d of %q is not at a character", inErr.Offset, input)
+	}
+	if n := utf8.RuneCountInString(input); inErr.Position < 0 || inErr.Position+inErr.Length > n {
+		t.Errorf("position %d, length %d outside of %q (%d characters)", inErr.Position, inErr.Length, input, n)
+	}
+}
+
+func FuzzParseHex(f *testing.F) {
+	for _, seed := range []string{"0x1234", "12 34:56,78", "0xAB 0xcd", "abc", "x12", "12zz", "12 34", "ï¿½", "\xa0\xff", "0x"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		b, err := ParseHex(input)
+		if err != nil {
+			checkInputError(t, input, err)
+			return
+		}
+		if len(b) == 0 || len(b) > (len(input)+1)/2 {
+			t.Fatalf("ParseHex(%q) returned %d bytes", input, len(b))
+		}
+		again, err := ParseHex(BytesToHex(b))
+		if err != nil || !bytes.Equal(again, b) {
+			t.Fatalf("ParseHex(BytesToHex(%x)) = %x, %v", b, again, err)
+		}
+	})
+}
+
+func FuzzParseBinary(f *testing.F) {
+	for _, seed := range []string{"00000001", "1111 0000", "1_0-1:0,1", "102", "1 0", "\xff"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		b, err := ParseBinary(input)
+		if err != nil {
+			checkInputError(t, input, err)
+			return
+		}
+		again, err := ParseBinary(BytesToBinary(b))
+		if err != nil || !bytes.Equal(again, b) {
+			t.Fatalf("ParseBinary(BytesToBinary(%x)) = %x, %v", b, again, err)
+		}
+	})
+}
+
+func FuzzBytesTo(f *testing.F) {
+	f.Add([]byte{}, uint8(0))
+	f.Add([]byte{1, 2, 3, 4, 5, 6, 7, 8}, uint8(3))
+	f.Fuzz(func(t *testing.T, b []byte, o uint8) {
+		order := ByteOrder(o % 4)
+		in := slices.Clone(b)
+		_, err16 := BytesToUint16(b, order)
+		_, err32 := BytesToInt32(b, order)
+		_, err64 := BytesToFloat64(b, order)
+		if !bytes.Equal(b, in) {
+			t.Fatalf("BytesTo* changed the input %x to %x", in, b)
+		}
+		for _, c := range []struct {
+			err  error
+			size int
+		}{{err16, 2}, {err32, 4}, {err64, 8}} {
+			if (c.err == nil) != (len(b) > 0 && len(b) <= c.size) {
+				t.Errorf("%d bytes read as %d-byte value: error %v", len(b), c.size, c.err)
+			}
+		}
+	})
+}
+
+// reorder returns b, stored in order, in big-endian order. Like the
+// Modbus conventions, 16-bit BADC values are big-endian and 16-bit CDAB
+// values little-endian; 64-bit CDAB values swap the words of each half.
+func reorder(b []byte, order ByteOrder) []byte {
+	out := slices.Clone(b)
+	switch {
+	case order == LittleEndian, order == MidLittleEndian && len(out) == 2:
+		slices.Reverse(out)
+	case order == MidBigEndian && len(out) >= 4:
+		for i := 0; i+1 < len(out); i += 2 {
+			out[i], out[i+1] = out[i+1], out[i]
+		}
+	case order == MidLittleEndian:
+		for i := 0; i+3 < len(out); i += 4 {
+			out[i], out[i+1], out[i+2], out[i+3] = out[i+2], out[i+3], out[i], out[i+1]
+		}
+	}
+	return out
+}
+
+// TestRoundTripHex checks hex → value → hex for all byte orders: the hex of
+// a value read in any order is its bytes in big-endian order.
+func TestRoundTripHex(t *testing.T) {
+	orders := []ByteOrder{BigEndian, LittleEndian, MidBigEndian, MidLittleEndian}
+	property := func(b [8]byte) bool {
+		for _, order := range orders {
+			v16, _ := BytesToUint16(b[:2], order)
+			v32, _ := BytesToInt32(b[:4], order)
+			v64, _ := BytesToUint64(b[:], order)
+			f32, _ := BytesToFloat32(b[:4], order)
+			f64, _ := BytesToFloat64(b[:], order)
+			want16, want32, want64 := BytesToHex(reorder(b[:2], order)), BytesToHex(reorder(b[:4], order)), BytesToHex(reorder(b[:], order))
+			if Uint16ToHex(v16) != want16 || Int32ToHex(v32) != want32 || Uint64ToHex(v64) != want64 ||
+				Float32ToHex(f32) != want32 || Float64ToHex(f64) != want64 {
+				t.Logf("%x in %s: %s %s %s %s %s", b, order, Uint16ToHex(v16), Int32ToHex(v32), Uint64ToHex(v64), Float32ToHex(f32), Float64ToHex(f64))
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestRoundTripBinary checks value → binary → value for all byte orders.
+func TestRoundTripBinary(t *testing.T) {
+	property := func(v16 int16, v32 uint32, v64 int64) bool {
+		type roundTrip struct {
+			name string
+			ok   func() bool
+		}
+		checks := []roundTrip{
+			{"int16 BE", func() bool { v, err := BinaryToInt16(Int16ToBinary(v16)); return err == nil && v == v16 }},
+			{"int16 LE", func() bool { v, err := BinaryToInt16LE(Int16ToBinaryLE(v16)); return err == nil && v == v16 }},
+			{"int16 BADC", func() bool { v, err := BinaryToInt16BADC(Int16ToBinaryBADC(v16)); return err == nil && v == v16 }},
+			{"int16 CDAB", func() bool { v, err := BinaryToInt16CDAB(Int16ToBinaryCDAB(v16)); return err == nil && v == v16 }},
+			{"uint32 BE", func() bool { v, err := BinaryToUint32(Uint32ToBinary(v32)); return err == nil && v == v32 }},
+			{"uint32 LE", func() bool { v, err := BinaryToUint32LE(Uint32ToBinaryLE(v32)); return err == nil && v == v32 }},
+			{"uint32 BADC", func() bool { v, err := BinaryToUint32BADC(Uint32ToBinaryBADC(v32)); return err == nil && v == v32 }},
+			{"uint32 CDAB", func() bool { v, err := BinaryToUint32CDAB(Uint32ToBinaryCDAB(v32)); return err == nil && v == v32 }},
+			{"int64 BE", func() bool { v, err := BinaryToInt64(Int64ToBinary(v64)); return err == nil && v == v64 }},
+			{"int64 LE", func() bool { v, err := BinaryToInt64LE(Int64ToBinaryLE(v64)); return err == nil && v == v64 }},
+			{"int64 BADC", func() bool { v, err := BinaryToInt64BADC(Int64ToBinaryBADC(v64)); return err == nil && v == v64 }},
+			{"int64 CDAB", func() bool { v, err := BinaryToInt64CDAB(Int64ToBinaryCDAB(v64)); return err == nil && v == v64 }},
+		}
+		for _, c := range checks {
+			if !c.ok() {
+				t.Logf("%s round trip failed for %d, %d, %d", c.name, v16, v32, v64)
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestRoundTripHexValues checks value → hex → value. The hex of a value is
+// its big-endian form whatever the byte order it was read in, so it always
+// reads back as big-endian.
+func TestRoundTripHexValues(t *testing.T) {
+	property := func(v16 uint16, v32 int32, v64 uint64) bool {
+		hex16 := []string{Uint16ToHex(v16), Uint16ToHexLE(v16), Uint16ToHexBADC(v16), Uint16ToHexCDAB(v16)}
+		hex32 := []string{Int32ToHex(v32), Int32ToHexLE(v32), Int32ToHexBADC(v32), Int32ToHexCDAB(v32)}
+		hex64 := []string{Uint64ToHex(v64), Uint64ToHexLE(v64), Uint64ToHexBADC(v64), Uint64ToHexCDAB(v64)}
+		for i := range hex16 {
+			r16, e16 := HexToUint16(hex16[i])
+			r32, e32 := HexToInt32(hex32[i])
+			r64, e64 := HexToUint64(hex64[i])
+			if e16 != nil || e32 != nil || e64 != nil || r16 != v16 || r32 != v32 || r64 != v64 {
+				t.Logf("order %s: %s %s %s", ByteOrder(i), hex16[i], hex32[i], hex64[i])
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
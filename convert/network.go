@@ -0,0 +1,100 @@
+package convert
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+)
+
+// ============================================================================
+// Network addresses (IPv4, IPv6, MAC)
+// ============================================================================
+
+// ErrInvalidAddress indicates text that is not an IP or MAC address
+var ErrInvalidAddress = errors.New("invalid network address")
+
+// BytesToIPv4 formats 4 bytes in network order as a dotted quad, e.g.
+// c0a80001 → "192.168.0.1".
+func BytesToIPv4(b []byte) (string, error) {
+	if len(b) != 4 {
+		return "", errLength(4, len(b))
+	}
+	return netip.AddrFrom4([4]byte(b)).String(), nil
+}
+
+// BytesToIPv6 formats 16 bytes in network order as an IPv6 address in the
+// RFC 5952 form, e.g. "2001:db8::1". IPv4-mapped addresses keep the dotted
+// quad: "::ffff:192.168.0.1".
+func BytesToIPv6(b []byte) (string, error) {
+	if len(b) != 16 {
+		return "", errLength(16, len(b))
+	}
+	return netip.AddrFrom16([16]byte(b)).String(), nil
+}
+
+// BytesToMAC formats 6 bytes as a colon-separated MAC address, e.g.
+// "00:1a:2b:3c:4d:5e".
+func BytesToMAC(b []byte) (string, error) {
+	if len(b) != 6 {
+		return "", errLength(6, len(b))
+	}
+	return net.HardwareAddr(b).String(), nil
+}
+
+// IPToHex encodes an IPv4 or IPv6 address as hex in network order, e.g.
+// "192.168.0.1" → "c0a80001".
+func IPToHex(addr string) (string, error) {
+	a, err := netip.ParseAddr(strings.TrimSpace(addr))
+	if err != nil || a.Zone() != "" {
+		return "", NewInputError(CodeInvalidNumber, ErrInvalidAddress,
+			fmt.Sprintf("%v: %q is not an IPv4 or IPv6 address", ErrInvalidAddress, addr))
+	}
+	return BytesToHex(a.AsSlice()), nil
+}
+
+// MACToHex encodes a MAC address written with colons, dashes or dots
+// (00:1a:2b:3c:4d:5e, 00-1A-2B-3C-4D-5E, 001a.2b3c.4d5e) as hex.
+func MACToHex(addr string) (string, error) {
+	mac, err := net.ParseMAC(strings.TrimSpace(addr))
+	if err != nil || len(mac) != 6 {
+		return "", NewInputError(CodeInvalidNumber, ErrInvalidAddress,
+			fmt.Sprintf("%v: %q is not a 6-byte MAC address", ErrInvalidAddress, addr))
+	}
+	return BytesToHex(mac), nil
+}
+
+//go:embed oui.txt
+var ouiTable string
+
+// ouis maps the first three bytes of a MAC address to the vendor.
+var ouis = sync.OnceValue(func() map[[3]byte]string {
+	m := make(map[[3]byte]string)
+	for _, line := range strings.Split(ouiTable, "\n") {
+		prefix, vendor, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok || strings.HasPrefix(prefix, "#") {
+			continue
+		}
+		b, err := HexToBytes(prefix)
+		if err != nil || len(b) != 3 {
+			continue
+		}
+		m[[3]byte(b)] = vendor
+	}
+	return m
+})
+
+// LookupOUI returns the vendor of the organizationally unique identifier in
+// the first three bytes of a MAC address. The embedded table lists common
+// vendors of network interfaces, virtual NICs and industrial devices, not
+// the whole IEEE registry; false means the OUI is not in it.
+func LookupOUI(mac []byte) (string, bool) {
+	if len(mac) < 3 {
+		return "", false
+	}
+	vendor, ok := ouis()[[3]byte(mac)]
+	return vendor, ok
+}
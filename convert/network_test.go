@@ -0,0 +1,95 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBytesToAddress(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		fn    func([]byte) (string, error)
+		want  string
+	}{
+		{"ipv4", "c0a80001", BytesToIPv4, "192.168.0.1"},
+		{"ipv4 broadcast", "ffffffff", BytesToIPv4, "255.255.255.255"},
+		{"ipv6", "20010db8000000000000000000000001", BytesToIPv6, "2001:db8::1"},
+		{"ipv6 link-local", "fe800000000000000211 22ff fe33 4455", BytesToIPv6, "fe80::211:22ff:fe33:4455"},
+		{"ipv4-mapped ipv6", "00000000000000000000ffffc0a80001", BytesToIPv6, "::ffff:192.168.0.1"},
+		{"mac", "001a2b3c4d5e", BytesToMAC, "00:1a:2b:3c:4d:5e"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := HexToBytes(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := tt.fn(b)
+			if err != nil || got != tt.want {
+				t.Errorf("got %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+
+	if _, err := BytesToIPv4([]byte{1, 2, 3}); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("BytesToIPv4(3 bytes) error = %v, want ErrInvalidLength", err)
+	}
+	if _, err := BytesToMAC(make([]byte, 8)); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("BytesToMAC(8 bytes) error = %v, want ErrInvalidLength", err)
+	}
+}
+
+func TestAddressToHex(t *testing.T) {
+	tests := []struct {
+		input   string
+		fn      func(string) (string, error)
+		want    string
+		wantErr error
+	}{
+		{"10.0.0.254", IPToHex, "0a0000fe", nil},
+		{" 2001:db8::1 ", IPToHex, "20010db8000000000000000000000001", nil},
+		{"::ffff:10.0.0.1", IPToHex, "00000000000000000000ffff0a000001", nil},
+		{"256.0.0.1", IPToHex, "", ErrInvalidAddress},
+		{"fe80::1%eth0", IPToHex, "", ErrInvalidAddress},
+		{"00:1A:2B:3C:4D:5E", MACToHex, "001a2b3c4d5e", nil},
+		{"00-1a-2b-3c-4d-5e", MACToHex, "001a2b3c4d5e", nil},
+		{"001a.2b3c.4d5e", MACToHex, "001a2b3c4d5e", nil},
+		{"00:1a:2b:3c:4d", MACToHex, "", ErrInvalidAddress},
+		{"02:00:5e:10:00:00:00:01", MACToHex, "", ErrInvalidAddress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := tt.fn(tt.input)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("got %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupOUI(t *testing.T) {
+	tests := []struct {
+		mac    []byte
+		vendor string
+		ok     bool
+	}{
+		{[]byte{0x00, 0x50, 0x56, 0x01, 0x02, 0x03}, "VMware", true},
+		{[]byte{0xb8, 0x27, 0xeb, 0x12, 0x34, 0x56}, "Raspberry Pi Foundation", true},
+		{[]byte{0x00, 0x00, 0x0c}, "Cisco Systems", true},
+		{[]byte{0x12, 0x34, 0x56, 0x00, 0x00, 0x00}, "", false},
+		{[]byte{0x00, 0x50}, "", false},
+	}
+	for _, tt := range tests {
+		vendor, ok := LookupOUI(tt.mac)
+		if vendor != tt.vendor || ok != tt.ok {
+			t.Errorf("LookupOUI(%x) = %q, %v, want %q, %v", tt.mac, vendor, ok, tt.vendor, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package convert
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// ============================================================================
+// One's Complement and Sign-Magnitude Integers
+// ============================================================================
+
+// Legacy instruments and protocols store negative numbers as the one's
+// complement of the positive value (all bits inverted) or as sign and
+// magnitude (the top bit set), instead of the two's complement of the Int
+// functions. Both have a negative zero, which reads as 0, and their range is
+// one smaller than two's complement, e.g. -127 to 127 for 8 bits. Like the
+// BytesTo and HexTo functions, the readers accept fewer bytes than the type
+// holds.
+
+// signed is the constraint of the signed integer types.
+type signed interface {
+	~int8 | ~int16 | ~int32 | ~int64
+}
+
+// BytesToOnesComplement8 reads a one's complement int8 from b.
+func BytesToOnesComplement8(b []byte) (int8, error) {
+	return bytesToOnesComplement[int8](b, 1, BigEndian)
+}
+
+// BytesToOnesComplement16 reads a one's complement int16 from b in the given
+// byte order.
+func BytesToOnesComplement16(b []byte, order ByteOrder) (int16, error) {
+	return bytesToOnesComplement[int16](b, 2, order)
+}
+
+// BytesToOnesComplement32 reads a one's complement int32 from b in the given
+// byte order.
+func BytesToOnesComplement32(b []byte, order ByteOrder) (int32, error) {
+	return bytesToOnesComplement[int32](b, 4, order)
+}
+
+// BytesToOnesComplement64 reads a one's complement int64 from b in the given
+// byte order.
+func BytesToOnesComplement64(b []byte, order ByteOrder) (int64, error) {
+	return bytesToOnesComplement[int64](b, 8, order)
+}
+
+// BytesToSignMagnitude8 reads a sign-magnitude int8 from b.
+func BytesToSignMagnitude8(b []byte) (int8, error) {
+	return bytesToSignMagnitude[int8](b, 1, BigEndian)
+}
+
+// BytesToSignMagnitude16 reads a sign-magnitude int16 from b in the given
+// byte order.
+func BytesToSignMagnitude16(b []byte, order ByteOrder) (int16, error) {
+	return bytesToSignMagnitude[int16](b, 2, order)
+}
+
+// BytesToSignMagnitude32 reads a sign-magnitude int32 from b in the given
+// byte order.
+func BytesToSignMagnitude32(b []byte, order ByteOrder) (int32, error) {
+	return bytesToSignMagnitude[int32](b, 4, order)
+}
+
+// BytesToSignMagnitude64 reads a sign-magnitude int64 from b in the given
+// byte order.
+func BytesToSignMagnitude64(b []byte, order ByteOrder) (int64, error) {
+	return bytesToSignMagnitude[int64](b, 8, order)
+}
+
+// HexToOnesComplement8 converts a hex string to a one's complement int8.
+func HexToOnesComplement8(hexStr string) (int8, error) {
+	return hexToSigned(hexStr, BytesToOnesComplement8)
+}
+
+// HexToOnesComplement16 converts a hex string to a one's complement int16
+// (big-endian).
+func HexToOnesComplement16(hexStr string) (int16, error) {
+	return hexToSigned(hexStr, func(b []byte) (int16, error) { return BytesToOnesComplement16(b, BigEndian) })
+}
+
+// HexToOnesComplement32 converts a hex string to a one's complement int32
+// (big-endian).
+func HexToOnesComplement32(hexStr string) (int32, error) {
+	return hexToSigned(hexStr, func(b []byte) (int32, error) { return BytesToOnesComplement32(b, BigEndian) })
+}
+
+// HexToOnesComplement64 converts a hex string to a one's complement int64
+// (big-endian).
+func HexToOnesComplement64(hexStr string) (int64, error) {
+	return hexToSigned(hexStr, func(b []byte) (int64, error) { return BytesToOnesComplement64(b, BigEndian) })
+}
+
+// HexToSignMagnitude8 converts a hex string to a sign-magnitude int8.
+func HexToSignMagnitude8(hexStr string) (int8, error) {
+	return hexToSigned(hexStr, BytesToSignMagnitude8)
+}
+
+// HexToSignMagnitude16 converts a hex string to a sign-magnitude int16
+// (big-endian).
+func HexToSignMagnitude16(hexStr string) (int16, error) {
+	return hexToSigned(hexStr, func(b []byte) (int16, error) { return BytesToSignMagnitude16(b, BigEndian) })
+}
+
+// HexToSignMagnitude32 converts a hex string to a sign-magnitude int32
+// (big-endian).
+func HexToSignMagnitude32(hexStr string) (int32, error) {
+	return hexToSigned(hexStr, func(b []byte) (int32, error) { return BytesToSignMagnitude32(b, BigEndian) })
+}
+
+// HexToSignMagnitude64 converts a hex string to a sign-magnitude int64
+// (big-endian).
+func HexToSignMagnitude64(hexStr string) (int64, error) {
+	return hexToSigned(hexStr, func(b []byte) (int64, error) { return BytesToSignMagnitude64(b, BigEndian) })
+}
+
+// OnesComplement8ToHex converts an int8 to one's complement hex. -128 has no
+// one's complement form and is an ErrOverflow.
+func OnesComplement8ToHex(n int8) (string, error) {
+	return onesComplementToHex(n, 1)
+}
+
+// OnesComplement16ToHex converts an int16 to one's complement hex
+// (big-endian).
+func OnesComplement16ToHex(n int16) (string, error) {
+	return onesComplementToHex(n, 2)
+}
+
+// OnesComplement32ToHex converts an int32 to one's complement hex
+// (big-endian).
+func OnesComplement32ToHex(n int32) (string, error) {
+	return onesComplementToHex(n, 4)
+}
+
+// OnesComplement64ToHex converts an int64 to one's complement hex
+// (big-endian).
+func OnesComplement64ToHex(n int64) (string, error) {
+	return onesComplementToHex(n, 8)
+}
+
+// SignMagnitude8ToHex converts an int8 to sign-magnitude hex. -128 has no
+// sign-magnitude form and is an ErrOverflow.
+func SignMagnitude8ToHex(n int8) (string, error) {
+	return signMagnitudeToHex(n, 1)
+}
+
+// SignMagnitude16ToHex converts an int16 to sign-magnitude hex (big-endian).
+func SignMagnitude16ToHex(n int16) (string, error) {
+	return signMagnitudeToHex(n, 2)
+}
+
+// SignMagnitude32ToHex converts an int32 to sign-magnitude hex (big-endian).
+func SignMagnitude32ToHex(n int32) (string, error) {
+	return signMagnitudeToHex(n, 4)
+}
+
+// SignMagnitude64ToHex converts an int64 to sign-magnitude hex (big-endian).
+func SignMagnitude64ToHex(n int64) (string, error) {
+	return signMagnitudeToHex(n, 8)
+}
+
+// bytesToOnesComplement reads byteSize bytes as a one's complement integer:
+// with the sign bit set, the value is the negated inverse of the bits.
+func bytesToOnesComplement[T signed](b []byte, byteSize int, order ByteOrder) (T, error) {
+	u, err := bytesToInt[uint64](b, byteSize, order)
+	if err != nil {
+		return 0, err
+	}
+	bits := 8 * byteSize
+	if u>>(bits-1) == 0 {
+		return T(u), nil
+	}
+	return -T(^u & (1<<bits - 1)), nil
+}
+
+// bytesToSignMagnitude reads byteSize bytes as a sign-magnitude integer:
+// the top bit is the sign, the others the magnitude.
+func bytesToSignMagnitude[T signed](b []byte, byteSize int, order ByteOrder) (T, error) {
+	u, err := bytesToInt[uint64](b, byteSize, order)
+	if err != nil {
+		return 0, err
+	}
+	sign := uint64(1) << (8*byteSize - 1)
+	if u&sign == 0 {
+		return T(u), nil
+	}
+	return -T(u &^ sign), nil
+}
+
+// hexToSigned parses hexStr and reads it with read.
+func hexToSigned[T signed](hexStr string, read func([]byte) (T, error)) (T, error) {
+	b, err := ParseHex(hexStr)
+	if err != nil {
+		return 0, err
+	}
+	return read(b)
+}
+
+// errNoForm returns the ErrOverflow of the most negative value of a type,
+// which one's complement and sign-magnitude cannot represent.
+func errNoForm[T signed](n T, form string) error {
+	return NewInputError(CodeOutOfRange, ErrOverflow, fmt.Sprintf("%v: %d has no %s form", ErrOverflow, n, form))
+}
+
+// onesComplementToHex encodes n in one's complement of byteSize bytes.
+func onesComplementToHex[T signed](n T, byteSize int) (string, error) {
+	if n == minSigned[T](byteSize) {
+		return "", errNoForm(n, "one's complement")
+	}
+	if n < 0 {
+		return intToHex(^uint64(-n), byteSize, binary.BigEndian), nil
+	}
+	return intToHex(uint64(n), byteSize, binary.BigEndian), nil
+}
+
+// signMagnitudeToHex encodes n in sign-magnitude of byteSize bytes.
+func signMagnitudeToHex[T signed](n T, byteSize int) (string, error) {
+	if n == minSigned[T](byteSize) {
+		return "", errNoForm(n, "sign-magnitude")
+	}
+	if n < 0 {
+		return intToHex(uint64(-n)|1<<(8*byteSize-1), byteSize, binary.BigEndian), nil
+	}
+	return intToHex(uint64(n), byteSize, binary.BigEndian), nil
+}
+
+// minSigned returns the most negative value of a signed type of byteSize
+// bytes.
+func minSigned[T signed](byteSize int) T {
+	return T(int64(-1) << (8*byteSize - 1))
+}
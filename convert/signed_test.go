@@ -0,0 +1,106 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOnesComplementAndSignMagnitude(t *testing.T) {
+	tests := []struct {
+		name  string
+		hex   string
+		ones  int64
+		sign  int64
+		twos  int64
+		width int
+	}{
+		{"8-bit positive", "7f", 127, 127, 127, 8},
+		{"8-bit all ones", "ff", 0, -127, -1, 8},
+		{"8-bit sign only", "80", -127, 0, -128, 8},
+		{"8-bit minus five", "fa", -5, -122, -6, 8},
+		{"16-bit", "fffe", -1, -32766, -2, 16},
+		{"16-bit minus 300", "812c", -32467, -300, -32468, 16},
+		{"32-bit", "fffffffe", -1, -2147483646, -2, 32},
+		{"64-bit", "8000000000000001", -9223372036854775806, -1, -9223372036854775807, 64},
+		{"short input", "fe", 254, 254, 254, 16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ones, sign int64
+			var err1, err2 error
+			switch tt.width {
+			case 8:
+				o, e1 := HexToOnesComplement8(tt.hex)
+				s, e2 := HexToSignMagnitude8(tt.hex)
+				ones, sign, err1, err2 = int64(o), int64(s), e1, e2
+			case 16:
+				o, e1 := HexToOnesComplement16(tt.hex)
+				s, e2 := HexToSignMagnitude16(tt.hex)
+				ones, sign, err1, err2 = int64(o), int64(s), e1, e2
+			case 32:
+				o, e1 := HexToOnesComplement32(tt.hex)
+				s, e2 := HexToSignMagnitude32(tt.hex)
+				ones, sign, err1, err2 = int64(o), int64(s), e1, e2
+			case 64:
+				ones, err1 = HexToOnesComplement64(tt.hex)
+				sign, err2 = HexToSignMagnitude64(tt.hex)
+			}
+			if err1 != nil || err2 != nil {
+				t.Fatalf("errors: %v, %v", err1, err2)
+			}
+			if ones != tt.ones {
+				t.Errorf("one's complement of %s = %d, want %d", tt.hex, ones, tt.ones)
+			}
+			if sign != tt.sign {
+				t.Errorf("sign-magnitude of %s = %d, want %d", tt.hex, sign, tt.sign)
+			}
+		})
+	}
+}
+
+func TestOnesComplementLittleEndian(t *testing.T) {
+	if v, err := BytesToOnesComplement16([]byte{0xfe, 0xff}, LittleEndian); err != nil || v != -1 {
+		t.Errorf("BytesToOnesComplement16(fe ff, LE) = %d, %v, want -1", v, err)
+	}
+	if v, err := BytesToSignMagnitude32([]byte{0x2c, 0x01, 0x00, 0x80}, LittleEndian); err != nil || v != -300 {
+		t.Errorf("BytesToSignMagnitude32(2c 01 00 80, LE) = %d, %v, want -300", v, err)
+	}
+	if _, err := BytesToOnesComplement16([]byte{1, 2, 3}, BigEndian); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("BytesToOnesComplement16 of 3 bytes error = %v, want ErrInvalidLength", err)
+	}
+}
+
+func TestOnesComplementToHex(t *testing.T) {
+	tests := []struct {
+		name string
+		got  func() (string, error)
+		want string
+	}{
+		{"ones 8 positive", func() (string, error) { return OnesComplement8ToHex(5) }, "05"},
+		{"ones 8 negative", func() (string, error) { return OnesComplement8ToHex(-5) }, "fa"},
+		{"ones 16", func() (string, error) { return OnesComplement16ToHex(-1) }, "fffe"},
+		{"ones 32", func() (string, error) { return OnesComplement32ToHex(-2147483647) }, "80000000"},
+		{"ones 64", func() (string, error) { return OnesComplement64ToHex(-1) }, "fffffffffffffffe"},
+		{"sign 8", func() (string, error) { return SignMagnitude8ToHex(-127) }, "ff"},
+		{"sign 16", func() (string, error) { return SignMagnitude16ToHex(-300) }, "812c"},
+		{"sign 32", func() (string, error) { return SignMagnitude32ToHex(300) }, "0000012c"},
+		{"sign 64", func() (string, error) { return SignMagnitude64ToHex(-1) }, "8000000000000001"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := tt.got(); err != nil || got != tt.want {
+				t.Errorf("got %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+
+	for _, err := range []error{
+		func() error { _, err := OnesComplement8ToHex(-128); return err }(),
+		func() error { _, err := SignMagnitude64ToHex(-9223372036854775808); return err }(),
+	} {
+		var inErr *InputError
+		if !errors.Is(err, ErrOverflow) || !errors.As(err, &inErr) || inErr.Code != CodeOutOfRange {
+			t.Errorf("most negative value error = %v, want ErrOverflow", err)
+		}
+	}
+}
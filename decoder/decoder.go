@@ -0,0 +1,145 @@
+// Package decoder defines the interface of protocol and format decoders and a
+// registry to look them up, so decoders can be added without changing the
+// conversion engine.
+//
+// Besides decoders compiled into hexview, external plugins are loaded from a
+// directory (see LoadDir). A plugin is any executable; hexview runs it with a
+// single argument and the raw bytes on stdin:
+//
+//	plugin accepts   exit status 0 if the plugin can decode the data
+//	plugin decode    print the decoded tree as JSON Node on stdout
+//
+// The decoder name is the file name without extension.
+//
+// Example usage:
+//
+//	reg := decoder.NewRegistry()
+//	reg.Register(decoder.Func("magic", accepts, decode))
+//	for _, d := range reg.Detect(data) {
+//		tree, _ := d.Decode(data)
+//		fmt.Println(tree.Name)
+//	}
+package decoder
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	// ErrDuplicateDecoder indicates a decoder with the same name is already registered
+	ErrDuplicateDecoder = errors.New("decoder already registered")
+
+	// ErrUnknownDecoder indicates no decoder with the given name is registered
+	ErrUnknownDecoder = errors.New("unknown decoder")
+)
+
+// Node is an element of a decoded tree. Offset and Length locate the element
+// in the decoded data; both are zero for purely logical groupings.
+type Node struct {
+	Name     string `json:"name"`
+	Value    string `json:"value,omitempty"`
+	Offset   int64  `json:"offset"`
+	Length   int64  `json:"length"`
+	Children []Node `json:"children,omitempty"`
+}
+
+// Decoder decodes a byte sequence into a tree.
+type Decoder interface {
+	// Name returns the unique name of the decoder.
+	Name() string
+	// Accepts reports whether the decoder recognizes data.
+	Accepts(data []byte) bool
+	// Decode decodes data into a tree.
+	Decode(data []byte) (*Node, error)
+}
+
+// funcDecoder adapts functions to the Decoder interface.
+type funcDecoder struct {
+	name    string
+	accepts func([]byte) bool
+	decode  func([]byte) (*Node, error)
+}
+
+// Func returns a Decoder calling the given functions.
+func Func(name string, accepts func([]byte) bool, decode func([]byte) (*Node, error)) Decoder {
+	return &funcDecoder{name: name, accepts: accepts, decode: decode}
+}
+
+func (d *funcDecoder) Name() string                      { return d.name }
+func (d *funcDecoder) Accepts(data []byte) bool          { return d.accepts(data) }
+func (d *funcDecoder) Decode(data []byte) (*Node, error) { return d.decode(data) }
+
+// Registry holds decoders by name. It is safe for concurrent use.
+type Registry struct {
+	mu       sync.RWMutex
+	decoders map[string]Decoder
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{decoders: make(map[string]Decoder)}
+}
+
+// Register adds d to the registry.
+func (r *Registry) Register(d Decoder) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.decoders[d.Name()]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateDecoder, d.Name())
+	}
+	r.decoders[d.Name()] = d
+	return nil
+}
+
+// Unregister removes the decoder with the given name, if any.
+func (r *Registry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.decoders, name)
+}
+
+// Get returns the decoder with the given name.
+func (r *Registry) Get(name string) (Decoder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	d, ok := r.decoders[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDecoder, name)
+	}
+	return d, nil
+}
+
+// All returns all decoders sorted by name.
+func (r *Registry) All() []Decoder {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	list := make([]Decoder, 0, len(r.decoders))
+	for _, d := range r.decoders {
+		list = append(list, d)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name() < list[j].Name() })
+	return list
+}
+
+// Detect returns the decoders accepting data, sorted by name.
+func (r *Registry) Detect(data []byte) []Decoder {
+	var accepted []Decoder
+	for _, d := range r.All() {
+		if d.Accepts(data) {
+			accepted = append(accepted, d)
+		}
+	}
+	return accepted
+}
+
+// Decode decodes data with the named decoder.
+func (r *Registry) Decode(name string, data []byte) (*Node, error) {
+	d, err := r.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return d.Decode(data)
+}
@@ -0,0 +1,110 @@
+package decoder
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func magicDecoder(name string, magic []byte) Decoder {
+	return Func(name,
+		func(data []byte) bool { return bytes.HasPrefix(data, magic) },
+		func(data []byte) (*Node, error) {
+			return &Node{Name: name, Length: int64(len(data)), Children: []Node{{Name: "magic", Length: int64(len(magic))}}}, nil
+		})
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	if err := r.Register(magicDecoder("zeta", []byte{0x7f})); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := r.Register(magicDecoder("alpha", []byte{0x7f, 'E'})); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := r.Register(magicDecoder("alpha", nil)); !errors.Is(err, ErrDuplicateDecoder) {
+		t.Errorf("duplicate Register() error = %v, want ErrDuplicateDecoder", err)
+	}
+
+	all := r.All()
+	if len(all) != 2 || all[0].Name() != "alpha" {
+		t.Errorf("All() not sorted: %v", all)
+	}
+
+	detected := r.Detect([]byte{0x7f, 'X'})
+	if len(detected) != 1 || detected[0].Name() != "zeta" {
+		t.Errorf("Detect() = %v, want [zeta]", detected)
+	}
+
+	node, err := r.Decode("alpha", []byte{0x7f, 'E', 0})
+	if err != nil || node.Length != 3 || len(node.Children) != 1 {
+		t.Errorf("Decode() = %+v, %v", node, err)
+	}
+	if _, err := r.Decode("missing", nil); !errors.Is(err, ErrUnknownDecoder) {
+		t.Errorf("Decode(missing) error = %v, want ErrUnknownDecoder", err)
+	}
+
+	r.Unregister("alpha")
+	if _, err := r.Get("alpha"); err == nil {
+		t.Error("expected alpha to be unregistered")
+	}
+}
+
+func TestExecPlugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins need a Unix shell")
+	}
+	dir := t.TempDir()
+	script := `#!/bin/sh
+case "$1" in
+accepts) [ "$(head -c 2)" = "HV" ] ;;
+decode) n=$(wc -c | tr -d ' '); echo "{\"name\":\"hv\",\"length\":$n,\"children\":[{\"name\":\"magic\",\"value\":\"HV\",\"length\":2}]}" ;;
+*) echo "unknown command" >&2; exit 2 ;;
+esac
+`
+	os.WriteFile(filepath.Join(dir, "hvframe.sh"), []byte(script), 0o755)
+	os.WriteFile(filepath.Join(dir, "README.txt"), []byte("not a plugin"), 0o644)
+	os.WriteFile(filepath.Join(dir, "broken"), []byte("#!/bin/sh\necho '{'\n"), 0o755)
+
+	plugins, err := LoadDir(dir)
+	if err != nil {
+		t.Fatalf("LoadDir() error = %v", err)
+	}
+	if len(plugins) != 2 {
+		t.Fatalf("LoadDir() found %d plugins, want 2", len(plugins))
+	}
+
+	var hv, broken *Exec
+	for _, p := range plugins {
+		switch p.Name() {
+		case "hvframe":
+			hv = p
+		case "broken":
+			broken = p
+		}
+	}
+	if hv == nil || broken == nil {
+		t.Fatalf("unexpected plugin names: %v", plugins)
+	}
+
+	if !hv.Accepts([]byte("HV\x01\x02")) || hv.Accepts([]byte("XX")) {
+		t.Error("Accepts() returned wrong result")
+	}
+	node, err := hv.Decode([]byte("HV\x01\x02"))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if node.Name != "hv" || node.Length != 4 || node.Children[0].Value != "HV" {
+		t.Errorf("Decode() = %+v", node)
+	}
+	if _, err := broken.Decode(nil); err == nil {
+		t.Error("expected error for invalid plugin output")
+	}
+
+	if plugins, err := LoadDir(filepath.Join(dir, "missing")); err != nil || plugins != nil {
+		t.Errorf("LoadDir(missing) = %v, %v", plugins, err)
+	}
+}
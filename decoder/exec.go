@@ -0,0 +1,112 @@
+package decoder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// PluginTimeout limits the run time of a single plugin invocation.
+const PluginTimeout = 5 * time.Second
+
+// Exec is a Decoder backed by an external plugin executable.
+type Exec struct {
+	name string
+	path string
+}
+
+// NewExec returns a Decoder running the plugin at path. The decoder name is
+// the file name without extension.
+func NewExec(path string) *Exec {
+	base := filepath.Base(path)
+	return &Exec{name: strings.TrimSuffix(base, filepath.Ext(base)), path: path}
+}
+
+// Name returns the decoder name.
+func (e *Exec) Name() string { return e.name }
+
+// Path returns the plugin executable.
+func (e *Exec) Path() string { return e.path }
+
+// Accepts runs "plugin accepts" and reports whether it exited with status 0.
+func (e *Exec) Accepts(data []byte) bool {
+	_, err := e.run("accepts", data)
+	return err == nil
+}
+
+// Decode runs "plugin decode" and parses its output as a JSON Node.
+func (e *Exec) Decode(data []byte) (*Node, error) {
+	out, err := e.run("decode", data)
+	if err != nil {
+		return nil, err
+	}
+	var node Node
+	if err := json.Unmarshal(out, &node); err != nil {
+		return nil, fmt.Errorf("plugin %s: invalid output: %w", e.name, err)
+	}
+	return &node, nil
+}
+
+// run executes the plugin with data on stdin and returns its stdout.
+func (e *Exec) run(command string, data []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), PluginTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, e.path, command)
+	cmd.Stdin = bytes.NewReader(data)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("plugin %s: %w: %s", e.name, err, msg)
+		}
+		return nil, fmt.Errorf("plugin %s: %w", e.name, err)
+	}
+	return out, nil
+}
+
+// LoadDir returns a Decoder for every executable file in dir. A missing
+// directory yields no decoders and no error.
+func LoadDir(dir string) ([]*Exec, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var plugins []*Exec
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !executable(info) {
+			continue
+		}
+		plugins = append(plugins, NewExec(filepath.Join(dir, entry.Name())))
+	}
+	return plugins, nil
+}
+
+// executable reports whether a file can be run as a plugin.
+func executable(info os.FileInfo) bool {
+	if !info.Mode().IsRegular() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(filepath.Ext(info.Name()), ".exe")
+	}
+	return info.Mode().Perm()&0o111 != 0
+}
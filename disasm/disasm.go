@@ -0,0 +1,216 @@
+// Package disasm provides a short disassembly preview for raw machine code bytes.
+//
+// It wraps the pure-Go decoders from golang.org/x/arch, so no cgo or external
+// libraries (such as capstone) are required. The preview is meant for quickly
+// recognizing code inside firmware dumps, not as a full-featured disassembler.
+//
+// Supported architectures:
+//   - x86 (16, 32 and 64-bit modes, Intel syntax)
+//   - ARM (A32)
+//   - ARM Thumb (16-bit instructions and BL, see package asm)
+//   - ARM64 (A64)
+//   - RISC-V 64 (including compressed instructions)
+//
+// Example usage:
+//
+//	code, _ := convert.HexToBytes("55 48 89 e5 c3")
+//	insts, _ := disasm.Disassemble(code, disasm.ArchX86_64, 0x1000, 16)
+//	for _, inst := range insts {
+//		fmt.Printf("%08x  %s\n", inst.Address, inst.Text)
+//	}
+package disasm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/arch/arm/armasm"
+	"golang.org/x/arch/arm64/arm64asm"
+	"golang.org/x/arch/riscv64/riscv64asm"
+	"golang.org/x/arch/x86/x86asm"
+
+	"hexview/asm"
+)
+
+// Arch identifies an instruction set architecture supported by the disassembler.
+type Arch string
+
+// Supported architectures
+const (
+	ArchX86_16  Arch = "x86-16"
+	ArchX86_32  Arch = "x86-32"
+	ArchX86_64  Arch = "x86-64"
+	ArchARM     Arch = "arm"
+	ArchThumb   Arch = "thumb"
+	ArchARM64   Arch = "arm64"
+	ArchRISCV64 Arch = "riscv64"
+)
+
+// Error definitions for disassembly operations
+var (
+	// ErrUnsupportedArch indicates the requested architecture is not supported
+	ErrUnsupportedArch = errors.New("unsupported architecture")
+
+	// ErrEmptyInput indicates no bytes were provided
+	ErrEmptyInput = errors.New("empty input")
+)
+
+// Instruction is a single decoded instruction.
+type Instruction struct {
+	Offset  int    // Offset of the instruction within the input buffer
+	Address uint64 // Virtual address (base address + offset)
+	Bytes   []byte // Raw encoding
+	Text    string // Assembly text, or a ".byte" directive if decoding failed
+	Valid   bool   // False if the bytes could not be decoded
+}
+
+// Architectures returns all supported architectures in display order.
+func Architectures() []Arch {
+	return []Arch{ArchX86_16, ArchX86_32, ArchX86_64, ArchARM, ArchThumb, ArchARM64, ArchRISCV64}
+}
+
+// ParseArch converts an architecture name to an Arch.
+// Names are case-insensitive; common aliases like "amd64", "aarch64" and "i386" are accepted.
+func ParseArch(name string) (Arch, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "x86-16", "x86_16", "8086", "16":
+		return ArchX86_16, nil
+	case "x86-32", "x86_32", "x86", "i386", "386", "32":
+		return ArchX86_32, nil
+	case "x86-64", "x86_64", "amd64", "x64", "64":
+		return ArchX86_64, nil
+	case "arm", "arm32", "a32":
+		return ArchARM, nil
+	case "thumb", "t16", "cortex-m":
+		return ArchThumb, nil
+	case "arm64", "aarch64", "a64":
+		return ArchARM64, nil
+	case "riscv64", "riscv", "rv64":
+		return ArchRISCV64, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedArch, name)
+}
+
+// Disassemble decodes up to maxInstructions instructions from code.
+// baseAddress is added to each instruction offset to form its address.
+// A maxInstructions value <= 0 decodes the whole buffer.
+// Undecodable bytes are emitted as ".byte" pseudo-instructions so the preview
+// stays aligned with the input instead of aborting.
+func Disassemble(code []byte, arch Arch, baseAddress uint64, maxInstructions int) ([]Instruction, error) {
+	if len(code) == 0 {
+		return nil, ErrEmptyInput
+	}
+
+	decode, err := decoderFor(arch)
+	if err != nil {
+		return nil, err
+	}
+
+	var insts []Instruction
+	offset := 0
+	for offset < len(code) {
+		if maxInstructions > 0 && len(insts) >= maxInstructions {
+			break
+		}
+
+		pc := baseAddress + uint64(offset)
+		text, size, err := decode(code[offset:], pc)
+		if err != nil || size <= 0 {
+			size = min(minInstructionSize(arch), len(code)-offset)
+			text = byteDirective(code[offset : offset+size])
+			insts = append(insts, Instruction{
+				Offset:  offset,
+				Address: pc,
+				Bytes:   code[offset : offset+size],
+				Text:    text,
+			})
+			offset += size
+			continue
+		}
+
+		insts = append(insts, Instruction{
+			Offset:  offset,
+			Address: pc,
+			Bytes:   code[offset : offset+size],
+			Text:    text,
+			Valid:   true,
+		})
+		offset += size
+	}
+
+	return insts, nil
+}
+
+// decodeFunc decodes a single instruction and returns its text and length in bytes.
+type decodeFunc func(src []byte, pc uint64) (string, int, error)
+
+// decoderFor returns the decode function for the given architecture.
+func decoderFor(arch Arch) (decodeFunc, error) {
+	switch arch {
+	case ArchX86_16, ArchX86_32, ArchX86_64:
+		mode := map[Arch]int{ArchX86_16: 16, ArchX86_32: 32, ArchX86_64: 64}[arch]
+		return func(src []byte, pc uint64) (string, int, error) {
+			inst, err := x86asm.Decode(src, mode)
+			if err != nil {
+				return "", 0, err
+			}
+			return x86asm.IntelSyntax(inst, pc, nil), inst.Len, nil
+		}, nil
+
+	case ArchARM:
+		return func(src []byte, pc uint64) (string, int, error) {
+			inst, err := armasm.Decode(src, armasm.ModeARM)
+			if err != nil {
+				return "", 0, err
+			}
+			return armasm.GNUSyntax(inst), inst.Len, nil
+		}, nil
+
+	case ArchThumb:
+		return func(src []byte, pc uint64) (string, int, error) {
+			return asm.DecodeThumb(src)
+		}, nil
+
+	case ArchARM64:
+		return func(src []byte, pc uint64) (string, int, error) {
+			inst, err := arm64asm.Decode(src)
+			if err != nil {
+				return "", 0, err
+			}
+			return arm64asm.GNUSyntax(inst), 4, nil
+		}, nil
+
+	case ArchRISCV64:
+		return func(src []byte, pc uint64) (string, int, error) {
+			inst, err := riscv64asm.Decode(src)
+			if err != nil {
+				return "", 0, err
+			}
+			return riscv64asm.GNUSyntax(inst), inst.Len, nil
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedArch, arch)
+}
+
+// minInstructionSize returns the number of bytes to skip when decoding fails.
+func minInstructionSize(arch Arch) int {
+	switch arch {
+	case ArchARM, ArchARM64:
+		return 4
+	case ArchThumb, ArchRISCV64:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// byteDirective formats undecodable bytes as an assembler data directive.
+func byteDirective(b []byte) string {
+	parts := make([]string, len(b))
+	for i, v := range b {
+		parts[i] = fmt.Sprintf("0x%02x", v)
+	}
+	return ".byte " + strings.Join(parts, ", ")
+}
@@ -0,0 +1,109 @@
+package disasm
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDisassemble(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     []byte
+		arch     Arch
+		wantText []string
+	}{
+		{"x86-64 prologue", []byte{0x55, 0x48, 0x89, 0xe5, 0xc3}, ArchX86_64, []string{"push rbp", "mov rbp, rsp", "ret"}},
+		{"x86-32 nop", []byte{0x90, 0x90}, ArchX86_32, []string{"nop", "nop"}},
+		{"arm64 ret", []byte{0xc0, 0x03, 0x5f, 0xd6}, ArchARM64, []string{"ret"}},
+		{"arm bx lr", []byte{0x1e, 0xff, 0x2f, 0xe1}, ArchARM, []string{"bx lr"}},
+		{"riscv64 compressed nop", []byte{0x01, 0x00}, ArchRISCV64, []string{"nop"}},
+		{"thumb push and bl", []byte{0x10, 0xb5, 0x00, 0xf0, 0x00, 0xf8, 0x10, 0xbd}, ArchThumb, []string{"push {r4, lr}", "bl .+4", "pop {r4, pc}"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Disassemble(tt.code, tt.arch, 0, 0)
+			if err != nil {
+				t.Fatalf("Disassemble() error = %v", err)
+			}
+			if len(got) != len(tt.wantText) {
+				t.Fatalf("Disassemble() returned %d instructions, want %d: %+v", len(got), len(tt.wantText), got)
+			}
+			for i, want := range tt.wantText {
+				if !strings.EqualFold(got[i].Text, want) {
+					t.Errorf("instruction %d = %q, want %q", i, got[i].Text, want)
+				}
+				if !got[i].Valid {
+					t.Errorf("instruction %d marked invalid", i)
+				}
+			}
+		})
+	}
+}
+
+func TestDisassemble_AddressesAndLimit(t *testing.T) {
+	code := []byte{0x90, 0x90, 0x90, 0x90}
+	got, err := Disassemble(code, ArchX86_64, 0x1000, 2)
+	if err != nil {
+		t.Fatalf("Disassemble() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 instructions, got %d", len(got))
+	}
+	if got[1].Address != 0x1001 || got[1].Offset != 1 {
+		t.Errorf("second instruction address = %#x offset = %d, want 0x1001 / 1", got[1].Address, got[1].Offset)
+	}
+}
+
+func TestDisassemble_InvalidBytes(t *testing.T) {
+	// Truncated ARM64 instruction: fewer than 4 bytes
+	got, err := Disassemble([]byte{0xc0, 0x03}, ArchARM64, 0, 0)
+	if err != nil {
+		t.Fatalf("Disassemble() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Valid {
+		t.Fatalf("expected one invalid instruction, got %+v", got)
+	}
+	if got[0].Text != ".byte 0xc0, 0x03" {
+		t.Errorf("Text = %q, want .byte directive", got[0].Text)
+	}
+}
+
+func TestDisassemble_Errors(t *testing.T) {
+	if _, err := Disassemble(nil, ArchX86_64, 0, 0); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("expected ErrEmptyInput, got %v", err)
+	}
+	if _, err := Disassemble([]byte{0x90}, Arch("z80"), 0, 0); !errors.Is(err, ErrUnsupportedArch) {
+		t.Errorf("expected ErrUnsupportedArch, got %v", err)
+	}
+}
+
+func TestParseArch(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Arch
+		wantErr bool
+	}{
+		{"amd64", ArchX86_64, false},
+		{"x86-32", ArchX86_32, false},
+		{"AArch64", ArchARM64, false},
+		{"arm", ArchARM, false},
+		{"riscv64", ArchRISCV64, false},
+		{"Thumb", ArchThumb, false},
+		{"mips", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseArch(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseArch(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseArch(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+// Package editor provides an in-memory byte buffer with an undo/redo journal.
+//
+// Every edit (overwrite, insert, delete, fill, replace) is recorded as one or more
+// splice operations. Operations can be grouped so that composite edits such as
+// "replace all" are undone and redone as a single step.
+//
+// Example usage:
+//
+//	buf := editor.New([]byte{0x00, 0x11, 0x22})
+//	buf.Overwrite(1, []byte{0xff})
+//	buf.Insert(0, []byte{0xaa})
+//	buf.Undo() // removes the inserted byte
+//	buf.Undo() // restores 0x11
+//	buf.Redo() // writes 0xff again
+package editor
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// Error definitions for editor operations
+var (
+	// ErrOutOfRange indicates an offset or length outside the buffer
+	ErrOutOfRange = errors.New("offset out of range")
+
+	// ErrNothingToUndo indicates the undo stack is empty
+	ErrNothingToUndo = errors.New("nothing to undo")
+
+	// ErrNothingToRedo indicates the redo stack is empty
+	ErrNothingToRedo = errors.New("nothing to redo")
+
+	// ErrEmptyPattern indicates an empty fill or search pattern
+	ErrEmptyPattern = errors.New("empty pattern")
+)
+
+// splice is the primitive journal entry: at offset, old was replaced by new.
+type splice struct {
+	offset int
+	old    []byte
+	new    []byte
+}
+
+// group is a labelled sequence of splices that is undone/redone atomically.
+type group struct {
+	label   string
+	splices []splice
+}
+
+// Buffer is an editable byte buffer with an undo/redo journal.
+// A Buffer is not safe for concurrent use.
+type Buffer struct {
+	data    []byte
+	undo    []group
+	redo    []group
+	open    *group // group being recorded by BeginGroup, nil otherwise
+	depth   int    // nesting depth of BeginGroup calls
+	savedAt int    // undo stack depth at the last save point, -1 if unreachable
+}
+
+// New creates a Buffer holding a copy of data.
+func New(data []byte) *Buffer {
+	return &Buffer{data: bytes.Clone(data)}
+}
+
+// Bytes returns the current buffer content. The slice must not be modified.
+func (b *Buffer) Bytes() []byte {
+	return b.data
+}
+
+// Len returns the current buffer length.
+func (b *Buffer) Len() int {
+	return len(b.data)
+}
+
+// Overwrite replaces bytes starting at offset with data.
+// Writing past the end extends the buffer.
+func (b *Buffer) Overwrite(offset int, data []byte) error {
+	if offset < 0 || offset > len(b.data) {
+		return fmt.Errorf("%w: %d", ErrOutOfRange, offset)
+	}
+	n := min(len(data), len(b.data)-offset)
+	return b.apply("overwrite", splice{offset: offset, old: b.data[offset : offset+n], new: data})
+}
+
+// Insert inserts data at offset, shifting the following bytes.
+func (b *Buffer) Insert(offset int, data []byte) error {
+	if offset < 0 || offset > len(b.data) {
+		return fmt.Errorf("%w: %d", ErrOutOfRange, offset)
+	}
+	return b.apply("insert", splice{offset: offset, new: data})
+}
+
+// Delete removes length bytes starting at offset.
+func (b *Buffer) Delete(offset, length int) error {
+	if err := b.checkRange(offset, length); err != nil {
+		return err
+	}
+	return b.apply("delete", splice{offset: offset, old: b.data[offset : offset+length]})
+}
+
+// Fill overwrites length bytes starting at offset with a repeating pattern.
+func (b *Buffer) Fill(offset, length int, pattern []byte) error {
+	if len(pattern) == 0 {
+		return ErrEmptyPattern
+	}
+	if err := b.checkRange(offset, length); err != nil {
+		return err
+	}
+	filled := make([]byte, length)
+	for i := range filled {
+		filled[i] = pattern[i%len(pattern)]
+	}
+	return b.apply("fill", splice{offset: offset, old: b.data[offset : offset+length], new: filled})
+}
+
+// Replace substitutes every non-overlapping occurrence of find with repl.
+// All substitutions are recorded as a single undo step. It returns the number of replacements.
+func (b *Buffer) Replace(find, repl []byte) (int, error) {
+	if len(find) == 0 {
+		return 0, ErrEmptyPattern
+	}
+
+	// Collect match offsets on the unmodified content first
+	var offsets []int
+	for pos := 0; pos <= len(b.data)-len(find); {
+		i := bytes.Index(b.data[pos:], find)
+		if i < 0 {
+			break
+		}
+		offsets = append(offsets, pos+i)
+		pos += i + len(find)
+	}
+	if len(offsets) == 0 {
+		return 0, nil
+	}
+
+	b.BeginGroup("replace")
+	defer b.EndGroup()
+
+	shift := 0
+	for _, off := range offsets {
+		at := off + shift
+		if err := b.apply("replace", splice{offset: at, old: b.data[at : at+len(find)], new: repl}); err != nil {
+			return 0, err
+		}
+		shift += len(repl) - len(find)
+	}
+	return len(offsets), nil
+}
+
+// BeginGroup starts recording a composite operation. All edits until the matching
+// EndGroup are undone and redone together. Groups may be nested; only the
+// outermost label is kept.
+func (b *Buffer) BeginGroup(label string) {
+	if b.depth == 0 {
+		b.open = &group{label: label}
+	}
+	b.depth++
+}
+
+// EndGroup finishes a composite operation started with BeginGroup.
+func (b *Buffer) EndGroup() {
+	if b.depth == 0 {
+		return
+	}
+	b.depth--
+	if b.depth > 0 {
+		return
+	}
+	g := b.open
+	b.open = nil
+	if len(g.splices) > 0 {
+		b.push(*g)
+	}
+}
+
+// Undo reverts the most recent operation or group.
+func (b *Buffer) Undo() error {
+	if len(b.undo) == 0 {
+		return ErrNothingToUndo
+	}
+	g := b.undo[len(b.undo)-1]
+	b.undo = b.undo[:len(b.undo)-1]
+
+	for i := len(g.splices) - 1; i >= 0; i-- {
+		s := g.splices[i]
+		b.splice(s.offset, len(s.new), s.old)
+	}
+	b.redo = append(b.redo, g)
+	return nil
+}
+
+// Redo re-applies the most recently undone operation or group.
+func (b *Buffer) Redo() error {
+	if len(b.redo) == 0 {
+		return ErrNothingToRedo
+	}
+	g := b.redo[len(b.redo)-1]
+	b.redo = b.redo[:len(b.redo)-1]
+
+	for _, s := range g.splices {
+		b.splice(s.offset, len(s.old), s.new)
+	}
+	b.undo = append(b.undo, g)
+	return nil
+}
+
+// CanUndo reports whether there is an operation to undo.
+func (b *Buffer) CanUndo() bool {
+	return len(b.undo) > 0
+}
+
+// CanRedo reports whether there is an operation to redo.
+func (b *Buffer) CanRedo() bool {
+	return len(b.redo) > 0
+}
+
+// UndoLabel returns the label of the operation Undo would revert, or "".
+func (b *Buffer) UndoLabel() string {
+	if len(b.undo) == 0 {
+		return ""
+	}
+	return b.undo[len(b.undo)-1].label
+}
+
+// RedoLabel returns the label of the operation Redo would re-apply, or "".
+func (b *Buffer) RedoLabel() string {
+	if len(b.redo) == 0 {
+		return ""
+	}
+	return b.redo[len(b.redo)-1].label
+}
+
+// MarkSaved records the current state as the saved state.
+func (b *Buffer) MarkSaved() {
+	b.savedAt = len(b.undo)
+}
+
+// Modified reports whether the buffer differs from the last saved state.
+func (b *Buffer) Modified() bool {
+	return b.savedAt != len(b.undo)
+}
+
+// Reset replaces the content and clears the journal, e.g. after reloading from disk.
+func (b *Buffer) Reset(data []byte) {
+	b.data = bytes.Clone(data)
+	b.undo = nil
+	b.redo = nil
+	b.open = nil
+	b.depth = 0
+	b.savedAt = 0
+}
+
+// apply performs a splice and records it in the journal.
+func (b *Buffer) apply(label string, s splice) error {
+	// Keep private copies so later edits cannot alias journal entries
+	s.old = bytes.Clone(s.old)
+	s.new = bytes.Clone(s.new)
+
+	b.splice(s.offset, len(s.old), s.new)
+
+	if b.open != nil {
+		b.open.splices = append(b.open.splices, s)
+		return nil
+	}
+	b.push(group{label: label, splices: []splice{s}})
+	return nil
+}
+
+// push adds a group to the undo stack and invalidates the redo stack.
+func (b *Buffer) push(g group) {
+	if b.savedAt > len(b.undo) {
+		// The saved state lived on the redo stack and is now unreachable
+		b.savedAt = -1
+	}
+	b.undo = append(b.undo, g)
+	b.redo = nil
+}
+
+// splice replaces n bytes at offset with repl without journaling.
+func (b *Buffer) splice(offset, n int, repl []byte) {
+	tail := bytes.Clone(b.data[offset+n:])
+	b.data = append(append(b.data[:offset], repl...), tail...)
+}
+
+// checkRange validates that [offset, offset+length) lies within the buffer.
+func (b *Buffer) checkRange(offset, length int) error {
+	if offset < 0 || length < 0 || offset+length > len(b.data) {
+		return fmt.Errorf("%w: offset %d length %d (size %d)", ErrOutOfRange, offset, length, len(b.data))
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package editor
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestBufferOperations(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(b *Buffer) error
+		want []byte
+	}{
+		{"overwrite", func(b *Buffer) error { return b.Overwrite(1, []byte{0xff}) }, []byte{0x00, 0xff, 0x22, 0x33}},
+		{"overwrite extends", func(b *Buffer) error { return b.Overwrite(3, []byte{0xaa, 0xbb}) }, []byte{0x00, 0x11, 0x22, 0xaa, 0xbb}},
+		{"insert", func(b *Buffer) error { return b.Insert(2, []byte{0xaa}) }, []byte{0x00, 0x11, 0xaa, 0x22, 0x33}},
+		{"insert at end", func(b *Buffer) error { return b.Insert(4, []byte{0xaa}) }, []byte{0x00, 0x11, 0x22, 0x33, 0xaa}},
+		{"delete", func(b *Buffer) error { return b.Delete(1, 2) }, []byte{0x00, 0x33}},
+		{"fill", func(b *Buffer) error { return b.Fill(0, 3, []byte{0xde, 0xad}) }, []byte{0xde, 0xad, 0xde, 0x33}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := []byte{0x00, 0x11, 0x22, 0x33}
+			b := New(orig)
+			if err := tt.edit(b); err != nil {
+				t.Fatalf("edit error = %v", err)
+			}
+			if !bytes.Equal(b.Bytes(), tt.want) {
+				t.Errorf("after edit = %x, want %x", b.Bytes(), tt.want)
+			}
+			if err := b.Undo(); err != nil {
+				t.Fatalf("Undo() error = %v", err)
+			}
+			if !bytes.Equal(b.Bytes(), orig) {
+				t.Errorf("after undo = %x, want %x", b.Bytes(), orig)
+			}
+			if err := b.Redo(); err != nil {
+				t.Fatalf("Redo() error = %v", err)
+			}
+			if !bytes.Equal(b.Bytes(), tt.want) {
+				t.Errorf("after redo = %x, want %x", b.Bytes(), tt.want)
+			}
+		})
+	}
+}
+
+func TestBufferReplaceIsSingleStep(t *testing.T) {
+	orig := []byte("abXcdXef")
+	b := New(orig)
+
+	n, err := b.Replace([]byte("X"), []byte("YY"))
+	if err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Replace() count = %d, want 2", n)
+	}
+	if got := string(b.Bytes()); got != "abYYcdYYef" {
+		t.Errorf("after replace = %q", got)
+	}
+	if b.UndoLabel() != "replace" {
+		t.Errorf("UndoLabel() = %q, want replace", b.UndoLabel())
+	}
+
+	if err := b.Undo(); err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	if !bytes.Equal(b.Bytes(), orig) {
+		t.Errorf("after undo = %q, want %q", b.Bytes(), orig)
+	}
+	if b.CanUndo() {
+		t.Error("expected empty undo stack after undoing the replace group")
+	}
+}
+
+func TestBufferGroups(t *testing.T) {
+	b := New([]byte{0x00, 0x00, 0x00})
+	b.BeginGroup("patch")
+	_ = b.Overwrite(0, []byte{0x01})
+	b.BeginGroup("inner")
+	_ = b.Overwrite(1, []byte{0x02})
+	b.EndGroup()
+	_ = b.Insert(3, []byte{0x03})
+	b.EndGroup()
+
+	if b.UndoLabel() != "patch" {
+		t.Errorf("UndoLabel() = %q, want patch", b.UndoLabel())
+	}
+	if err := b.Undo(); err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	if !bytes.Equal(b.Bytes(), []byte{0x00, 0x00, 0x00}) {
+		t.Errorf("after undo = %x", b.Bytes())
+	}
+	if b.RedoLabel() != "patch" {
+		t.Errorf("RedoLabel() = %q, want patch", b.RedoLabel())
+	}
+}
+
+func TestBufferNewEditClearsRedo(t *testing.T) {
+	b := New([]byte{0x00})
+	_ = b.Overwrite(0, []byte{0x01})
+	_ = b.Undo()
+	_ = b.Overwrite(0, []byte{0x02})
+	if b.CanRedo() {
+		t.Error("expected redo stack to be cleared by a new edit")
+	}
+	if err := b.Redo(); !errors.Is(err, ErrNothingToRedo) {
+		t.Errorf("Redo() error = %v, want ErrNothingToRedo", err)
+	}
+}
+
+func TestBufferModified(t *testing.T) {
+	b := New([]byte{0x00})
+	if b.Modified() {
+		t.Error("new buffer should not be modified")
+	}
+	_ = b.Overwrite(0, []byte{0x01})
+	if !b.Modified() {
+		t.Error("expected modified after edit")
+	}
+	b.MarkSaved()
+	if b.Modified() {
+		t.Error("expected unmodified after MarkSaved")
+	}
+	_ = b.Undo()
+	if !b.Modified() {
+		t.Error("expected modified after undoing past the save point")
+	}
+	_ = b.Redo()
+	if b.Modified() {
+		t.Error("expected unmodified after redoing back to the save point")
+	}
+}
+
+func TestBufferErrors(t *testing.T) {
+	b := New([]byte{0x00, 0x11})
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"overwrite past end", b.Overwrite(3, []byte{0x01}), ErrOutOfRange},
+		{"insert negative", b.Insert(-1, []byte{0x01}), ErrOutOfRange},
+		{"delete too long", b.Delete(1, 5), ErrOutOfRange},
+		{"fill empty pattern", b.Fill(0, 1, nil), ErrEmptyPattern},
+		{"undo empty", b.Undo(), ErrNothingToUndo},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.want) {
+				t.Errorf("error = %v, want %v", tt.err, tt.want)
+			}
+		})
+	}
+	if _, err := b.Replace(nil, []byte{0x01}); !errors.Is(err, ErrEmptyPattern) {
+		t.Errorf("Replace() error = %v, want ErrEmptyPattern", err)
+	}
+}
@@ -0,0 +1,328 @@
+// Package exif decodes TIFF/EXIF IFD structures from raw bytes.
+//
+// The decoder accepts a bare TIFF header ("II*\x00" or "MM\x00*"), a JPEG APP1
+// "Exif\x00\x00" segment, or a whole JPEG file, and walks IFD0, IFD1 and the
+// EXIF, GPS and Interoperability sub-IFDs. Byte order is taken from the TIFF
+// header, so little-endian (Intel) and big-endian (Motorola) data both work.
+//
+// Example usage:
+//
+//	data, _ := os.ReadFile("photo.jpg")
+//	result, _ := exif.Decode(data)
+//	for _, tag := range result.Tags {
+//		fmt.Printf("%s %s = %s\n", tag.IFD, tag.Name, tag.Value)
+//	}
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ErrNoTIFFHeader indicates no TIFF header could be located in the data
+var ErrNoTIFFHeader = errors.New("no TIFF header found")
+
+// maxIFDEntries guards against corrupt entry counts.
+const maxIFDEntries = 1024
+
+// maxValuesShown limits how many array elements are formatted per tag.
+const maxValuesShown = 16
+
+// Tag is a decoded IFD entry.
+type Tag struct {
+	IFD         string // IFD0, IFD1, EXIF, GPS, Interop
+	ID          uint16
+	Name        string
+	Type        string
+	Count       uint32
+	Value       string
+	Offset      int64 // Offset of the 12-byte IFD entry in the input
+	ValueOffset int64 // Offset of the value data in the input
+}
+
+// Result holds all decoded tags of a TIFF structure.
+type Result struct {
+	ByteOrder    string // "little" (II) or "big" (MM)
+	HeaderOffset int64  // Offset of the TIFF header in the input
+	Tags         []Tag
+	Warnings     []string
+}
+
+// typeSizes maps TIFF field types to their element size in bytes.
+var typeSizes = map[uint16]int{
+	1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8,
+}
+
+// typeNames maps TIFF field types to their names.
+var typeNames = map[uint16]string{
+	1: "BYTE", 2: "ASCII", 3: "SHORT", 4: "LONG", 5: "RATIONAL", 6: "SBYTE",
+	7: "UNDEFINED", 8: "SSHORT", 9: "SLONG", 10: "SRATIONAL", 11: "FLOAT", 12: "DOUBLE",
+}
+
+// Sub-IFD pointer tags
+const (
+	tagExifIFD    = 0x8769
+	tagGPSIFD     = 0x8825
+	tagInteropIFD = 0xa005
+)
+
+// tagNames holds the names of common TIFF/EXIF tags.
+var tagNames = map[uint16]string{
+	0x0100: "ImageWidth", 0x0101: "ImageLength", 0x0102: "BitsPerSample", 0x0103: "Compression",
+	0x0106: "PhotometricInterpretation", 0x010e: "ImageDescription", 0x010f: "Make", 0x0110: "Model",
+	0x0111: "StripOffsets", 0x0112: "Orientation", 0x0115: "SamplesPerPixel", 0x0116: "RowsPerStrip",
+	0x0117: "StripByteCounts", 0x011a: "XResolution", 0x011b: "YResolution", 0x0128: "ResolutionUnit",
+	0x0131: "Software", 0x0132: "DateTime", 0x013b: "Artist", 0x0201: "JPEGInterchangeFormat",
+	0x0202: "JPEGInterchangeFormatLength", 0x0213: "YCbCrPositioning", 0x8298: "Copyright",
+	0x8769: "ExifIFDPointer", 0x8825: "GPSInfoIFDPointer", 0x829a: "ExposureTime", 0x829d: "FNumber",
+	0x8822: "ExposureProgram", 0x8827: "ISOSpeedRatings", 0x9000: "ExifVersion",
+	0x9003: "DateTimeOriginal", 0x9004: "DateTimeDigitized", 0x9101: "ComponentsConfiguration",
+	0x9201: "ShutterSpeedValue", 0x9202: "ApertureValue", 0x9204: "ExposureBiasValue",
+	0x9207: "MeteringMode", 0x9209: "Flash", 0x920a: "FocalLength", 0x927c: "MakerNote",
+	0x9286: "UserComment", 0xa000: "FlashpixVersion", 0xa001: "ColorSpace", 0xa002: "PixelXDimension",
+	0xa003: "PixelYDimension", 0xa005: "InteroperabilityIFDPointer", 0xa402: "ExposureMode",
+	0xa403: "WhiteBalance", 0xa405: "FocalLengthIn35mmFilm", 0xa406: "SceneCaptureType",
+	0xa430: "CameraOwnerName", 0xa431: "BodySerialNumber", 0xa434: "LensModel",
+	0x0000: "GPSVersionID", 0x0001: "GPSLatitudeRef", 0x0002: "GPSLatitude", 0x0003: "GPSLongitudeRef",
+	0x0004: "GPSLongitude", 0x0005: "GPSAltitudeRef", 0x0006: "GPSAltitude", 0x0007: "GPSTimeStamp",
+	0x001d: "GPSDateStamp",
+}
+
+// interopTagNames holds names of Interoperability IFD tags, which reuse low tag IDs.
+var interopTagNames = map[uint16]string{
+	0x0001: "InteroperabilityIndex", 0x0002: "InteroperabilityVersion",
+}
+
+// FindTIFF returns the offset of the TIFF header within data.
+// It checks for a bare TIFF header, then for an "Exif\x00\x00" APP1 marker.
+func FindTIFF(data []byte) (int64, error) {
+	if isTIFFHeader(data) {
+		return 0, nil
+	}
+	if i := bytes.Index(data, []byte("Exif\x00\x00")); i >= 0 && isTIFFHeader(data[i+6:]) {
+		return int64(i + 6), nil
+	}
+	return 0, ErrNoTIFFHeader
+}
+
+// isTIFFHeader reports whether b starts with a TIFF byte-order mark and magic number.
+func isTIFFHeader(b []byte) bool {
+	return bytes.HasPrefix(b, []byte("II*\x00")) || bytes.HasPrefix(b, []byte("MM\x00*"))
+}
+
+// Decode locates the TIFF header in data and decodes all reachable IFDs.
+func Decode(data []byte) (*Result, error) {
+	base, err := FindTIFF(data)
+	if err != nil {
+		return nil, err
+	}
+	tiff := data[base:]
+	if len(tiff) < 8 {
+		return nil, fmt.Errorf("%w: truncated header", ErrNoTIFFHeader)
+	}
+
+	d := &decoder{
+		tiff:    tiff,
+		base:    base,
+		visited: make(map[uint32]bool),
+		result:  &Result{HeaderOffset: base, ByteOrder: "little"},
+	}
+	d.order = binary.LittleEndian
+	if tiff[0] == 'M' {
+		d.order = binary.BigEndian
+		d.result.ByteOrder = "big"
+	}
+
+	next := d.order.Uint32(tiff[4:])
+	for i := 0; next != 0; i++ {
+		next = d.readIFD(fmt.Sprintf("IFD%d", i), next)
+	}
+
+	return d.result, nil
+}
+
+// decoder holds the state of a single Decode call.
+type decoder struct {
+	tiff    []byte // data starting at the TIFF header; IFD offsets are relative to it
+	base    int64  // offset of the TIFF header in the original input
+	order   binary.ByteOrder
+	visited map[uint32]bool // IFD offsets already decoded, to break loops
+	result  *Result
+}
+
+// subIFD is a pointer to a nested IFD found while decoding its parent.
+type subIFD struct {
+	name string
+	off  uint32
+}
+
+// readIFD decodes one IFD and returns the offset of the next IFD in the chain.
+func (d *decoder) readIFD(name string, off uint32) uint32 {
+	if d.visited[off] {
+		d.warn("%s at offset 0x%x already visited (loop)", name, off)
+		return 0
+	}
+	d.visited[off] = true
+
+	if int64(off)+2 > int64(len(d.tiff)) {
+		d.warn("%s offset 0x%x out of range", name, off)
+		return 0
+	}
+	count := int(d.order.Uint16(d.tiff[off:]))
+	if count > maxIFDEntries {
+		d.warn("%s has implausible entry count %d", name, count)
+		return 0
+	}
+
+	var subIFDs []subIFD
+
+	pos := int64(off) + 2
+	for i := 0; i < count; i++ {
+		if pos+12 > int64(len(d.tiff)) {
+			d.warn("%s entry %d truncated", name, i)
+			return 0
+		}
+		tag := d.readEntry(name, d.tiff[pos:pos+12], pos)
+		d.result.Tags = append(d.result.Tags, tag)
+
+		if tag.Type == "LONG" || tag.Type == "UNDEFINED" {
+			ptr := d.order.Uint32(d.tiff[pos+8:])
+			switch {
+			case tag.ID == tagExifIFD && name != "GPS":
+				subIFDs = append(subIFDs, subIFD{"EXIF", ptr})
+			case tag.ID == tagGPSIFD && name != "GPS":
+				subIFDs = append(subIFDs, subIFD{"GPS", ptr})
+			case tag.ID == tagInteropIFD && name == "EXIF":
+				subIFDs = append(subIFDs, subIFD{"Interop", ptr})
+			}
+		}
+		pos += 12
+	}
+
+	for _, sub := range subIFDs {
+		d.readIFD(sub.name, sub.off)
+	}
+
+	if pos+4 > int64(len(d.tiff)) {
+		return 0
+	}
+	return d.order.Uint32(d.tiff[pos:])
+}
+
+// readEntry decodes a single 12-byte IFD entry located at pos.
+func (d *decoder) readEntry(ifd string, entry []byte, pos int64) Tag {
+	id := d.order.Uint16(entry)
+	typ := d.order.Uint16(entry[2:])
+	count := d.order.Uint32(entry[4:])
+
+	tag := Tag{
+		IFD:    ifd,
+		ID:     id,
+		Name:   tagName(ifd, id),
+		Type:   typeNames[typ],
+		Count:  count,
+		Offset: d.base + pos,
+	}
+	if tag.Type == "" {
+		tag.Type = fmt.Sprintf("type %d", typ)
+		tag.Value = "(unknown type)"
+		return tag
+	}
+
+	size := int64(typeSizes[typ]) * int64(count)
+	valuePos := pos + 8
+	if size > 4 {
+		valuePos = int64(d.order.Uint32(entry[8:]))
+	}
+	tag.ValueOffset = d.base + valuePos
+
+	if valuePos+size > int64(len(d.tiff)) {
+		tag.Value = "(out of range)"
+		d.warn("%s tag 0x%04x value at 0x%x runs past end of data", ifd, id, valuePos)
+		return tag
+	}
+
+	tag.Value = d.formatValue(typ, count, d.tiff[valuePos:valuePos+size])
+	return tag
+}
+
+// formatValue renders a tag value according to its type.
+func (d *decoder) formatValue(typ uint16, count uint32, b []byte) string {
+	if typ == 2 {
+		return strings.TrimRight(string(b), "\x00")
+	}
+	if typ == 7 {
+		if count <= 8 {
+			return fmt.Sprintf("% x", b)
+		}
+		return fmt.Sprintf("% x … (%d bytes)", b[:8], count)
+	}
+
+	n := int(count)
+	shown := min(n, maxValuesShown)
+	parts := make([]string, 0, shown)
+	size := typeSizes[typ]
+	for i := 0; i < shown; i++ {
+		v := b[i*size : (i+1)*size]
+		switch typ {
+		case 1:
+			parts = append(parts, fmt.Sprint(v[0]))
+		case 6:
+			parts = append(parts, fmt.Sprint(int8(v[0])))
+		case 3:
+			parts = append(parts, fmt.Sprint(d.order.Uint16(v)))
+		case 8:
+			parts = append(parts, fmt.Sprint(int16(d.order.Uint16(v))))
+		case 4:
+			parts = append(parts, fmt.Sprint(d.order.Uint32(v)))
+		case 9:
+			parts = append(parts, fmt.Sprint(int32(d.order.Uint32(v))))
+		case 5:
+			parts = append(parts, formatRational(int64(d.order.Uint32(v)), int64(d.order.Uint32(v[4:]))))
+		case 10:
+			parts = append(parts, formatRational(int64(int32(d.order.Uint32(v))), int64(int32(d.order.Uint32(v[4:])))))
+		case 11:
+			parts = append(parts, fmt.Sprint(math.Float32frombits(d.order.Uint32(v))))
+		case 12:
+			parts = append(parts, fmt.Sprint(math.Float64frombits(d.order.Uint64(v))))
+		}
+	}
+	s := strings.Join(parts, ", ")
+	if n > shown {
+		s += fmt.Sprintf(", … (%d values)", n)
+	}
+	return s
+}
+
+// formatRational renders a rational as "num/den (decimal)".
+func formatRational(num, den int64) string {
+	if den == 0 {
+		return fmt.Sprintf("%d/0", num)
+	}
+	return fmt.Sprintf("%d/%d (%g)", num, den, float64(num)/float64(den))
+}
+
+// tagName returns the name of a tag in the context of its IFD.
+func tagName(ifd string, id uint16) string {
+	if ifd == "Interop" {
+		if n, ok := interopTagNames[id]; ok {
+			return n
+		}
+	} else if ifd != "GPS" && id < 0x0100 {
+		// Low IDs outside GPS/Interop IFDs are not GPS tags
+		return fmt.Sprintf("Tag0x%04x", id)
+	}
+	if n, ok := tagNames[id]; ok {
+		return n
+	}
+	return fmt.Sprintf("Tag0x%04x", id)
+}
+
+// warn records a non-fatal decoding problem.
+func (d *decoder) warn(format string, args ...any) {
+	d.result.Warnings = append(d.result.Warnings, fmt.Sprintf(format, args...))
+}
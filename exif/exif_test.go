@@ -0,0 +1,150 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// entry is a raw IFD entry used to build test fixtures.
+type entry struct {
+	id, typ uint16
+	count   uint32
+	value   uint32 // inline value or offset
+}
+
+// buildTIFF assembles a TIFF with IFD0 (Make, Orientation, XResolution, ExifIFD)
+// followed by an EXIF IFD holding ISOSpeedRatings.
+func buildTIFF(order binary.ByteOrder) []byte {
+	var buf bytes.Buffer
+	if order == binary.LittleEndian {
+		buf.WriteString("II*\x00")
+	} else {
+		buf.WriteString("MM\x00*")
+	}
+	binary.Write(&buf, order, uint32(8))
+
+	// Layout: IFD0 at 8 (2 + 4*12 + 4 = 54 bytes) -> ends at 62
+	// "Canon\x00" at 62, rational at 68, EXIF IFD at 76
+	writeIFD := func(entries []entry, next uint32) {
+		binary.Write(&buf, order, uint16(len(entries)))
+		for _, e := range entries {
+			binary.Write(&buf, order, e.id)
+			binary.Write(&buf, order, e.typ)
+			binary.Write(&buf, order, e.count)
+			if e.typ == 3 && e.count == 1 {
+				// SHORT values are left-justified in the 4-byte field
+				binary.Write(&buf, order, uint16(e.value))
+				binary.Write(&buf, order, uint16(0))
+			} else {
+				binary.Write(&buf, order, e.value)
+			}
+		}
+		binary.Write(&buf, order, next)
+	}
+
+	writeIFD([]entry{
+		{0x010f, 2, 6, 62},
+		{0x0112, 3, 1, 6},
+		{0x011a, 5, 1, 68},
+		{0x8769, 4, 1, 76},
+	}, 0)
+	buf.WriteString("Canon\x00")
+	binary.Write(&buf, order, uint32(72))
+	binary.Write(&buf, order, uint32(1))
+	writeIFD([]entry{{0x8827, 3, 1, 400}}, 0)
+	return buf.Bytes()
+}
+
+func TestDecodeByteOrders(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		order binary.ByteOrder
+		want  string
+	}{
+		{"little endian", binary.LittleEndian, "little"},
+		{"big endian", binary.BigEndian, "big"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Decode(buildTIFF(tc.order))
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if result.ByteOrder != tc.want {
+				t.Errorf("ByteOrder = %q, want %q", result.ByteOrder, tc.want)
+			}
+			if len(result.Warnings) != 0 {
+				t.Errorf("unexpected warnings: %v", result.Warnings)
+			}
+
+			want := []struct{ ifd, name, value string }{
+				{"IFD0", "Make", "Canon"},
+				{"IFD0", "Orientation", "6"},
+				{"IFD0", "XResolution", "72/1 (72)"},
+				{"IFD0", "ExifIFDPointer", "76"},
+				{"EXIF", "ISOSpeedRatings", "400"},
+			}
+			if len(result.Tags) != len(want) {
+				t.Fatalf("got %d tags, want %d: %+v", len(result.Tags), len(want), result.Tags)
+			}
+			for i, w := range want {
+				got := result.Tags[i]
+				if got.IFD != w.ifd || got.Name != w.name || got.Value != w.value {
+					t.Errorf("tag %d = %s/%s=%q, want %s/%s=%q", i, got.IFD, got.Name, got.Value, w.ifd, w.name, w.value)
+				}
+			}
+			if result.Tags[0].Offset != 10 || result.Tags[0].ValueOffset != 62 {
+				t.Errorf("Make offsets = %d/%d, want 10/62", result.Tags[0].Offset, result.Tags[0].ValueOffset)
+			}
+		})
+	}
+}
+
+func TestDecodeExifSegment(t *testing.T) {
+	tiff := buildTIFF(binary.LittleEndian)
+	data := append([]byte{0xff, 0xd8, 0xff, 0xe1, 0x00, 0x00}, "Exif\x00\x00"...)
+	data = append(data, tiff...)
+
+	result, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if result.HeaderOffset != 12 {
+		t.Errorf("HeaderOffset = %d, want 12", result.HeaderOffset)
+	}
+	if result.Tags[0].ValueOffset != 12+62 {
+		t.Errorf("ValueOffset = %d, want %d", result.Tags[0].ValueOffset, 12+62)
+	}
+}
+
+func TestDecodeCorrupt(t *testing.T) {
+	t.Run("no header", func(t *testing.T) {
+		if _, err := Decode([]byte("not a tiff")); !errors.Is(err, ErrNoTIFFHeader) {
+			t.Errorf("expected ErrNoTIFFHeader, got %v", err)
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		result, err := Decode(buildTIFF(binary.LittleEndian)[:40])
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if len(result.Warnings) == 0 {
+			t.Error("expected warnings for truncated IFD")
+		}
+	})
+
+	t.Run("loop", func(t *testing.T) {
+		data := buildTIFF(binary.LittleEndian)
+		// Point IFD0's next-IFD field back at IFD0
+		binary.LittleEndian.PutUint32(data[58:], 8)
+		result, err := Decode(data)
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if len(result.Warnings) != 1 {
+			t.Errorf("expected one loop warning, got %v", result.Warnings)
+		}
+	})
+}
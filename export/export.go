@@ -0,0 +1,116 @@
+// Package export writes tabular results as CSV, JSON or Markdown, e.g. for
+// decoded register tables in commissioning reports.
+//
+// Example usage:
+//
+//	t := export.Table{Title: "Registers", Columns: []string{"Index", "Hex"}}
+//	t.Rows = append(t.Rows, []string{"1", "4248"})
+//	export.WriteMarkdown(os.Stdout, "Modbus", []export.Table{t})
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Formats
+const (
+	CSV      = "csv"
+	JSON     = "json"
+	Markdown = "markdown"
+)
+
+// ErrUnknownFormat indicates an unsupported export format
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// Table is a titled table of string cells.
+type Table struct {
+	Title   string
+	Columns []string
+	Rows    [][]string
+}
+
+// FormatFromPath derives the export format from a file extension
+// (.csv, .json, .md or .markdown).
+func FormatFromPath(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return CSV, nil
+	case ".json":
+		return JSON, nil
+	case ".md", ".markdown":
+		return Markdown, nil
+	default:
+		return "", fmt.Errorf("%w: %q (want .csv, .json or .md)", ErrUnknownFormat, filepath.Ext(path))
+	}
+}
+
+// Extension returns the file extension for format, including the dot.
+func Extension(format string) string {
+	if format == Markdown {
+		return ".md"
+	}
+	return "." + format
+}
+
+// WriteCSV writes the tables as CSV. Multiple tables are separated by an
+// empty line, and each starts with its title on a line of its own.
+func WriteCSV(w io.Writer, tables []Table) error {
+	cw := csv.NewWriter(w)
+	for i, t := range tables {
+		if len(tables) > 1 {
+			if i > 0 {
+				cw.Write(nil)
+			}
+			cw.Write([]string{t.Title})
+		}
+		cw.Write(t.Columns)
+		cw.WriteAll(t.Rows)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteMarkdown writes the tables as a Markdown document with a level 1
+// heading and a level 2 heading per table.
+func WriteMarkdown(w io.Writer, title string, tables []Table) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", title)
+	for _, t := range tables {
+		fmt.Fprintf(&b, "\n## %s\n\n", t.Title)
+		writeMarkdownRow(&b, t.Columns)
+		seps := make([]string, len(t.Columns))
+		for i := range seps {
+			seps[i] = "---"
+		}
+		writeMarkdownRow(&b, seps)
+		for _, row := range t.Rows {
+			writeMarkdownRow(&b, row)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMarkdownRow writes one table row, escaping pipes in cells.
+func writeMarkdownRow(b *strings.Builder, cells []string) {
+	b.WriteString("|")
+	for _, c := range cells {
+		b.WriteString(" ")
+		b.WriteString(strings.ReplaceAll(c, "|", `\|`))
+		b.WriteString(" |")
+	}
+	b.WriteString("\n")
+}
+
+// WriteJSON writes v as indented JSON.
+func WriteJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
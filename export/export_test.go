@@ -0,0 +1,72 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var testTables = []Table{
+	{Title: "Registers", Columns: []string{"Index", "Hex"}, Rows: [][]string{{"1", "4248"}, {"2", "0000"}}},
+	{Title: "Notes", Columns: []string{"Text"}, Rows: [][]string{{"a|b, c"}}},
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, testTables[:1]); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	if want := "Index,Hex\n1,4248\n2,0000\n"; buf.String() != want {
+		t.Errorf("single table CSV = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := WriteCSV(&buf, testTables); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	want := "Registers\nIndex,Hex\n1,4248\n2,0000\n\nNotes\nText\n\"a|b, c\"\n"
+	if buf.String() != want {
+		t.Errorf("multi table CSV = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteMarkdown(&buf, "Modbus", testTables); err != nil {
+		t.Fatalf("WriteMarkdown() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"# Modbus\n", "## Registers\n\n| Index | Hex |\n| --- | --- |\n| 1 | 4248 |\n", `| a\|b, c |`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Markdown missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatFromPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{"report.csv", CSV, false},
+		{"/tmp/Result.JSON", JSON, false},
+		{"notes.md", Markdown, false},
+		{"notes.markdown", Markdown, false},
+		{"data.xlsx", "", true},
+		{"noext", "", true},
+	}
+	for _, tt := range tests {
+		got, err := FormatFromPath(tt.path)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("FormatFromPath(%q) = %q, %v", tt.path, got, err)
+		}
+		if err != nil && !errors.Is(err, ErrUnknownFormat) {
+			t.Errorf("FormatFromPath(%q) error = %v, want ErrUnknownFormat", tt.path, err)
+		}
+	}
+	if Extension(Markdown) != ".md" || Extension(CSV) != ".csv" {
+		t.Error("unexpected extensions")
+	}
+}
@@ -0,0 +1,219 @@
+// Package format renders byte sequences as hex strings, value lists and
+// source code literals, e.g. for copying into other tools.
+//
+// Example usage:
+//
+//	s, _ := format.Bytes([]byte{0xde, 0xad}, format.Options{Style: format.CArray})
+//	fmt.Println(s) // const uint8_t data[2] = { 0xde, 0xad };
+package format
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Styles
+const (
+	Hex       = "hex"        // deadbeef
+	HexSpaced = "hex-spaced" // de ad be ef
+	Hex0x     = "hex-0x"     // 0xde 0xad 0xbe 0xef
+	CArray    = "c-array"    // const uint8_t data[4] = { 0xde, 0xad, 0xbe, 0xef };
+	GoSlice   = "go-slice"   // []byte{0xde, 0xad, 0xbe, 0xef}
+	Python    = "python"     // b"\xde\xad\xbe\xef"
+	Decimal   = "decimal"    // 222, 173, 190, 239
+	Binary    = "binary"     // 11011110 10101101 10111110 11101111
+
+	// Formats of Wireshark's exports. Its hex stream (Copy > as a Hex Stream)
+	// is Hex, its escaped string (Copy > as Escaped String) EscapedHex.
+	WiresharkCArray = "wireshark-c-array" // Export Packet Dissections > As C Arrays
+
+	// Escaped strings escape every byte, also printable ones
+	EscapedHex     = "escaped-hex"     // "\xde\xad\xbe\xef"
+	EscapedUnicode = "escaped-unicode" // "\u00de\u00ad\u00be\u00ef", bytes as Latin-1 code points
+	URLEncoded     = "url"             // %de%ad%be%ef
+	HTMLEntities   = "html"            // &#xde;&#xad;&#xbe;&#xef;
+)
+
+// DefaultName is the variable name used for array literals.
+const DefaultName = "data"
+
+// DefaultPacketName is the variable name used for WiresharkCArray, that of
+// the first frame of a Wireshark export.
+const DefaultPacketName = "pkt1"
+
+// wiresharkPerLine is the number of bytes per line of Wireshark's C arrays.
+const wiresharkPerLine = 8
+
+// ErrUnknownStyle indicates an unsupported Style
+var ErrUnknownStyle = errors.New("unknown format style")
+
+// Options controls how bytes are rendered.
+type Options struct {
+	Style        string
+	Uppercase    bool   // upper case hex digits
+	BytesPerLine int    // wrap after this many bytes; 0 keeps everything on one line
+	Name         string // variable name for CArray and WiresharkCArray, DefaultName or DefaultPacketName if empty
+}
+
+// StyleInfo describes a style for selection in a UI.
+type StyleInfo struct {
+	ID      string
+	Name    string
+	Example string
+}
+
+// Styles returns all supported styles with an example rendering of de ad be ef.
+func Styles() []StyleInfo {
+	sample := []byte{0xde, 0xad, 0xbe, 0xef}
+	infos := []StyleInfo{
+		{ID: Hex, Name: "Hex (Wireshark hex stream)"},
+		{ID: HexSpaced, Name: "Spaced hex"},
+		{ID: Hex0x, Name: "0x-prefixed hex"},
+		{ID: CArray, Name: "C array"},
+		{ID: GoSlice, Name: "Go byte slice"},
+		{ID: Python, Name: "Python bytes"},
+		{ID: WiresharkCArray, Name: "Wireshark C array"},
+		{ID: Decimal, Name: "Decimal list"},
+		{ID: Binary, Name: "Binary"},
+		{ID: EscapedHex, Name: `Escaped string (\x)`},
+		{ID: EscapedUnicode, Name: `Escaped string (\u)`},
+		{ID: URLEncoded, Name: "URL-encoded"},
+		{ID: HTMLEntities, Name: "HTML entities"},
+	}
+	for i := range infos {
+		infos[i].Example, _ = Bytes(sample, Options{Style: infos[i].ID})
+	}
+	return infos
+}
+
+// Bytes renders data in the style given by opts.
+func Bytes(data []byte, opts Options) (string, error) {
+	hexByte := func(b byte, prefix string) string {
+		if opts.Uppercase {
+			return fmt.Sprintf("%s%02X", prefix, b)
+		}
+		return fmt.Sprintf("%s%02x", prefix, b)
+	}
+	items := func(f func(b byte) string) []string {
+		out := make([]string, len(data))
+		for i, b := range data {
+			out[i] = f(b)
+		}
+		return out
+	}
+
+	switch opts.Style {
+	case Hex:
+		return wrap(items(func(b byte) string { return hexByte(b, "") }), "", opts.BytesPerLine), nil
+	case HexSpaced:
+		return wrap(items(func(b byte) string { return hexByte(b, "") }), " ", opts.BytesPerLine), nil
+	case Hex0x:
+		return wrap(items(func(b byte) string { return hexByte(b, "0x") }), " ", opts.BytesPerLine), nil
+	case Decimal:
+		return wrap(items(func(b byte) string { return strconv.Itoa(int(b)) }), ", ", opts.BytesPerLine), nil
+	case Binary:
+		return wrap(items(func(b byte) string { return fmt.Sprintf("%08b", b) }), " ", opts.BytesPerLine), nil
+	case Python:
+		return `b"` + strings.Join(items(func(b byte) string { return hexByte(b, `\x`) }), "") + `"`, nil
+	case EscapedHex:
+		return `"` + strings.Join(items(func(b byte) string { return hexByte(b, `\x`) }), "") + `"`, nil
+	case EscapedUnicode:
+		return `"` + strings.Join(items(func(b byte) string { return hexByte(b, `\u00`) }), "") + `"`, nil
+	case URLEncoded:
+		return wrap(items(func(b byte) string { return hexByte(b, "%") }), "", opts.BytesPerLine), nil
+	case HTMLEntities:
+		return wrap(items(func(b byte) string { return hexByte(b, "&#x") + ";" }), "", opts.BytesPerLine), nil
+	case CArray:
+		name := opts.Name
+		if name == "" {
+			name = DefaultName
+		}
+		open := fmt.Sprintf("const uint8_t %s[%d] = {", name, len(data))
+		return literal(open, "};", "    ", true, items(func(b byte) string { return hexByte(b, "0x") }), opts.BytesPerLine), nil
+	case GoSlice:
+		return literal("[]byte{", "}", "\t", false, items(func(b byte) string { return hexByte(b, "0x") }), opts.BytesPerLine), nil
+	case WiresharkCArray:
+		name := opts.Name
+		if name == "" {
+			name = DefaultPacketName
+		}
+		return wiresharkArray(name, data, items(func(b byte) string { return hexByte(b, "0x") })), nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownStyle, opts.Style)
+	}
+}
+
+// wrap joins items with sep, starting a new line after every perLine items.
+// Trailing spaces of sep are dropped at line ends.
+func wrap(items []string, sep string, perLine int) string {
+	if perLine <= 0 {
+		return strings.Join(items, sep)
+	}
+	var lines []string
+	for i := 0; i < len(items); i += perLine {
+		lines = append(lines, strings.Join(items[i:min(i+perLine, len(items))], sep))
+	}
+	return strings.Join(lines, strings.TrimRight(sep, " ")+"\n")
+}
+
+// literal renders a braced array literal. Without wrapping it fits on one
+// line, with pad adding spaces inside the braces; otherwise each line is
+// indented and ends with a comma.
+func literal(open, close, indent string, pad bool, items []string, perLine int) string {
+	if perLine <= 0 || len(items) == 0 {
+		if pad && len(items) > 0 {
+			return open + " " + strings.Join(items, ", ") + " " + close
+		}
+		return open + strings.Join(items, ", ") + close
+	}
+
+	var b strings.Builder
+	b.WriteString(open)
+	b.WriteByte('\n')
+	for i := 0; i < len(items); i += perLine {
+		b.WriteString(indent)
+		b.WriteString(strings.Join(items[i:min(i+perLine, len(items))], ", "))
+		b.WriteString(",\n")
+	}
+	b.WriteString(close)
+	return b.String()
+}
+
+// wiresharkArray renders a C array the way Wireshark exports a frame: a
+// comment with the length, then 8 bytes per line, each line followed by
+// the bytes as ASCII in a comment aligned with the full lines.
+func wiresharkArray(name string, data []byte, items []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "/* Frame (%d bytes) */\n", len(data))
+	fmt.Fprintf(&b, "static const unsigned char %s[%d] = {\n", name, len(data))
+	width := wiresharkPerLine*len("0x00, ") - 1
+	for i := 0; i < len(items); i += wiresharkPerLine {
+		end := min(i+wiresharkPerLine, len(items))
+		line := strings.Join(items[i:end], ", ")
+		if end < len(items) {
+			line += ","
+		}
+		fmt.Fprintf(&b, "%-*s /* %s */\n", width, line, commentASCII(data[i:end]))
+	}
+	b.WriteString("};")
+	return b.String()
+}
+
+// commentASCII returns data as printable ASCII with '.' for other bytes,
+// also for a '/' after a '*', which would end the comment.
+func commentASCII(data []byte) string {
+	out := make([]byte, len(data))
+	for i, c := range data {
+		switch {
+		case c < 0x20 || c > 0x7e:
+			out[i] = '.'
+		case c == '/' && i > 0 && out[i-1] == '*':
+			out[i] = '.'
+		default:
+			out[i] = c
+		}
+	}
+	return string(out)
+}
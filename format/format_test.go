@@ -0,0 +1,78 @@
+package format
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBytes(t *testing.T) {
+	data := []byte{0xde, 0xad, 0xbe, 0xef}
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{"hex", Options{Style: Hex}, "deadbeef"},
+		{"hex upper", Options{Style: Hex, Uppercase: true}, "DEADBEEF"},
+		{"hex wrapped", Options{Style: Hex, BytesPerLine: 2}, "dead\nbeef"},
+		{"spaced", Options{Style: HexSpaced}, "de ad be ef"},
+		{"spaced wrapped", Options{Style: HexSpaced, BytesPerLine: 3}, "de ad be\nef"},
+		{"0x", Options{Style: Hex0x, Uppercase: true}, "0xDE 0xAD 0xBE 0xEF"},
+		{"decimal", Options{Style: Decimal}, "222, 173, 190, 239"},
+		{"decimal wrapped", Options{Style: Decimal, BytesPerLine: 2}, "222, 173,\n190, 239"},
+		{"binary", Options{Style: Binary}, "11011110 10101101 10111110 11101111"},
+		{"python", Options{Style: Python}, `b"\xde\xad\xbe\xef"`},
+		{"c array", Options{Style: CArray}, "const uint8_t data[4] = { 0xde, 0xad, 0xbe, 0xef };"},
+		{"c array named wrapped", Options{Style: CArray, Name: "frame", BytesPerLine: 2},
+			"const uint8_t frame[4] = {\n    0xde, 0xad,\n    0xbe, 0xef,\n};"},
+		{"go slice", Options{Style: GoSlice}, "[]byte{0xde, 0xad, 0xbe, 0xef}"},
+		{"escaped hex", Options{Style: EscapedHex}, `"\xde\xad\xbe\xef"`},
+		{"escaped unicode", Options{Style: EscapedUnicode, Uppercase: true}, `"\u00DE\u00AD\u00BE\u00EF"`},
+		{"url", Options{Style: URLEncoded, Uppercase: true}, "%DE%AD%BE%EF"},
+		{"html wrapped", Options{Style: HTMLEntities, BytesPerLine: 2}, "&#xde;&#xad;\n&#xbe;&#xef;"},
+		{"go slice wrapped", Options{Style: GoSlice, BytesPerLine: 4}, "[]byte{\n\t0xde, 0xad, 0xbe, 0xef,\n}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Bytes(data, tt.opts)
+			if err != nil {
+				t.Fatalf("Bytes() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Bytes() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBytesWiresharkCArray(t *testing.T) {
+	data := []byte("\x00\x1a\x2b\x3c\x4d\x5e\x00\x50V*/\x08\x00E")
+	want := `/* Frame (14 bytes) */
+static const unsigned char pkt1[14] = {
+0x00, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e, 0x00, 0x50, /* ..+<M^.P */
+0x56, 0x2a, 0x2f, 0x08, 0x00, 0x45              /* V*...E */
+};`
+	if got, _ := Bytes(data, Options{Style: WiresharkCArray}); got != want {
+		t.Errorf("Bytes() = %q, want %q", got, want)
+	}
+	if got, _ := Bytes(nil, Options{Style: WiresharkCArray, Name: "pkt2"}); got != "/* Frame (0 bytes) */\nstatic const unsigned char pkt2[0] = {\n};" {
+		t.Errorf("empty Wireshark C array = %q", got)
+	}
+}
+
+func TestBytesEmptyAndUnknown(t *testing.T) {
+	if got, _ := Bytes(nil, Options{Style: CArray}); got != "const uint8_t data[0] = {};" {
+		t.Errorf("empty C array = %q", got)
+	}
+	if _, err := Bytes([]byte{1}, Options{Style: "cobol"}); !errors.Is(err, ErrUnknownStyle) {
+		t.Errorf("unknown style error = %v, want ErrUnknownStyle", err)
+	}
+}
+
+func TestStyles(t *testing.T) {
+	for _, s := range Styles() {
+		if s.Example == "" {
+			t.Errorf("style %s has no example", s.ID)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+// Package lighting decodes frames of lighting-control buses for
+// commissioning: DALI (IEC 62386) forward and backward frames and DMX512
+// packets.
+//
+// Example usage:
+//
+//	frame, _ := lighting.DecodeDALI([]byte{0x0b, 0x90})
+//	fmt.Println(frame.Address, frame.Command) // short address 5 QUERY STATUS
+package lighting
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// DALI frame kinds
+const (
+	DALIForward   = "forward"   // 16 bits from a control device to control gear
+	DALIForward24 = "forward24" // 24 bits to control devices (IEC 62386-103)
+	DALIBackward  = "backward"  // 8-bit answer of control gear
+)
+
+// ErrInvalidFrame indicates data that does not have the size of a frame
+var ErrInvalidFrame = errors.New("invalid frame")
+
+// DALIFrame is a decoded DALI frame.
+type DALIFrame struct {
+	Kind    string
+	Address string // e.g. "short address 5", "group 3" or "broadcast"; empty for backward frames
+	Command string // e.g. "QUERY STATUS" or "DAPC 254 (100.0%)"
+	Value   byte   // arc power level, command number or answer
+}
+
+// daliCommands names the commands of 16-bit forward frames to control gear
+// (IEC 62386-102) by opcode. Ranges addressing a scene or group are added
+// by daliCommand.
+var daliCommands = map[byte]string{
+	0: "OFF", 1: "UP", 2: "DOWN", 3: "STEP UP", 4: "STEP DOWN",
+	5: "RECALL MAX LEVEL", 6: "RECALL MIN LEVEL", 7: "STEP DOWN AND OFF", 8: "ON AND STEP UP",
+	9: "ENABLE DAPC SEQUENCE", 10: "GO TO LAST ACTIVE LEVEL",
+	32: "RESET", 33: "STORE ACTUAL LEVEL IN DTR0", 34: "SAVE PERSISTENT VARIABLES",
+	35: "SET OPERATING MODE (DTR0)", 36: "RESET MEMORY BANK (DTR0)", 37: "IDENTIFY DEVICE",
+	42: "SET MAX LEVEL (DTR0)", 43: "SET MIN LEVEL (DTR0)", 44: "SET SYSTEM FAILURE LEVEL (DTR0)",
+	45: "SET POWER ON LEVEL (DTR0)", 46: "SET FADE TIME (DTR0)", 47: "SET FADE RATE (DTR0)",
+	48:  "SET EXTENDED FADE TIME (DTR0)",
+	128: "SET SHORT ADDRESS (DTR0)", 129: "ENABLE WRITE MEMORY",
+	144: "QUERY STATUS", 145: "QUERY CONTROL GEAR PRESENT", 146: "QUERY LAMP FAILURE",
+	147: "QUERY LAMP POWER ON", 148: "QUERY LIMIT ERROR", 149: "QUERY RESET STATE",
+	150: "QUERY MISSING SHORT ADDRESS", 151: "QUERY VERSION NUMBER", 152: "QUERY CONTENT DTR0",
+	153: "QUERY DEVICE TYPE", 154: "QUERY PHYSICAL MINIMUM", 155: "QUERY POWER FAILURE",
+	156: "QUERY CONTENT DTR1", 157: "QUERY CONTENT DTR2", 158: "QUERY OPERATING MODE",
+	159: "QUERY LIGHT SOURCE TYPE", 160: "QUERY ACTUAL LEVEL", 161: "QUERY MAX LEVEL",
+	162: "QUERY MIN LEVEL", 163: "QUERY POWER ON LEVEL", 164: "QUERY SYSTEM FAILURE LEVEL",
+	165: "QUERY FADE TIME/FADE RATE", 166: "QUERY MANUFACTURER SPECIFIC MODE",
+	167: "QUERY NEXT DEVICE TYPE", 168: "QUERY EXTENDED FADE TIME", 170: "QUERY CONTROL GEAR FAILURE",
+	192: "QUERY GROUPS 0-7", 193: "QUERY GROUPS 8-15", 194: "QUERY RANDOM ADDRESS (H)",
+	195: "QUERY RANDOM ADDRESS (M)", 196: "QUERY RANDOM ADDRESS (L)", 197: "READ MEMORY LOCATION (DTR1, DTR0)",
+	255: "QUERY EXTENDED VERSION NUMBER",
+}
+
+// daliSpecialCommands names the special commands by their first byte. The
+// second byte is their data.
+var daliSpecialCommands = map[byte]string{
+	0xA1: "TERMINATE", 0xA3: "DTR0", 0xA5: "INITIALISE", 0xA7: "RANDOMISE",
+	0xA9: "COMPARE", 0xAB: "WITHDRAW", 0xAD: "PING", 0xB1: "SEARCHADDRH",
+	0xB3: "SEARCHADDRM", 0xB5: "SEARCHADDRL", 0xB7: "PROGRAM SHORT ADDRESS",
+	0xB9: "VERIFY SHORT ADDRESS", 0xBB: "QUERY SHORT ADDRESS", 0xC1: "ENABLE DEVICE TYPE",
+	0xC3: "DTR1", 0xC5: "DTR2", 0xC7: "WRITE MEMORY LOCATION",
+	0xC9: "WRITE MEMORY LOCATION - NO REPLY",
+}
+
+// DecodeDALI decodes a backward frame (1 byte), a forward frame to control
+// gear (2 bytes) or a forward frame to control devices (3 bytes).
+func DecodeDALI(data []byte) (*DALIFrame, error) {
+	switch len(data) {
+	case 1:
+		f := &DALIFrame{Kind: DALIBackward, Value: data[0], Command: fmt.Sprintf("answer %d", data[0])}
+		if data[0] == 0xFF {
+			f.Command += " (YES)"
+		}
+		return f, nil
+	case 2:
+		return decodeForward(data[0], data[1]), nil
+	case 3:
+		return decodeForward24(data), nil
+	default:
+		return nil, fmt.Errorf("%w: DALI frames have 1 to 3 bytes, got %d", ErrInvalidFrame, len(data))
+	}
+}
+
+// decodeForward decodes a 16-bit forward frame from its address and opcode
+// byte.
+func decodeForward(addr, op byte) *DALIFrame {
+	f := &DALIFrame{Kind: DALIForward, Value: op}
+	if name, ok := daliSpecialCommands[addr]; ok {
+		f.Address = "special command"
+		f.Command = fmt.Sprintf("%s %d", name, op)
+		return f
+	}
+	switch {
+	case addr&0x80 == 0:
+		f.Address = fmt.Sprintf("short address %d", addr>>1)
+	case addr&0xE0 == 0x80:
+		f.Address = fmt.Sprintf("group %d", (addr>>1)&0x0F)
+	case addr&0xFE == 0xFE:
+		f.Address = "broadcast"
+	case addr&0xFE == 0xFC:
+		f.Address = "broadcast unaddressed"
+	default:
+		f.Address = fmt.Sprintf("reserved 0x%02x", addr)
+		f.Command = fmt.Sprintf("0x%02x", op)
+		return f
+	}
+
+	if addr&1 == 0 {
+		f.Command = "DAPC " + ArcPowerText(op)
+	} else {
+		f.Command = daliCommand(op)
+	}
+	return f
+}
+
+// daliCommand names an opcode of a 16-bit command frame.
+func daliCommand(op byte) string {
+	if name, ok := daliCommands[op]; ok {
+		return name
+	}
+	switch {
+	case op >= 16 && op <= 31:
+		return fmt.Sprintf("GO TO SCENE %d", op-16)
+	case op >= 64 && op <= 79:
+		return fmt.Sprintf("SET SCENE %d (DTR0)", op-64)
+	case op >= 80 && op <= 95:
+		return fmt.Sprintf("REMOVE FROM SCENE %d", op-80)
+	case op >= 96 && op <= 111:
+		return fmt.Sprintf("ADD TO GROUP %d", op-96)
+	case op >= 112 && op <= 127:
+		return fmt.Sprintf("REMOVE FROM GROUP %d", op-112)
+	case op >= 176 && op <= 191:
+		return fmt.Sprintf("QUERY SCENE LEVEL %d", op-176)
+	case op >= 224:
+		return fmt.Sprintf("application extended command %d", op)
+	default:
+		return fmt.Sprintf("reserved command %d", op)
+	}
+}
+
+// decodeForward24 decodes a 24-bit forward frame to control devices. Only
+// the addressing is named; the instance byte and opcode are reported as
+// numbers.
+func decodeForward24(data []byte) *DALIFrame {
+	addr := data[0]
+	f := &DALIFrame{Kind: DALIForward24, Value: data[2]}
+	switch {
+	case addr&0x80 == 0:
+		f.Address = fmt.Sprintf("device short address %d", addr>>1)
+	case addr&0xC0 == 0x80:
+		f.Address = fmt.Sprintf("device group %d", (addr>>1)&0x1F)
+	case addr == 0xFF:
+		f.Address = "device broadcast"
+	case addr == 0xFD:
+		f.Address = "device broadcast unaddressed"
+	case addr == 0xC1:
+		f.Address = "special command"
+	default:
+		f.Address = fmt.Sprintf("reserved 0x%02x", addr)
+	}
+	if addr&1 == 0 && addr != 0xC1 {
+		f.Command = fmt.Sprintf("event, data 0x%02x%02x", data[1], data[2])
+		return f
+	}
+	f.Command = fmt.Sprintf("instance 0x%02x, opcode %d", data[1], data[2])
+	return f
+}
+
+// ArcPowerPercent returns the light output of a DALI arc power level on the
+// standard logarithmic dimming curve: 0 is off, 1 is 0.1 % and 254 is
+// 100 %. 255 (MASK) returns NaN, as it stops fading instead of setting a level.
+func ArcPowerPercent(level byte) float64 {
+	switch level {
+	case 0:
+		return 0
+	case 255:
+		return math.NaN()
+	}
+	return math.Pow(10, (float64(level)-1)/(253.0/3)-1)
+}
+
+// ArcPowerText formats an arc power level with its light output, e.g.
+// "254 (100.0%)".
+func ArcPowerText(level byte) string {
+	if level == 255 {
+		return "255 (MASK, stop fading)"
+	}
+	return fmt.Sprintf("%d (%.1f%%)", level, ArcPowerPercent(level))
+}
@@ -0,0 +1,57 @@
+package lighting
+
+import "fmt"
+
+// MaxDMXSlots is the number of data slots of a DMX512 packet.
+const MaxDMXSlots = 512
+
+// DMX512 start codes
+const (
+	StartCodeDimmer       = 0x00 // NULL start code, slots are dimmer levels
+	StartCodeText         = 0x17
+	StartCodeTest         = 0x55
+	StartCodeUTF8         = 0x90
+	StartCodeManufacturer = 0x91
+	StartCodeRDM          = 0xCC
+	StartCodeSIP          = 0xCF // system information packet
+)
+
+// startCodeNames names the start codes defined by ANSI E1.11 and E1.20.
+var startCodeNames = map[byte]string{
+	StartCodeDimmer:       "NULL (dimmer data)",
+	StartCodeText:         "text packet",
+	StartCodeTest:         "test packet",
+	StartCodeUTF8:         "UTF-8 text packet",
+	StartCodeManufacturer: "manufacturer ID",
+	StartCodeRDM:          "RDM",
+	StartCodeSIP:          "system information packet",
+}
+
+// DMXPacket is a decoded DMX512 packet.
+type DMXPacket struct {
+	StartCode byte
+	Kind      string // name of the start code
+	Slots     []byte // slot 1 is Slots[0]
+}
+
+// StartCodeName names a DMX512 start code.
+func StartCodeName(code byte) string {
+	if name, ok := startCodeNames[code]; ok {
+		return name
+	}
+	return fmt.Sprintf("alternate start code 0x%02x", code)
+}
+
+// DecodeDMX decodes a DMX512 packet: the start code followed by up to 512
+// slots, as captured after the break and mark-after-break.
+func DecodeDMX(data []byte) (*DMXPacket, error) {
+	if len(data) == 0 || len(data) > MaxDMXSlots+1 {
+		return nil, fmt.Errorf("%w: DMX512 packets have 1 to %d bytes, got %d", ErrInvalidFrame, MaxDMXSlots+1, len(data))
+	}
+	return &DMXPacket{StartCode: data[0], Kind: StartCodeName(data[0]), Slots: data[1:]}, nil
+}
+
+// SlotPercent returns a dimmer slot value as a percentage of full.
+func SlotPercent(v byte) float64 {
+	return float64(v) * 100 / 255
+}
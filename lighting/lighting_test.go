@@ -0,0 +1,89 @@
+package lighting
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestDecodeDALI(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		kind    string
+		address string
+		command string
+	}{
+		{"backward", []byte{0x42}, DALIBackward, "", "answer 66"},
+		{"backward yes", []byte{0xFF}, DALIBackward, "", "answer 255 (YES)"},
+		{"dapc short address", []byte{0x0A, 0xFE}, DALIForward, "short address 5", "DAPC 254 (100.0%)"},
+		{"dapc mask", []byte{0x00, 0xFF}, DALIForward, "short address 0", "DAPC 255 (MASK, stop fading)"},
+		{"query status", []byte{0x0B, 0x90}, DALIForward, "short address 5", "QUERY STATUS"},
+		{"group scene", []byte{0x87, 0x13}, DALIForward, "group 3", "GO TO SCENE 3"},
+		{"broadcast off", []byte{0xFF, 0x00}, DALIForward, "broadcast", "OFF"},
+		{"broadcast unaddressed", []byte{0xFD, 0x60}, DALIForward, "broadcast unaddressed", "ADD TO GROUP 0"},
+		{"special initialise", []byte{0xA5, 0x00}, DALIForward, "special command", "INITIALISE 0"},
+		{"special dtr0", []byte{0xA3, 0x2A}, DALIForward, "special command", "DTR0 42"},
+		{"forward24 device", []byte{0x03, 0xFE, 0x30}, DALIForward24, "device short address 1", "instance 0xfe, opcode 48"},
+		{"forward24 event", []byte{0x02, 0x12, 0x34}, DALIForward24, "device short address 1", "event, data 0x1234"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := DecodeDALI(tt.data)
+			if err != nil {
+				t.Fatalf("DecodeDALI() error: %v", err)
+			}
+			if f.Kind != tt.kind || f.Address != tt.address || f.Command != tt.command {
+				t.Errorf("DecodeDALI() = %+v, want %s %q %q", f, tt.kind, tt.address, tt.command)
+			}
+		})
+	}
+
+	if _, err := DecodeDALI([]byte{1, 2, 3, 4}); !errors.Is(err, ErrInvalidFrame) {
+		t.Errorf("DecodeDALI(4 bytes) error = %v, want ErrInvalidFrame", err)
+	}
+}
+
+func TestArcPowerText(t *testing.T) {
+	tests := []struct {
+		level byte
+		want  string
+	}{
+		{0, "0 (0.0%)"},
+		{1, "1 (0.1%)"},
+		{85, "85 (1.0%)"},
+		{170, "170 (10.1%)"},
+		{254, "254 (100.0%)"},
+		{255, "255 (MASK, stop fading)"},
+	}
+	for _, tt := range tests {
+		if got := ArcPowerText(tt.level); got != tt.want {
+			t.Errorf("ArcPowerText(%d) = %q, want %q", tt.level, got, tt.want)
+		}
+	}
+	if !math.IsNaN(ArcPowerPercent(255)) {
+		t.Error("ArcPowerPercent(255) is not NaN")
+	}
+}
+
+func TestDecodeDMX(t *testing.T) {
+	p, err := DecodeDMX([]byte{0xCC, 0x01, 0x18})
+	if err != nil {
+		t.Fatalf("DecodeDMX() error: %v", err)
+	}
+	if p.StartCode != StartCodeRDM || p.Kind != "RDM" || len(p.Slots) != 2 {
+		t.Errorf("DecodeDMX() = %+v", p)
+	}
+	if got := StartCodeName(0x42); got != "alternate start code 0x42" {
+		t.Errorf("StartCodeName(0x42) = %q", got)
+	}
+
+	if _, err := DecodeDMX(make([]byte, MaxDMXSlots+1)); err != nil {
+		t.Errorf("DecodeDMX(513 bytes) error: %v", err)
+	}
+	for _, n := range []int{0, MaxDMXSlots + 2} {
+		if _, err := DecodeDMX(make([]byte, n)); !errors.Is(err, ErrInvalidFrame) {
+			t.Errorf("DecodeDMX(%d bytes) error = %v, want ErrInvalidFrame", n, err)
+		}
+	}
+}
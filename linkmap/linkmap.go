@@ -0,0 +1,276 @@
+// Package linkmap reads the map files GNU ld and Arm armlink write next to
+// a firmware image, so addresses found in memory dumps resolve to the
+// symbols they point into.
+//
+// GNU ld maps give the address of each global symbol under its input
+// section; a symbol's size reaches to the next symbol of the section or
+// the section end. Addresses that fall in an input section without a
+// symbol, e.g. of static functions built with -ffunction-sections, resolve
+// to the section. armlink maps list every symbol with its size in the
+// Image Symbol Table; Thumb code addresses have bit 0 cleared.
+//
+// Example usage:
+//
+//	m, _ := linkmap.Parse(file)
+//	if sym, off, ok := m.Lookup(0x20000110); ok {
+//		fmt.Printf("%s + 0x%x\n", sym.Name, off) // g_buffer + 0x10
+//	}
+package linkmap
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Map file formats
+const (
+	FormatGNU     = "gnu"
+	FormatArmlink = "armlink"
+)
+
+// ErrInvalidMap indicates a file that is not a GNU ld or armlink map file
+var ErrInvalidMap = errors.New("invalid linker map")
+
+// Map is a parsed linker map file.
+type Map struct {
+	Format  string
+	Regions []Region
+	Symbols []Symbol // sorted by address
+}
+
+// Region is a memory region or execution region of the image.
+type Region struct {
+	Name   string
+	Origin uint64
+	Length uint64
+}
+
+// Symbol is a symbol or, for addresses without one, an input section.
+type Symbol struct {
+	Name    string
+	Address uint64
+	Size    uint64
+	Section string // input section, e.g. .text.main
+	Object  string // object file, e.g. main.o
+}
+
+var (
+	// gnuSection matches an input section with its address, size and
+	// object, or only the name if it is too long and the rest follows on
+	// the next line
+	gnuSection     = regexp.MustCompile(`^ (\.\S+|COMMON)(?:\s+0x([0-9a-fA-F]+)\s+0x([0-9a-fA-F]+)\s+(\S.*))?$`)
+	gnuSectionRest = regexp.MustCompile(`^\s+0x([0-9a-fA-F]+)\s+0x([0-9a-fA-F]+)\s+(\S.*)$`)
+	gnuSymbol      = regexp.MustCompile(`^\s+0x([0-9a-fA-F]+)\s+([A-Za-z_$][\w$.]*)$`)
+	gnuRegion      = regexp.MustCompile(`^(\S+)\s+0x([0-9a-fA-F]+)\s+0x([0-9a-fA-F]+)`)
+
+	armSymbol = regexp.MustCompile(`^\s+(\S+)\s+0x([0-9a-fA-F]+)\s+(ARM Code|Thumb Code|Data|Number|Section)\s+(\d+)\s+(\S+?)(?:\((.*)\))?(?:\s.*)?$`)
+	armRegion = regexp.MustCompile(`^\s*Execution Region (\S+) \((?:Exec base|Base): 0x([0-9a-fA-F]+).*Max: 0x([0-9a-fA-F]+)`)
+)
+
+// Parse reads a GNU ld or armlink map file, detected from its headings.
+func Parse(r io.Reader) (*Map, error) {
+	var lines []string
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64<<10), 1<<20)
+	for sc.Scan() {
+		lines = append(lines, strings.TrimRight(sc.Text(), " \t\r"))
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	var m *Map
+	for _, line := range lines {
+		if strings.HasPrefix(line, "Linker script and memory map") || strings.HasPrefix(line, "Memory Configuration") {
+			m = parseGNU(lines)
+			break
+		}
+		if strings.Contains(line, "Image Symbol Table") || strings.Contains(line, "Memory Map of the image") {
+			m = parseArmlink(lines)
+			break
+		}
+	}
+	if m == nil {
+		return nil, fmt.Errorf("%w: no GNU ld or armlink headings found", ErrInvalidMap)
+	}
+	if len(m.Symbols) == 0 && len(m.Regions) == 0 {
+		return nil, fmt.Errorf("%w: no symbols or regions found", ErrInvalidMap)
+	}
+	m.sortSymbols()
+	return m, nil
+}
+
+// New returns a Map of symbols from another source, e.g. the symbol table
+// of an ELF file, so addresses can be looked up in it like in a map file.
+func New(format string, regions []Region, symbols []Symbol) *Map {
+	m := &Map{Format: format, Regions: regions, Symbols: symbols}
+	m.sortSymbols()
+	return m
+}
+
+// sortSymbols sorts the symbols by address for Lookup.
+func (m *Map) sortSymbols() {
+	sort.SliceStable(m.Symbols, func(i, j int) bool { return m.Symbols[i].Address < m.Symbols[j].Address })
+}
+
+// parseGNU reads the memory configuration and the symbols of the memory
+// map of a GNU ld map file. Discarded input sections are skipped.
+func parseGNU(lines []string) *Map {
+	m := &Map{Format: FormatGNU}
+	const (
+		other = iota
+		memory
+		script
+	)
+	state := other
+
+	// Input section the following symbols belong to
+	var section Symbol
+	var symbols []Symbol
+	flush := func() {
+		if section.Name == "" {
+			return
+		}
+		end := section.Address + section.Size
+		switch {
+		case len(symbols) == 0 && section.Size > 0:
+			// Name the address range after the section, e.g. of a static function
+			m.Symbols = append(m.Symbols, Symbol{
+				Name: section.Object + "(" + section.Name + ")", Address: section.Address,
+				Size: section.Size, Section: section.Name, Object: section.Object,
+			})
+		default:
+			for i := range symbols {
+				next := end
+				if i+1 < len(symbols) {
+					next = symbols[i+1].Address
+				}
+				if next > symbols[i].Address {
+					symbols[i].Size = next - symbols[i].Address
+				}
+			}
+			m.Symbols = append(m.Symbols, symbols...)
+		}
+		section, symbols = Symbol{}, nil
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.HasPrefix(line, "Memory Configuration"):
+			state = memory
+			continue
+		case strings.HasPrefix(line, "Linker script and memory map"):
+			state = script
+			continue
+		case strings.HasPrefix(line, "Discarded input sections"), strings.HasPrefix(line, "Cross Reference Table"):
+			flush()
+			state = other
+			continue
+		}
+
+		switch state {
+		case memory:
+			if r := gnuRegion.FindStringSubmatch(line); r != nil && r[1] != "*default*" {
+				origin, _ := strconv.ParseUint(r[2], 16, 64)
+				length, _ := strconv.ParseUint(r[3], 16, 64)
+				m.Regions = append(m.Regions, Region{Name: r[1], Origin: origin, Length: length})
+			}
+
+		case script:
+			if s := gnuSection.FindStringSubmatch(line); s != nil {
+				flush()
+				addr, size, object := s[2], s[3], s[4]
+				if addr == "" {
+					// Long section names continue on the next line
+					if i+1 >= len(lines) {
+						continue
+					}
+					rest := gnuSectionRest.FindStringSubmatch(lines[i+1])
+					if rest == nil {
+						continue
+					}
+					i++
+					addr, size, object = rest[1], rest[2], rest[3]
+				}
+				section.Name = s[1]
+				section.Address, _ = strconv.ParseUint(addr, 16, 64)
+				section.Size, _ = strconv.ParseUint(size, 16, 64)
+				section.Object = objectName(object)
+				continue
+			}
+			if s := gnuSymbol.FindStringSubmatch(line); s != nil && section.Name != "" {
+				addr, _ := strconv.ParseUint(s[1], 16, 64)
+				symbols = append(symbols, Symbol{Name: s[2], Address: addr, Section: section.Name, Object: section.Object})
+				continue
+			}
+			if line != "" && !strings.HasPrefix(line, " ") {
+				// An output section ends the input section
+				flush()
+			}
+		}
+	}
+	flush()
+	return m
+}
+
+// parseArmlink reads the execution regions and the Image Symbol Table of
+// an armlink map file.
+func parseArmlink(lines []string) *Map {
+	m := &Map{Format: FormatArmlink}
+	for _, line := range lines {
+		if r := armRegion.FindStringSubmatch(line); r != nil {
+			origin, _ := strconv.ParseUint(r[2], 16, 64)
+			length, _ := strconv.ParseUint(r[3], 16, 64)
+			m.Regions = append(m.Regions, Region{Name: r[1], Origin: origin, Length: length})
+			continue
+		}
+		s := armSymbol.FindStringSubmatch(line)
+		if s == nil || s[3] == "Number" || s[3] == "Section" {
+			continue
+		}
+		addr, _ := strconv.ParseUint(s[2], 16, 64)
+		if s[3] == "Thumb Code" {
+			addr &^= 1
+		}
+		size, _ := strconv.ParseUint(s[4], 10, 64)
+		m.Symbols = append(m.Symbols, Symbol{Name: s[1], Address: addr, Size: size, Section: s[6], Object: s[5]})
+	}
+	return m
+}
+
+// objectName returns the object file of a GNU ld input section line
+// without its directory, e.g. libc.a(lib_a-memcpy.o) for
+// /usr/lib/libc.a(lib_a-memcpy.o).
+func objectName(s string) string {
+	s = strings.TrimSpace(s)
+	path, _, _ := strings.Cut(s, "(")
+	if i := strings.LastIndexAny(path, "/\\"); i >= 0 {
+		s = s[i+1:]
+	}
+	return s
+}
+
+// maxSymbolSpan limits how far below an address Lookup looks for a symbol
+// containing it.
+const maxSymbolSpan = 1 << 20
+
+// Lookup returns the symbol addr points into and the offset of addr in it.
+// Symbols without a size only match their own address.
+func (m *Map) Lookup(addr uint64) (Symbol, uint64, bool) {
+	// Symbols below the last one at or below addr may be larger and contain it
+	i := sort.Search(len(m.Symbols), func(i int) bool { return m.Symbols[i].Address > addr })
+	for j := i - 1; j >= 0 && addr-m.Symbols[j].Address < maxSymbolSpan; j-- {
+		s := m.Symbols[j]
+		if addr == s.Address || addr-s.Address < s.Size {
+			return s, addr - s.Address, true
+		}
+	}
+	return Symbol{}, 0, false
+}
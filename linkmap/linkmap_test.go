@@ -0,0 +1,188 @@
+package linkmap
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testGNUMap = `Archive member included to satisfy reference by file (symbol)
+
+/usr/lib/arm-none-eabi/newlib/thumb/v7e-m/libc.a(lib_a-memcpy-stub.o)
+                              main.o (memcpy)
+
+Discarded input sections
+
+ .text          0x00000000        0x0 startup.o
+ .text.unused   0x00000000       0x10 main.o
+
+Memory Configuration
+
+Name             Origin             Length             Attributes
+FLASH            0x08000000         0x00080000         xr
+RAM              0x20000000         0x00020000         xrw
+*default*        0x00000000         0xffffffff
+
+Linker script and memory map
+
+LOAD startup.o
+LOAD main.o
+
+.isr_vector     0x08000000      0x188
+                0x08000000                . = ALIGN (0x4)
+ *(.isr_vector)
+ .isr_vector    0x08000000      0x188 startup.o
+                0x08000000                g_pfnVectors
+                0x08000188                . = ALIGN (0x4)
+
+.text           0x08000188       0xd8
+ *(.text*)
+ .text.main     0x08000188       0x40 build/main.o
+                0x08000188                main
+ .text.helper   0x080001c8       0x18 build/main.o
+ .text.Reset_Handler
+                0x080001e0       0x50 build/startup.o
+                0x080001e0                Reset_Handler
+                0x08000220                Default_Handler
+ .text          0x08000230       0x30 /usr/lib/arm-none-eabi/newlib/thumb/v7e-m/libc.a(lib_a-memcpy-stub.o)
+                0x08000230                memcpy
+                0x08000260                _etext = .
+
+.bss            0x20000000      0x204
+                0x20000000                _sbss = .
+ .bss.counter   0x20000000        0x4 build/main.o
+                0x20000000                counter
+ .bss.g_buffer  0x20000004      0x100 build/main.o
+                0x20000004                g_buffer
+ COMMON         0x20000104      0x100 build/main.o
+                0x20000104                rx_fifo
+OUTPUT(firmware.elf elf32-littlearm)
+`
+
+const testArmlinkMap = `Component: ARM Compiler 6.16 Tool: armlink [5dfeb700]
+
+==============================================================================
+
+Image Symbol Table
+
+    Local Symbols
+
+    Symbol Name                              Value     Ov Type        Size  Object(Section)
+
+    ../src/main.c                            0x00000000   Number         0  main.o ABSOLUTE
+    helper                                   0x080001c9   Thumb Code    24  main.o(.text.helper)
+    .text                                    0x08000188   Section        0  main.o(.text)
+
+    Global Symbols
+
+    Symbol Name                              Value     Ov Type        Size  Object(Section)
+
+    main                                     0x08000189   Thumb Code    64  main.o(.text.main)
+    g_buffer                                 0x20000004   Data         256  main.o(.bss.g_buffer)
+
+==============================================================================
+
+Memory Map of the image
+
+  Image Entry point : 0x080001e1
+
+  Load Region LR_IROM1 (Base: 0x08000000, Size: 0x00000300, Max: 0x00080000, ABSOLUTE)
+
+    Execution Region ER_IROM1 (Exec base: 0x08000000, Load base: 0x08000000, Size: 0x00000260, Max: 0x00080000, ABSOLUTE)
+
+    Execution Region RW_IRAM1 (Exec base: 0x20000000, Load base: 0x08000260, Size: 0x00000204, Max: 0x00020000, ABSOLUTE)
+`
+
+func TestParseGNU(t *testing.T) {
+	m, err := Parse(strings.NewReader(testGNUMap))
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if m.Format != FormatGNU {
+		t.Errorf("Format = %s, want gnu", m.Format)
+	}
+	wantRegions := []Region{{"FLASH", 0x08000000, 0x80000}, {"RAM", 0x20000000, 0x20000}}
+	if len(m.Regions) != 2 || m.Regions[0] != wantRegions[0] || m.Regions[1] != wantRegions[1] {
+		t.Errorf("Regions = %+v, want %+v", m.Regions, wantRegions)
+	}
+	for _, s := range m.Symbols {
+		if s.Address == 0 {
+			t.Errorf("Discarded section symbol %+v was kept", s)
+		}
+	}
+
+	tests := []struct {
+		addr   uint64
+		name   string
+		offset uint64
+		object string
+	}{
+		{0x08000000, "g_pfnVectors", 0, "startup.o"},
+		{0x0800018c, "main", 4, "main.o"},
+		{0x080001d0, "main.o(.text.helper)", 8, "main.o"},
+		{0x08000224, "Default_Handler", 4, "startup.o"},
+		{0x08000240, "memcpy", 0x10, "libc.a(lib_a-memcpy-stub.o)"},
+		{0x20000000, "counter", 0, "main.o"},
+		{0x20000014, "g_buffer", 0x10, "main.o"},
+		{0x20000203, "rx_fifo", 0xff, "main.o"},
+	}
+	for _, tt := range tests {
+		s, off, ok := m.Lookup(tt.addr)
+		if !ok || s.Name != tt.name || off != tt.offset || s.Object != tt.object {
+			t.Errorf("Lookup(0x%x) = %s + 0x%x (%s), %v, want %s + 0x%x (%s)", tt.addr, s.Name, off, s.Object, ok, tt.name, tt.offset, tt.object)
+		}
+	}
+	for _, addr := range []uint64{0x07ffffff, 0x08000260, 0x20000204} {
+		if s, _, ok := m.Lookup(addr); ok {
+			t.Errorf("Lookup(0x%x) = %s, want no symbol", addr, s.Name)
+		}
+	}
+}
+
+func TestParseArmlink(t *testing.T) {
+	m, err := Parse(strings.NewReader(testArmlinkMap))
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if m.Format != FormatArmlink || len(m.Symbols) != 3 {
+		t.Fatalf("Parse() = %s with %d symbols, want armlink with 3", m.Format, len(m.Symbols))
+	}
+	if len(m.Regions) != 2 || m.Regions[1] != (Region{"RW_IRAM1", 0x20000000, 0x20000}) {
+		t.Errorf("Regions = %+v", m.Regions)
+	}
+	s, off, ok := m.Lookup(0x080001c0)
+	if !ok || s.Name != "main" || off != 0x38 {
+		t.Errorf("Lookup(0x080001c0) = %s + 0x%x, want main + 0x38", s.Name, off)
+	}
+	if s.Section != ".text.main" || s.Object != "main.o" {
+		t.Errorf("Section = %s in %s, want .text.main in main.o", s.Section, s.Object)
+	}
+	// The Thumb bit of the symbol address is cleared
+	if s, off, _ := m.Lookup(0x080001c8); s.Name != "helper" || off != 0 {
+		t.Errorf("Lookup(0x080001c8) = %s + 0x%x, want helper", s.Name, off)
+	}
+	if s, off, _ := m.Lookup(0x080001d0); s.Name != "helper" || off != 8 {
+		t.Errorf("Lookup(0x080001d0) = %s + 0x%x, want helper + 0x8", s.Name, off)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, text := range []string{"", "just some text\n", "Memory Configuration\n\nName Origin Length\n"} {
+		if _, err := Parse(strings.NewReader(text)); !errors.Is(err, ErrInvalidMap) {
+			t.Errorf("Parse(%q) error = %v, want ErrInvalidMap", text, err)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	m := New("elf", nil, []Symbol{
+		{Name: "g_buffer", Address: 0x20000000, Size: 0x100},
+		{Name: "main", Address: 0x08000000, Size: 0x10},
+	})
+	if s, off, ok := m.Lookup(0x08000004); !ok || s.Name != "main" || off != 4 {
+		t.Errorf("Lookup(0x08000004) = %s + 0x%x, %v, want main + 0x4", s.Name, off, ok)
+	}
+	if s, off, ok := m.Lookup(0x200000ff); !ok || s.Name != "g_buffer" || off != 0xff {
+		t.Errorf("Lookup(0x200000ff) = %s + 0x%x, %v, want g_buffer + 0xff", s.Name, off, ok)
+	}
+}
@@ -2,16 +2,26 @@ package main
 
 import (
 	"embed"
+	"os"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
 	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+
+	"hexview/cli"
+	"hexview/models"
+	"hexview/service"
 )
 
 //go:embed all:frontend/dist
 var assets embed.FS
 
 func main() {
+	// CLI subcommands and --stdin mode run the conversion engine without starting the GUI
+	if cli.Headless(os.Args[1:]) {
+		os.Exit(cli.Run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+	}
+
 	// Create an instance of the app structure
 	app := NewApp()
 
@@ -27,9 +37,27 @@ func main() {
 		},
 		BackgroundColour: &options.RGBA{R: 27, G: 38, B: 54, A: 1},
 		OnStartup:        app.startup,
+		OnShutdown:       app.shutdown,
+		DragAndDrop: &options.DragAndDrop{
+			EnableFileDrop: true,
+		},
 		Bind: []interface{}{
 			app,
 		},
+		// Generates the IntType and FloatType enums used by ConvertInt and
+		// ConvertFloat in the frontend bindings
+		EnumBind: []interface{}{
+			models.AllIntTypes,
+			models.AllFloatTypes,
+		},
+		// Input errors reach the frontend as objects with a message, code and
+		// position so the invalid characters can be highlighted
+		ErrorFormatter: func(err error) any {
+			if details := service.ErrorDetails(err); details != nil {
+				return details
+			}
+			return err.Error()
+		},
 	})
 
 	if err != nil {
@@ -0,0 +1,44 @@
+package models
+
+// Alert rule conditions
+const (
+	AlertAbove    = "above"    // value greater than the threshold
+	AlertBelow    = "below"    // value less than the threshold
+	AlertBitSet   = "bitSet"   // bit of the raw value is 1
+	AlertBitClear = "bitClear" // bit of the raw value is 0
+)
+
+// Alert rule sources
+const (
+	AlertSourcePoll    = ""        // values of Modbus polls
+	AlertSourceCapture = "capture" // bytes received by capture sessions
+)
+
+// AlertRule raises an alert while a polled value or received bytes meet a
+// condition, e.g. a fault bit being set
+type AlertRule struct {
+	ID        int64   `json:"id"`
+	Label     string  `json:"label"`
+	Source    string  `json:"source,omitempty"`    // AlertSourcePoll or AlertSourceCapture
+	Value     string  `json:"value,omitempty"`     // name of the polled value, e.g. "voltage" or "40001"
+	Condition string  `json:"condition"`           // AlertAbove, AlertBelow, AlertBitSet or AlertBitClear
+	Threshold float64 `json:"threshold,omitempty"` // compared with the scaled value
+	Bit       int     `json:"bit,omitempty"`       // 0 to 63, tested in the raw integer value
+
+	// Capture rules read an unsigned integer from every received chunk
+	Offset    int    `json:"offset,omitempty"`    // of the integer in the chunk
+	Size      int    `json:"size,omitempty"`      // 1, 2, 4 or 8 bytes, 0 means 1
+	ByteOrder string `json:"byteOrder,omitempty"` // BE (default) or LE
+}
+
+// Alert reports that a rule started or stopped matching the values of a
+// poll or the bytes received by a capture session
+type Alert struct {
+	Rule      AlertRule `json:"rule"`
+	PollID    string    `json:"pollId,omitempty"`
+	CaptureID string    `json:"captureId,omitempty"`
+	Timestamp string    `json:"timestamp"`     // of the sample or chunk, RFC 3339 with nanoseconds
+	Value     string    `json:"value"`         // sample value, or the integer read from the chunk
+	Raw       string    `json:"raw,omitempty"` // raw sample value, or the bytes of the integer in hex
+	Active    bool      `json:"active"`        // true when raised, false when cleared
+}
@@ -0,0 +1,30 @@
+package models
+
+// ALUValue is a value of an ALU operation in a fixed width
+type ALUValue struct {
+	Hex      string `json:"hex"`
+	Binary   string `json:"binary"`
+	Unsigned uint64 `json:"unsigned"`
+	Signed   int64  `json:"signed"` // two's complement
+}
+
+// ALUFlags are the condition flags an ALU sets for a result
+type ALUFlags struct {
+	Carry    bool `json:"carry"`    // unsigned result does not fit; a borrow for sub, like x86 CF
+	Overflow bool `json:"overflow"` // signed result does not fit
+	Zero     bool `json:"zero"`
+	Negative bool `json:"negative"` // sign bit of the result
+}
+
+// ALUResult holds an add, sub or mul computed in a fixed width with the
+// flags a CPU would set
+type ALUResult struct {
+	Op            string   `json:"op"`   // add, sub or mul
+	Bits          int      `json:"bits"` // 8, 16, 32 or 64
+	A             ALUValue `json:"a"`
+	B             ALUValue `json:"b"`
+	Result        ALUValue `json:"result"`
+	UnsignedExact string   `json:"unsignedExact"` // result of the operands read as unsigned, without wrapping
+	SignedExact   string   `json:"signedExact"`   // result of the operands read as signed, without wrapping
+	Flags         ALUFlags `json:"flags"`
+}
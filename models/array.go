@@ -0,0 +1,88 @@
+package models
+
+// ArrayResult holds a buffer decoded as consecutive values of one type,
+// e.g. 256 × int16 LE
+type ArrayResult struct {
+	Type      string      `json:"type"`
+	Order     string      `json:"order"`     // BE, LE, BADC or CDAB
+	Size      int         `json:"size"`      // bytes per value; value i starts at offset i*size
+	Count     int         `json:"count"`     // values in the buffer
+	Remainder int         `json:"remainder"` // trailing bytes too short for a value
+	Values    []string    `json:"values"`
+	Truncated bool        `json:"truncated,omitempty"` // only the first values are listed
+	Stats     *ArrayStats `json:"stats,omitempty"`     // of the listed values, nil if none is finite
+}
+
+// Directions of monotonic array values
+const (
+	TrendIncreasing = "increasing"
+	TrendDecreasing = "decreasing"
+	TrendConstant   = "constant"
+)
+
+// ArrayStats summarizes the finite values of an array, which helps to
+// recognize waveform or counter data in unknown dumps
+type ArrayStats struct {
+	Count      int      `json:"count"` // finite values; NaN and infinities are skipped
+	Min        float64  `json:"min"`
+	Max        float64  `json:"max"`
+	Mean       float64  `json:"mean"`
+	StdDev     float64  `json:"stdDev"`         // population standard deviation
+	Monotonic  string   `json:"monotonic"`      // TrendIncreasing, TrendDecreasing, TrendConstant or "" if the values go up and down
+	Step       *float64 `json:"step,omitempty"` // difference between all consecutive values, e.g. 1 for a counter
+	LongestRun ArrayRun `json:"longestRun"`     // longest strictly increasing or decreasing sequence
+}
+
+// ArrayRun is a sequence of consecutive array values
+type ArrayRun struct {
+	Start     int    `json:"start"` // index of the first value
+	Length    int    `json:"length"`
+	Direction string `json:"direction"` // TrendIncreasing or TrendDecreasing
+}
+
+// PlotOptions selects how array values are decoded and downsampled for a chart
+type PlotOptions struct {
+	Type      string `json:"type"`      // int8 to uint64, float32 or float64
+	Order     string `json:"order"`     // BE, LE, BADC or CDAB, BE if empty
+	MaxPoints int    `json:"maxPoints"` // points of the series, 1000 if zero
+}
+
+// PlotSeries is a chart-friendly series of array values. Above MaxPoints
+// values, consecutive values are combined into one point with their minimum
+// and maximum, so peaks stay visible
+type PlotSeries struct {
+	Type     string      `json:"type"`
+	Order    string      `json:"order"`
+	Offset   int64       `json:"offset"`   // of the first value in the source
+	Size     int         `json:"size"`     // bytes per value
+	Count    int         `json:"count"`    // values decoded
+	PerPoint int         `json:"perPoint"` // values combined into one point
+	Points   []PlotPoint `json:"points"`
+}
+
+// PlotPoint is the range of one or more consecutive array values. Points
+// without finite values (NaN, infinities) are left out
+type PlotPoint struct {
+	Index  int     `json:"index"`  // of the first value
+	Offset int64   `json:"offset"` // of the first value in the source
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+}
+
+// Delta transforms
+const (
+	DeltaDecode = "decode" // values are differences from the previous value
+	DeltaEncode = "encode" // store values as differences from the previous value
+)
+
+// DeltaResult holds an array after delta decoding or encoding. The first
+// value is kept as is
+type DeltaResult struct {
+	Type      string   `json:"type"`
+	Order     string   `json:"order"`
+	Mode      string   `json:"mode"` // DeltaDecode or DeltaEncode
+	Count     int      `json:"count"`
+	Values    []string `json:"values"`
+	Truncated bool     `json:"truncated,omitempty"` // only the first values are listed
+	Hex       string   `json:"hex"`                 // transformed buffer, trailing bytes too short for a value unchanged
+}
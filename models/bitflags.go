@@ -0,0 +1,21 @@
+package models
+
+// BitFlags breaks the input, read as an unsigned integer of up to 64 bits,
+// down into its bits, e.g. to see which flags of a status register are set
+type BitFlags struct {
+	Width    int       `json:"width"`    // bits
+	Value    string    `json:"value"`    // hex, 0x prefixed
+	SetCount int       `json:"setCount"` // number of set bits
+	Parity   string    `json:"parity"`   // "even" or "odd" number of set bits
+	Set      []int     `json:"set"`      // indexes of the set bits, ascending
+	Bits     []BitFlag `json:"bits"`     // bit 0 first
+}
+
+// BitFlag is one bit of BitFlags
+type BitFlag struct {
+	Index     int    `json:"index"`     // 0 is the least significant bit
+	Value     int    `json:"value"`     // 0 or 1
+	Mask      string `json:"mask"`      // the bit alone, e.g. 0x0004
+	Byte      int    `json:"byte"`      // offset of the byte holding the bit in the input
+	BitInByte int    `json:"bitInByte"` // position of the bit in that byte, 0 for the least significant
+}
@@ -0,0 +1,25 @@
+package models
+
+// BulkOptions configure a bulk conversion
+type BulkOptions struct {
+	Type    string `json:"type,omitempty"`    // integer or float type of int and float conversions
+	Workers int    `json:"workers,omitempty"` // conversions run in parallel, 0 for the number of CPUs
+	Compact bool   `json:"compact,omitempty"` // store conversions as their non-empty values (name → value, hex) to save memory
+}
+
+// BulkItem is the outcome of converting one input of a bulk conversion.
+// Depending on the mode and options Conversion, Compact or Modbus is set, or
+// Error if the input could not be converted.
+type BulkItem struct {
+	Index      int                   `json:"index"`
+	Conversion *ConversionResult     `json:"conversion,omitempty"`
+	Compact    map[string]TypedValue `json:"compact,omitempty"`
+	Modbus     *ModbusResult         `json:"modbus,omitempty"`
+	Error      string                `json:"error,omitempty"`
+}
+
+// BulkResult holds one item per input, in the order of the inputs.
+type BulkResult struct {
+	Items  []BulkItem `json:"items"`
+	Failed int        `json:"failed"` // number of items with an error
+}
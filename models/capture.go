@@ -0,0 +1,55 @@
+package models
+
+// SerialConfig holds the line settings for a serial port capture
+type SerialConfig struct {
+	Port     string `json:"port"`
+	BaudRate int    `json:"baudRate"`
+	DataBits int    `json:"dataBits"` // 5-8, 8 if zero
+	Parity   string `json:"parity"`   // none, odd, even, mark, space
+	StopBits string `json:"stopBits"` // 1, 1.5, 2
+}
+
+// CaptureInfo describes a live capture session
+type CaptureInfo struct {
+	ID            string `json:"id"`
+	Kind          string `json:"kind"`           // serial, tcp-client, tcp-server, udp
+	Source        string `json:"source"`         // port name or address
+	Settings      string `json:"settings"`       // e.g. "115200 8N1"
+	Peer          string `json:"peer,omitempty"` // current remote peer of socket captures
+	Running       bool   `json:"running"`
+	Error         string `json:"error,omitempty"`
+	StartedAt     string `json:"startedAt"`
+	TotalBytes    int64  `json:"totalBytes"` // bytes received
+	SentBytes     int64  `json:"sentBytes"`
+	RetainedBytes int    `json:"retainedBytes"`
+	Chunks        int    `json:"chunks"`
+}
+
+// CaptureChunk is a timestamped block of bytes received or sent by a capture session
+type CaptureChunk struct {
+	CaptureID string `json:"captureId"`
+	Seq       int    `json:"seq"`
+	Timestamp string `json:"timestamp"` // RFC 3339 with nanoseconds
+	Direction string `json:"direction"` // rx or tx
+	Offset    int64  `json:"offset"`    // offset in the received or sent stream
+	Length    int    `json:"length"`
+	Hex       string `json:"hex"`
+	ASCII     string `json:"ascii"`
+}
+
+// TransmitOptions controls how a frame is sent and its response collected
+type TransmitOptions struct {
+	TimeoutMs  int `json:"timeoutMs"`  // wait for the first response byte; 1000 if zero
+	IdleMs     int `json:"idleMs"`     // silence that ends a response; 50 if zero, -1 to wait for the full timeout
+	IntervalMs int `json:"intervalMs"` // pause between frames when replaying
+}
+
+// TransmitResult holds a sent frame and the response logged for it
+type TransmitResult struct {
+	Sent           CaptureChunk `json:"sent"`
+	Response       string       `json:"response"` // hex
+	ResponseASCII  string       `json:"responseAscii"`
+	ResponseOffset int64        `json:"responseOffset"` // offset in the received stream
+	LatencyMs      float64      `json:"latencyMs"`
+	TimedOut       bool         `json:"timedOut"`
+}
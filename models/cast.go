@@ -0,0 +1,20 @@
+package models
+
+// CastResult shows what a value becomes when converted to a narrower or
+// differently signed integer type
+type CastResult struct {
+	From  string      `json:"from"`  // source type, e.g. int32 or float64
+	To    string      `json:"to"`    // integer target type, e.g. int16
+	Value string      `json:"value"` // input as parsed
+	Exact bool        `json:"exact"` // the target type holds the value unchanged
+	Casts []CastValue `json:"casts"`
+}
+
+// CastValue is the result of one way of converting a value
+type CastValue struct {
+	Mode    string `json:"mode"`           // truncate, saturate or round
+	Value   string `json:"value"`          // "undefined" for NaN and Inf truncated
+	Hex     string `json:"hex,omitempty"`  // bits of the result, big-endian
+	Changed bool   `json:"changed"`        // the result differs from the input
+	Note    string `json:"note,omitempty"` // e.g. that C leaves the cast undefined
+}
@@ -0,0 +1,15 @@
+package models
+
+// ChecksumValue is the result of a single checksum algorithm
+type ChecksumValue struct {
+	Name  string `json:"name"`
+	Width int    `json:"width"` // in bits
+	Hex   string `json:"hex"`   // big-endian, zero padded to the width
+	Value uint64 `json:"value"`
+}
+
+// ChecksumResult holds the checksums of a byte sequence
+type ChecksumResult struct {
+	Length    int             `json:"length"`
+	Checksums []ChecksumValue `json:"checksums"`
+}
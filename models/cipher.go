@@ -0,0 +1,10 @@
+package models
+
+// CipherResult is a buffer after a classical cipher was applied to its
+// ASCII letters
+type CipherResult struct {
+	Cipher string `json:"cipher"`
+	Shift  int    `json:"shift,omitempty"` // of the Caesar cipher
+	Text   string `json:"text"`            // ASCII interpretation, '.' for non-printable bytes
+	Hex    string `json:"hex"`             // transformed bytes
+}
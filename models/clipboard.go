@@ -0,0 +1,9 @@
+package models
+
+// ClipboardConversion is the automatic conversion of a value copied to the clipboard
+type ClipboardConversion struct {
+	Text      string            `json:"text"`
+	Kind      string            `json:"kind"` // "hex" or "decimal"
+	Result    *ConversionResult `json:"result"`
+	Timestamp string            `json:"timestamp"` // RFC3339
+}
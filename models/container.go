@@ -0,0 +1,19 @@
+package models
+
+// ContainerChunk describes a structural chunk of a container file (PNG chunk, ZIP record, ...)
+type ContainerChunk struct {
+	Name       string `json:"name"`
+	Offset     int64  `json:"offset"`
+	Size       int64  `json:"size"`
+	DataOffset int64  `json:"dataOffset"`
+	DataSize   int64  `json:"dataSize"`
+	Details    string `json:"details,omitempty"`
+	Valid      bool   `json:"valid"`
+}
+
+// ContainerLayout holds the chunk listing of a PNG/ZIP/TAR/RIFF container
+type ContainerLayout struct {
+	Format   string           `json:"format"`
+	Chunks   []ContainerChunk `json:"chunks"`
+	Warnings []string         `json:"warnings,omitempty"`
+}
@@ -0,0 +1,32 @@
+package models
+
+// Crash dump layouts
+const (
+	CrashCortexM    = "cortex-m"     // exception stack frame: R0-R3, R12, LR, PC, xPSR
+	CrashCortexMFPU = "cortex-m-fpu" // extended frame with S0-S15 and FPSCR
+	CrashFreeRTOS   = "freertos"     // FreeRTOS TCB_t of a 32-bit port
+	CrashThreadX    = "threadx"      // ThreadX TX_THREAD of a 32-bit port
+)
+
+// DumpField is a named field of a register dump or task control block
+type DumpField struct {
+	Name    string `json:"name"`
+	Offset  int    `json:"offset"`
+	Value   string `json:"value"`             // words in hex, e.g. 0x08000235; names as text
+	Note    string `json:"note,omitempty"`    // e.g. "exception 3 (HardFault)"
+	Pointer bool   `json:"pointer,omitempty"` // a code or data address
+	Symbol  string `json:"symbol,omitempty"`  // symbol of the loaded linker map or ELF file the address points into
+}
+
+// CrashDump is a Cortex-M exception stack frame or an RTOS task control
+// block decoded from a memory dump
+type CrashDump struct {
+	Layout    string      `json:"layout"`
+	Fields    []DumpField `json:"fields"`
+	Exception string      `json:"exception,omitempty"` // active exception of a stack frame, e.g. HardFault
+	Task      string      `json:"task,omitempty"`      // task name of a FreeRTOS TCB
+	State     string      `json:"state,omitempty"`     // thread state of a ThreadX TCB
+	Size      int         `json:"size"`                // bytes decoded
+	Remaining int         `json:"remaining,omitempty"` // bytes of the input after the frame or TCB
+	Warnings  []string    `json:"warnings,omitempty"`
+}
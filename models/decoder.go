@@ -0,0 +1,17 @@
+package models
+
+// DecoderInfo describes a registered decoder
+type DecoderInfo struct {
+	Name    string `json:"name"`
+	Builtin bool   `json:"builtin"`
+	Path    string `json:"path,omitempty"` // plugin executable
+}
+
+// DecodeNode is an element of a tree produced by a decoder
+type DecodeNode struct {
+	Name     string       `json:"name"`
+	Value    string       `json:"value,omitempty"`
+	Offset   int64        `json:"offset"`
+	Length   int64        `json:"length"`
+	Children []DecodeNode `json:"children,omitempty"`
+}
@@ -0,0 +1,19 @@
+package models
+
+// CacheStats are the metrics of the conversion result cache
+type CacheStats struct {
+	Entries   int     `json:"entries"`
+	Capacity  int     `json:"capacity"`
+	Hits      uint64  `json:"hits"`
+	Misses    uint64  `json:"misses"`
+	Evictions uint64  `json:"evictions"`
+	HitRate   float64 `json:"hitRate"` // hits / (hits + misses), 0 before the first lookup
+}
+
+// Diagnostics describe the state of the running app for troubleshooting
+type Diagnostics struct {
+	Cache      CacheStats `json:"cache"`
+	Goroutines int        `json:"goroutines"`
+	HeapBytes  uint64     `json:"heapBytes"`  // bytes of allocated heap objects
+	Operations int        `json:"operations"` // running cancellable operations
+}
@@ -0,0 +1,21 @@
+package models
+
+// DiffRange is a run of differing bytes at the same offset in both inputs.
+// Past the end of the shorter input only one side has bytes.
+type DiffRange struct {
+	Offset int64  `json:"offset"`
+	Length int    `json:"length"`
+	A      string `json:"a"` // hex
+	B      string `json:"b"` // hex
+}
+
+// DiffResult holds a byte-wise comparison of two inputs
+type DiffResult struct {
+	LengthA       int         `json:"lengthA"`
+	LengthB       int         `json:"lengthB"`
+	Equal         bool        `json:"equal"`
+	DiffBytes     int         `json:"diffBytes"` // number of differing byte positions
+	FirstDiff     int64       `json:"firstDiff"` // offset of the first difference, -1 if equal
+	Ranges        []DiffRange `json:"ranges"`
+	RangesTrimmed bool        `json:"rangesTrimmed,omitempty"` // more than MaxDiffRanges ranges
+}
@@ -0,0 +1,28 @@
+package models
+
+// DisassembledInstruction represents a single decoded machine instruction
+type DisassembledInstruction struct {
+	Offset  int    `json:"offset"`
+	Address string `json:"address"`
+	Hex     string `json:"hex"`
+	Text    string `json:"text"`
+	Valid   bool   `json:"valid"`
+}
+
+// DisassemblyResult holds a disassembly preview of a byte range
+type DisassemblyResult struct {
+	Arch         string                    `json:"arch"`
+	Instructions []DisassembledInstruction `json:"instructions"`
+	ByteCount    int                       `json:"byteCount"`
+	Truncated    bool                      `json:"truncated"`
+}
+
+// InstructionEncoding is a single instruction with its encoding, as
+// assembled from text or decoded from bytes
+type InstructionEncoding struct {
+	Arch string `json:"arch"`
+	Text string `json:"text"`
+	Hex  string `json:"hex"`  // bytes in memory order
+	Word string `json:"word"` // instruction word as written in manuals, e.g. 0xf000f800 for Thumb BL
+	Size int    `json:"size"` // bytes
+}
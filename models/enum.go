@@ -0,0 +1,22 @@
+package models
+
+// EnumMap names the values of an integer, e.g. the states of a device
+type EnumMap struct {
+	Name   string      `json:"name"`
+	Types  []string    `json:"types,omitempty"` // integer types it annotates, e.g. uint8; empty for all
+	Flags  bool        `json:"flags,omitempty"` // values are bit masks combined in one unsigned integer
+	Values []EnumValue `json:"values"`
+}
+
+// EnumValue is a named value of an EnumMap
+type EnumValue struct {
+	Value int64  `json:"value"`
+	Name  string `json:"name"`
+}
+
+// EnumMatch annotates an integer interpretation with the name of its value
+type EnumMatch struct {
+	Field string `json:"field"` // result field, e.g. uint8BE
+	Enum  string `json:"enum"`  // name of the EnumMap
+	Name  string `json:"name"`  // e.g. STATE_RUNNING, or FLAG_A | FLAG_C for flags
+}
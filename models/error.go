@@ -0,0 +1,11 @@
+package models
+
+// InputError describes why an input could not be converted. Position and
+// Length locate the offending characters in the input; Position is -1 if
+// the problem concerns the input as a whole.
+type InputError struct {
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	Position int    `json:"position"` // character offset
+	Length   int    `json:"length"`
+}
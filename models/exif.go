@@ -0,0 +1,21 @@
+package models
+
+// ExifTag is a decoded TIFF/EXIF IFD entry
+type ExifTag struct {
+	IFD         string `json:"ifd"`
+	ID          string `json:"id"` // hex, e.g. "0x010f"
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Count       uint32 `json:"count"`
+	Value       string `json:"value"`
+	Offset      int64  `json:"offset"`
+	ValueOffset int64  `json:"valueOffset"`
+}
+
+// ExifResult holds the tags of a TIFF/EXIF structure
+type ExifResult struct {
+	ByteOrder    string    `json:"byteOrder"`
+	HeaderOffset int64     `json:"headerOffset"`
+	Tags         []ExifTag `json:"tags"`
+	Warnings     []string  `json:"warnings,omitempty"`
+}
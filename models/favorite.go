@@ -0,0 +1,20 @@
+package models
+
+// Favorite is a pinned conversion that can be run again by ID
+type Favorite struct {
+	ID        int64  `json:"id"`
+	Label     string `json:"label"`
+	Mode      string `json:"mode"` // hex, int, intAuto, binary, float or modbus
+	Input     string `json:"input"`
+	Type      string `json:"type,omitempty"` // integer or float type of int and float conversions
+	CreatedAt string `json:"createdAt"`      // RFC3339
+}
+
+// FavoriteResult is the outcome of running a favorite. Depending on the mode
+// either Conversion or Modbus is set, or Error if the conversion failed.
+type FavoriteResult struct {
+	Favorite   Favorite          `json:"favorite"`
+	Conversion *ConversionResult `json:"conversion,omitempty"`
+	Modbus     *ModbusResult     `json:"modbus,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
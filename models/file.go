@@ -0,0 +1,88 @@
+package models
+
+// FileInfo describes a binary file opened in the file subsystem
+type FileInfo struct {
+	ID      string `json:"id"`
+	Path    string `json:"path"`
+	Name    string `json:"name"`
+	Size    int64  `json:"size"`
+	ModTime string `json:"modTime"`
+
+	// Edit state
+	Modified  bool   `json:"modified"`
+	CanUndo   bool   `json:"canUndo"`
+	CanRedo   bool   `json:"canRedo"`
+	UndoLabel string `json:"undoLabel,omitempty"`
+	RedoLabel string `json:"redoLabel,omitempty"`
+
+	// Cursor is the offset of the selected byte, the base of address calculations
+	Cursor int64 `json:"cursor"`
+}
+
+// ReplaceResult holds the outcome of a search-and-replace edit
+type ReplaceResult struct {
+	File  FileInfo `json:"file"`
+	Count int      `json:"count"`
+}
+
+// FileRange holds a slice of an opened file's or capture's content
+type FileRange struct {
+	Offset int64  `json:"offset"`
+	Length int    `json:"length"`
+	Hex    string `json:"hex"`
+	ASCII  string `json:"ascii"`
+}
+
+// ExecutableSection describes a section of an executable with its file offset
+type ExecutableSection struct {
+	Name    string `json:"name"`
+	Offset  int64  `json:"offset"`
+	Size    int64  `json:"size"`
+	Address string `json:"address"`
+	Flags   string `json:"flags,omitempty"`
+}
+
+// ExecutableSegment describes a loadable segment of an executable
+type ExecutableSegment struct {
+	Name     string `json:"name,omitempty"`
+	Type     string `json:"type"`
+	Offset   int64  `json:"offset"`
+	FileSize int64  `json:"fileSize"`
+	Address  string `json:"address"`
+	MemSize  int64  `json:"memSize"`
+	Flags    string `json:"flags,omitempty"`
+}
+
+// ExecutableInfo holds an ELF/PE/Mach-O header summary
+type ExecutableInfo struct {
+	Format    string              `json:"format"`
+	Type      string              `json:"type"`
+	Arch      string              `json:"arch"`
+	Bits      int                 `json:"bits"`
+	Endian    string              `json:"endian"`
+	Entry     string              `json:"entry"`
+	Sections  []ExecutableSection `json:"sections"`
+	Segments  []ExecutableSegment `json:"segments"`
+	FatArches []string            `json:"fatArches,omitempty"`
+}
+
+// FileChangeEvent notifies the frontend that an opened file changed on disk.
+// Conflict is set when the open buffer has unsaved edits, so a reload would discard them.
+type FileChangeEvent struct {
+	FileID   string `json:"fileId"`
+	Path     string `json:"path"`
+	Change   string `json:"change"` // "modified" or "deleted"
+	Conflict bool   `json:"conflict"`
+}
+
+// DroppedFile is the outcome of a file dropped onto the window. Depending on
+// Kind either Conversion or File is set, or Error if the file could not be read.
+type DroppedFile struct {
+	Path       string            `json:"path"`
+	Name       string            `json:"name"`
+	Size       int64             `json:"size"`
+	Kind       string            `json:"kind"` // "bytes" if converted, "file" if opened
+	Conversion *ConversionResult `json:"conversion,omitempty"`
+	File       *FileInfo         `json:"file,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
@@ -0,0 +1,22 @@
+package models
+
+// FixedPointValue is one Qm.n fixed-point number
+type FixedPointValue struct {
+	Hex   string  `json:"hex"`   // bytes in the order of the result
+	Value string  `json:"value"` // exact decimal value
+	Float float64 `json:"float"` // value rounded to a float64, e.g. for plotting
+}
+
+// FixedPointResult holds hex input decoded as consecutive Qm.n fixed-point
+// numbers, e.g. Q15 samples of a DSP, or the encoding of one value
+type FixedPointResult struct {
+	Format     string            `json:"format"` // Q15, Q1.14 or UQ8.8
+	Order      string            `json:"order"`  // BE, LE, BADC or CDAB
+	Size       int               `json:"size"`   // bytes per value
+	Min        string            `json:"min"`
+	Max        string            `json:"max"`
+	Resolution string            `json:"resolution"` // 2^-n, the value of the lowest bit
+	Values     []FixedPointValue `json:"values"`
+	Remainder  int               `json:"remainder,omitempty"` // trailing bytes too short for a value
+	Truncated  bool              `json:"truncated,omitempty"` // only the first values are listed
+}
@@ -0,0 +1,16 @@
+package models
+
+// FormatOptions controls how bytes are rendered for copying
+type FormatOptions struct {
+	Style        string `json:"style"` // e.g. "c-array", "hex-0x", "decimal"
+	Uppercase    bool   `json:"uppercase"`
+	BytesPerLine int    `json:"bytesPerLine"` // 0 keeps the output on one line
+	Name         string `json:"name"`         // variable name for C arrays
+}
+
+// FormatStyle describes an available copy format
+type FormatStyle struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Example string `json:"example"`
+}
@@ -0,0 +1,11 @@
+package models
+
+// HashResult holds the digests of a byte sequence in hex
+type HashResult struct {
+	Length int    `json:"length"`
+	MD5    string `json:"md5"`
+	SHA1   string `json:"sha1"`
+	SHA256 string `json:"sha256"`
+	SHA512 string `json:"sha512"`
+	CRC32  string `json:"crc32"` // CRC-32/ISO-HDLC as used by zip and Ethernet
+}
@@ -0,0 +1,66 @@
+package models
+
+// HexDumpOptions selects how input is rendered as a hex dump and which rows
+// are returned
+type HexDumpOptions struct {
+	Format      string `json:"format"`      // input format: hex (default), binary or dump (a text dump ParseDump reads)
+	Width       int    `json:"width"`       // bytes per row, 16 if zero
+	BaseAddress int64  `json:"baseAddress"` // added to the offsets in Address
+	StartRow    int    `json:"startRow"`    // first row to return, for scrolling
+	MaxRows     int    `json:"maxRows"`     // rows to return, all up to a limit if zero
+}
+
+// HexDump is a classic hex editor view of a byte blob: an offset column,
+// the bytes of each row as hex cells and an ASCII gutter
+type HexDump struct {
+	Length    int64        `json:"length"`         // bytes in the input, including gaps
+	Width     int          `json:"width"`          // bytes per row
+	TotalRows int          `json:"totalRows"`      // rows of the whole input
+	StartRow  int          `json:"startRow"`       // index of Rows[0]
+	Gaps      int          `json:"gaps,omitempty"` // gap rows of a sparse input
+	Rows      []HexDumpRow `json:"rows"`
+}
+
+// HexDumpRow is one row of a hex dump. The last row may hold fewer bytes
+// than the width. In the dump of a sparse input, e.g. a memory dump with
+// unreadable pages, the rows between two segments are collapsed into one
+// gap row without cells
+type HexDumpRow struct {
+	Offset  int64    `json:"offset"`        // of the first byte in the input
+	Address string   `json:"address"`       // offset plus base address, e.g. 08000010
+	Cells   []string `json:"cells"`         // one two-digit hex cell per byte, ?? for bytes in a gap
+	ASCII   string   `json:"ascii"`         // printable characters, '.' for others, ' ' for bytes in a gap
+	Gap     int64    `json:"gap,omitempty"` // bytes of the rows a gap row stands for
+}
+
+// ParsedDump holds the bytes read back from the text hex dump of a tool:
+// xxd, hexdump -C, od, Wireshark or a Windows debugger (db), or from C
+// arrays or escaped strings, e.g. exported by Wireshark
+type ParsedDump struct {
+	Dialect  string       `json:"dialect"` // xxd, hexdump, od, wireshark, windbg, c-array or escaped
+	Base     string       `json:"base"`    // offset or address of the first byte, 0x prefixed
+	Hex      string       `json:"hex"`     // bytes from Base, with gaps filled with zeros
+	Length   int          `json:"length"`  // bytes, including gaps
+	Lines    int          `json:"lines"`   // dump lines read
+	Gaps     []DumpRange  `json:"gaps,omitempty"`
+	Segments []DumpRange  `json:"segments"`          // ranges holding bytes, between the gaps
+	Packets  []DumpPacket `json:"packets,omitempty"` // arrays of C array input, concatenated in Hex
+}
+
+// DumpRange is an address range of a parsed dump: a segment with bytes or
+// a gap without, i.e. lines the dump skips or memory the debugger could not
+// read
+type DumpRange struct {
+	Offset  int    `json:"offset"`  // from Base
+	Address string `json:"address"` // Base plus offset, 0x prefixed
+	Length  int    `json:"length"`
+}
+
+// DumpPacket is one array of C array input, e.g. a frame of a Wireshark
+// export
+type DumpPacket struct {
+	Name   string `json:"name,omitempty"`  // variable name, e.g. pkt1
+	Title  string `json:"title,omitempty"` // comment above the array, e.g. "Frame (74 bytes)"
+	Offset int    `json:"offset"`          // from Base
+	Length int    `json:"length"`
+}
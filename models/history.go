@@ -0,0 +1,12 @@
+package models
+
+// HistoryEntry is a recorded conversion
+type HistoryEntry struct {
+	ID        int64    `json:"id"`
+	Mode      string   `json:"mode"` // hex, int, intAuto, binary, float or modbus
+	Input     string   `json:"input"`
+	Type      string   `json:"type,omitempty"` // integer or float type of int and float conversions
+	Summary   string   `json:"summary"`
+	Values    []string `json:"values,omitempty"` // decimal values of the result, used for search
+	Timestamp string   `json:"timestamp"`        // RFC3339
+}
@@ -0,0 +1,23 @@
+package models
+
+// LinkerMapInfo summarizes a loaded GNU ld or armlink map file or ELF
+// symbol table
+type LinkerMapInfo struct {
+	Path    string         `json:"path,omitempty"`
+	Format  string         `json:"format"`  // gnu, armlink or elf
+	Symbols int            `json:"symbols"` // number of symbols and sections addresses resolve to
+	Regions []MemoryRegion `json:"regions"` // memory regions, e.g. to add to the memory map of a profile
+}
+
+// SymbolMatch annotates an address-like value with the symbol of the
+// linker map it points into
+type SymbolMatch struct {
+	Field   string `json:"field"`             // result field, e.g. uint32LE; empty for LookupSymbol
+	Symbol  string `json:"symbol"`            // symbol name, or object(section) without one
+	Address uint64 `json:"address"`           // start of the symbol
+	Size    uint64 `json:"size"`              // bytes of the symbol, 0 if unknown
+	Offset  uint64 `json:"offset"`            // value - symbol address
+	Section string `json:"section,omitempty"` // input section, e.g. .text.main
+	Object  string `json:"object,omitempty"`  // object file, e.g. main.o
+	Text    string `json:"text"`              // e.g. "g_buffer + 0x10"
+}
@@ -0,0 +1,18 @@
+package models
+
+// MemoryRegion is a named address range of a memory map, e.g. SRAM1 of a
+// microcontroller
+type MemoryRegion struct {
+	Name  string `json:"name"`
+	Start uint64 `json:"start"`
+	End   uint64 `json:"end"` // last address of the region, inclusive
+}
+
+// RegionMatch annotates an address-like value with the memory region it
+// falls in
+type RegionMatch struct {
+	Field  string `json:"field"`  // result field, e.g. uint32LE
+	Region string `json:"region"` // name of the MemoryRegion
+	Offset uint64 `json:"offset"` // value - region start
+	Text   string `json:"text"`   // e.g. "SRAM1 + 0x1f00"
+}
@@ -0,0 +1,11 @@
+package models
+
+// NaNPayload describes a NaN float interpretation, whose payload bits often
+// mark where in a numerical code the value came from
+type NaNPayload struct {
+	Field     string `json:"field"`              // result field, e.g. float32LE
+	Signaling bool   `json:"signaling"`          // quiet bit clear
+	Negative  bool   `json:"negative,omitempty"` // sign bit set
+	Payload   string `json:"payload"`            // mantissa bits below the quiet bit, e.g. 0x000001
+	Text      string `json:"text"`               // e.g. sNaN(0x000001) or -qNaN(0x000000)
+}
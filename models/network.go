@@ -0,0 +1,10 @@
+package models
+
+// MACAddress is a 6-byte buffer read as an Ethernet MAC address
+type MACAddress struct {
+	Address   string `json:"address"`          // e.g. "00:1a:2b:3c:4d:5e"
+	Vendor    string `json:"vendor,omitempty"` // owner of the OUI, if it is in the embedded table
+	Multicast bool   `json:"multicast"`        // group bit (I/G) of the first byte
+	Local     bool   `json:"local"`            // locally administered (U/L bit), so the OUI is not a vendor's
+	Note      string `json:"note,omitempty"`   // e.g. "broadcast" or "IPv6 multicast"
+}
@@ -0,0 +1,46 @@
+package models
+
+// OffsetRequest is an address calculation. Numbers are decimal or 0x hex.
+type OffsetRequest struct {
+	Op     string `json:"op"`               // index, alignUp, alignDown or diff
+	Base   string `json:"base"`             // start address
+	Index  string `json:"index,omitempty"`  // element number for index, may be negative
+	Stride string `json:"stride,omitempty"` // element size in bytes for index
+	Align  string `json:"align,omitempty"`  // alignment in bytes for alignUp and alignDown
+	Target string `json:"target,omitempty"` // second address for diff
+}
+
+// AddressValue is an address or byte count in hex and decimal
+type AddressValue struct {
+	Hex     string `json:"hex"` // 0x prefixed, with a sign if negative
+	Decimal string `json:"decimal"`
+}
+
+// OffsetResult holds the outcome of an address calculation
+type OffsetResult struct {
+	Op      string       `json:"op"`
+	Base    AddressValue `json:"base"`
+	Result  AddressValue `json:"result"`            // computed address, the target for diff
+	Delta   AddressValue `json:"delta"`             // result - base: the difference, index × stride or the padding of alignUp
+	Aligned *bool        `json:"aligned,omitempty"` // base already aligned, for alignUp and alignDown
+}
+
+// AlignmentBoundary is the block of a power-of-two size an address falls in,
+// e.g. its 4 KiB page
+type AlignmentBoundary struct {
+	Name    string       `json:"name"` // e.g. "page (4 KiB)"
+	Size    uint64       `json:"size"`
+	Start   AddressValue `json:"start"`  // address aligned down to size
+	Offset  AddressValue `json:"offset"` // address - start
+	Aligned bool         `json:"aligned"`
+}
+
+// AddressAlignment describes the alignment of an address, e.g. for MMU and
+// DMA debugging
+type AddressAlignment struct {
+	Address    AddressValue        `json:"address"`
+	Alignment  uint64              `json:"alignment"` // largest power of two the address is a multiple of, up to 2^63
+	Boundaries []AlignmentBoundary `json:"boundaries"`
+	Base       *AddressValue       `json:"base,omitempty"`
+	BaseOffset *AddressValue       `json:"baseOffset,omitempty"` // address - base, e.g. the offset in a section
+}
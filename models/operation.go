@@ -0,0 +1,10 @@
+package models
+
+// Operation describes a cancellable call running in the backend
+type Operation struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"` // modbus, transmit, replay or favorites
+	Started   string `json:"started"`
+	Done      bool   `json:"done,omitempty"`
+	Cancelled bool   `json:"cancelled,omitempty"`
+}
@@ -0,0 +1,32 @@
+package models
+
+// PCMResult holds a buffer decoded as interleaved PCM audio samples, e.g.
+// the DMA buffer of an I2S peripheral
+type PCMResult struct {
+	Format    string     `json:"format"`              // e.g. s16le
+	Bits      int        `json:"bits"`                // 8, 16 or 24
+	Signed    bool       `json:"signed"`              // unsigned samples are centered on 2^(bits-1)
+	Order     string     `json:"order"`               // BE or LE
+	Channels  int        `json:"channels"`            // interleaved
+	Frames    int        `json:"frames"`              // samples per channel
+	Remainder int        `json:"remainder"`           // trailing bytes too short for a frame
+	Samples   []int32    `json:"samples"`             // raw sample values in buffer order
+	Truncated bool       `json:"truncated,omitempty"` // only the first samples are listed
+	Stats     []PCMStats `json:"stats"`               // per channel, of all frames
+}
+
+// PCMStats summarizes the samples of one channel. Levels are fractions of
+// full scale (2^(bits-1)) measured from the midpoint
+type PCMStats struct {
+	Channel      int      `json:"channel"`
+	Min          int32    `json:"min"` // raw sample values
+	Max          int32    `json:"max"`
+	Peak         float64  `json:"peak"`
+	PeakDBFS     *float64 `json:"peakDBFS,omitempty"` // nil for silence
+	RMS          float64  `json:"rms"`
+	RMSDBFS      *float64 `json:"rmsDBFS,omitempty"` // nil for silence
+	DCOffset     float64  `json:"dcOffset"`          // mean level
+	Clipped      int      `json:"clipped"`           // samples at the lowest or highest value
+	MaxJump      int64    `json:"maxJump"`           // largest difference between consecutive samples
+	MaxJumpFrame int      `json:"maxJumpFrame"`      // frame of the second sample of MaxJump
+}
@@ -0,0 +1,24 @@
+package models
+
+// PixelOptions selects how a buffer is decoded as pixel data
+type PixelOptions struct {
+	Format  string `json:"format"`  // pixel format, e.g. rgb565, rgb888, gray8, yuyv, nv12 or bayer_rggb
+	Width   int    `json:"width"`   // pixels per row
+	Stride  int    `json:"stride"`  // bytes from one row to the next, the row size if zero
+	Offset  int    `json:"offset"`  // bytes to skip before the first row, e.g. a header
+	MaxSize int    `json:"maxSize"` // largest side of the preview in pixels, 256 if zero
+}
+
+// PixelPreview is a buffer decoded as an image, downscaled to a small
+// preview bitmap
+type PixelPreview struct {
+	Format        string `json:"format"`
+	Width         int    `json:"width"`  // of the decoded image
+	Height        int    `json:"height"` // complete rows in the buffer
+	Stride        int    `json:"stride"`
+	Remainder     int    `json:"remainder"` // trailing bytes after the last complete row
+	PreviewWidth  int    `json:"previewWidth"`
+	PreviewHeight int    `json:"previewHeight"`
+	Step          int    `json:"step"` // every step-th pixel of every step-th row is shown
+	PNG           string `json:"png"`  // preview as a data:image/png;base64 URL
+}
@@ -0,0 +1,31 @@
+package models
+
+// PMBus and SMBus data formats
+const (
+	PMBusLinear11 = "linear11" // 5-bit exponent and 11-bit mantissa, both signed
+	PMBusLinear16 = "linear16" // unsigned mantissa, exponent from VOUT_MODE
+	PMBusBlock    = "block"    // SMBus block read: byte count followed by the data
+)
+
+// PMBusValue is one 16-bit word decoded from a PMBus linear format
+type PMBusValue struct {
+	Hex      string `json:"hex"` // word, most significant byte first
+	Mantissa int    `json:"mantissa"`
+	Exponent int    `json:"exponent"`
+	Value    string `json:"value"` // mantissa * 2^exponent
+}
+
+// PMBusResult holds PMBus telemetry words or an SMBus block decoded from hex
+// input
+type PMBusResult struct {
+	Format string       `json:"format"`
+	Order  string       `json:"order,omitempty"` // byte order of the words, LE as sent on the bus
+	Values []PMBusValue `json:"values,omitempty"`
+
+	// SMBus block read
+	Count   int    `json:"count,omitempty"` // byte count sent by the device
+	Data    string `json:"data,omitempty"`  // block data in hex
+	ASCII   string `json:"ascii,omitempty"` // block data as ASCII, e.g. MFR_ID
+	PEC     string `json:"pec,omitempty"`   // byte following the block, the packet error code if enabled
+	Warning string `json:"warning,omitempty"`
+}
@@ -0,0 +1,53 @@
+package models
+
+// Modbus framings of a poll or decoded frame
+const (
+	FramingRTU = "rtu" // unit ID, PDU and CRC-16, e.g. over a serial port
+	FramingTCP = "tcp" // MBAP header and PDU
+	FramingPDU = "pdu" // function code and data only; decoding only
+)
+
+// PollConfig describes a Modbus read repeated over a capture session
+type PollConfig struct {
+	CaptureID  string            `json:"captureId"`
+	Framing    string            `json:"framing"` // FramingRTU or FramingTCP
+	UnitID     int               `json:"unitId"`
+	Function   int               `json:"function"`         // 3 (holding registers) or 4 (input registers), 3 if zero
+	Address    int               `json:"address"`          // protocol address of the first register
+	Count      int               `json:"count"`            // registers per read, 1 to 125
+	IntervalMs int               `json:"intervalMs"`       // time between reads, 1000 if zero
+	TimeoutMs  int               `json:"timeoutMs"`        // wait for a response, 1000 if zero
+	ByteOrder  string            `json:"byteOrder"`        // of multi-register values: BE, LE, BADC or CDAB, BE if empty
+	Points     []RegisterMapping `json:"points,omitempty"` // values to record, Register is the protocol address; every register as uint16 if empty
+}
+
+// PollInfo describes a running or finished Modbus poll
+type PollInfo struct {
+	ID        string     `json:"id"`
+	Config    PollConfig `json:"config"`
+	Running   bool       `json:"running"`
+	StartedAt string     `json:"startedAt"`
+	Polls     int        `json:"polls"`    // reads answered
+	Failures  int        `json:"failures"` // reads without a valid response
+	LastError string     `json:"lastError,omitempty"`
+	Samples   int        `json:"samples"` // retained samples
+}
+
+// PollSample is a value recorded by a Modbus poll
+type PollSample struct {
+	PollID    string `json:"pollId"`
+	Timestamp string `json:"timestamp"` // RFC 3339 with nanoseconds
+	Name      string `json:"name"`
+	Value     string `json:"value,omitempty"`
+	Raw       string `json:"raw,omitempty"` // value before scaling
+	Unit      string `json:"unit,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// SampleQuery selects recorded samples of a poll
+type SampleQuery struct {
+	Names []string `json:"names,omitempty"` // empty for all values
+	From  string   `json:"from,omitempty"`  // RFC 3339, empty for the oldest sample
+	To    string   `json:"to,omitempty"`    // RFC 3339, empty for the newest sample
+	Limit int      `json:"limit,omitempty"` // most recent matching samples, 0 for all
+}
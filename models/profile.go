@@ -0,0 +1,43 @@
+package models
+
+// Profile bundles the settings, visible result sections, Modbus register
+// map, enum maps and memory map used for one device or project
+type Profile struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Settings    Settings          `json:"settings"`
+	Sections    []string          `json:"sections,omitempty"` // visible result sections, empty for all
+	Registers   []RegisterMapping `json:"registers,omitempty"`
+	Enums       []EnumMap         `json:"enums,omitempty"`     // names of integer values
+	MemoryMap   []MemoryRegion    `json:"memoryMap,omitempty"` // regions address-like values are annotated with
+}
+
+// RegisterMapping names a value stored in one or more Modbus registers
+type RegisterMapping struct {
+	Register int     `json:"register"` // first register, counted from the profile's address base
+	Name     string  `json:"name"`
+	Type     string  `json:"type"`             // int16, uint16, int32, uint32, float32, int64, uint64 or float64
+	Scale    float64 `json:"scale,omitempty"`  // multiplier applied to the raw value, 0 means 1
+	Offset   float64 `json:"offset,omitempty"` // added after scaling
+	Unit     string  `json:"unit,omitempty"`
+	Enum     string  `json:"enum,omitempty"` // name of an EnumMap of the profile naming the raw value
+}
+
+// MappedRegister is a register map entry decoded from Modbus registers
+type MappedRegister struct {
+	Register int    `json:"register"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Hex      string `json:"hex,omitempty"`
+	Raw      string `json:"raw,omitempty"`
+	Value    string `json:"value,omitempty"` // scaled value
+	Unit     string `json:"unit,omitempty"`
+	EnumName string `json:"enumName,omitempty"` // name of the raw value in the register's enum
+	Error    string `json:"error,omitempty"`
+}
+
+// ProfileList holds all profiles and the name of the active one
+type ProfileList struct {
+	Active   string    `json:"active,omitempty"`
+	Profiles []Profile `json:"profiles"`
+}
@@ -0,0 +1,40 @@
+package models
+
+// Project bundles the opened files, register and memory maps, struct
+// layouts, pinned conversions and profiles of a device in one file, so the
+// context of a commissioning job can be handed to a colleague
+type Project struct {
+	Version       int             `json:"version"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description,omitempty"`
+	Files         []ProjectFile   `json:"files,omitempty"`
+	SVD           string          `json:"svd,omitempty"`        // path of the CMSIS-SVD register map
+	LinkerMap     string          `json:"linkerMap,omitempty"`  // path of the GNU ld or armlink map file
+	ELFSymbols    string          `json:"elfSymbols,omitempty"` // path of the ELF file symbols are loaded from instead
+	Schemas       []ProjectSchema `json:"schemas,omitempty"`
+	Bookmarks     []Favorite      `json:"bookmarks,omitempty"` // pinned conversions
+	Profiles      []Profile       `json:"profiles,omitempty"`  // with their register maps, enums and memory maps
+	ActiveProfile string          `json:"activeProfile,omitempty"`
+}
+
+// ProjectFile is a file of a project, opened in its own tab
+type ProjectFile struct {
+	Path string `json:"path"`           // relative to the project file if it is below its directory
+	Name string `json:"name,omitempty"` // tab name
+}
+
+// ProjectSchema is a named struct layout, written in JSON or the
+// structdecode DSL
+type ProjectSchema struct {
+	Name   string `json:"name"`
+	Layout string `json:"layout"`
+}
+
+// ProjectOpenResult is the outcome of opening a project. Artifacts that
+// cannot be restored, e.g. files missing on this machine, are skipped and
+// listed in Warnings.
+type ProjectOpenResult struct {
+	Project  Project   `json:"project"`
+	Sessions []Session `json:"sessions"` // tabs opened for the files of the project
+	Warnings []string  `json:"warnings,omitempty"`
+}
@@ -4,96 +4,210 @@ package models
 
 // ConversionResult holds all conversion outputs for hex, integer, binary, and float inputs.
 // It provides multiple representations across different data types and endianness formats.
+// Every value has its bytes in that order as hex (e.g. Int32LEHex) and binary (Int32LEBin).
 type ConversionResult struct {
 	// Signed Integers - Big Endian
 	Int8BE     *int8  `json:"int8BE,omitempty"`
 	Int8BEHex  string `json:"int8BEHex,omitempty"`
+	Int8BEBin  string `json:"int8BEBin,omitempty"`
 	Int16BE    *int16 `json:"int16BE,omitempty"`
 	Int16BEHex string `json:"int16BEHex,omitempty"`
+	Int16BEBin string `json:"int16BEBin,omitempty"`
 	Int32BE    *int32 `json:"int32BE,omitempty"`
 	Int32BEHex string `json:"int32BEHex,omitempty"`
+	Int32BEBin string `json:"int32BEBin,omitempty"`
 	Int64BE    *int64 `json:"int64BE,omitempty"`
 	Int64BEHex string `json:"int64BEHex,omitempty"`
+	Int64BEBin string `json:"int64BEBin,omitempty"`
 
 	// Signed Integers - Little Endian
+	Int8LE     *int8  `json:"int8LE,omitempty"`
+	Int8LEHex  string `json:"int8LEHex,omitempty"`
+	Int8LEBin  string `json:"int8LEBin,omitempty"`
 	Int16LE    *int16 `json:"int16LE,omitempty"`
 	Int16LEHex string `json:"int16LEHex,omitempty"`
+	Int16LEBin string `json:"int16LEBin,omitempty"`
 	Int32LE    *int32 `json:"int32LE,omitempty"`
 	Int32LEHex string `json:"int32LEHex,omitempty"`
+	Int32LEBin string `json:"int32LEBin,omitempty"`
 	Int64LE    *int64 `json:"int64LE,omitempty"`
 	Int64LEHex string `json:"int64LEHex,omitempty"`
+	Int64LEBin string `json:"int64LEBin,omitempty"`
 
 	// Signed Integers - Mid-Big Endian (BADC)
 	Int16BADC    *int16 `json:"int16BADC,omitempty"`
 	Int16BADCHex string `json:"int16BADCHex,omitempty"`
+	Int16BADCBin string `json:"int16BADCBin,omitempty"`
 	Int32BADC    *int32 `json:"int32BADC,omitempty"`
 	Int32BADCHex string `json:"int32BADCHex,omitempty"`
+	Int32BADCBin string `json:"int32BADCBin,omitempty"`
 	Int64BADC    *int64 `json:"int64BADC,omitempty"`
 	Int64BADCHex string `json:"int64BADCHex,omitempty"`
+	Int64BADCBin string `json:"int64BADCBin,omitempty"`
 
 	// Signed Integers - Mid-Little Endian (CDAB)
 	Int16CDAB    *int16 `json:"int16CDAB,omitempty"`
 	Int16CDABHex string `json:"int16CDABHex,omitempty"`
+	Int16CDABBin string `json:"int16CDABBin,omitempty"`
 	Int32CDAB    *int32 `json:"int32CDAB,omitempty"`
 	Int32CDABHex string `json:"int32CDABHex,omitempty"`
+	Int32CDABBin string `json:"int32CDABBin,omitempty"`
 	Int64CDAB    *int64 `json:"int64CDAB,omitempty"`
 	Int64CDABHex string `json:"int64CDABHex,omitempty"`
+	Int64CDABBin string `json:"int64CDABBin,omitempty"`
 
 	// Unsigned Integers - Big Endian
 	Uint8BE     *uint8  `json:"uint8BE,omitempty"`
 	Uint8BEHex  string  `json:"uint8BEHex,omitempty"`
+	Uint8BEBin  string  `json:"uint8BEBin,omitempty"`
 	Uint16BE    *uint16 `json:"uint16BE,omitempty"`
 	Uint16BEHex string  `json:"uint16BEHex,omitempty"`
+	Uint16BEBin string  `json:"uint16BEBin,omitempty"`
 	Uint32BE    *uint32 `json:"uint32BE,omitempty"`
 	Uint32BEHex string  `json:"uint32BEHex,omitempty"`
+	Uint32BEBin string  `json:"uint32BEBin,omitempty"`
 	Uint64BE    *uint64 `json:"uint64BE,omitempty"`
 	Uint64BEHex string  `json:"uint64BEHex,omitempty"`
+	Uint64BEBin string  `json:"uint64BEBin,omitempty"`
 
 	// Unsigned Integers - Little Endian
+	Uint8LE     *uint8  `json:"uint8LE,omitempty"`
+	Uint8LEHex  string  `json:"uint8LEHex,omitempty"`
+	Uint8LEBin  string  `json:"uint8LEBin,omitempty"`
 	Uint16LE    *uint16 `json:"uint16LE,omitempty"`
 	Uint16LEHex string  `json:"uint16LEHex,omitempty"`
+	Uint16LEBin string  `json:"uint16LEBin,omitempty"`
 	Uint32LE    *uint32 `json:"uint32LE,omitempty"`
 	Uint32LEHex string  `json:"uint32LEHex,omitempty"`
+	Uint32LEBin string  `json:"uint32LEBin,omitempty"`
 	Uint64LE    *uint64 `json:"uint64LE,omitempty"`
 	Uint64LEHex string  `json:"uint64LEHex,omitempty"`
+	Uint64LEBin string  `json:"uint64LEBin,omitempty"`
 
 	// Unsigned Integers - Mid-Big Endian (BADC)
 	Uint16BADC    *uint16 `json:"uint16BADC,omitempty"`
 	Uint16BADCHex string  `json:"uint16BADCHex,omitempty"`
+	Uint16BADCBin string  `json:"uint16BADCBin,omitempty"`
 	Uint32BADC    *uint32 `json:"uint32BADC,omitempty"`
 	Uint32BADCHex string  `json:"uint32BADCHex,omitempty"`
+	Uint32BADCBin string  `json:"uint32BADCBin,omitempty"`
 	Uint64BADC    *uint64 `json:"uint64BADC,omitempty"`
 	Uint64BADCHex string  `json:"uint64BADCHex,omitempty"`
+	Uint64BADCBin string  `json:"uint64BADCBin,omitempty"`
 
 	// Unsigned Integers - Mid-Little Endian (CDAB)
 	Uint16CDAB    *uint16 `json:"uint16CDAB,omitempty"`
 	Uint16CDABHex string  `json:"uint16CDABHex,omitempty"`
+	Uint16CDABBin string  `json:"uint16CDABBin,omitempty"`
 	Uint32CDAB    *uint32 `json:"uint32CDAB,omitempty"`
 	Uint32CDABHex string  `json:"uint32CDABHex,omitempty"`
+	Uint32CDABBin string  `json:"uint32CDABBin,omitempty"`
 	Uint64CDAB    *uint64 `json:"uint64CDAB,omitempty"`
 	Uint64CDABHex string  `json:"uint64CDABHex,omitempty"`
+	Uint64CDABBin string  `json:"uint64CDABBin,omitempty"`
+
+	// One's Complement Integers (legacySigned section) - Big Endian
+	OnesComplement8BE     *int8  `json:"onesComplement8BE,omitempty"`
+	OnesComplement8BEHex  string `json:"onesComplement8BEHex,omitempty"`
+	OnesComplement8BEBin  string `json:"onesComplement8BEBin,omitempty"`
+	OnesComplement16BE    *int16 `json:"onesComplement16BE,omitempty"`
+	OnesComplement16BEHex string `json:"onesComplement16BEHex,omitempty"`
+	OnesComplement16BEBin string `json:"onesComplement16BEBin,omitempty"`
+	OnesComplement32BE    *int32 `json:"onesComplement32BE,omitempty"`
+	OnesComplement32BEHex string `json:"onesComplement32BEHex,omitempty"`
+	OnesComplement32BEBin string `json:"onesComplement32BEBin,omitempty"`
+	OnesComplement64BE    *int64 `json:"onesComplement64BE,omitempty"`
+	OnesComplement64BEHex string `json:"onesComplement64BEHex,omitempty"`
+	OnesComplement64BEBin string `json:"onesComplement64BEBin,omitempty"`
+
+	// One's Complement Integers (legacySigned section) - Little Endian
+	OnesComplement8LE     *int8  `json:"onesComplement8LE,omitempty"`
+	OnesComplement8LEHex  string `json:"onesComplement8LEHex,omitempty"`
+	OnesComplement8LEBin  string `json:"onesComplement8LEBin,omitempty"`
+	OnesComplement16LE    *int16 `json:"onesComplement16LE,omitempty"`
+	OnesComplement16LEHex string `json:"onesComplement16LEHex,omitempty"`
+	OnesComplement16LEBin string `json:"onesComplement16LEBin,omitempty"`
+	OnesComplement32LE    *int32 `json:"onesComplement32LE,omitempty"`
+	OnesComplement32LEHex string `json:"onesComplement32LEHex,omitempty"`
+	OnesComplement32LEBin string `json:"onesComplement32LEBin,omitempty"`
+	OnesComplement64LE    *int64 `json:"onesComplement64LE,omitempty"`
+	OnesComplement64LEHex string `json:"onesComplement64LEHex,omitempty"`
+	OnesComplement64LEBin string `json:"onesComplement64LEBin,omitempty"`
+
+	// Sign-Magnitude Integers (legacySigned section) - Big Endian
+	SignMagnitude8BE     *int8  `json:"signMagnitude8BE,omitempty"`
+	SignMagnitude8BEHex  string `json:"signMagnitude8BEHex,omitempty"`
+	SignMagnitude8BEBin  string `json:"signMagnitude8BEBin,omitempty"`
+	SignMagnitude16BE    *int16 `json:"signMagnitude16BE,omitempty"`
+	SignMagnitude16BEHex string `json:"signMagnitude16BEHex,omitempty"`
+	SignMagnitude16BEBin string `json:"signMagnitude16BEBin,omitempty"`
+	SignMagnitude32BE    *int32 `json:"signMagnitude32BE,omitempty"`
+	SignMagnitude32BEHex string `json:"signMagnitude32BEHex,omitempty"`
+	SignMagnitude32BEBin string `json:"signMagnitude32BEBin,omitempty"`
+	SignMagnitude64BE    *int64 `json:"signMagnitude64BE,omitempty"`
+	SignMagnitude64BEHex string `json:"signMagnitude64BEHex,omitempty"`
+	SignMagnitude64BEBin string `json:"signMagnitude64BEBin,omitempty"`
+
+	// Sign-Magnitude Integers (legacySigned section) - Little Endian
+	SignMagnitude8LE     *int8  `json:"signMagnitude8LE,omitempty"`
+	SignMagnitude8LEHex  string `json:"signMagnitude8LEHex,omitempty"`
+	SignMagnitude8LEBin  string `json:"signMagnitude8LEBin,omitempty"`
+	SignMagnitude16LE    *int16 `json:"signMagnitude16LE,omitempty"`
+	SignMagnitude16LEHex string `json:"signMagnitude16LEHex,omitempty"`
+	SignMagnitude16LEBin string `json:"signMagnitude16LEBin,omitempty"`
+	SignMagnitude32LE    *int32 `json:"signMagnitude32LE,omitempty"`
+	SignMagnitude32LEHex string `json:"signMagnitude32LEHex,omitempty"`
+	SignMagnitude32LEBin string `json:"signMagnitude32LEBin,omitempty"`
+	SignMagnitude64LE    *int64 `json:"signMagnitude64LE,omitempty"`
+	SignMagnitude64LEHex string `json:"signMagnitude64LEHex,omitempty"`
+	SignMagnitude64LEBin string `json:"signMagnitude64LEBin,omitempty"`
+
+	// The bits of the input read as a big- and little-endian unsigned
+	// integer (bitFlags section), for inputs up to 8 bytes
+	BitFlagsBE *BitFlags `json:"bitFlagsBE,omitempty"`
+	BitFlagsLE *BitFlags `json:"bitFlagsLE,omitempty"`
 
 	// Floating Point (stored as strings to support NaN/Inf)
+	Float16BE    *string `json:"float16BE,omitempty"`
+	Float16BEHex string  `json:"float16BEHex,omitempty"`
+	Float16BEBin string  `json:"float16BEBin,omitempty"`
+	Float16LE    *string `json:"float16LE,omitempty"`
+	Float16LEHex string  `json:"float16LEHex,omitempty"`
+	Float16LEBin string  `json:"float16LEBin,omitempty"`
 	Float32BE    *string `json:"float32BE,omitempty"`
 	Float32BEHex string  `json:"float32BEHex,omitempty"`
+	Float32BEBin string  `json:"float32BEBin,omitempty"`
 	Float64BE    *string `json:"float64BE,omitempty"`
 	Float64BEHex string  `json:"float64BEHex,omitempty"`
+	Float64BEBin string  `json:"float64BEBin,omitempty"`
 	Float32LE    *string `json:"float32LE,omitempty"`
 	Float32LEHex string  `json:"float32LEHex,omitempty"`
+	Float32LEBin string  `json:"float32LEBin,omitempty"`
 	Float64LE    *string `json:"float64LE,omitempty"`
 	Float64LEHex string  `json:"float64LEHex,omitempty"`
+	Float64LEBin string  `json:"float64LEBin,omitempty"`
 
 	// Floating Point - Mid-Big Endian (BADC)
+	Float16BADC    *string `json:"float16BADC,omitempty"`
+	Float16BADCHex string  `json:"float16BADCHex,omitempty"`
+	Float16BADCBin string  `json:"float16BADCBin,omitempty"`
 	Float32BADC    *string `json:"float32BADC,omitempty"`
 	Float32BADCHex string  `json:"float32BADCHex,omitempty"`
+	Float32BADCBin string  `json:"float32BADCBin,omitempty"`
 	Float64BADC    *string `json:"float64BADC,omitempty"`
 	Float64BADCHex string  `json:"float64BADCHex,omitempty"`
+	Float64BADCBin string  `json:"float64BADCBin,omitempty"`
 
 	// Floating Point - Mid-Little Endian (CDAB)
+	Float16CDAB    *string `json:"float16CDAB,omitempty"`
+	Float16CDABHex string  `json:"float16CDABHex,omitempty"`
+	Float16CDABBin string  `json:"float16CDABBin,omitempty"`
 	Float32CDAB    *string `json:"float32CDAB,omitempty"`
 	Float32CDABHex string  `json:"float32CDABHex,omitempty"`
+	Float32CDABBin string  `json:"float32CDABBin,omitempty"`
 	Float64CDAB    *string `json:"float64CDAB,omitempty"`
 	Float64CDABHex string  `json:"float64CDABHex,omitempty"`
+	Float64CDABBin string  `json:"float64CDABBin,omitempty"`
 
 	// Binary Representations
 	Binary string `json:"binary,omitempty"`
@@ -101,11 +215,65 @@ type ConversionResult struct {
 
 	// ASCII representation (printable chars, '.' for non-printable)
 	ASCII string `json:"ascii,omitempty"`
+
+	// The bytes as standard base64 and base32, e.g. to paste into a request
+	Base64 string `json:"base64,omitempty"`
+	Base32 string `json:"base32,omitempty"`
+
+	// Decimal digits of the bytes as packed and unpacked BCD, set when every
+	// nibble or byte is 0-9
+	BCD         string `json:"bcd,omitempty"`
+	BCDUnpacked string `json:"bcdUnpacked,omitempty"`
+
+	// The bytes as a network address in network order: an IPv4 address for 4
+	// bytes, a MAC address for 6 and an IPv6 address for 16
+	IPv4 string      `json:"ipv4,omitempty"`
+	IPv6 string      `json:"ipv6,omitempty"`
+	MAC  *MACAddress `json:"mac,omitempty"`
+
+	// Text encoding the bytes appear to use; nil if they do not look like text
+	Text *TextEncoding `json:"text,omitempty"`
+
+	// The bytes decoded as UTF-16 and UTF-32 text, e.g. Windows wide strings
+	Strings []DecodedString `json:"strings,omitempty"`
+
+	// Canonical form of the parsed input, e.g. "0x11 0x22 0x33" for hex
+	// input, so users can see how a messy paste was read and copy it clean
+	Canonical string `json:"canonical,omitempty"`
+
+	// Encoding the input was decoded from, e.g. "quoted-printable"
+	InputEncoding string `json:"inputEncoding,omitempty"`
+
+	// Set when only the first bytes of a large input were converted
+	Truncated   bool `json:"truncated,omitempty"`
+	TotalLength int  `json:"totalLength,omitempty"` // length of the whole input in bytes
+
+	// Names of integer values in the enum maps of the active profile
+	Enums []EnumMatch `json:"enums,omitempty"`
+
+	// Memory regions of the active profile that 32- and 64-bit unsigned values fall in
+	Regions []RegionMatch `json:"regions,omitempty"`
+
+	// Symbols of the loaded linker map that 32- and 64-bit unsigned values point into
+	Symbols []SymbolMatch `json:"symbols,omitempty"`
+
+	// Quiet or signaling and payload of NaN float values
+	NaNs []NaNPayload `json:"nans,omitempty"`
+
+	// Stored values of a decimal float input and their rounding errors
+	Rounding []FloatRounding `json:"rounding,omitempty"`
+
+	// Sections produced by user scripts
+	Scripts []ScriptSection `json:"scripts,omitempty"`
+
+	// On-demand sections that were computed (midEndian, float, legacySigned, bitFlags); nil if all were
+	Sections []string `json:"sections,omitempty"`
 }
 
 // ModbusRegister represents a single 16-bit Modbus register
 type ModbusRegister struct {
 	Index    int    `json:"index"`
+	Address  *int   `json:"address,omitempty"` // register number as in device documentation, if known
 	Hex      string `json:"hex"`
 	Unsigned uint16 `json:"unsigned"`
 	Signed   int16  `json:"signed"`
@@ -115,6 +283,7 @@ type ModbusRegister struct {
 // ModbusCombined32 represents a 32-bit value from two consecutive Modbus registers
 type ModbusCombined32 struct {
 	RegisterStart int    `json:"registerStart"`
+	Address       *int   `json:"address,omitempty"` // register number of the first register, if known
 	Hex           string `json:"hex"`
 	Uint32BE      uint32 `json:"uint32BE"`
 	Uint32LE      uint32 `json:"uint32LE"`
@@ -133,6 +302,7 @@ type ModbusCombined32 struct {
 // ModbusCombined64 represents a 64-bit value from four consecutive Modbus registers
 type ModbusCombined64 struct {
 	RegisterStart int    `json:"registerStart"`
+	Address       *int   `json:"address,omitempty"` // register number of the first register, if known
 	Hex           string `json:"hex"`
 	Uint64BE      uint64 `json:"uint64BE"`
 	Uint64LE      uint64 `json:"uint64LE"`
@@ -140,13 +310,87 @@ type ModbusCombined64 struct {
 	Int64LE       int64  `json:"int64LE"`
 	Float64BE     string `json:"float64BE"`
 	Float64LE     string `json:"float64LE"`
+	Float64BADC   string `json:"float64BADC"`
+	Float64CDAB   string `json:"float64CDAB"`
+
+	// Two float32 values in the window, as stored by some devices: registers
+	// 1-2 and 3-4 each read big-endian, or with their words swapped (CDAB)
+	Float32PairBE   [2]string `json:"float32PairBE"`
+	Float32PairCDAB [2]string `json:"float32PairCDAB"`
+}
+
+// Register notations of ModbusAddressing
+const (
+	NotationPlain   = ""   // protocol address plus base, e.g. 1
+	NotationHolding = "4x" // holding register numbers, e.g. 40001, or 400001 above 9999
+)
+
+// ModbusAddressing describes how registers are numbered in device
+// documentation
+type ModbusAddressing struct {
+	Start    int    `json:"start"`    // protocol (0-based) address of the first register
+	Base     int    `json:"base"`     // 0 or 1, number of protocol address 0
+	Notation string `json:"notation"` // NotationPlain or NotationHolding
+}
+
+// ModbusEncoding holds the registers to write to a device for a value, the
+// inverse of a ModbusResult
+type ModbusEncoding struct {
+	Type      string           `json:"type"`      // value type, e.g. float32
+	Order     string           `json:"order"`     // byte and word order: BE, LE, BADC or CDAB
+	Value     string           `json:"value"`     // value as it was parsed
+	Registers []ModbusRegister `json:"registers"` // in write order
+	RawHex    string           `json:"rawHex"`    // registers as Modbus input, e.g. "4248 0000"
+}
+
+// ModbusRTUFrame is a Modbus RTU frame split into its fields, with the CRC
+// checked
+type ModbusRTUFrame struct {
+	Address     int    `json:"address"`     // slave address, 0 for broadcast
+	Function    int    `json:"function"`    // function code, with the high bit set in exception responses
+	Exception   bool   `json:"exception"`   // the function code marks an exception response
+	Data        string `json:"data"`        // hex bytes between function code and CRC
+	DataLength  int    `json:"dataLength"`  // bytes
+	CRC         string `json:"crc"`         // received CRC, e.g. cdc5 for the bytes c5 cd
+	ExpectedCRC string `json:"expectedCrc"` // CRC of address, function code and data
+	Valid       bool   `json:"valid"`       // the received CRC matches
+}
+
+// Directions of a decoded Modbus frame
+const (
+	ModbusRequest  = "request"
+	ModbusResponse = "response"
+)
+
+// ModbusFrameResult is a Modbus request or response decoded from its bytes.
+// Address, Count and the values are set as far as the function code and
+// direction carry them, e.g. a read response has no address.
+type ModbusFrameResult struct {
+	Framing       string           `json:"framing"`                 // FramingRTU, FramingTCP or FramingPDU
+	TransactionID *int             `json:"transactionId,omitempty"` // TCP only
+	SlaveID       int              `json:"slaveId"`                 // address or unit ID; 0 for a bare PDU
+	CRCValid      *bool            `json:"crcValid,omitempty"`      // RTU only
+	Function      int              `json:"function"`                // without the exception bit
+	FunctionName  string           `json:"functionName"`            // e.g. Read Holding Registers
+	Direction     string           `json:"direction"`               // ModbusRequest or ModbusResponse; single writes are echoed unchanged
+	Exception     bool             `json:"exception"`
+	ExceptionCode int              `json:"exceptionCode,omitempty"`
+	ExceptionName string           `json:"exceptionName,omitempty"` // e.g. Illegal Data Address
+	Address       *int             `json:"address,omitempty"`       // first register or coil
+	Count         *int             `json:"count,omitempty"`         // registers or coils
+	Registers     []ModbusRegister `json:"registers,omitempty"`
+	Coils         []bool           `json:"coils,omitempty"` // coils or discrete inputs; a read response pads to whole bytes
+	Data          string           `json:"data"`            // hex bytes after the function code
 }
 
 // ModbusResult holds the conversion results for Modbus registers
 type ModbusResult struct {
-	Registers  []ModbusRegister   `json:"registers"`
-	Combined32 []ModbusCombined32 `json:"combined32"`
-	Combined64 []ModbusCombined64 `json:"combined64"`
-	RawHex     string             `json:"rawHex"`
-	ASCII      string             `json:"ascii"`
+	Registers  []ModbusRegister    `json:"registers"`
+	Combined32 []ModbusCombined32  `json:"combined32"`
+	Combined64 []ModbusCombined64  `json:"combined64"`
+	RawHex     string              `json:"rawHex"`
+	ASCII      string              `json:"ascii"`
+	Mapped     []MappedRegister    `json:"mapped,omitempty"`   // values of the active profile's register map
+	Scripts    []ModbusScriptTable `json:"scripts,omitempty"`  // values of user scripts defining modbus(data)
+	Sections   []string            `json:"sections,omitempty"` // on-demand sections that were computed (modbus32, modbus64); nil if all were
 }
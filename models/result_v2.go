@@ -0,0 +1,75 @@
+package models
+
+// ResultVersion2 is the Version of a ConversionResultV2.
+const ResultVersion2 = 2
+
+// TypedValue is one interpretation of the converted bytes.
+type TypedValue struct {
+	Value string `json:"value,omitempty"` // decimal integer or formatted float, as a string to keep 64-bit precision in JavaScript
+	Hex   string `json:"hex,omitempty"`
+	Bin   string `json:"bin,omitempty"`
+}
+
+// ConversionResultV2 holds the same values as ConversionResult, grouped by
+// type and keyed by byte order (BE, LE, BADC, CDAB), e.g.
+// result.Int32["LE"].Value. Types and byte orders that do not apply to the
+// input are left out.
+type ConversionResultV2 struct {
+	Version int `json:"version"`
+
+	Int8  map[string]TypedValue `json:"int8,omitempty"`
+	Int16 map[string]TypedValue `json:"int16,omitempty"`
+	Int32 map[string]TypedValue `json:"int32,omitempty"`
+	Int64 map[string]TypedValue `json:"int64,omitempty"`
+
+	Uint8  map[string]TypedValue `json:"uint8,omitempty"`
+	Uint16 map[string]TypedValue `json:"uint16,omitempty"`
+	Uint32 map[string]TypedValue `json:"uint32,omitempty"`
+	Uint64 map[string]TypedValue `json:"uint64,omitempty"`
+
+	OnesComplement8  map[string]TypedValue `json:"onesComplement8,omitempty"`
+	OnesComplement16 map[string]TypedValue `json:"onesComplement16,omitempty"`
+	OnesComplement32 map[string]TypedValue `json:"onesComplement32,omitempty"`
+	OnesComplement64 map[string]TypedValue `json:"onesComplement64,omitempty"`
+
+	SignMagnitude8  map[string]TypedValue `json:"signMagnitude8,omitempty"`
+	SignMagnitude16 map[string]TypedValue `json:"signMagnitude16,omitempty"`
+	SignMagnitude32 map[string]TypedValue `json:"signMagnitude32,omitempty"`
+	SignMagnitude64 map[string]TypedValue `json:"signMagnitude64,omitempty"`
+
+	BitFlags map[string]*BitFlags `json:"bitFlags,omitempty"` // by byte order, BE and LE
+
+	Float16 map[string]TypedValue `json:"float16,omitempty"`
+	Float32 map[string]TypedValue `json:"float32,omitempty"`
+	Float64 map[string]TypedValue `json:"float64,omitempty"`
+
+	Binary string `json:"binary,omitempty"`
+	Bytes  string `json:"bytes,omitempty"`
+	ASCII  string `json:"ascii,omitempty"`
+	Base64 string `json:"base64,omitempty"`
+	Base32 string `json:"base32,omitempty"`
+
+	BCD         string `json:"bcd,omitempty"`
+	BCDUnpacked string `json:"bcdUnpacked,omitempty"`
+
+	IPv4 string      `json:"ipv4,omitempty"`
+	IPv6 string      `json:"ipv6,omitempty"`
+	MAC  *MACAddress `json:"mac,omitempty"`
+
+	Text    *TextEncoding   `json:"text,omitempty"`
+	Strings []DecodedString `json:"strings,omitempty"`
+
+	Canonical     string `json:"canonical,omitempty"`
+	InputEncoding string `json:"inputEncoding,omitempty"`
+
+	Truncated   bool `json:"truncated,omitempty"`
+	TotalLength int  `json:"totalLength,omitempty"`
+
+	Enums    []EnumMatch     `json:"enums,omitempty"`
+	Regions  []RegionMatch   `json:"regions,omitempty"`
+	Symbols  []SymbolMatch   `json:"symbols,omitempty"`
+	NaNs     []NaNPayload    `json:"nans,omitempty"`
+	Rounding []FloatRounding `json:"rounding,omitempty"`
+	Scripts  []ScriptSection `json:"scripts,omitempty"`
+	Sections []string        `json:"sections,omitempty"`
+}
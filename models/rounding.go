@@ -0,0 +1,9 @@
+package models
+
+// FloatRounding shows how a decimal input is actually stored in a float type
+type FloatRounding struct {
+	Type   string `json:"type"`            // float32 or float64
+	Stored string `json:"stored"`          // exact decimal value of the stored number, e.g. 0.100000001490116119384765625
+	Error  string `json:"error,omitempty"` // stored value minus input; empty if the value overflows to ±Inf
+	Exact  bool   `json:"exact"`           // the input is stored without rounding
+}
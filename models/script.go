@@ -0,0 +1,38 @@
+package models
+
+// ScriptInfo describes a user script
+type ScriptInfo struct {
+	Name  string `json:"name"`
+	Path  string `json:"path,omitempty"`
+	Error string `json:"error,omitempty"` // compile error
+}
+
+// ScriptValue is a named value returned by a script
+type ScriptValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ScriptSection holds the values a script computed for a conversion
+type ScriptSection struct {
+	Name   string        `json:"name"`
+	Values []ScriptValue `json:"values,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+// ModbusScriptTable holds the values a script computed for every window of
+// consecutive Modbus registers
+type ModbusScriptTable struct {
+	Name   string            `json:"name"`
+	Window int               `json:"window"` // registers per row
+	Rows   []ModbusScriptRow `json:"rows,omitempty"`
+	Error  string            `json:"error,omitempty"` // compile error
+}
+
+// ModbusScriptRow holds the values of one window of registers
+type ModbusScriptRow struct {
+	RegisterStart int           `json:"registerStart"`
+	Address       *int          `json:"address,omitempty"` // register number of the first register, if known
+	Values        []ScriptValue `json:"values,omitempty"`
+	Error         string        `json:"error,omitempty"`
+}
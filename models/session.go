@@ -0,0 +1,27 @@
+package models
+
+// Session is the state of one conversion tab. Every session has its own
+// input, settings, opened file and history.
+type Session struct {
+	ID       string         `json:"id"`
+	Name     string         `json:"name"`
+	Mode     string         `json:"mode"` // hex, int, intAuto, binary, float or modbus
+	Input    string         `json:"input"`
+	Type     string         `json:"type,omitempty"` // integer or float type of int and float conversions
+	Settings Settings       `json:"settings"`
+	FileID   string         `json:"fileId,omitempty"`   // ID of the file opened in the session
+	FilePath string         `json:"filePath,omitempty"` // path of that file, used to reopen it on startup
+	History  []HistoryEntry `json:"history"`            // conversions of the session, oldest first
+
+	// Undo state of the input
+	CanUndo bool `json:"canUndo"`
+	CanRedo bool `json:"canRedo"`
+}
+
+// SessionResult is the outcome of a conversion in a session. Depending on
+// the mode either Conversion or Modbus is set.
+type SessionResult struct {
+	Session    Session           `json:"session"`
+	Conversion *ConversionResult `json:"conversion,omitempty"`
+	Modbus     *ModbusResult     `json:"modbus,omitempty"`
+}
@@ -0,0 +1,25 @@
+package models
+
+// Settings holds user preferences persisted across sessions
+type Settings struct {
+	ByteOrder      string         `json:"byteOrder"`      // preferred byte/word order: BE, LE, BADC or CDAB
+	FloatPrecision int            `json:"floatPrecision"` // decimal places for floats, -1 for shortest exact
+	HexUppercase   bool           `json:"hexUppercase"`
+	NaNPayloads    bool           `json:"nanPayloads"`   // show quiet/signaling and payload of NaN floats
+	DumpWidth      int            `json:"dumpWidth"`     // bytes per hex dump line
+	DropThreshold  int            `json:"dropThreshold"` // dropped files up to this size are converted, larger ones opened
+	MaxInputSize   int            `json:"maxInputSize"`  // longest accepted input in bytes, 0 for no limit
+	TruncateInput  bool           `json:"truncateInput"` // convert a preview of longer hex and binary input instead of rejecting it
+	PreviewSize    int            `json:"previewSize"`   // bytes converted of a truncated input
+	Modbus         ModbusSettings `json:"modbus"`
+}
+
+// ModbusSettings holds Modbus related preferences
+type ModbusSettings struct {
+	AddressBase  int    `json:"addressBase"`  // 0 or 1, number of the first register
+	StartAddress int    `json:"startAddress"` // protocol address of the first converted register
+	Notation     string `json:"notation"`     // register numbers: "" plain or "4x" (40001)
+	DecimalInput bool   `json:"decimalInput"` // treat register input as decimal by default
+	Show32       bool   `json:"show32"`       // show 32-bit combinations
+	Show64       bool   `json:"show64"`       // show 64-bit combinations
+}
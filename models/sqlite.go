@@ -0,0 +1,37 @@
+package models
+
+// SQLiteHeader is the decoded 100-byte SQLite database header
+type SQLiteHeader struct {
+	PageSize           int      `json:"pageSize"`
+	WriteVersion       int      `json:"writeVersion"`
+	ReadVersion        int      `json:"readVersion"`
+	ReservedSpace      int      `json:"reservedSpace"`
+	ChangeCounter      uint32   `json:"changeCounter"`
+	PageCount          uint32   `json:"pageCount"`
+	FirstFreelistTrunk uint32   `json:"firstFreelistTrunk"`
+	FreelistPages      uint32   `json:"freelistPages"`
+	SchemaCookie       uint32   `json:"schemaCookie"`
+	SchemaFormat       uint32   `json:"schemaFormat"`
+	DefaultCacheSize   uint32   `json:"defaultCacheSize"`
+	LargestRootPage    uint32   `json:"largestRootPage"`
+	TextEncoding       string   `json:"textEncoding"`
+	UserVersion        uint32   `json:"userVersion"`
+	IncrementalVacuum  bool     `json:"incrementalVacuum"`
+	ApplicationID      uint32   `json:"applicationId"`
+	SQLiteVersion      string   `json:"sqliteVersion"`
+	Warnings           []string `json:"warnings,omitempty"`
+}
+
+// SQLitePage is a decoded SQLite b-tree page header
+type SQLitePage struct {
+	PageNumber       uint32  `json:"pageNumber,omitempty"` // 0 when decoded from a raw buffer
+	Offset           int64   `json:"offset"`               // offset of the page start
+	HeaderOffset     int64   `json:"headerOffset"`         // offset of the b-tree header
+	Type             string  `json:"type"`
+	FirstFreeblock   int     `json:"firstFreeblock"`
+	CellCount        int     `json:"cellCount"`
+	CellContentStart int     `json:"cellContentStart"`
+	FragmentedBytes  int     `json:"fragmentedBytes"`
+	RightMostPointer uint32  `json:"rightMostPointer,omitempty"`
+	CellOffsets      []int64 `json:"cellOffsets"` // absolute offsets of the cells
+}
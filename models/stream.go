@@ -0,0 +1,21 @@
+package models
+
+// StreamEvent carries one batch of incremental results of a long-running
+// operation. The last event of a stream has Done set.
+type StreamEvent struct {
+	StreamID  string `json:"streamId"`
+	Kind      string `json:"kind"` // "file-search" or "modbus"
+	Seq       int    `json:"seq"`
+	Items     any    `json:"items,omitempty"` // SearchMatches or ModbusResult, depending on Kind
+	Processed int64  `json:"processed"`       // bytes or registers processed so far
+	Total     int64  `json:"total"`
+	Done      bool   `json:"done"`
+	Cancelled bool   `json:"cancelled,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// SearchMatches is a batch of pattern occurrences found in a file
+type SearchMatches struct {
+	FileID  string  `json:"fileId"`
+	Offsets []int64 `json:"offsets"`
+}
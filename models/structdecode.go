@@ -0,0 +1,20 @@
+package models
+
+// StructField is a field of a struct decoded with a user-defined layout
+type StructField struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Endian string `json:"endian,omitempty"` // le or be; empty for bytes, chars and 1-byte types
+	Offset int    `json:"offset"`
+	Size   int    `json:"size"`
+	Value  string `json:"value"` // numbers in decimal, arrays as [1, 2], char as text, bytes in hex
+	Hex    string `json:"hex"`   // raw bytes of the field
+}
+
+// StructResult holds hex input decoded with a layout
+type StructResult struct {
+	Layout    string        `json:"layout,omitempty"` // name of the layout
+	Size      int           `json:"size"`             // bytes the layout covers
+	Fields    []StructField `json:"fields"`
+	Remaining int           `json:"remaining"` // bytes of input after the layout
+}
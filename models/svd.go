@@ -0,0 +1,42 @@
+package models
+
+// SVDDevice summarizes a loaded CMSIS-SVD device description
+type SVDDevice struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Path        string          `json:"path,omitempty"`
+	Peripherals []SVDPeripheral `json:"peripherals"`
+}
+
+// SVDPeripheral lists the registers of a peripheral
+type SVDPeripheral struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	BaseAddress string   `json:"baseAddress"`
+	Registers   []string `json:"registers"`
+}
+
+// SVDRegisterValue is a register value split into the bitfields of its SVD
+// description
+type SVDRegisterValue struct {
+	Peripheral  string          `json:"peripheral"`
+	Register    string          `json:"register"`
+	Description string          `json:"description,omitempty"`
+	Address     string          `json:"address"`
+	Size        int             `json:"size"` // bits
+	Value       string          `json:"value"`
+	ResetValue  string          `json:"resetValue"`
+	Fields      []SVDFieldValue `json:"fields"`
+	Unassigned  string          `json:"unassigned,omitempty"` // bits set outside of all fields
+}
+
+// SVDFieldValue is a bitfield of a decoded register value
+type SVDFieldValue struct {
+	Name        string `json:"name"`
+	Bits        string `json:"bits"` // e.g. "[7:4]"
+	Value       uint64 `json:"value"`
+	Hex         string `json:"hex"`
+	Enum        string `json:"enum,omitempty"` // name of the value in the SVD
+	Description string `json:"description,omitempty"`
+	Access      string `json:"access,omitempty"`
+}
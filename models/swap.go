@@ -0,0 +1,18 @@
+package models
+
+// SwapComparison lists the byte-order transforms that turn an observed
+// value into the expected one
+type SwapComparison struct {
+	Observed   string          `json:"observed"` // hex
+	Expected   string          `json:"expected"` // hex
+	Matches    []string        `json:"matches"`  // names of the matching transforms, none if empty
+	Transforms []SwapTransform `json:"transforms"`
+}
+
+// SwapTransform is the observed value after one byte-order transform
+type SwapTransform struct {
+	Name        string `json:"name"`        // e.g. swap16
+	Description string `json:"description"` // e.g. "swap the bytes of each 16-bit word (BADC)"
+	Result      string `json:"result"`      // hex
+	Match       bool   `json:"match"`       // the result is the expected value
+}
@@ -0,0 +1,51 @@
+package models
+
+// Text encodings reported by TextEncoding
+const (
+	EncodingASCII   = "ASCII"
+	EncodingUTF8    = "UTF-8"
+	EncodingUTF16LE = "UTF-16LE"
+	EncodingUTF16BE = "UTF-16BE"
+	EncodingUTF32LE = "UTF-32LE"
+	EncodingUTF32BE = "UTF-32BE"
+)
+
+// TextEncoding describes the text encoding a buffer appears to use, found
+// from its byte-order mark or by decoding it heuristically
+type TextEncoding struct {
+	Encoding  string `json:"encoding"`            // one of the Encoding constants
+	BOM       bool   `json:"bom"`                 // found from a byte-order mark
+	Summary   string `json:"summary"`             // e.g. "looks like UTF-16LE text"
+	Text      string `json:"text"`                // decoded text without the BOM, '.' for control characters
+	Truncated bool   `json:"truncated,omitempty"` // set when Text holds only the first characters
+}
+
+// DecodedString is a buffer decoded as text in one encoding
+type DecodedString struct {
+	Encoding  string `json:"encoding"`            // one of the Encoding constants
+	Text      string `json:"text"`                // '.' for control characters, U+FFFD for invalid sequences
+	Valid     bool   `json:"valid"`               // decoded without invalid sequences
+	Truncated bool   `json:"truncated,omitempty"` // set when Text holds only the first characters
+}
+
+// StringConversion holds the encodings of text typed by the user, the
+// reverse of decoding hex to text
+type StringConversion struct {
+	Text       string      `json:"text"`
+	Hex        string      `json:"hex"`        // UTF-8 bytes
+	Binary     string      `json:"binary"`     // UTF-8 bytes
+	Length     int         `json:"length"`     // UTF-8 bytes
+	Characters int         `json:"characters"` // code points
+	ASCII      bool        `json:"ascii"`      // all characters are 7-bit ASCII
+	UTF16BE    string      `json:"utf16BE"`    // hex, without byte-order mark
+	UTF16LE    string      `json:"utf16LE"`
+	CodePoints []CodePoint `json:"codePoints"`
+	Truncated  bool        `json:"truncated,omitempty"` // set when CodePoints holds only the first characters
+}
+
+// CodePoint is a character of a StringConversion with its UTF-8 encoding
+type CodePoint struct {
+	Char      string `json:"char"`
+	CodePoint string `json:"codePoint"` // e.g. U+00E9
+	UTF8      string `json:"utf8"`      // hex, e.g. c3a9
+}
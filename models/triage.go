@@ -0,0 +1,32 @@
+package models
+
+// Kinds of words flagged by buffer triage
+const (
+	TriageCanary  = "canary"  // stack canary or security cookie
+	TriagePointer = "pointer" // address in a typical ASLR range
+	TriagePAC     = "pac"     // arm64 pointer signed with a pointer authentication code
+	TriageTag     = "tag"     // arm64 pointer with a top-byte tag (MTE, HWASan)
+)
+
+// TriageFinding is a word of a buffer that looks like a stack canary or a
+// pointer
+type TriageFinding struct {
+	Offset  int    `json:"offset"`
+	Value   string `json:"value"` // word, 0x prefixed
+	Kind    string `json:"kind"`
+	Region  string `json:"region,omitempty"`  // of pointers, e.g. "stack" or "shared library"
+	Address string `json:"address,omitempty"` // pointer without its PAC or tag
+	Note    string `json:"note"`
+}
+
+// TriageResult lists the words of a memory dump that look like stack
+// canaries, ASLR'd pointers or signed pointers, for exploit and crash
+// triage
+type TriageResult struct {
+	Arch      string          `json:"arch"`
+	WordSize  int             `json:"wordSize"`  // bytes; words are read at multiples of it
+	Words     int             `json:"words"`     // words scanned
+	Remainder int             `json:"remainder"` // trailing bytes too short for a word
+	Findings  []TriageFinding `json:"findings"`
+	Truncated bool            `json:"truncated,omitempty"` // only the first findings are listed
+}
@@ -0,0 +1,64 @@
+package models
+
+// IntType names an integer type accepted by ConvertInt
+type IntType string
+
+// Integer types
+const (
+	Int8   IntType = "int8"
+	Int16  IntType = "int16"
+	Int32  IntType = "int32"
+	Int64  IntType = "int64"
+	Uint8  IntType = "uint8"
+	Uint16 IntType = "uint16"
+	Uint32 IntType = "uint32"
+	Uint64 IntType = "uint64"
+)
+
+// AllIntTypes lists the integer types for the generated frontend enum
+var AllIntTypes = []struct {
+	Value  IntType
+	TSName string
+}{
+	{Int8, "INT8"},
+	{Int16, "INT16"},
+	{Int32, "INT32"},
+	{Int64, "INT64"},
+	{Uint8, "UINT8"},
+	{Uint16, "UINT16"},
+	{Uint32, "UINT32"},
+	{Uint64, "UINT64"},
+}
+
+// Valid reports whether t is a known integer type.
+func (t IntType) Valid() bool {
+	for _, e := range AllIntTypes {
+		if e.Value == t {
+			return true
+		}
+	}
+	return false
+}
+
+// FloatType names a floating-point type accepted by ConvertFloat
+type FloatType string
+
+// Floating-point types
+const (
+	Float32 FloatType = "float32"
+	Float64 FloatType = "float64"
+)
+
+// AllFloatTypes lists the float types for the generated frontend enum
+var AllFloatTypes = []struct {
+	Value  FloatType
+	TSName string
+}{
+	{Float32, "FLOAT32"},
+	{Float64, "FLOAT64"},
+}
+
+// Valid reports whether t is a known float type.
+func (t FloatType) Valid() bool {
+	return t == Float32 || t == Float64
+}
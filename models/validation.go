@@ -0,0 +1,10 @@
+package models
+
+// InputValidation is the result of checking an input without converting it
+type InputValidation struct {
+	Valid      bool        `json:"valid"`
+	Format     string      `json:"format,omitempty"`     // detected notation, e.g. "spaced" or "scientific"
+	Normalized string      `json:"normalized,omitempty"` // canonical preview of the parsed value, possibly truncated
+	Length     int         `json:"length"`               // number of bytes (or registers in Modbus mode)
+	Error      *InputError `json:"error,omitempty"`
+}
@@ -0,0 +1,144 @@
+// Package onewire decodes 1-Wire device ROM codes and the scratchpad of
+// DS18B20 compatible temperature sensors.
+//
+// Example usage:
+//
+//	rom, _ := onewire.ParseROM([]byte{0x28, 0xff, 0x64, 0x1e, 0x0f, 0x16, 0x03, 0x90})
+//	fmt.Println(rom.ID(), rom.FamilyName(), rom.CRCValid()) // 28-03160f1e64ff DS18B20 temperature sensor true
+//
+//	sp, _ := onewire.ParseScratchpad(data)
+//	fmt.Printf("%.4f °C\n", sp.Temperature)
+package onewire
+
+import (
+	"errors"
+	"fmt"
+
+	"hexview/checksum"
+)
+
+// Sizes of a ROM code and a scratchpad
+const (
+	ROMSize        = 8
+	ScratchpadSize = 9
+)
+
+// PowerOnTemperature is the raw temperature register after power-on. Reading
+// it usually means no conversion was started or the sensor lost power.
+const PowerOnTemperature = 0x0550 // 85 °C
+
+// ErrInvalidSize indicates data that is not a ROM code or scratchpad
+var ErrInvalidSize = errors.New("invalid size")
+
+// familyNames names common 1-Wire family codes.
+var familyNames = map[byte]string{
+	0x01: "DS2401 silicon serial number",
+	0x05: "DS2405 addressable switch",
+	0x10: "DS18S20 temperature sensor",
+	0x12: "DS2406 dual addressable switch",
+	0x1D: "DS2423 counter",
+	0x20: "DS2450 quad A/D converter",
+	0x22: "DS1822 temperature sensor",
+	0x23: "DS2433 4 kbit EEPROM",
+	0x26: "DS2438 battery monitor",
+	0x28: "DS18B20 temperature sensor",
+	0x29: "DS2408 8-channel switch",
+	0x2D: "DS2431 1 kbit EEPROM",
+	0x3A: "DS2413 dual-channel switch",
+	0x3B: "DS1825/MAX31850 temperature sensor",
+	0x42: "DS28EA00 temperature sensor",
+}
+
+// crc8 computes the Dallas/Maxim CRC used by ROM codes and scratchpads.
+func crc8(data []byte) byte {
+	return byte(checksum.CRC8Maxim.Checksum(data))
+}
+
+// ROM is a 64-bit 1-Wire ROM code as read by READ ROM or a search: the family
+// code, a 48-bit serial number sent LSB first and a CRC8.
+type ROM [ROMSize]byte
+
+// ParseROM reads a ROM code in transmission order.
+func ParseROM(data []byte) (ROM, error) {
+	var rom ROM
+	if len(data) != ROMSize {
+		return rom, fmt.Errorf("%w: ROM codes have %d bytes, got %d", ErrInvalidSize, ROMSize, len(data))
+	}
+	copy(rom[:], data)
+	return rom, nil
+}
+
+// Family returns the family code.
+func (r ROM) Family() byte {
+	return r[0]
+}
+
+// FamilyName names the device family, or returns "" if it is unknown.
+func (r ROM) FamilyName() string {
+	return familyNames[r[0]]
+}
+
+// Serial returns the serial number in hex, most significant byte first.
+func (r ROM) Serial() string {
+	return fmt.Sprintf("%02x%02x%02x%02x%02x%02x", r[6], r[5], r[4], r[3], r[2], r[1])
+}
+
+// ID formats the ROM code like the Linux w1 subsystem, e.g. "28-03160f1e64ff".
+func (r ROM) ID() string {
+	return fmt.Sprintf("%02x-%s", r[0], r.Serial())
+}
+
+// CRC returns the CRC8 computed over family code and serial number.
+func (r ROM) CRC() byte {
+	return crc8(r[:7])
+}
+
+// CRCValid reports whether the last byte matches the CRC.
+func (r ROM) CRCValid() bool {
+	return r.CRC() == r[7]
+}
+
+// Scratchpad is the decoded scratchpad of a DS18B20, DS1822 or compatible
+// sensor.
+type Scratchpad struct {
+	Raw         int16   // temperature register
+	Temperature float64 // °C, undefined bits below the resolution cleared
+	High        int8    // TH alarm trigger or user byte 1, °C
+	Low         int8    // TL alarm trigger or user byte 2, °C
+	Config      byte
+	Resolution  int // 9 to 12 bits
+	CRC         byte
+	CRCValid    bool
+}
+
+// ParseScratchpad decodes the 9 bytes returned by READ SCRATCHPAD.
+func ParseScratchpad(data []byte) (*Scratchpad, error) {
+	if len(data) != ScratchpadSize {
+		return nil, fmt.Errorf("%w: scratchpads have %d bytes, got %d", ErrInvalidSize, ScratchpadSize, len(data))
+	}
+	s := &Scratchpad{
+		Raw:        int16(uint16(data[1])<<8 | uint16(data[0])),
+		High:       int8(data[2]),
+		Low:        int8(data[3]),
+		Config:     data[4],
+		Resolution: 9 + int(data[4]>>5&3),
+		CRC:        data[8],
+		CRCValid:   crc8(data[:8]) == data[8],
+	}
+	undefined := int16(1)<<(12-s.Resolution) - 1
+	s.Temperature = float64(s.Raw&^undefined) * 0.0625
+	return s, nil
+}
+
+// IsScratchpad reports whether data looks like a DS18B20 scratchpad: 9 bytes
+// with a valid CRC and the fixed bits of the configuration and reserved
+// registers.
+func IsScratchpad(data []byte) bool {
+	return len(data) == ScratchpadSize && data[4]&0x9F == 0x1F && data[7] == 0x10 && crc8(data[:8]) == data[8]
+}
+
+// IsROM reports whether data is a ROM code with a valid CRC. An all-zero code,
+// which has a valid CRC, is rejected.
+func IsROM(data []byte) bool {
+	return len(data) == ROMSize && data[0] != 0 && crc8(data[:7]) == data[7]
+}
@@ -0,0 +1,65 @@
+package onewire
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseROM(t *testing.T) {
+	data := []byte{0x28, 0xff, 0x64, 0x1e, 0x0f, 0x16, 0x03, 0x90}
+	rom, err := ParseROM(data)
+	if err != nil {
+		t.Fatalf("ParseROM() error: %v", err)
+	}
+	if rom.Family() != 0x28 || rom.FamilyName() != "DS18B20 temperature sensor" {
+		t.Errorf("family = 0x%02x %q", rom.Family(), rom.FamilyName())
+	}
+	if rom.ID() != "28-03160f1e64ff" {
+		t.Errorf("ID() = %q", rom.ID())
+	}
+	if !rom.CRCValid() || !IsROM(data) {
+		t.Errorf("CRC() = 0x%02x, want valid", rom.CRC())
+	}
+
+	data[7] = 0x91
+	if rom, _ := ParseROM(data); rom.CRCValid() || IsROM(data) {
+		t.Error("corrupted CRC reported as valid")
+	}
+	if IsROM(make([]byte, ROMSize)) {
+		t.Error("IsROM(zeros) = true")
+	}
+	if _, err := ParseROM(data[:7]); !errors.Is(err, ErrInvalidSize) {
+		t.Errorf("ParseROM(7 bytes) error = %v, want ErrInvalidSize", err)
+	}
+}
+
+func TestParseScratchpad(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       []byte
+		want       float64
+		resolution int
+	}{
+		{"power-on 85", []byte{0x50, 0x05, 0x4b, 0x46, 0x7f, 0xff, 0x0c, 0x10, 0x1c}, 85, 12},
+		{"12-bit positive", []byte{0x91, 0x01, 0x4b, 0x46, 0x7f, 0xff, 0x0f, 0x10, 0x25}, 25.0625, 12},
+		{"9-bit negative", []byte{0x5e, 0xff, 0x4b, 0x46, 0x1f, 0xff, 0x02, 0x10, 0x26}, -10.5, 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp, err := ParseScratchpad(tt.data)
+			if err != nil {
+				t.Fatalf("ParseScratchpad() error: %v", err)
+			}
+			if sp.Temperature != tt.want || sp.Resolution != tt.resolution {
+				t.Errorf("ParseScratchpad() = %g °C %d-bit, want %g °C %d-bit", sp.Temperature, sp.Resolution, tt.want, tt.resolution)
+			}
+			if sp.High != 75 || sp.Low != 70 || !sp.CRCValid || !IsScratchpad(tt.data) {
+				t.Errorf("ParseScratchpad() = %+v", sp)
+			}
+		})
+	}
+
+	if _, err := ParseScratchpad(make([]byte, 8)); !errors.Is(err, ErrInvalidSize) {
+		t.Errorf("ParseScratchpad(8 bytes) error = %v, want ErrInvalidSize", err)
+	}
+}
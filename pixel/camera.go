@@ -0,0 +1,72 @@
+package pixel
+
+import (
+	"image"
+	"image/color"
+)
+
+// yuv converts a BT.601 limited range Y'CbCr sample to RGB.
+func yuv(y, u, v byte) color.RGBA {
+	c := 298 * (int(y) - 16)
+	d, e := int(u)-128, int(v)-128
+	return rgb(clamp((c+409*e+128)>>8), clamp((c-100*d-208*e+128)>>8), clamp((c+516*d+128)>>8))
+}
+
+// clamp limits v to a channel value.
+func clamp(v int) byte {
+	return byte(min(max(v, 0), 255))
+}
+
+// decodeSemiPlanar decodes NV12 (swapUV false) or NV21: a plane of luma rows
+// followed by a plane of interleaved chroma rows, each chroma pair covering
+// 2×2 pixels.
+func decodeSemiPlanar(img *image.RGBA, data []byte, stride int, swapUV bool) {
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	chroma := data[height*stride:]
+	for y := range height {
+		luma := data[y*stride:]
+		uv := chroma[y/2*stride:]
+		for x := range width {
+			u, v := uv[x&^1], uv[x|1]
+			if swapUV {
+				u, v = v, u
+			}
+			img.SetRGBA(x, y, yuv(luma[x], u, v))
+		}
+	}
+}
+
+// bayer returns the 8-bit raw Bayer format whose top-left 2×2 cell has the
+// color filters of pattern, e.g. "rggb". The preview is demosaiced simply:
+// all four pixels of a cell get its red, blue and mean green value.
+func bayer(pattern string) Format {
+	return Format{
+		Name:  "bayer_" + pattern,
+		Bits:  8,
+		align: 2,
+		decode: func(img *image.RGBA, data []byte, stride int) {
+			width, height := img.Bounds().Dx(), img.Bounds().Dy()
+			for y := 0; y < height; y += 2 {
+				for x := 0; x < width; x += 2 {
+					cell := [4]byte{data[y*stride+x], data[y*stride+x+1], data[(y+1)*stride+x], data[(y+1)*stride+x+1]}
+					var r, g, b int
+					for i, filter := range []byte(pattern) {
+						switch filter {
+						case 'r':
+							r = int(cell[i])
+						case 'g':
+							g += int(cell[i])
+						case 'b':
+							b = int(cell[i])
+						}
+					}
+					c := rgb(byte(r), byte(g/2), byte(b))
+					img.SetRGBA(x, y, c)
+					img.SetRGBA(x+1, y, c)
+					img.SetRGBA(x, y+1, c)
+					img.SetRGBA(x+1, y+1, c)
+				}
+			}
+		},
+	}
+}
@@ -0,0 +1,228 @@
+// Package pixel decodes raw buffers as pixel data, so framebuffer dumps of
+// displays and cameras become recognizable images.
+//
+// Rows are read top to bottom and start every stride bytes; pixels smaller
+// than a byte are packed starting with the most significant bits.
+//
+// Example usage:
+//
+//	f, _ := pixel.ParseFormat("rgb565")
+//	img, _ := pixel.Decode(framebuffer, f, 320, 0)
+//	png.Encode(w, img)
+package pixel
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+)
+
+var (
+	// ErrUnknownFormat indicates a pixel format name that is not supported
+	ErrUnknownFormat = errors.New("unknown pixel format")
+
+	// ErrInvalidGeometry indicates a width or stride that does not fit the data
+	ErrInvalidGeometry = errors.New("invalid image geometry")
+)
+
+// Format is a pixel layout.
+type Format struct {
+	Name string
+	Bits int // bits per pixel; of the luma plane for NV12 and NV21
+
+	align int // width must be a multiple of align, 1 if zero
+
+	// at returns the color of pixel x of a row of a packed format
+	at func(row []byte, x int) color.RGBA
+
+	// decode decodes formats that need more than one row per pixel, Bayer
+	// patterns and semi-planar YUV, into img
+	decode func(img *image.RGBA, data []byte, stride int)
+
+	planar bool // a luma plane followed by a chroma plane of half the rows
+}
+
+// formats lists the supported pixel formats. 16-bit formats are
+// little-endian as stored by most microcontrollers, the "be" variants as
+// sent to SPI displays. YUV formats use the BT.601 limited range of camera
+// sensors, Bayer patterns are named by their top-left 2×2 cell.
+var formats = []Format{
+	{Name: "gray1", Bits: 1, at: func(row []byte, x int) color.RGBA { return gray(packed(row, x, 1) * 0xff) }},
+	{Name: "gray2", Bits: 2, at: func(row []byte, x int) color.RGBA { return gray(packed(row, x, 2) * 0x55) }},
+	{Name: "gray4", Bits: 4, at: func(row []byte, x int) color.RGBA { return gray(packed(row, x, 4) * 0x11) }},
+	{Name: "gray8", Bits: 8, at: func(row []byte, x int) color.RGBA { return gray(row[x]) }},
+	{Name: "rgb332", Bits: 8, at: func(row []byte, x int) color.RGBA {
+		v := row[x]
+		return rgb(scale(uint32(v>>5), 3), scale(uint32(v>>2&7), 3), scale(uint32(v&3), 2))
+	}},
+	{Name: "rgb565", Bits: 16, at: func(row []byte, x int) color.RGBA { return rgb565(uint16(row[2*x]) | uint16(row[2*x+1])<<8) }},
+	{Name: "rgb565be", Bits: 16, at: func(row []byte, x int) color.RGBA { return rgb565(uint16(row[2*x])<<8 | uint16(row[2*x+1])) }},
+	{Name: "bgr565", Bits: 16, at: func(row []byte, x int) color.RGBA {
+		c := rgb565(uint16(row[2*x]) | uint16(row[2*x+1])<<8)
+		c.R, c.B = c.B, c.R
+		return c
+	}},
+	{Name: "rgb888", Bits: 24, at: func(row []byte, x int) color.RGBA { return rgb(row[3*x], row[3*x+1], row[3*x+2]) }},
+	{Name: "bgr888", Bits: 24, at: func(row []byte, x int) color.RGBA { return rgb(row[3*x+2], row[3*x+1], row[3*x]) }},
+	{Name: "rgba8888", Bits: 32, at: func(row []byte, x int) color.RGBA {
+		p := row[4*x:]
+		return color.RGBA{p[0], p[1], p[2], 0xff}
+	}},
+	{Name: "bgra8888", Bits: 32, at: func(row []byte, x int) color.RGBA {
+		p := row[4*x:]
+		return color.RGBA{p[2], p[1], p[0], 0xff}
+	}},
+	{Name: "yuyv", Bits: 16, align: 2, at: func(row []byte, x int) color.RGBA {
+		p := row[4*(x/2):]
+		return yuv(p[2*(x%2)], p[1], p[3])
+	}},
+	{Name: "uyvy", Bits: 16, align: 2, at: func(row []byte, x int) color.RGBA {
+		p := row[4*(x/2):]
+		return yuv(p[1+2*(x%2)], p[0], p[2])
+	}},
+	{Name: "nv12", Bits: 8, align: 2, planar: true, decode: func(img *image.RGBA, data []byte, stride int) {
+		decodeSemiPlanar(img, data, stride, false)
+	}},
+	{Name: "nv21", Bits: 8, align: 2, planar: true, decode: func(img *image.RGBA, data []byte, stride int) {
+		decodeSemiPlanar(img, data, stride, true)
+	}},
+	bayer("rggb"),
+	bayer("bggr"),
+	bayer("grbg"),
+	bayer("gbrg"),
+}
+
+// Formats returns the names of the supported pixel formats.
+func Formats() []string {
+	names := make([]string, len(formats))
+	for i, f := range formats {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// ParseFormat returns the pixel format with the given name, ignoring case.
+// "gray" and "grayscale" are aliases for gray8, "mono" for gray1, "yuv422"
+// and "yuy2" for yuyv, and "rggb" etc. for the Bayer patterns.
+func ParseFormat(name string) (Format, error) {
+	n := strings.ToLower(strings.TrimSpace(name))
+	switch n {
+	case "gray", "grayscale", "grey":
+		n = "gray8"
+	case "mono", "monochrome":
+		n = "gray1"
+	case "yuv422", "yuy2":
+		n = "yuyv"
+	case "rggb", "bggr", "grbg", "gbrg":
+		n = "bayer_" + n
+	}
+	for _, f := range formats {
+		if f.Name == n {
+			return f, nil
+		}
+	}
+	return Format{}, fmt.Errorf("%w: %s", ErrUnknownFormat, name)
+}
+
+// RowBytes returns the bytes of a row of width pixels without padding.
+func (f Format) RowBytes(width int) int {
+	return (width*f.Bits + 7) / 8
+}
+
+// Size returns the bytes of an image of width × height pixels whose rows
+// start every stride bytes, or every f.RowBytes(width) bytes if stride is
+// zero.
+func (f Format) Size(width, height, stride int) int {
+	rowBytes := f.RowBytes(width)
+	if stride == 0 {
+		stride = rowBytes
+	}
+	if height == 0 {
+		return 0
+	}
+	if f.planar {
+		height += height / 2
+	}
+	return (height-1)*stride + rowBytes
+}
+
+// height returns the complete rows of an image in n bytes.
+func (f Format) height(n, rowBytes, stride int) int {
+	if n < rowBytes {
+		return 0
+	}
+	rows := (n-rowBytes)/stride + 1
+	switch {
+	case f.planar:
+		// Every two luma rows share one chroma row
+		return rows * 2 / 3 &^ 1
+	case f.decode != nil:
+		return rows &^ 1 // Bayer cells span two rows
+	}
+	return rows
+}
+
+// Decode decodes data as an image of width pixels per row. Rows start every
+// stride bytes, or every f.RowBytes(width) bytes if stride is zero; a last
+// row that is not complete is left out. Alpha channels are ignored, so
+// images are always opaque.
+func Decode(data []byte, f Format, width, stride int) (*image.RGBA, error) {
+	if width <= 0 {
+		return nil, fmt.Errorf("%w: width %d", ErrInvalidGeometry, width)
+	}
+	if f.align > 1 && width%f.align != 0 {
+		return nil, fmt.Errorf("%w: %s needs a width that is a multiple of %d, got %d", ErrInvalidGeometry, f.Name, f.align, width)
+	}
+	rowBytes := f.RowBytes(width)
+	if stride == 0 {
+		stride = rowBytes
+	}
+	if stride < rowBytes {
+		return nil, fmt.Errorf("%w: stride %d is shorter than a row of %d bytes", ErrInvalidGeometry, stride, rowBytes)
+	}
+	height := f.height(len(data), rowBytes, stride)
+	if height == 0 {
+		return nil, fmt.Errorf("%w: %d bytes are too short for an image of %s with %d bytes per row", ErrInvalidGeometry, len(data), f.Name, rowBytes)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	if f.decode != nil {
+		f.decode(img, data, stride)
+		return img, nil
+	}
+	for y := range height {
+		row := data[y*stride : y*stride+rowBytes]
+		for x := range width {
+			img.SetRGBA(x, y, f.at(row, x))
+		}
+	}
+	return img, nil
+}
+
+// packed returns pixel x of a row of bits-wide pixels, most significant
+// bits first.
+func packed(row []byte, x, bits int) byte {
+	bit := x * bits
+	shift := 8 - bits - bit%8
+	return row[bit/8] >> shift & (1<<bits - 1)
+}
+
+// rgb565 expands a 5-6-5 bit color to 8 bits per channel.
+func rgb565(v uint16) color.RGBA {
+	return rgb(scale(uint32(v>>11), 5), scale(uint32(v>>5&0x3f), 6), scale(uint32(v&0x1f), 5))
+}
+
+// scale expands a bits-wide channel value to 8 bits.
+func scale(v uint32, bits int) byte {
+	return byte(v * 255 / (1<<bits - 1))
+}
+
+func gray(v byte) color.RGBA {
+	return color.RGBA{v, v, v, 0xff}
+}
+
+func rgb(r, g, b byte) color.RGBA {
+	return color.RGBA{r, g, b, 0xff}
+}
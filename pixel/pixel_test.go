@@ -0,0 +1,150 @@
+package pixel
+
+import (
+	"errors"
+	"image/color"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		format string
+		data   []byte
+		width  int
+		want   []color.RGBA // first row
+	}{
+		{"gray1", []byte{0xa0}, 3, []color.RGBA{{255, 255, 255, 255}, {0, 0, 0, 255}, {255, 255, 255, 255}}},
+		{"gray2", []byte{0xe4}, 4, []color.RGBA{{255, 255, 255, 255}, {170, 170, 170, 255}, {85, 85, 85, 255}, {0, 0, 0, 255}}},
+		{"gray4", []byte{0xf0}, 2, []color.RGBA{{255, 255, 255, 255}, {0, 0, 0, 255}}},
+		{"grayscale", []byte{0x80}, 1, []color.RGBA{{128, 128, 128, 255}}},
+		{"rgb332", []byte{0xe0, 0x03}, 2, []color.RGBA{{255, 0, 0, 255}, {0, 0, 255, 255}}},
+		{"rgb565", []byte{0x00, 0xf8, 0xe0, 0x07}, 2, []color.RGBA{{255, 0, 0, 255}, {0, 255, 0, 255}}},
+		{"RGB565BE", []byte{0x00, 0x1f}, 1, []color.RGBA{{0, 0, 255, 255}}},
+		{"bgr565", []byte{0x00, 0xf8}, 1, []color.RGBA{{0, 0, 255, 255}}},
+		{"rgb888", []byte{1, 2, 3}, 1, []color.RGBA{{1, 2, 3, 255}}},
+		{"bgr888", []byte{1, 2, 3}, 1, []color.RGBA{{3, 2, 1, 255}}},
+		{"rgba8888", []byte{1, 2, 3, 0}, 1, []color.RGBA{{1, 2, 3, 255}}},
+		{"bgra8888", []byte{1, 2, 3, 4}, 1, []color.RGBA{{3, 2, 1, 255}}},
+		// White and black sharing the chroma of gray, then red
+		{"yuyv", []byte{235, 128, 16, 128, 81, 90, 81, 240}, 4, []color.RGBA{white, black, red, red}},
+		{"uyvy", []byte{128, 235, 128, 16}, 2, []color.RGBA{white, black}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			f, err := ParseFormat(tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			img, err := Decode(tt.data, f, tt.width, 0)
+			if err != nil {
+				t.Fatalf("Decode() error: %v", err)
+			}
+			for x, want := range tt.want {
+				if got := img.RGBAAt(x, 0); got != want {
+					t.Errorf("pixel %d = %v, want %v", x, got, want)
+				}
+			}
+		})
+	}
+}
+
+var (
+	white = color.RGBA{255, 255, 255, 255}
+	black = color.RGBA{0, 0, 0, 255}
+	red   = color.RGBA{255, 0, 0, 255}
+)
+
+func TestDecodeCamera(t *testing.T) {
+	tests := []struct {
+		format string
+		data   []byte
+		width  int
+		want   [][]color.RGBA // rows
+	}{
+		// 2x2 luma (white, black / black, white), one red chroma pair
+		{"nv12", []byte{235, 16, 16, 235, 90, 240}, 2, [][]color.RGBA{
+			{{255, 179, 178, 255}, {179, 0, 0, 255}},
+			{{179, 0, 0, 255}, {255, 179, 178, 255}},
+		}},
+		{"nv21", []byte{81, 81, 81, 81, 240, 90}, 2, [][]color.RGBA{{red, red}, {red, red}}},
+		{"rggb", []byte{200, 100, 50, 10}, 2, [][]color.RGBA{{{200, 75, 10, 255}, {200, 75, 10, 255}}, {{200, 75, 10, 255}, {200, 75, 10, 255}}}},
+		{"bayer_bggr", []byte{10, 100, 50, 200}, 2, [][]color.RGBA{{{200, 75, 10, 255}, {200, 75, 10, 255}}}},
+		{"grbg", []byte{100, 200, 10, 50, 0, 0}, 2, [][]color.RGBA{{{200, 75, 10, 255}, {200, 75, 10, 255}}}},
+		{"gbrg", []byte{100, 10, 200, 50}, 2, [][]color.RGBA{{{200, 75, 10, 255}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			f, err := ParseFormat(tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			img, err := Decode(tt.data, f, tt.width, 0)
+			if err != nil {
+				t.Fatalf("Decode() error: %v", err)
+			}
+			if h := img.Bounds().Dy(); h != 2 {
+				t.Errorf("height = %d, want 2", h)
+			}
+			for y, row := range tt.want {
+				for x, want := range row {
+					if got := img.RGBAAt(x, y); got != want {
+						t.Errorf("pixel (%d,%d) = %v, want %v", x, y, got, want)
+					}
+				}
+			}
+		})
+	}
+
+	nv12, _ := ParseFormat("nv12")
+	if got := nv12.Size(4, 4, 0); got != 24 {
+		t.Errorf("nv12 Size(4, 4) = %d, want 24", got)
+	}
+	// 2 luma rows need a chroma row; 5 rows of 4 bytes hold 2 luma rows only
+	if img, err := Decode(make([]byte, 20), nv12, 4, 0); err != nil || img.Bounds().Dy() != 2 {
+		t.Errorf("nv12 height = %v, %v, want 2", img.Bounds(), err)
+	}
+	if _, err := Decode(make([]byte, 8), nv12, 4, 0); !errors.Is(err, ErrInvalidGeometry) {
+		t.Errorf("nv12 without chroma: error = %v, want %v", err, ErrInvalidGeometry)
+	}
+	yuyv, _ := ParseFormat("yuv422")
+	if _, err := Decode(make([]byte, 6), yuyv, 3, 0); !errors.Is(err, ErrInvalidGeometry) {
+		t.Errorf("yuyv odd width: error = %v, want %v", err, ErrInvalidGeometry)
+	}
+}
+
+func TestDecodeGeometry(t *testing.T) {
+	f, _ := ParseFormat("gray8")
+
+	// Two rows of 2 pixels with a stride of 3, the last row incomplete
+	img, err := Decode([]byte{1, 2, 0xee, 3, 4, 0xee, 5}, f, 2, 3)
+	if err != nil {
+		t.Fatalf("Decode() error: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 2 || b.Dy() != 2 {
+		t.Fatalf("bounds = %v, want 2x2", b)
+	}
+	if got := img.RGBAAt(1, 1).R; got != 4 {
+		t.Errorf("pixel (1,1) = %d, want 4", got)
+	}
+
+	// The last row does not need its padding
+	if img, err := Decode([]byte{1, 2, 0xee, 3, 4}, f, 2, 3); err != nil || img.Bounds().Dy() != 2 {
+		t.Errorf("Decode() without trailing padding = %v, %v", img.Bounds(), err)
+	}
+
+	for _, tt := range []struct {
+		width, stride int
+		data          []byte
+	}{
+		{0, 0, []byte{1}},
+		{4, 2, []byte{1, 2, 3, 4}},
+		{4, 0, []byte{1, 2}},
+	} {
+		if _, err := Decode(tt.data, f, tt.width, tt.stride); !errors.Is(err, ErrInvalidGeometry) {
+			t.Errorf("Decode(width %d, stride %d) error = %v, want %v", tt.width, tt.stride, err, ErrInvalidGeometry)
+		}
+	}
+	if _, err := ParseFormat("yuv420p"); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("ParseFormat(yuv420p) error = %v, want %v", err, ErrUnknownFormat)
+	}
+}
@@ -0,0 +1,368 @@
+// Package profinet decodes PROFINET real-time frames from captured bytes.
+//
+// It accepts Ethernet frames with EtherType 0x8892, with or without a VLAN
+// tag, and bare PROFINET payloads starting at the FrameID. The Ethernet FCS
+// must not be part of the data, as in most capture exports. Decoded are:
+//   - cyclic RT_CLASS_1, 2 and 3 frames: FrameID, I/O data and the APDU
+//     status (cycle counter, data status, transfer status)
+//   - DCP frames, e.g. identify requests and responses, with their blocks
+//
+// Other frames, e.g. alarms and PTCP, are reported with their FrameID only.
+//
+// Example usage:
+//
+//	frame, _ := profinet.Parse(data)
+//	if frame.Cyclic != nil {
+//		fmt.Println(frame.FrameID, frame.Cyclic.CycleCounter)
+//	}
+package profinet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// EtherType is the EtherType of PROFINET real-time frames.
+const EtherType = 0x8892
+
+// etherTypeVLAN is the EtherType of an IEEE 802.1Q tag.
+const etherTypeVLAN = 0x8100
+
+// ErrTruncated indicates data ending within a header or block
+var ErrTruncated = errors.New("truncated PROFINET frame")
+
+// Frame is a decoded PROFINET frame. Offsets are relative to the input.
+type Frame struct {
+	Ethernet      bool   // the input starts with an Ethernet header
+	Destination   string // MAC addresses, empty without Ethernet header
+	Source        string
+	VLAN          int // VLAN ID, -1 without VLAN tag
+	Priority      int // 802.1Q priority code point
+	PayloadOffset int // offset of the FrameID
+	FrameID       uint16
+	Kind          string  // e.g. "RT_CLASS_1" or "DCP Identify request", see FrameKind
+	Cyclic        *Cyclic // cyclic data frames only
+	DCP           *DCP    // DCP frames only
+}
+
+// Cyclic holds the I/O data and APDU status of a cyclic RT frame.
+type Cyclic struct {
+	DataOffset     int
+	DataLength     int
+	CycleCounter   uint16
+	DataStatus     byte
+	TransferStatus byte
+	StatusOffset   int // offset of the cycle counter
+}
+
+// DataStatus bits of the APDU status
+const (
+	StatusPrimary    = 1 << 0 // 1: primary, 0: backup
+	StatusRedundancy = 1 << 1
+	StatusDataValid  = 1 << 2
+	StatusRun        = 1 << 4 // provider state, 0: stop
+	StatusNoProblem  = 1 << 5 // station problem indicator, 0: problem detected
+	StatusIgnore     = 1 << 7
+)
+
+// DataStatusText describes the bits of a data status byte, e.g.
+// "primary, data valid, run, no problem".
+func DataStatusText(s byte) string {
+	var parts []string
+	parts = append(parts, pick(s&StatusPrimary != 0, "primary", "backup"))
+	if s&StatusRedundancy != 0 {
+		parts = append(parts, "redundancy")
+	}
+	parts = append(parts, pick(s&StatusDataValid != 0, "data valid", "data invalid"))
+	parts = append(parts, pick(s&StatusRun != 0, "run", "stop"))
+	parts = append(parts, pick(s&StatusNoProblem != 0, "no problem", "station problem"))
+	if s&StatusIgnore != 0 {
+		parts = append(parts, "ignore")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// DCP is a Discovery and Configuration Protocol PDU.
+type DCP struct {
+	ServiceID     byte
+	ServiceType   byte
+	Xid           uint32
+	ResponseDelay uint16 // identify requests only; reserved otherwise
+	DataLength    uint16
+	Blocks        []Block
+}
+
+// Service describes the service ID and type, e.g. "Identify response".
+func (d *DCP) Service() string {
+	name, ok := dcpServices[d.ServiceID]
+	if !ok {
+		name = fmt.Sprintf("service %d", d.ServiceID)
+	}
+	switch d.ServiceType {
+	case 0:
+		return name + " request"
+	case 1:
+		return name + " response"
+	case 5:
+		return name + " response (not supported)"
+	default:
+		return fmt.Sprintf("%s type %d", name, d.ServiceType)
+	}
+}
+
+// Block is a DCP option block.
+type Block struct {
+	Offset    int
+	Length    int // including the 4-byte header and padding
+	Option    byte
+	Suboption byte
+	Name      string // e.g. "NameOfStation"
+	BlockInfo *uint16
+	Value     string
+}
+
+// dcpServices maps DCP service IDs to names.
+var dcpServices = map[byte]string{3: "Get", 4: "Set", 5: "Identify", 6: "Hello"}
+
+// dcpOptions maps DCP option and suboption to block names.
+var dcpOptions = map[[2]byte]string{
+	{1, 1}:       "MAC address",
+	{1, 2}:       "IP parameter",
+	{1, 3}:       "Full IP suite",
+	{2, 1}:       "Type of station",
+	{2, 2}:       "NameOfStation",
+	{2, 3}:       "Device ID",
+	{2, 4}:       "Device role",
+	{2, 5}:       "Device options",
+	{2, 6}:       "Alias name",
+	{2, 7}:       "Device instance",
+	{2, 8}:       "OEM device ID",
+	{3, 12}:      "DHCP client identifier",
+	{5, 1}:       "Start transaction",
+	{5, 2}:       "End transaction",
+	{5, 3}:       "Signal",
+	{5, 4}:       "Response",
+	{5, 5}:       "Reset factory settings",
+	{5, 6}:       "Reset to factory",
+	{6, 1}:       "Device initiative",
+	{0xFF, 0xFF}: "All selector",
+}
+
+// FrameKind describes the frame type selected by a FrameID.
+func FrameKind(id uint16) string {
+	switch {
+	case id == 0x0020 || id == 0x0021 || id == 0x0080 || id == 0x0081:
+		return "PTCP RTSync"
+	case id >= 0x0100 && id <= 0x0FFF:
+		return "RT_CLASS_3"
+	case id >= 0x8000 && id <= 0xBBFF:
+		return "RT_CLASS_2"
+	case id >= 0xC000 && id <= 0xF7FF:
+		return "RT_CLASS_1"
+	case id >= 0xBC00 && id <= 0xBFFF, id >= 0xF800 && id <= 0xFBFF:
+		return "RT_CLASS_UDP"
+	case id == 0xFC01:
+		return "Alarm high"
+	case id == 0xFE01:
+		return "Alarm low"
+	case id == 0xFEFC:
+		return "DCP Hello"
+	case id == 0xFEFD:
+		return "DCP Get/Set"
+	case id == 0xFEFE:
+		return "DCP Identify request"
+	case id == 0xFEFF:
+		return "DCP Identify response"
+	case id >= 0xFF00 && id <= 0xFF43:
+		return "PTCP"
+	default:
+		return "reserved"
+	}
+}
+
+// isCyclic reports whether id selects a cyclic data frame.
+func isCyclic(id uint16) bool {
+	return id >= 0x0100 && id <= 0x0FFF || id >= 0x8000 && id <= 0xFBFF
+}
+
+// isDCP reports whether id selects a DCP frame.
+func isDCP(id uint16) bool {
+	return id >= 0xFEFC && id <= 0xFEFF
+}
+
+// IsFrame reports whether data is an Ethernet frame carrying PROFINET.
+func IsFrame(data []byte) bool {
+	_, ok := etherPayload(data)
+	return ok
+}
+
+// etherPayload returns the offset of the PROFINET payload of an Ethernet
+// frame, skipping a VLAN tag.
+func etherPayload(data []byte) (int, bool) {
+	if len(data) < 16 {
+		return 0, false
+	}
+	etherType := binary.BigEndian.Uint16(data[12:])
+	if etherType == etherTypeVLAN && len(data) >= 20 {
+		return 18, binary.BigEndian.Uint16(data[16:]) == EtherType
+	}
+	return 14, etherType == EtherType
+}
+
+// Parse decodes an Ethernet frame with EtherType 0x8892 or, if data is not
+// an Ethernet frame, a bare PROFINET payload starting at the FrameID.
+func Parse(data []byte) (*Frame, error) {
+	f := &Frame{VLAN: -1}
+	if offset, ok := etherPayload(data); ok {
+		f.Ethernet = true
+		f.Destination = net.HardwareAddr(data[0:6]).String()
+		f.Source = net.HardwareAddr(data[6:12]).String()
+		if offset == 18 {
+			tci := binary.BigEndian.Uint16(data[14:])
+			f.VLAN = int(tci & 0x0FFF)
+			f.Priority = int(tci >> 13)
+		}
+		f.PayloadOffset = offset
+	}
+
+	payload := data[f.PayloadOffset:]
+	if len(payload) < 2 {
+		return nil, fmt.Errorf("%w: no FrameID", ErrTruncated)
+	}
+	f.FrameID = binary.BigEndian.Uint16(payload)
+	f.Kind = FrameKind(f.FrameID)
+
+	var err error
+	switch {
+	case isCyclic(f.FrameID):
+		f.Cyclic, err = parseCyclic(payload, f.PayloadOffset)
+	case isDCP(f.FrameID):
+		f.DCP, err = parseDCP(payload, f.PayloadOffset)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// parseCyclic decodes the I/O data and the APDU status at the end of a
+// cyclic frame payload.
+func parseCyclic(payload []byte, base int) (*Cyclic, error) {
+	if len(payload) < 6 {
+		return nil, fmt.Errorf("%w: %d bytes, need at least 6 for FrameID and APDU status", ErrTruncated, len(payload))
+	}
+	status := len(payload) - 4
+	return &Cyclic{
+		DataOffset:     base + 2,
+		DataLength:     status - 2,
+		CycleCounter:   binary.BigEndian.Uint16(payload[status:]),
+		DataStatus:     payload[status+2],
+		TransferStatus: payload[status+3],
+		StatusOffset:   base + status,
+	}, nil
+}
+
+// parseDCP decodes the DCP header and blocks following the FrameID.
+func parseDCP(payload []byte, base int) (*DCP, error) {
+	if len(payload) < 12 {
+		return nil, fmt.Errorf("%w: %d bytes, need 12 for the DCP header", ErrTruncated, len(payload))
+	}
+	d := &DCP{
+		ServiceID:     payload[2],
+		ServiceType:   payload[3],
+		Xid:           binary.BigEndian.Uint32(payload[4:]),
+		ResponseDelay: binary.BigEndian.Uint16(payload[8:]),
+		DataLength:    binary.BigEndian.Uint16(payload[10:]),
+	}
+	end := 12 + int(d.DataLength)
+	if end > len(payload) {
+		return nil, fmt.Errorf("%w: DCP data length %d, have %d bytes", ErrTruncated, d.DataLength, len(payload)-12)
+	}
+
+	// Blocks of responses, Set and Hello requests carry BlockInfo or
+	// BlockQualifier before the value; Identify and Get requests do not.
+	qualified := d.ServiceType != 0 || d.ServiceID == 4 || d.ServiceID == 6
+	for pos := 12; pos < end; {
+		if end-pos < 4 {
+			return nil, fmt.Errorf("%w: block header at offset %d", ErrTruncated, base+pos)
+		}
+		b := Block{Offset: base + pos, Option: payload[pos], Suboption: payload[pos+1]}
+		length := int(binary.BigEndian.Uint16(payload[pos+2:]))
+		if pos+4+length > end {
+			return nil, fmt.Errorf("%w: block at offset %d has length %d", ErrTruncated, base+pos, length)
+		}
+		value := payload[pos+4 : pos+4+length]
+		if qualified && len(value) >= 2 && !(b.Option == 0xFF && b.Suboption == 0xFF) {
+			info := binary.BigEndian.Uint16(value)
+			b.BlockInfo = &info
+			value = value[2:]
+		}
+		b.Name = dcpOptions[[2]byte{b.Option, b.Suboption}]
+		if b.Name == "" {
+			b.Name = fmt.Sprintf("option %d/%d", b.Option, b.Suboption)
+		}
+		b.Value = blockValue(b.Option, b.Suboption, value)
+		b.Length = 4 + length + length%2
+		pos += b.Length
+		d.Blocks = append(d.Blocks, b)
+	}
+	return d, nil
+}
+
+// blockValue formats the value of a DCP block.
+func blockValue(option, suboption byte, v []byte) string {
+	switch [2]byte{option, suboption} {
+	case [2]byte{1, 1}:
+		if len(v) == 6 {
+			return net.HardwareAddr(v).String()
+		}
+	case [2]byte{1, 2}, [2]byte{1, 3}:
+		if len(v) >= 12 {
+			s := fmt.Sprintf("ip %s, mask %s, gateway %s", net.IP(v[0:4]), net.IP(v[4:8]), net.IP(v[8:12]))
+			for i := 12; i+4 <= len(v) && i < 28; i += 4 {
+				s += fmt.Sprintf(", dns %s", net.IP(v[i:i+4]))
+			}
+			return s
+		}
+	case [2]byte{2, 1}, [2]byte{2, 2}, [2]byte{2, 6}:
+		return string(v)
+	case [2]byte{2, 3}, [2]byte{2, 8}:
+		if len(v) == 4 {
+			return fmt.Sprintf("vendor 0x%04x, device 0x%04x", binary.BigEndian.Uint16(v), binary.BigEndian.Uint16(v[2:]))
+		}
+	case [2]byte{2, 4}:
+		if len(v) >= 1 {
+			var roles []string
+			for bit, name := range []string{"IO-Device", "IO-Controller", "IO-Multidevice", "PN-Supervisor"} {
+				if v[0]&(1<<bit) != 0 {
+					roles = append(roles, name)
+				}
+			}
+			return strings.Join(roles, ", ")
+		}
+	case [2]byte{2, 5}:
+		var opts []string
+		for i := 0; i+1 < len(v); i += 2 {
+			opts = append(opts, fmt.Sprintf("%d/%d", v[i], v[i+1]))
+		}
+		return strings.Join(opts, " ")
+	case [2]byte{2, 7}:
+		if len(v) == 2 {
+			return fmt.Sprintf("%d.%d", v[0], v[1])
+		}
+	case [2]byte{0xFF, 0xFF}:
+		return ""
+	}
+	return fmt.Sprintf("%x", v)
+}
+
+// pick returns a if cond is true, b otherwise.
+func pick(cond bool, a, b string) string {
+	if cond {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,103 @@
+package profinet
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	data, err := hex.DecodeString(strings.ReplaceAll(s, " ", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestParse_Cyclic(t *testing.T) {
+	data := mustHex(t, "010ecf000000 001122334455 8100 c000 8892 c001 01020304 1234 35 00")
+	if !IsFrame(data) {
+		t.Fatal("IsFrame() = false")
+	}
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if f.Destination != "01:0e:cf:00:00:00" || f.VLAN != 0 || f.Priority != 6 || f.PayloadOffset != 18 {
+		t.Errorf("Ethernet header = %+v", f)
+	}
+	if f.FrameID != 0xc001 || f.Kind != "RT_CLASS_1" || f.Cyclic == nil {
+		t.Fatalf("Parse() = %+v", f)
+	}
+	c := f.Cyclic
+	if c.DataOffset != 20 || c.DataLength != 4 || c.CycleCounter != 0x1234 || c.DataStatus != 0x35 || c.StatusOffset != 24 {
+		t.Errorf("Cyclic = %+v", c)
+	}
+	if got := DataStatusText(c.DataStatus); got != "primary, data valid, run, no problem" {
+		t.Errorf("DataStatusText(0x35) = %q", got)
+	}
+	if got := DataStatusText(0x80); got != "backup, data invalid, stop, station problem, ignore" {
+		t.Errorf("DataStatusText(0x80) = %q", got)
+	}
+}
+
+func TestParse_Payload(t *testing.T) {
+	f, err := Parse(mustHex(t, "8001 aabb 0001 04 00"))
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if f.Ethernet || f.Kind != "RT_CLASS_2" || f.Cyclic.DataLength != 2 || f.Cyclic.DataStatus != StatusDataValid {
+		t.Errorf("Parse() = %+v %+v", f, f.Cyclic)
+	}
+
+	if f, err := Parse(mustHex(t, "fc01 0000")); err != nil || f.Kind != "Alarm high" {
+		t.Errorf("Parse(alarm) = %+v, %v", f, err)
+	}
+	for _, s := range []string{"c0", "c001 0102", "fefe 0500 00000001 0080 0008 ffff0000"} {
+		if _, err := Parse(mustHex(t, s)); !errors.Is(err, ErrTruncated) {
+			t.Errorf("Parse(%s) error = %v, want ErrTruncated", s, err)
+		}
+	}
+}
+
+func TestParse_DCPIdentify(t *testing.T) {
+	request := mustHex(t, "010ecf000000 001122334455 8892 fefe 05 00 00000001 0080 0004 ffff0000 000000")
+	f, err := Parse(request)
+	if err != nil {
+		t.Fatalf("Parse(request) error: %v", err)
+	}
+	if f.Kind != "DCP Identify request" || f.DCP.Service() != "Identify request" || f.DCP.ResponseDelay != 0x80 {
+		t.Errorf("request = %+v %+v", f, f.DCP)
+	}
+	if len(f.DCP.Blocks) != 1 || f.DCP.Blocks[0].Name != "All selector" || f.DCP.Blocks[0].BlockInfo != nil {
+		t.Errorf("request blocks = %+v", f.DCP.Blocks)
+	}
+
+	response := mustHex(t, "001122334455 0a0b0c0d0e0f 8892 feff 05 01 00000001 0000 0028"+
+		"0202 0007 0000 706c632d31 00"+
+		"0203 0006 0000 002a 0105"+
+		"0102 000e 0001 c0a8000a ffffff00 c0a80001")
+	f, err = Parse(response)
+	if err != nil {
+		t.Fatalf("Parse(response) error: %v", err)
+	}
+	want := []struct{ name, value string }{
+		{"NameOfStation", "plc-1"},
+		{"Device ID", "vendor 0x002a, device 0x0105"},
+		{"IP parameter", "ip 192.168.0.10, mask 255.255.255.0, gateway 192.168.0.1"},
+	}
+	if f.DCP.Service() != "Identify response" || len(f.DCP.Blocks) != len(want) {
+		t.Fatalf("response = %+v", f.DCP)
+	}
+	for i, w := range want {
+		b := f.DCP.Blocks[i]
+		if b.Name != w.name || b.Value != w.value || b.BlockInfo == nil {
+			t.Errorf("block %d = %+v, want %s %q", i, b, w.name, w.value)
+		}
+	}
+	if b := f.DCP.Blocks[1]; b.Offset != 38 || b.Length != 10 {
+		t.Errorf("block 1 offset %d length %d, want 38 10", b.Offset, b.Length)
+	}
+}
@@ -0,0 +1,181 @@
+package rpc
+
+import (
+	"hexview/models"
+	"hexview/rpc/hexviewpb"
+)
+
+// widen copies an optional integer into a wider proto field type.
+func widen[To, From int32 | int64 | uint32 | uint64 | int8 | int16 | uint8 | uint16](v *From) *To {
+	if v == nil {
+		return nil
+	}
+	w := To(*v)
+	return &w
+}
+
+// conversionToProto converts a ConversionResult to its proto message.
+func conversionToProto(r *models.ConversionResult) *hexviewpb.ConversionResult {
+	return &hexviewpb.ConversionResult{
+		Int8Be:     widen[int32](r.Int8BE),
+		Int8BeHex:  r.Int8BEHex,
+		Int16Be:    widen[int32](r.Int16BE),
+		Int16BeHex: r.Int16BEHex,
+		Int32Be:    r.Int32BE,
+		Int32BeHex: r.Int32BEHex,
+		Int64Be:    r.Int64BE,
+		Int64BeHex: r.Int64BEHex,
+
+		Int16Le:    widen[int32](r.Int16LE),
+		Int16LeHex: r.Int16LEHex,
+		Int32Le:    r.Int32LE,
+		Int32LeHex: r.Int32LEHex,
+		Int64Le:    r.Int64LE,
+		Int64LeHex: r.Int64LEHex,
+
+		Int16Badc:    widen[int32](r.Int16BADC),
+		Int16BadcHex: r.Int16BADCHex,
+		Int32Badc:    r.Int32BADC,
+		Int32BadcHex: r.Int32BADCHex,
+		Int64Badc:    r.Int64BADC,
+		Int64BadcHex: r.Int64BADCHex,
+
+		Int16Cdab:    widen[int32](r.Int16CDAB),
+		Int16CdabHex: r.Int16CDABHex,
+		Int32Cdab:    r.Int32CDAB,
+		Int32CdabHex: r.Int32CDABHex,
+		Int64Cdab:    r.Int64CDAB,
+		Int64CdabHex: r.Int64CDABHex,
+
+		Uint8Be:     widen[uint32](r.Uint8BE),
+		Uint8BeHex:  r.Uint8BEHex,
+		Uint16Be:    widen[uint32](r.Uint16BE),
+		Uint16BeHex: r.Uint16BEHex,
+		Uint32Be:    r.Uint32BE,
+		Uint32BeHex: r.Uint32BEHex,
+		Uint64Be:    r.Uint64BE,
+		Uint64BeHex: r.Uint64BEHex,
+
+		Uint16Le:    widen[uint32](r.Uint16LE),
+		Uint16LeHex: r.Uint16LEHex,
+		Uint32Le:    r.Uint32LE,
+		Uint32LeHex: r.Uint32LEHex,
+		Uint64Le:    r.Uint64LE,
+		Uint64LeHex: r.Uint64LEHex,
+
+		Uint16Badc:    widen[uint32](r.Uint16BADC),
+		Uint16BadcHex: r.Uint16BADCHex,
+		Uint32Badc:    r.Uint32BADC,
+		Uint32BadcHex: r.Uint32BADCHex,
+		Uint64Badc:    r.Uint64BADC,
+		Uint64BadcHex: r.Uint64BADCHex,
+
+		Uint16Cdab:    widen[uint32](r.Uint16CDAB),
+		Uint16CdabHex: r.Uint16CDABHex,
+		Uint32Cdab:    r.Uint32CDAB,
+		Uint32CdabHex: r.Uint32CDABHex,
+		Uint64Cdab:    r.Uint64CDAB,
+		Uint64CdabHex: r.Uint64CDABHex,
+
+		Float32Be:      r.Float32BE,
+		Float32BeHex:   r.Float32BEHex,
+		Float64Be:      r.Float64BE,
+		Float64BeHex:   r.Float64BEHex,
+		Float32Le:      r.Float32LE,
+		Float32LeHex:   r.Float32LEHex,
+		Float64Le:      r.Float64LE,
+		Float64LeHex:   r.Float64LEHex,
+		Float32Badc:    r.Float32BADC,
+		Float32BadcHex: r.Float32BADCHex,
+		Float64Badc:    r.Float64BADC,
+		Float64BadcHex: r.Float64BADCHex,
+		Float32Cdab:    r.Float32CDAB,
+		Float32CdabHex: r.Float32CDABHex,
+		Float64Cdab:    r.Float64CDAB,
+		Float64CdabHex: r.Float64CDABHex,
+
+		Binary: r.Binary,
+		Bytes:  r.Bytes,
+		Ascii:  r.ASCII,
+	}
+}
+
+// modbusToProto converts a ModbusResult to its proto message.
+func modbusToProto(r *models.ModbusResult) *hexviewpb.ModbusResult {
+	out := &hexviewpb.ModbusResult{RawHex: r.RawHex, Ascii: r.ASCII}
+	for _, reg := range r.Registers {
+		out.Registers = append(out.Registers, &hexviewpb.ModbusRegister{
+			Index:    int32(reg.Index),
+			Hex:      reg.Hex,
+			Unsigned: uint32(reg.Unsigned),
+			Signed:   int32(reg.Signed),
+			Binary:   reg.Binary,
+		})
+	}
+	for _, c := range r.Combined32 {
+		out.Combined32 = append(out.Combined32, &hexviewpb.ModbusCombined32{
+			RegisterStart: int32(c.RegisterStart),
+			Hex:           c.Hex,
+			Uint32Be:      c.Uint32BE,
+			Uint32Le:      c.Uint32LE,
+			Uint32Badc:    c.Uint32BADC,
+			Uint32Cdab:    c.Uint32CDAB,
+			Int32Be:       c.Int32BE,
+			Int32Le:       c.Int32LE,
+			Int32Badc:     c.Int32BADC,
+			Int32Cdab:     c.Int32CDAB,
+			Float32Be:     c.Float32BE,
+			Float32Le:     c.Float32LE,
+			Float32Badc:   c.Float32BADC,
+			Float32Cdab:   c.Float32CDAB,
+		})
+	}
+	for _, c := range r.Combined64 {
+		out.Combined64 = append(out.Combined64, &hexviewpb.ModbusCombined64{
+			RegisterStart: int32(c.RegisterStart),
+			Hex:           c.Hex,
+			Uint64Be:      c.Uint64BE,
+			Uint64Le:      c.Uint64LE,
+			Int64Be:       c.Int64BE,
+			Int64Le:       c.Int64LE,
+			Float64Be:     c.Float64BE,
+			Float64Le:     c.Float64LE,
+		})
+	}
+	return out
+}
+
+// checksumToProto converts a ChecksumResult to its proto message.
+func checksumToProto(r *models.ChecksumResult) *hexviewpb.ChecksumResult {
+	out := &hexviewpb.ChecksumResult{Length: int32(r.Length)}
+	for _, cs := range r.Checksums {
+		out.Checksums = append(out.Checksums, &hexviewpb.ChecksumValue{
+			Name:  cs.Name,
+			Width: int32(cs.Width),
+			Hex:   cs.Hex,
+			Value: cs.Value,
+		})
+	}
+	return out
+}
+
+// diffToProto converts a DiffResult to its proto message.
+func diffToProto(r *models.DiffResult) *hexviewpb.DiffResult {
+	out := &hexviewpb.DiffResult{
+		LengthA:       int32(r.LengthA),
+		LengthB:       int32(r.LengthB),
+		Equal:         r.Equal,
+		DiffBytes:     int32(r.DiffBytes),
+		FirstDiff:     r.FirstDiff,
+		RangesTrimmed: r.RangesTrimmed,
+	}
+	for _, d := range r.Ranges {
+		out.Ranges = append(out.Ranges, &hexviewpb.DiffRange{
+			Offset: d.Offset,
+			Length: int32(d.Length),
+			A:      d.A,
+			B:      d.B,
+		})
+	}
+	return out
+}
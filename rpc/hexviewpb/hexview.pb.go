@@ -0,0 +1,1746 @@
+// gRPC interface of the hexview conversion engine.
+//
+// Messages mirror the JSON models returned by the GUI and the REST API.
+// Integer results are optional because only the types that fit the input are
+// populated; floats are strings so NaN and Inf survive the round trip.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: hexview.proto
+
+package hexviewpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ConvertRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Input string                 `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
+	// Integer or float type for ConvertInt and ConvertFloat, e.g. "uint16".
+	Type          string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertRequest) Reset() {
+	*x = ConvertRequest{}
+	mi := &file_hexview_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertRequest) ProtoMessage() {}
+
+func (x *ConvertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hexview_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertRequest.ProtoReflect.Descriptor instead.
+func (*ConvertRequest) Descriptor() ([]byte, []int) {
+	return file_hexview_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConvertRequest) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type DiffRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	A             string                 `protobuf:"bytes,1,opt,name=a,proto3" json:"a,omitempty"`
+	B             string                 `protobuf:"bytes,2,opt,name=b,proto3" json:"b,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffRequest) Reset() {
+	*x = DiffRequest{}
+	mi := &file_hexview_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffRequest) ProtoMessage() {}
+
+func (x *DiffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hexview_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffRequest.ProtoReflect.Descriptor instead.
+func (*DiffRequest) Descriptor() ([]byte, []int) {
+	return file_hexview_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DiffRequest) GetA() string {
+	if x != nil {
+		return x.A
+	}
+	return ""
+}
+
+func (x *DiffRequest) GetB() string {
+	if x != nil {
+		return x.B
+	}
+	return ""
+}
+
+type ConversionResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Signed integers - big endian
+	Int8Be     *int32 `protobuf:"zigzag32,1,opt,name=int8_be,json=int8Be,proto3,oneof" json:"int8_be,omitempty"`
+	Int8BeHex  string `protobuf:"bytes,2,opt,name=int8_be_hex,json=int8BeHex,proto3" json:"int8_be_hex,omitempty"`
+	Int16Be    *int32 `protobuf:"zigzag32,3,opt,name=int16_be,json=int16Be,proto3,oneof" json:"int16_be,omitempty"`
+	Int16BeHex string `protobuf:"bytes,4,opt,name=int16_be_hex,json=int16BeHex,proto3" json:"int16_be_hex,omitempty"`
+	Int32Be    *int32 `protobuf:"zigzag32,5,opt,name=int32_be,json=int32Be,proto3,oneof" json:"int32_be,omitempty"`
+	Int32BeHex string `protobuf:"bytes,6,opt,name=int32_be_hex,json=int32BeHex,proto3" json:"int32_be_hex,omitempty"`
+	Int64Be    *int64 `protobuf:"zigzag64,7,opt,name=int64_be,json=int64Be,proto3,oneof" json:"int64_be,omitempty"`
+	Int64BeHex string `protobuf:"bytes,8,opt,name=int64_be_hex,json=int64BeHex,proto3" json:"int64_be_hex,omitempty"`
+	// Signed integers - little endian
+	Int16Le    *int32 `protobuf:"zigzag32,9,opt,name=int16_le,json=int16Le,proto3,oneof" json:"int16_le,omitempty"`
+	Int16LeHex string `protobuf:"bytes,10,opt,name=int16_le_hex,json=int16LeHex,proto3" json:"int16_le_hex,omitempty"`
+	Int32Le    *int32 `protobuf:"zigzag32,11,opt,name=int32_le,json=int32Le,proto3,oneof" json:"int32_le,omitempty"`
+	Int32LeHex string `protobuf:"bytes,12,opt,name=int32_le_hex,json=int32LeHex,proto3" json:"int32_le_hex,omitempty"`
+	Int64Le    *int64 `protobuf:"zigzag64,13,opt,name=int64_le,json=int64Le,proto3,oneof" json:"int64_le,omitempty"`
+	Int64LeHex string `protobuf:"bytes,14,opt,name=int64_le_hex,json=int64LeHex,proto3" json:"int64_le_hex,omitempty"`
+	// Signed integers - mid-big endian (BADC)
+	Int16Badc    *int32 `protobuf:"zigzag32,15,opt,name=int16_badc,json=int16Badc,proto3,oneof" json:"int16_badc,omitempty"`
+	Int16BadcHex string `protobuf:"bytes,16,opt,name=int16_badc_hex,json=int16BadcHex,proto3" json:"int16_badc_hex,omitempty"`
+	Int32Badc    *int32 `protobuf:"zigzag32,17,opt,name=int32_badc,json=int32Badc,proto3,oneof" json:"int32_badc,omitempty"`
+	Int32BadcHex string `protobuf:"bytes,18,opt,name=int32_badc_hex,json=int32BadcHex,proto3" json:"int32_badc_hex,omitempty"`
+	Int64Badc    *int64 `protobuf:"zigzag64,19,opt,name=int64_badc,json=int64Badc,proto3,oneof" json:"int64_badc,omitempty"`
+	Int64BadcHex string `protobuf:"bytes,20,opt,name=int64_badc_hex,json=int64BadcHex,proto3" json:"int64_badc_hex,omitempty"`
+	// Signed integers - mid-little endian (CDAB)
+	Int16Cdab    *int32 `protobuf:"zigzag32,21,opt,name=int16_cdab,json=int16Cdab,proto3,oneof" json:"int16_cdab,omitempty"`
+	Int16CdabHex string `protobuf:"bytes,22,opt,name=int16_cdab_hex,json=int16CdabHex,proto3" json:"int16_cdab_hex,omitempty"`
+	Int32Cdab    *int32 `protobuf:"zigzag32,23,opt,name=int32_cdab,json=int32Cdab,proto3,oneof" json:"int32_cdab,omitempty"`
+	Int32CdabHex string `protobuf:"bytes,24,opt,name=int32_cdab_hex,json=int32CdabHex,proto3" json:"int32_cdab_hex,omitempty"`
+	Int64Cdab    *int64 `protobuf:"zigzag64,25,opt,name=int64_cdab,json=int64Cdab,proto3,oneof" json:"int64_cdab,omitempty"`
+	Int64CdabHex string `protobuf:"bytes,26,opt,name=int64_cdab_hex,json=int64CdabHex,proto3" json:"int64_cdab_hex,omitempty"`
+	// Unsigned integers - big endian
+	Uint8Be     *uint32 `protobuf:"varint,27,opt,name=uint8_be,json=uint8Be,proto3,oneof" json:"uint8_be,omitempty"`
+	Uint8BeHex  string  `protobuf:"bytes,28,opt,name=uint8_be_hex,json=uint8BeHex,proto3" json:"uint8_be_hex,omitempty"`
+	Uint16Be    *uint32 `protobuf:"varint,29,opt,name=uint16_be,json=uint16Be,proto3,oneof" json:"uint16_be,omitempty"`
+	Uint16BeHex string  `protobuf:"bytes,30,opt,name=uint16_be_hex,json=uint16BeHex,proto3" json:"uint16_be_hex,omitempty"`
+	Uint32Be    *uint32 `protobuf:"varint,31,opt,name=uint32_be,json=uint32Be,proto3,oneof" json:"uint32_be,omitempty"`
+	Uint32BeHex string  `protobuf:"bytes,32,opt,name=uint32_be_hex,json=uint32BeHex,proto3" json:"uint32_be_hex,omitempty"`
+	Uint64Be    *uint64 `protobuf:"varint,33,opt,name=uint64_be,json=uint64Be,proto3,oneof" json:"uint64_be,omitempty"`
+	Uint64BeHex string  `protobuf:"bytes,34,opt,name=uint64_be_hex,json=uint64BeHex,proto3" json:"uint64_be_hex,omitempty"`
+	// Unsigned integers - little endian
+	Uint16Le    *uint32 `protobuf:"varint,35,opt,name=uint16_le,json=uint16Le,proto3,oneof" json:"uint16_le,omitempty"`
+	Uint16LeHex string  `protobuf:"bytes,36,opt,name=uint16_le_hex,json=uint16LeHex,proto3" json:"uint16_le_hex,omitempty"`
+	Uint32Le    *uint32 `protobuf:"varint,37,opt,name=uint32_le,json=uint32Le,proto3,oneof" json:"uint32_le,omitempty"`
+	Uint32LeHex string  `protobuf:"bytes,38,opt,name=uint32_le_hex,json=uint32LeHex,proto3" json:"uint32_le_hex,omitempty"`
+	Uint64Le    *uint64 `protobuf:"varint,39,opt,name=uint64_le,json=uint64Le,proto3,oneof" json:"uint64_le,omitempty"`
+	Uint64LeHex string  `protobuf:"bytes,40,opt,name=uint64_le_hex,json=uint64LeHex,proto3" json:"uint64_le_hex,omitempty"`
+	// Unsigned integers - mid-big endian (BADC)
+	Uint16Badc    *uint32 `protobuf:"varint,41,opt,name=uint16_badc,json=uint16Badc,proto3,oneof" json:"uint16_badc,omitempty"`
+	Uint16BadcHex string  `protobuf:"bytes,42,opt,name=uint16_badc_hex,json=uint16BadcHex,proto3" json:"uint16_badc_hex,omitempty"`
+	Uint32Badc    *uint32 `protobuf:"varint,43,opt,name=uint32_badc,json=uint32Badc,proto3,oneof" json:"uint32_badc,omitempty"`
+	Uint32BadcHex string  `protobuf:"bytes,44,opt,name=uint32_badc_hex,json=uint32BadcHex,proto3" json:"uint32_badc_hex,omitempty"`
+	Uint64Badc    *uint64 `protobuf:"varint,45,opt,name=uint64_badc,json=uint64Badc,proto3,oneof" json:"uint64_badc,omitempty"`
+	Uint64BadcHex string  `protobuf:"bytes,46,opt,name=uint64_badc_hex,json=uint64BadcHex,proto3" json:"uint64_badc_hex,omitempty"`
+	// Unsigned integers - mid-little endian (CDAB)
+	Uint16Cdab    *uint32 `protobuf:"varint,47,opt,name=uint16_cdab,json=uint16Cdab,proto3,oneof" json:"uint16_cdab,omitempty"`
+	Uint16CdabHex string  `protobuf:"bytes,48,opt,name=uint16_cdab_hex,json=uint16CdabHex,proto3" json:"uint16_cdab_hex,omitempty"`
+	Uint32Cdab    *uint32 `protobuf:"varint,49,opt,name=uint32_cdab,json=uint32Cdab,proto3,oneof" json:"uint32_cdab,omitempty"`
+	Uint32CdabHex string  `protobuf:"bytes,50,opt,name=uint32_cdab_hex,json=uint32CdabHex,proto3" json:"uint32_cdab_hex,omitempty"`
+	Uint64Cdab    *uint64 `protobuf:"varint,51,opt,name=uint64_cdab,json=uint64Cdab,proto3,oneof" json:"uint64_cdab,omitempty"`
+	Uint64CdabHex string  `protobuf:"bytes,52,opt,name=uint64_cdab_hex,json=uint64CdabHex,proto3" json:"uint64_cdab_hex,omitempty"`
+	// Floating point
+	Float32Be      *string `protobuf:"bytes,53,opt,name=float32_be,json=float32Be,proto3,oneof" json:"float32_be,omitempty"`
+	Float32BeHex   string  `protobuf:"bytes,54,opt,name=float32_be_hex,json=float32BeHex,proto3" json:"float32_be_hex,omitempty"`
+	Float64Be      *string `protobuf:"bytes,55,opt,name=float64_be,json=float64Be,proto3,oneof" json:"float64_be,omitempty"`
+	Float64BeHex   string  `protobuf:"bytes,56,opt,name=float64_be_hex,json=float64BeHex,proto3" json:"float64_be_hex,omitempty"`
+	Float32Le      *string `protobuf:"bytes,57,opt,name=float32_le,json=float32Le,proto3,oneof" json:"float32_le,omitempty"`
+	Float32LeHex   string  `protobuf:"bytes,58,opt,name=float32_le_hex,json=float32LeHex,proto3" json:"float32_le_hex,omitempty"`
+	Float64Le      *string `protobuf:"bytes,59,opt,name=float64_le,json=float64Le,proto3,oneof" json:"float64_le,omitempty"`
+	Float64LeHex   string  `protobuf:"bytes,60,opt,name=float64_le_hex,json=float64LeHex,proto3" json:"float64_le_hex,omitempty"`
+	Float32Badc    *string `protobuf:"bytes,61,opt,name=float32_badc,json=float32Badc,proto3,oneof" json:"float32_badc,omitempty"`
+	Float32BadcHex string  `protobuf:"bytes,62,opt,name=float32_badc_hex,json=float32BadcHex,proto3" json:"float32_badc_hex,omitempty"`
+	Float64Badc    *string `protobuf:"bytes,63,opt,name=float64_badc,json=float64Badc,proto3,oneof" json:"float64_badc,omitempty"`
+	Float64BadcHex string  `protobuf:"bytes,64,opt,name=float64_badc_hex,json=float64BadcHex,proto3" json:"float64_badc_hex,omitempty"`
+	Float32Cdab    *string `protobuf:"bytes,65,opt,name=float32_cdab,json=float32Cdab,proto3,oneof" json:"float32_cdab,omitempty"`
+	Float32CdabHex string  `protobuf:"bytes,66,opt,name=float32_cdab_hex,json=float32CdabHex,proto3" json:"float32_cdab_hex,omitempty"`
+	Float64Cdab    *string `protobuf:"bytes,67,opt,name=float64_cdab,json=float64Cdab,proto3,oneof" json:"float64_cdab,omitempty"`
+	Float64CdabHex string  `protobuf:"bytes,68,opt,name=float64_cdab_hex,json=float64CdabHex,proto3" json:"float64_cdab_hex,omitempty"`
+	// Binary, hex and ASCII representations
+	Binary        string `protobuf:"bytes,69,opt,name=binary,proto3" json:"binary,omitempty"`
+	Bytes         string `protobuf:"bytes,70,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Ascii         string `protobuf:"bytes,71,opt,name=ascii,proto3" json:"ascii,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConversionResult) Reset() {
+	*x = ConversionResult{}
+	mi := &file_hexview_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConversionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConversionResult) ProtoMessage() {}
+
+func (x *ConversionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_hexview_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConversionResult.ProtoReflect.Descriptor instead.
+func (*ConversionResult) Descriptor() ([]byte, []int) {
+	return file_hexview_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ConversionResult) GetInt8Be() int32 {
+	if x != nil && x.Int8Be != nil {
+		return *x.Int8Be
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetInt8BeHex() string {
+	if x != nil {
+		return x.Int8BeHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetInt16Be() int32 {
+	if x != nil && x.Int16Be != nil {
+		return *x.Int16Be
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetInt16BeHex() string {
+	if x != nil {
+		return x.Int16BeHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetInt32Be() int32 {
+	if x != nil && x.Int32Be != nil {
+		return *x.Int32Be
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetInt32BeHex() string {
+	if x != nil {
+		return x.Int32BeHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetInt64Be() int64 {
+	if x != nil && x.Int64Be != nil {
+		return *x.Int64Be
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetInt64BeHex() string {
+	if x != nil {
+		return x.Int64BeHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetInt16Le() int32 {
+	if x != nil && x.Int16Le != nil {
+		return *x.Int16Le
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetInt16LeHex() string {
+	if x != nil {
+		return x.Int16LeHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetInt32Le() int32 {
+	if x != nil && x.Int32Le != nil {
+		return *x.Int32Le
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetInt32LeHex() string {
+	if x != nil {
+		return x.Int32LeHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetInt64Le() int64 {
+	if x != nil && x.Int64Le != nil {
+		return *x.Int64Le
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetInt64LeHex() string {
+	if x != nil {
+		return x.Int64LeHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetInt16Badc() int32 {
+	if x != nil && x.Int16Badc != nil {
+		return *x.Int16Badc
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetInt16BadcHex() string {
+	if x != nil {
+		return x.Int16BadcHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetInt32Badc() int32 {
+	if x != nil && x.Int32Badc != nil {
+		return *x.Int32Badc
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetInt32BadcHex() string {
+	if x != nil {
+		return x.Int32BadcHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetInt64Badc() int64 {
+	if x != nil && x.Int64Badc != nil {
+		return *x.Int64Badc
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetInt64BadcHex() string {
+	if x != nil {
+		return x.Int64BadcHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetInt16Cdab() int32 {
+	if x != nil && x.Int16Cdab != nil {
+		return *x.Int16Cdab
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetInt16CdabHex() string {
+	if x != nil {
+		return x.Int16CdabHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetInt32Cdab() int32 {
+	if x != nil && x.Int32Cdab != nil {
+		return *x.Int32Cdab
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetInt32CdabHex() string {
+	if x != nil {
+		return x.Int32CdabHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetInt64Cdab() int64 {
+	if x != nil && x.Int64Cdab != nil {
+		return *x.Int64Cdab
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetInt64CdabHex() string {
+	if x != nil {
+		return x.Int64CdabHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetUint8Be() uint32 {
+	if x != nil && x.Uint8Be != nil {
+		return *x.Uint8Be
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetUint8BeHex() string {
+	if x != nil {
+		return x.Uint8BeHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetUint16Be() uint32 {
+	if x != nil && x.Uint16Be != nil {
+		return *x.Uint16Be
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetUint16BeHex() string {
+	if x != nil {
+		return x.Uint16BeHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetUint32Be() uint32 {
+	if x != nil && x.Uint32Be != nil {
+		return *x.Uint32Be
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetUint32BeHex() string {
+	if x != nil {
+		return x.Uint32BeHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetUint64Be() uint64 {
+	if x != nil && x.Uint64Be != nil {
+		return *x.Uint64Be
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetUint64BeHex() string {
+	if x != nil {
+		return x.Uint64BeHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetUint16Le() uint32 {
+	if x != nil && x.Uint16Le != nil {
+		return *x.Uint16Le
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetUint16LeHex() string {
+	if x != nil {
+		return x.Uint16LeHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetUint32Le() uint32 {
+	if x != nil && x.Uint32Le != nil {
+		return *x.Uint32Le
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetUint32LeHex() string {
+	if x != nil {
+		return x.Uint32LeHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetUint64Le() uint64 {
+	if x != nil && x.Uint64Le != nil {
+		return *x.Uint64Le
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetUint64LeHex() string {
+	if x != nil {
+		return x.Uint64LeHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetUint16Badc() uint32 {
+	if x != nil && x.Uint16Badc != nil {
+		return *x.Uint16Badc
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetUint16BadcHex() string {
+	if x != nil {
+		return x.Uint16BadcHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetUint32Badc() uint32 {
+	if x != nil && x.Uint32Badc != nil {
+		return *x.Uint32Badc
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetUint32BadcHex() string {
+	if x != nil {
+		return x.Uint32BadcHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetUint64Badc() uint64 {
+	if x != nil && x.Uint64Badc != nil {
+		return *x.Uint64Badc
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetUint64BadcHex() string {
+	if x != nil {
+		return x.Uint64BadcHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetUint16Cdab() uint32 {
+	if x != nil && x.Uint16Cdab != nil {
+		return *x.Uint16Cdab
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetUint16CdabHex() string {
+	if x != nil {
+		return x.Uint16CdabHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetUint32Cdab() uint32 {
+	if x != nil && x.Uint32Cdab != nil {
+		return *x.Uint32Cdab
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetUint32CdabHex() string {
+	if x != nil {
+		return x.Uint32CdabHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetUint64Cdab() uint64 {
+	if x != nil && x.Uint64Cdab != nil {
+		return *x.Uint64Cdab
+	}
+	return 0
+}
+
+func (x *ConversionResult) GetUint64CdabHex() string {
+	if x != nil {
+		return x.Uint64CdabHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetFloat32Be() string {
+	if x != nil && x.Float32Be != nil {
+		return *x.Float32Be
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetFloat32BeHex() string {
+	if x != nil {
+		return x.Float32BeHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetFloat64Be() string {
+	if x != nil && x.Float64Be != nil {
+		return *x.Float64Be
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetFloat64BeHex() string {
+	if x != nil {
+		return x.Float64BeHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetFloat32Le() string {
+	if x != nil && x.Float32Le != nil {
+		return *x.Float32Le
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetFloat32LeHex() string {
+	if x != nil {
+		return x.Float32LeHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetFloat64Le() string {
+	if x != nil && x.Float64Le != nil {
+		return *x.Float64Le
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetFloat64LeHex() string {
+	if x != nil {
+		return x.Float64LeHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetFloat32Badc() string {
+	if x != nil && x.Float32Badc != nil {
+		return *x.Float32Badc
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetFloat32BadcHex() string {
+	if x != nil {
+		return x.Float32BadcHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetFloat64Badc() string {
+	if x != nil && x.Float64Badc != nil {
+		return *x.Float64Badc
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetFloat64BadcHex() string {
+	if x != nil {
+		return x.Float64BadcHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetFloat32Cdab() string {
+	if x != nil && x.Float32Cdab != nil {
+		return *x.Float32Cdab
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetFloat32CdabHex() string {
+	if x != nil {
+		return x.Float32CdabHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetFloat64Cdab() string {
+	if x != nil && x.Float64Cdab != nil {
+		return *x.Float64Cdab
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetFloat64CdabHex() string {
+	if x != nil {
+		return x.Float64CdabHex
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetBinary() string {
+	if x != nil {
+		return x.Binary
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetBytes() string {
+	if x != nil {
+		return x.Bytes
+	}
+	return ""
+}
+
+func (x *ConversionResult) GetAscii() string {
+	if x != nil {
+		return x.Ascii
+	}
+	return ""
+}
+
+type ModbusRegister struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Hex           string                 `protobuf:"bytes,2,opt,name=hex,proto3" json:"hex,omitempty"`
+	Unsigned      uint32                 `protobuf:"varint,3,opt,name=unsigned,proto3" json:"unsigned,omitempty"`
+	Signed        int32                  `protobuf:"zigzag32,4,opt,name=signed,proto3" json:"signed,omitempty"`
+	Binary        string                 `protobuf:"bytes,5,opt,name=binary,proto3" json:"binary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModbusRegister) Reset() {
+	*x = ModbusRegister{}
+	mi := &file_hexview_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModbusRegister) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModbusRegister) ProtoMessage() {}
+
+func (x *ModbusRegister) ProtoReflect() protoreflect.Message {
+	mi := &file_hexview_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModbusRegister.ProtoReflect.Descriptor instead.
+func (*ModbusRegister) Descriptor() ([]byte, []int) {
+	return file_hexview_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ModbusRegister) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ModbusRegister) GetHex() string {
+	if x != nil {
+		return x.Hex
+	}
+	return ""
+}
+
+func (x *ModbusRegister) GetUnsigned() uint32 {
+	if x != nil {
+		return x.Unsigned
+	}
+	return 0
+}
+
+func (x *ModbusRegister) GetSigned() int32 {
+	if x != nil {
+		return x.Signed
+	}
+	return 0
+}
+
+func (x *ModbusRegister) GetBinary() string {
+	if x != nil {
+		return x.Binary
+	}
+	return ""
+}
+
+type ModbusCombined32 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RegisterStart int32                  `protobuf:"varint,1,opt,name=register_start,json=registerStart,proto3" json:"register_start,omitempty"`
+	Hex           string                 `protobuf:"bytes,2,opt,name=hex,proto3" json:"hex,omitempty"`
+	Uint32Be      uint32                 `protobuf:"varint,3,opt,name=uint32_be,json=uint32Be,proto3" json:"uint32_be,omitempty"`
+	Uint32Le      uint32                 `protobuf:"varint,4,opt,name=uint32_le,json=uint32Le,proto3" json:"uint32_le,omitempty"`
+	Uint32Badc    uint32                 `protobuf:"varint,5,opt,name=uint32_badc,json=uint32Badc,proto3" json:"uint32_badc,omitempty"`
+	Uint32Cdab    uint32                 `protobuf:"varint,6,opt,name=uint32_cdab,json=uint32Cdab,proto3" json:"uint32_cdab,omitempty"`
+	Int32Be       int32                  `protobuf:"zigzag32,7,opt,name=int32_be,json=int32Be,proto3" json:"int32_be,omitempty"`
+	Int32Le       int32                  `protobuf:"zigzag32,8,opt,name=int32_le,json=int32Le,proto3" json:"int32_le,omitempty"`
+	Int32Badc     int32                  `protobuf:"zigzag32,9,opt,name=int32_badc,json=int32Badc,proto3" json:"int32_badc,omitempty"`
+	Int32Cdab     int32                  `protobuf:"zigzag32,10,opt,name=int32_cdab,json=int32Cdab,proto3" json:"int32_cdab,omitempty"`
+	Float32Be     string                 `protobuf:"bytes,11,opt,name=float32_be,json=float32Be,proto3" json:"float32_be,omitempty"`
+	Float32Le     string                 `protobuf:"bytes,12,opt,name=float32_le,json=float32Le,proto3" json:"float32_le,omitempty"`
+	Float32Badc   string                 `protobuf:"bytes,13,opt,name=float32_badc,json=float32Badc,proto3" json:"float32_badc,omitempty"`
+	Float32Cdab   string                 `protobuf:"bytes,14,opt,name=float32_cdab,json=float32Cdab,proto3" json:"float32_cdab,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModbusCombined32) Reset() {
+	*x = ModbusCombined32{}
+	mi := &file_hexview_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModbusCombined32) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModbusCombined32) ProtoMessage() {}
+
+func (x *ModbusCombined32) ProtoReflect() protoreflect.Message {
+	mi := &file_hexview_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModbusCombined32.ProtoReflect.Descriptor instead.
+func (*ModbusCombined32) Descriptor() ([]byte, []int) {
+	return file_hexview_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ModbusCombined32) GetRegisterStart() int32 {
+	if x != nil {
+		return x.RegisterStart
+	}
+	return 0
+}
+
+func (x *ModbusCombined32) GetHex() string {
+	if x != nil {
+		return x.Hex
+	}
+	return ""
+}
+
+func (x *ModbusCombined32) GetUint32Be() uint32 {
+	if x != nil {
+		return x.Uint32Be
+	}
+	return 0
+}
+
+func (x *ModbusCombined32) GetUint32Le() uint32 {
+	if x != nil {
+		return x.Uint32Le
+	}
+	return 0
+}
+
+func (x *ModbusCombined32) GetUint32Badc() uint32 {
+	if x != nil {
+		return x.Uint32Badc
+	}
+	return 0
+}
+
+func (x *ModbusCombined32) GetUint32Cdab() uint32 {
+	if x != nil {
+		return x.Uint32Cdab
+	}
+	return 0
+}
+
+func (x *ModbusCombined32) GetInt32Be() int32 {
+	if x != nil {
+		return x.Int32Be
+	}
+	return 0
+}
+
+func (x *ModbusCombined32) GetInt32Le() int32 {
+	if x != nil {
+		return x.Int32Le
+	}
+	return 0
+}
+
+func (x *ModbusCombined32) GetInt32Badc() int32 {
+	if x != nil {
+		return x.Int32Badc
+	}
+	return 0
+}
+
+func (x *ModbusCombined32) GetInt32Cdab() int32 {
+	if x != nil {
+		return x.Int32Cdab
+	}
+	return 0
+}
+
+func (x *ModbusCombined32) GetFloat32Be() string {
+	if x != nil {
+		return x.Float32Be
+	}
+	return ""
+}
+
+func (x *ModbusCombined32) GetFloat32Le() string {
+	if x != nil {
+		return x.Float32Le
+	}
+	return ""
+}
+
+func (x *ModbusCombined32) GetFloat32Badc() string {
+	if x != nil {
+		return x.Float32Badc
+	}
+	return ""
+}
+
+func (x *ModbusCombined32) GetFloat32Cdab() string {
+	if x != nil {
+		return x.Float32Cdab
+	}
+	return ""
+}
+
+type ModbusCombined64 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RegisterStart int32                  `protobuf:"varint,1,opt,name=register_start,json=registerStart,proto3" json:"register_start,omitempty"`
+	Hex           string                 `protobuf:"bytes,2,opt,name=hex,proto3" json:"hex,omitempty"`
+	Uint64Be      uint64                 `protobuf:"varint,3,opt,name=uint64_be,json=uint64Be,proto3" json:"uint64_be,omitempty"`
+	Uint64Le      uint64                 `protobuf:"varint,4,opt,name=uint64_le,json=uint64Le,proto3" json:"uint64_le,omitempty"`
+	Int64Be       int64                  `protobuf:"zigzag64,5,opt,name=int64_be,json=int64Be,proto3" json:"int64_be,omitempty"`
+	Int64Le       int64                  `protobuf:"zigzag64,6,opt,name=int64_le,json=int64Le,proto3" json:"int64_le,omitempty"`
+	Float64Be     string                 `protobuf:"bytes,7,opt,name=float64_be,json=float64Be,proto3" json:"float64_be,omitempty"`
+	Float64Le     string                 `protobuf:"bytes,8,opt,name=float64_le,json=float64Le,proto3" json:"float64_le,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModbusCombined64) Reset() {
+	*x = ModbusCombined64{}
+	mi := &file_hexview_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModbusCombined64) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModbusCombined64) ProtoMessage() {}
+
+func (x *ModbusCombined64) ProtoReflect() protoreflect.Message {
+	mi := &file_hexview_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModbusCombined64.ProtoReflect.Descriptor instead.
+func (*ModbusCombined64) Descriptor() ([]byte, []int) {
+	return file_hexview_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ModbusCombined64) GetRegisterStart() int32 {
+	if x != nil {
+		return x.RegisterStart
+	}
+	return 0
+}
+
+func (x *ModbusCombined64) GetHex() string {
+	if x != nil {
+		return x.Hex
+	}
+	return ""
+}
+
+func (x *ModbusCombined64) GetUint64Be() uint64 {
+	if x != nil {
+		return x.Uint64Be
+	}
+	return 0
+}
+
+func (x *ModbusCombined64) GetUint64Le() uint64 {
+	if x != nil {
+		return x.Uint64Le
+	}
+	return 0
+}
+
+func (x *ModbusCombined64) GetInt64Be() int64 {
+	if x != nil {
+		return x.Int64Be
+	}
+	return 0
+}
+
+func (x *ModbusCombined64) GetInt64Le() int64 {
+	if x != nil {
+		return x.Int64Le
+	}
+	return 0
+}
+
+func (x *ModbusCombined64) GetFloat64Be() string {
+	if x != nil {
+		return x.Float64Be
+	}
+	return ""
+}
+
+func (x *ModbusCombined64) GetFloat64Le() string {
+	if x != nil {
+		return x.Float64Le
+	}
+	return ""
+}
+
+type ModbusResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Registers     []*ModbusRegister      `protobuf:"bytes,1,rep,name=registers,proto3" json:"registers,omitempty"`
+	Combined32    []*ModbusCombined32    `protobuf:"bytes,2,rep,name=combined32,proto3" json:"combined32,omitempty"`
+	Combined64    []*ModbusCombined64    `protobuf:"bytes,3,rep,name=combined64,proto3" json:"combined64,omitempty"`
+	RawHex        string                 `protobuf:"bytes,4,opt,name=raw_hex,json=rawHex,proto3" json:"raw_hex,omitempty"`
+	Ascii         string                 `protobuf:"bytes,5,opt,name=ascii,proto3" json:"ascii,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModbusResult) Reset() {
+	*x = ModbusResult{}
+	mi := &file_hexview_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModbusResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModbusResult) ProtoMessage() {}
+
+func (x *ModbusResult) ProtoReflect() protoreflect.Message {
+	mi := &file_hexview_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModbusResult.ProtoReflect.Descriptor instead.
+func (*ModbusResult) Descriptor() ([]byte, []int) {
+	return file_hexview_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ModbusResult) GetRegisters() []*ModbusRegister {
+	if x != nil {
+		return x.Registers
+	}
+	return nil
+}
+
+func (x *ModbusResult) GetCombined32() []*ModbusCombined32 {
+	if x != nil {
+		return x.Combined32
+	}
+	return nil
+}
+
+func (x *ModbusResult) GetCombined64() []*ModbusCombined64 {
+	if x != nil {
+		return x.Combined64
+	}
+	return nil
+}
+
+func (x *ModbusResult) GetRawHex() string {
+	if x != nil {
+		return x.RawHex
+	}
+	return ""
+}
+
+func (x *ModbusResult) GetAscii() string {
+	if x != nil {
+		return x.Ascii
+	}
+	return ""
+}
+
+type ChecksumValue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Width in bits.
+	Width int32 `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	// Big-endian hex, zero padded to the width.
+	Hex           string `protobuf:"bytes,3,opt,name=hex,proto3" json:"hex,omitempty"`
+	Value         uint64 `protobuf:"varint,4,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChecksumValue) Reset() {
+	*x = ChecksumValue{}
+	mi := &file_hexview_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChecksumValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChecksumValue) ProtoMessage() {}
+
+func (x *ChecksumValue) ProtoReflect() protoreflect.Message {
+	mi := &file_hexview_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChecksumValue.ProtoReflect.Descriptor instead.
+func (*ChecksumValue) Descriptor() ([]byte, []int) {
+	return file_hexview_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ChecksumValue) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ChecksumValue) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ChecksumValue) GetHex() string {
+	if x != nil {
+		return x.Hex
+	}
+	return ""
+}
+
+func (x *ChecksumValue) GetValue() uint64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type ChecksumResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Length        int32                  `protobuf:"varint,1,opt,name=length,proto3" json:"length,omitempty"`
+	Checksums     []*ChecksumValue       `protobuf:"bytes,2,rep,name=checksums,proto3" json:"checksums,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChecksumResult) Reset() {
+	*x = ChecksumResult{}
+	mi := &file_hexview_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChecksumResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChecksumResult) ProtoMessage() {}
+
+func (x *ChecksumResult) ProtoReflect() protoreflect.Message {
+	mi := &file_hexview_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChecksumResult.ProtoReflect.Descriptor instead.
+func (*ChecksumResult) Descriptor() ([]byte, []int) {
+	return file_hexview_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ChecksumResult) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *ChecksumResult) GetChecksums() []*ChecksumValue {
+	if x != nil {
+		return x.Checksums
+	}
+	return nil
+}
+
+type DiffRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offset        int64                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length        int32                  `protobuf:"varint,2,opt,name=length,proto3" json:"length,omitempty"`
+	A             string                 `protobuf:"bytes,3,opt,name=a,proto3" json:"a,omitempty"`
+	B             string                 `protobuf:"bytes,4,opt,name=b,proto3" json:"b,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffRange) Reset() {
+	*x = DiffRange{}
+	mi := &file_hexview_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffRange) ProtoMessage() {}
+
+func (x *DiffRange) ProtoReflect() protoreflect.Message {
+	mi := &file_hexview_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffRange.ProtoReflect.Descriptor instead.
+func (*DiffRange) Descriptor() ([]byte, []int) {
+	return file_hexview_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DiffRange) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *DiffRange) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *DiffRange) GetA() string {
+	if x != nil {
+		return x.A
+	}
+	return ""
+}
+
+func (x *DiffRange) GetB() string {
+	if x != nil {
+		return x.B
+	}
+	return ""
+}
+
+type DiffResult struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	LengthA   int32                  `protobuf:"varint,1,opt,name=length_a,json=lengthA,proto3" json:"length_a,omitempty"`
+	LengthB   int32                  `protobuf:"varint,2,opt,name=length_b,json=lengthB,proto3" json:"length_b,omitempty"`
+	Equal     bool                   `protobuf:"varint,3,opt,name=equal,proto3" json:"equal,omitempty"`
+	DiffBytes int32                  `protobuf:"varint,4,opt,name=diff_bytes,json=diffBytes,proto3" json:"diff_bytes,omitempty"`
+	// Offset of the first difference, -1 if equal.
+	FirstDiff int64        `protobuf:"varint,5,opt,name=first_diff,json=firstDiff,proto3" json:"first_diff,omitempty"`
+	Ranges    []*DiffRange `protobuf:"bytes,6,rep,name=ranges,proto3" json:"ranges,omitempty"`
+	// More ranges than the server reports were found.
+	RangesTrimmed bool `protobuf:"varint,7,opt,name=ranges_trimmed,json=rangesTrimmed,proto3" json:"ranges_trimmed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffResult) Reset() {
+	*x = DiffResult{}
+	mi := &file_hexview_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffResult) ProtoMessage() {}
+
+func (x *DiffResult) ProtoReflect() protoreflect.Message {
+	mi := &file_hexview_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffResult.ProtoReflect.Descriptor instead.
+func (*DiffResult) Descriptor() ([]byte, []int) {
+	return file_hexview_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DiffResult) GetLengthA() int32 {
+	if x != nil {
+		return x.LengthA
+	}
+	return 0
+}
+
+func (x *DiffResult) GetLengthB() int32 {
+	if x != nil {
+		return x.LengthB
+	}
+	return 0
+}
+
+func (x *DiffResult) GetEqual() bool {
+	if x != nil {
+		return x.Equal
+	}
+	return false
+}
+
+func (x *DiffResult) GetDiffBytes() int32 {
+	if x != nil {
+		return x.DiffBytes
+	}
+	return 0
+}
+
+func (x *DiffResult) GetFirstDiff() int64 {
+	if x != nil {
+		return x.FirstDiff
+	}
+	return 0
+}
+
+func (x *DiffResult) GetRanges() []*DiffRange {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+func (x *DiffResult) GetRangesTrimmed() bool {
+	if x != nil {
+		return x.RangesTrimmed
+	}
+	return false
+}
+
+var File_hexview_proto protoreflect.FileDescriptor
+
+const file_hexview_proto_rawDesc = "" +
+	"\n" +
+	"\rhexview.proto\x12\n" +
+	"hexview.v1\":\n" +
+	"\x0eConvertRequest\x12\x14\n" +
+	"\x05input\x18\x01 \x01(\tR\x05input\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\")\n" +
+	"\vDiffRequest\x12\f\n" +
+	"\x01a\x18\x01 \x01(\tR\x01a\x12\f\n" +
+	"\x01b\x18\x02 \x01(\tR\x01b\"\xfb\x17\n" +
+	"\x10ConversionResult\x12\x1c\n" +
+	"\aint8_be\x18\x01 \x01(\x11H\x00R\x06int8Be\x88\x01\x01\x12\x1e\n" +
+	"\vint8_be_hex\x18\x02 \x01(\tR\tint8BeHex\x12\x1e\n" +
+	"\bint16_be\x18\x03 \x01(\x11H\x01R\aint16Be\x88\x01\x01\x12 \n" +
+	"\fint16_be_hex\x18\x04 \x01(\tR\n" +
+	"int16BeHex\x12\x1e\n" +
+	"\bint32_be\x18\x05 \x01(\x11H\x02R\aint32Be\x88\x01\x01\x12 \n" +
+	"\fint32_be_hex\x18\x06 \x01(\tR\n" +
+	"int32BeHex\x12\x1e\n" +
+	"\bint64_be\x18\a \x01(\x12H\x03R\aint64Be\x88\x01\x01\x12 \n" +
+	"\fint64_be_hex\x18\b \x01(\tR\n" +
+	"int64BeHex\x12\x1e\n" +
+	"\bint16_le\x18\t \x01(\x11H\x04R\aint16Le\x88\x01\x01\x12 \n" +
+	"\fint16_le_hex\x18\n" +
+	" \x01(\tR\n" +
+	"int16LeHex\x12\x1e\n" +
+	"\bint32_le\x18\v \x01(\x11H\x05R\aint32Le\x88\x01\x01\x12 \n" +
+	"\fint32_le_hex\x18\f \x01(\tR\n" +
+	"int32LeHex\x12\x1e\n" +
+	"\bint64_le\x18\r \x01(\x12H\x06R\aint64Le\x88\x01\x01\x12 \n" +
+	"\fint64_le_hex\x18\x0e \x01(\tR\n" +
+	"int64LeHex\x12\"\n" +
+	"\n" +
+	"int16_badc\x18\x0f \x01(\x11H\aR\tint16Badc\x88\x01\x01\x12$\n" +
+	"\x0eint16_badc_hex\x18\x10 \x01(\tR\fint16BadcHex\x12\"\n" +
+	"\n" +
+	"int32_badc\x18\x11 \x01(\x11H\bR\tint32Badc\x88\x01\x01\x12$\n" +
+	"\x0eint32_badc_hex\x18\x12 \x01(\tR\fint32BadcHex\x12\"\n" +
+	"\n" +
+	"int64_badc\x18\x13 \x01(\x12H\tR\tint64Badc\x88\x01\x01\x12$\n" +
+	"\x0eint64_badc_hex\x18\x14 \x01(\tR\fint64BadcHex\x12\"\n" +
+	"\n" +
+	"int16_cdab\x18\x15 \x01(\x11H\n" +
+	"R\tint16Cdab\x88\x01\x01\x12$\n" +
+	"\x0eint16_cdab_hex\x18\x16 \x01(\tR\fint16CdabHex\x12\"\n" +
+	"\n" +
+	"int32_cdab\x18\x17 \x01(\x11H\vR\tint32Cdab\x88\x01\x01\x12$\n" +
+	"\x0eint32_cdab_hex\x18\x18 \x01(\tR\fint32CdabHex\x12\"\n" +
+	"\n" +
+	"int64_cdab\x18\x19 \x01(\x12H\fR\tint64Cdab\x88\x01\x01\x12$\n" +
+	"\x0eint64_cdab_hex\x18\x1a \x01(\tR\fint64CdabHex\x12\x1e\n" +
+	"\buint8_be\x18\x1b \x01(\rH\rR\auint8Be\x88\x01\x01\x12 \n" +
+	"\fuint8_be_hex\x18\x1c \x01(\tR\n" +
+	"uint8BeHex\x12 \n" +
+	"\tuint16_be\x18\x1d \x01(\rH\x0eR\buint16Be\x88\x01\x01\x12\"\n" +
+	"\ruint16_be_hex\x18\x1e \x01(\tR\vuint16BeHex\x12 \n" +
+	"\tuint32_be\x18\x1f \x01(\rH\x0fR\buint32Be\x88\x01\x01\x12\"\n" +
+	"\ruint32_be_hex\x18  \x01(\tR\vuint32BeHex\x12 \n" +
+	"\tuint64_be\x18! \x01(\x04H\x10R\buint64Be\x88\x01\x01\x12\"\n" +
+	"\ruint64_be_hex\x18\" \x01(\tR\vuint64BeHex\x12 \n" +
+	"\tuint16_le\x18# \x01(\rH\x11R\buint16Le\x88\x01\x01\x12\"\n" +
+	"\ruint16_le_hex\x18$ \x01(\tR\vuint16LeHex\x12 \n" +
+	"\tuint32_le\x18% \x01(\rH\x12R\buint32Le\x88\x01\x01\x12\"\n" +
+	"\ruint32_le_hex\x18& \x01(\tR\vuint32LeHex\x12 \n" +
+	"\tuint64_le\x18' \x01(\x04H\x13R\buint64Le\x88\x01\x01\x12\"\n" +
+	"\ruint64_le_hex\x18( \x01(\tR\vuint64LeHex\x12$\n" +
+	"\vuint16_badc\x18) \x01(\rH\x14R\n" +
+	"uint16Badc\x88\x01\x01\x12&\n" +
+	"\x0fuint16_badc_hex\x18* \x01(\tR\ruint16BadcHex\x12$\n" +
+	"\vuint32_badc\x18+ \x01(\rH\x15R\n" +
+	"uint32Badc\x88\x01\x01\x12&\n" +
+	"\x0fuint32_badc_hex\x18, \x01(\tR\ruint32BadcHex\x12$\n" +
+	"\vuint64_badc\x18- \x01(\x04H\x16R\n" +
+	"uint64Badc\x88\x01\x01\x12&\n" +
+	"\x0fuint64_badc_hex\x18. \x01(\tR\ruint64BadcHex\x12$\n" +
+	"\vuint16_cdab\x18/ \x01(\rH\x17R\n" +
+	"uint16Cdab\x88\x01\x01\x12&\n" +
+	"\x0fuint16_cdab_hex\x180 \x01(\tR\ruint16CdabHex\x12$\n" +
+	"\vuint32_cdab\x181 \x01(\rH\x18R\n" +
+	"uint32Cdab\x88\x01\x01\x12&\n" +
+	"\x0fuint32_cdab_hex\x182 \x01(\tR\ruint32CdabHex\x12$\n" +
+	"\vuint64_cdab\x183 \x01(\x04H\x19R\n" +
+	"uint64Cdab\x88\x01\x01\x12&\n" +
+	"\x0fuint64_cdab_hex\x184 \x01(\tR\ruint64CdabHex\x12\"\n" +
+	"\n" +
+	"float32_be\x185 \x01(\tH\x1aR\tfloat32Be\x88\x01\x01\x12$\n" +
+	"\x0efloat32_be_hex\x186 \x01(\tR\ffloat32BeHex\x12\"\n" +
+	"\n" +
+	"float64_be\x187 \x01(\tH\x1bR\tfloat64Be\x88\x01\x01\x12$\n" +
+	"\x0efloat64_be_hex\x188 \x01(\tR\ffloat64BeHex\x12\"\n" +
+	"\n" +
+	"float32_le\x189 \x01(\tH\x1cR\tfloat32Le\x88\x01\x01\x12$\n" +
+	"\x0efloat32_le_hex\x18: \x01(\tR\ffloat32LeHex\x12\"\n" +
+	"\n" +
+	"float64_le\x18; \x01(\tH\x1dR\tfloat64Le\x88\x01\x01\x12$\n" +
+	"\x0efloat64_le_hex\x18< \x01(\tR\ffloat64LeHex\x12&\n" +
+	"\ffloat32_badc\x18= \x01(\tH\x1eR\vfloat32Badc\x88\x01\x01\x12(\n" +
+	"\x10float32_badc_hex\x18> \x01(\tR\x0efloat32BadcHex\x12&\n" +
+	"\ffloat64_badc\x18? \x01(\tH\x1fR\vfloat64Badc\x88\x01\x01\x12(\n" +
+	"\x10float64_badc_hex\x18@ \x01(\tR\x0efloat64BadcHex\x12&\n" +
+	"\ffloat32_cdab\x18A \x01(\tH R\vfloat32Cdab\x88\x01\x01\x12(\n" +
+	"\x10float32_cdab_hex\x18B \x01(\tR\x0efloat32CdabHex\x12&\n" +
+	"\ffloat64_cdab\x18C \x01(\tH!R\vfloat64Cdab\x88\x01\x01\x12(\n" +
+	"\x10float64_cdab_hex\x18D \x01(\tR\x0efloat64CdabHex\x12\x16\n" +
+	"\x06binary\x18E \x01(\tR\x06binary\x12\x14\n" +
+	"\x05bytes\x18F \x01(\tR\x05bytes\x12\x14\n" +
+	"\x05ascii\x18G \x01(\tR\x05asciiB\n" +
+	"\n" +
+	"\b_int8_beB\v\n" +
+	"\t_int16_beB\v\n" +
+	"\t_int32_beB\v\n" +
+	"\t_int64_beB\v\n" +
+	"\t_int16_leB\v\n" +
+	"\t_int32_leB\v\n" +
+	"\t_int64_leB\r\n" +
+	"\v_int16_badcB\r\n" +
+	"\v_int32_badcB\r\n" +
+	"\v_int64_badcB\r\n" +
+	"\v_int16_cdabB\r\n" +
+	"\v_int32_cdabB\r\n" +
+	"\v_int64_cdabB\v\n" +
+	"\t_uint8_beB\f\n" +
+	"\n" +
+	"_uint16_beB\f\n" +
+	"\n" +
+	"_uint32_beB\f\n" +
+	"\n" +
+	"_uint64_beB\f\n" +
+	"\n" +
+	"_uint16_leB\f\n" +
+	"\n" +
+	"_uint32_leB\f\n" +
+	"\n" +
+	"_uint64_leB\x0e\n" +
+	"\f_uint16_badcB\x0e\n" +
+	"\f_uint32_badcB\x0e\n" +
+	"\f_uint64_badcB\x0e\n" +
+	"\f_uint16_cdabB\x0e\n" +
+	"\f_uint32_cdabB\x0e\n" +
+	"\f_uint64_cdabB\r\n" +
+	"\v_float32_beB\r\n" +
+	"\v_float64_beB\r\n" +
+	"\v_float32_leB\r\n" +
+	"\v_float64_leB\x0f\n" +
+	"\r_float32_badcB\x0f\n" +
+	"\r_float64_badcB\x0f\n" +
+	"\r_float32_cdabB\x0f\n" +
+	"\r_float64_cdab\"\x84\x01\n" +
+	"\x0eModbusRegister\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x10\n" +
+	"\x03hex\x18\x02 \x01(\tR\x03hex\x12\x1a\n" +
+	"\bunsigned\x18\x03 \x01(\rR\bunsigned\x12\x16\n" +
+	"\x06signed\x18\x04 \x01(\x11R\x06signed\x12\x16\n" +
+	"\x06binary\x18\x05 \x01(\tR\x06binary\"\xbf\x03\n" +
+	"\x10ModbusCombined32\x12%\n" +
+	"\x0eregister_start\x18\x01 \x01(\x05R\rregisterStart\x12\x10\n" +
+	"\x03hex\x18\x02 \x01(\tR\x03hex\x12\x1b\n" +
+	"\tuint32_be\x18\x03 \x01(\rR\buint32Be\x12\x1b\n" +
+	"\tuint32_le\x18\x04 \x01(\rR\buint32Le\x12\x1f\n" +
+	"\vuint32_badc\x18\x05 \x01(\rR\n" +
+	"uint32Badc\x12\x1f\n" +
+	"\vuint32_cdab\x18\x06 \x01(\rR\n" +
+	"uint32Cdab\x12\x19\n" +
+	"\bint32_be\x18\a \x01(\x11R\aint32Be\x12\x19\n" +
+	"\bint32_le\x18\b \x01(\x11R\aint32Le\x12\x1d\n" +
+	"\n" +
+	"int32_badc\x18\t \x01(\x11R\tint32Badc\x12\x1d\n" +
+	"\n" +
+	"int32_cdab\x18\n" +
+	" \x01(\x11R\tint32Cdab\x12\x1d\n" +
+	"\n" +
+	"float32_be\x18\v \x01(\tR\tfloat32Be\x12\x1d\n" +
+	"\n" +
+	"float32_le\x18\f \x01(\tR\tfloat32Le\x12!\n" +
+	"\ffloat32_badc\x18\r \x01(\tR\vfloat32Badc\x12!\n" +
+	"\ffloat32_cdab\x18\x0e \x01(\tR\vfloat32Cdab\"\xf9\x01\n" +
+	"\x10ModbusCombined64\x12%\n" +
+	"\x0eregister_start\x18\x01 \x01(\x05R\rregisterStart\x12\x10\n" +
+	"\x03hex\x18\x02 \x01(\tR\x03hex\x12\x1b\n" +
+	"\tuint64_be\x18\x03 \x01(\x04R\buint64Be\x12\x1b\n" +
+	"\tuint64_le\x18\x04 \x01(\x04R\buint64Le\x12\x19\n" +
+	"\bint64_be\x18\x05 \x01(\x12R\aint64Be\x12\x19\n" +
+	"\bint64_le\x18\x06 \x01(\x12R\aint64Le\x12\x1d\n" +
+	"\n" +
+	"float64_be\x18\a \x01(\tR\tfloat64Be\x12\x1d\n" +
+	"\n" +
+	"float64_le\x18\b \x01(\tR\tfloat64Le\"\xf3\x01\n" +
+	"\fModbusResult\x128\n" +
+	"\tregisters\x18\x01 \x03(\v2\x1a.hexview.v1.ModbusRegisterR\tregisters\x12<\n" +
+	"\n" +
+	"combined32\x18\x02 \x03(\v2\x1c.hexview.v1.ModbusCombined32R\n" +
+	"combined32\x12<\n" +
+	"\n" +
+	"combined64\x18\x03 \x03(\v2\x1c.hexview.v1.ModbusCombined64R\n" +
+	"combined64\x12\x17\n" +
+	"\araw_hex\x18\x04 \x01(\tR\x06rawHex\x12\x14\n" +
+	"\x05ascii\x18\x05 \x01(\tR\x05ascii\"a\n" +
+	"\rChecksumValue\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x05R\x05width\x12\x10\n" +
+	"\x03hex\x18\x03 \x01(\tR\x03hex\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\x04R\x05value\"a\n" +
+	"\x0eChecksumResult\x12\x16\n" +
+	"\x06length\x18\x01 \x01(\x05R\x06length\x127\n" +
+	"\tchecksums\x18\x02 \x03(\v2\x19.hexview.v1.ChecksumValueR\tchecksums\"W\n" +
+	"\tDiffRange\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06length\x18\x02 \x01(\x05R\x06length\x12\f\n" +
+	"\x01a\x18\x03 \x01(\tR\x01a\x12\f\n" +
+	"\x01b\x18\x04 \x01(\tR\x01b\"\xec\x01\n" +
+	"\n" +
+	"DiffResult\x12\x19\n" +
+	"\blength_a\x18\x01 \x01(\x05R\alengthA\x12\x19\n" +
+	"\blength_b\x18\x02 \x01(\x05R\alengthB\x12\x14\n" +
+	"\x05equal\x18\x03 \x01(\bR\x05equal\x12\x1d\n" +
+	"\n" +
+	"diff_bytes\x18\x04 \x01(\x05R\tdiffBytes\x12\x1d\n" +
+	"\n" +
+	"first_diff\x18\x05 \x01(\x03R\tfirstDiff\x12-\n" +
+	"\x06ranges\x18\x06 \x03(\v2\x15.hexview.v1.DiffRangeR\x06ranges\x12%\n" +
+	"\x0eranges_trimmed\x18\a \x01(\bR\rrangesTrimmed2\xc9\x04\n" +
+	"\tConverter\x12F\n" +
+	"\n" +
+	"ConvertHex\x12\x1a.hexview.v1.ConvertRequest\x1a\x1c.hexview.v1.ConversionResult\x12F\n" +
+	"\n" +
+	"ConvertInt\x12\x1a.hexview.v1.ConvertRequest\x1a\x1c.hexview.v1.ConversionResult\x12H\n" +
+	"\fConvertFloat\x12\x1a.hexview.v1.ConvertRequest\x1a\x1c.hexview.v1.ConversionResult\x12I\n" +
+	"\rConvertBinary\x12\x1a.hexview.v1.ConvertRequest\x1a\x1c.hexview.v1.ConversionResult\x12J\n" +
+	"\x0eConvertIntAuto\x12\x1a.hexview.v1.ConvertRequest\x1a\x1c.hexview.v1.ConversionResult\x12N\n" +
+	"\x16ConvertModbusRegisters\x12\x1a.hexview.v1.ConvertRequest\x1a\x18.hexview.v1.ModbusResult\x12B\n" +
+	"\bChecksum\x12\x1a.hexview.v1.ConvertRequest\x1a\x1a.hexview.v1.ChecksumResult\x127\n" +
+	"\x04Diff\x12\x17.hexview.v1.DiffRequest\x1a\x16.hexview.v1.DiffResultB\x17Z\x15hexview/rpc/hexviewpbb\x06proto3"
+
+var (
+	file_hexview_proto_rawDescOnce sync.Once
+	file_hexview_proto_rawDescData []byte
+)
+
+func file_hexview_proto_rawDescGZIP() []byte {
+	file_hexview_proto_rawDescOnce.Do(func() {
+		file_hexview_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hexview_proto_rawDesc), len(file_hexview_proto_rawDesc)))
+	})
+	return file_hexview_proto_rawDescData
+}
+
+var file_hexview_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_hexview_proto_goTypes = []any{
+	(*ConvertRequest)(nil),   // 0: hexview.v1.ConvertRequest
+	(*DiffRequest)(nil),      // 1: hexview.v1.DiffRequest
+	(*ConversionResult)(nil), // 2: hexview.v1.ConversionResult
+	(*ModbusRegister)(nil),   // 3: hexview.v1.ModbusRegister
+	(*ModbusCombined32)(nil), // 4: hexview.v1.ModbusCombined32
+	(*ModbusCombined64)(nil), // 5: hexview.v1.ModbusCombined64
+	(*ModbusResult)(nil),     // 6: hexview.v1.ModbusResult
+	(*ChecksumValue)(nil),    // 7: hexview.v1.ChecksumValue
+	(*ChecksumResult)(nil),   // 8: hexview.v1.ChecksumResult
+	(*DiffRange)(nil),        // 9: hexview.v1.DiffRange
+	(*DiffResult)(nil),       // 10: hexview.v1.DiffResult
+}
+var file_hexview_proto_depIdxs = []int32{
+	3,  // 0: hexview.v1.ModbusResult.registers:type_name -> hexview.v1.ModbusRegister
+	4,  // 1: hexview.v1.ModbusResult.combined32:type_name -> hexview.v1.ModbusCombined32
+	5,  // 2: hexview.v1.ModbusResult.combined64:type_name -> hexview.v1.ModbusCombined64
+	7,  // 3: hexview.v1.ChecksumResult.checksums:type_name -> hexview.v1.ChecksumValue
+	9,  // 4: hexview.v1.DiffResult.ranges:type_name -> hexview.v1.DiffRange
+	0,  // 5: hexview.v1.Converter.ConvertHex:input_type -> hexview.v1.ConvertRequest
+	0,  // 6: hexview.v1.Converter.ConvertInt:input_type -> hexview.v1.ConvertRequest
+	0,  // 7: hexview.v1.Converter.ConvertFloat:input_type -> hexview.v1.ConvertRequest
+	0,  // 8: hexview.v1.Converter.ConvertBinary:input_type -> hexview.v1.ConvertRequest
+	0,  // 9: hexview.v1.Converter.ConvertIntAuto:input_type -> hexview.v1.ConvertRequest
+	0,  // 10: hexview.v1.Converter.ConvertModbusRegisters:input_type -> hexview.v1.ConvertRequest
+	0,  // 11: hexview.v1.Converter.Checksum:input_type -> hexview.v1.ConvertRequest
+	1,  // 12: hexview.v1.Converter.Diff:input_type -> hexview.v1.DiffRequest
+	2,  // 13: hexview.v1.Converter.ConvertHex:output_type -> hexview.v1.ConversionResult
+	2,  // 14: hexview.v1.Converter.ConvertInt:output_type -> hexview.v1.ConversionResult
+	2,  // 15: hexview.v1.Converter.ConvertFloat:output_type -> hexview.v1.ConversionResult
+	2,  // 16: hexview.v1.Converter.ConvertBinary:output_type -> hexview.v1.ConversionResult
+	2,  // 17: hexview.v1.Converter.ConvertIntAuto:output_type -> hexview.v1.ConversionResult
+	6,  // 18: hexview.v1.Converter.ConvertModbusRegisters:output_type -> hexview.v1.ModbusResult
+	8,  // 19: hexview.v1.Converter.Checksum:output_type -> hexview.v1.ChecksumResult
+	10, // 20: hexview.v1.Converter.Diff:output_type -> hexview.v1.DiffResult
+	13, // [13:21] is the sub-list for method output_type
+	5,  // [5:13] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_hexview_proto_init() }
+func file_hexview_proto_init() {
+	if File_hexview_proto != nil {
+		return
+	}
+	file_hexview_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hexview_proto_rawDesc), len(file_hexview_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hexview_proto_goTypes,
+		DependencyIndexes: file_hexview_proto_depIdxs,
+		MessageInfos:      file_hexview_proto_msgTypes,
+	}.Build()
+	File_hexview_proto = out.File
+	file_hexview_proto_goTypes = nil
+	file_hexview_proto_depIdxs = nil
+}
@@ -0,0 +1,409 @@
+// gRPC interface of the hexview conversion engine.
+//
+// Messages mirror the JSON models returned by the GUI and the REST API.
+// Integer results are optional because only the types that fit the input are
+// populated; floats are strings so NaN and Inf survive the round trip.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: hexview.proto
+
+package hexviewpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Converter_ConvertHex_FullMethodName             = "/hexview.v1.Converter/ConvertHex"
+	Converter_ConvertInt_FullMethodName             = "/hexview.v1.Converter/ConvertInt"
+	Converter_ConvertFloat_FullMethodName           = "/hexview.v1.Converter/ConvertFloat"
+	Converter_ConvertBinary_FullMethodName          = "/hexview.v1.Converter/ConvertBinary"
+	Converter_ConvertIntAuto_FullMethodName         = "/hexview.v1.Converter/ConvertIntAuto"
+	Converter_ConvertModbusRegisters_FullMethodName = "/hexview.v1.Converter/ConvertModbusRegisters"
+	Converter_Checksum_FullMethodName               = "/hexview.v1.Converter/Checksum"
+	Converter_Diff_FullMethodName                   = "/hexview.v1.Converter/Diff"
+)
+
+// ConverterClient is the client API for Converter service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ConverterClient interface {
+	// ConvertHex interprets hex input as every integer and float type.
+	ConvertHex(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConversionResult, error)
+	// ConvertInt encodes a decimal or 0x-prefixed integer of the given type (default int32).
+	ConvertInt(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConversionResult, error)
+	// ConvertFloat encodes a float of the given type (float32 or float64, default float32).
+	ConvertFloat(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConversionResult, error)
+	// ConvertBinary interprets a binary string.
+	ConvertBinary(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConversionResult, error)
+	// ConvertIntAuto picks the smallest integer or float type that fits the input.
+	ConvertIntAuto(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConversionResult, error)
+	// ConvertModbusRegisters interprets 16-bit register values.
+	ConvertModbusRegisters(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ModbusResult, error)
+	// Checksum computes CRCs and simple checksums of hex input.
+	Checksum(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ChecksumResult, error)
+	// Diff compares two hex inputs byte by byte.
+	Diff(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (*DiffResult, error)
+}
+
+type converterClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewConverterClient(cc grpc.ClientConnInterface) ConverterClient {
+	return &converterClient{cc}
+}
+
+func (c *converterClient) ConvertHex(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConversionResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConversionResult)
+	err := c.cc.Invoke(ctx, Converter_ConvertHex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *converterClient) ConvertInt(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConversionResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConversionResult)
+	err := c.cc.Invoke(ctx, Converter_ConvertInt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *converterClient) ConvertFloat(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConversionResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConversionResult)
+	err := c.cc.Invoke(ctx, Converter_ConvertFloat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *converterClient) ConvertBinary(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConversionResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConversionResult)
+	err := c.cc.Invoke(ctx, Converter_ConvertBinary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *converterClient) ConvertIntAuto(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConversionResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConversionResult)
+	err := c.cc.Invoke(ctx, Converter_ConvertIntAuto_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *converterClient) ConvertModbusRegisters(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ModbusResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ModbusResult)
+	err := c.cc.Invoke(ctx, Converter_ConvertModbusRegisters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *converterClient) Checksum(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ChecksumResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChecksumResult)
+	err := c.cc.Invoke(ctx, Converter_Checksum_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *converterClient) Diff(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (*DiffResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffResult)
+	err := c.cc.Invoke(ctx, Converter_Diff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ConverterServer is the server API for Converter service.
+// All implementations must embed UnimplementedConverterServer
+// for forward compatibility.
+type ConverterServer interface {
+	// ConvertHex interprets hex input as every integer and float type.
+	ConvertHex(context.Context, *ConvertRequest) (*ConversionResult, error)
+	// ConvertInt encodes a decimal or 0x-prefixed integer of the given type (default int32).
+	ConvertInt(context.Context, *ConvertRequest) (*ConversionResult, error)
+	// ConvertFloat encodes a float of the given type (float32 or float64, default float32).
+	ConvertFloat(context.Context, *ConvertRequest) (*ConversionResult, error)
+	// ConvertBinary interprets a binary string.
+	ConvertBinary(context.Context, *ConvertRequest) (*ConversionResult, error)
+	// ConvertIntAuto picks the smallest integer or float type that fits the input.
+	ConvertIntAuto(context.Context, *ConvertRequest) (*ConversionResult, error)
+	// ConvertModbusRegisters interprets 16-bit register values.
+	ConvertModbusRegisters(context.Context, *ConvertRequest) (*ModbusResult, error)
+	// Checksum computes CRCs and simple checksums of hex input.
+	Checksum(context.Context, *ConvertRequest) (*ChecksumResult, error)
+	// Diff compares two hex inputs byte by byte.
+	Diff(context.Context, *DiffRequest) (*DiffResult, error)
+	mustEmbedUnimplementedConverterServer()
+}
+
+// UnimplementedConverterServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedConverterServer struct{}
+
+func (UnimplementedConverterServer) ConvertHex(context.Context, *ConvertRequest) (*ConversionResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertHex not implemented")
+}
+func (UnimplementedConverterServer) ConvertInt(context.Context, *ConvertRequest) (*ConversionResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertInt not implemented")
+}
+func (UnimplementedConverterServer) ConvertFloat(context.Context, *ConvertRequest) (*ConversionResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertFloat not implemented")
+}
+func (UnimplementedConverterServer) ConvertBinary(context.Context, *ConvertRequest) (*ConversionResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertBinary not implemented")
+}
+func (UnimplementedConverterServer) ConvertIntAuto(context.Context, *ConvertRequest) (*ConversionResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertIntAuto not implemented")
+}
+func (UnimplementedConverterServer) ConvertModbusRegisters(context.Context, *ConvertRequest) (*ModbusResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertModbusRegisters not implemented")
+}
+func (UnimplementedConverterServer) Checksum(context.Context, *ConvertRequest) (*ChecksumResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Checksum not implemented")
+}
+func (UnimplementedConverterServer) Diff(context.Context, *DiffRequest) (*DiffResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Diff not implemented")
+}
+func (UnimplementedConverterServer) mustEmbedUnimplementedConverterServer() {}
+func (UnimplementedConverterServer) testEmbeddedByValue()                   {}
+
+// UnsafeConverterServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ConverterServer will
+// result in compilation errors.
+type UnsafeConverterServer interface {
+	mustEmbedUnimplementedConverterServer()
+}
+
+func RegisterConverterServer(s grpc.ServiceRegistrar, srv ConverterServer) {
+	// If the following call pancis, it indicates UnimplementedConverterServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Converter_ServiceDesc, srv)
+}
+
+func _Converter_ConvertHex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConverterServer).ConvertHex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Converter_ConvertHex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConverterServer).ConvertHex(ctx, req.(*ConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Converter_ConvertInt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConverterServer).ConvertInt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Converter_ConvertInt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConverterServer).ConvertInt(ctx, req.(*ConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Converter_ConvertFloat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConverterServer).ConvertFloat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Converter_ConvertFloat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConverterServer).ConvertFloat(ctx, req.(*ConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Converter_ConvertBinary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConverterServer).ConvertBinary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Converter_ConvertBinary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConverterServer).ConvertBinary(ctx, req.(*ConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Converter_ConvertIntAuto_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConverterServer).ConvertIntAuto(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Converter_ConvertIntAuto_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConverterServer).ConvertIntAuto(ctx, req.(*ConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Converter_ConvertModbusRegisters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConverterServer).ConvertModbusRegisters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Converter_ConvertModbusRegisters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConverterServer).ConvertModbusRegisters(ctx, req.(*ConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Converter_Checksum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConverterServer).Checksum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Converter_Checksum_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConverterServer).Checksum(ctx, req.(*ConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Converter_Diff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConverterServer).Diff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Converter_Diff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConverterServer).Diff(ctx, req.(*DiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Converter_ServiceDesc is the grpc.ServiceDesc for Converter service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Converter_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hexview.v1.Converter",
+	HandlerType: (*ConverterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ConvertHex",
+			Handler:    _Converter_ConvertHex_Handler,
+		},
+		{
+			MethodName: "ConvertInt",
+			Handler:    _Converter_ConvertInt_Handler,
+		},
+		{
+			MethodName: "ConvertFloat",
+			Handler:    _Converter_ConvertFloat_Handler,
+		},
+		{
+			MethodName: "ConvertBinary",
+			Handler:    _Converter_ConvertBinary_Handler,
+		},
+		{
+			MethodName: "ConvertIntAuto",
+			Handler:    _Converter_ConvertIntAuto_Handler,
+		},
+		{
+			MethodName: "ConvertModbusRegisters",
+			Handler:    _Converter_ConvertModbusRegisters_Handler,
+		},
+		{
+			MethodName: "Checksum",
+			Handler:    _Converter_Checksum_Handler,
+		},
+		{
+			MethodName: "Diff",
+			Handler:    _Converter_Diff_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hexview.proto",
+}
@@ -0,0 +1,107 @@
+// Package rpc exposes the conversion engine over gRPC. The service is defined
+// in hexview.proto; clients for other languages can be generated from it
+// with protoc.
+//
+// Example usage:
+//
+//	ln, _ := net.Listen("tcp", rpc.DefaultAddr)
+//	srv := rpc.NewServer(service.NewConverter())
+//	go srv.Serve(ln)
+//	defer srv.GracefulStop()
+package rpc
+
+//go:generate protoc --go_out=hexviewpb --go_opt=paths=source_relative --go-grpc_out=hexviewpb --go-grpc_opt=paths=source_relative hexview.proto
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"hexview/models"
+	"hexview/rpc/hexviewpb"
+	"hexview/service"
+)
+
+// DefaultAddr is the default gRPC listen address. It only accepts local connections.
+const DefaultAddr = "127.0.0.1:8788"
+
+// converterServer implements hexviewpb.ConverterServer on top of the service layer.
+type converterServer struct {
+	hexviewpb.UnimplementedConverterServer
+	conv *service.Converter
+}
+
+// NewServer returns a gRPC server with the Converter service and server
+// reflection registered, so tools like grpcurl can discover the API.
+func NewServer(conv *service.Converter, opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(opts...)
+	Register(s, conv)
+	reflection.Register(s)
+	return s
+}
+
+// Register adds the Converter service backed by conv to s.
+func Register(s grpc.ServiceRegistrar, conv *service.Converter) {
+	hexviewpb.RegisterConverterServer(s, &converterServer{conv: conv})
+}
+
+func (s *converterServer) ConvertHex(ctx context.Context, req *hexviewpb.ConvertRequest) (*hexviewpb.ConversionResult, error) {
+	return conversion(s.conv.ConvertHex(req.GetInput()))
+}
+
+func (s *converterServer) ConvertInt(ctx context.Context, req *hexviewpb.ConvertRequest) (*hexviewpb.ConversionResult, error) {
+	return conversion(s.conv.ConvertInt(req.GetInput(), service.OrDefault(models.IntType(req.GetType()), models.Int32)))
+}
+
+func (s *converterServer) ConvertFloat(ctx context.Context, req *hexviewpb.ConvertRequest) (*hexviewpb.ConversionResult, error) {
+	return conversion(s.conv.ConvertFloat(req.GetInput(), service.OrDefault(models.FloatType(req.GetType()), models.Float32)))
+}
+
+func (s *converterServer) ConvertBinary(ctx context.Context, req *hexviewpb.ConvertRequest) (*hexviewpb.ConversionResult, error) {
+	return conversion(s.conv.ConvertBinary(req.GetInput()))
+}
+
+func (s *converterServer) ConvertIntAuto(ctx context.Context, req *hexviewpb.ConvertRequest) (*hexviewpb.ConversionResult, error) {
+	return conversion(s.conv.ConvertIntAuto(req.GetInput()))
+}
+
+func (s *converterServer) ConvertModbusRegisters(ctx context.Context, req *hexviewpb.ConvertRequest) (*hexviewpb.ModbusResult, error) {
+	result, err := s.conv.ConvertModbusRegistersContext(ctx, req.GetInput())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	return modbusToProto(result), nil
+}
+
+func (s *converterServer) Checksum(ctx context.Context, req *hexviewpb.ConvertRequest) (*hexviewpb.ChecksumResult, error) {
+	result, err := s.conv.Checksum(req.GetInput())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	return checksumToProto(result), nil
+}
+
+func (s *converterServer) Diff(ctx context.Context, req *hexviewpb.DiffRequest) (*hexviewpb.DiffResult, error) {
+	result, err := s.conv.Diff(req.GetA(), req.GetB())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	return diffToProto(result), nil
+}
+
+// conversion converts a service conversion result, mapping errors to InvalidArgument.
+func conversion(result *models.ConversionResult, err error) (*hexviewpb.ConversionResult, error) {
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	return conversionToProto(result), nil
+}
+
+// invalidArgument wraps a service error in a gRPC status. All service errors
+// are caused by bad input.
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
@@ -0,0 +1,100 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"hexview/rpc/hexviewpb"
+	"hexview/service"
+)
+
+// newClient starts a server on an in-memory listener and returns a client for it.
+func newClient(t *testing.T) hexviewpb.ConverterClient {
+	t.Helper()
+	ln := bufconn.Listen(1 << 20)
+	srv := NewServer(service.NewConverter())
+	go srv.Serve(ln)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return hexviewpb.NewConverterClient(conn)
+}
+
+func TestConvert(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	hex, err := client.ConvertHex(ctx, &hexviewpb.ConvertRequest{Input: "0xff"})
+	if err != nil {
+		t.Fatalf("ConvertHex() error = %v", err)
+	}
+	if hex.Int8Be == nil || hex.GetInt8Be() != -1 || hex.GetUint8Be() != 255 {
+		t.Errorf("ConvertHex(0xff): int8 = %v, uint8 = %v", hex.Int8Be, hex.Uint8Be)
+	}
+
+	i, err := client.ConvertInt(ctx, &hexviewpb.ConvertRequest{Input: "513", Type: "uint16"})
+	if err != nil {
+		t.Fatalf("ConvertInt() error = %v", err)
+	}
+	if i.GetBytes() != "0201" {
+		t.Errorf("ConvertInt(513, uint16) bytes = %q, want 0201", i.GetBytes())
+	}
+
+	f, err := client.ConvertFloat(ctx, &hexviewpb.ConvertRequest{Input: "1.5"})
+	if err != nil {
+		t.Fatalf("ConvertFloat() error = %v", err)
+	}
+	if f.GetFloat32BeHex() != "3fc00000" {
+		t.Errorf("ConvertFloat(1.5) = %q, want 3fc00000", f.GetFloat32BeHex())
+	}
+}
+
+func TestModbusChecksumDiff(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	mb, err := client.ConvertModbusRegisters(ctx, &hexviewpb.ConvertRequest{Input: "0x4248 0x0000"})
+	if err != nil {
+		t.Fatalf("ConvertModbusRegisters() error = %v", err)
+	}
+	if len(mb.Registers) != 2 || len(mb.Combined32) != 1 || mb.Combined32[0].GetFloat32Be() != "50" {
+		t.Errorf("unexpected modbus result: %v", mb)
+	}
+
+	cs, err := client.Checksum(ctx, &hexviewpb.ConvertRequest{Input: "313233343536373839"})
+	if err != nil {
+		t.Fatalf("Checksum() error = %v", err)
+	}
+	if cs.GetLength() != 9 || len(cs.Checksums) == 0 {
+		t.Errorf("unexpected checksum result: %v", cs)
+	}
+
+	diff, err := client.Diff(ctx, &hexviewpb.DiffRequest{A: "0102", B: "01ff"})
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if diff.GetEqual() || diff.GetFirstDiff() != 1 || len(diff.Ranges) != 1 {
+		t.Errorf("unexpected diff result: %v", diff)
+	}
+}
+
+func TestInvalidArgument(t *testing.T) {
+	client := newClient(t)
+	_, err := client.ConvertHex(context.Background(), &hexviewpb.ConvertRequest{Input: "zz"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ConvertHex(zz) code = %v, want InvalidArgument", status.Code(err))
+	}
+}
@@ -0,0 +1,311 @@
+// Package script runs user-defined conversions written in Starlark, a small
+// Python dialect. A script defines decode(data), which receives the bytes as
+// a Starlark bytes value and returns a dict of named values:
+//
+//	def decode(data):
+//	    raw, status = unpack(">hB", data)
+//	    return {"temperature": raw / 10.0, "alarm": status & 0x80 != 0}
+//
+// A script can also interpret windows of Modbus registers. It sets window to
+// the number of registers and defines modbus(data), which receives the
+// registers of each window as big-endian bytes:
+//
+//	window = 4
+//
+//	def modbus(data):
+//	    high, low = unpack(">II", data)
+//	    return {"energy": high * 10000 + low}
+//
+// Scripts cannot access files or the network, and each run is limited in
+// execution steps and time.
+//
+// Builtins available to scripts:
+//
+//	unpack(format, data, offset=0)  decode values like Python's struct.unpack;
+//	                                format is an optional byte order ('<' little,
+//	                                '>' big, the default) followed by codes
+//	                                b B h H i I q Q f d and x (pad byte),
+//	                                each optionally preceded by a count
+//	hex(data)                       lower case hex string of bytes
+//
+// Example usage:
+//
+//	s, _ := script.Compile("sensor", src)
+//	values, _ := s.Run([]byte{0x00, 0xfa, 0x80})
+//	for _, v := range values {
+//		fmt.Println(v.Name, v.Value) // temperature 25.0, alarm True
+//	}
+package script
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+)
+
+const (
+	// MaxSteps limits the execution steps of a single run.
+	MaxSteps = 1_000_000
+
+	// Timeout limits the run time of a single run.
+	Timeout = time.Second
+)
+
+// MaxWindow is the largest number of registers a modbus function can take.
+const MaxWindow = 64
+
+var (
+	// ErrNoDecode indicates a script defines neither a decode nor a modbus
+	// function
+	ErrNoDecode = errors.New("script does not define decode(data) or modbus(data)")
+
+	// ErrInvalidWindow indicates a modbus function without a valid window
+	ErrInvalidWindow = errors.New("modbus(data) needs window set to a register count")
+)
+
+// fileOptions enables the Starlark features useful for small decoders.
+var fileOptions = &syntax.FileOptions{While: true, TopLevelControl: true, GlobalReassign: true, Recursion: true}
+
+// Value is a named value returned by a script.
+type Value struct {
+	Name  string
+	Value string
+}
+
+// Script is a compiled user script.
+type Script struct {
+	name   string
+	decode starlark.Callable // nil if the script only interprets registers
+	modbus starlark.Callable // nil if the script does not interpret registers
+	window int
+}
+
+// Compile executes the top level of src and looks up its decode and modbus
+// functions. At least one of them must be defined.
+func Compile(name, src string) (*Script, error) {
+	thread := newThread(name)
+	globals, err := starlark.ExecFileOptions(fileOptions, thread, name+".star", src, builtins)
+	if err != nil {
+		return nil, scriptError(err)
+	}
+	s := &Script{name: name}
+	s.decode, _ = globals["decode"].(starlark.Callable)
+	s.modbus, _ = globals["modbus"].(starlark.Callable)
+	if s.decode == nil && s.modbus == nil {
+		return nil, ErrNoDecode
+	}
+	if s.modbus != nil {
+		window, ok := globals["window"].(starlark.Int)
+		if !ok {
+			return nil, ErrInvalidWindow
+		}
+		n, ok := window.Int64()
+		if !ok || n < 1 || n > MaxWindow {
+			return nil, fmt.Errorf("%w: %s (want 1 to %d)", ErrInvalidWindow, window, MaxWindow)
+		}
+		s.window = int(n)
+	}
+	return s, nil
+}
+
+// Name returns the name of the script.
+func (s *Script) Name() string { return s.name }
+
+// HasDecode reports whether the script defines decode(data).
+func (s *Script) HasDecode() bool { return s.decode != nil }
+
+// Window returns the number of registers modbus(data) interprets, or 0 if
+// the script does not define it.
+func (s *Script) Window() int { return s.window }
+
+// Run calls decode(data) and returns the named values in the order of the
+// returned dict.
+func (s *Script) Run(data []byte) ([]Value, error) {
+	if s.decode == nil {
+		return nil, ErrNoDecode
+	}
+	return s.call("decode", s.decode, data)
+}
+
+// RunModbus calls modbus(data) with the big-endian bytes of Window registers
+// and returns the named values in the order of the returned dict.
+func (s *Script) RunModbus(data []byte) ([]Value, error) {
+	if s.modbus == nil {
+		return nil, ErrNoDecode
+	}
+	return s.call("modbus", s.modbus, data)
+}
+
+// call calls fn(data), a function returning a dict of named values.
+func (s *Script) call(name string, fn starlark.Callable, data []byte) ([]Value, error) {
+	thread := newThread(s.name)
+	timer := time.AfterFunc(Timeout, func() { thread.Cancel("timeout") })
+	defer timer.Stop()
+
+	result, err := starlark.Call(thread, fn, starlark.Tuple{starlark.Bytes(data)}, nil)
+	if err != nil {
+		return nil, scriptError(err)
+	}
+	dict, ok := result.(*starlark.Dict)
+	if !ok {
+		return nil, fmt.Errorf("%s must return a dict, got %s", name, result.Type())
+	}
+
+	values := make([]Value, 0, dict.Len())
+	for _, item := range dict.Items() {
+		name, ok := starlark.AsString(item[0])
+		if !ok {
+			name = item[0].String()
+		}
+		values = append(values, Value{Name: name, Value: formatValue(item[1])})
+	}
+	return values, nil
+}
+
+// newThread creates a thread with the execution step limit.
+func newThread(name string) *starlark.Thread {
+	thread := &starlark.Thread{
+		Name:  name,
+		Print: func(*starlark.Thread, string) {},
+	}
+	thread.SetMaxExecutionSteps(MaxSteps)
+	return thread
+}
+
+// scriptError returns the message of a Starlark error including its backtrace position.
+func scriptError(err error) error {
+	var evalErr *starlark.EvalError
+	if errors.As(err, &evalErr) {
+		return errors.New(evalErr.Backtrace())
+	}
+	return err
+}
+
+// formatValue renders a Starlark value for display. Strings are shown
+// without quotes.
+func formatValue(v starlark.Value) string {
+	switch v := v.(type) {
+	case starlark.String:
+		return string(v)
+	case starlark.Bytes:
+		return hex.EncodeToString([]byte(v))
+	case starlark.Float:
+		return strconv.FormatFloat(float64(v), 'g', -1, 64)
+	default:
+		return v.String()
+	}
+}
+
+// builtins are the functions predeclared for scripts.
+var builtins = starlark.StringDict{
+	"unpack": starlark.NewBuiltin("unpack", unpack),
+	"hex":    starlark.NewBuiltin("hex", hexBuiltin),
+}
+
+func hexBuiltin(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var data starlark.Bytes
+	if err := starlark.UnpackPositionalArgs(fn.Name(), args, kwargs, 1, &data); err != nil {
+		return nil, err
+	}
+	return starlark.String(hex.EncodeToString([]byte(data))), nil
+}
+
+func unpack(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var format string
+	var data starlark.Bytes
+	offset := 0
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "format", &format, "data", &data, "offset?", &offset); err != nil {
+		return nil, err
+	}
+	values, err := Unpack(format, []byte(data), offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn.Name(), err)
+	}
+	return starlark.Tuple(values), nil
+}
+
+// Unpack decodes data starting at offset according to a struct format
+// string, as described in the package documentation.
+func Unpack(format string, data []byte, offset int) ([]starlark.Value, error) {
+	var order binary.ByteOrder = binary.BigEndian
+	if len(format) > 0 {
+		switch format[0] {
+		case '<':
+			order, format = binary.LittleEndian, format[1:]
+		case '>', '!':
+			format = format[1:]
+		}
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("negative offset %d", offset)
+	}
+
+	var values []starlark.Value
+	pos := offset
+	for i := 0; i < len(format); i++ {
+		count := 1
+		if c := format[i]; c >= '0' && c <= '9' {
+			j := i
+			for j < len(format) && format[j] >= '0' && format[j] <= '9' {
+				j++
+			}
+			count, _ = strconv.Atoi(format[i:j])
+			i = j
+			if i == len(format) {
+				return nil, fmt.Errorf("count without format code")
+			}
+		}
+		code := format[i]
+		if code == ' ' {
+			continue
+		}
+		size, ok := codeSizes[code]
+		if !ok {
+			return nil, fmt.Errorf("unknown format code %q", code)
+		}
+		for n := 0; n < count; n++ {
+			if pos+size > len(data) {
+				return nil, fmt.Errorf("need %d bytes at offset %d, have %d", size, pos, len(data))
+			}
+			b := data[pos : pos+size]
+			pos += size
+			switch code {
+			case 'x':
+				continue
+			case 'b':
+				values = append(values, starlark.MakeInt(int(int8(b[0]))))
+			case 'B':
+				values = append(values, starlark.MakeInt(int(b[0])))
+			case 'h':
+				values = append(values, starlark.MakeInt(int(int16(order.Uint16(b)))))
+			case 'H':
+				values = append(values, starlark.MakeInt(int(order.Uint16(b))))
+			case 'i':
+				values = append(values, starlark.MakeInt64(int64(int32(order.Uint32(b)))))
+			case 'I':
+				values = append(values, starlark.MakeUint64(uint64(order.Uint32(b))))
+			case 'q':
+				values = append(values, starlark.MakeInt64(int64(order.Uint64(b))))
+			case 'Q':
+				values = append(values, starlark.MakeUint64(order.Uint64(b)))
+			case 'f':
+				values = append(values, starlark.Float(math.Float32frombits(order.Uint32(b))))
+			case 'd':
+				values = append(values, starlark.Float(math.Float64frombits(order.Uint64(b))))
+			}
+		}
+	}
+	return values, nil
+}
+
+// codeSizes maps format codes to their size in bytes.
+var codeSizes = map[byte]int{
+	'x': 1, 'b': 1, 'B': 1, 'h': 2, 'H': 2, 'i': 4, 'I': 4, 'q': 8, 'Q': 8, 'f': 4, 'd': 8,
+}
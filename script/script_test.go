@@ -0,0 +1,151 @@
+package script
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	src := `
+def decode(data):
+    raw, status = unpack(">hB", data)
+    return {"temperature": raw / 10.0, "alarm": status & 0x80 != 0, "raw": hex(data), "name": "probe"}
+`
+	s, err := Compile("sensor", src)
+	if err != nil {
+		t.Fatalf("Compile() error: %v", err)
+	}
+	values, err := s.Run([]byte{0x00, 0xfa, 0x80})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	want := []Value{{"temperature", "25"}, {"alarm", "True"}, {"raw", "00fa80"}, {"name", "probe"}}
+	if len(values) != len(want) {
+		t.Fatalf("Run() = %v, want %v", values, want)
+	}
+	for i := range want {
+		if values[i] != want[i] {
+			t.Errorf("value %d = %v, want %v", i, values[i], want[i])
+		}
+	}
+}
+
+func TestRunModbus(t *testing.T) {
+	src := `
+window = 4
+
+def modbus(data):
+    high, low = unpack(">II", data)
+    return {"energy": high * 10000 + low}
+`
+	s, err := Compile("meter", src)
+	if err != nil {
+		t.Fatalf("Compile() error: %v", err)
+	}
+	if s.Window() != 4 || s.HasDecode() {
+		t.Errorf("Window() = %d, HasDecode() = %v, want 4, false", s.Window(), s.HasDecode())
+	}
+	values, err := s.RunModbus([]byte{0, 0, 0, 12, 0, 0, 0x04, 0xd2})
+	if err != nil {
+		t.Fatalf("RunModbus() error: %v", err)
+	}
+	if len(values) != 1 || values[0] != (Value{"energy", "121234"}) {
+		t.Errorf("RunModbus() = %v, want [{energy 121234}]", values)
+	}
+	if _, err := s.Run(nil); !errors.Is(err, ErrNoDecode) {
+		t.Errorf("Run() error = %v, want ErrNoDecode", err)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"syntax error", "def decode(data)\n", "sensor.star:2"},
+		{"no decode", "x = 1\n", ErrNoDecode.Error()},
+		{"decode not callable", "decode = 1\n", ErrNoDecode.Error()},
+		{"top level failure", "fail('broken')\n", "broken"},
+		{"no file access", "load('os.star', 'os')\n", "load"},
+		{"modbus without window", "def modbus(data):\n    return {}\n", ErrInvalidWindow.Error()},
+		{"window out of range", "window = 0\ndef modbus(data):\n    return {}\n", "want 1 to 64"},
+		{"window not an int", "window = '2'\ndef modbus(data):\n    return {}\n", ErrInvalidWindow.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile("sensor", tt.src)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Compile() error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"wrong return type", "def decode(data):\n    return 1\n", "must return a dict"},
+		{"short data", "def decode(data):\n    return {'v': unpack('I', data)[0]}\n", "need 4 bytes"},
+		{"runtime error", "def decode(data):\n    return {'v': 1 // 0}\n", "division by zero"},
+		{"endless loop", "def decode(data):\n    while True:\n        pass\n", "too many steps"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Compile("sensor", tt.src)
+			if err != nil {
+				t.Fatalf("Compile() error: %v", err)
+			}
+			_, err = s.Run([]byte{0x01, 0x02})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Run() error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnpack(t *testing.T) {
+	data := []byte{0xff, 0xfe, 0x3f, 0x80, 0x00, 0x00}
+	tests := []struct {
+		format string
+		offset int
+		want   string
+	}{
+		{"b", 0, "-1"},
+		{"B", 0, "255"},
+		{">h", 0, "-2"},
+		{"<H", 0, "65279"},
+		{"xxf", 0, "1.0"},
+		{"2B", 0, "255 254"},
+		{">I", 2, "1065353216"},
+		{"", 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			values, err := Unpack(tt.format, data, tt.offset)
+			if err != nil {
+				t.Fatalf("Unpack() error: %v", err)
+			}
+			got := make([]string, len(values))
+			for i, v := range values {
+				got[i] = v.String()
+			}
+			if s := strings.Join(got, " "); s != tt.want {
+				t.Errorf("Unpack(%q) = %q, want %q", tt.format, s, tt.want)
+			}
+		})
+	}
+
+	for _, format := range []string{"z", "2", ">q"} {
+		if _, err := Unpack(format, data, 0); err == nil {
+			t.Errorf("Unpack(%q) expected error", format)
+		}
+	}
+	if _, err := Unpack("B", data, -1); err == nil {
+		t.Error("Unpack() expected error for negative offset")
+	}
+}
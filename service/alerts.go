@@ -0,0 +1,358 @@
+package service
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"hexview/capture"
+	"hexview/convert"
+	"hexview/models"
+)
+
+var (
+	// ErrAlertRuleNotFound indicates an unknown alert rule ID was used
+	ErrAlertRuleNotFound = errors.New("alert rule not found")
+
+	// ErrInvalidAlertRule indicates an alert rule that cannot be evaluated
+	ErrInvalidAlertRule = errors.New("invalid alert rule")
+)
+
+// AlertService evaluates alert rules on the samples of Modbus polls and the
+// bytes received by capture sessions. Rules are stored in a JSON file; with
+// an empty path they are kept in memory only. An alert is reported when a
+// rule starts matching the value of a poll or capture and again when it
+// stops matching, not for every sample.
+type AlertService struct {
+	mu     sync.Mutex
+	path   string
+	rules  []models.AlertRule
+	nextID int64
+	active map[alertKey]models.Alert // raised alerts by rule and poll or capture
+}
+
+// alertKey identifies the state of a rule for one poll or capture session.
+type alertKey struct {
+	rule   int64
+	source string // poll or capture ID
+}
+
+// alertsFile is the on-disk format of the alert rules.
+type alertsFile struct {
+	NextID int64              `json:"nextId"`
+	Rules  []models.AlertRule `json:"rules"`
+}
+
+// NewAlertService creates an AlertService without rules backed by the file
+// at path. Call Load to read the file.
+func NewAlertService(path string) *AlertService {
+	return &AlertService{path: path, nextID: 1, active: make(map[alertKey]models.Alert)}
+}
+
+// Load reads the alert rules file. A missing file leaves the list empty.
+func (s *AlertService) Load() error {
+	if s.path == "" {
+		return nil
+	}
+	var f alertsFile
+	if err := loadJSON(s.path, &f); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rules = f.Rules
+	s.nextID = max(f.NextID, 1)
+	clear(s.active)
+	return nil
+}
+
+// List returns all alert rules in the order they were added.
+func (s *AlertService) List() []models.AlertRule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append(make([]models.AlertRule, 0, len(s.rules)), s.rules...)
+}
+
+// Add stores a new alert rule.
+func (s *AlertService) Add(rule models.AlertRule) (*models.AlertRule, error) {
+	rule.Label = strings.TrimSpace(rule.Label)
+	if err := validateAlertRule(rule); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rule.ID = s.nextID
+	s.nextID++
+	s.rules = append(s.rules, rule)
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Update replaces an alert rule. Its alerts are cleared without being
+// reported, and raised again by the next matching sample.
+func (s *AlertService) Update(rule models.AlertRule) (*models.AlertRule, error) {
+	rule.Label = strings.TrimSpace(rule.Label)
+	if err := validateAlertRule(rule); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, err := s.index(rule.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.rules[i] = rule
+	s.clearRule(rule.ID)
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Remove deletes an alert rule and its alerts.
+func (s *AlertService) Remove(id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, err := s.index(id)
+	if err != nil {
+		return err
+	}
+	s.rules = slices.Delete(s.rules, i, i+1)
+	s.clearRule(id)
+	return s.save()
+}
+
+// Active returns the raised alerts ordered by rule, poll and capture.
+func (s *AlertService) Active() []models.Alert {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	alerts := make([]models.Alert, 0, len(s.active))
+	for _, a := range s.active {
+		alerts = append(alerts, a)
+	}
+	slices.SortFunc(alerts, func(a, b models.Alert) int {
+		return cmp.Or(cmp.Compare(a.Rule.ID, b.Rule.ID), strings.Compare(a.PollID, b.PollID), strings.Compare(a.CaptureID, b.CaptureID))
+	})
+	return alerts
+}
+
+// ClearPoll discards the alerts of a poll, e.g. when it is removed.
+func (s *AlertService) ClearPoll(pollID string) {
+	s.clearSource(pollID)
+}
+
+// ClearCapture discards the alerts of a capture session, e.g. when it is
+// removed.
+func (s *AlertService) ClearCapture(captureID string) {
+	s.clearSource(captureID)
+}
+
+// clearSource discards the alerts of a poll or capture session.
+func (s *AlertService) clearSource(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key := range s.active {
+		if key.source == id {
+			delete(s.active, key)
+		}
+	}
+}
+
+// Evaluate checks the samples of a poll read against all rules and returns
+// the alerts that were raised or cleared by them. Samples with errors and
+// values a rule cannot test (e.g. bits of a float) leave the state unchanged.
+func (s *AlertService) Evaluate(samples []models.PollSample) []models.Alert {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var changed []models.Alert
+	for _, sample := range samples {
+		if sample.Error != "" {
+			continue
+		}
+		for _, rule := range s.rules {
+			if rule.Source != models.AlertSourcePoll || rule.Value != sample.Name {
+				continue
+			}
+			match, ok := matchAlertRule(rule, sample)
+			if !ok {
+				continue
+			}
+			alert := models.Alert{
+				Rule:      rule,
+				PollID:    sample.PollID,
+				Timestamp: sample.Timestamp,
+				Value:     sample.Value,
+				Raw:       sample.Raw,
+				Active:    match,
+			}
+			if s.transition(alertKey{rule: rule.ID, source: sample.PollID}, alert) {
+				changed = append(changed, alert)
+			}
+		}
+	}
+	return changed
+}
+
+// EvaluateCapture checks a chunk received by a capture session against the
+// capture rules and returns the alerts that were raised or cleared by it.
+// Each rule reads an unsigned integer at its offset in the chunk; chunks too
+// short for it, and sent chunks, leave the state unchanged.
+func (s *AlertService) EvaluateCapture(chunk models.CaptureChunk) []models.Alert {
+	if chunk.Direction != string(capture.RX) {
+		return nil
+	}
+	data, err := convert.HexToBytes(chunk.Hex)
+	if err != nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var changed []models.Alert
+	for _, rule := range s.rules {
+		if rule.Source != models.AlertSourceCapture {
+			continue
+		}
+		size := max(rule.Size, 1)
+		if rule.Offset+size > len(data) {
+			continue
+		}
+		field := data[rule.Offset : rule.Offset+size]
+		var v uint64
+		for i := range field {
+			b := field[i]
+			if rule.ByteOrder == "LE" {
+				b = field[size-1-i]
+			}
+			v = v<<8 | uint64(b)
+		}
+		value := strconv.FormatUint(v, 10)
+		match, _ := matchAlertRule(rule, models.PollSample{Value: value, Raw: value})
+		alert := models.Alert{
+			Rule:      rule,
+			CaptureID: chunk.CaptureID,
+			Timestamp: chunk.Timestamp,
+			Value:     value,
+			Raw:       convert.BytesToHex(field),
+			Active:    match,
+		}
+		if s.transition(alertKey{rule: rule.ID, source: chunk.CaptureID}, alert) {
+			changed = append(changed, alert)
+		}
+	}
+	return changed
+}
+
+// transition raises or clears the alert of key and reports whether its
+// state changed. Must be called with s.mu held.
+func (s *AlertService) transition(key alertKey, alert models.Alert) bool {
+	if _, active := s.active[key]; active == alert.Active {
+		return false
+	}
+	if alert.Active {
+		s.active[key] = alert
+	} else {
+		delete(s.active, key)
+	}
+	return true
+}
+
+// matchAlertRule reports whether sample meets the condition of rule. ok is
+// false if the value cannot be tested.
+func matchAlertRule(rule models.AlertRule, sample models.PollSample) (match, ok bool) {
+	switch rule.Condition {
+	case models.AlertAbove, models.AlertBelow:
+		v, err := strconv.ParseFloat(sample.Value, 64)
+		if err != nil {
+			return false, false
+		}
+		if rule.Condition == models.AlertAbove {
+			return v > rule.Threshold, true
+		}
+		return v < rule.Threshold, true
+	default:
+		raw, err := strconv.ParseUint(sample.Raw, 10, 64)
+		if err != nil {
+			signed, serr := strconv.ParseInt(sample.Raw, 10, 64)
+			if serr != nil {
+				return false, false
+			}
+			raw = uint64(signed)
+		}
+		set := raw&(1<<rule.Bit) != 0
+		return set == (rule.Condition == models.AlertBitSet), true
+	}
+}
+
+// clearRule discards the alerts of a rule. Must be called with s.mu held.
+func (s *AlertService) clearRule(id int64) {
+	for key := range s.active {
+		if key.rule == id {
+			delete(s.active, key)
+		}
+	}
+}
+
+// index returns the position of a rule. Must be called with s.mu held.
+func (s *AlertService) index(id int64) (int, error) {
+	i := slices.IndexFunc(s.rules, func(r models.AlertRule) bool { return r.ID == id })
+	if i < 0 {
+		return 0, fmt.Errorf("%w: %d", ErrAlertRuleNotFound, id)
+	}
+	return i, nil
+}
+
+// save writes the alert rules file. Must be called with s.mu held.
+func (s *AlertService) save() error {
+	if s.path == "" {
+		return nil
+	}
+	return saveJSON(s.path, alertsFile{NextID: s.nextID, Rules: s.rules})
+}
+
+// validateAlertRule checks that a rule names a value, or the integer of
+// captured bytes, and has a known condition.
+func validateAlertRule(rule models.AlertRule) error {
+	bits := 64
+	switch rule.Source {
+	case models.AlertSourcePoll:
+		if rule.Value == "" {
+			return fmt.Errorf("%w: empty value name", ErrInvalidAlertRule)
+		}
+	case models.AlertSourceCapture:
+		if rule.Offset < 0 {
+			return fmt.Errorf("%w: negative offset %d", ErrInvalidAlertRule, rule.Offset)
+		}
+		if !slices.Contains([]int{0, 1, 2, 4, 8}, rule.Size) {
+			return fmt.Errorf("%w: size %d (want 1, 2, 4 or 8 bytes)", ErrInvalidAlertRule, rule.Size)
+		}
+		if rule.ByteOrder != "" && rule.ByteOrder != "BE" && rule.ByteOrder != "LE" {
+			return fmt.Errorf("%w: unknown byte order %q", ErrInvalidAlertRule, rule.ByteOrder)
+		}
+		bits = 8 * max(rule.Size, 1)
+	default:
+		return fmt.Errorf("%w: unknown source %q", ErrInvalidAlertRule, rule.Source)
+	}
+	switch rule.Condition {
+	case models.AlertAbove, models.AlertBelow:
+	case models.AlertBitSet, models.AlertBitClear:
+		if rule.Bit < 0 || rule.Bit >= bits {
+			return fmt.Errorf("%w: bit %d (want 0 to %d)", ErrInvalidAlertRule, rule.Bit, bits-1)
+		}
+	default:
+		return fmt.Errorf("%w: unknown condition %q", ErrInvalidAlertRule, rule.Condition)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"hexview/models"
+)
+
+func TestAlertService_Evaluate(t *testing.T) {
+	s := NewAlertService("")
+	high, err := s.Add(models.AlertRule{Label: "Overvoltage", Value: "voltage", Condition: models.AlertAbove, Threshold: 250})
+	if err != nil {
+		t.Fatalf("Add() error: %v", err)
+	}
+	fault, _ := s.Add(models.AlertRule{Label: "Fault", Value: "status", Condition: models.AlertBitSet, Bit: 3})
+
+	sample := func(poll, name, value, raw string) models.PollSample {
+		return models.PollSample{PollID: poll, Name: name, Value: value, Raw: raw}
+	}
+	steps := []struct {
+		samples []models.PollSample
+		want    []bool // Active of the reported alerts
+		rules   []int64
+	}{
+		{[]models.PollSample{sample("poll-1", "voltage", "230", "2300"), sample("poll-1", "status", "1", "1")}, nil, nil},
+		{[]models.PollSample{sample("poll-1", "voltage", "251.5", "2515"), sample("poll-1", "status", "9", "9")}, []bool{true, true}, []int64{high.ID, fault.ID}},
+		// Alerts are reported once, not for every matching sample
+		{[]models.PollSample{sample("poll-1", "voltage", "260", "2600"), sample("poll-1", "status", "9", "9")}, nil, nil},
+		// Another poll has its own state
+		{[]models.PollSample{sample("poll-2", "voltage", "260", "2600")}, []bool{true}, []int64{high.ID}},
+		{[]models.PollSample{sample("poll-1", "voltage", "240", "2400"), sample("poll-1", "status", "-32760", "-32760")}, []bool{false}, []int64{high.ID}},
+		{[]models.PollSample{{PollID: "poll-1", Name: "status", Error: "needs registers 1 to 2"}}, nil, nil},
+		{[]models.PollSample{sample("poll-1", "status", "1.5", "1.5")}, nil, nil},
+		{[]models.PollSample{sample("poll-1", "status", "0", "0")}, []bool{false}, []int64{fault.ID}},
+	}
+	for i, step := range steps {
+		alerts := s.Evaluate(step.samples)
+		if len(alerts) != len(step.want) {
+			t.Fatalf("step %d: Evaluate() = %+v, want %d alerts", i, alerts, len(step.want))
+		}
+		for j, a := range alerts {
+			if a.Active != step.want[j] || a.Rule.ID != step.rules[j] {
+				t.Errorf("step %d: alert %d = %+v, want rule %d active %v", i, j, a, step.rules[j], step.want[j])
+			}
+		}
+	}
+
+	active := s.Active()
+	if len(active) != 1 || active[0].PollID != "poll-2" || active[0].Value != "260" {
+		t.Errorf("Active() = %+v, want the overvoltage of poll-2", active)
+	}
+	s.ClearPoll("poll-2")
+	if active := s.Active(); len(active) != 0 {
+		t.Errorf("Active() after ClearPoll = %+v", active)
+	}
+}
+
+func TestAlertService_EvaluateCapture(t *testing.T) {
+	s := NewAlertService("")
+	fault, err := s.Add(models.AlertRule{Label: "Fault", Source: models.AlertSourceCapture, Offset: 2, Condition: models.AlertBitSet, Bit: 7})
+	if err != nil {
+		t.Fatalf("Add() error: %v", err)
+	}
+	temp, _ := s.Add(models.AlertRule{Label: "Hot", Source: models.AlertSourceCapture, Offset: 0, Size: 2, ByteOrder: "LE", Condition: models.AlertAbove, Threshold: 500})
+	if _, err := s.Add(models.AlertRule{Label: "Voltage", Value: "voltage", Condition: models.AlertAbove}); err != nil {
+		t.Fatal(err)
+	}
+
+	chunk := func(capture, dir, hex string) models.CaptureChunk {
+		return models.CaptureChunk{CaptureID: capture, Direction: dir, Hex: hex}
+	}
+	steps := []struct {
+		chunk models.CaptureChunk
+		want  []bool
+		rules []int64
+	}{
+		{chunk("capture-1", "rx", "e80100"), nil, nil},
+		{chunk("capture-1", "rx", "f50180"), []bool{true, true}, []int64{fault.ID, temp.ID}},
+		// Sent and too short chunks leave the state unchanged
+		{chunk("capture-1", "tx", "000000"), nil, nil},
+		{chunk("capture-1", "rx", "00"), nil, nil},
+		{chunk("capture-2", "rx", "000080"), []bool{true}, []int64{fault.ID}},
+		{chunk("capture-1", "rx", "f50100"), []bool{false}, []int64{fault.ID}},
+	}
+	for i, step := range steps {
+		alerts := s.EvaluateCapture(step.chunk)
+		if len(alerts) != len(step.want) {
+			t.Fatalf("step %d: EvaluateCapture() = %+v, want %d alerts", i, alerts, len(step.want))
+		}
+		for j, a := range alerts {
+			if a.Active != step.want[j] || a.Rule.ID != step.rules[j] || a.CaptureID != step.chunk.CaptureID {
+				t.Errorf("step %d: alert %d = %+v, want rule %d active %v", i, j, a, step.rules[j], step.want[j])
+			}
+		}
+	}
+
+	active := s.Active()
+	if len(active) != 2 || active[0].CaptureID != "capture-2" || active[1].Value != "501" || active[1].Raw != "f501" {
+		t.Errorf("Active() = %+v", active)
+	}
+	s.ClearCapture("capture-1")
+	if active := s.Active(); len(active) != 1 {
+		t.Errorf("Active() after ClearCapture = %+v", active)
+	}
+	if alerts := s.Evaluate([]models.PollSample{{PollID: "poll-1", Name: "", Value: "1000"}}); len(alerts) != 0 {
+		t.Errorf("Capture rules evaluated on poll samples: %+v", alerts)
+	}
+}
+
+func TestAlertService_Rules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.json")
+	s := NewAlertService(path)
+	rule, err := s.Add(models.AlertRule{Label: " Low ", Value: "40001", Condition: models.AlertBelow, Threshold: 10})
+	if err != nil {
+		t.Fatalf("Add() error: %v", err)
+	}
+	if rule.ID != 1 || rule.Label != "Low" {
+		t.Errorf("Add() = %+v", rule)
+	}
+	s.Evaluate([]models.PollSample{{PollID: "poll-1", Name: "40001", Value: "5"}})
+
+	rule.Threshold = 1
+	if _, err := s.Update(*rule); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	if active := s.Active(); len(active) != 0 {
+		t.Errorf("Active() after Update = %+v, want none", active)
+	}
+
+	loaded := NewAlertService(path)
+	if err := loaded.Load(); err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if rules := loaded.List(); len(rules) != 1 || rules[0].Threshold != 1 {
+		t.Errorf("List() after Load = %+v", rules)
+	}
+	if err := loaded.Remove(rule.ID); err != nil {
+		t.Fatalf("Remove() error: %v", err)
+	}
+	if err := loaded.Remove(rule.ID); !errors.Is(err, ErrAlertRuleNotFound) {
+		t.Errorf("Remove() error = %v, want ErrAlertRuleNotFound", err)
+	}
+
+	invalid := []models.AlertRule{
+		{Condition: models.AlertAbove},
+		{Value: "v", Condition: "equals"},
+		{Value: "v", Condition: models.AlertBitClear, Bit: 64},
+		{Source: "file", Value: "v", Condition: models.AlertAbove},
+		{Source: models.AlertSourceCapture, Offset: -1, Condition: models.AlertAbove},
+		{Source: models.AlertSourceCapture, Size: 3, Condition: models.AlertAbove},
+		{Source: models.AlertSourceCapture, ByteOrder: "CDAB", Condition: models.AlertAbove},
+		{Source: models.AlertSourceCapture, Condition: models.AlertBitSet, Bit: 8},
+	}
+	for _, r := range invalid {
+		if _, err := s.Add(r); !errors.Is(err, ErrInvalidAlertRule) {
+			t.Errorf("Add(%+v) error = %v, want ErrInvalidAlertRule", r, err)
+		}
+	}
+}
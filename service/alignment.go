@@ -0,0 +1,64 @@
+package service
+
+import (
+	"math/big"
+	"math/bits"
+
+	"hexview/models"
+)
+
+// alignmentBoundaries are the blocks AnalyzeAddress locates an address in.
+var alignmentBoundaries = []struct {
+	name string
+	size uint64
+}{
+	{"word (4 B)", 4},
+	{"double word (8 B)", 8},
+	{"cache line (32 B)", 32},
+	{"cache line (64 B)", 64},
+	{"page (4 KiB)", 4 << 10},
+	{"page (64 KiB)", 64 << 10},
+	{"huge page (2 MiB)", 2 << 20},
+}
+
+// AnalyzeAddress reports the alignment of an address: the largest power of
+// two it is a multiple of, and its offset in words, cache lines and pages,
+// for MMU and DMA issues. If base is not empty the offset from base, e.g.
+// the start of a section or DMA buffer, is reported too. Numbers are
+// decimal or 0x hex.
+func (c *Converter) AnalyzeAddress(address, base string) (*models.AddressAlignment, error) {
+	a, err := offsetOperand(address, "address", false)
+	if err != nil {
+		return nil, err
+	}
+	addr := a.Uint64()
+
+	result := &models.AddressAlignment{
+		Address:   addressValue(a),
+		Alignment: 1 << 63,
+	}
+	if addr != 0 {
+		result.Alignment = 1 << bits.TrailingZeros64(addr)
+	}
+	for _, b := range alignmentBoundaries {
+		offset := addr & (b.size - 1)
+		result.Boundaries = append(result.Boundaries, models.AlignmentBoundary{
+			Name:    b.name,
+			Size:    b.size,
+			Start:   addressValue(new(big.Int).SetUint64(addr - offset)),
+			Offset:  addressValue(new(big.Int).SetUint64(offset)),
+			Aligned: offset == 0,
+		})
+	}
+
+	if base != "" {
+		b, err := offsetOperand(base, "base address", false)
+		if err != nil {
+			return nil, err
+		}
+		baseValue := addressValue(b)
+		offset := addressValue(new(big.Int).Sub(a, b))
+		result.Base, result.BaseOffset = &baseValue, &offset
+	}
+	return result, nil
+}
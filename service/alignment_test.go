@@ -0,0 +1,61 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAnalyzeAddress(t *testing.T) {
+	c := NewConverter()
+	r, err := c.AnalyzeAddress("0x20001044", "0x20000000")
+	if err != nil {
+		t.Fatalf("AnalyzeAddress() error: %v", err)
+	}
+	if r.Address.Decimal != "536875076" || r.Alignment != 4 {
+		t.Errorf("AnalyzeAddress() = %s aligned to %d, want 536875076 aligned to 4", r.Address.Decimal, r.Alignment)
+	}
+	want := map[string]struct {
+		start, offset string
+		aligned       bool
+	}{
+		"word (4 B)":        {"0x20001044", "0x0", true},
+		"double word (8 B)": {"0x20001040", "0x4", false},
+		"cache line (64 B)": {"0x20001040", "0x4", false},
+		"page (4 KiB)":      {"0x20001000", "0x44", false},
+		"huge page (2 MiB)": {"0x20000000", "0x1044", false},
+	}
+	for _, b := range r.Boundaries {
+		w, ok := want[b.Name]
+		if !ok {
+			continue
+		}
+		if b.Start.Hex != w.start || b.Offset.Hex != w.offset || b.Aligned != w.aligned {
+			t.Errorf("%s = %s + %s (aligned %v), want %s + %s (aligned %v)",
+				b.Name, b.Start.Hex, b.Offset.Hex, b.Aligned, w.start, w.offset, w.aligned)
+		}
+	}
+	if r.BaseOffset == nil || r.BaseOffset.Hex != "0x1044" {
+		t.Errorf("BaseOffset = %+v, want 0x1044", r.BaseOffset)
+	}
+
+	r, err = c.AnalyzeAddress("4096", "0x2000")
+	if err != nil {
+		t.Fatalf("AnalyzeAddress() error: %v", err)
+	}
+	if r.Alignment != 4096 || r.BaseOffset.Decimal != "-4096" {
+		t.Errorf("AnalyzeAddress(4096) = alignment %d, base offset %s, want 4096, -4096", r.Alignment, r.BaseOffset.Decimal)
+	}
+	if r, _ := c.AnalyzeAddress("0", ""); r.Alignment != 1<<63 || r.Base != nil {
+		t.Errorf("AnalyzeAddress(0) = %+v, want alignment 2^63 and no base", r)
+	}
+}
+
+func TestAnalyzeAddress_Errors(t *testing.T) {
+	c := NewConverter()
+	if _, err := c.AnalyzeAddress("0x1_0000_0000_0000_0000", ""); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange, got %v", err)
+	}
+	if _, err := c.AnalyzeAddress("0x10", "zz"); !errors.Is(err, ErrInvalidNumber) {
+		t.Errorf("Expected ErrInvalidNumber, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"hexview/models"
+)
+
+func TestComputeALU(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		name      string
+		a, b, op  string
+		bits      int
+		hex       string
+		signed    int64
+		flags     models.ALUFlags
+		exactUnsg string
+		exactSgn  string
+	}{
+		{"add no flags", "1", "2", "add", 8, "03", 3, models.ALUFlags{}, "3", "3"},
+		{"add signed overflow", "127", "1", "add", 8, "80", -128, models.ALUFlags{Overflow: true, Negative: true}, "128", "128"},
+		{"add carry to zero", "0xff", "1", "add", 8, "00", 0, models.ALUFlags{Carry: true, Zero: true}, "256", "0"},
+		{"add both", "0x80", "0x80", "add", 8, "00", 0, models.ALUFlags{Carry: true, Overflow: true, Zero: true}, "256", "-256"},
+		{"sub borrow", "1", "2", "sub", 16, "ffff", -1, models.ALUFlags{Carry: true, Negative: true}, "-1", "-1"},
+		{"sub signed overflow", "-32768", "1", "sub", 16, "7fff", 32767, models.ALUFlags{Overflow: true}, "32767", "-32769"},
+		{"mul", "-2", "3", "mul", 32, "fffffffa", -6, models.ALUFlags{Carry: true, Negative: true}, "12884901882", "-6"},
+		{"mul 64-bit overflow", "0x100000000", "0x100000000", "mul", 64, "0000000000000000", 0, models.ALUFlags{Carry: true, Overflow: true, Zero: true}, "18446744073709551616", "18446744073709551616"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := c.ComputeALU(tt.a, tt.b, tt.op, tt.bits)
+			if err != nil {
+				t.Fatalf("ComputeALU(%s %s %s) error: %v", tt.a, tt.op, tt.b, err)
+			}
+			if r.Result.Hex != tt.hex || r.Result.Signed != tt.signed || r.Flags != tt.flags {
+				t.Errorf("ComputeALU(%s %s %s) = %s (%d) %+v, want %s (%d) %+v",
+					tt.a, tt.op, tt.b, r.Result.Hex, r.Result.Signed, r.Flags, tt.hex, tt.signed, tt.flags)
+			}
+			if r.UnsignedExact != tt.exactUnsg || r.SignedExact != tt.exactSgn {
+				t.Errorf("exact = %s / %s, want %s / %s", r.UnsignedExact, r.SignedExact, tt.exactUnsg, tt.exactSgn)
+			}
+		})
+	}
+}
+
+func TestComputeALU_Errors(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		a, b, op string
+		bits     int
+		want     error
+	}{
+		{"1", "2", "div", 8, ErrUnsupportedType},
+		{"1", "2", "add", 12, ErrUnsupportedType},
+		{"256", "1", "add", 8, ErrOutOfRange},
+		{"-129", "1", "add", 8, ErrOutOfRange},
+		{"1", "x", "add", 8, ErrInvalidNumber},
+	}
+	for _, tt := range tests {
+		if _, err := c.ComputeALU(tt.a, tt.b, tt.op, tt.bits); !errors.Is(err, tt.want) {
+			t.Errorf("ComputeALU(%s %s %s, %d) error = %v, want %v", tt.a, tt.op, tt.b, tt.bits, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"strconv"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+// MaxArrayValues is the largest number of values listed in an array result.
+const MaxArrayValues = 65536
+
+// arrayElement decodes the values of an array of one type and byte order.
+type arrayElement struct {
+	size   int
+	number func(b []byte) float64 // value as a number, e.g. for statistics
+	format func(b []byte) string  // exact value for display
+}
+
+// elementOf returns the arrayElement of values of size bytes read by read.
+func elementOf[T int8 | int16 | int32 | int64 | uint8 | uint16 | uint32 | uint64 | float32 | float64](size int, read func([]byte) T, format func(T) string) arrayElement {
+	return arrayElement{
+		size:   size,
+		number: func(b []byte) float64 { return float64(read(b)) },
+		format: func(b []byte) string { return format(read(b)) },
+	}
+}
+
+// ConvertArray decodes the whole hex input as consecutive values of typ
+// (int8 to uint64, float32 or float64) in the given byte order (BE, LE,
+// BADC or CDAB), e.g. 256 × int16 LE, instead of only the first value.
+// Trailing bytes too short for a value are reported as the remainder. The
+// result includes statistics of the values.
+func (c *Converter) ConvertArray(hexInput, typ, order string) (*models.ArrayResult, error) {
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+	result, numbers, err := decodeArray(data, typ, order)
+	if err != nil {
+		return nil, err
+	}
+	result.Stats = arrayStats(numbers)
+	return result, nil
+}
+
+// decodeArray decodes data as an array of typ in order and also returns
+// the listed values as numbers.
+func decodeArray(data []byte, typ, order string) (*models.ArrayResult, []float64, error) {
+	element, err := arrayElementOf(typ, order)
+	if err != nil {
+		return nil, nil, err
+	}
+	size := element.size
+	result := &models.ArrayResult{
+		Type:      typ,
+		Order:     order,
+		Size:      size,
+		Count:     len(data) / size,
+		Remainder: len(data) % size,
+	}
+	n := min(result.Count, MaxArrayValues)
+	result.Truncated = n < result.Count
+	result.Values = make([]string, n)
+	numbers := make([]float64, n)
+	for i := range n {
+		b := data[i*size : (i+1)*size]
+		result.Values[i], numbers[i] = element.format(b), element.number(b)
+	}
+	return result, numbers, nil
+}
+
+// arrayElementOf returns the decoder of values of typ in order.
+func arrayElementOf(typ, order string) (arrayElement, error) {
+	o, ok := parseByteOrder(order)
+	if !ok {
+		return arrayElement{}, errUnsupportedType("array", typ+" "+order)
+	}
+
+	formatInt := func(v int64) string { return strconv.FormatInt(v, 10) }
+	formatUint := func(v uint64) string { return strconv.FormatUint(v, 10) }
+	switch typ {
+	case string(models.Int8):
+		return elementOf(1, func(b []byte) int8 { v, _ := convert.BytesToInt8(b); return v },
+			func(v int8) string { return formatInt(int64(v)) }), nil
+	case string(models.Int16):
+		return elementOf(2, func(b []byte) int16 { v, _ := convert.BytesToInt16(b, o); return v },
+			func(v int16) string { return formatInt(int64(v)) }), nil
+	case string(models.Int32):
+		return elementOf(4, func(b []byte) int32 { v, _ := convert.BytesToInt32(b, o); return v },
+			func(v int32) string { return formatInt(int64(v)) }), nil
+	case string(models.Int64):
+		return elementOf(8, func(b []byte) int64 { v, _ := convert.BytesToInt64(b, o); return v }, formatInt), nil
+	case string(models.Uint8):
+		return elementOf(1, func(b []byte) uint8 { v, _ := convert.BytesToUint8(b); return v },
+			func(v uint8) string { return formatUint(uint64(v)) }), nil
+	case string(models.Uint16):
+		return elementOf(2, func(b []byte) uint16 { v, _ := convert.BytesToUint16(b, o); return v },
+			func(v uint16) string { return formatUint(uint64(v)) }), nil
+	case string(models.Uint32):
+		return elementOf(4, func(b []byte) uint32 { v, _ := convert.BytesToUint32(b, o); return v },
+			func(v uint32) string { return formatUint(uint64(v)) }), nil
+	case string(models.Uint64):
+		return elementOf(8, func(b []byte) uint64 { v, _ := convert.BytesToUint64(b, o); return v }, formatUint), nil
+	case string(models.Float32):
+		return elementOf(4, func(b []byte) float32 { v, _ := convert.BytesToFloat32(b, o); return v }, formatFloat32), nil
+	case string(models.Float64):
+		return elementOf(8, func(b []byte) float64 { v, _ := convert.BytesToFloat64(b, o); return v }, formatFloat64), nil
+	}
+	return arrayElement{}, errUnsupportedType("array", typ)
+}
+
+// parseByteOrder returns the byte order named BE, LE, BADC or CDAB.
+func parseByteOrder(name string) (convert.ByteOrder, bool) {
+	for _, o := range []convert.ByteOrder{convert.BigEndian, convert.LittleEndian, convert.MidBigEndian, convert.MidLittleEndian} {
+		if o.String() == name {
+			return o, true
+		}
+	}
+	return 0, false
+}
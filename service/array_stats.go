@@ -0,0 +1,91 @@
+package service
+
+import (
+	"math"
+
+	"hexview/models"
+)
+
+// arrayStats summarizes the finite values of numbers, or returns nil if
+// there are none. Monotonic, step and runs are computed over the finite
+// values in order.
+func arrayStats(numbers []float64) *models.ArrayStats {
+	var finite []float64
+	var indexes []int
+	for i, v := range numbers {
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			finite = append(finite, v)
+			indexes = append(indexes, i)
+		}
+	}
+	if len(finite) == 0 {
+		return nil
+	}
+
+	s := &models.ArrayStats{Count: len(finite), Min: finite[0], Max: finite[0]}
+	var sum float64
+	for _, v := range finite {
+		s.Min = min(s.Min, v)
+		s.Max = max(s.Max, v)
+		sum += v
+	}
+	s.Mean = sum / float64(len(finite))
+	var squares float64
+	for _, v := range finite {
+		squares += (v - s.Mean) * (v - s.Mean)
+	}
+	s.StdDev = math.Sqrt(squares / float64(len(finite)))
+
+	rising, falling := false, false
+	constantStep := len(finite) > 1
+	for i := 1; i < len(finite); i++ {
+		d := finite[i] - finite[i-1]
+		rising = rising || d > 0
+		falling = falling || d < 0
+		constantStep = constantStep && d == finite[1]-finite[0]
+	}
+	switch {
+	case rising && !falling:
+		s.Monotonic = models.TrendIncreasing
+	case falling && !rising:
+		s.Monotonic = models.TrendDecreasing
+	case !rising && !falling:
+		s.Monotonic = models.TrendConstant
+	}
+	if constantStep {
+		step := finite[1] - finite[0]
+		s.Step = &step
+	}
+	s.LongestRun = longestRun(finite, indexes)
+	return s
+}
+
+// longestRun returns the longest strictly increasing or decreasing sequence
+// of values. indexes are the positions of the values in the array.
+func longestRun(values []float64, indexes []int) models.ArrayRun {
+	best := models.ArrayRun{Start: indexes[0], Length: 1}
+	start, dir := 0, 0
+	for i := 1; i < len(values); i++ {
+		d := 0
+		switch {
+		case values[i] > values[i-1]:
+			d = 1
+		case values[i] < values[i-1]:
+			d = -1
+		}
+		if d == 0 || d != dir {
+			start = i - 1
+			if d == 0 {
+				start = i
+			}
+			dir = d
+		}
+		if length := i - start + 1; d != 0 && length > best.Length {
+			best = models.ArrayRun{Start: indexes[start], Length: length, Direction: models.TrendIncreasing}
+			if d < 0 {
+				best.Direction = models.TrendDecreasing
+			}
+		}
+	}
+	return best
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"hexview/models"
+)
+
+func TestArrayStats(t *testing.T) {
+	step := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name      string
+		values    []float64
+		min, max  float64
+		mean, std float64
+		monotonic string
+		step      *float64
+		run       models.ArrayRun
+	}{
+		{"counter", []float64{10, 11, 12, 13}, 10, 13, 11.5, math.Sqrt(1.25), models.TrendIncreasing, step(1), models.ArrayRun{Start: 0, Length: 4, Direction: models.TrendIncreasing}},
+		{"constant", []float64{5, 5, 5}, 5, 5, 5, 0, models.TrendConstant, step(0), models.ArrayRun{Start: 0, Length: 1}},
+		{"falling with plateau", []float64{9, 7, 7, 3}, 3, 9, 6.5, math.Sqrt(4.75), models.TrendDecreasing, nil, models.ArrayRun{Start: 0, Length: 2, Direction: models.TrendDecreasing}},
+		{"waveform", []float64{0, 2, 4, 2, 0, -2, -4, -2}, -4, 4, 0, math.Sqrt(6), "", nil, models.ArrayRun{Start: 2, Length: 5, Direction: models.TrendDecreasing}},
+		{"skips NaN", []float64{1, math.NaN(), 3, math.Inf(1)}, 1, 3, 2, 1, models.TrendIncreasing, step(2), models.ArrayRun{Start: 0, Length: 2, Direction: models.TrendIncreasing}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := arrayStats(tt.values)
+			if s.Min != tt.min || s.Max != tt.max || s.Mean != tt.mean || math.Abs(s.StdDev-tt.std) > 1e-12 || s.Monotonic != tt.monotonic {
+				t.Errorf("arrayStats() = %+v", s)
+			}
+			if (s.Step == nil) != (tt.step == nil) || (s.Step != nil && *s.Step != *tt.step) {
+				t.Errorf("Step = %v, want %v", s.Step, tt.step)
+			}
+			if s.LongestRun != tt.run {
+				t.Errorf("LongestRun = %+v, want %+v", s.LongestRun, tt.run)
+			}
+		})
+	}
+
+	if s := arrayStats([]float64{math.NaN()}); s != nil {
+		t.Errorf("arrayStats() of NaN = %+v, want nil", s)
+	}
+}
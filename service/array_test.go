@@ -0,0 +1,58 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestConvertArray(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		typ       string
+		order     string
+		want      string
+		remainder int
+	}{
+		{"int16 LE", "0100 ffff 0080", "int16", "LE", "1 -1 -32768", 0},
+		{"int16 BE", "0100 ffff 0080", "int16", "BE", "256 -1 128", 0},
+		{"uint8", "00 7f ff", "uint8", "BE", "0 127 255", 0},
+		{"uint32 CDAB", "0001 0000 0002 0000 ff", "uint32", "CDAB", "1 2", 1},
+		{"float32 BE", "3fc00000 c2480000", "float32", "BE", "1.5 -50", 0},
+		{"float64 LE", "000000000000f03f", "float64", "LE", "1", 0},
+		{"too short", "0102", "int32", "BE", "", 2},
+	}
+	c := NewConverter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := c.ConvertArray(tt.input, tt.typ, tt.order)
+			if err != nil {
+				t.Fatalf("ConvertArray() error: %v", err)
+			}
+			if got := strings.Join(result.Values, " "); got != tt.want || result.Remainder != tt.remainder || result.Count != len(result.Values) {
+				t.Errorf("ConvertArray() = %+v, want values %q remainder %d", result, tt.want, tt.remainder)
+			}
+		})
+	}
+}
+
+func TestConvertArray_Errors(t *testing.T) {
+	c := NewConverter()
+	for _, tt := range []struct{ typ, order string }{{"int24", "BE"}, {"int16", "XY"}} {
+		if _, err := c.ConvertArray("0102", tt.typ, tt.order); !errors.Is(err, ErrUnsupportedType) {
+			t.Errorf("ConvertArray(%s, %s) error = %v, want ErrUnsupportedType", tt.typ, tt.order, err)
+		}
+	}
+	if _, err := c.ConvertArray("", "int16", "BE"); err == nil {
+		t.Error("ConvertArray() of empty input succeeded")
+	}
+
+	result, _, err := decodeArray(make([]byte, MaxArrayValues+2), "uint8", "BE")
+	if err != nil {
+		t.Fatalf("decodeArray() error: %v", err)
+	}
+	if !result.Truncated || result.Count != MaxArrayValues+2 || len(result.Values) != MaxArrayValues {
+		t.Errorf("decodeArray() count %d, %d values, truncated %v", result.Count, len(result.Values), result.Truncated)
+	}
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"fmt"
+	"math/bits"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+// bitFlags reads up to 8 bytes as an unsigned integer in order (BigEndian
+// or LittleEndian) and lists its bits; nil for longer input.
+func bitFlags(data []byte, order convert.ByteOrder) *models.BitFlags {
+	if len(data) == 0 || len(data) > 8 {
+		return nil
+	}
+	var v uint64
+	for i := range data {
+		b := data[i]
+		if order == convert.LittleEndian {
+			b = data[len(data)-1-i]
+		}
+		v = v<<8 | uint64(b)
+	}
+
+	width := 8 * len(data)
+	flags := &models.BitFlags{
+		Width:    width,
+		Value:    fmt.Sprintf("0x%0*x", 2*len(data), v),
+		SetCount: bits.OnesCount64(v),
+		Parity:   "even",
+		Set:      []int{},
+		Bits:     make([]models.BitFlag, width),
+	}
+	if flags.SetCount%2 == 1 {
+		flags.Parity = "odd"
+	}
+	for i := range width {
+		byteIndex := i / 8 // from the least significant byte
+		if order != convert.LittleEndian {
+			byteIndex = len(data) - 1 - byteIndex
+		}
+		set := int(v >> i & 1)
+		flags.Bits[i] = models.BitFlag{
+			Index:     i,
+			Value:     set,
+			Mask:      fmt.Sprintf("0x%0*x", 2*len(data), uint64(1)<<i),
+			Byte:      byteIndex,
+			BitInByte: i % 8,
+		}
+		if set == 1 {
+			flags.Set = append(flags.Set, i)
+		}
+	}
+	return flags
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"hexview/models"
+)
+
+// ErrTooManyInputs indicates a bulk conversion with more than MaxBulkInputs inputs
+var ErrTooManyInputs = errors.New("too many inputs")
+
+// MaxBulkInputs is the largest number of inputs of one bulk conversion.
+const MaxBulkInputs = 100_000
+
+// BulkConvert converts every input in mode (see ConvertModeLimited) on a
+// pool of workers, each within the input limits of settings. The items of
+// the result are in the order of inputs, whatever the order the workers
+// finish in. Inputs that fail to convert are reported in their item and do
+// not stop the others. When ctx is cancelled the conversion stops and the
+// error of ctx is returned.
+func (c *Converter) BulkConvert(ctx context.Context, inputs []string, mode string, opts models.BulkOptions, settings models.Settings) (*models.BulkResult, error) {
+	if !validMode(mode) {
+		return nil, fmt.Errorf("unknown mode: %s", mode)
+	}
+	if len(inputs) > MaxBulkInputs {
+		return nil, fmt.Errorf("%w: %d (maximum %d)", ErrTooManyInputs, len(inputs), MaxBulkInputs)
+	}
+	if opts.Workers < 0 {
+		return nil, fmt.Errorf("invalid number of workers: %d", opts.Workers)
+	}
+	workers := opts.Workers
+	if workers == 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(inputs))
+
+	result := &models.BulkResult{Items: make([]models.BulkItem, len(inputs))}
+	var (
+		next   atomic.Int64
+		failed atomic.Int64
+		wg     sync.WaitGroup
+	)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				i := int(next.Add(1) - 1)
+				if i >= len(inputs) {
+					return
+				}
+				item := &result.Items[i]
+				item.Index = i
+				conversion, modbus, err := c.ConvertModeLimited(ctx, mode, inputs[i], opts.Type, settings)
+				switch {
+				case err != nil:
+					item.Error = err.Error()
+					failed.Add(1)
+				case opts.Compact && conversion != nil:
+					item.Compact = CompactResult(conversion)
+				default:
+					item.Conversion, item.Modbus = conversion, modbus
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	result.Failed = int(failed.Load())
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"hexview/models"
+)
+
+func TestBulkConvert(t *testing.T) {
+	c := NewConverter()
+	inputs := make([]string, 1000)
+	for i := range inputs {
+		inputs[i] = fmt.Sprintf("%04x", i)
+	}
+	inputs[500] = "zz"
+
+	result, err := c.BulkConvert(context.Background(), inputs, ModeHex, models.BulkOptions{Workers: 8}, DefaultSettings())
+	if err != nil {
+		t.Fatalf("BulkConvert() error: %v", err)
+	}
+	if len(result.Items) != len(inputs) || result.Failed != 1 {
+		t.Fatalf("Got %d items, %d failed", len(result.Items), result.Failed)
+	}
+	for i, item := range result.Items {
+		if i == 500 {
+			if item.Error == "" || item.Conversion != nil {
+				t.Errorf("Expected error for item 500, got %+v", item)
+			}
+			continue
+		}
+		if item.Index != i || item.Conversion == nil || item.Conversion.Bytes != inputs[i] {
+			t.Fatalf("Item %d out of order: %+v", i, item)
+		}
+	}
+
+	compact, err := c.BulkConvert(context.Background(), []string{"d1 d2"}, ModeModbus, models.BulkOptions{Compact: true}, DefaultSettings())
+	if err != nil || compact.Items[0].Modbus == nil || len(compact.Items[0].Modbus.Registers) != 2 {
+		t.Errorf("Expected Modbus result, got %+v, %v", compact, err)
+	}
+	compact, err = c.BulkConvert(context.Background(), []string{"513"}, ModeInt, models.BulkOptions{Type: "uint16", Compact: true}, DefaultSettings())
+	if err != nil || compact.Items[0].Compact["uint16BE"].Value != "513" {
+		t.Errorf("Expected compact result, got %+v, %v", compact, err)
+	}
+
+	settings := DefaultSettings()
+	settings.MaxInputSize = 8
+	limited, err := c.BulkConvert(context.Background(), []string{"4248", strings.Repeat("42", 8)}, ModeHex, models.BulkOptions{}, settings)
+	if err != nil || limited.Failed != 1 || !strings.Contains(limited.Items[1].Error, "input too large") {
+		t.Errorf("Expected the long input to fail, got %+v, %v", limited, err)
+	}
+	settings.TruncateInput, settings.PreviewSize = true, 2
+	limited, err = c.BulkConvert(context.Background(), []string{strings.Repeat("42", 8)}, ModeHex, models.BulkOptions{}, settings)
+	if err != nil || limited.Items[0].Conversion == nil || !limited.Items[0].Conversion.Truncated {
+		t.Errorf("Expected a truncated preview, got %+v, %v", limited, err)
+	}
+
+	if _, err := c.BulkConvert(context.Background(), make([]string, MaxBulkInputs+1), ModeHex, models.BulkOptions{}, DefaultSettings()); !errors.Is(err, ErrTooManyInputs) {
+		t.Errorf("Expected ErrTooManyInputs, got %v", err)
+	}
+	if _, err := c.BulkConvert(context.Background(), inputs, "octal", models.BulkOptions{}, DefaultSettings()); err == nil {
+		t.Error("Expected error for unknown mode")
+	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.BulkConvert(cancelled, inputs, ModeHex, models.BulkOptions{}, DefaultSettings()); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
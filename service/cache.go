@@ -0,0 +1,141 @@
+package service
+
+import (
+	"container/list"
+	"sync"
+
+	"hexview/models"
+)
+
+// DefaultCacheSize is the number of results kept by the converter of the app.
+const DefaultCacheSize = 256
+
+// MaxCachedInput is the longest input whose result is cached. Repeated
+// conversions are typically single values from polling or the clipboard;
+// large inputs would only push them out.
+const MaxCachedInput = 1024
+
+// cacheKey identifies a conversion. typ holds the integer or float type.
+type cacheKey struct {
+	mode, input, typ string
+}
+
+// cacheEntry is an element of the LRU list.
+type cacheEntry struct {
+	key    cacheKey
+	result any // *models.ConversionResult or *models.ModbusResult
+}
+
+// resultCache is an LRU cache of conversion results.
+type resultCache struct {
+	mu        sync.Mutex
+	size      int
+	entries   map[cacheKey]*list.Element
+	order     *list.List // most recently used first
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// newResultCache creates a cache holding up to size results.
+func newResultCache(size int) *resultCache {
+	return &resultCache{size: size, entries: make(map[cacheKey]*list.Element), order: list.New()}
+}
+
+// get returns the cached result of key.
+func (rc *resultCache) get(key cacheKey) (any, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	e, ok := rc.entries[key]
+	if !ok {
+		rc.misses++
+		return nil, false
+	}
+	rc.hits++
+	rc.order.MoveToFront(e)
+	return e.Value.(*cacheEntry).result, true
+}
+
+// put stores the result of key, evicting the least recently used result if
+// the cache is full.
+func (rc *resultCache) put(key cacheKey, result any) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if e, ok := rc.entries[key]; ok {
+		e.Value.(*cacheEntry).result = result
+		rc.order.MoveToFront(e)
+		return
+	}
+	rc.entries[key] = rc.order.PushFront(&cacheEntry{key: key, result: result})
+	if rc.order.Len() > rc.size {
+		oldest := rc.order.Back()
+		rc.order.Remove(oldest)
+		delete(rc.entries, oldest.Value.(*cacheEntry).key)
+		rc.evictions++
+	}
+}
+
+// clear removes all results and resets the counters.
+func (rc *resultCache) clear() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	clear(rc.entries)
+	rc.order.Init()
+	rc.hits, rc.misses, rc.evictions = 0, 0, 0
+}
+
+// stats returns the size and counters of the cache.
+func (rc *resultCache) stats() models.CacheStats {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	stats := models.CacheStats{
+		Entries:   rc.order.Len(),
+		Capacity:  rc.size,
+		Hits:      rc.hits,
+		Misses:    rc.misses,
+		Evictions: rc.evictions,
+	}
+	if total := rc.hits + rc.misses; total > 0 {
+		stats.HitRate = float64(rc.hits) / float64(total)
+	}
+	return stats
+}
+
+// cached returns the result of convert for the conversion identified by mode,
+// input and typ, running it only if the result is not cached. Callers get a
+// copy of the cached result, so they can set its fields (e.g. script
+// sections) without changing the cache. Errors are not cached.
+func cached[T any](c *Converter, mode, input, typ string, convert func() (*T, error)) (*T, error) {
+	if c.cache == nil || len(input) > MaxCachedInput {
+		return convert()
+	}
+	key := cacheKey{mode: mode, input: input, typ: typ}
+	if result, ok := c.cache.get(key); ok {
+		copied := *result.(*T)
+		return &copied, nil
+	}
+
+	result, err := convert()
+	if err != nil {
+		return nil, err
+	}
+	copied := *result
+	c.cache.put(key, &copied)
+	return result, nil
+}
+
+// CacheStats returns the metrics of the result cache. They are zero for a
+// converter without cache.
+func (c *Converter) CacheStats() models.CacheStats {
+	if c.cache == nil {
+		return models.CacheStats{}
+	}
+	return c.cache.stats()
+}
+
+// ClearCache removes all cached results and resets the cache metrics.
+func (c *Converter) ClearCache() {
+	if c.cache != nil {
+		c.cache.clear()
+	}
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"hexview/models"
+)
+
+func TestCachedConverter(t *testing.T) {
+	c := NewCachedConverter(2)
+
+	first, err := c.ConvertHex("0102")
+	if err != nil {
+		t.Fatalf("ConvertHex() error: %v", err)
+	}
+	first.Scripts = []models.ScriptSection{{Name: "changed"}}
+	second, err := c.ConvertHex("0102")
+	if err != nil {
+		t.Fatalf("ConvertHex() error: %v", err)
+	}
+	if second == first || second.Scripts != nil || second.Bytes != "0102" {
+		t.Errorf("Expected an unchanged copy of the cached result, got %+v", second)
+	}
+
+	// Same input in another mode or type is a different conversion
+	if r, _ := c.ConvertInt("258", models.Uint16); r.Uint16BE == nil || *r.Uint16BE != 258 {
+		t.Errorf("ConvertInt(uint16) = %+v", r)
+	}
+	if r, _ := c.ConvertInt("258", models.Int32); r.Int32BE == nil {
+		t.Errorf("ConvertInt(int32) = %+v", r)
+	}
+	if _, err := c.ConvertHex("zz"); err == nil {
+		t.Error("Expected error for invalid hex")
+	}
+	if _, err := c.ConvertHex(strings.Repeat("00", MaxCachedInput)); err != nil {
+		t.Fatalf("ConvertHex() error: %v", err)
+	}
+	if _, err := c.ConvertModbusRegistersContext(context.Background(), "0x4248 0x0000"); err != nil {
+		t.Fatalf("ConvertModbusRegistersContext() error: %v", err)
+	}
+	if r, err := c.ConvertModbusRegisters("0x4248 0x0000"); err != nil || len(r.Registers) != 2 {
+		t.Errorf("ConvertModbusRegisters() = %+v, %v", r, err)
+	}
+
+	want := models.CacheStats{Entries: 2, Capacity: 2, Hits: 2, Misses: 5, Evictions: 2, HitRate: 2.0 / 7}
+	if got := c.CacheStats(); got != want {
+		t.Errorf("CacheStats() = %+v, want %+v", got, want)
+	}
+
+	c.ClearCache()
+	if got := c.CacheStats(); got != (models.CacheStats{Capacity: 2}) {
+		t.Errorf("CacheStats() after ClearCache = %+v", got)
+	}
+	if got := NewConverter().CacheStats(); got != (models.CacheStats{}) {
+		t.Errorf("CacheStats() without cache = %+v", got)
+	}
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"hexview/models"
+)
+
+// canonicalHex returns bytes as prefixed hex bytes, e.g. "0x11 0x22 0x33".
+func canonicalHex(bytes []byte) string {
+	var sb strings.Builder
+	sb.Grow(5 * len(bytes))
+	for i, b := range bytes {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		fmt.Fprintf(&sb, "0x%02x", b)
+	}
+	return sb.String()
+}
+
+// canonicalValue returns the value of the field with JSON name name, e.g.
+// the int16BE value of an int16 conversion, which is the number as it was
+// parsed.
+func canonicalValue(result *models.ConversionResult, name string) string {
+	v := reflect.ValueOf(result).Elem()
+	for _, f := range resultFields {
+		if field := v.Field(f.index); f.name == name && !field.IsNil() {
+			return fmt.Sprint(field.Elem().Interface())
+		}
+	}
+	return ""
+}
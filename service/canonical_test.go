@@ -0,0 +1,39 @@
+package service
+
+import (
+	"testing"
+
+	"hexview/models"
+)
+
+func TestCanonicalInput(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		name    string
+		convert func() (*models.ConversionResult, error)
+		want    string
+	}{
+		{"hex paste", func() (*models.ConversionResult, error) { return c.ConvertHex("0X11,22 :33") }, "0x11 0x22 0x33"},
+		{"odd hex digits", func() (*models.ConversionResult, error) { return c.ConvertHex("abc") }, "0x0a 0xbc"},
+		{"hex sections", func() (*models.ConversionResult, error) { return c.ConvertHexSections("de-ad", nil) }, "0xde 0xad"},
+		{"hex preview", func() (*models.ConversionResult, error) { return c.ConvertHexPreview("01 02 03", 2) }, "0x01 0x02"},
+		{"binary", func() (*models.ConversionResult, error) { return c.ConvertBinary("1_0001 0010") }, "00000001 00010010"},
+		{"binary preview", func() (*models.ConversionResult, error) { return c.ConvertBinaryPreview("1 00000010", 1) }, "00000001"},
+		{"typed int", func() (*models.ConversionResult, error) { return c.ConvertInt("+0042", models.Int16) }, "42"},
+		{"typed uint", func() (*models.ConversionResult, error) { return c.ConvertInt("65535", models.Uint16) }, "65535"},
+		{"auto int", func() (*models.ConversionResult, error) { return c.ConvertIntAuto("-007") }, "-7"},
+		{"auto float with comma", func() (*models.ConversionResult, error) { return c.ConvertIntAuto("1,50") }, "1.5"},
+		{"typed float", func() (*models.ConversionResult, error) { return c.ConvertFloat("2.50e0", models.Float32) }, "2.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.convert()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Canonical != tt.want {
+				t.Errorf("Canonical = %q, want %q", result.Canonical, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,431 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"hexview/capture"
+	"hexview/convert"
+	"hexview/models"
+)
+
+// ErrCaptureNotFound indicates an unknown capture ID was used
+var ErrCaptureNotFound = errors.New("capture not found")
+
+// tcpDialTimeout bounds how long StartTCPClient waits for a connection.
+const tcpDialTimeout = 5 * time.Second
+
+// Transmit defaults
+const (
+	defaultResponseTimeout = time.Second
+	defaultResponseIdle    = 50 * time.Millisecond
+)
+
+// CaptureService manages live capture sessions (serial ports, sockets).
+// Sessions are addressed by an ID assigned when they are started and keep
+// their recorded data after being stopped until they are removed.
+type CaptureService struct {
+	mu       sync.RWMutex
+	sessions map[string]*captureSession
+	nextID   int
+
+	onData func(models.CaptureChunk)
+	onStop func(models.CaptureInfo)
+}
+
+// captureSession holds a capture session with its descriptive metadata.
+type captureSession struct {
+	id       string
+	kind     string
+	source   string
+	settings string
+	conn     io.ReadWriteCloser
+	sess     *capture.Session
+}
+
+// peerer is implemented by connections that can report their current remote peer.
+type peerer interface {
+	Peer() string
+}
+
+// NewCaptureService creates a new CaptureService instance.
+func NewCaptureService() *CaptureService {
+	return &CaptureService{
+		sessions: make(map[string]*captureSession),
+	}
+}
+
+// SetHandlers registers callbacks for received data and for sessions that end.
+// Both are called from the session reader goroutines. Sessions started before
+// the call are not affected.
+func (s *CaptureService) SetHandlers(onData func(models.CaptureChunk), onStop func(models.CaptureInfo)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onData = onData
+	s.onStop = onStop
+}
+
+// SerialPorts lists the serial ports available on the system.
+func (s *CaptureService) SerialPorts() ([]string, error) {
+	ports, err := capture.SerialPorts()
+	if err != nil {
+		return nil, fmt.Errorf("cannot list serial ports: %w", err)
+	}
+	sort.Strings(ports)
+	return ports, nil
+}
+
+// StartSerial opens a serial port and starts capturing received bytes.
+func (s *CaptureService) StartSerial(cfg models.SerialConfig) (*models.CaptureInfo, error) {
+	sc := capture.SerialConfig{
+		Port:     cfg.Port,
+		BaudRate: cfg.BaudRate,
+		DataBits: cfg.DataBits,
+		Parity:   cfg.Parity,
+		StopBits: cfg.StopBits,
+	}
+	conn, err := capture.OpenSerial(sc)
+	if err != nil {
+		return nil, err
+	}
+	return s.start("serial", cfg.Port, sc.String(), conn), nil
+}
+
+// StartTCPClient connects to a TCP server and starts capturing received bytes.
+func (s *CaptureService) StartTCPClient(address string) (*models.CaptureInfo, error) {
+	if address == "" {
+		return nil, fmt.Errorf("empty address")
+	}
+	conn, err := capture.DialTCP(address, tcpDialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return s.start("tcp-client", address, "", conn), nil
+}
+
+// StartTCPServer listens on address and captures bytes from connecting clients,
+// one client at a time.
+func (s *CaptureService) StartTCPServer(address string) (*models.CaptureInfo, error) {
+	srv, err := capture.ListenTCP(address)
+	if err != nil {
+		return nil, err
+	}
+	return s.start("tcp-server", srv.Addr(), "", srv), nil
+}
+
+// StartUDP binds a UDP socket and captures every received datagram as a chunk.
+// Sends go to remoteAddress, or to the last sender if remoteAddress is empty.
+func (s *CaptureService) StartUDP(localAddress, remoteAddress string) (*models.CaptureInfo, error) {
+	e, err := capture.ListenUDP(localAddress, remoteAddress)
+	if err != nil {
+		return nil, err
+	}
+	settings := ""
+	if remoteAddress != "" {
+		settings = "remote " + remoteAddress
+	}
+	return s.start("udp", e.Addr(), settings, e), nil
+}
+
+// Send transmits hex-encoded bytes over the connection of a running session.
+// It returns the number of bytes written.
+func (s *CaptureService) Send(id, hexInput string) (int, error) {
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return 0, err
+	}
+	cs, err := s.running(id)
+	if err != nil {
+		return 0, err
+	}
+	n, err := cs.sess.Write(data)
+	if err != nil {
+		return n, fmt.Errorf("cannot send to %s: %w", cs.source, err)
+	}
+	return n, nil
+}
+
+// Transmit sends hex-encoded bytes (e.g. a frame composed in the converter) over
+// a running session and waits for the response, which is logged in the session
+// like any other received data. Cancelling ctx stops waiting for the response.
+func (s *CaptureService) Transmit(ctx context.Context, id, hexInput string, opts models.TransmitOptions) (*models.TransmitResult, error) {
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+	cs, err := s.running(id)
+	if err != nil {
+		return nil, err
+	}
+	return cs.transact(ctx, data, opts)
+}
+
+// Replay sends several hex-encoded frames in order, pausing IntervalMs between
+// frames, and collects the response to each one. It stops at the first send
+// error or when ctx is cancelled, returning the results collected so far.
+func (s *CaptureService) Replay(ctx context.Context, id string, frames []string, opts models.TransmitOptions) ([]models.TransmitResult, error) {
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("no frames to replay")
+	}
+	payloads := make([][]byte, len(frames))
+	for i, f := range frames {
+		data, err := parseHexBlob(f)
+		if err != nil {
+			return nil, fmt.Errorf("frame %d: %w", i+1, err)
+		}
+		payloads[i] = data
+	}
+	cs, err := s.running(id)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.TransmitResult, 0, len(payloads))
+	for i, data := range payloads {
+		if i > 0 && opts.IntervalMs > 0 {
+			select {
+			case <-time.After(time.Duration(opts.IntervalMs) * time.Millisecond):
+			case <-ctx.Done():
+				return results, ctx.Err()
+			}
+		}
+		r, err := cs.transact(ctx, data, opts)
+		if err != nil {
+			return results, fmt.Errorf("frame %d: %w", i+1, err)
+		}
+		results = append(results, *r)
+	}
+	return results, nil
+}
+
+// start registers a new session reading from conn.
+func (s *CaptureService) start(kind, source, settings string, conn io.ReadWriteCloser) *models.CaptureInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	cs := &captureSession{
+		id:       fmt.Sprintf("capture-%d", s.nextID),
+		kind:     kind,
+		source:   source,
+		settings: settings,
+		conn:     conn,
+	}
+
+	onData, onStop := s.onData, s.onStop
+	opts := capture.Options{}
+	if onData != nil {
+		opts.OnChunk = func(c capture.Chunk) { onData(chunkModel(cs.id, c)) }
+	}
+	if onStop != nil {
+		opts.OnStop = func(error) {
+			// The session may still be registering when a connection fails immediately
+			s.mu.RLock()
+			defer s.mu.RUnlock()
+			onStop(cs.info())
+		}
+	}
+	cs.sess = capture.Start(conn, opts)
+	s.sessions[cs.id] = cs
+
+	info := cs.info()
+	return &info
+}
+
+// Stop ends a capture session. Its recorded data remains available.
+func (s *CaptureService) Stop(id string) (*models.CaptureInfo, error) {
+	cs, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := cs.sess.Stop(); err != nil {
+		return nil, fmt.Errorf("cannot close %s: %w", cs.source, err)
+	}
+	info := cs.info()
+	return &info, nil
+}
+
+// StopAll ends all running capture sessions, e.g. on application shutdown.
+func (s *CaptureService) StopAll() {
+	for _, info := range s.List() {
+		if info.Running {
+			_, _ = s.Stop(info.ID)
+		}
+	}
+}
+
+// Remove stops a capture session and discards its data.
+func (s *CaptureService) Remove(id string) error {
+	cs, err := s.get(id)
+	if err != nil {
+		return err
+	}
+	_ = cs.sess.Stop()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, id)
+	return nil
+}
+
+// Clear discards the recorded data of a session while it keeps running.
+func (s *CaptureService) Clear(id string) error {
+	cs, err := s.get(id)
+	if err != nil {
+		return err
+	}
+	cs.sess.Clear()
+	return nil
+}
+
+// List returns descriptors of all capture sessions in the order they were started.
+func (s *CaptureService) List() []models.CaptureInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]models.CaptureInfo, 0, len(s.sessions))
+	for _, cs := range s.sessions {
+		list = append(list, cs.info())
+	}
+	sort.Slice(list, func(i, j int) bool { return compareIDs(list[i].ID, list[j].ID) < 0 })
+	return list
+}
+
+// Chunks returns the retained chunks of a session with a sequence number >= since.
+// Polling with the last seen sequence number + 1 yields only new data.
+func (s *CaptureService) Chunks(id string, since int) ([]models.CaptureChunk, error) {
+	cs, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := cs.sess.ChunksSince(since)
+	result := make([]models.CaptureChunk, len(chunks))
+	for i, c := range chunks {
+		result[i] = chunkModel(id, c)
+	}
+	return result, nil
+}
+
+// ReadRange returns up to length bytes of the received stream starting at offset.
+// The range may span several chunks, so it can be passed to the converter as a whole.
+func (s *CaptureService) ReadRange(id string, offset int64, length int) (*models.FileRange, error) {
+	data, err := s.Bytes(id, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	return &models.FileRange{
+		Offset: offset,
+		Length: len(data),
+		Hex:    convert.BytesToHex(data),
+		ASCII:  bytesToASCII(data),
+	}, nil
+}
+
+// Bytes returns up to length bytes of the received stream starting at
+// offset, like ReadRange but without encoding them.
+func (s *CaptureService) Bytes(id string, offset int64, length int) ([]byte, error) {
+	cs, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+	return cs.sess.Range(offset, length)
+}
+
+// get looks up a session by ID.
+func (s *CaptureService) get(id string) (*captureSession, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cs, ok := s.sessions[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrCaptureNotFound, id)
+	}
+	return cs, nil
+}
+
+// running looks up a session by ID and checks that it can still send.
+func (s *CaptureService) running(id string) (*captureSession, error) {
+	cs, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+	if !cs.sess.Running() {
+		return nil, fmt.Errorf("capture %s is not running", id)
+	}
+	return cs, nil
+}
+
+// transact sends data and converts the collected response to its model representation.
+func (cs *captureSession) transact(ctx context.Context, data []byte, opts models.TransmitOptions) (*models.TransmitResult, error) {
+	timeout := defaultResponseTimeout
+	if opts.TimeoutMs > 0 {
+		timeout = time.Duration(opts.TimeoutMs) * time.Millisecond
+	}
+	idle := defaultResponseIdle
+	switch {
+	case opts.IdleMs < 0:
+		idle = 0
+	case opts.IdleMs > 0:
+		idle = time.Duration(opts.IdleMs) * time.Millisecond
+	}
+
+	resp, err := cs.sess.Transact(ctx, data, timeout, idle)
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot send to %s: %w", cs.source, err)
+	}
+	return &models.TransmitResult{
+		Sent:           chunkModel(cs.id, resp.Sent),
+		Response:       convert.BytesToHex(resp.Data),
+		ResponseASCII:  bytesToASCII(resp.Data),
+		ResponseOffset: resp.Offset,
+		LatencyMs:      float64(resp.Latency.Microseconds()) / 1000,
+		TimedOut:       resp.TimedOut,
+	}, nil
+}
+
+// info builds the model descriptor of a session.
+func (cs *captureSession) info() models.CaptureInfo {
+	total, retained, chunks := cs.sess.Stats()
+	info := models.CaptureInfo{
+		ID:            cs.id,
+		Kind:          cs.kind,
+		Source:        cs.source,
+		Settings:      cs.settings,
+		Running:       cs.sess.Running(),
+		StartedAt:     cs.sess.Started().Format(time.RFC3339),
+		TotalBytes:    total,
+		SentBytes:     cs.sess.SentBytes(),
+		RetainedBytes: retained,
+		Chunks:        chunks,
+	}
+	if p, ok := cs.conn.(peerer); ok {
+		info.Peer = p.Peer()
+	}
+	if err := cs.sess.Err(); err != nil {
+		info.Error = err.Error()
+	}
+	return info
+}
+
+// chunkModel converts a captured chunk to its model representation.
+func chunkModel(id string, c capture.Chunk) models.CaptureChunk {
+	return models.CaptureChunk{
+		CaptureID: id,
+		Seq:       c.Seq,
+		Timestamp: c.Time.Format(time.RFC3339Nano),
+		Direction: string(c.Direction),
+		Offset:    c.Offset,
+		Length:    len(c.Data),
+		Hex:       convert.BytesToHex(c.Data),
+		ASCII:     bytesToASCII(c.Data),
+	}
+}
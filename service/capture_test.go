@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"hexview/models"
+)
+
+func TestCaptureService_Session(t *testing.T) {
+	s := NewCaptureService()
+	received := make(chan models.CaptureChunk, 4)
+	stopped := make(chan models.CaptureInfo, 1)
+	s.SetHandlers(
+		func(c models.CaptureChunk) { received <- c },
+		func(info models.CaptureInfo) { stopped <- info },
+	)
+
+	local, remote := net.Pipe()
+	defer remote.Close()
+	info := s.start("serial", "/dev/ttyTEST", "9600 8N1", local)
+	if info.ID != "capture-1" || !info.Running {
+		t.Errorf("Unexpected capture info: %+v", info)
+	}
+
+	remote.Write([]byte{0x01, 0x02})
+	remote.Write([]byte{0x03, 0x04})
+	first, second := <-received, <-received
+	if first.Hex != "0102" || first.CaptureID != info.ID || second.Offset != 2 {
+		t.Errorf("Unexpected chunks: %+v, %+v", first, second)
+	}
+
+	r, err := s.ReadRange(info.ID, 1, 2)
+	if err != nil {
+		t.Fatalf("ReadRange() error: %v", err)
+	}
+	if r.Hex != "0203" {
+		t.Errorf("Expected range 0203, got %s", r.Hex)
+	}
+
+	chunks, err := s.Chunks(info.ID, second.Seq)
+	if err != nil {
+		t.Fatalf("Chunks() error: %v", err)
+	}
+	if len(chunks) != 1 || chunks[0].Hex != "0304" {
+		t.Errorf("Unexpected chunks since %d: %+v", second.Seq, chunks)
+	}
+
+	stoppedInfo, err := s.Stop(info.ID)
+	if err != nil {
+		t.Fatalf("Stop() error: %v", err)
+	}
+	if stoppedInfo.Running || stoppedInfo.TotalBytes != 4 {
+		t.Errorf("Unexpected info after stop: %+v", stoppedInfo)
+	}
+	if ev := <-stopped; ev.ID != info.ID {
+		t.Errorf("Unexpected stop event: %+v", ev)
+	}
+
+	// Data stays readable after stopping until the session is removed
+	if _, err := s.ReadRange(info.ID, 0, 4); err != nil {
+		t.Errorf("ReadRange() after stop error: %v", err)
+	}
+	if err := s.Remove(info.ID); err != nil {
+		t.Fatalf("Remove() error: %v", err)
+	}
+	if len(s.List()) != 0 {
+		t.Error("Expected no sessions after Remove")
+	}
+}
+
+func TestCaptureService_Errors(t *testing.T) {
+	s := NewCaptureService()
+
+	if _, err := s.Stop("capture-9"); !errors.Is(err, ErrCaptureNotFound) {
+		t.Errorf("Stop() error = %v, want ErrCaptureNotFound", err)
+	}
+	if _, err := s.ReadRange("capture-9", 0, 1); !errors.Is(err, ErrCaptureNotFound) {
+		t.Errorf("ReadRange() error = %v, want ErrCaptureNotFound", err)
+	}
+	if _, err := s.StartSerial(models.SerialConfig{Port: "", BaudRate: 9600}); err == nil {
+		t.Error("Expected error for missing port")
+	}
+	if _, err := s.StartSerial(models.SerialConfig{Port: "/dev/hexview-does-not-exist", BaudRate: 9600}); err == nil {
+		t.Error("Expected error for nonexistent port")
+	}
+}
+
+func TestCaptureService_TCPServerSend(t *testing.T) {
+	s := NewCaptureService()
+	received := make(chan models.CaptureChunk, 4)
+	s.SetHandlers(func(c models.CaptureChunk) { received <- c }, nil)
+
+	info, err := s.StartTCPServer("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("StartTCPServer() error: %v", err)
+	}
+	defer s.StopAll()
+	if info.Kind != "tcp-server" {
+		t.Errorf("Expected kind tcp-server, got %s", info.Kind)
+	}
+
+	client, err := net.Dial("tcp", info.Source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	client.Write([]byte{0x01, 0x03})
+	if c := <-received; c.Hex != "0103" {
+		t.Errorf("Expected chunk 0103, got %s", c.Hex)
+	}
+	if peer := s.List()[0].Peer; peer != client.LocalAddr().String() {
+		t.Errorf("Expected peer %s, got %s", client.LocalAddr(), peer)
+	}
+
+	n, err := s.Send(info.ID, "DE AD BE EF")
+	if err != nil || n != 4 {
+		t.Fatalf("Send() = %d, %v", n, err)
+	}
+	buf := make([]byte, 4)
+	if _, err := client.Read(buf); err != nil || buf[0] != 0xde {
+		t.Errorf("Client read %x, %v", buf, err)
+	}
+
+	if _, err := s.Send(info.ID, "XYZ"); err == nil {
+		t.Error("Expected error for invalid hex")
+	}
+	s.Stop(info.ID)
+	if _, err := s.Send(info.ID, "00"); err == nil {
+		t.Error("Expected error sending on a stopped capture")
+	}
+}
+
+func TestCaptureService_TransmitAndReplay(t *testing.T) {
+	s := NewCaptureService()
+	local, remote := net.Pipe()
+	defer remote.Close()
+
+	// The device answers each request with its first byte followed by 0x80
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			if _, err := remote.Read(buf); err != nil {
+				return
+			}
+			remote.Write([]byte{buf[0], 0x80})
+		}
+	}()
+
+	info := s.start("serial", "/dev/ttyTEST", "9600 8N1", local)
+	defer s.StopAll()
+
+	r, err := s.Transmit(context.Background(), info.ID, "11 03 00 6B 00 03", models.TransmitOptions{IdleMs: 20})
+	if err != nil {
+		t.Fatalf("Transmit() error: %v", err)
+	}
+	if r.Sent.Direction != "tx" || r.Sent.Hex != "1103006b0003" {
+		t.Errorf("Unexpected sent chunk: %+v", r.Sent)
+	}
+	if r.Response != "1180" || r.TimedOut {
+		t.Errorf("Expected response 1180, got %+v", r)
+	}
+
+	results, err := s.Replay(context.Background(), info.ID, []string{"01", "02"}, models.TransmitOptions{IdleMs: 20, IntervalMs: 1})
+	if err != nil {
+		t.Fatalf("Replay() error: %v", err)
+	}
+	if len(results) != 2 || results[0].Response != "0180" || results[1].Response != "0280" {
+		t.Errorf("Unexpected replay results: %+v", results)
+	}
+	if results[1].ResponseOffset != 4 {
+		t.Errorf("Expected second response at offset 4, got %d", results[1].ResponseOffset)
+	}
+
+	got := s.List()[0]
+	if got.SentBytes != 8 || got.TotalBytes != 6 {
+		t.Errorf("Expected 8 bytes sent and 6 received, got %+v", got)
+	}
+
+	if _, err := s.Replay(context.Background(), info.ID, []string{"01", "ZZ"}, models.TransmitOptions{}); err == nil {
+		t.Error("Expected error for invalid frame")
+	}
+	if _, err := s.Replay(context.Background(), info.ID, nil, models.TransmitOptions{}); err == nil {
+		t.Error("Expected error for empty frame list")
+	}
+
+	// Cancelling stops the replay during the pause between frames
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	results, err = s.Replay(ctx, info.ID, []string{"01", "02"}, models.TransmitOptions{IdleMs: 20, IntervalMs: 10000})
+	if !errors.Is(err, context.Canceled) || len(results) != 1 {
+		t.Errorf("Replay() after cancel = %d results, %v", len(results), err)
+	}
+}
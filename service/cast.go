@@ -0,0 +1,163 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"hexview/models"
+)
+
+// Modes of SimulateCast
+const (
+	CastTruncate = "truncate" // C cast: keep the low bits
+	CastSaturate = "saturate" // clamp to the range of the target
+	CastRound    = "round"    // round to nearest, ties to even, then clamp
+)
+
+// SimulateCast shows what value of type from (an integer or float type)
+// becomes when converted to the integer type to, to explain overflow bugs:
+// truncated like a C cast, which keeps the low bits and drops the fraction
+// of floats, and saturated to the range of to. Float values are also rounded
+// to the nearest integer and saturated, like lrint with clamping.
+func (c *Converter) SimulateCast(value, from, to string) (*models.CastResult, error) {
+	target := models.IntType(to)
+	if !target.Valid() {
+		return nil, errUnsupportedType("cast target", to)
+	}
+	bits := intBits(target)
+	lo, hi := intRange(bits, strings.HasPrefix(to, "int"))
+
+	s := strings.TrimSpace(value)
+	if s == "" {
+		return nil, errEmptyInput()
+	}
+	result := &models.CastResult{From: from, To: to}
+	switch {
+	case models.IntType(from).Valid():
+		fromBits := intBits(models.IntType(from))
+		v, ok := new(big.Int).SetString(s, 10)
+		if fromLo, fromHi := intRange(fromBits, strings.HasPrefix(from, "int")); !ok || v.Cmp(fromLo) < 0 || v.Cmp(fromHi) > 0 {
+			_, err := strconv.ParseInt(s, 10, fromBits) // for the error message
+			if strings.HasPrefix(from, "uint") {
+				_, err = strconv.ParseUint(s, 10, fromBits)
+			}
+			return nil, numberError(value, from, false, err)
+		}
+		result.Value = v.String()
+		result.Exact = v.Cmp(lo) >= 0 && v.Cmp(hi) <= 0
+		wrapped := wrapInt(v, bits, lo.Sign() < 0)
+		saturated := clampInt(v, lo, hi)
+		result.Casts = []models.CastValue{
+			castValue(CastTruncate, wrapped, bits, wrapped.Cmp(v) != 0),
+			castValue(CastSaturate, saturated, bits, saturated.Cmp(v) != 0),
+		}
+
+	case models.FloatType(from).Valid():
+		fromBits, _ := strconv.Atoi(strings.TrimPrefix(from, "float"))
+		f, err := strconv.ParseFloat(s, fromBits)
+		if err != nil {
+			return nil, numberError(value, from, true, err)
+		}
+		result.Value = formatFloat64(f)
+		if fromBits == 32 {
+			result.Value = formatFloat32(float32(f))
+		}
+		result.Casts = floatCasts(f, bits, lo, hi)
+		result.Exact = !result.Casts[0].Changed && result.Casts[0].Note == ""
+
+	default:
+		return nil, errUnsupportedType("cast source", from)
+	}
+	return result, nil
+}
+
+// floatCasts converts a float to an integer of bits bits in the range lo to
+// hi by all modes.
+func floatCasts(f float64, bits int, lo, hi *big.Int) []models.CastValue {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		// Saturating conversions map NaN to 0, like Rust's as
+		saturated := new(big.Int)
+		switch {
+		case math.IsInf(f, 1):
+			saturated.Set(hi)
+		case math.IsInf(f, -1):
+			saturated.Set(lo)
+		}
+		return []models.CastValue{
+			{Mode: CastTruncate, Value: "undefined", Changed: true, Note: "undefined behavior in C"},
+			castValue(CastSaturate, saturated, bits, true),
+			castValue(CastRound, saturated, bits, true),
+		}
+	}
+
+	changed := func(v *big.Int) bool {
+		return new(big.Float).SetFloat64(f).Cmp(new(big.Float).SetInt(v)) != 0
+	}
+	truncated, _ := new(big.Float).SetFloat64(math.Trunc(f)).Int(nil)
+	wrapped := wrapInt(truncated, bits, lo.Sign() < 0)
+	truncate := castValue(CastTruncate, wrapped, bits, changed(wrapped))
+	if truncated.Cmp(lo) < 0 || truncated.Cmp(hi) > 0 {
+		truncate.Note = "undefined behavior in C, shown with the low bits kept"
+	}
+	saturated := clampInt(truncated, lo, hi)
+	rounded, _ := new(big.Float).SetFloat64(math.RoundToEven(f)).Int(nil)
+	rounded = clampInt(rounded, lo, hi)
+	return []models.CastValue{
+		truncate,
+		castValue(CastSaturate, saturated, bits, changed(saturated)),
+		castValue(CastRound, rounded, bits, changed(rounded)),
+	}
+}
+
+// intBits returns the width of an integer type.
+func intBits(t models.IntType) int {
+	bits, _ := strconv.Atoi(strings.TrimPrefix(strings.TrimPrefix(string(t), "u"), "int"))
+	return bits
+}
+
+// intRange returns the smallest and largest value of an integer type.
+func intRange(bits int, signed bool) (lo, hi *big.Int) {
+	if signed {
+		hi = new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+		lo = new(big.Int).Neg(hi)
+		return lo, hi.Sub(hi, big.NewInt(1))
+	}
+	hi = new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	return new(big.Int), hi.Sub(hi, big.NewInt(1))
+}
+
+// wrapInt keeps the low bits of v, read as signed or unsigned.
+func wrapInt(v *big.Int, bits int, signed bool) *big.Int {
+	mod := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	w := new(big.Int).Mod(v, mod)
+	if signed && w.Bit(bits-1) == 1 {
+		w.Sub(w, mod)
+	}
+	return w
+}
+
+// clampInt limits v to the range lo to hi.
+func clampInt(v, lo, hi *big.Int) *big.Int {
+	switch {
+	case v.Cmp(lo) < 0:
+		return new(big.Int).Set(lo)
+	case v.Cmp(hi) > 0:
+		return new(big.Int).Set(hi)
+	}
+	return new(big.Int).Set(v)
+}
+
+// castValue formats a converted value with its bits-wide two's complement
+// bits in hex.
+func castValue(mode string, v *big.Int, bits int, changed bool) models.CastValue {
+	u := new(big.Int).Mod(v, new(big.Int).Lsh(big.NewInt(1), uint(bits)))
+	return models.CastValue{
+		Mode:    mode,
+		Value:   v.String(),
+		Hex:     fmt.Sprintf("%0*x", bits/4, u),
+		Changed: changed,
+	}
+}
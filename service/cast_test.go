@@ -0,0 +1,93 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"hexview/models"
+)
+
+func TestSimulateCast(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		name, value, from, to string
+		exact                 bool
+		want                  []models.CastValue
+	}{
+		{"int32 to int16 wraps", "40000", "int32", "int16", false, []models.CastValue{
+			{Mode: "truncate", Value: "-25536", Hex: "9c40", Changed: true},
+			{Mode: "saturate", Value: "32767", Hex: "7fff", Changed: true},
+		}},
+		{"fits", "-5", "int32", "int16", true, []models.CastValue{
+			{Mode: "truncate", Value: "-5", Hex: "fffb"},
+			{Mode: "saturate", Value: "-5", Hex: "fffb"},
+		}},
+		{"negative to unsigned", "-1", "int8", "uint32", false, []models.CastValue{
+			{Mode: "truncate", Value: "4294967295", Hex: "ffffffff", Changed: true},
+			{Mode: "saturate", Value: "0", Hex: "00000000", Changed: true},
+		}},
+		{"uint64 to int64", "18446744073709551615", "uint64", "int64", false, []models.CastValue{
+			{Mode: "truncate", Value: "-1", Hex: "ffffffffffffffff", Changed: true},
+			{Mode: "saturate", Value: "9223372036854775807", Hex: "7fffffffffffffff", Changed: true},
+		}},
+		{"float fraction", "-2.5", "float64", "int8", false, []models.CastValue{
+			{Mode: "truncate", Value: "-2", Hex: "fe", Changed: true},
+			{Mode: "saturate", Value: "-2", Hex: "fe", Changed: true},
+			{Mode: "round", Value: "-2", Hex: "fe", Changed: true},
+		}},
+		{"float out of range", "300.7", "float32", "uint8", false, []models.CastValue{
+			{Mode: "truncate", Value: "44", Hex: "2c", Changed: true, Note: "undefined behavior in C, shown with the low bits kept"},
+			{Mode: "saturate", Value: "255", Hex: "ff", Changed: true},
+			{Mode: "round", Value: "255", Hex: "ff", Changed: true},
+		}},
+		{"integral float", "7", "float64", "int16", true, []models.CastValue{
+			{Mode: "truncate", Value: "7", Hex: "0007"},
+			{Mode: "saturate", Value: "7", Hex: "0007"},
+			{Mode: "round", Value: "7", Hex: "0007"},
+		}},
+		{"NaN", "NaN", "float64", "int32", false, []models.CastValue{
+			{Mode: "truncate", Value: "undefined", Changed: true, Note: "undefined behavior in C"},
+			{Mode: "saturate", Value: "0", Hex: "00000000", Changed: true},
+			{Mode: "round", Value: "0", Hex: "00000000", Changed: true},
+		}},
+		{"-Inf", "-Inf", "float32", "int8", false, []models.CastValue{
+			{Mode: "truncate", Value: "undefined", Changed: true, Note: "undefined behavior in C"},
+			{Mode: "saturate", Value: "-128", Hex: "80", Changed: true},
+			{Mode: "round", Value: "-128", Hex: "80", Changed: true},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.SimulateCast(tt.value, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("SimulateCast(%q, %s, %s) error: %v", tt.value, tt.from, tt.to, err)
+			}
+			if got.Exact != tt.exact {
+				t.Errorf("Exact = %v, want %v", got.Exact, tt.exact)
+			}
+			if !slices.Equal(got.Casts, tt.want) {
+				t.Errorf("Casts = %+v, want %+v", got.Casts, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimulateCast_Errors(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		value, from, to string
+		want            error
+	}{
+		{"1", "int32", "float32", ErrUnsupportedType},
+		{"1", "int24", "int16", ErrUnsupportedType},
+		{"40000", "int16", "int8", ErrOutOfRange},
+		{"1.5", "int32", "int16", ErrInvalidNumber},
+		{"abc", "float64", "int16", ErrInvalidNumber},
+	}
+	for _, tt := range tests {
+		if _, err := c.SimulateCast(tt.value, tt.from, tt.to); !errors.Is(err, tt.want) {
+			t.Errorf("SimulateCast(%q, %s, %s) error = %v, want %v", tt.value, tt.from, tt.to, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"fmt"
+
+	"hexview/checksum"
+	"hexview/models"
+)
+
+// Checksum computes the common CRCs and simple checksums of hex input.
+func (c *Converter) Checksum(hexInput string) (*models.ChecksumResult, error) {
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+	return checksums(data), nil
+}
+
+// ChecksumBytes computes the checksums of raw bytes like Checksum.
+func (c *Converter) ChecksumBytes(data []byte) (*models.ChecksumResult, error) {
+	if len(data) == 0 {
+		return nil, errEmptyInput()
+	}
+	return checksums(data), nil
+}
+
+// checksums computes all supported checksums of data.
+func checksums(data []byte) *models.ChecksumResult {
+	result := &models.ChecksumResult{Length: len(data)}
+	add := func(name string, width int, v uint64) {
+		result.Checksums = append(result.Checksums, models.ChecksumValue{
+			Name:  name,
+			Width: width,
+			Hex:   fmt.Sprintf("%0*x", width/4, v),
+			Value: v,
+		})
+	}
+
+	for _, crc := range checksum.CRCs() {
+		add(crc.Name, crc.Width, crc.Checksum(data))
+	}
+	add("Sum8", 8, uint64(checksum.Sum8(data)))
+	add("XOR8", 8, uint64(checksum.XOR8(data)))
+	add("LRC", 8, uint64(checksum.LRC(data)))
+	add("Fletcher-16", 16, uint64(checksum.Fletcher16(data)))
+	add("Adler-32", 32, uint64(checksum.Adler32(data)))
+	return result
+}
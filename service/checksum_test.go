@@ -0,0 +1,40 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestChecksum(t *testing.T) {
+	c := NewConverter()
+
+	// ASCII "123456789", the standard CRC check input
+	result, err := c.Checksum("313233343536373839")
+	if err != nil {
+		t.Fatalf("Checksum() error: %v", err)
+	}
+	if result.Length != 9 {
+		t.Errorf("Expected length 9, got %d", result.Length)
+	}
+
+	want := map[string]string{
+		"CRC-16/MODBUS": "4b37",
+		"CRC-32":        "cbf43926",
+		"CRC-8":         "f4",
+		"Sum8":          "dd",
+	}
+	for _, cs := range result.Checksums {
+		if w, ok := want[cs.Name]; ok {
+			if cs.Hex != w {
+				t.Errorf("%s = %s, want %s", cs.Name, cs.Hex, w)
+			}
+			delete(want, cs.Name)
+		}
+	}
+	if len(want) != 0 {
+		t.Errorf("Missing checksums: %v", want)
+	}
+
+	if _, err := c.Checksum(""); err == nil {
+		t.Error("Expected error for empty input")
+	}
+}
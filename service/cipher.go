@@ -0,0 +1,84 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+// Classical ciphers applied by ApplyCipher. Bytes they do not cover are
+// left unchanged.
+const (
+	CipherROT13  = "rot13"  // letters rotated by 13, its own inverse
+	CipherROT47  = "rot47"  // printable ASCII 0x21 to 0x7e rotated by 47, its own inverse
+	CipherCaesar = "caesar" // letters rotated by the shift, decoded with the negated shift
+	CipherAtbash = "atbash" // letters mirrored, A becomes Z, its own inverse
+)
+
+// ErrUnknownCipher indicates an unsupported cipher name
+var ErrUnknownCipher = errors.New("unknown cipher")
+
+// ApplyCipher applies a classical cipher to the ASCII interpretation of hex
+// input, e.g. to read obfuscated strings in configuration blobs. shift is
+// only used by CipherCaesar.
+func (c *Converter) ApplyCipher(hexInput, cipher string, shift int) (*models.CipherResult, error) {
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+
+	var transform func(b byte) byte
+	switch cipher {
+	case CipherROT13:
+		transform = func(b byte) byte { return rotateLetter(b, 13) }
+	case CipherROT47:
+		transform = func(b byte) byte {
+			if b < '!' || b > '~' {
+				return b
+			}
+			return '!' + (b-'!'+47)%94
+		}
+	case CipherCaesar:
+		transform = func(b byte) byte { return rotateLetter(b, shift) }
+	case CipherAtbash:
+		transform = func(b byte) byte {
+			switch {
+			case b >= 'a' && b <= 'z':
+				return 'z' - (b - 'a')
+			case b >= 'A' && b <= 'Z':
+				return 'Z' - (b - 'A')
+			}
+			return b
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCipher, cipher)
+	}
+
+	out := make([]byte, len(data))
+	for i, b := range data {
+		out[i] = transform(b)
+	}
+	result := &models.CipherResult{Cipher: cipher, Text: bytesToASCII(out), Hex: convert.BytesToHex(out)}
+	if cipher == CipherCaesar {
+		result.Shift = shift
+	}
+	return result, nil
+}
+
+// rotateLetter rotates an ASCII letter by shift places within its case.
+// Other bytes are returned unchanged.
+func rotateLetter(b byte, shift int) byte {
+	var base byte
+	switch {
+	case b >= 'a' && b <= 'z':
+		base = 'a'
+	case b >= 'A' && b <= 'Z':
+		base = 'A'
+	default:
+		return b
+	}
+	n := (int(b-base) + shift%26 + 26) % 26
+	return base + byte(n)
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestApplyCipher(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		cipher string
+		shift  int
+		text   string
+		hex    string
+	}{
+		{"rot13", "48656c6c6f2c20576f726c6421", CipherROT13, 0, "Uryyb, Jbeyq!", "55727979622c204a6265797121"},
+		{"rot47", "48656c6c6f 00", CipherROT47, 0, "w6==@.", "77363d3d4000"},
+		{"caesar", "78797a 41", CipherCaesar, 3, "abcD", "61626344"},
+		{"caesar negative", "61626344", CipherCaesar, -3, "xyzA", "78797a41"},
+		{"caesar large shift", "61", CipherCaesar, 27, "b", "62"},
+		{"atbash", "4162637a ff", CipherAtbash, 0, "Zyxa.", "5a797861ff"},
+	}
+	c := NewConverter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := c.ApplyCipher(tt.input, tt.cipher, tt.shift)
+			if err != nil {
+				t.Fatalf("ApplyCipher() error: %v", err)
+			}
+			if result.Text != tt.text || result.Hex != tt.hex {
+				t.Errorf("ApplyCipher() = %q %s, want %q %s", result.Text, result.Hex, tt.text, tt.hex)
+			}
+		})
+	}
+
+	if _, err := c.ApplyCipher("41", "vigenere", 0); !errors.Is(err, ErrUnknownCipher) {
+		t.Errorf("unknown cipher error = %v, want ErrUnknownCipher", err)
+	}
+}
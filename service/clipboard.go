@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+// MaxClipboardText is the longest clipboard text considered for conversion.
+// Longer text is most likely not a single value and is ignored.
+const MaxClipboardText = 256
+
+// Clipboard value kinds
+const (
+	ClipboardHex     = "hex"
+	ClipboardDecimal = "decimal"
+)
+
+// decimalPattern matches decimal integers and floats with dot or comma.
+var decimalPattern = regexp.MustCompile(`^[+-]?[0-9]+([.,][0-9]+)?$`)
+
+// ClipboardWatcher polls the system clipboard and converts newly copied hex
+// or decimal values. Reading the clipboard is delegated to a function so the
+// watcher does not depend on the GUI runtime.
+type ClipboardWatcher struct {
+	conv *Converter
+	read func() (string, error)
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewClipboardWatcher creates a stopped watcher reading the clipboard with read.
+func NewClipboardWatcher(conv *Converter, read func() (string, error)) *ClipboardWatcher {
+	return &ClipboardWatcher{conv: conv, read: read}
+}
+
+// Start polls the clipboard every interval, calling notify for every new text
+// that converts as a value. Text already on the clipboard is not converted.
+// Starting a running watcher is a no-op.
+func (w *ClipboardWatcher) Start(interval time.Duration, notify func(models.ClipboardConversion)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.done = make(chan struct{})
+	last, _ := w.read()
+
+	go func(done chan struct{}) {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				text, err := w.read()
+				if err != nil || text == last {
+					continue
+				}
+				last = text
+				if conv, ok := w.conv.ConvertClipboardText(text); ok {
+					notify(*conv)
+				}
+			}
+		}
+	}(w.done)
+}
+
+// Stop ends polling and waits for the watcher to finish.
+func (w *ClipboardWatcher) Stop() {
+	w.mu.Lock()
+	cancel, done := w.cancel, w.done
+	w.cancel, w.done = nil, nil
+	w.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+// Running reports whether the watcher is polling the clipboard.
+func (w *ClipboardWatcher) Running() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.cancel != nil
+}
+
+// ConvertClipboardText detects whether text is a single decimal or hex value
+// and converts it. Decimal takes precedence, so "1234" is read as a number;
+// use a 0x prefix for hex digits only. It reports false for any other text.
+func (c *Converter) ConvertClipboardText(text string) (*models.ClipboardConversion, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" || len(text) > MaxClipboardText {
+		return nil, false
+	}
+
+	kind := ClipboardHex
+	var result *models.ConversionResult
+	var err error
+	if decimalPattern.MatchString(text) {
+		kind = ClipboardDecimal
+		result, err = c.ConvertIntAuto(strings.TrimPrefix(text, "+"))
+	} else {
+		if _, err := convert.HexToBytes(text); err != nil {
+			return nil, false
+		}
+		result, err = c.ConvertHex(text)
+	}
+	if err != nil {
+		return nil, false
+	}
+
+	return &models.ClipboardConversion{
+		Text:      text,
+		Kind:      kind,
+		Result:    result,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}, true
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"hexview/models"
+)
+
+func TestConverter_ConvertClipboardText(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		text      string
+		wantOK    bool
+		wantKind  string
+		wantBytes string
+	}{
+		{"0x1234", true, ClipboardHex, "1234"},
+		{"  de ad be ef\n", true, ClipboardHex, "deadbeef"},
+		{"1234", true, ClipboardDecimal, "04d2"},
+		{"+255", true, ClipboardDecimal, "ff"},
+		{"-1", true, ClipboardDecimal, "ff"},
+		{"hello world", false, "", ""},
+		{"", false, "", ""},
+		{string(make([]byte, MaxClipboardText+1)), false, "", ""},
+	}
+	for _, tt := range tests {
+		got, ok := c.ConvertClipboardText(tt.text)
+		if ok != tt.wantOK {
+			t.Errorf("ConvertClipboardText(%q) ok = %v, want %v", tt.text, ok, tt.wantOK)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if got.Kind != tt.wantKind || got.Result.Bytes != tt.wantBytes {
+			t.Errorf("ConvertClipboardText(%q) = %s %s, want %s %s", tt.text, got.Kind, got.Result.Bytes, tt.wantKind, tt.wantBytes)
+		}
+	}
+}
+
+func TestClipboardWatcher(t *testing.T) {
+	var mu sync.Mutex
+	clipboard := "0xff"
+	setClipboard := func(s string) {
+		mu.Lock()
+		clipboard = s
+		mu.Unlock()
+	}
+	read := func() (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return clipboard, nil
+	}
+
+	w := NewClipboardWatcher(NewConverter(), read)
+	converted := make(chan models.ClipboardConversion, 4)
+	w.Start(time.Millisecond, func(c models.ClipboardConversion) { converted <- c })
+	if !w.Running() {
+		t.Fatal("Expected watcher to be running")
+	}
+
+	// Text present before Start and non-values are ignored.
+	setClipboard("not a value")
+	time.Sleep(20 * time.Millisecond)
+	setClipboard("0x4142")
+
+	select {
+	case c := <-converted:
+		if c.Text != "0x4142" || c.Result.ASCII != "AB" {
+			t.Errorf("Unexpected conversion: %+v", c)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for conversion")
+	}
+	select {
+	case c := <-converted:
+		t.Errorf("Unexpected extra conversion: %+v", c)
+	default:
+	}
+
+	w.Stop()
+	if w.Running() {
+		t.Error("Expected watcher to be stopped")
+	}
+	w.Stop()
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+
+	"hexview/models"
+)
+
+// CompactResult returns the non-empty values of a conversion keyed by their
+// JSON field name, e.g. {"int32LE": {"value": "1", "hex": "01000000"}}. The
+// representations are added as "bytes" (hex), "binary", "ascii" and
+// "canonical" (value).
+// Script sections are left out.
+func CompactResult(r *models.ConversionResult) map[string]models.TypedValue {
+	compact := make(map[string]models.TypedValue)
+	v := reflect.ValueOf(r).Elem()
+	for _, f := range resultFields {
+		field := v.Field(f.index)
+		if field.IsNil() {
+			continue
+		}
+		compact[f.name] = models.TypedValue{Value: fmt.Sprint(field.Elem().Interface()), Hex: v.Field(f.hexIndex).String()}
+	}
+
+	if r.Bytes != "" {
+		compact["bytes"] = models.TypedValue{Hex: r.Bytes}
+	}
+	if r.Binary != "" {
+		compact["binary"] = models.TypedValue{Value: r.Binary}
+	}
+	if r.ASCII != "" {
+		compact["ascii"] = models.TypedValue{Value: r.ASCII}
+	}
+	if r.Canonical != "" {
+		compact["canonical"] = models.TypedValue{Value: r.Canonical}
+	}
+	return compact
+}
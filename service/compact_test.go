@@ -0,0 +1,35 @@
+package service
+
+import (
+	"testing"
+
+	"hexview/models"
+)
+
+func TestCompactResult(t *testing.T) {
+	r, err := NewConverter().ConvertHex("0102")
+	if err != nil {
+		t.Fatalf("ConvertHex() error: %v", err)
+	}
+	got := CompactResult(r)
+
+	want := map[string]models.TypedValue{
+		"uint16BE": {Value: "258", Hex: "0102"},
+		"int16LE":  {Value: "513", Hex: "0201"},
+		"bytes":    {Hex: "0102"},
+		"binary":   {Value: "00000001 00000010"},
+	}
+	for name, w := range want {
+		if got[name] != w {
+			t.Errorf("%s = %+v, want %+v", name, got[name], w)
+		}
+	}
+	if _, ok := got["int8BE"]; ok {
+		t.Error("Expected no int8 values for two bytes")
+	}
+	for name, v := range got {
+		if v.Value == "" && v.Hex == "" {
+			t.Errorf("%s is empty", name)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"hexview/container"
+	"hexview/models"
+)
+
+// DecodeContainer lists the chunks of a PNG/ZIP/TAR/RIFF container given as hex input.
+func (c *Converter) DecodeContainer(hexInput string) (*models.ContainerLayout, error) {
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeContainer(data)
+}
+
+// ContainerLayout lists the chunks of an opened PNG/ZIP/TAR/RIFF file.
+// Chunk offsets can be passed to ReadRange for navigation.
+func (s *FileService) ContainerLayout(id string) (*models.ContainerLayout, error) {
+	f, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return decodeContainer(f.buf.Bytes())
+}
+
+// decodeContainer parses data and converts the layout to its model representation.
+func decodeContainer(data []byte) (*models.ContainerLayout, error) {
+	layout, err := container.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ContainerLayout{
+		Format:   string(layout.Format),
+		Chunks:   make([]models.ContainerChunk, len(layout.Chunks)),
+		Warnings: layout.Warnings,
+	}
+	for i, ch := range layout.Chunks {
+		result.Chunks[i] = models.ContainerChunk{
+			Name:       ch.Name,
+			Offset:     ch.Offset,
+			Size:       ch.Size,
+			DataOffset: ch.DataOffset,
+			DataSize:   ch.DataSize,
+			Details:    ch.Details,
+			Valid:      ch.Valid,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"testing"
+)
+
+// minimalPNG is a 1x1 grayscale PNG with valid CRCs.
+const minimalPNG = "89504e470d0a1a0a" +
+	"0000000d49484452000000010000000108000000003a7e9b55" +
+	"0000000f49444154789c000200fdff020003000006000321fcac06" +
+	"0000000049454e44ae426082"
+
+func TestDecodeContainer(t *testing.T) {
+	c := NewConverter()
+
+	result, err := c.DecodeContainer(minimalPNG)
+	if err != nil {
+		t.Fatalf("DecodeContainer() error: %v", err)
+	}
+	if result.Format != "PNG" {
+		t.Errorf("Expected format PNG, got %s", result.Format)
+	}
+	if len(result.Chunks) != 4 {
+		t.Fatalf("Expected 4 chunks, got %d: %+v", len(result.Chunks), result.Chunks)
+	}
+	if result.Chunks[1].Name != "IHDR" || result.Chunks[1].Offset != 8 {
+		t.Errorf("Unexpected IHDR chunk: %+v", result.Chunks[1])
+	}
+	for _, ch := range result.Chunks {
+		if !ch.Valid {
+			t.Errorf("Chunk %s unexpectedly invalid (warnings: %v)", ch.Name, result.Warnings)
+		}
+	}
+}
+
+func TestDecodeContainer_Errors(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"empty", ""},
+		{"invalid hex", "GG"},
+		{"unknown format", "00112233"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.DecodeContainer(tt.input); err == nil {
+				t.Errorf("DecodeContainer(%q) expected error", tt.input)
+			}
+		})
+	}
+}
+
+func TestFileService_ContainerLayout(t *testing.T) {
+	data := []byte("RIFF\x04\x00\x00\x00WAVE")
+	s := NewFileService()
+	info, err := s.Open(writeTempFile(t, "empty.wav", data))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	layout, err := s.ContainerLayout(info.ID)
+	if err != nil {
+		t.Fatalf("ContainerLayout() error: %v", err)
+	}
+	if layout.Format != "RIFF" || len(layout.Chunks) != 1 {
+		t.Errorf("Unexpected layout: %+v", layout)
+	}
+}
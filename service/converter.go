@@ -4,8 +4,11 @@
 package service
 
 import (
+	"encoding/hex"
 	"fmt"
 	"math"
+	"reflect"
+	"strconv"
 	"strings"
 
 	"hexview/convert"
@@ -13,216 +16,65 @@ import (
 )
 
 // Converter provides methods for converting between hex, integer, binary, and float formats.
-type Converter struct{}
+type Converter struct {
+	cache *resultCache // nil if results are not cached
+}
 
 // NewConverter creates a new Converter instance.
 func NewConverter() *Converter {
 	return &Converter{}
 }
 
+// NewCachedConverter creates a Converter that keeps the results of the last
+// size conversions, so repeated conversions of the same input, e.g. while
+// polling or watching the clipboard, are not computed again.
+func NewCachedConverter(size int) *Converter {
+	return &Converter{cache: newResultCache(size)}
+}
+
 // ConvertHex performs all possible conversions on hex input.
 func (c *Converter) ConvertHex(hexInput string) (*models.ConversionResult, error) {
-	if hexInput == "" {
-		return nil, fmt.Errorf("empty input")
-	}
-
-	result := &models.ConversionResult{}
-
-	// Convert to bytes first to get binary representation
-	bytes, err := convert.HexToBytes(hexInput)
-	if err != nil {
-		return nil, fmt.Errorf("invalid hex input: %w", err)
-	}
-
-	result.Binary = convert.BytesToBinary(bytes)
-	result.Bytes = convert.BytesToHex(bytes)
-	result.ASCII = bytesToASCII(bytes)
-
-	// Try all signed integer conversions (Big Endian)
-	if v, err := convert.HexToInt8(hexInput); err == nil {
-		result.Int8BE = &v
-		result.Int8BEHex = convert.Int8ToHex(v)
-	}
-	if v, err := convert.HexToInt16(hexInput); err == nil {
-		result.Int16BE = &v
-		result.Int16BEHex = convert.Int16ToHex(v)
-	}
-	if v, err := convert.HexToInt32(hexInput); err == nil {
-		result.Int32BE = &v
-		result.Int32BEHex = convert.Int32ToHex(v)
-	}
-	if v, err := convert.HexToInt64(hexInput); err == nil {
-		result.Int64BE = &v
-		result.Int64BEHex = convert.Int64ToHex(v)
-	}
-
-	// Try all signed integer conversions (Little Endian)
-	if v, err := convert.HexToInt16LE(hexInput); err == nil {
-		result.Int16LE = &v
-		result.Int16LEHex = convert.Int16ToHexLE(v)
-	}
-	if v, err := convert.HexToInt32LE(hexInput); err == nil {
-		result.Int32LE = &v
-		result.Int32LEHex = convert.Int32ToHexLE(v)
-	}
-	if v, err := convert.HexToInt64LE(hexInput); err == nil {
-		result.Int64LE = &v
-		result.Int64LEHex = convert.Int64ToHexLE(v)
-	}
-
-	// Try all signed integer conversions (Mid-Big Endian / BADC)
-	if v, err := convert.HexToInt16BADC(hexInput); err == nil {
-		result.Int16BADC = &v
-		result.Int16BADCHex = convert.Int16ToHexBADC(v)
-	}
-	if v, err := convert.HexToInt32BADC(hexInput); err == nil {
-		result.Int32BADC = &v
-		result.Int32BADCHex = convert.Int32ToHexBADC(v)
-	}
-	if v, err := convert.HexToInt64BADC(hexInput); err == nil {
-		result.Int64BADC = &v
-		result.Int64BADCHex = convert.Int64ToHexBADC(v)
-	}
-
-	// Try all signed integer conversions (Mid-Little Endian / CDAB)
-	if v, err := convert.HexToInt16CDAB(hexInput); err == nil {
-		result.Int16CDAB = &v
-		result.Int16CDABHex = convert.Int16ToHexCDAB(v)
-	}
-	if v, err := convert.HexToInt32CDAB(hexInput); err == nil {
-		result.Int32CDAB = &v
-		result.Int32CDABHex = convert.Int32ToHexCDAB(v)
-	}
-	if v, err := convert.HexToInt64CDAB(hexInput); err == nil {
-		result.Int64CDAB = &v
-		result.Int64CDABHex = convert.Int64ToHexCDAB(v)
-	}
-
-	// Try all unsigned integer conversions (Big Endian)
-	if v, err := convert.HexToUint8(hexInput); err == nil {
-		result.Uint8BE = &v
-		result.Uint8BEHex = convert.Uint8ToHex(v)
-	}
-	if v, err := convert.HexToUint16(hexInput); err == nil {
-		result.Uint16BE = &v
-		result.Uint16BEHex = convert.Uint16ToHex(v)
-	}
-	if v, err := convert.HexToUint32(hexInput); err == nil {
-		result.Uint32BE = &v
-		result.Uint32BEHex = convert.Uint32ToHex(v)
-	}
-	if v, err := convert.HexToUint64(hexInput); err == nil {
-		result.Uint64BE = &v
-		result.Uint64BEHex = convert.Uint64ToHex(v)
-	}
-
-	// Try all unsigned integer conversions (Little Endian)
-	if v, err := convert.HexToUint16LE(hexInput); err == nil {
-		result.Uint16LE = &v
-		result.Uint16LEHex = convert.Uint16ToHexLE(v)
-	}
-	if v, err := convert.HexToUint32LE(hexInput); err == nil {
-		result.Uint32LE = &v
-		result.Uint32LEHex = convert.Uint32ToHexLE(v)
-	}
-	if v, err := convert.HexToUint64LE(hexInput); err == nil {
-		result.Uint64LE = &v
-		result.Uint64LEHex = convert.Uint64ToHexLE(v)
-	}
-
-	// Try all unsigned integer conversions (Mid-Big Endian / BADC)
-	if v, err := convert.HexToUint16BADC(hexInput); err == nil {
-		result.Uint16BADC = &v
-		result.Uint16BADCHex = convert.Uint16ToHexBADC(v)
-	}
-	if v, err := convert.HexToUint32BADC(hexInput); err == nil {
-		result.Uint32BADC = &v
-		result.Uint32BADCHex = convert.Uint32ToHexBADC(v)
-	}
-	if v, err := convert.HexToUint64BADC(hexInput); err == nil {
-		result.Uint64BADC = &v
-		result.Uint64BADCHex = convert.Uint64ToHexBADC(v)
-	}
-
-	// Try all unsigned integer conversions (Mid-Little Endian / CDAB)
-	if v, err := convert.HexToUint16CDAB(hexInput); err == nil {
-		result.Uint16CDAB = &v
-		result.Uint16CDABHex = convert.Uint16ToHexCDAB(v)
-	}
-	if v, err := convert.HexToUint32CDAB(hexInput); err == nil {
-		result.Uint32CDAB = &v
-		result.Uint32CDABHex = convert.Uint32ToHexCDAB(v)
-	}
-	if v, err := convert.HexToUint64CDAB(hexInput); err == nil {
-		result.Uint64CDAB = &v
-		result.Uint64CDABHex = convert.Uint64ToHexCDAB(v)
-	}
-
-	// Try float conversions (Big Endian)
-	if v, err := convert.HexToFloat32(hexInput); err == nil {
-		formatted := formatFloat32(v)
-		result.Float32BE = &formatted
-		result.Float32BEHex = convert.Float32ToHex(v)
-	}
-	if v, err := convert.HexToFloat64(hexInput); err == nil {
-		formatted := formatFloat64(v)
-		result.Float64BE = &formatted
-		result.Float64BEHex = convert.Float64ToHex(v)
-	}
-
-	// Try float conversions (Little Endian)
-	if v, err := convert.HexToFloat32LE(hexInput); err == nil {
-		formatted := formatFloat32(v)
-		result.Float32LE = &formatted
-		result.Float32LEHex = convert.Float32ToHexLE(v)
-	}
-	if v, err := convert.HexToFloat64LE(hexInput); err == nil {
-		formatted := formatFloat64(v)
-		result.Float64LE = &formatted
-		result.Float64LEHex = convert.Float64ToHexLE(v)
-	}
-
-	// Try float conversions (Mid-Big Endian / BADC)
-	if v, err := convert.HexToFloat32BADC(hexInput); err == nil {
-		formatted := formatFloat32(v)
-		result.Float32BADC = &formatted
-		result.Float32BADCHex = convert.Float32ToHexBADC(v)
-	}
-	if v, err := convert.HexToFloat64BADC(hexInput); err == nil {
-		formatted := formatFloat64(v)
-		result.Float64BADC = &formatted
-		result.Float64BADCHex = convert.Float64ToHexBADC(v)
-	}
-
-	// Try float conversions (Mid-Little Endian / CDAB)
-	if v, err := convert.HexToFloat32CDAB(hexInput); err == nil {
-		formatted := formatFloat32(v)
-		result.Float32CDAB = &formatted
-		result.Float32CDABHex = convert.Float32ToHexCDAB(v)
-	}
-	if v, err := convert.HexToFloat64CDAB(hexInput); err == nil {
-		formatted := formatFloat64(v)
-		result.Float64CDAB = &formatted
-		result.Float64CDABHex = convert.Float64ToHexCDAB(v)
-	}
+	return cached(c, ModeHex, hexInput, "", func() (*models.ConversionResult, error) {
+		if hexInput == "" {
+			return nil, errEmptyInput()
+		}
 
-	return result, nil
+		bytes, err := convert.HexToBytes(hexInput)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex input: %w", err)
+		}
+		result := bytesResult(bytes, allSections)
+		result.Canonical = canonicalHex(bytes)
+		return result, nil
+	})
 }
 
 // ConvertInt performs conversions from integer input to hex and binary.
-func (c *Converter) ConvertInt(intInput string, intType string) (*models.ConversionResult, error) {
+func (c *Converter) ConvertInt(intInput string, intType models.IntType) (*models.ConversionResult, error) {
+	return cached(c, ModeInt, intInput, string(intType), func() (*models.ConversionResult, error) {
+		result, err := completeResult(c.convertInt(intInput, intType))
+		if err != nil {
+			return nil, err
+		}
+		result.Canonical = canonicalValue(result, string(intType)+"BE")
+		return result, nil
+	})
+}
+
+// convertInt sets the values of ConvertInt.
+func (c *Converter) convertInt(intInput string, intType models.IntType) (*models.ConversionResult, error) {
 	if intInput == "" {
-		return nil, fmt.Errorf("empty input")
+		return nil, errEmptyInput()
 	}
 
 	result := &models.ConversionResult{}
 
 	switch intType {
-	case "int8":
+	case models.Int8:
 		var val int8
 		_, err := fmt.Sscanf(intInput, "%d", &val)
 		if err != nil {
-			return nil, fmt.Errorf("invalid int8 value: %w", err)
+			return nil, numberError(intInput, "int8", false, err)
 		}
 		hexStr := convert.Int8ToHex(val)
 		bytes, _ := convert.HexToBytes(hexStr)
@@ -233,11 +85,11 @@ func (c *Converter) ConvertInt(intInput string, intType string) (*models.Convers
 		result.Int8BEHex = hexStr
 		return result, nil
 
-	case "int16":
+	case models.Int16:
 		var val int16
 		_, err := fmt.Sscanf(intInput, "%d", &val)
 		if err != nil {
-			return nil, fmt.Errorf("invalid int16 value: %w", err)
+			return nil, numberError(intInput, "int16", false, err)
 		}
 		hexStrBE := convert.Int16ToHex(val)
 		hexStrLE := convert.Int16ToHexLE(val)
@@ -253,11 +105,11 @@ func (c *Converter) ConvertInt(intInput string, intType string) (*models.Convers
 		}
 		return result, nil
 
-	case "int32":
+	case models.Int32:
 		var val int32
 		_, err := fmt.Sscanf(intInput, "%d", &val)
 		if err != nil {
-			return nil, fmt.Errorf("invalid int32 value: %w", err)
+			return nil, numberError(intInput, "int32", false, err)
 		}
 		hexStrBE := convert.Int32ToHex(val)
 		hexStrLE := convert.Int32ToHexLE(val)
@@ -273,11 +125,11 @@ func (c *Converter) ConvertInt(intInput string, intType string) (*models.Convers
 		}
 		return result, nil
 
-	case "int64":
+	case models.Int64:
 		var val int64
 		_, err := fmt.Sscanf(intInput, "%d", &val)
 		if err != nil {
-			return nil, fmt.Errorf("invalid int64 value: %w", err)
+			return nil, numberError(intInput, "int64", false, err)
 		}
 		hexStrBE := convert.Int64ToHex(val)
 		hexStrLE := convert.Int64ToHexLE(val)
@@ -293,11 +145,11 @@ func (c *Converter) ConvertInt(intInput string, intType string) (*models.Convers
 		}
 		return result, nil
 
-	case "uint8":
+	case models.Uint8:
 		var val uint8
 		_, err := fmt.Sscanf(intInput, "%d", &val)
 		if err != nil {
-			return nil, fmt.Errorf("invalid uint8 value: %w", err)
+			return nil, numberError(intInput, "uint8", false, err)
 		}
 		hexStr := convert.Uint8ToHex(val)
 		bytes, _ := convert.HexToBytes(hexStr)
@@ -308,11 +160,11 @@ func (c *Converter) ConvertInt(intInput string, intType string) (*models.Convers
 		result.Uint8BEHex = hexStr
 		return result, nil
 
-	case "uint16":
+	case models.Uint16:
 		var val uint16
 		_, err := fmt.Sscanf(intInput, "%d", &val)
 		if err != nil {
-			return nil, fmt.Errorf("invalid uint16 value: %w", err)
+			return nil, numberError(intInput, "uint16", false, err)
 		}
 		hexStrBE := convert.Uint16ToHex(val)
 		hexStrLE := convert.Uint16ToHexLE(val)
@@ -328,11 +180,11 @@ func (c *Converter) ConvertInt(intInput string, intType string) (*models.Convers
 		}
 		return result, nil
 
-	case "uint32":
+	case models.Uint32:
 		var val uint32
 		_, err := fmt.Sscanf(intInput, "%d", &val)
 		if err != nil {
-			return nil, fmt.Errorf("invalid uint32 value: %w", err)
+			return nil, numberError(intInput, "uint32", false, err)
 		}
 		hexStrBE := convert.Uint32ToHex(val)
 		hexStrLE := convert.Uint32ToHexLE(val)
@@ -348,11 +200,11 @@ func (c *Converter) ConvertInt(intInput string, intType string) (*models.Convers
 		}
 		return result, nil
 
-	case "uint64":
+	case models.Uint64:
 		var val uint64
 		_, err := fmt.Sscanf(intInput, "%d", &val)
 		if err != nil {
-			return nil, fmt.Errorf("invalid uint64 value: %w", err)
+			return nil, numberError(intInput, "uint64", false, err)
 		}
 		hexStrBE := convert.Uint64ToHex(val)
 		hexStrLE := convert.Uint64ToHexLE(val)
@@ -369,7 +221,7 @@ func (c *Converter) ConvertInt(intInput string, intType string) (*models.Convers
 		return result, nil
 
 	default:
-		return nil, fmt.Errorf("unsupported integer type: %s", intType)
+		return nil, errUnsupportedType("integer", string(intType))
 	}
 }
 
@@ -379,8 +231,15 @@ func (c *Converter) ConvertInt(intInput string, intType string) (*models.Convers
 // If the input contains a decimal point or comma, it's treated as a float.
 // Negative values automatically exclude unsigned types.
 func (c *Converter) ConvertIntAuto(intInput string) (*models.ConversionResult, error) {
+	return cached(c, ModeIntAuto, intInput, "", func() (*models.ConversionResult, error) {
+		return completeResult(c.convertIntAuto(intInput))
+	})
+}
+
+// convertIntAuto sets the values of ConvertIntAuto.
+func (c *Converter) convertIntAuto(intInput string) (*models.ConversionResult, error) {
 	if intInput == "" {
-		return nil, fmt.Errorf("empty input")
+		return nil, errEmptyInput()
 	}
 
 	// Normalize comma to dot for float parsing (support both European and US notation)
@@ -397,8 +256,9 @@ func (c *Converter) ConvertIntAuto(intInput string) (*models.ConversionResult, e
 	var val64 int64
 	_, err := fmt.Sscanf(intInput, "%d", &val64)
 	if err != nil {
-		return nil, fmt.Errorf("invalid decimal value: %w", err)
+		return nil, numberError(intInput, "decimal", false, err)
 	}
+	result.Canonical = strconv.FormatInt(val64, 10)
 
 	// Helper function to set binary/bytes/ASCII from hex string (use first valid representation)
 	setCommonFields := func(hexStr string) {
@@ -527,8 +387,9 @@ func (c *Converter) convertFloatAuto(floatInput string) (*models.ConversionResul
 	var val64 float64
 	_, err := fmt.Sscanf(floatInput, "%f", &val64)
 	if err != nil {
-		return nil, fmt.Errorf("invalid float value: %w", err)
+		return nil, numberError(floatInput, "float", true, err)
 	}
+	result.Canonical = formatFloat64(val64)
 
 	// Convert to float32 to check if it fits without precision loss
 	val32 := float32(val64)
@@ -606,208 +467,357 @@ func (c *Converter) convertFloatAuto(floatInput string) (*models.ConversionResul
 
 // ConvertBinary performs all possible conversions on binary input.
 func (c *Converter) ConvertBinary(binaryInput string) (*models.ConversionResult, error) {
-	if binaryInput == "" {
-		return nil, fmt.Errorf("empty input")
-	}
+	return cached(c, ModeBinary, binaryInput, "", func() (*models.ConversionResult, error) {
+		if binaryInput == "" {
+			return nil, errEmptyInput()
+		}
 
-	result := &models.ConversionResult{}
+		bytes, err := convert.ParseBinary(binaryInput)
+		if err != nil {
+			return nil, fmt.Errorf("invalid binary input: %w", err)
+		}
+		result := bytesResult(bytes, allSections)
+		result.Canonical = convert.BytesToBinary(bytes)
+		return result, nil
+	})
+}
 
-	bytes, err := convert.ParseBinary(binaryInput)
-	if err != nil {
-		return nil, fmt.Errorf("invalid binary input: %w", err)
+// ConvertBytes performs all possible conversions on raw bytes, e.g. a range
+// of an opened file or a capture, without hex-encoding them first.
+func (c *Converter) ConvertBytes(data []byte) (*models.ConversionResult, error) {
+	if len(data) == 0 {
+		return nil, errEmptyInput()
 	}
+	return bytesResult(data, allSections), nil
+}
 
+// bytesResult interprets bytes as every integer and float type in all byte
+// orders. Types smaller than the input are left empty, as are mid-endian and
+// float values unless their section is in sections.
+func bytesResult(bytes []byte, sections sectionSet) *models.ConversionResult {
+	result := &models.ConversionResult{}
 	result.Binary = convert.BytesToBinary(bytes)
 	result.Bytes = convert.BytesToHex(bytes)
 	result.ASCII = bytesToASCII(bytes)
-
-	hexStr := convert.BytesToHex(bytes)
+	result.Base64 = convert.BytesToBase64(bytes)
+	result.Base32 = convert.BytesToBase32(bytes)
+	result.BCD, _ = convert.BytesToBCD(bytes)
+	result.BCDUnpacked, _ = convert.BytesToBCDUnpacked(bytes)
+	result.IPv4, _ = convert.BytesToIPv4(bytes)
+	result.IPv6, _ = convert.BytesToIPv6(bytes)
+	result.MAC = macAddress(bytes)
+	result.Text = sniffText(bytes)
+	result.Strings = decodeStrings(bytes)
 
 	// Try all signed integer conversions (Big Endian)
-	if v, err := convert.HexToInt8(hexStr); err == nil {
+	if v, err := convert.BytesToInt8(bytes); err == nil {
 		result.Int8BE = &v
 		result.Int8BEHex = convert.Int8ToHex(v)
 	}
-	if v, err := convert.HexToInt16(hexStr); err == nil {
+	if v, err := convert.BytesToInt16(bytes, convert.BigEndian); err == nil {
 		result.Int16BE = &v
 		result.Int16BEHex = convert.Int16ToHex(v)
 	}
-	if v, err := convert.HexToInt32(hexStr); err == nil {
+	if v, err := convert.BytesToInt32(bytes, convert.BigEndian); err == nil {
 		result.Int32BE = &v
 		result.Int32BEHex = convert.Int32ToHex(v)
 	}
-	if v, err := convert.HexToInt64(hexStr); err == nil {
+	if v, err := convert.BytesToInt64(bytes, convert.BigEndian); err == nil {
 		result.Int64BE = &v
 		result.Int64BEHex = convert.Int64ToHex(v)
 	}
 
 	// Try all signed integer conversions (Little Endian)
-	if v, err := convert.HexToInt16LE(hexStr); err == nil {
+	if v, err := convert.BytesToInt16(bytes, convert.LittleEndian); err == nil {
 		result.Int16LE = &v
 		result.Int16LEHex = convert.Int16ToHexLE(v)
 	}
-	if v, err := convert.HexToInt32LE(hexStr); err == nil {
+	if v, err := convert.BytesToInt32(bytes, convert.LittleEndian); err == nil {
 		result.Int32LE = &v
 		result.Int32LEHex = convert.Int32ToHexLE(v)
 	}
-	if v, err := convert.HexToInt64LE(hexStr); err == nil {
+	if v, err := convert.BytesToInt64(bytes, convert.LittleEndian); err == nil {
 		result.Int64LE = &v
 		result.Int64LEHex = convert.Int64ToHexLE(v)
 	}
 
-	// Try all signed integer conversions (Mid-Big Endian / BADC)
-	if v, err := convert.HexToInt16BADC(hexStr); err == nil {
-		result.Int16BADC = &v
-		result.Int16BADCHex = convert.Int16ToHexBADC(v)
-	}
-	if v, err := convert.HexToInt32BADC(hexStr); err == nil {
-		result.Int32BADC = &v
-		result.Int32BADCHex = convert.Int32ToHexBADC(v)
-	}
-	if v, err := convert.HexToInt64BADC(hexStr); err == nil {
-		result.Int64BADC = &v
-		result.Int64BADCHex = convert.Int64ToHexBADC(v)
-	}
-
-	// Try all signed integer conversions (Mid-Little Endian / CDAB)
-	if v, err := convert.HexToInt16CDAB(hexStr); err == nil {
-		result.Int16CDAB = &v
-		result.Int16CDABHex = convert.Int16ToHexCDAB(v)
-	}
-	if v, err := convert.HexToInt32CDAB(hexStr); err == nil {
-		result.Int32CDAB = &v
-		result.Int32CDABHex = convert.Int32ToHexCDAB(v)
-	}
-	if v, err := convert.HexToInt64CDAB(hexStr); err == nil {
-		result.Int64CDAB = &v
-		result.Int64CDABHex = convert.Int64ToHexCDAB(v)
-	}
-
 	// Try all unsigned integer conversions (Big Endian)
-	if v, err := convert.HexToUint8(hexStr); err == nil {
+	if v, err := convert.BytesToUint8(bytes); err == nil {
 		result.Uint8BE = &v
 		result.Uint8BEHex = convert.Uint8ToHex(v)
 	}
-	if v, err := convert.HexToUint16(hexStr); err == nil {
+	if v, err := convert.BytesToUint16(bytes, convert.BigEndian); err == nil {
 		result.Uint16BE = &v
 		result.Uint16BEHex = convert.Uint16ToHex(v)
 	}
-	if v, err := convert.HexToUint32(hexStr); err == nil {
+	if v, err := convert.BytesToUint32(bytes, convert.BigEndian); err == nil {
 		result.Uint32BE = &v
 		result.Uint32BEHex = convert.Uint32ToHex(v)
 	}
-	if v, err := convert.HexToUint64(hexStr); err == nil {
+	if v, err := convert.BytesToUint64(bytes, convert.BigEndian); err == nil {
 		result.Uint64BE = &v
 		result.Uint64BEHex = convert.Uint64ToHex(v)
 	}
 
 	// Try all unsigned integer conversions (Little Endian)
-	if v, err := convert.HexToUint16LE(hexStr); err == nil {
+	if v, err := convert.BytesToUint16(bytes, convert.LittleEndian); err == nil {
 		result.Uint16LE = &v
 		result.Uint16LEHex = convert.Uint16ToHexLE(v)
 	}
-	if v, err := convert.HexToUint32LE(hexStr); err == nil {
+	if v, err := convert.BytesToUint32(bytes, convert.LittleEndian); err == nil {
 		result.Uint32LE = &v
 		result.Uint32LEHex = convert.Uint32ToHexLE(v)
 	}
-	if v, err := convert.HexToUint64LE(hexStr); err == nil {
+	if v, err := convert.BytesToUint64(bytes, convert.LittleEndian); err == nil {
 		result.Uint64LE = &v
 		result.Uint64LEHex = convert.Uint64ToHexLE(v)
 	}
 
-	// Try all unsigned integer conversions (Mid-Big Endian / BADC)
-	if v, err := convert.HexToUint16BADC(hexStr); err == nil {
-		result.Uint16BADC = &v
-		result.Uint16BADCHex = convert.Uint16ToHexBADC(v)
-	}
-	if v, err := convert.HexToUint32BADC(hexStr); err == nil {
-		result.Uint32BADC = &v
-		result.Uint32BADCHex = convert.Uint32ToHexBADC(v)
-	}
-	if v, err := convert.HexToUint64BADC(hexStr); err == nil {
-		result.Uint64BADC = &v
-		result.Uint64BADCHex = convert.Uint64ToHexBADC(v)
+	if sections.has(SectionBitFlags) {
+		result.BitFlagsBE = bitFlags(bytes, convert.BigEndian)
+		result.BitFlagsLE = bitFlags(bytes, convert.LittleEndian)
 	}
 
-	// Try all unsigned integer conversions (Mid-Little Endian / CDAB)
-	if v, err := convert.HexToUint16CDAB(hexStr); err == nil {
-		result.Uint16CDAB = &v
-		result.Uint16CDABHex = convert.Uint16ToHexCDAB(v)
-	}
-	if v, err := convert.HexToUint32CDAB(hexStr); err == nil {
-		result.Uint32CDAB = &v
-		result.Uint32CDABHex = convert.Uint32ToHexCDAB(v)
-	}
-	if v, err := convert.HexToUint64CDAB(hexStr); err == nil {
-		result.Uint64CDAB = &v
-		result.Uint64CDABHex = convert.Uint64ToHexCDAB(v)
-	}
+	if sections.has(SectionLegacySigned) {
+		// Try all one's complement conversions (Big Endian)
+		if v, err := convert.BytesToOnesComplement8(bytes); err == nil {
+			le := v
+			result.OnesComplement8BE, result.OnesComplement8BEHex = &v, convert.BytesToHex(bytes)
+			result.OnesComplement8LE, result.OnesComplement8LEHex = &le, result.OnesComplement8BEHex
+		}
+		if v, err := convert.BytesToOnesComplement16(bytes, convert.BigEndian); err == nil {
+			u, _ := convert.BytesToUint16(bytes, convert.BigEndian)
+			result.OnesComplement16BE = &v
+			result.OnesComplement16BEHex = convert.Uint16ToHex(u)
+		}
+		if v, err := convert.BytesToOnesComplement32(bytes, convert.BigEndian); err == nil {
+			u, _ := convert.BytesToUint32(bytes, convert.BigEndian)
+			result.OnesComplement32BE = &v
+			result.OnesComplement32BEHex = convert.Uint32ToHex(u)
+		}
+		if v, err := convert.BytesToOnesComplement64(bytes, convert.BigEndian); err == nil {
+			u, _ := convert.BytesToUint64(bytes, convert.BigEndian)
+			result.OnesComplement64BE = &v
+			result.OnesComplement64BEHex = convert.Uint64ToHex(u)
+		}
+		// Try all one's complement conversions (Little Endian)
+		if v, err := convert.BytesToOnesComplement16(bytes, convert.LittleEndian); err == nil {
+			u, _ := convert.BytesToUint16(bytes, convert.LittleEndian)
+			result.OnesComplement16LE = &v
+			result.OnesComplement16LEHex = convert.Uint16ToHexLE(u)
+		}
+		if v, err := convert.BytesToOnesComplement32(bytes, convert.LittleEndian); err == nil {
+			u, _ := convert.BytesToUint32(bytes, convert.LittleEndian)
+			result.OnesComplement32LE = &v
+			result.OnesComplement32LEHex = convert.Uint32ToHexLE(u)
+		}
+		if v, err := convert.BytesToOnesComplement64(bytes, convert.LittleEndian); err == nil {
+			u, _ := convert.BytesToUint64(bytes, convert.LittleEndian)
+			result.OnesComplement64LE = &v
+			result.OnesComplement64LEHex = convert.Uint64ToHexLE(u)
+		}
 
-	// Try float conversions (Big Endian)
-	if v, err := convert.HexToFloat32(hexStr); err == nil {
-		formatted := formatFloat32(v)
-		result.Float32BE = &formatted
-		result.Float32BEHex = convert.Float32ToHex(v)
-	}
-	if v, err := convert.HexToFloat64(hexStr); err == nil {
-		formatted := formatFloat64(v)
-		result.Float64BE = &formatted
-		result.Float64BEHex = convert.Float64ToHex(v)
+		// Try all sign-magnitude conversions (Big Endian)
+		if v, err := convert.BytesToSignMagnitude8(bytes); err == nil {
+			le := v
+			result.SignMagnitude8BE, result.SignMagnitude8BEHex = &v, convert.BytesToHex(bytes)
+			result.SignMagnitude8LE, result.SignMagnitude8LEHex = &le, result.SignMagnitude8BEHex
+		}
+		if v, err := convert.BytesToSignMagnitude16(bytes, convert.BigEndian); err == nil {
+			u, _ := convert.BytesToUint16(bytes, convert.BigEndian)
+			result.SignMagnitude16BE = &v
+			result.SignMagnitude16BEHex = convert.Uint16ToHex(u)
+		}
+		if v, err := convert.BytesToSignMagnitude32(bytes, convert.BigEndian); err == nil {
+			u, _ := convert.BytesToUint32(bytes, convert.BigEndian)
+			result.SignMagnitude32BE = &v
+			result.SignMagnitude32BEHex = convert.Uint32ToHex(u)
+		}
+		if v, err := convert.BytesToSignMagnitude64(bytes, convert.BigEndian); err == nil {
+			u, _ := convert.BytesToUint64(bytes, convert.BigEndian)
+			result.SignMagnitude64BE = &v
+			result.SignMagnitude64BEHex = convert.Uint64ToHex(u)
+		}
+		// Try all sign-magnitude conversions (Little Endian)
+		if v, err := convert.BytesToSignMagnitude16(bytes, convert.LittleEndian); err == nil {
+			u, _ := convert.BytesToUint16(bytes, convert.LittleEndian)
+			result.SignMagnitude16LE = &v
+			result.SignMagnitude16LEHex = convert.Uint16ToHexLE(u)
+		}
+		if v, err := convert.BytesToSignMagnitude32(bytes, convert.LittleEndian); err == nil {
+			u, _ := convert.BytesToUint32(bytes, convert.LittleEndian)
+			result.SignMagnitude32LE = &v
+			result.SignMagnitude32LEHex = convert.Uint32ToHexLE(u)
+		}
+		if v, err := convert.BytesToSignMagnitude64(bytes, convert.LittleEndian); err == nil {
+			u, _ := convert.BytesToUint64(bytes, convert.LittleEndian)
+			result.SignMagnitude64LE = &v
+			result.SignMagnitude64LEHex = convert.Uint64ToHexLE(u)
+		}
 	}
 
-	// Try float conversions (Little Endian)
-	if v, err := convert.HexToFloat32LE(hexStr); err == nil {
-		formatted := formatFloat32(v)
-		result.Float32LE = &formatted
-		result.Float32LEHex = convert.Float32ToHexLE(v)
-	}
-	if v, err := convert.HexToFloat64LE(hexStr); err == nil {
-		formatted := formatFloat64(v)
-		result.Float64LE = &formatted
-		result.Float64LEHex = convert.Float64ToHexLE(v)
-	}
+	if sections.has(SectionMidEndian) {
+		// Try all signed integer conversions (Mid-Big Endian / BADC)
+		if v, err := convert.BytesToInt16(bytes, convert.MidBigEndian); err == nil {
+			result.Int16BADC = &v
+			result.Int16BADCHex = convert.Int16ToHexBADC(v)
+		}
+		if v, err := convert.BytesToInt32(bytes, convert.MidBigEndian); err == nil {
+			result.Int32BADC = &v
+			result.Int32BADCHex = convert.Int32ToHexBADC(v)
+		}
+		if v, err := convert.BytesToInt64(bytes, convert.MidBigEndian); err == nil {
+			result.Int64BADC = &v
+			result.Int64BADCHex = convert.Int64ToHexBADC(v)
+		}
 
-	// Try float conversions (Mid-Big Endian / BADC)
-	if v, err := convert.HexToFloat32BADC(hexStr); err == nil {
-		formatted := formatFloat32(v)
-		result.Float32BADC = &formatted
-		result.Float32BADCHex = convert.Float32ToHexBADC(v)
-	}
-	if v, err := convert.HexToFloat64BADC(hexStr); err == nil {
-		formatted := formatFloat64(v)
-		result.Float64BADC = &formatted
-		result.Float64BADCHex = convert.Float64ToHexBADC(v)
+		// Try all signed integer conversions (Mid-Little Endian / CDAB)
+		if v, err := convert.BytesToInt16(bytes, convert.MidLittleEndian); err == nil {
+			result.Int16CDAB = &v
+			result.Int16CDABHex = convert.Int16ToHexCDAB(v)
+		}
+		if v, err := convert.BytesToInt32(bytes, convert.MidLittleEndian); err == nil {
+			result.Int32CDAB = &v
+			result.Int32CDABHex = convert.Int32ToHexCDAB(v)
+		}
+		if v, err := convert.BytesToInt64(bytes, convert.MidLittleEndian); err == nil {
+			result.Int64CDAB = &v
+			result.Int64CDABHex = convert.Int64ToHexCDAB(v)
+		}
+
+		// Try all unsigned integer conversions (Mid-Big Endian / BADC)
+		if v, err := convert.BytesToUint16(bytes, convert.MidBigEndian); err == nil {
+			result.Uint16BADC = &v
+			result.Uint16BADCHex = convert.Uint16ToHexBADC(v)
+		}
+		if v, err := convert.BytesToUint32(bytes, convert.MidBigEndian); err == nil {
+			result.Uint32BADC = &v
+			result.Uint32BADCHex = convert.Uint32ToHexBADC(v)
+		}
+		if v, err := convert.BytesToUint64(bytes, convert.MidBigEndian); err == nil {
+			result.Uint64BADC = &v
+			result.Uint64BADCHex = convert.Uint64ToHexBADC(v)
+		}
+
+		// Try all unsigned integer conversions (Mid-Little Endian / CDAB)
+		if v, err := convert.BytesToUint16(bytes, convert.MidLittleEndian); err == nil {
+			result.Uint16CDAB = &v
+			result.Uint16CDABHex = convert.Uint16ToHexCDAB(v)
+		}
+		if v, err := convert.BytesToUint32(bytes, convert.MidLittleEndian); err == nil {
+			result.Uint32CDAB = &v
+			result.Uint32CDABHex = convert.Uint32ToHexCDAB(v)
+		}
+		if v, err := convert.BytesToUint64(bytes, convert.MidLittleEndian); err == nil {
+			result.Uint64CDAB = &v
+			result.Uint64CDABHex = convert.Uint64ToHexCDAB(v)
+		}
 	}
 
-	// Try float conversions (Mid-Little Endian / CDAB)
-	if v, err := convert.HexToFloat32CDAB(hexStr); err == nil {
-		formatted := formatFloat32(v)
-		result.Float32CDAB = &formatted
-		result.Float32CDABHex = convert.Float32ToHexCDAB(v)
+	if sections.has(SectionFloat) {
+		// Try float conversions (Big Endian)
+		if v, err := convert.BytesToFloat16(bytes, convert.BigEndian); err == nil {
+			formatted := formatFloat32(v)
+			result.Float16BE = &formatted
+			result.Float16BEHex = convert.Float16ToHex(v)
+		}
+		if v, err := convert.BytesToFloat32(bytes, convert.BigEndian); err == nil {
+			formatted := formatFloat32(v)
+			result.Float32BE = &formatted
+			result.Float32BEHex = convert.Float32ToHex(v)
+		}
+		if v, err := convert.BytesToFloat64(bytes, convert.BigEndian); err == nil {
+			formatted := formatFloat64(v)
+			result.Float64BE = &formatted
+			result.Float64BEHex = convert.Float64ToHex(v)
+		}
+
+		// Try float conversions (Little Endian)
+		if v, err := convert.BytesToFloat16(bytes, convert.LittleEndian); err == nil {
+			formatted := formatFloat32(v)
+			result.Float16LE = &formatted
+			result.Float16LEHex = convert.Float16ToHexLE(v)
+		}
+		if v, err := convert.BytesToFloat32(bytes, convert.LittleEndian); err == nil {
+			formatted := formatFloat32(v)
+			result.Float32LE = &formatted
+			result.Float32LEHex = convert.Float32ToHexLE(v)
+		}
+		if v, err := convert.BytesToFloat64(bytes, convert.LittleEndian); err == nil {
+			formatted := formatFloat64(v)
+			result.Float64LE = &formatted
+			result.Float64LEHex = convert.Float64ToHexLE(v)
+		}
 	}
-	if v, err := convert.HexToFloat64CDAB(hexStr); err == nil {
-		formatted := formatFloat64(v)
-		result.Float64CDAB = &formatted
-		result.Float64CDABHex = convert.Float64ToHexCDAB(v)
+
+	if sections.has(SectionFloat) && sections.has(SectionMidEndian) {
+		// Try float conversions (Mid-Big Endian / BADC)
+		if v, err := convert.BytesToFloat16(bytes, convert.MidBigEndian); err == nil {
+			formatted := formatFloat32(v)
+			result.Float16BADC = &formatted
+			result.Float16BADCHex = convert.Float16ToHexBADC(v)
+		}
+		if v, err := convert.BytesToFloat32(bytes, convert.MidBigEndian); err == nil {
+			formatted := formatFloat32(v)
+			result.Float32BADC = &formatted
+			result.Float32BADCHex = convert.Float32ToHexBADC(v)
+		}
+		if v, err := convert.BytesToFloat64(bytes, convert.MidBigEndian); err == nil {
+			formatted := formatFloat64(v)
+			result.Float64BADC = &formatted
+			result.Float64BADCHex = convert.Float64ToHexBADC(v)
+		}
+
+		// Try float conversions (Mid-Little Endian / CDAB)
+		if v, err := convert.BytesToFloat16(bytes, convert.MidLittleEndian); err == nil {
+			formatted := formatFloat32(v)
+			result.Float16CDAB = &formatted
+			result.Float16CDABHex = convert.Float16ToHexCDAB(v)
+		}
+		if v, err := convert.BytesToFloat32(bytes, convert.MidLittleEndian); err == nil {
+			formatted := formatFloat32(v)
+			result.Float32CDAB = &formatted
+			result.Float32CDABHex = convert.Float32ToHexCDAB(v)
+		}
+		if v, err := convert.BytesToFloat64(bytes, convert.MidLittleEndian); err == nil {
+			formatted := formatFloat64(v)
+			result.Float64CDAB = &formatted
+			result.Float64CDABHex = convert.Float64ToHexCDAB(v)
+		}
 	}
 
-	return result, nil
+	deriveFields(result)
+	return result
 }
 
 // ConvertFloat performs conversions from float input to hex and binary.
-func (c *Converter) ConvertFloat(floatInput string, floatType string) (*models.ConversionResult, error) {
+// Rounding shows the values float32 and float64 actually store for the input.
+func (c *Converter) ConvertFloat(floatInput string, floatType models.FloatType) (*models.ConversionResult, error) {
+	return cached(c, ModeFloat, floatInput, string(floatType), func() (*models.ConversionResult, error) {
+		result, err := completeResult(c.convertFloat(floatInput, floatType))
+		if err != nil {
+			return nil, err
+		}
+		result.Canonical = canonicalValue(result, string(floatType)+"BE")
+		result.Rounding = floatRounding(floatInput)
+		return result, nil
+	})
+}
+
+// convertFloat sets the values of ConvertFloat.
+func (c *Converter) convertFloat(floatInput string, floatType models.FloatType) (*models.ConversionResult, error) {
 	if floatInput == "" {
-		return nil, fmt.Errorf("empty input")
+		return nil, errEmptyInput()
 	}
 
 	result := &models.ConversionResult{}
 
 	switch floatType {
-	case "float32":
+	case models.Float32:
 		var val float32
 		_, err := fmt.Sscanf(floatInput, "%f", &val)
 		if err != nil {
-			return nil, fmt.Errorf("invalid float32 value: %w", err)
+			return nil, numberError(floatInput, "float32", true, err)
 		}
 		hexStrBE := convert.Float32ToHex(val)
 		bytes, _ := convert.HexToBytes(hexStrBE)
@@ -849,11 +859,11 @@ func (c *Converter) ConvertFloat(floatInput string, floatType string) (*models.C
 
 		return result, nil
 
-	case "float64":
+	case models.Float64:
 		var val float64
 		_, err := fmt.Sscanf(floatInput, "%f", &val)
 		if err != nil {
-			return nil, fmt.Errorf("invalid float64 value: %w", err)
+			return nil, numberError(floatInput, "float64", true, err)
 		}
 		hexStrBE := convert.Float64ToHex(val)
 		bytes, _ := convert.HexToBytes(hexStrBE)
@@ -896,27 +906,36 @@ func (c *Converter) ConvertFloat(floatInput string, floatType string) (*models.C
 		return result, nil
 
 	default:
-		return nil, fmt.Errorf("unsupported float type: %s", floatType)
+		return nil, errUnsupportedType("float", string(floatType))
 	}
 }
 
 // ConvertModbusRegisters converts an array of 16-bit register values.
 func (c *Converter) ConvertModbusRegisters(input string) (*models.ModbusResult, error) {
-	if input == "" {
-		return nil, fmt.Errorf("empty input")
-	}
+	return cached(c, ModeModbus, input, "", func() (*models.ModbusResult, error) {
+		if input == "" {
+			return nil, errEmptyInput()
+		}
 
-	registers, err := parseModbusInput(input)
-	if err != nil {
-		return nil, err
-	}
+		registers, err := parseModbusInput(input)
+		if err != nil {
+			return nil, err
+		}
 
-	if len(registers) == 0 {
-		return nil, fmt.Errorf("no valid register values found")
-	}
+		if len(registers) == 0 {
+			return nil, errNoRegisters()
+		}
+
+		return modbusBatch(registers, 0, len(registers), allSections), nil
+	})
+}
 
+// modbusBatch converts registers[lo:hi]. Combined values starting in the batch
+// may extend past hi into the following registers. The combinations are only
+// computed if their section is in sections.
+func modbusBatch(registers []uint16, lo, hi int, sections sectionSet) *models.ModbusResult {
 	result := &models.ModbusResult{
-		Registers:  make([]models.ModbusRegister, len(registers)),
+		Registers:  make([]models.ModbusRegister, hi-lo),
 		Combined32: make([]models.ModbusCombined32, 0),
 		Combined64: make([]models.ModbusCombined64, 0),
 	}
@@ -924,14 +943,15 @@ func (c *Converter) ConvertModbusRegisters(input string) (*models.ModbusResult,
 	var allBytes []byte
 	var hexParts []string
 
-	for i, val := range registers {
+	for i := lo; i < hi; i++ {
+		val := registers[i]
 		regHex := convert.Uint16ToHex(val)
 		hexParts = append(hexParts, regHex)
 
 		regBytes, _ := convert.HexToBytes(regHex)
 		allBytes = append(allBytes, regBytes...)
 
-		result.Registers[i] = models.ModbusRegister{
+		result.Registers[i-lo] = models.ModbusRegister{
 			Index:    i + 1,
 			Hex:      regHex,
 			Unsigned: val,
@@ -943,94 +963,147 @@ func (c *Converter) ConvertModbusRegisters(input string) (*models.ModbusResult,
 	result.RawHex = strings.Join(hexParts, " ")
 	result.ASCII = bytesToASCII(allBytes)
 
-	// Generate 32-bit combinations
-	for i := 0; i <= len(registers)-2; i++ {
-		hexStr := convert.Uint16ToHex(registers[i]) + convert.Uint16ToHex(registers[i+1])
+	if sections.has(SectionModbus32) {
+		// Generate 32-bit combinations
+		for i := lo; i < hi && i+1 < len(registers); i++ {
+			hexStr := convert.Uint16ToHex(registers[i]) + convert.Uint16ToHex(registers[i+1])
 
-		combined := models.ModbusCombined32{
-			RegisterStart: i + 1,
-			Hex:           hexStr,
-		}
+			combined := models.ModbusCombined32{
+				RegisterStart: i + 1,
+				Hex:           hexStr,
+			}
 
-		if v, err := convert.HexToUint32(hexStr); err == nil {
-			combined.Uint32BE = v
-		}
-		if v, err := convert.HexToUint32LE(hexStr); err == nil {
-			combined.Uint32LE = v
-		}
-		if v, err := convert.HexToUint32BADC(hexStr); err == nil {
-			combined.Uint32BADC = v
-		}
-		if v, err := convert.HexToUint32CDAB(hexStr); err == nil {
-			combined.Uint32CDAB = v
-		}
-		if v, err := convert.HexToInt32(hexStr); err == nil {
-			combined.Int32BE = v
-		}
-		if v, err := convert.HexToInt32LE(hexStr); err == nil {
-			combined.Int32LE = v
-		}
-		if v, err := convert.HexToInt32BADC(hexStr); err == nil {
-			combined.Int32BADC = v
-		}
-		if v, err := convert.HexToInt32CDAB(hexStr); err == nil {
-			combined.Int32CDAB = v
-		}
-		if v, err := convert.HexToFloat32(hexStr); err == nil {
-			combined.Float32BE = formatFloat32(v)
-		}
-		if v, err := convert.HexToFloat32LE(hexStr); err == nil {
-			combined.Float32LE = formatFloat32(v)
-		}
-		if v, err := convert.HexToFloat32BADC(hexStr); err == nil {
-			combined.Float32BADC = formatFloat32(v)
-		}
-		if v, err := convert.HexToFloat32CDAB(hexStr); err == nil {
-			combined.Float32CDAB = formatFloat32(v)
-		}
+			if v, err := convert.HexToUint32(hexStr); err == nil {
+				combined.Uint32BE = v
+			}
+			if v, err := convert.HexToUint32LE(hexStr); err == nil {
+				combined.Uint32LE = v
+			}
+			if v, err := convert.HexToUint32BADC(hexStr); err == nil {
+				combined.Uint32BADC = v
+			}
+			if v, err := convert.HexToUint32CDAB(hexStr); err == nil {
+				combined.Uint32CDAB = v
+			}
+			if v, err := convert.HexToInt32(hexStr); err == nil {
+				combined.Int32BE = v
+			}
+			if v, err := convert.HexToInt32LE(hexStr); err == nil {
+				combined.Int32LE = v
+			}
+			if v, err := convert.HexToInt32BADC(hexStr); err == nil {
+				combined.Int32BADC = v
+			}
+			if v, err := convert.HexToInt32CDAB(hexStr); err == nil {
+				combined.Int32CDAB = v
+			}
+			if v, err := convert.HexToFloat32(hexStr); err == nil {
+				combined.Float32BE = formatFloat32(v)
+			}
+			if v, err := convert.HexToFloat32LE(hexStr); err == nil {
+				combined.Float32LE = formatFloat32(v)
+			}
+			if v, err := convert.HexToFloat32BADC(hexStr); err == nil {
+				combined.Float32BADC = formatFloat32(v)
+			}
+			if v, err := convert.HexToFloat32CDAB(hexStr); err == nil {
+				combined.Float32CDAB = formatFloat32(v)
+			}
 
-		result.Combined32 = append(result.Combined32, combined)
+			result.Combined32 = append(result.Combined32, combined)
+		}
 	}
 
-	// Generate 64-bit combinations
-	for i := 0; i <= len(registers)-4; i++ {
-		hexStr := convert.Uint16ToHex(registers[i]) +
-			convert.Uint16ToHex(registers[i+1]) +
-			convert.Uint16ToHex(registers[i+2]) +
-			convert.Uint16ToHex(registers[i+3])
+	if sections.has(SectionModbus64) {
+		// Generate 64-bit combinations
+		for i := lo; i < hi && i+3 < len(registers); i++ {
+			hexStr := convert.Uint16ToHex(registers[i]) +
+				convert.Uint16ToHex(registers[i+1]) +
+				convert.Uint16ToHex(registers[i+2]) +
+				convert.Uint16ToHex(registers[i+3])
 
-		combined := models.ModbusCombined64{
-			RegisterStart: i + 1,
-			Hex:           hexStr,
-		}
+			combined := models.ModbusCombined64{
+				RegisterStart: i + 1,
+				Hex:           hexStr,
+			}
 
-		if v, err := convert.HexToUint64(hexStr); err == nil {
-			combined.Uint64BE = v
-		}
-		if v, err := convert.HexToUint64LE(hexStr); err == nil {
-			combined.Uint64LE = v
-		}
-		if v, err := convert.HexToInt64(hexStr); err == nil {
-			combined.Int64BE = v
-		}
-		if v, err := convert.HexToInt64LE(hexStr); err == nil {
-			combined.Int64LE = v
-		}
-		if v, err := convert.HexToFloat64(hexStr); err == nil {
-			combined.Float64BE = formatFloat64(v)
-		}
-		if v, err := convert.HexToFloat64LE(hexStr); err == nil {
-			combined.Float64LE = formatFloat64(v)
-		}
+			if v, err := convert.HexToUint64(hexStr); err == nil {
+				combined.Uint64BE = v
+			}
+			if v, err := convert.HexToUint64LE(hexStr); err == nil {
+				combined.Uint64LE = v
+			}
+			if v, err := convert.HexToInt64(hexStr); err == nil {
+				combined.Int64BE = v
+			}
+			if v, err := convert.HexToInt64LE(hexStr); err == nil {
+				combined.Int64LE = v
+			}
+			if v, err := convert.HexToFloat64(hexStr); err == nil {
+				combined.Float64BE = formatFloat64(v)
+			}
+			if v, err := convert.HexToFloat64LE(hexStr); err == nil {
+				combined.Float64LE = formatFloat64(v)
+			}
+			if v, err := convert.HexToFloat64BADC(hexStr); err == nil {
+				combined.Float64BADC = formatFloat64(v)
+			}
+			if v, err := convert.HexToFloat64CDAB(hexStr); err == nil {
+				combined.Float64CDAB = formatFloat64(v)
+			}
+			for j, half := range []string{hexStr[:8], hexStr[8:]} {
+				if v, err := convert.HexToFloat32(half); err == nil {
+					combined.Float32PairBE[j] = formatFloat32(v)
+				}
+				if v, err := convert.HexToFloat32CDAB(half); err == nil {
+					combined.Float32PairCDAB[j] = formatFloat32(v)
+				}
+			}
 
-		result.Combined64 = append(result.Combined64, combined)
+			result.Combined64 = append(result.Combined64, combined)
+		}
 	}
 
-	return result, nil
+	return result
 }
 
 // Helper functions
 
+// completeResult derives the remaining fields of a successful conversion.
+func completeResult(result *models.ConversionResult, err error) (*models.ConversionResult, error) {
+	if err != nil {
+		return nil, err
+	}
+	deriveFields(result)
+	return result, nil
+}
+
+// deriveFields sets the fields that follow from others: the little-endian
+// 8-bit values, which equal the big-endian ones, and the binary form of
+// every value.
+func deriveFields(result *models.ConversionResult) {
+	if result.Int8BE != nil {
+		v := *result.Int8BE
+		result.Int8LE, result.Int8LEHex = &v, result.Int8BEHex
+	}
+	if result.Uint8BE != nil {
+		v := *result.Uint8BE
+		result.Uint8LE, result.Uint8LEHex = &v, result.Uint8BEHex
+	}
+
+	r := reflect.ValueOf(result).Elem()
+	var buf [8]byte
+	for _, f := range resultFields {
+		h := r.Field(f.hexIndex).String()
+		if h == "" || len(h) > 2*len(buf) {
+			continue
+		}
+		if n, err := hex.Decode(buf[:], []byte(h)); err == nil {
+			r.Field(f.binIndex).SetString(convert.BytesToBinary(buf[:n]))
+		}
+	}
+}
+
 func formatFloat32(v float32) string {
 	if math.IsNaN(float64(v)) {
 		return "NaN"
@@ -1080,10 +1153,15 @@ func parseModbusInput(input string) ([]uint16, error) {
 	parts := strings.Fields(normalized)
 	registers := make([]uint16, 0, len(parts))
 
+	// The separators are replaced byte for byte, so offsets in normalized
+	// are offsets in input
+	pos := 0
 	for _, part := range parts {
 		if part == "" {
 			continue
 		}
+		offset := pos + strings.Index(normalized[pos:], part)
+		pos = offset + len(part)
 
 		var val uint64
 		var err error
@@ -1091,19 +1169,22 @@ func parseModbusInput(input string) ([]uint16, error) {
 		if len(part) > 1 && (part[0] == 'd' || part[0] == 'D') {
 			_, err = fmt.Sscanf(part[1:], "%d", &val)
 			if err != nil {
-				return nil, fmt.Errorf("invalid decimal value: %s", part)
+				return nil, convert.NewInputError(convert.CodeInvalidNumber, ErrInvalidNumber,
+					"invalid decimal value: "+part).At(input, offset, len(part))
 			}
 		} else {
 			cleanHex := strings.TrimPrefix(part, "0x")
 			cleanHex = strings.TrimPrefix(cleanHex, "0X")
 			_, err = fmt.Sscanf(cleanHex, "%x", &val)
 			if err != nil {
-				return nil, fmt.Errorf("invalid hex value: %s", part)
+				return nil, convert.NewInputError(convert.CodeInvalidHexChar, convert.ErrInvalidHexChar,
+					"invalid hex value: "+part).At(input, offset, len(part))
 			}
 		}
 
 		if val > 0xFFFF {
-			return nil, fmt.Errorf("value exceeds 16-bit range: %s", part)
+			return nil, convert.NewInputError(convert.CodeOutOfRange, ErrOutOfRange,
+				"value exceeds 16-bit range: "+part).At(input, offset, len(part))
 		}
 
 		registers = append(registers, uint16(val))
@@ -1111,3 +1192,16 @@ func parseModbusInput(input string) ([]uint16, error) {
 
 	return registers, nil
 }
+
+// parseHexBlob converts non-empty hex input to bytes.
+func parseHexBlob(hexInput string) ([]byte, error) {
+	if hexInput == "" {
+		return nil, errEmptyInput()
+	}
+
+	data, err := convert.HexToBytes(hexInput)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex input: %w", err)
+	}
+	return data, nil
+}
@@ -1,7 +1,12 @@
 package service
 
 import (
+	"reflect"
+	"slices"
 	"testing"
+
+	"hexview/convert"
+	"hexview/models"
 )
 
 func TestNewConverter(t *testing.T) {
@@ -80,6 +85,31 @@ func TestConvertHex_IntegerConversions(t *testing.T) {
 	}
 }
 
+func TestConvertHex_Float16(t *testing.T) {
+	c := NewConverter()
+	result, err := c.ConvertHex("3c00")
+	if err != nil {
+		t.Fatalf("ConvertHex(3c00) error: %v", err)
+	}
+	if result.Float16BE == nil || *result.Float16BE != "1" {
+		t.Errorf("Expected Float16BE=1, got %v", result.Float16BE)
+	}
+	if result.Float16LE == nil || *result.Float16LE != "3.5762787e-06" {
+		t.Errorf("Expected Float16LE=3.5762787e-06, got %v", result.Float16LE)
+	}
+	if result.Float16BEBin != "00111100 00000000" {
+		t.Errorf("Expected Float16BEBin=00111100 00000000, got %s", result.Float16BEBin)
+	}
+
+	result, err = c.ConvertHex("3c000000")
+	if err != nil {
+		t.Fatalf("ConvertHex(3c000000) error: %v", err)
+	}
+	if result.Float16BE != nil {
+		t.Errorf("Expected no float16 for 4 bytes, got %v", *result.Float16BE)
+	}
+}
+
 func TestConvertHex_ASCII(t *testing.T) {
 	c := NewConverter()
 	result, err := c.ConvertHex("4869")
@@ -91,6 +121,136 @@ func TestConvertHex_ASCII(t *testing.T) {
 	}
 }
 
+func TestConvertHex_BitFlags(t *testing.T) {
+	c := NewConverter()
+	result, err := c.ConvertHex("8005")
+	if err != nil {
+		t.Fatalf("ConvertHex() error: %v", err)
+	}
+	be, le := result.BitFlagsBE, result.BitFlagsLE
+	if be == nil || le == nil {
+		t.Fatal("ConvertHex() has no bit flags")
+	}
+	if be.Width != 16 || be.Value != "0x8005" || be.SetCount != 3 || be.Parity != "odd" || !slices.Equal(be.Set, []int{0, 2, 15}) {
+		t.Errorf("BitFlagsBE = %+v", be)
+	}
+	want := models.BitFlag{Index: 15, Value: 1, Mask: "0x8000", Byte: 0, BitInByte: 7}
+	if len(be.Bits) != 16 || be.Bits[15] != want {
+		t.Errorf("BitFlagsBE bit 15 = %+v, want %+v", be.Bits[15], want)
+	}
+	if b := be.Bits[2]; b.Value != 1 || b.Byte != 1 || b.BitInByte != 2 {
+		t.Errorf("BitFlagsBE bit 2 = %+v", b)
+	}
+	if le.Value != "0x0580" || !slices.Equal(le.Set, []int{7, 8, 10}) || le.Bits[7].Byte != 0 || le.Bits[8].Byte != 1 {
+		t.Errorf("BitFlagsLE = %+v", le)
+	}
+
+	result, _ = c.ConvertHex("03")
+	if result.BitFlagsBE.Parity != "even" || result.BitFlagsLE.Value != "0x03" {
+		t.Errorf("BitFlags of one byte = %+v, %+v", result.BitFlagsBE, result.BitFlagsLE)
+	}
+	result, _ = c.ConvertHex("000000000000000001")
+	if result.BitFlagsBE != nil || result.BitFlagsLE != nil {
+		t.Error("BitFlags set for 9 bytes")
+	}
+	result, _ = c.ConvertHexSections("ff", nil)
+	if result.BitFlagsBE != nil {
+		t.Error("BitFlags computed without the bitFlags section")
+	}
+}
+
+func TestConvertHex_LegacySigned(t *testing.T) {
+	c := NewConverter()
+	result, err := c.ConvertHex("812c")
+	if err != nil {
+		t.Fatalf("ConvertHex(812c) error: %v", err)
+	}
+	if result.SignMagnitude16BE == nil || *result.SignMagnitude16BE != -300 || result.SignMagnitude16BEHex != "812c" {
+		t.Errorf("SignMagnitude16BE = %v, %q, want -300, 812c", result.SignMagnitude16BE, result.SignMagnitude16BEHex)
+	}
+	if result.OnesComplement16BE == nil || *result.OnesComplement16BE != -32467 || result.OnesComplement16BEBin != "10000001 00101100" {
+		t.Errorf("OnesComplement16BE = %v, %q", result.OnesComplement16BE, result.OnesComplement16BEBin)
+	}
+	if result.SignMagnitude16LE == nil || *result.SignMagnitude16LE != 11393 || result.SignMagnitude16LEHex != result.Int16LEHex {
+		t.Errorf("SignMagnitude16LE = %v, %q, want 11393, %q", result.SignMagnitude16LE, result.SignMagnitude16LEHex, result.Int16LEHex)
+	}
+	if result.OnesComplement8BE != nil {
+		t.Errorf("OnesComplement8BE of 2 bytes = %v, want none", *result.OnesComplement8BE)
+	}
+
+	// Negative zero keeps its bytes
+	result, _ = c.ConvertHex("ff")
+	if *result.OnesComplement8BE != 0 || result.OnesComplement8BEHex != "ff" || *result.SignMagnitude8LE != -127 {
+		t.Errorf("ff = %d (%s), %d, want 0 (ff), -127", *result.OnesComplement8BE, result.OnesComplement8BEHex, *result.SignMagnitude8LE)
+	}
+
+	lazy, _ := c.ConvertHexSections("812c", nil)
+	if lazy.SignMagnitude16BE != nil {
+		t.Error("legacySigned computed without being requested")
+	}
+	lazy, _ = c.ConvertHexSections("812c", []string{SectionLegacySigned})
+	if lazy.SignMagnitude16BE == nil || !slices.Equal(lazy.Sections, []string{SectionLegacySigned}) {
+		t.Errorf("ConvertHexSections(legacySigned) = %v, sections %v", lazy.SignMagnitude16BE, lazy.Sections)
+	}
+}
+
+func TestConvertHex_BCD(t *testing.T) {
+	tests := []struct {
+		input       string
+		bcd         string
+		bcdUnpacked string
+	}{
+		{"20261016", "20261016", ""},
+		{"01020304", "01020304", "1234"},
+		{"4a69", "", ""},
+	}
+	c := NewConverter()
+	for _, tt := range tests {
+		result, err := c.ConvertHex(tt.input)
+		if err != nil {
+			t.Fatalf("ConvertHex(%s) error: %v", tt.input, err)
+		}
+		if result.BCD != tt.bcd || result.BCDUnpacked != tt.bcdUnpacked {
+			t.Errorf("ConvertHex(%s) BCD = %q, %q, want %q, %q", tt.input, result.BCD, result.BCDUnpacked, tt.bcd, tt.bcdUnpacked)
+		}
+	}
+}
+
+func TestConvertHex_NetworkAddress(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		input string
+		ipv4  string
+		ipv6  string
+		mac   models.MACAddress
+	}{
+		{"c0a80001", "192.168.0.1", "", models.MACAddress{}},
+		{"20010db8000000000000000000000001", "", "2001:db8::1", models.MACAddress{}},
+		{"005056a1b2c3", "", "", models.MACAddress{Address: "00:50:56:a1:b2:c3", Vendor: "VMware"}},
+		{"525400123456", "", "", models.MACAddress{Address: "52:54:00:12:34:56", Vendor: "QEMU/KVM virtual NIC", Local: true}},
+		{"ffffffffffff", "", "", models.MACAddress{Address: "ff:ff:ff:ff:ff:ff", Multicast: true, Local: true, Note: "broadcast"}},
+		{"333300000001", "", "", models.MACAddress{Address: "33:33:00:00:00:01", Multicast: true, Local: true, Note: "IPv6 multicast"}},
+		{"01005e000001", "", "", models.MACAddress{Address: "01:00:5e:00:00:01", Vendor: "IPv4 multicast", Multicast: true}},
+		{"123456789a", "", "", models.MACAddress{}},
+	}
+	for _, tt := range tests {
+		result, err := c.ConvertHex(tt.input)
+		if err != nil {
+			t.Fatalf("ConvertHex(%s) error: %v", tt.input, err)
+		}
+		if result.IPv4 != tt.ipv4 || result.IPv6 != tt.ipv6 {
+			t.Errorf("ConvertHex(%s) = %q, %q, want %q, %q", tt.input, result.IPv4, result.IPv6, tt.ipv4, tt.ipv6)
+		}
+		var mac models.MACAddress
+		if result.MAC != nil {
+			mac = *result.MAC
+		}
+		if mac != tt.mac {
+			t.Errorf("ConvertHex(%s) MAC = %+v, want %+v", tt.input, mac, tt.mac)
+		}
+	}
+}
+
 func TestConvertInt_EmptyInput(t *testing.T) {
 	c := NewConverter()
 	_, err := c.ConvertInt("", "int8")
@@ -103,7 +263,7 @@ func TestConvertInt_ValidTypes(t *testing.T) {
 	tests := []struct {
 		name    string
 		input   string
-		intType string
+		intType models.IntType
 		wantErr bool
 	}{
 		{"int8 positive", "127", "int8", false},
@@ -179,7 +339,7 @@ func TestConvertFloat_ValidInput(t *testing.T) {
 	tests := []struct {
 		name      string
 		input     string
-		floatType string
+		floatType models.FloatType
 		wantErr   bool
 	}{
 		{"float32 positive", "3.14159", "float32", false},
@@ -264,6 +424,29 @@ func TestConvertModbusRegisters_Combined32(t *testing.T) {
 	}
 }
 
+func TestConvertModbusRegisters_Combined64(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		input string
+		check func(models.ModbusCombined64) bool
+	}{
+		{"3ff8 0000 0000 0000", func(v models.ModbusCombined64) bool { return v.Float64BE == "1.5" }},
+		{"f83f 0000 0000 0000", func(v models.ModbusCombined64) bool { return v.Float64BADC == "1.5" }},
+		{"0000 3ff8 0000 0000", func(v models.ModbusCombined64) bool { return v.Float64CDAB == "1.5" }},
+		{"4248 0000 3f80 0000", func(v models.ModbusCombined64) bool { return v.Float32PairBE == [2]string{"50", "1"} }},
+		{"0000 4248 0000 3f80", func(v models.ModbusCombined64) bool { return v.Float32PairCDAB == [2]string{"50", "1"} }},
+	}
+	for _, tt := range tests {
+		result, err := c.ConvertModbusRegisters(tt.input)
+		if err != nil {
+			t.Fatalf("ConvertModbusRegisters(%s) error: %v", tt.input, err)
+		}
+		if len(result.Combined64) != 1 || !tt.check(result.Combined64[0]) {
+			t.Errorf("ConvertModbusRegisters(%s) combined64 = %+v", tt.input, result.Combined64)
+		}
+	}
+}
+
 func TestFormatFloat32(t *testing.T) {
 	tests := []struct {
 		val  float32
@@ -724,3 +907,113 @@ func TestConvertIntAuto_IntegerNoFloat(t *testing.T) {
 		t.Error("Expected float fields to be nil for integer input")
 	}
 }
+
+// TestEnumTypes checks that every type of the frontend enums is supported.
+func TestEnumTypes(t *testing.T) {
+	c := NewConverter()
+	for _, e := range models.AllIntTypes {
+		if !e.Value.Valid() {
+			t.Errorf("%s.Valid() = false", e.Value)
+		}
+		if _, err := c.ConvertInt("1", e.Value); err != nil {
+			t.Errorf("ConvertInt(1, %s) error = %v", e.Value, err)
+		}
+	}
+	for _, e := range models.AllFloatTypes {
+		if !e.Value.Valid() {
+			t.Errorf("%s.Valid() = false", e.Value)
+		}
+		if _, err := c.ConvertFloat("1.5", e.Value); err != nil {
+			t.Errorf("ConvertFloat(1.5, %s) error = %v", e.Value, err)
+		}
+	}
+	if models.IntType("int128").Valid() || models.FloatType("float16").Valid() {
+		t.Error("Valid() accepted an unknown type")
+	}
+}
+
+// TestConvertBytes checks that raw bytes convert exactly like their hex form.
+func TestConvertBytes(t *testing.T) {
+	c := NewConverter()
+	for _, input := range [][]byte{{0x42}, {0x42, 0x48, 0x00, 0x00}, []byte("Hello, World")} {
+		got, err := c.ConvertBytes(input)
+		if err != nil {
+			t.Fatalf("ConvertBytes(%x) error: %v", input, err)
+		}
+		want, err := c.ConvertHex(convert.BytesToHex(input))
+		if err != nil {
+			t.Fatalf("ConvertHex(%x) error: %v", input, err)
+		}
+		want.Canonical = "" // raw bytes are not parsed from input
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ConvertBytes(%x) = %+v, want %+v", input, got, want)
+		}
+	}
+	if _, err := c.ConvertBytes(nil); err == nil {
+		t.Error("Expected error for empty input")
+	}
+	if _, err := c.ChecksumBytes(nil); err == nil {
+		t.Error("Expected error for empty checksum input")
+	}
+	if _, err := c.DiffBytes([]byte{1}, nil); err == nil {
+		t.Error("Expected error for empty diff input")
+	}
+}
+
+// TestDerivedFields checks the little-endian 8-bit values and the binary
+// form of every value.
+func TestDerivedFields(t *testing.T) {
+	c := NewConverter()
+	r, err := c.ConvertHex("ff")
+	if err != nil {
+		t.Fatalf("ConvertHex() error: %v", err)
+	}
+	if r.Int8LE == nil || *r.Int8LE != -1 || r.Int8LEHex != "ff" || r.Int8LEBin != "11111111" {
+		t.Errorf("Int8LE = %v, %q, %q", r.Int8LE, r.Int8LEHex, r.Int8LEBin)
+	}
+	if r.Uint8LE == nil || *r.Uint8LE != 255 || r.Uint8BEBin != "11111111" {
+		t.Errorf("Uint8LE = %v, Uint8BEBin = %q", r.Uint8LE, r.Uint8BEBin)
+	}
+
+	r, err = c.ConvertInt("258", models.Int32)
+	if err != nil {
+		t.Fatalf("ConvertInt() error: %v", err)
+	}
+	if r.Int32BEBin != "00000000 00000000 00000001 00000010" || r.Int32LEBin == "" {
+		t.Errorf("Int32BEBin = %q, Int32LEBin = %q", r.Int32BEBin, r.Int32LEBin)
+	}
+
+	r, err = c.ConvertFloat("1.5", models.Float32)
+	if err != nil {
+		t.Fatalf("ConvertFloat() error: %v", err)
+	}
+	if r.Float32BEBin != "00111111 11000000 00000000 00000000" {
+		t.Errorf("Float32BEBin = %q", r.Float32BEBin)
+	}
+
+	// Every value field must have hex and binary fields to be found
+	var values int
+	typ := reflect.TypeFor[models.ConversionResult]()
+	for i := 0; i < typ.NumField(); i++ {
+		if f := typ.Field(i).Type; f.Kind() == reflect.Pointer && f.Elem().Kind() != reflect.Struct {
+			values++
+		}
+	}
+	if len(resultFields) != values {
+		t.Errorf("resultFields has %d entries, ConversionResult %d values", len(resultFields), values)
+	}
+}
+
+func BenchmarkConvertHex(b *testing.B) {
+	c := NewConverter()
+	for _, input := range []string{"42", "42480000", "0x4048f5c3 0x00000000"} {
+		b.Run(input, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := c.ConvertHex(input); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"hexview/models"
+)
+
+// ErrInvalidCrashDump indicates a crash dump layout that is not supported
+// or input too short for it
+var ErrInvalidCrashDump = errors.New("invalid crash dump")
+
+// cortexMExceptions names the system exceptions of the xPSR exception
+// number. Numbers from 16 are external interrupts.
+var cortexMExceptions = map[uint32]string{
+	1: "Reset", 2: "NMI", 3: "HardFault", 4: "MemManage", 5: "BusFault",
+	6: "UsageFault", 7: "SecureFault", 11: "SVCall", 12: "DebugMonitor",
+	14: "PendSV", 15: "SysTick",
+}
+
+// threadXStates names the values of tx_thread_state.
+var threadXStates = []string{
+	"READY", "COMPLETED", "TERMINATED", "SUSPENDED", "SLEEP", "QUEUE_SUSP",
+	"SEMAPHORE_SUSP", "EVENT_FLAG", "BLOCK_MEMORY", "BYTE_MEMORY", "IO_DRIVER",
+	"FILE", "TCP_IP", "MUTEX_SUSP", "PRIORITY_CHANGE",
+}
+
+// threadXID is tx_thread_id of a created thread, "THRD".
+const threadXID = 0x54485244
+
+// tcbField is a field of a task control block layout.
+type tcbField struct {
+	name    string
+	offset  int
+	size    int  // bytes; 4 for words
+	pointer bool // words that are addresses
+	text    bool // a NUL-terminated name
+}
+
+// freeRTOSTCB is TCB_t of a 32-bit FreeRTOS port with the default
+// configuration: no MPU, no list integrity check bytes and
+// configMAX_TASK_NAME_LEN 16.
+var freeRTOSTCB = []tcbField{
+	{name: "pxTopOfStack", offset: 0, size: 4, pointer: true},
+	{name: "xStateListItem.xItemValue", offset: 4, size: 4},
+	{name: "xStateListItem.pxNext", offset: 8, size: 4, pointer: true},
+	{name: "xStateListItem.pxPrevious", offset: 12, size: 4, pointer: true},
+	{name: "xStateListItem.pvOwner", offset: 16, size: 4, pointer: true},
+	{name: "xStateListItem.pxContainer", offset: 20, size: 4, pointer: true},
+	{name: "xEventListItem.xItemValue", offset: 24, size: 4},
+	{name: "xEventListItem.pxNext", offset: 28, size: 4, pointer: true},
+	{name: "xEventListItem.pxPrevious", offset: 32, size: 4, pointer: true},
+	{name: "xEventListItem.pvOwner", offset: 36, size: 4, pointer: true},
+	{name: "xEventListItem.pxContainer", offset: 40, size: 4, pointer: true},
+	{name: "uxPriority", offset: 44, size: 4},
+	{name: "pxStack", offset: 48, size: 4, pointer: true},
+	{name: "pcTaskName", offset: 52, size: 16, text: true},
+}
+
+// threadXTCB is the start of TX_THREAD of a 32-bit ThreadX port, up to the
+// fields that do not depend on the configuration.
+var threadXTCB = []tcbField{
+	{name: "tx_thread_id", offset: 0, size: 4},
+	{name: "tx_thread_run_count", offset: 4, size: 4},
+	{name: "tx_thread_stack_ptr", offset: 8, size: 4, pointer: true},
+	{name: "tx_thread_stack_start", offset: 12, size: 4, pointer: true},
+	{name: "tx_thread_stack_end", offset: 16, size: 4, pointer: true},
+	{name: "tx_thread_stack_size", offset: 20, size: 4},
+	{name: "tx_thread_priority", offset: 24, size: 4},
+	{name: "tx_thread_state", offset: 28, size: 4},
+	{name: "tx_thread_delayed_suspend", offset: 32, size: 4},
+	{name: "tx_thread_suspending", offset: 36, size: 4},
+	{name: "tx_thread_preempt_threshold", offset: 40, size: 4},
+	{name: "tx_thread_schedule_hook", offset: 44, size: 4, pointer: true},
+	{name: "tx_thread_entry", offset: 48, size: 4, pointer: true},
+	{name: "tx_thread_entry_parameter", offset: 52, size: 4},
+}
+
+// DecodeCrashDump decodes a Cortex-M exception stack frame or an RTOS task
+// control block from a little-endian memory dump in hex, e.g. the words at
+// the stack pointer of a HardFault handler. layout is one of the
+// models.Crash* constants. Input after the frame or TCB is counted in
+// Remaining, so a dump can be pasted from its start address.
+func (c *Converter) DecodeCrashDump(hexInput, layout string) (*models.CrashDump, error) {
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+	switch layout {
+	case models.CrashCortexM:
+		return decodeFaultFrame(data, false)
+	case models.CrashCortexMFPU:
+		return decodeFaultFrame(data, true)
+	case models.CrashFreeRTOS:
+		return decodeFreeRTOSTCB(data)
+	case models.CrashThreadX:
+		return decodeThreadXTCB(data)
+	}
+	return nil, fmt.Errorf("%w: unknown layout %q (want %s, %s, %s or %s)", ErrInvalidCrashDump, layout,
+		models.CrashCortexM, models.CrashCortexMFPU, models.CrashFreeRTOS, models.CrashThreadX)
+}
+
+// needBytes checks that data holds a layout of size bytes.
+func needBytes(data []byte, layout string, size int) error {
+	if len(data) < size {
+		return fmt.Errorf("%w: %s needs %d bytes, got %d", ErrInvalidCrashDump, layout, size, len(data))
+	}
+	return nil
+}
+
+// word formats a 32-bit word as a dump field.
+func word(name string, offset int, v uint32, pointer bool) models.DumpField {
+	return models.DumpField{Name: name, Offset: offset, Value: fmt.Sprintf("0x%08x", v), Pointer: pointer}
+}
+
+// decodeFaultFrame decodes the registers the core pushes on exception
+// entry, with the FPU registers of the extended frame if fpu is set.
+func decodeFaultFrame(data []byte, fpu bool) (*models.CrashDump, error) {
+	layout, size := models.CrashCortexM, 32
+	if fpu {
+		layout, size = models.CrashCortexMFPU, 104
+	}
+	if err := needBytes(data, layout, size); err != nil {
+		return nil, err
+	}
+	w := func(i int) uint32 { return binary.LittleEndian.Uint32(data[4*i:]) }
+	dump := &models.CrashDump{Layout: layout, Size: size, Remaining: len(data) - size}
+
+	for i, name := range []string{"R0", "R1", "R2", "R3", "R12"} {
+		dump.Fields = append(dump.Fields, word(name, 4*i, w(i), false))
+	}
+
+	lr := word("LR", 20, w(5), true)
+	lr.Note = "return address of the interrupted function"
+	if w(5) >= 0xffffffe0 {
+		lr.Note = "EXC_RETURN: the exception interrupted another exception handler"
+		lr.Pointer = false
+	}
+	pc := word("PC", 24, w(6), true)
+	pc.Note = "faulting or next instruction"
+	if w(6)&1 != 0 {
+		dump.Warnings = append(dump.Warnings, "PC is odd, which the core never stacks: the frame is probably misaligned")
+	}
+	if w(6) >= 0xffffffe0 {
+		dump.Warnings = append(dump.Warnings, "PC is an EXC_RETURN value: the frame is probably misaligned")
+	}
+
+	xpsr := w(7)
+	exception := xpsr & 0x1ff
+	dump.Exception = "Thread mode"
+	if name, ok := cortexMExceptions[exception]; ok {
+		dump.Exception = name
+	} else if exception >= 16 {
+		dump.Exception = fmt.Sprintf("IRQ %d", exception-16)
+	} else if exception != 0 {
+		dump.Exception = fmt.Sprintf("reserved exception %d", exception)
+	}
+	notes := []string{fmt.Sprintf("exception %d (%s)", exception, dump.Exception)}
+	if xpsr&(1<<9) != 0 {
+		notes = append(notes, "stack realigned by 4 bytes")
+	}
+	flags := ""
+	for bit, flag := range "NZCVQ" {
+		if xpsr&(1<<(31-bit)) != 0 {
+			flags += string(flag)
+		}
+	}
+	if flags != "" {
+		notes = append(notes, "flags "+flags)
+	}
+	if xpsr&(1<<24) == 0 {
+		dump.Warnings = append(dump.Warnings, "Thumb bit of xPSR is clear: the core tried to execute in ARM state (INVSTATE UsageFault), e.g. after a call through a function pointer with bit 0 clear")
+	} else {
+		notes = append(notes, "Thumb")
+	}
+	psr := word("xPSR", 28, xpsr, false)
+	psr.Note = strings.Join(notes, ", ")
+	dump.Fields = append(dump.Fields, lr, pc, psr)
+
+	if fpu {
+		for i := range 16 {
+			s := word("S"+strconv.Itoa(i), 32+4*i, w(8+i), false)
+			s.Note = strconv.FormatFloat(float64(math.Float32frombits(w(8+i))), 'g', -1, 32)
+			dump.Fields = append(dump.Fields, s)
+		}
+		dump.Fields = append(dump.Fields, word("FPSCR", 96, w(24), false), word("Reserved", 100, w(25), false))
+	}
+	return dump, nil
+}
+
+// decodeTCB decodes the fields of a task control block layout.
+func decodeTCB(data []byte, layout string, fields []tcbField) (*models.CrashDump, error) {
+	last := fields[len(fields)-1]
+	size := last.offset + last.size
+	if err := needBytes(data, layout, size); err != nil {
+		return nil, err
+	}
+	dump := &models.CrashDump{Layout: layout, Size: size, Remaining: len(data) - size}
+	for _, f := range fields {
+		raw := data[f.offset : f.offset+f.size]
+		if f.text {
+			name, _, _ := strings.Cut(string(raw), "\x00")
+			dump.Fields = append(dump.Fields, models.DumpField{Name: f.name, Offset: f.offset, Value: bytesToASCII([]byte(name))})
+			continue
+		}
+		dump.Fields = append(dump.Fields, word(f.name, f.offset, binary.LittleEndian.Uint32(raw), f.pointer))
+	}
+	return dump, nil
+}
+
+// decodeFreeRTOSTCB decodes a FreeRTOS TCB and checks that the saved stack
+// pointer lies above the start of the stack, which grows down on Cortex-M.
+func decodeFreeRTOSTCB(data []byte) (*models.CrashDump, error) {
+	dump, err := decodeTCB(data, models.CrashFreeRTOS, freeRTOSTCB)
+	if err != nil {
+		return nil, err
+	}
+	top := binary.LittleEndian.Uint32(data[0:])
+	stack := binary.LittleEndian.Uint32(data[48:])
+	dump.Task = dump.Fields[len(dump.Fields)-1].Value
+	if top < stack {
+		dump.Warnings = append(dump.Warnings, fmt.Sprintf("pxTopOfStack is %d bytes below pxStack: the task overflowed its stack", stack-top))
+	}
+	return dump, nil
+}
+
+// decodeThreadXTCB decodes a ThreadX TCB, names its state and checks its
+// ID and saved stack pointer.
+func decodeThreadXTCB(data []byte) (*models.CrashDump, error) {
+	dump, err := decodeTCB(data, models.CrashThreadX, threadXTCB)
+	if err != nil {
+		return nil, err
+	}
+	w := func(offset int) uint32 { return binary.LittleEndian.Uint32(data[offset:]) }
+
+	if w(0) == threadXID {
+		dump.Fields[0].Note = "THRD"
+	} else {
+		dump.Warnings = append(dump.Warnings, "tx_thread_id is not THRD (0x54485244): not a created thread, or a different layout")
+	}
+	if state := w(28); int(state) < len(threadXStates) {
+		dump.State = threadXStates[state]
+		dump.Fields[7].Note = dump.State
+	}
+	ptr, start, end := w(8), w(12), w(16)
+	switch {
+	case ptr < start:
+		dump.Warnings = append(dump.Warnings, fmt.Sprintf("tx_thread_stack_ptr is %d bytes below tx_thread_stack_start: the thread overflowed its stack", start-ptr))
+	case ptr > end:
+		dump.Warnings = append(dump.Warnings, "tx_thread_stack_ptr is above tx_thread_stack_end: the stack pointer is corrupt")
+	default:
+		dump.Fields[2].Note = fmt.Sprintf("%d of %d bytes used", end+1-ptr, end+1-start)
+	}
+	return dump, nil
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"hexview/models"
+)
+
+// leWords encodes 32-bit words little-endian as hex.
+func leWords(words ...uint32) string {
+	b := make([]byte, 4*len(words))
+	for i, w := range words {
+		binary.LittleEndian.PutUint32(b[4*i:], w)
+	}
+	return hex.EncodeToString(b)
+}
+
+// dumpField returns the field of dump with the given name.
+func dumpField(t *testing.T, dump *models.CrashDump, name string) models.DumpField {
+	t.Helper()
+	for _, f := range dump.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	t.Fatalf("no field %s in %+v", name, dump.Fields)
+	return models.DumpField{}
+}
+
+func TestDecodeCrashDump_FaultFrame(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		layout    string
+		exception string
+		psrNote   string
+		lrNote    string
+		warning   string // substring of the first warning, if any
+		remaining int
+	}{
+		{
+			name:      "hard fault",
+			input:     leWords(1, 2, 3, 4, 12, 0x08000235, 0x08000240, 0x61000003),
+			layout:    models.CrashCortexM,
+			exception: "HardFault",
+			psrNote:   "exception 3 (HardFault), flags ZC, Thumb",
+			lrNote:    "return address of the interrupted function",
+		},
+		{
+			name:      "irq with padding and dump tail",
+			input:     leWords(0, 0, 0, 0, 0, 0xfffffff9, 0x08001000, 0x01000225, 0xdeadbeef),
+			layout:    models.CrashCortexM,
+			exception: "IRQ 21",
+			psrNote:   "exception 37 (IRQ 21), stack realigned by 4 bytes, Thumb",
+			lrNote:    "EXC_RETURN: the exception interrupted another exception handler",
+			remaining: 4,
+		},
+		{
+			name:      "arm state",
+			input:     leWords(0, 0, 0, 0, 0, 0x08000235, 0x08000240, 0x00000000),
+			layout:    models.CrashCortexM,
+			exception: "Thread mode",
+			psrNote:   "exception 0 (Thread mode)",
+			lrNote:    "return address of the interrupted function",
+			warning:   "INVSTATE",
+		},
+		{
+			name:      "misaligned",
+			input:     leWords(0, 0, 0, 0, 0x08000235, 0x08000241, 0x01000003, 0),
+			layout:    models.CrashCortexM,
+			exception: "Thread mode",
+			psrNote:   "exception 0 (Thread mode)",
+			lrNote:    "return address of the interrupted function",
+			warning:   "PC is odd",
+		},
+	}
+	c := NewConverter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dump, err := c.DecodeCrashDump(tt.input, tt.layout)
+			if err != nil {
+				t.Fatalf("DecodeCrashDump() error: %v", err)
+			}
+			if dump.Exception != tt.exception || dump.Size != 32 || dump.Remaining != tt.remaining {
+				t.Errorf("DecodeCrashDump() = %s, %d bytes, %d remaining, want %s, 32, %d", dump.Exception, dump.Size, dump.Remaining, tt.exception, tt.remaining)
+			}
+			if f := dumpField(t, dump, "xPSR"); f.Note != tt.psrNote {
+				t.Errorf("xPSR note = %q, want %q", f.Note, tt.psrNote)
+			}
+			if f := dumpField(t, dump, "LR"); f.Note != tt.lrNote {
+				t.Errorf("LR note = %q, want %q", f.Note, tt.lrNote)
+			}
+			if tt.warning == "" && len(dump.Warnings) != 0 {
+				t.Errorf("Warnings = %v, want none", dump.Warnings)
+			}
+			if tt.warning != "" && (len(dump.Warnings) == 0 || !strings.Contains(dump.Warnings[0], tt.warning)) {
+				t.Errorf("Warnings = %v, want one with %q first", dump.Warnings, tt.warning)
+			}
+		})
+	}
+}
+
+func TestDecodeCrashDump_FPUFrame(t *testing.T) {
+	words := make([]uint32, 26)
+	words[7] = 0x01000004
+	words[8] = 0x3fc00000 // S0 = 1.5
+	words[24] = 0x03000000
+	dump, err := NewConverter().DecodeCrashDump(leWords(words...), models.CrashCortexMFPU)
+	if err != nil {
+		t.Fatalf("DecodeCrashDump() error: %v", err)
+	}
+	if len(dump.Fields) != 26 || dump.Size != 104 || dump.Exception != "MemManage" {
+		t.Fatalf("DecodeCrashDump() = %d fields, %d bytes, %s", len(dump.Fields), dump.Size, dump.Exception)
+	}
+	if f := dumpField(t, dump, "S0"); f.Offset != 32 || f.Note != "1.5" {
+		t.Errorf("S0 = %+v, want 1.5 at 32", f)
+	}
+	if f := dumpField(t, dump, "FPSCR"); f.Value != "0x03000000" {
+		t.Errorf("FPSCR = %s, want 0x03000000", f.Value)
+	}
+}
+
+func TestDecodeCrashDump_FreeRTOS(t *testing.T) {
+	tcb := leWords(0x20001f80, 5, 0, 0, 0x20001000, 0x20000100, 0, 0, 0, 0x20001000, 0, 3, 0x20001c00) +
+		hex.EncodeToString([]byte("SensorTask\x00\x00\x00\x00\x00\x00"))
+	dump, err := NewConverter().DecodeCrashDump(tcb, models.CrashFreeRTOS)
+	if err != nil {
+		t.Fatalf("DecodeCrashDump() error: %v", err)
+	}
+	if dump.Task != "SensorTask" || dump.Size != 68 || len(dump.Warnings) != 0 {
+		t.Errorf("DecodeCrashDump() = task %q, %d bytes, warnings %v", dump.Task, dump.Size, dump.Warnings)
+	}
+	if f := dumpField(t, dump, "uxPriority"); f.Value != "0x00000003" || f.Pointer {
+		t.Errorf("uxPriority = %+v", f)
+	}
+	if f := dumpField(t, dump, "pxStack"); f.Value != "0x20001c00" || !f.Pointer || f.Offset != 48 {
+		t.Errorf("pxStack = %+v", f)
+	}
+
+	overflow := leWords(0x20001bf0) + tcb[8:]
+	dump, _ = NewConverter().DecodeCrashDump(overflow, models.CrashFreeRTOS)
+	if len(dump.Warnings) != 1 || !strings.Contains(dump.Warnings[0], "16 bytes below pxStack") {
+		t.Errorf("Warnings of overflowed stack = %v", dump.Warnings)
+	}
+}
+
+func TestDecodeCrashDump_ThreadX(t *testing.T) {
+	tcb := leWords(threadXID, 42, 0x20002f00, 0x20002000, 0x20002fff, 0x1000, 16, 4, 0, 0, 16, 0, 0x08004001, 0)
+	dump, err := NewConverter().DecodeCrashDump(tcb, models.CrashThreadX)
+	if err != nil {
+		t.Fatalf("DecodeCrashDump() error: %v", err)
+	}
+	if dump.State != "SLEEP" || dump.Size != 56 || len(dump.Warnings) != 0 {
+		t.Errorf("DecodeCrashDump() = state %s, %d bytes, warnings %v", dump.State, dump.Size, dump.Warnings)
+	}
+	if f := dumpField(t, dump, "tx_thread_stack_ptr"); f.Note != "256 of 4096 bytes used" {
+		t.Errorf("tx_thread_stack_ptr note = %q", f.Note)
+	}
+
+	bad := leWords(0, 0, 0x20001ff0, 0x20002000, 0x20002fff) + tcb[40:]
+	dump, _ = NewConverter().DecodeCrashDump(bad, models.CrashThreadX)
+	if len(dump.Warnings) != 2 {
+		t.Errorf("Warnings of a bad TCB = %v, want ID and overflow", dump.Warnings)
+	}
+}
+
+func TestDecodeCrashDump_Errors(t *testing.T) {
+	c := NewConverter()
+	if _, err := c.DecodeCrashDump(leWords(1, 2, 3), models.CrashCortexM); !errors.Is(err, ErrInvalidCrashDump) {
+		t.Errorf("short frame error = %v, want ErrInvalidCrashDump", err)
+	}
+	if _, err := c.DecodeCrashDump(leWords(1), "zephyr"); !errors.Is(err, ErrInvalidCrashDump) {
+		t.Errorf("unknown layout error = %v, want ErrInvalidCrashDump", err)
+	}
+	if _, err := c.DecodeCrashDump("", models.CrashCortexM); err == nil {
+		t.Error("empty input succeeded")
+	}
+}
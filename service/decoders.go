@@ -0,0 +1,325 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+
+	"hexview/checksum"
+	"hexview/container"
+	"hexview/decoder"
+	"hexview/exif"
+	"hexview/lighting"
+	"hexview/models"
+	"hexview/onewire"
+	"hexview/profinet"
+)
+
+// DecoderService manages the built-in decoders and plugins loaded from a
+// directory.
+type DecoderService struct {
+	registry  *decoder.Registry
+	pluginDir string
+
+	mu      sync.Mutex
+	plugins map[string]string // plugin name -> path
+}
+
+// NewDecoderService creates a DecoderService with the built-in decoders.
+// Plugins are loaded from pluginDir by LoadPlugins; an empty dir disables them.
+func NewDecoderService(pluginDir string) *DecoderService {
+	s := &DecoderService{
+		registry:  decoder.NewRegistry(),
+		pluginDir: pluginDir,
+		plugins:   make(map[string]string),
+	}
+	s.registry.Register(decoder.Func("container", acceptsContainer, decodeContainerTree))
+	s.registry.Register(decoder.Func("exif", acceptsEXIF, decodeEXIFTree))
+	s.registry.Register(decoder.Func("profinet", profinet.IsFrame, decodeProfinetTree))
+	s.registry.Register(decoder.Func("dali", decodeOnly, decodeDALITree))
+	s.registry.Register(decoder.Func("dmx512", decodeOnly, decodeDMXTree))
+	s.registry.Register(decoder.Func("onewire", acceptsOneWire, decodeOneWireTree))
+	return s
+}
+
+// LoadPlugins (re)loads all plugins from the plugin directory, replacing
+// previously loaded ones. Plugins that cannot be registered, e.g. because
+// their name is taken, are skipped and reported as errors.
+func (s *DecoderService) LoadPlugins() []error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for name := range s.plugins {
+		s.registry.Unregister(name)
+	}
+	clear(s.plugins)
+	if s.pluginDir == "" {
+		return nil
+	}
+
+	plugins, err := decoder.LoadDir(s.pluginDir)
+	if err != nil {
+		return []error{fmt.Errorf("cannot read plugin directory: %w", err)}
+	}
+	var errs []error
+	for _, p := range plugins {
+		if err := s.registry.Register(p); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.Path(), err))
+			continue
+		}
+		s.plugins[p.Name()] = p.Path()
+	}
+	return errs
+}
+
+// PluginDir returns the directory plugins are loaded from.
+func (s *DecoderService) PluginDir() string {
+	return s.pluginDir
+}
+
+// List returns all registered decoders sorted by name.
+func (s *DecoderService) List() []models.DecoderInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all := s.registry.All()
+	list := make([]models.DecoderInfo, len(all))
+	for i, d := range all {
+		path, plugin := s.plugins[d.Name()]
+		list[i] = models.DecoderInfo{Name: d.Name(), Builtin: !plugin, Path: path}
+	}
+	return list
+}
+
+// Detect returns the names of the decoders accepting hex input.
+func (s *DecoderService) Detect(hexInput string) ([]string, error) {
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0)
+	for _, d := range s.registry.Detect(data) {
+		names = append(names, d.Name())
+	}
+	return names, nil
+}
+
+// Decode decodes hex input with the named decoder.
+func (s *DecoderService) Decode(name, hexInput string) (*models.DecodeNode, error) {
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+	node, err := s.registry.Decode(name, data)
+	if err != nil {
+		return nil, err
+	}
+	out := decodeNodeModel(*node)
+	return &out, nil
+}
+
+// decodeNodeModel converts a decoder tree to its model.
+func decodeNodeModel(n decoder.Node) models.DecodeNode {
+	out := models.DecodeNode{Name: n.Name, Value: n.Value, Offset: n.Offset, Length: n.Length}
+	for _, c := range n.Children {
+		out.Children = append(out.Children, decodeNodeModel(c))
+	}
+	return out
+}
+
+func acceptsContainer(data []byte) bool {
+	_, err := container.Detect(data)
+	return err == nil
+}
+
+// decodeContainerTree lists container chunks as a flat tree.
+func decodeContainerTree(data []byte) (*decoder.Node, error) {
+	layout, err := container.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	root := &decoder.Node{Name: string(layout.Format), Length: int64(len(data))}
+	for _, c := range layout.Chunks {
+		value := c.Details
+		if !c.Valid {
+			value = "invalid " + value
+		}
+		root.Children = append(root.Children, decoder.Node{Name: c.Name, Value: value, Offset: c.Offset, Length: c.Size})
+	}
+	for _, w := range layout.Warnings {
+		root.Children = append(root.Children, decoder.Node{Name: "warning", Value: w})
+	}
+	return root, nil
+}
+
+func acceptsEXIF(data []byte) bool {
+	_, err := exif.FindTIFF(data)
+	return err == nil
+}
+
+// decodeEXIFTree groups EXIF tags by IFD.
+func decodeEXIFTree(data []byte) (*decoder.Node, error) {
+	result, err := exif.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	root := &decoder.Node{Name: "TIFF", Value: result.ByteOrder + " endian", Offset: result.HeaderOffset, Length: int64(len(data)) - result.HeaderOffset}
+	ifds := make(map[string]int)
+	for _, tag := range result.Tags {
+		i, ok := ifds[tag.IFD]
+		if !ok {
+			i = len(root.Children)
+			ifds[tag.IFD] = i
+			root.Children = append(root.Children, decoder.Node{Name: tag.IFD})
+		}
+		root.Children[i].Children = append(root.Children[i].Children,
+			decoder.Node{Name: tag.Name, Value: tag.Value, Offset: tag.Offset, Length: 12})
+	}
+	for _, w := range result.Warnings {
+		root.Children = append(root.Children, decoder.Node{Name: "warning", Value: w})
+	}
+	return root, nil
+}
+
+// decodeProfinetTree shows the Ethernet header, the FrameID and the APDU
+// status or DCP blocks of a PROFINET frame.
+func decodeProfinetTree(data []byte) (*decoder.Node, error) {
+	f, err := profinet.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	root := &decoder.Node{Name: "PROFINET", Value: f.Kind, Length: int64(len(data))}
+	if f.Ethernet {
+		eth := decoder.Node{Name: "Ethernet", Length: int64(f.PayloadOffset), Children: []decoder.Node{
+			{Name: "destination", Value: f.Destination, Offset: 0, Length: 6},
+			{Name: "source", Value: f.Source, Offset: 6, Length: 6},
+		}}
+		if f.VLAN >= 0 {
+			eth.Children = append(eth.Children, decoder.Node{Name: "VLAN", Value: fmt.Sprintf("%d, priority %d", f.VLAN, f.Priority), Offset: 12, Length: 4})
+		}
+		eth.Children = append(eth.Children, decoder.Node{Name: "EtherType", Value: "0x8892", Offset: int64(f.PayloadOffset - 2), Length: 2})
+		root.Children = append(root.Children, eth)
+	}
+	base := int64(f.PayloadOffset)
+	root.Children = append(root.Children, decoder.Node{Name: "FrameID", Value: fmt.Sprintf("0x%04x (%s)", f.FrameID, f.Kind), Offset: base, Length: 2})
+
+	if c := f.Cyclic; c != nil {
+		status := int64(c.StatusOffset)
+		root.Children = append(root.Children,
+			decoder.Node{Name: "data", Value: fmt.Sprintf("%d bytes", c.DataLength), Offset: int64(c.DataOffset), Length: int64(c.DataLength)},
+			decoder.Node{Name: "cycle counter", Value: fmt.Sprint(c.CycleCounter), Offset: status, Length: 2},
+			decoder.Node{Name: "data status", Value: fmt.Sprintf("0x%02x (%s)", c.DataStatus, profinet.DataStatusText(c.DataStatus)), Offset: status + 2, Length: 1},
+			decoder.Node{Name: "transfer status", Value: fmt.Sprintf("0x%02x", c.TransferStatus), Offset: status + 3, Length: 1},
+		)
+	}
+	if d := f.DCP; d != nil {
+		root.Children = append(root.Children,
+			decoder.Node{Name: "service", Value: d.Service(), Offset: base + 2, Length: 2},
+			decoder.Node{Name: "Xid", Value: fmt.Sprintf("0x%08x", d.Xid), Offset: base + 4, Length: 4},
+			decoder.Node{Name: "response delay", Value: fmt.Sprint(d.ResponseDelay), Offset: base + 8, Length: 2},
+			decoder.Node{Name: "data length", Value: fmt.Sprint(d.DataLength), Offset: base + 10, Length: 2},
+		)
+		for _, b := range d.Blocks {
+			node := decoder.Node{Name: b.Name, Value: b.Value, Offset: int64(b.Offset), Length: int64(b.Length)}
+			if b.BlockInfo != nil {
+				node.Children = []decoder.Node{{Name: "block info", Value: fmt.Sprintf("0x%04x", *b.BlockInfo), Offset: int64(b.Offset) + 4, Length: 2}}
+			}
+			root.Children = append(root.Children, node)
+		}
+	}
+	return root, nil
+}
+
+// decodeOnly accepts no data for detection. It is used by decoders of short
+// bus frames that any input of the right size would match; they run only
+// when picked by name.
+func decodeOnly([]byte) bool {
+	return false
+}
+
+// decodeDALITree shows the address and command of a DALI frame.
+func decodeDALITree(data []byte) (*decoder.Node, error) {
+	f, err := lighting.DecodeDALI(data)
+	if err != nil {
+		return nil, err
+	}
+	root := &decoder.Node{Name: "DALI", Value: f.Kind, Length: int64(len(data))}
+	if f.Kind == lighting.DALIBackward {
+		root.Children = append(root.Children, decoder.Node{Name: "answer", Value: f.Command, Length: 1})
+		return root, nil
+	}
+	root.Children = append(root.Children,
+		decoder.Node{Name: "address", Value: fmt.Sprintf("0x%02x (%s)", data[0], f.Address), Offset: 0, Length: 1},
+		decoder.Node{Name: "command", Value: f.Command, Offset: 1, Length: int64(len(data) - 1)},
+	)
+	return root, nil
+}
+
+// decodeDMXTree lists the start code and the slot values of a DMX512 packet.
+// Dimmer slots show their level in percent.
+func decodeDMXTree(data []byte) (*decoder.Node, error) {
+	p, err := lighting.DecodeDMX(data)
+	if err != nil {
+		return nil, err
+	}
+	root := &decoder.Node{Name: "DMX512", Value: fmt.Sprintf("%d slots", len(p.Slots)), Length: int64(len(data))}
+	root.Children = append(root.Children, decoder.Node{Name: "start code", Value: fmt.Sprintf("0x%02x (%s)", p.StartCode, p.Kind), Offset: 0, Length: 1})
+	slots := decoder.Node{Name: "slots", Offset: 1, Length: int64(len(p.Slots))}
+	for i, v := range p.Slots {
+		value := fmt.Sprint(v)
+		if p.StartCode == lighting.StartCodeDimmer {
+			value = fmt.Sprintf("%d (%.0f%%)", v, lighting.SlotPercent(v))
+		}
+		slots.Children = append(slots.Children, decoder.Node{Name: fmt.Sprintf("slot %d", i+1), Value: value, Offset: int64(i + 1), Length: 1})
+	}
+	root.Children = append(root.Children, slots)
+	return root, nil
+}
+
+func acceptsOneWire(data []byte) bool {
+	return onewire.IsROM(data) || onewire.IsScratchpad(data)
+}
+
+// decodeOneWireTree shows a 1-Wire ROM code (8 bytes) or a DS18B20
+// scratchpad (9 bytes).
+func decodeOneWireTree(data []byte) (*decoder.Node, error) {
+	if len(data) == onewire.ROMSize {
+		rom, err := onewire.ParseROM(data)
+		if err != nil {
+			return nil, err
+		}
+		family := fmt.Sprintf("0x%02x", rom.Family())
+		if name := rom.FamilyName(); name != "" {
+			family += " (" + name + ")"
+		}
+		return &decoder.Node{Name: "1-Wire ROM", Value: rom.ID(), Length: onewire.ROMSize, Children: []decoder.Node{
+			{Name: "family", Value: family, Offset: 0, Length: 1},
+			{Name: "serial number", Value: rom.Serial(), Offset: 1, Length: 6},
+			{Name: "CRC", Value: crcText(rom[7], rom.CRC()), Offset: 7, Length: 1},
+		}}, nil
+	}
+
+	sp, err := onewire.ParseScratchpad(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w (or a %d-byte ROM code)", err, onewire.ROMSize)
+	}
+	root := &decoder.Node{Name: "DS18B20 scratchpad", Value: fmt.Sprintf("%g °C", sp.Temperature), Length: onewire.ScratchpadSize, Children: []decoder.Node{
+		{Name: "temperature", Value: fmt.Sprintf("%g °C (raw 0x%04x)", sp.Temperature, uint16(sp.Raw)), Offset: 0, Length: 2},
+		{Name: "TH", Value: fmt.Sprintf("%d °C", sp.High), Offset: 2, Length: 1},
+		{Name: "TL", Value: fmt.Sprintf("%d °C", sp.Low), Offset: 3, Length: 1},
+		{Name: "configuration", Value: fmt.Sprintf("0x%02x (%d-bit)", sp.Config, sp.Resolution), Offset: 4, Length: 1},
+		{Name: "reserved", Value: fmt.Sprintf("%02x %02x %02x", data[5], data[6], data[7]), Offset: 5, Length: 3},
+		{Name: "CRC", Value: crcText(sp.CRC, byte(checksum.CRC8Maxim.Checksum(data[:8]))), Offset: 8, Length: 1},
+	}}
+	if sp.Raw == onewire.PowerOnTemperature {
+		root.Children = append(root.Children, decoder.Node{Name: "warning", Value: "85 °C is the power-on value; no conversion may have run"})
+	}
+	return root, nil
+}
+
+// crcText shows a received CRC and whether it matches the computed one.
+func crcText(got, want byte) string {
+	if got == want {
+		return fmt.Sprintf("0x%02x (valid)", got)
+	}
+	return fmt.Sprintf("0x%02x (invalid, expected 0x%02x)", got, want)
+}
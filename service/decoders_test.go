@@ -0,0 +1,156 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"hexview/decoder"
+)
+
+func TestDecoderService_Builtins(t *testing.T) {
+	s := NewDecoderService("")
+	if errs := s.LoadPlugins(); errs != nil {
+		t.Fatalf("LoadPlugins() errors: %v", errs)
+	}
+	list := s.List()
+	if len(list) != 6 || list[0].Name != "container" || !list[0].Builtin {
+		t.Fatalf("Unexpected decoders: %+v", list)
+	}
+
+	names, err := s.Detect(minimalTIFF)
+	if err != nil {
+		t.Fatalf("Detect() error: %v", err)
+	}
+	if len(names) != 1 || names[0] != "exif" {
+		t.Errorf("Detect() = %v, want [exif]", names)
+	}
+
+	tree, err := s.Decode("exif", minimalTIFF)
+	if err != nil {
+		t.Fatalf("Decode() error: %v", err)
+	}
+	if tree.Value != "big endian" || len(tree.Children) != 1 || tree.Children[0].Name != "IFD0" {
+		t.Fatalf("Unexpected tree: %+v", tree)
+	}
+	if tag := tree.Children[0].Children[0]; tag.Name != "Orientation" || tag.Offset != 10 {
+		t.Errorf("Unexpected tag node: %+v", tag)
+	}
+
+	if _, err := s.Decode("nope", "00"); !errors.Is(err, decoder.ErrUnknownDecoder) {
+		t.Errorf("Expected ErrUnknownDecoder, got %v", err)
+	}
+	if _, err := s.Detect("zz"); err == nil {
+		t.Error("Expected error for invalid hex")
+	}
+}
+
+func TestDecoderService_Plugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins need a Unix shell")
+	}
+	dir := t.TempDir()
+	plugin := "#!/bin/sh\n[ \"$1\" = accepts ] && exit 0\necho '{\"name\":\"frame\",\"children\":[{\"name\":\"id\",\"value\":\"1\"}]}'\n"
+	os.WriteFile(filepath.Join(dir, "frame"), []byte(plugin), 0o755)
+	os.WriteFile(filepath.Join(dir, "exif"), []byte(plugin), 0o755)
+
+	s := NewDecoderService(dir)
+	errs := s.LoadPlugins()
+	if len(errs) != 1 || !errors.Is(errs[0], decoder.ErrDuplicateDecoder) {
+		t.Errorf("Expected a duplicate name error for the exif plugin, got %v", errs)
+	}
+
+	var found bool
+	for _, d := range s.List() {
+		if d.Name == "frame" {
+			found = !d.Builtin && d.Path == filepath.Join(dir, "frame")
+		}
+	}
+	if !found {
+		t.Errorf("Plugin not listed: %+v", s.List())
+	}
+
+	tree, err := s.Decode("frame", "0102")
+	if err != nil || tree.Name != "frame" || tree.Children[0].Value != "1" {
+		t.Errorf("Decode() = %+v, %v", tree, err)
+	}
+
+	os.Remove(filepath.Join(dir, "frame"))
+	s.LoadPlugins()
+	if _, err := s.Decode("frame", "01"); !errors.Is(err, decoder.ErrUnknownDecoder) {
+		t.Errorf("Expected removed plugin to be unregistered, got %v", err)
+	}
+}
+
+func TestDecoderService_Profinet(t *testing.T) {
+	s := NewDecoderService("")
+	frame := "001122334455 0a0b0c0d0e0f 8892 c001 01020304 1234 35 00"
+	names, err := s.Detect(frame)
+	if err != nil || len(names) != 1 || names[0] != "profinet" {
+		t.Fatalf("Detect() = %v, %v, want [profinet]", names, err)
+	}
+	tree, err := s.Decode("profinet", frame)
+	if err != nil {
+		t.Fatalf("Decode() error: %v", err)
+	}
+	if tree.Value != "RT_CLASS_1" || len(tree.Children) != 6 {
+		t.Fatalf("Unexpected tree: %+v", tree)
+	}
+	if status := tree.Children[4]; status.Name != "data status" || status.Offset != 22 || status.Value != "0x35 (primary, data valid, run, no problem)" {
+		t.Errorf("Unexpected status node: %+v", status)
+	}
+}
+
+func TestDecoderService_Lighting(t *testing.T) {
+	s := NewDecoderService("")
+	names, err := s.Detect("0b90")
+	if err != nil || len(names) != 0 {
+		t.Fatalf("Detect() = %v, %v, want none", names, err)
+	}
+
+	tree, err := s.Decode("dali", "0b90")
+	if err != nil {
+		t.Fatalf("Decode(dali) error: %v", err)
+	}
+	if tree.Value != "forward" || len(tree.Children) != 2 || tree.Children[0].Value != "0x0b (short address 5)" || tree.Children[1].Value != "QUERY STATUS" {
+		t.Errorf("Unexpected DALI tree: %+v", tree)
+	}
+
+	tree, err = s.Decode("dmx512", "00ff80")
+	if err != nil {
+		t.Fatalf("Decode(dmx512) error: %v", err)
+	}
+	if tree.Value != "2 slots" || len(tree.Children) != 2 {
+		t.Fatalf("Unexpected DMX tree: %+v", tree)
+	}
+	slots := tree.Children[1].Children
+	if len(slots) != 2 || slots[0].Value != "255 (100%)" || slots[1].Name != "slot 2" || slots[1].Offset != 2 || slots[1].Value != "128 (50%)" {
+		t.Errorf("Unexpected slots: %+v", slots)
+	}
+}
+
+func TestDecoderService_OneWire(t *testing.T) {
+	s := NewDecoderService("")
+	scratchpad := "5005 4b 46 7f ff0c10 1c"
+	names, err := s.Detect(scratchpad)
+	if err != nil || len(names) != 1 || names[0] != "onewire" {
+		t.Fatalf("Detect() = %v, %v, want [onewire]", names, err)
+	}
+	tree, err := s.Decode("onewire", scratchpad)
+	if err != nil {
+		t.Fatalf("Decode() error: %v", err)
+	}
+	if tree.Value != "85 °C" || len(tree.Children) != 7 || tree.Children[6].Name != "warning" {
+		t.Errorf("Unexpected scratchpad tree: %+v", tree)
+	}
+
+	tree, err = s.Decode("onewire", "28ff641e0f160391")
+	if err != nil {
+		t.Fatalf("Decode() error: %v", err)
+	}
+	if tree.Value != "28-03160f1e64ff" || tree.Children[2].Value != "0x91 (invalid, expected 0x90)" {
+		t.Errorf("Unexpected ROM tree: %+v", tree)
+	}
+}
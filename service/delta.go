@@ -0,0 +1,120 @@
+package service
+
+import (
+	"fmt"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+// ConvertDelta decodes the hex input as an array of typ in order (see
+// ConvertArray) and applies a delta transform: DeltaDecode treats every
+// value as the difference from the previous one and returns the running
+// sums, e.g. delta-compressed sensor samples; DeltaEncode is the reverse.
+// Integers wrap around like in the fixed-width arithmetic of a device, so
+// encoding and decoding round-trip exactly.
+func (c *Converter) ConvertDelta(hexInput, typ, order, mode string) (*models.DeltaResult, error) {
+	if mode != models.DeltaDecode && mode != models.DeltaEncode {
+		return nil, fmt.Errorf("unknown delta mode %q (want %s or %s)", mode, models.DeltaDecode, models.DeltaEncode)
+	}
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+	element, err := arrayElementOf(typ, order)
+	if err != nil {
+		return nil, err
+	}
+	o, _ := parseByteOrder(order)
+
+	size := element.size
+	count := len(data) / size
+	out := make([]byte, 0, len(data))
+	decode := mode == models.DeltaDecode
+	switch typ {
+	case string(models.Float32):
+		var prev float32
+		for i := range count {
+			v, _ := convert.BytesToFloat32(data[i*size:(i+1)*size], o)
+			if decode {
+				prev += v
+				v = prev
+			} else {
+				v, prev = v-prev, v
+			}
+			out = append(out, convert.Float32ToBytes(v, o)...)
+		}
+	case string(models.Float64):
+		var prev float64
+		for i := range count {
+			v, _ := convert.BytesToFloat64(data[i*size:(i+1)*size], o)
+			if decode {
+				prev += v
+				v = prev
+			} else {
+				v, prev = v-prev, v
+			}
+			out = append(out, convert.Float64ToBytes(v, o)...)
+		}
+	default:
+		// The bits of signed and unsigned integers add and subtract alike
+		var prev uint64
+		for i := range count {
+			v := readUint(data[i*size:(i+1)*size], o)
+			if decode {
+				prev += v
+				v = prev
+			} else {
+				v, prev = v-prev, v
+			}
+			out = append(out, writeUint(v, size, o)...)
+		}
+	}
+	out = append(out, data[count*size:]...)
+
+	n := min(count, MaxArrayValues)
+	result := &models.DeltaResult{
+		Type:      typ,
+		Order:     order,
+		Mode:      mode,
+		Count:     count,
+		Values:    make([]string, n),
+		Truncated: n < count,
+		Hex:       convert.BytesToHex(out),
+	}
+	for i := range n {
+		result.Values[i] = element.format(out[i*size : (i+1)*size])
+	}
+	return result, nil
+}
+
+// readUint reads the bits of an integer of len(b) bytes in order.
+func readUint(b []byte, order convert.ByteOrder) uint64 {
+	var v uint64
+	switch len(b) {
+	case 1:
+		v = uint64(b[0])
+	case 2:
+		n, _ := convert.BytesToUint16(b, order)
+		v = uint64(n)
+	case 4:
+		n, _ := convert.BytesToUint32(b, order)
+		v = uint64(n)
+	default:
+		v, _ = convert.BytesToUint64(b, order)
+	}
+	return v
+}
+
+// writeUint returns the low size bytes of v in order.
+func writeUint(v uint64, size int, order convert.ByteOrder) []byte {
+	switch size {
+	case 1:
+		return convert.Uint8ToBytes(uint8(v))
+	case 2:
+		return convert.Uint16ToBytes(uint16(v), order)
+	case 4:
+		return convert.Uint32ToBytes(uint32(v), order)
+	}
+	return convert.Uint64ToBytes(v, order)
+}
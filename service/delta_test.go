@@ -0,0 +1,59 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"hexview/models"
+)
+
+func TestConvertDelta(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		typ, order string
+		mode       string
+		want       string
+		hex        string
+	}{
+		{"decode int16 LE", "0a00 0100 feff 0500", "int16", "LE", models.DeltaDecode, "10 11 9 14", "0a000b0009000e00"},
+		{"encode int16 LE", "0a00 0b00 0900 0e00", "int16", "LE", models.DeltaEncode, "10 1 -2 5", "0a000100feff0500"},
+		{"decode uint8 wraps", "fe 01 01", "uint8", "BE", models.DeltaDecode, "254 255 0", "feff00"},
+		{"encode uint16 wraps", "0001 0000", "uint16", "BE", models.DeltaEncode, "1 65535", "0001ffff"},
+		{"decode float32", "3f800000 3f000000", "float32", "BE", models.DeltaDecode, "1 1.5", "3f8000003fc00000"},
+		{"trailing byte kept", "0001 0001 ff", "int16", "BE", models.DeltaDecode, "1 2", "00010002ff"},
+	}
+	c := NewConverter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := c.ConvertDelta(tt.input, tt.typ, tt.order, tt.mode)
+			if err != nil {
+				t.Fatalf("ConvertDelta() error: %v", err)
+			}
+			if got := strings.Join(result.Values, " "); got != tt.want || result.Hex != tt.hex {
+				t.Errorf("ConvertDelta() = %q %s, want %q %s", got, result.Hex, tt.want, tt.hex)
+			}
+		})
+	}
+}
+
+func TestConvertDelta_RoundTrip(t *testing.T) {
+	c := NewConverter()
+	input := "7fffffff 80000000 00000001 deadbeef"
+	for _, order := range []string{"BE", "LE", "BADC", "CDAB"} {
+		encoded, err := c.ConvertDelta(input, "int32", order, models.DeltaEncode)
+		if err != nil {
+			t.Fatalf("encode error: %v", err)
+		}
+		decoded, err := c.ConvertDelta(encoded.Hex, "int32", order, models.DeltaDecode)
+		if err != nil {
+			t.Fatalf("decode error: %v", err)
+		}
+		if decoded.Hex != "7fffffff80000000"+"00000001deadbeef" {
+			t.Errorf("%s: round trip = %s", order, decoded.Hex)
+		}
+	}
+	if _, err := c.ConvertDelta(input, "int32", "BE", "xor"); err == nil {
+		t.Error("ConvertDelta() with unknown mode succeeded")
+	}
+}
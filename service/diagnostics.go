@@ -0,0 +1,20 @@
+package service
+
+import (
+	"runtime"
+
+	"hexview/models"
+)
+
+// Diagnose collects the cache metrics of c, the number of running
+// operations of ops and memory and goroutine figures of the process.
+func Diagnose(c *Converter, ops *OperationService) models.Diagnostics {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	return models.Diagnostics{
+		Cache:      c.CacheStats(),
+		Goroutines: runtime.NumGoroutine(),
+		HeapBytes:  mem.HeapAlloc,
+		Operations: len(ops.List()),
+	}
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"fmt"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+// MaxDiffRanges limits the number of ranges reported by Diff.
+const MaxDiffRanges = 1000
+
+// Diff compares two hex inputs byte by byte at equal offsets and reports the
+// runs of differing bytes.
+func (c *Converter) Diff(hexA, hexB string) (*models.DiffResult, error) {
+	a, err := parseHexBlob(hexA)
+	if err != nil {
+		return nil, fmt.Errorf("first input: %w", err)
+	}
+	b, err := parseHexBlob(hexB)
+	if err != nil {
+		return nil, fmt.Errorf("second input: %w", err)
+	}
+	return diffBytes(a, b), nil
+}
+
+// DiffBytes compares two byte slices like Diff.
+func (c *Converter) DiffBytes(a, b []byte) (*models.DiffResult, error) {
+	if len(a) == 0 {
+		return nil, fmt.Errorf("first input: %w", errEmptyInput())
+	}
+	if len(b) == 0 {
+		return nil, fmt.Errorf("second input: %w", errEmptyInput())
+	}
+	return diffBytes(a, b), nil
+}
+
+// diffBytes compares a and b position by position.
+func diffBytes(a, b []byte) *models.DiffResult {
+	result := &models.DiffResult{
+		LengthA:   len(a),
+		LengthB:   len(b),
+		FirstDiff: -1,
+		Ranges:    []models.DiffRange{},
+	}
+
+	n := max(len(a), len(b))
+	differs := func(i int) bool {
+		return i >= len(a) || i >= len(b) || a[i] != b[i]
+	}
+
+	for i := 0; i < n; {
+		if !differs(i) {
+			i++
+			continue
+		}
+		start := i
+		for i < n && differs(i) {
+			i++
+		}
+		result.DiffBytes += i - start
+		if result.FirstDiff < 0 {
+			result.FirstDiff = int64(start)
+		}
+		if len(result.Ranges) == MaxDiffRanges {
+			result.RangesTrimmed = true
+			continue
+		}
+		result.Ranges = append(result.Ranges, models.DiffRange{
+			Offset: int64(start),
+			Length: i - start,
+			A:      convert.BytesToHex(a[min(start, len(a)):min(i, len(a))]),
+			B:      convert.BytesToHex(b[min(start, len(b)):min(i, len(b))]),
+		})
+	}
+
+	result.Equal = result.DiffBytes == 0
+	return result
+}
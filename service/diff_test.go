@@ -0,0 +1,50 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	c := NewConverter()
+
+	tests := []struct {
+		name       string
+		a, b       string
+		wantEqual  bool
+		wantBytes  int
+		wantFirst  int64
+		wantRanges []string // "offset:a/b"
+	}{
+		{"equal", "01020304", "01 02 03 04", true, 0, -1, nil},
+		{"single byte", "01020304", "01ff0304", false, 1, 1, []string{"1:02/ff"}},
+		{"two runs", "0102030405", "ff02eeee05", false, 3, 0, []string{"0:01/ff", "2:0304/eeee"}},
+		{"b longer", "0102", "01020304", false, 2, 2, []string{"2:/0304"}},
+		{"a longer with change", "010203", "01ff", false, 2, 1, []string{"1:0203/ff"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := c.Diff(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("Diff() error: %v", err)
+			}
+			if result.Equal != tt.wantEqual || result.DiffBytes != tt.wantBytes || result.FirstDiff != tt.wantFirst {
+				t.Errorf("Diff() = equal %v, %d bytes, first %d; want %v, %d, %d",
+					result.Equal, result.DiffBytes, result.FirstDiff, tt.wantEqual, tt.wantBytes, tt.wantFirst)
+			}
+			if len(result.Ranges) != len(tt.wantRanges) {
+				t.Fatalf("Expected %d ranges, got %+v", len(tt.wantRanges), result.Ranges)
+			}
+			for i, r := range result.Ranges {
+				got := fmt.Sprintf("%d:%s/%s", r.Offset, r.A, r.B)
+				if got != tt.wantRanges[i] {
+					t.Errorf("Range %d = %s, want %s", i, got, tt.wantRanges[i])
+				}
+			}
+		})
+	}
+
+	if _, err := c.Diff("01", "zz"); err == nil {
+		t.Error("Expected error for invalid second input")
+	}
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"hexview/asm"
+	"hexview/convert"
+	"hexview/disasm"
+	"hexview/models"
+)
+
+// maxPreviewInstructions limits the disassembly preview to keep the UI responsive.
+const maxPreviewInstructions = 64
+
+// Disassemble decodes hex input as machine code for the given architecture.
+// baseAddress is optional and accepts hex ("0x08000000") or decimal notation;
+// it is used to compute instruction addresses.
+func (c *Converter) Disassemble(hexInput string, arch string, baseAddress string) (*models.DisassemblyResult, error) {
+	if hexInput == "" {
+		return nil, errEmptyInput()
+	}
+
+	a, err := disasm.ParseArch(arch)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := parseAddress(baseAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := convert.HexToBytes(hexInput)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex input: %w", err)
+	}
+
+	insts, err := disasm.Disassemble(code, a, base, maxPreviewInstructions)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.DisassemblyResult{
+		Arch:         string(a),
+		Instructions: make([]models.DisassembledInstruction, len(insts)),
+		ByteCount:    len(code),
+	}
+
+	decoded := 0
+	for i, inst := range insts {
+		result.Instructions[i] = models.DisassembledInstruction{
+			Offset:  inst.Offset,
+			Address: fmt.Sprintf("0x%08x", inst.Address),
+			Hex:     convert.BytesToHex(inst.Bytes),
+			Text:    inst.Text,
+			Valid:   inst.Valid,
+		}
+		decoded = inst.Offset + len(inst.Bytes)
+	}
+	result.Truncated = decoded < len(code)
+
+	return result, nil
+}
+
+// parseAddress parses an optional address in hex (0x prefix) or decimal notation.
+// An empty string yields address 0.
+func parseAddress(input string) (uint64, error) {
+	s := strings.ReplaceAll(strings.TrimSpace(input), "_", "")
+	if s == "" {
+		return 0, nil
+	}
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		v, err := strconv.ParseUint(s[2:], 16, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid address: %s", input)
+		}
+		return v, nil
+	}
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid address: %s", input)
+	}
+	return v, nil
+}
+
+// AssembleInstruction encodes one ARM Thumb or RISC-V instruction, e.g.
+// "movs r0, #1" or "addi a0, a0, 1", for patching firmware by hand. Branch
+// offsets are relative to the instruction (".+8").
+func (c *Converter) AssembleInstruction(arch, text string) (*models.InstructionEncoding, error) {
+	a, err := asm.ParseArch(arch)
+	if err != nil {
+		return nil, err
+	}
+	code, err := asm.Encode(a, text)
+	if err != nil {
+		return nil, err
+	}
+	decoded, _, err := asm.Decode(a, code)
+	if err != nil {
+		decoded = strings.TrimSpace(text)
+	}
+	return instructionEncoding(a, decoded, code), nil
+}
+
+// DecodeInstruction decodes the ARM Thumb or RISC-V instruction at the start
+// of hex input; the input may hold more bytes than the instruction.
+func (c *Converter) DecodeInstruction(arch, hexInput string) (*models.InstructionEncoding, error) {
+	a, err := asm.ParseArch(arch)
+	if err != nil {
+		return nil, err
+	}
+	code, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+	text, size, err := asm.Decode(a, code)
+	if err != nil {
+		return nil, err
+	}
+	return instructionEncoding(a, text, code[:size]), nil
+}
+
+// instructionEncoding builds the model of an instruction. Thumb words are
+// written halfword by halfword, RISC-V words as one little-endian value.
+func instructionEncoding(a asm.Arch, text string, code []byte) *models.InstructionEncoding {
+	var word uint64
+	if a == asm.Thumb {
+		for i := 0; i+1 < len(code); i += 2 {
+			word = word<<16 | uint64(code[i+1])<<8 | uint64(code[i])
+		}
+	} else {
+		for i := len(code) - 1; i >= 0; i-- {
+			word = word<<8 | uint64(code[i])
+		}
+	}
+	return &models.InstructionEncoding{
+		Arch: string(a),
+		Text: text,
+		Hex:  convert.BytesToHex(code),
+		Word: fmt.Sprintf("0x%0*x", len(code)*2, word),
+		Size: len(code),
+	}
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestDisassemble(t *testing.T) {
+	c := NewConverter()
+
+	result, err := c.Disassemble("55 48 89 e5 c3", "x86-64", "0x1000")
+	if err != nil {
+		t.Fatalf("Disassemble() error: %v", err)
+	}
+	if len(result.Instructions) != 3 {
+		t.Fatalf("Expected 3 instructions, got %d", len(result.Instructions))
+	}
+	if result.Instructions[1].Address != "0x00001001" {
+		t.Errorf("Expected address 0x00001001, got %s", result.Instructions[1].Address)
+	}
+	if result.Instructions[1].Hex != "4889e5" {
+		t.Errorf("Expected hex 4889e5, got %s", result.Instructions[1].Hex)
+	}
+	if result.Truncated {
+		t.Error("Expected Truncated=false")
+	}
+}
+
+func TestDisassemble_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		arch    string
+		base    string
+		wantErr bool
+	}{
+		{"empty input", "", "x86-64", "", true},
+		{"invalid hex", "GG", "x86-64", "", true},
+		{"unknown arch", "90", "z80", "", true},
+		{"invalid base", "90", "x86-64", "0xZZ", true},
+		{"decimal base", "90", "x86-64", "4096", false},
+	}
+
+	c := NewConverter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.Disassemble(tt.input, tt.arch, tt.base)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Disassemble() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDisassemble_Truncated(t *testing.T) {
+	c := NewConverter()
+	input := ""
+	for i := 0; i < maxPreviewInstructions+10; i++ {
+		input += "90"
+	}
+	result, err := c.Disassemble(input, "x86-64", "")
+	if err != nil {
+		t.Fatalf("Disassemble() error: %v", err)
+	}
+	if !result.Truncated || len(result.Instructions) != maxPreviewInstructions {
+		t.Errorf("Expected truncated preview of %d instructions, got %d (truncated=%v)",
+			maxPreviewInstructions, len(result.Instructions), result.Truncated)
+	}
+}
+
+func TestAssembleInstruction(t *testing.T) {
+	c := NewConverter()
+
+	tests := []struct {
+		arch, text string
+		wantHex    string
+		wantWord   string
+		wantText   string
+	}{
+		{"thumb", "movs r0, #1", "0120", "0x2001", "movs r0, #1"},
+		{"cortex-m", "bl .+4", "00f000f8", "0xf000f800", "bl .+4"},
+		{"riscv", "addi a0, a0, 1", "13051500", "0x00150513", "addi x10,x10,1"},
+		{"rv64", "ret", "67800000", "0x00008067", "ret"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := c.AssembleInstruction(tt.arch, tt.text)
+			if err != nil {
+				t.Fatalf("AssembleInstruction() error: %v", err)
+			}
+			if got.Hex != tt.wantHex || got.Word != tt.wantWord || got.Text != tt.wantText {
+				t.Errorf("AssembleInstruction() = %+v, want hex %s word %s text %q", got, tt.wantHex, tt.wantWord, tt.wantText)
+			}
+		})
+	}
+
+	if _, err := c.AssembleInstruction("mips", "nop"); err == nil {
+		t.Error("Expected error for unsupported arch")
+	}
+	if _, err := c.AssembleInstruction("thumb", "frobnicate r0"); err == nil {
+		t.Error("Expected error for unknown mnemonic")
+	}
+}
+
+func TestDecodeInstruction(t *testing.T) {
+	c := NewConverter()
+
+	got, err := c.DecodeInstruction("thumb", "01 20 70 47")
+	if err != nil {
+		t.Fatalf("DecodeInstruction() error: %v", err)
+	}
+	if got.Text != "movs r0, #1" || got.Size != 2 || got.Hex != "0120" {
+		t.Errorf("DecodeInstruction() = %+v", got)
+	}
+
+	got, err = c.DecodeInstruction("riscv", "13 05 15 00")
+	if err != nil {
+		t.Fatalf("DecodeInstruction() error: %v", err)
+	}
+	if got.Size != 4 || got.Word != "0x00150513" {
+		t.Errorf("DecodeInstruction() = %+v", got)
+	}
+
+	if _, err := c.DecodeInstruction("thumb", "zz"); err == nil {
+		t.Error("Expected error for invalid hex")
+	}
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"hexview/models"
+)
+
+// MaxDropConvertSize is the largest drop threshold; bigger files are always
+// opened in the file subsystem.
+const MaxDropConvertSize = 64 << 10
+
+// Kinds of dropped files
+const (
+	DropBytes = "bytes" // loaded into the converter
+	DropFile  = "file"  // opened in the file subsystem
+)
+
+// IngestFile handles a file dropped onto the window. Files of up to
+// threshold bytes are converted like hex input; larger ones are opened in
+// files for the hex dump view.
+func IngestFile(files *FileService, c *Converter, path string, threshold int) (*models.DroppedFile, error) {
+	st, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open file: %w", err)
+	}
+	if st.IsDir() {
+		return nil, fmt.Errorf("cannot open file: %s is a directory", path)
+	}
+	result := &models.DroppedFile{Path: path, Name: filepath.Base(path), Size: st.Size()}
+
+	if st.Size() > 0 && st.Size() <= int64(min(threshold, MaxDropConvertSize)) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read file: %w", err)
+		}
+		// The file may have grown since Stat; fall back to opening it
+		if len(data) <= threshold {
+			result.Kind = DropBytes
+			result.Conversion, err = c.ConvertBytes(data)
+			if err != nil {
+				return nil, err
+			}
+			return result, nil
+		}
+	}
+
+	result.Kind = DropFile
+	result.File, err = files.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestIngestFile(t *testing.T) {
+	files := NewFileService()
+	c := NewConverter()
+	small := writeTempFile(t, "value.bin", []byte{0x42, 0x48, 0x00, 0x00})
+	large := writeTempFile(t, "firmware.bin", bytes.Repeat([]byte{0xaa}, 64))
+	empty := writeTempFile(t, "empty.bin", nil)
+
+	tests := []struct {
+		name      string
+		path      string
+		threshold int
+		wantKind  string
+	}{
+		{"small file is converted", small, 16, DropBytes},
+		{"at threshold is converted", small, 4, DropBytes},
+		{"large file is opened", large, 16, DropFile},
+		{"zero threshold always opens", small, 0, DropFile},
+		{"empty file is opened", empty, 16, DropFile},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IngestFile(files, c, tt.path, tt.threshold)
+			if err != nil {
+				t.Fatalf("IngestFile() error = %v", err)
+			}
+			if got.Kind != tt.wantKind {
+				t.Errorf("Kind = %q, want %q", got.Kind, tt.wantKind)
+			}
+			if tt.wantKind == DropBytes && (got.Conversion == nil || got.Conversion.Bytes != "42480000" || got.File != nil) {
+				t.Errorf("Expected conversion of 42480000, got %+v", got)
+			}
+			if tt.wantKind == DropFile && (got.File == nil || got.File.Size != got.Size || got.Conversion != nil) {
+				t.Errorf("Expected opened file, got %+v", got)
+			}
+		})
+	}
+
+	if _, err := IngestFile(files, c, t.TempDir(), 16); err == nil {
+		t.Error("Expected error for directory")
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/quotedprintable"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+// Text encodings of bytes accepted by ConvertEncoded
+const (
+	EncodedQuotedPrintable = "quoted-printable" // Gr=C3=BC=C3=9Fe, e.g. in email bodies
+	EncodedURL             = "url"              // Gr%C3%BC%C3%9Fe+x, '+' is a space, e.g. in HTTP payloads
+	EncodedBase64          = "base64"           // R3LDvMOfZQ==, standard or URL-safe, padded or not
+	EncodedBase32          = "base32"           // I5ZMHPGDT5SQ====
+)
+
+// ErrUnknownEncoding indicates an unknown encoding, or input in which no
+// encoding could be detected
+var ErrUnknownEncoding = errors.New("unknown input encoding")
+
+var (
+	quotedPrintableEscape = regexp.MustCompile(`=(?:[0-9A-Fa-f]{2}|\r?\n)`)
+	percentEscape         = regexp.MustCompile(`%[0-9A-Fa-f]{2}`)
+)
+
+// ConvertEncoded decodes quoted-printable, percent-encoded, base64 or
+// base32 input and converts the bytes like ConvertHex. encoding is one of
+// the Encoded constants, or empty to detect quoted-printable or
+// percent-encoding from the escapes in the input. Base64 and base32 are
+// not detected, as short hex and decimal input is valid base64 too.
+func (c *Converter) ConvertEncoded(input, encoding string) (*models.ConversionResult, error) {
+	return cached(c, ModeEncoded, input, encoding, func() (*models.ConversionResult, error) {
+		if input == "" {
+			return nil, errEmptyInput()
+		}
+
+		data, encoding, err := decodeEncoded(input, encoding)
+		if err != nil {
+			return nil, err
+		}
+		result := bytesResult(data, allSections)
+		result.Canonical = canonicalHex(data)
+		result.InputEncoding = encoding
+		return result, nil
+	})
+}
+
+// detectEncoding returns the encoding whose escapes input uses most.
+// Percent-encoding wins a tie, as query strings like "id=42&x=%20" contain
+// sequences that look like quoted-printable escapes.
+func detectEncoding(input string) (string, error) {
+	qp := len(quotedPrintableEscape.FindAllStringIndex(input, -1))
+	percent := len(percentEscape.FindAllStringIndex(input, -1))
+	switch {
+	case qp == 0 && percent == 0:
+		return "", fmt.Errorf("%w: no quoted-printable or percent escapes found", ErrUnknownEncoding)
+	case qp > percent:
+		return EncodedQuotedPrintable, nil
+	default:
+		return EncodedURL, nil
+	}
+}
+
+// decodeEncoded decodes input and returns the bytes and the encoding used,
+// detecting it if encoding is empty.
+func decodeEncoded(input, encoding string) ([]byte, string, error) {
+	if encoding == "" {
+		var err error
+		if encoding, err = detectEncoding(input); err != nil {
+			return nil, "", err
+		}
+	}
+
+	switch encoding {
+	case EncodedQuotedPrintable:
+		data, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(input)))
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid quoted-printable input: %w", err)
+		}
+		return data, encoding, nil
+	case EncodedURL:
+		s, err := url.QueryUnescape(input)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid percent-encoded input: %w", err)
+		}
+		return []byte(s), encoding, nil
+	case EncodedBase64:
+		data, err := convert.Base64ToBytes(input)
+		return data, encoding, err
+	case EncodedBase32:
+		data, err := convert.Base32ToBytes(input)
+		return data, encoding, err
+	default:
+		return nil, "", fmt.Errorf("%w: %q", ErrUnknownEncoding, encoding)
+	}
+}
+
+func validateEncoded(input string) (*models.InputValidation, error) {
+	if input == "" {
+		return nil, errEmptyInput()
+	}
+	data, encoding, err := decodeEncoded(input, "")
+	if err != nil {
+		return nil, err
+	}
+	return &models.InputValidation{Format: encoding, Normalized: previewBytes(data, "%02x"), Length: len(data)}, nil
+}
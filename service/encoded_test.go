@@ -0,0 +1,61 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"hexview/convert"
+)
+
+func TestConvertEncoded(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		encoding string
+		want     string // bytes as hex
+		detected string
+	}{
+		{"quoted-printable", "Gr=C3=BC=C3=9Fe", "", "4772c3bcc39f65", EncodedQuotedPrintable},
+		{"quoted-printable soft break", "ab=\r\ncd=3D", "", "616263643d", EncodedQuotedPrintable},
+		{"percent", "Gr%C3%BC%C3%9Fe+x", "", "4772c3bcc39f652078", EncodedURL},
+		{"percent wins tie", "id=42&v=%01", "", "69643d343226763d01", EncodedURL},
+		{"explicit", "a=42", EncodedQuotedPrintable, "6142", EncodedQuotedPrintable},
+		{"explicit url", "a=42", EncodedURL, "613d3432", EncodedURL},
+		{"base64", "R3LDvMOfZQ==", EncodedBase64, "4772c3bcc39f65", EncodedBase64},
+		{"base64 url-safe unpadded", "-_8", EncodedBase64, "fbff", EncodedBase64},
+		{"base32", "i5zm hpgd t5sq", EncodedBase32, "4772c3bcc39f65", EncodedBase32},
+	}
+	c := NewConverter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := c.ConvertEncoded(tt.input, tt.encoding)
+			if err != nil {
+				t.Fatalf("ConvertEncoded() error: %v", err)
+			}
+			if result.Bytes != tt.want || result.InputEncoding != tt.detected {
+				t.Errorf("ConvertEncoded() = %s (%s), want %s (%s)", result.Bytes, result.InputEncoding, tt.want, tt.detected)
+			}
+		})
+	}
+}
+
+func TestConvertEncoded_Errors(t *testing.T) {
+	c := NewConverter()
+	if _, err := c.ConvertEncoded("plain text", ""); !errors.Is(err, ErrUnknownEncoding) {
+		t.Errorf("undetectable input error = %v, want ErrUnknownEncoding", err)
+	}
+	if _, err := c.ConvertEncoded("a=42", "uuencode"); !errors.Is(err, ErrUnknownEncoding) {
+		t.Errorf("unknown encoding error = %v, want ErrUnknownEncoding", err)
+	}
+	if _, err := c.ConvertEncoded("AQ*D", EncodedBase64); !errors.Is(err, convert.ErrInvalidBase64) {
+		t.Errorf("invalid base64 error = %v, want ErrInvalidBase64", err)
+	}
+	if _, err := c.ConvertEncoded("%zz", EncodedURL); err == nil {
+		t.Error("invalid percent escape succeeded")
+	}
+
+	v, err := c.ValidateInput("a%20b", ModeEncoded)
+	if err != nil || !v.Valid || v.Format != EncodedURL || v.Length != 3 {
+		t.Errorf("ValidateInput() = %+v, %v", v, err)
+	}
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"hexview/models"
+)
+
+// ErrInvalidEnum indicates enum text that cannot be parsed
+var ErrInvalidEnum = errors.New("invalid enum")
+
+// enumTypes lists the integer types an EnumMap can annotate.
+var enumTypes = []string{"int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64"}
+
+// ApplyEnums annotates the integer values of result with their names in the
+// enum maps of p. Flag maps only annotate unsigned values.
+func (c *Converter) ApplyEnums(result *models.ConversionResult, p *models.Profile) {
+	if result == nil || p == nil || len(p.Enums) == 0 {
+		return
+	}
+	v := reflect.ValueOf(result).Elem()
+	for _, f := range resultFields {
+		typ := strings.ToLower(f.typ)
+		field := v.Field(f.index)
+		if !slices.Contains(enumTypes, typ) || field.IsNil() {
+			continue
+		}
+		unsigned := strings.HasPrefix(typ, "uint")
+		var value int64
+		if unsigned {
+			value = int64(field.Elem().Uint())
+		} else {
+			value = field.Elem().Int()
+		}
+		for _, e := range p.Enums {
+			if len(e.Types) > 0 && !slices.Contains(e.Types, typ) || e.Flags && !unsigned {
+				continue
+			}
+			if name, ok := enumName(e, value); ok {
+				result.Enums = append(result.Enums, models.EnumMatch{Field: f.name, Enum: e.Name, Name: name})
+			}
+		}
+	}
+}
+
+// enumName returns the name of v in e. For flag maps it joins the names of
+// the set masks with " | " and adds remaining bits in hex; ok is false if no
+// mask is set.
+func enumName(e models.EnumMap, v int64) (string, bool) {
+	if !e.Flags {
+		i := slices.IndexFunc(e.Values, func(ev models.EnumValue) bool { return ev.Value == v })
+		if i < 0 {
+			return "", false
+		}
+		return e.Values[i].Name, true
+	}
+
+	if v == 0 {
+		i := slices.IndexFunc(e.Values, func(ev models.EnumValue) bool { return ev.Value == 0 })
+		if i < 0 {
+			return "", false
+		}
+		return e.Values[i].Name, true
+	}
+	var names []string
+	rest := uint64(v)
+	for _, ev := range e.Values {
+		mask := uint64(ev.Value)
+		if mask != 0 && uint64(v)&mask == mask {
+			names = append(names, ev.Name)
+			rest &^= mask
+		}
+	}
+	if len(names) == 0 {
+		return "", false
+	}
+	if rest != 0 {
+		names = append(names, fmt.Sprintf("0x%x", rest))
+	}
+	return strings.Join(names, " | "), true
+}
+
+// mapEnum sets the enum name of a decoded register map entry.
+func mapEnum(out *models.MappedRegister, m models.RegisterMapping, enums []models.EnumMap) {
+	i := slices.IndexFunc(enums, func(e models.EnumMap) bool { return e.Name == m.Enum })
+	if m.Enum == "" || out.Error != "" || i < 0 {
+		return
+	}
+	v, err := strconv.ParseInt(out.Raw, 10, 64)
+	if err != nil {
+		u, uerr := strconv.ParseUint(out.Raw, 10, 64)
+		if uerr != nil {
+			return
+		}
+		v = int64(u)
+	}
+	out.EnumName, _ = enumName(enums[i], v)
+}
+
+var (
+	// enumValueFirst matches "3 STATE_RUNNING", "0x03,STATE_RUNNING" and
+	// "3: STATE_RUNNING".
+	enumValueFirst = regexp.MustCompile(`^(-?(?:0[xX][0-9A-Fa-f_]+|0[bBoO][0-9_]+|[0-9][0-9_]*))\s*[,;:=\t ]\s*([A-Za-z_][A-Za-z0-9_]*)$`)
+
+	// enumComment matches C comments within one line.
+	enumComment = regexp.MustCompile(`//.*$|/\*.*?\*/`)
+
+	// enumShift matches flag values like "1 << 3" or "1u<<3".
+	enumShift = regexp.MustCompile(`^(\w+)\s*<<\s*(\w+)$`)
+)
+
+// ParseEnumValues reads enum values from text, e.g. to load an enum map
+// from a header file. Each line holds a value and a name ("3 RUNNING",
+// "0x03,RUNNING") or C enum entries ("RUNNING = 3," or "RUNNING,", which
+// counts up from the previous value). Comments, "typedef enum name {" and
+// braces are ignored. Values may be written in C notation, e.g. 0x10u or
+// 1 << 4.
+func ParseEnumValues(text string) ([]models.EnumValue, error) {
+	var values []models.EnumValue
+	next := int64(0)
+	for n, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(enumComment.ReplaceAllString(line, ""))
+		if m := enumValueFirst.FindStringSubmatch(line); m != nil {
+			v, err := parseEnumNumber(m[1])
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidEnum, n+1, err)
+			}
+			values = append(values, models.EnumValue{Value: v, Name: m[2]})
+			next = v + 1
+			continue
+		}
+
+		if i := strings.Index(line, "{"); i >= 0 {
+			line = line[i+1:]
+		} else if strings.HasPrefix(line, "typedef") || strings.HasPrefix(line, "enum") {
+			continue
+		}
+		line, _, _ = strings.Cut(line, "}")
+		for _, entry := range strings.Split(line, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			name, value, hasValue := strings.Cut(entry, "=")
+			name = strings.TrimSpace(name)
+			if !isIdentifier(name) {
+				return nil, fmt.Errorf("%w: line %d: %q is not a name", ErrInvalidEnum, n+1, name)
+			}
+			if hasValue {
+				v, err := parseEnumNumber(strings.TrimSpace(value))
+				if err != nil {
+					return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidEnum, n+1, err)
+				}
+				next = v
+			}
+			values = append(values, models.EnumValue{Value: next, Name: name})
+			next++
+		}
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%w: no values found", ErrInvalidEnum)
+	}
+	return values, nil
+}
+
+// parseEnumNumber parses a value in decimal, hex (0x), binary (0b) or octal
+// (0o) notation with optional C integer suffixes, or a shift like "1 << 3".
+func parseEnumNumber(s string) (int64, error) {
+	if m := enumShift.FindStringSubmatch(s); m != nil {
+		base, err := parseEnumNumber(m[1])
+		if err != nil {
+			return 0, err
+		}
+		shift, err := parseEnumNumber(m[2])
+		if err != nil {
+			return 0, err
+		}
+		if shift < 0 || shift >= 64 || bits.Len64(uint64(base))+int(shift) > 64 {
+			return 0, fmt.Errorf("shift %q out of range", s)
+		}
+		return base << shift, nil
+	}
+	s = strings.TrimRight(s, "uUlL")
+	if v, err := strconv.ParseInt(s, 0, 64); err == nil {
+		return v, nil
+	}
+	u, err := strconv.ParseUint(s, 0, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	return int64(u), nil
+}
+
+// isIdentifier reports whether s is a C identifier.
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if r != '_' && !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && (i == 0 || r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// validateEnums checks the enum maps of a profile and the enums named by its
+// register map.
+func validateEnums(p models.Profile) error {
+	seen := make(map[string]bool)
+	for _, e := range p.Enums {
+		if strings.TrimSpace(e.Name) == "" {
+			return fmt.Errorf("%w: enum without name", ErrInvalidProfile)
+		}
+		if seen[e.Name] {
+			return fmt.Errorf("%w: duplicate enum %q", ErrInvalidProfile, e.Name)
+		}
+		seen[e.Name] = true
+		for _, typ := range e.Types {
+			if !slices.Contains(enumTypes, typ) {
+				return fmt.Errorf("%w: enum %q has unknown type %q", ErrInvalidProfile, e.Name, typ)
+			}
+		}
+		for _, v := range e.Values {
+			if strings.TrimSpace(v.Name) == "" {
+				return fmt.Errorf("%w: enum %q has a value without name", ErrInvalidProfile, e.Name)
+			}
+		}
+	}
+	for _, r := range p.Registers {
+		if r.Enum != "" && !seen[r.Enum] {
+			return fmt.Errorf("%w: register %q names unknown enum %q", ErrInvalidProfile, r.Name, r.Enum)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"hexview/models"
+)
+
+func TestApplyEnums(t *testing.T) {
+	profile := &models.Profile{Name: "drive", Enums: []models.EnumMap{
+		{Name: "state", Types: []string{"uint8", "int16"}, Values: []models.EnumValue{{Value: 3, Name: "STATE_RUNNING"}, {Value: 768, Name: "STATE_FAULT"}}},
+		{Name: "status", Types: []string{"uint16"}, Flags: true, Values: []models.EnumValue{{Value: 0, Name: "OK"}, {Value: 1, Name: "READY"}, {Value: 0x0300, Name: "MODE"}}},
+	}}
+	c := NewConverter()
+	result, err := c.ConvertHex("03")
+	if err != nil {
+		t.Fatalf("ConvertHex() error: %v", err)
+	}
+	c.ApplyEnums(result, &models.Profile{Enums: []models.EnumMap{{Name: "byte", Types: []string{"uint8"}, Values: profile.Enums[0].Values}}})
+	want := []models.EnumMatch{{Field: "uint8BE", Enum: "byte", Name: "STATE_RUNNING"}, {Field: "uint8LE", Enum: "byte", Name: "STATE_RUNNING"}}
+	if !slices.Equal(result.Enums, want) {
+		t.Errorf("Enums of 03 = %+v, want %+v", result.Enums, want)
+	}
+
+	result, _ = c.ConvertHex("0300")
+	c.ApplyEnums(result, profile)
+	want = []models.EnumMatch{
+		{Field: "int16BE", Enum: "state", Name: "STATE_FAULT"},
+		{Field: "int16LE", Enum: "state", Name: "STATE_RUNNING"},
+		{Field: "int16BADC", Enum: "state", Name: "STATE_FAULT"},
+		{Field: "int16CDAB", Enum: "state", Name: "STATE_RUNNING"},
+		{Field: "uint16BE", Enum: "status", Name: "MODE"},
+		{Field: "uint16LE", Enum: "status", Name: "READY | 0x2"},
+		{Field: "uint16BADC", Enum: "status", Name: "MODE"},
+		{Field: "uint16CDAB", Enum: "status", Name: "READY | 0x2"},
+	}
+	if !slices.Equal(result.Enums, want) {
+		t.Errorf("Enums of 0300 = %+v, want %+v", result.Enums, want)
+	}
+
+	result, _ = c.ConvertHex("0000")
+	c.ApplyEnums(result, profile)
+	if len(result.Enums) != 4 || result.Enums[0].Name != "OK" {
+		t.Errorf("Enums of 0000 = %+v, want OK for each uint16", result.Enums)
+	}
+}
+
+func TestApplyProfile_Enum(t *testing.T) {
+	c := NewConverter()
+	result, err := c.ConvertModbusRegisters("0003 0007")
+	if err != nil {
+		t.Fatalf("ConvertModbusRegisters() error: %v", err)
+	}
+	c.ApplyProfile(result, &models.Profile{
+		Registers: []models.RegisterMapping{{Register: 0, Name: "state", Type: "uint16", Enum: "state"}, {Register: 1, Name: "other", Type: "uint16", Enum: "state"}},
+		Enums:     []models.EnumMap{{Name: "state", Values: []models.EnumValue{{Value: 3, Name: "RUNNING"}}}},
+	})
+	if result.Mapped[0].EnumName != "RUNNING" || result.Mapped[1].EnumName != "" {
+		t.Errorf("Mapped = %+v", result.Mapped)
+	}
+}
+
+func TestParseEnumValues(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []models.EnumValue
+	}{
+		{"c enum", "typedef enum {\n  IDLE,      // waiting\n  RUNNING = 0x03u,\n  FAULT, /* latched */\n} state_t;",
+			[]models.EnumValue{{Value: 0, Name: "IDLE"}, {Value: 3, Name: "RUNNING"}, {Value: 4, Name: "FAULT"}}},
+		{"one line", "enum state { A = -1, B, C = 1 << 4 };", []models.EnumValue{{Value: -1, Name: "A"}, {Value: 0, Name: "B"}, {Value: 16, Name: "C"}}},
+		{"value first", "0x01,READY\n2 BUSY\n3: DONE\n", []models.EnumValue{{Value: 1, Name: "READY"}, {Value: 2, Name: "BUSY"}, {Value: 3, Name: "DONE"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEnumValues(tt.text)
+			if err != nil {
+				t.Fatalf("ParseEnumValues() error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseEnumValues() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	for _, text := range []string{"", "3 = 4", "A = 1 << 70", "A = zz"} {
+		if _, err := ParseEnumValues(text); !errors.Is(err, ErrInvalidEnum) {
+			t.Errorf("ParseEnumValues(%q) error = %v, want ErrInvalidEnum", text, err)
+		}
+	}
+}
+
+func TestValidateProfile_Enums(t *testing.T) {
+	invalid := []models.Profile{
+		{Name: "p", Enums: []models.EnumMap{{Name: ""}}},
+		{Name: "p", Enums: []models.EnumMap{{Name: "a"}, {Name: "a"}}},
+		{Name: "p", Enums: []models.EnumMap{{Name: "a", Types: []string{"float32"}}}},
+		{Name: "p", Enums: []models.EnumMap{{Name: "a", Values: []models.EnumValue{{Value: 1}}}}},
+		{Name: "p", Registers: []models.RegisterMapping{{Name: "r", Type: "uint16", Enum: "missing"}}},
+	}
+	for _, p := range invalid {
+		if err := validateProfile(p); !errors.Is(err, ErrInvalidProfile) {
+			t.Errorf("validateProfile(%+v) error = %v, want ErrInvalidProfile", p, err)
+		}
+	}
+}
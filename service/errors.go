@@ -0,0 +1,87 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+var (
+	// ErrInvalidNumber indicates a decimal or float input that cannot be parsed
+	ErrInvalidNumber = errors.New("invalid number")
+
+	// ErrOutOfRange indicates a number that does not fit the requested type
+	ErrOutOfRange = errors.New("value out of range")
+
+	// ErrUnsupportedType indicates an unknown integer or float type name
+	ErrUnsupportedType = errors.New("unsupported type")
+)
+
+// ErrorDetails returns the structured form of an input error, or nil if err
+// does not wrap a convert.InputError. The message is the one of err so any
+// context added while wrapping is kept.
+func ErrorDetails(err error) *models.InputError {
+	var inErr *convert.InputError
+	if !errors.As(err, &inErr) {
+		return nil
+	}
+	return &models.InputError{
+		Code:     string(inErr.Code),
+		Message:  err.Error(),
+		Position: inErr.Position,
+		Length:   inErr.Length,
+	}
+}
+
+// errEmptyInput returns the error for an empty input.
+func errEmptyInput() error {
+	return convert.NewInputError(convert.CodeEmptyInput, convert.ErrEmptyInput, "empty input")
+}
+
+// errNoRegisters returns the error for Modbus input without register values.
+func errNoRegisters() error {
+	return convert.NewInputError(convert.CodeEmptyInput, convert.ErrEmptyInput, "no valid register values found")
+}
+
+// errUnsupportedType returns the error for an unknown integer or float type.
+func errUnsupportedType(kind, typ string) error {
+	return convert.NewInputError(convert.CodeUnsupportedType, ErrUnsupportedType,
+		fmt.Sprintf("unsupported %s type: %s", kind, typ))
+}
+
+// numberError returns the error for a number input that failed to scan as
+// label (e.g. "int8" or "decimal"). Values that are well-formed but too
+// large are reported as out of range and span the whole number; otherwise
+// the error points at the first character that cannot be part of a number.
+func numberError(input, label string, float bool, err error) error {
+	msg := fmt.Sprintf("invalid %s value: %v", label, err)
+	start := len(input) - len(strings.TrimLeft(input, " \t\r\n"))
+	end := len(strings.TrimRight(input, " \t\r\n"))
+	if start >= end {
+		return convert.NewInputError(convert.CodeEmptyInput, convert.ErrEmptyInput, msg)
+	}
+
+	if s := err.Error(); strings.Contains(s, "overflow") || strings.Contains(s, "out of range") {
+		return convert.NewInputError(convert.CodeOutOfRange, ErrOutOfRange, msg).At(input, start, end-start)
+	}
+
+	allowed := "0123456789"
+	if float {
+		allowed += ".eE+-"
+	}
+	i := start
+	if input[i] == '+' || input[i] == '-' {
+		i++
+	}
+	for i < end && strings.IndexByte(allowed, input[i]) >= 0 {
+		i++
+	}
+	if i == end {
+		// Every character is valid on its own, e.g. a lone sign or "1e"
+		return convert.NewInputError(convert.CodeInvalidNumber, ErrInvalidNumber, msg).At(input, start, end-start)
+	}
+	return convert.NewInputError(convert.CodeInvalidNumber, ErrInvalidNumber, msg).At(input, i, 1)
+}
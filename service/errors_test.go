@@ -0,0 +1,53 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"hexview/convert"
+)
+
+func TestErrorDetails(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		name     string
+		convert  func() error
+		code     convert.ErrorCode
+		position int
+		length   int
+	}{
+		{"empty hex", func() error { _, err := c.ConvertHex(""); return err }, convert.CodeEmptyInput, -1, 0},
+		{"invalid hex char", func() error { _, err := c.ConvertHex("0x12g4"); return err }, convert.CodeInvalidHexChar, 4, 1},
+		{"invalid binary char", func() error { _, err := c.ConvertBinary("1012"); return err }, convert.CodeInvalidBinaryChar, 3, 1},
+		{"invalid int", func() error { _, err := c.ConvertInt(" -x5", "int16"); return err }, convert.CodeInvalidNumber, 2, 1},
+		{"int overflow", func() error { _, err := c.ConvertInt(" 300 ", "uint8"); return err }, convert.CodeOutOfRange, 1, 3},
+		{"lone sign", func() error { _, err := c.ConvertInt("-", "int8"); return err }, convert.CodeInvalidNumber, 0, 1},
+		{"unsupported int type", func() error { _, err := c.ConvertInt("1", "int128"); return err }, convert.CodeUnsupportedType, -1, 0},
+		{"invalid float", func() error { _, err := c.ConvertFloat("abc", "float32"); return err }, convert.CodeInvalidNumber, 0, 1},
+		{"unsupported float type", func() error { _, err := c.ConvertFloat("1", "float16"); return err }, convert.CodeUnsupportedType, -1, 0},
+		{"invalid auto float", func() error { _, err := c.ConvertIntAuto("x,5"); return err }, convert.CodeInvalidNumber, 0, 1},
+		{"modbus invalid hex", func() error { _, err := c.ConvertModbusRegisters("0x1234, zz12"); return err }, convert.CodeInvalidHexChar, 8, 4},
+		{"modbus out of range", func() error { _, err := c.ConvertModbusRegisters("1 d70000"); return err }, convert.CodeOutOfRange, 2, 6},
+		{"modbus after multibyte char", func() error { _, err := c.ConvertModbusRegisters("µ"); return err }, convert.CodeInvalidHexChar, 0, 1},
+		{"modbus separators only", func() error { _, err := c.ConvertModbusRegisters(",;"); return err }, convert.CodeEmptyInput, -1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.convert()
+			d := ErrorDetails(err)
+			if d == nil {
+				t.Fatalf("ErrorDetails(%v) = nil", err)
+			}
+			if d.Code != string(tt.code) || d.Position != tt.position || d.Length != tt.length {
+				t.Errorf("ErrorDetails() = %+v, want code %s position %d length %d", d, tt.code, tt.position, tt.length)
+			}
+			if d.Message != err.Error() {
+				t.Errorf("Message = %q, want %q", d.Message, err.Error())
+			}
+		})
+	}
+
+	if d := ErrorDetails(errors.New("plain")); d != nil {
+		t.Errorf("ErrorDetails(plain error) = %+v, want nil", d)
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"fmt"
+
+	"hexview/exif"
+	"hexview/models"
+)
+
+// DecodeEXIF decodes TIFF/EXIF tags from hex input. The input may start with a
+// TIFF header or contain an "Exif\0\0" APP1 segment.
+func (c *Converter) DecodeEXIF(hexInput string) (*models.ExifResult, error) {
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeEXIF(data, 0)
+}
+
+// EXIF decodes TIFF/EXIF tags from a region of an opened file.
+// A length of 0 decodes up to the end of the file. Reported offsets are file offsets.
+func (s *FileService) EXIF(id string, offset int64, length int) (*models.ExifResult, error) {
+	f, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data := f.buf.Bytes()
+	if offset < 0 || offset > int64(len(data)) {
+		return nil, fmt.Errorf("offset %d out of range (size %d)", offset, len(data))
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("invalid length: %d", length)
+	}
+
+	end := int64(len(data))
+	if length > 0 {
+		end = min(offset+int64(length), end)
+	}
+	return decodeEXIF(data[offset:end], offset)
+}
+
+// decodeEXIF decodes data and converts the result to its model representation,
+// shifting all offsets by base.
+func decodeEXIF(data []byte, base int64) (*models.ExifResult, error) {
+	decoded, err := exif.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ExifResult{
+		ByteOrder:    decoded.ByteOrder,
+		HeaderOffset: base + decoded.HeaderOffset,
+		Tags:         make([]models.ExifTag, len(decoded.Tags)),
+		Warnings:     decoded.Warnings,
+	}
+	for i, tag := range decoded.Tags {
+		result.Tags[i] = models.ExifTag{
+			IFD:         tag.IFD,
+			ID:          fmt.Sprintf("0x%04x", tag.ID),
+			Name:        tag.Name,
+			Type:        tag.Type,
+			Count:       tag.Count,
+			Value:       tag.Value,
+			Offset:      base + tag.Offset,
+			ValueOffset: base + tag.ValueOffset,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"testing"
+
+	"hexview/convert"
+)
+
+// minimalTIFF is a big-endian TIFF header with one IFD0 entry: Orientation = 6.
+const minimalTIFF = "4d4d002a00000008" +
+	"0001" + "011200030000000100060000" + "00000000"
+
+func TestDecodeEXIF(t *testing.T) {
+	c := NewConverter()
+
+	result, err := c.DecodeEXIF(minimalTIFF)
+	if err != nil {
+		t.Fatalf("DecodeEXIF() error: %v", err)
+	}
+	if result.ByteOrder != "big" {
+		t.Errorf("Expected big byte order, got %s", result.ByteOrder)
+	}
+	if len(result.Tags) != 1 {
+		t.Fatalf("Expected 1 tag, got %d: %+v", len(result.Tags), result.Tags)
+	}
+	tag := result.Tags[0]
+	if tag.ID != "0x0112" || tag.Name != "Orientation" || tag.Value != "6" {
+		t.Errorf("Unexpected tag: %+v", tag)
+	}
+}
+
+func TestDecodeEXIF_Errors(t *testing.T) {
+	c := NewConverter()
+	for _, input := range []string{"", "GG", "00112233"} {
+		if _, err := c.DecodeEXIF(input); err == nil {
+			t.Errorf("DecodeEXIF(%q) expected error", input)
+		}
+	}
+}
+
+func TestFileService_EXIF(t *testing.T) {
+	tiff, _ := convert.HexToBytes(minimalTIFF)
+	data := append([]byte("JUNKExif\x00\x00"), tiff...)
+
+	s := NewFileService()
+	info, err := s.Open(writeTempFile(t, "photo.bin", data))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+
+	result, err := s.EXIF(info.ID, 4, 0)
+	if err != nil {
+		t.Fatalf("EXIF() error: %v", err)
+	}
+	if result.HeaderOffset != 10 {
+		t.Errorf("Expected header offset 10, got %d", result.HeaderOffset)
+	}
+	if result.Tags[0].Offset != 20 {
+		t.Errorf("Expected tag entry at file offset 20, got %d", result.Tags[0].Offset)
+	}
+
+	if _, err := s.EXIF(info.ID, 100, 0); err == nil {
+		t.Error("Expected error for out-of-range offset")
+	}
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"hexview/export"
+	"hexview/models"
+)
+
+// ExportConversion writes a conversion result to path. The format (CSV, JSON
+// or Markdown) is derived from the file extension.
+func (c *Converter) ExportConversion(result *models.ConversionResult, path string) error {
+	if result == nil {
+		return fmt.Errorf("no result to export")
+	}
+	return writeExport(path, "Conversion", conversionTables(result), result)
+}
+
+// ExportModbus writes a Modbus result to path. The format (CSV, JSON or
+// Markdown) is derived from the file extension.
+func (c *Converter) ExportModbus(result *models.ModbusResult, path string) error {
+	if result == nil {
+		return fmt.Errorf("no result to export")
+	}
+	return writeExport(path, "Modbus Registers", modbusTables(result), result)
+}
+
+// writeExport renders tables, or v for JSON, and writes the file.
+func writeExport(path, title string, tables []export.Table, v any) error {
+	format, err := export.FormatFromPath(path)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case export.CSV:
+		err = export.WriteCSV(&buf, tables)
+	case export.Markdown:
+		err = export.WriteMarkdown(&buf, title, tables)
+	default:
+		err = export.WriteJSON(&buf, v)
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// conversionTables lists the populated values of a conversion result by type
+// and byte order, followed by its binary, hex and ASCII representations.
+func conversionTables(r *models.ConversionResult) []export.Table {
+	values := export.Table{Title: "Values", Columns: []string{"Type", "Byte Order", "Value", "Hex"}}
+
+	v := reflect.ValueOf(r).Elem()
+	for _, f := range resultFields {
+		field := v.Field(f.index)
+		if field.IsNil() {
+			continue
+		}
+		values.Rows = append(values.Rows, []string{
+			strings.ToLower(f.typ), f.order, fmt.Sprint(field.Elem().Interface()), v.Field(f.hexIndex).String(),
+		})
+	}
+
+	reprs := export.Table{Title: "Representations", Columns: []string{"Name", "Value"}}
+	reprs.Rows = [][]string{
+		{"Bytes", r.Bytes},
+		{"Binary", r.Binary},
+		{"ASCII", r.ASCII},
+	}
+	if r.Canonical != "" {
+		reprs.Rows = append(reprs.Rows, []string{"Input", r.Canonical})
+	}
+	return []export.Table{values, reprs}
+}
+
+// modbusTables lists registers and their 32-bit and 64-bit combinations.
+func modbusTables(r *models.ModbusResult) []export.Table {
+	regs := export.Table{Title: "Registers", Columns: []string{"Index", "Hex", "Unsigned", "Signed", "Binary"}}
+	withAddress := len(r.Registers) > 0 && r.Registers[0].Address != nil
+	if withAddress {
+		regs.Columns = append([]string{"Address"}, regs.Columns...)
+	}
+	for i, reg := range r.Registers {
+		row := []string{
+			strconv.Itoa(reg.Index), reg.Hex,
+			strconv.FormatUint(uint64(reg.Unsigned), 10), strconv.Itoa(int(reg.Signed)), reg.Binary,
+		}
+		if withAddress {
+			row = append([]string{strconv.Itoa(registerAddress(r, i))}, row...)
+		}
+		regs.Rows = append(regs.Rows, row)
+	}
+
+	c32 := export.Table{Title: "32-bit Values", Columns: []string{
+		"Registers", "Hex",
+		"Uint32 BE", "Uint32 LE", "Uint32 BADC", "Uint32 CDAB",
+		"Int32 BE", "Int32 LE", "Int32 BADC", "Int32 CDAB",
+		"Float32 BE", "Float32 LE", "Float32 BADC", "Float32 CDAB",
+	}}
+	for _, c := range r.Combined32 {
+		c32.Rows = append(c32.Rows, []string{
+			registerSpan(r, c.RegisterStart, 2), c.Hex,
+			fmt.Sprint(c.Uint32BE), fmt.Sprint(c.Uint32LE), fmt.Sprint(c.Uint32BADC), fmt.Sprint(c.Uint32CDAB),
+			fmt.Sprint(c.Int32BE), fmt.Sprint(c.Int32LE), fmt.Sprint(c.Int32BADC), fmt.Sprint(c.Int32CDAB),
+			c.Float32BE, c.Float32LE, c.Float32BADC, c.Float32CDAB,
+		})
+	}
+
+	c64 := export.Table{Title: "64-bit Values", Columns: []string{
+		"Registers", "Hex", "Uint64 BE", "Uint64 LE", "Int64 BE", "Int64 LE",
+		"Float64 BE", "Float64 LE", "Float64 BADC", "Float64 CDAB", "Float32 Pair BE", "Float32 Pair CDAB",
+	}}
+	for _, c := range r.Combined64 {
+		c64.Rows = append(c64.Rows, []string{
+			registerSpan(r, c.RegisterStart, 4), c.Hex,
+			fmt.Sprint(c.Uint64BE), fmt.Sprint(c.Uint64LE), fmt.Sprint(c.Int64BE), fmt.Sprint(c.Int64LE),
+			c.Float64BE, c.Float64LE, c.Float64BADC, c.Float64CDAB,
+			strings.Join(c.Float32PairBE[:], " "), strings.Join(c.Float32PairCDAB[:], " "),
+		})
+	}
+
+	tables := []export.Table{regs}
+	if len(c32.Rows) > 0 {
+		tables = append(tables, c32)
+	}
+	if len(c64.Rows) > 0 {
+		tables = append(tables, c64)
+	}
+	for _, st := range r.Scripts {
+		if len(st.Rows) > 0 {
+			tables = append(tables, modbusScriptTable(r, st))
+		}
+	}
+	return tables
+}
+
+// modbusScriptTable returns the values of a script as a table with a column
+// per value name, in the order the names first appear. An error column is
+// added if any window failed.
+func modbusScriptTable(r *models.ModbusResult, st models.ModbusScriptTable) export.Table {
+	columns := map[string]int{}
+	t := export.Table{Title: st.Name, Columns: []string{"Registers"}}
+	withError := false
+	for _, row := range st.Rows {
+		for _, v := range row.Values {
+			if _, ok := columns[v.Name]; !ok {
+				columns[v.Name] = len(t.Columns)
+				t.Columns = append(t.Columns, v.Name)
+			}
+		}
+		withError = withError || row.Error != ""
+	}
+	if withError {
+		t.Columns = append(t.Columns, "Error")
+	}
+	for _, row := range st.Rows {
+		cells := make([]string, len(t.Columns))
+		cells[0] = registerSpan(r, row.RegisterStart, st.Window)
+		for _, v := range row.Values {
+			cells[columns[v.Name]] = v.Value
+		}
+		if withError {
+			cells[len(cells)-1] = row.Error
+		}
+		t.Rows = append(t.Rows, cells)
+	}
+	return t
+}
+
+// registerSpan formats the register range of a combined value starting at
+// the 1-based index start, e.g. "1-2", or "40001-40002" for registers with
+// addresses.
+func registerSpan(r *models.ModbusResult, start, count int) string {
+	return fmt.Sprintf("%d-%d", registerAddress(r, start-1), registerAddress(r, start+count-2))
+}
+
+// samplesTables lists recorded poll samples, one row per value.
+func samplesTables(samples []models.PollSample) []export.Table {
+	t := export.Table{Title: "Samples", Columns: []string{"Timestamp", "Name", "Value", "Raw", "Unit", "Error"}}
+	for _, s := range samples {
+		t.Rows = append(t.Rows, []string{s.Timestamp, s.Name, s.Value, s.Raw, s.Unit, s.Error})
+	}
+	return []export.Table{t}
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"hexview/models"
+)
+
+func TestConverter_ExportConversion(t *testing.T) {
+	c := NewConverter()
+	result, err := c.ConvertHex("0x0102")
+	if err != nil {
+		t.Fatalf("ConvertHex() error: %v", err)
+	}
+	dir := t.TempDir()
+
+	csvPath := filepath.Join(dir, "result.csv")
+	if err := c.ExportConversion(result, csvPath); err != nil {
+		t.Fatalf("ExportConversion(csv) error: %v", err)
+	}
+	data, _ := os.ReadFile(csvPath)
+	for _, want := range []string{"Type,Byte Order,Value,Hex", "uint16,BE,258,0102", "int16,LE,513,", "Bytes,0102"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("CSV missing %q:\n%s", want, data)
+		}
+	}
+
+	mdPath := filepath.Join(dir, "result.md")
+	if err := c.ExportConversion(result, mdPath); err != nil {
+		t.Fatalf("ExportConversion(md) error: %v", err)
+	}
+	data, _ = os.ReadFile(mdPath)
+	if !strings.Contains(string(data), "| uint16 | BE | 258 | 0102 |") {
+		t.Errorf("Markdown missing uint16 row:\n%s", data)
+	}
+
+	jsonPath := filepath.Join(dir, "result.json")
+	if err := c.ExportConversion(result, jsonPath); err != nil {
+		t.Fatalf("ExportConversion(json) error: %v", err)
+	}
+	var decoded models.ConversionResult
+	data, _ = os.ReadFile(jsonPath)
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Bytes != "0102" {
+		t.Errorf("JSON round trip failed: %v, %s", err, data)
+	}
+
+	if err := c.ExportConversion(result, filepath.Join(dir, "result.xlsx")); err == nil {
+		t.Error("Expected error for unknown extension")
+	}
+	if err := c.ExportConversion(nil, csvPath); err == nil {
+		t.Error("Expected error for nil result")
+	}
+}
+
+func TestConverter_ExportModbus(t *testing.T) {
+	c := NewConverter()
+	result, err := c.ConvertModbusRegisters("0x4248 0x0000")
+	if err != nil {
+		t.Fatalf("ConvertModbusRegisters() error: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "registers.csv")
+	if err := c.ExportModbus(result, path); err != nil {
+		t.Fatalf("ExportModbus() error: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	out := string(data)
+	for _, want := range []string{"Registers\nIndex,Hex,Unsigned,Signed,Binary\n1,4248,16968,16968,", "32-bit Values", "1-2,42480000,"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("CSV missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "64-bit Values") {
+		t.Errorf("Unexpected empty 64-bit table:\n%s", out)
+	}
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"hexview/models"
+)
+
+// ErrFavoriteNotFound indicates an unknown favorite ID was used
+var ErrFavoriteNotFound = errors.New("favorite not found")
+
+// FavoritesService stores pinned conversions in a JSON file.
+// With an empty path favorites are kept in memory only.
+type FavoritesService struct {
+	mu        sync.Mutex
+	path      string
+	favorites []models.Favorite
+	nextID    int64
+}
+
+// favoritesFile is the on-disk format of the favorites.
+type favoritesFile struct {
+	NextID    int64             `json:"nextId"`
+	Favorites []models.Favorite `json:"favorites"`
+}
+
+// NewFavoritesService creates an empty FavoritesService backed by the file at
+// path. Call Load to read the file.
+func NewFavoritesService(path string) *FavoritesService {
+	return &FavoritesService{path: path, nextID: 1}
+}
+
+// Load reads the favorites file. A missing file leaves the list empty.
+func (s *FavoritesService) Load() error {
+	if s.path == "" {
+		return nil
+	}
+	var f favoritesFile
+	if err := loadJSON(s.path, &f); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.favorites = f.Favorites
+	s.nextID = max(f.NextID, 1)
+	return nil
+}
+
+// List returns all favorites in the order they were added.
+func (s *FavoritesService) List() []models.Favorite {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append(make([]models.Favorite, 0, len(s.favorites)), s.favorites...)
+}
+
+// Add pins a conversion under label.
+func (s *FavoritesService) Add(label, mode, input, typ string) (*models.Favorite, error) {
+	fav := models.Favorite{Label: strings.TrimSpace(label), Mode: mode, Input: input, Type: typ}
+	if err := validateFavorite(fav); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fav.ID = s.nextID
+	fav.CreatedAt = time.Now().Format(time.RFC3339)
+	s.nextID++
+	s.favorites = append(s.favorites, fav)
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+	return &fav, nil
+}
+
+// Import adds favorites, e.g. the bookmarks of a project, with new IDs.
+// Favorites with the mode, input and type of an existing one are skipped.
+// It returns the number of favorites added.
+func (s *FavoritesService) Import(favs []models.Favorite) (int, error) {
+	for _, fav := range favs {
+		if err := validateFavorite(fav); err != nil {
+			return 0, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	added := 0
+	for _, fav := range favs {
+		if slices.ContainsFunc(s.favorites, func(f models.Favorite) bool {
+			return f.Mode == fav.Mode && f.Input == fav.Input && f.Type == fav.Type
+		}) {
+			continue
+		}
+		fav.ID = s.nextID
+		if fav.CreatedAt == "" {
+			fav.CreatedAt = time.Now().Format(time.RFC3339)
+		}
+		s.nextID++
+		s.favorites = append(s.favorites, fav)
+		added++
+	}
+	if added == 0 {
+		return 0, nil
+	}
+	return added, s.save()
+}
+
+// Update replaces the label, mode, input and type of a favorite.
+func (s *FavoritesService) Update(fav models.Favorite) (*models.Favorite, error) {
+	fav.Label = strings.TrimSpace(fav.Label)
+	if err := validateFavorite(fav); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, err := s.index(fav.ID)
+	if err != nil {
+		return nil, err
+	}
+	fav.CreatedAt = s.favorites[i].CreatedAt
+	s.favorites[i] = fav
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+	return &fav, nil
+}
+
+// Remove deletes a favorite.
+func (s *FavoritesService) Remove(id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, err := s.index(id)
+	if err != nil {
+		return err
+	}
+	s.favorites = slices.Delete(s.favorites, i, i+1)
+	return s.save()
+}
+
+// Run converts the input of a favorite with its mode and type.
+// Conversion errors are reported in the result.
+func (s *FavoritesService) Run(ctx context.Context, c *Converter, id int64) (*models.FavoriteResult, error) {
+	s.mu.Lock()
+	i, err := s.index(id)
+	var fav models.Favorite
+	if err == nil {
+		fav = s.favorites[i]
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	result := runFavorite(ctx, c, fav)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RunAll runs every favorite, e.g. to re-check a set of registers at once.
+// When ctx is cancelled it stops and returns the results so far with the
+// error of ctx.
+func (s *FavoritesService) RunAll(ctx context.Context, c *Converter) ([]models.FavoriteResult, error) {
+	favorites := s.List()
+	results := make([]models.FavoriteResult, 0, len(favorites))
+	for _, fav := range favorites {
+		result := runFavorite(ctx, c, fav)
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		results = append(results, *result)
+	}
+	return results, nil
+}
+
+// index returns the position of a favorite. Must be called with s.mu held.
+func (s *FavoritesService) index(id int64) (int, error) {
+	i := slices.IndexFunc(s.favorites, func(f models.Favorite) bool { return f.ID == id })
+	if i < 0 {
+		return 0, fmt.Errorf("%w: %d", ErrFavoriteNotFound, id)
+	}
+	return i, nil
+}
+
+// save writes the favorites file. Must be called with s.mu held.
+func (s *FavoritesService) save() error {
+	if s.path == "" {
+		return nil
+	}
+	return saveJSON(s.path, favoritesFile{NextID: s.nextID, Favorites: s.favorites})
+}
+
+// validateFavorite checks that a favorite has a label, input and known mode.
+func validateFavorite(fav models.Favorite) error {
+	if fav.Label == "" {
+		return fmt.Errorf("empty label")
+	}
+	if fav.Input == "" {
+		return fmt.Errorf("empty input")
+	}
+	if !validMode(fav.Mode) {
+		return fmt.Errorf("unknown mode: %s", fav.Mode)
+	}
+	return nil
+}
+
+// runFavorite converts the input of fav according to its mode.
+func runFavorite(ctx context.Context, c *Converter, fav models.Favorite) *models.FavoriteResult {
+	result := &models.FavoriteResult{Favorite: fav}
+	var err error
+	result.Conversion, result.Modbus, err = c.ConvertMode(ctx, fav.Mode, fav.Input, fav.Type)
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// ConvertMode converts input in one of the conversion modes (ModeHex,
+// ModeInt, ...). typ is the integer or float type of int and float
+// conversions and defaults to int32 and float32. Modbus conversions return
+// a ModbusResult, all other modes a ConversionResult.
+func (c *Converter) ConvertMode(ctx context.Context, mode, input, typ string) (*models.ConversionResult, *models.ModbusResult, error) {
+	var (
+		conversion *models.ConversionResult
+		modbus     *models.ModbusResult
+		err        error
+	)
+	switch mode {
+	case ModeHex:
+		conversion, err = c.ConvertHex(input)
+	case ModeInt:
+		conversion, err = c.ConvertInt(input, OrDefault(models.IntType(typ), models.Int32))
+	case ModeIntAuto:
+		conversion, err = c.ConvertIntAuto(input)
+	case ModeBinary:
+		conversion, err = c.ConvertBinary(input)
+	case ModeFloat:
+		conversion, err = c.ConvertFloat(input, OrDefault(models.FloatType(typ), models.Float32))
+	case ModeModbus:
+		modbus, err = c.ConvertModbusRegistersContext(ctx, input)
+	case ModeEncoded:
+		conversion, err = c.ConvertEncoded(input, typ)
+	default:
+		err = fmt.Errorf("unknown mode: %s", mode)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return conversion, modbus, nil
+}
+
+// OrDefault returns s, or def if s is empty, e.g. for optional fields of
+// requests.
+func OrDefault[T ~string](s, def T) T {
+	if s == "" {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"hexview/models"
+)
+
+func TestFavoritesService(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "favorites.json")
+	s := NewFavoritesService(path)
+	c := NewConverter()
+
+	temp, err := s.Add(" Boiler temperature ", ModeModbus, "0x4248 0x0000", "")
+	if err != nil {
+		t.Fatalf("Add() error: %v", err)
+	}
+	if temp.ID != 1 || temp.Label != "Boiler temperature" || temp.CreatedAt == "" {
+		t.Errorf("Unexpected favorite: %+v", temp)
+	}
+	setpoint, err := s.Add("Setpoint", ModeInt, "513", "uint16")
+	if err != nil {
+		t.Fatalf("Add() error: %v", err)
+	}
+
+	r, err := s.Run(context.Background(), c, temp.ID)
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if r.Modbus == nil || r.Modbus.Combined32[0].Float32BE != "50" {
+		t.Errorf("Unexpected Modbus result: %+v", r)
+	}
+
+	setpoint.Input = "bogus"
+	if _, err := s.Update(*setpoint); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	results, err := s.RunAll(context.Background(), c)
+	if err != nil || len(results) != 2 || results[1].Error == "" || results[1].Conversion != nil {
+		t.Errorf("Expected conversion error for second favorite, got %+v, %v", results, err)
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if results, err := s.RunAll(cancelled, c); !errors.Is(err, context.Canceled) || len(results) != 0 {
+		t.Errorf("RunAll() with cancelled context = %+v, %v", results, err)
+	}
+
+	reloaded := NewFavoritesService(path)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	list := reloaded.List()
+	if len(list) != 2 || list[1].Input != "bogus" || list[1].CreatedAt != setpoint.CreatedAt {
+		t.Errorf("Reloaded favorites differ: %+v", list)
+	}
+
+	if err := reloaded.Remove(temp.ID); err != nil {
+		t.Fatalf("Remove() error: %v", err)
+	}
+	if _, err := reloaded.Run(context.Background(), c, temp.ID); !errors.Is(err, ErrFavoriteNotFound) {
+		t.Errorf("Expected ErrFavoriteNotFound, got %v", err)
+	}
+	if fav, _ := reloaded.Add("Next", ModeHex, "01", ""); fav.ID != 3 {
+		t.Errorf("Expected IDs to continue after reload, got %d", fav.ID)
+	}
+}
+
+func TestFavoritesService_Invalid(t *testing.T) {
+	s := NewFavoritesService("")
+	tests := []struct{ label, mode, input string }{
+		{"", ModeHex, "01"},
+		{"x", ModeHex, ""},
+		{"x", "octal", "01"},
+	}
+	for _, tt := range tests {
+		if _, err := s.Add(tt.label, tt.mode, tt.input, ""); err == nil {
+			t.Errorf("Add(%q, %q, %q) expected error", tt.label, tt.mode, tt.input)
+		}
+	}
+	if _, err := s.Add("x", ModeHex, "01", ""); err != nil {
+		t.Fatalf("Add() error: %v", err)
+	}
+	if _, err := s.Update(s.List()[0]); err != nil {
+		t.Errorf("Update() error: %v", err)
+	}
+	missing := s.List()[0]
+	missing.ID = 99
+	if _, err := s.Update(missing); !errors.Is(err, ErrFavoriteNotFound) {
+		t.Errorf("Expected ErrFavoriteNotFound, got %v", err)
+	}
+}
+
+func TestFavoritesService_Import(t *testing.T) {
+	s := NewFavoritesService(filepath.Join(t.TempDir(), "favorites.json"))
+	if _, err := s.Add("Setpoint", ModeInt, "513", "uint16"); err != nil {
+		t.Fatal(err)
+	}
+
+	added, err := s.Import([]models.Favorite{
+		{ID: 1, Label: "Setpoint (project)", Mode: ModeInt, Input: "513", Type: "uint16"},
+		{ID: 1, Label: "Boiler temperature", Mode: ModeModbus, Input: "0x4248 0x0000"},
+	})
+	if err != nil || added != 1 {
+		t.Fatalf("Import() = %d, %v, want 1, nil", added, err)
+	}
+	list := s.List()
+	if len(list) != 2 || list[1].Label != "Boiler temperature" || list[1].ID == list[0].ID || list[1].CreatedAt == "" {
+		t.Errorf("Unexpected favorites: %+v", list)
+	}
+
+	if _, err := s.Import([]models.Favorite{{Label: "empty", Mode: ModeHex}}); err == nil {
+		t.Error("Import() of an invalid favorite succeeded")
+	}
+}
@@ -0,0 +1,547 @@
+package service
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"hexview/binfmt"
+	"hexview/convert"
+	"hexview/editor"
+	"hexview/models"
+)
+
+// MaxFileSize is the largest file that can be opened in the file subsystem.
+const MaxFileSize = 256 << 20
+
+// ErrFileNotOpen indicates an unknown file ID was used
+var ErrFileNotOpen = errors.New("file not open")
+
+// FileService manages binary files opened from disk.
+// Files are addressed by an ID assigned on Open.
+type FileService struct {
+	mu     sync.RWMutex
+	files  map[string]*openFile
+	nextID int
+}
+
+// openFile holds the state of a single opened file.
+type openFile struct {
+	id      string
+	path    string
+	buf     *editor.Buffer
+	modTime time.Time // modification time of the loaded or saved content
+	size    int64     // on-disk size of the loaded or saved content
+	cursor  int64     // offset of the selected byte
+
+	// Last external change reported by CheckChanges, used to avoid repeated notifications
+	notifiedModTime time.Time
+	notifiedDeleted bool
+}
+
+// NewFileService creates a new FileService instance.
+func NewFileService() *FileService {
+	return &FileService{
+		files: make(map[string]*openFile),
+	}
+}
+
+// Open reads the file at path into memory and returns its descriptor.
+func (s *FileService) Open(path string) (*models.FileInfo, error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty path")
+	}
+
+	st, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open file: %w", err)
+	}
+	if st.IsDir() {
+		return nil, fmt.Errorf("cannot open file: %s is a directory", path)
+	}
+	if st.Size() > MaxFileSize {
+		return nil, fmt.Errorf("file too large: %d bytes (limit %d)", st.Size(), MaxFileSize)
+	}
+
+	data, err := readFileLimited(path, MaxFileSize)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	f := &openFile{
+		id:      fmt.Sprintf("file-%d", s.nextID),
+		path:    path,
+		buf:     editor.New(data),
+		modTime: st.ModTime(),
+		size:    st.Size(),
+	}
+	s.files[f.id] = f
+
+	info := f.info()
+	return &info, nil
+}
+
+// readFileLimited reads the file at path in chunks and fails once it holds
+// more than limit bytes. Unlike the size reported by Stat, this also bounds
+// files that grow while they are read and devices or pipes without a size.
+func readFileLimited(path string, limit int64) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open file: %w", err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read file: %w", err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("file too large: more than %d bytes", limit)
+	}
+	return data, nil
+}
+
+// Close releases an opened file.
+func (s *FileService) Close(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.files[id]; !ok {
+		return fmt.Errorf("%w: %s", ErrFileNotOpen, id)
+	}
+	delete(s.files, id)
+	return nil
+}
+
+// List returns descriptors of all opened files ordered by ID.
+func (s *FileService) List() []models.FileInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]models.FileInfo, 0, len(s.files))
+	for _, f := range s.files {
+		list = append(list, f.info())
+	}
+	sort.Slice(list, func(i, j int) bool { return compareIDs(list[i].ID, list[j].ID) < 0 })
+	return list
+}
+
+// compareIDs orders IDs of the form "prefix-N" by their counter N, so
+// "file-2" comes before "file-10".
+func compareIDs(a, b string) int {
+	return cmp.Or(cmp.Compare(len(a), len(b)), strings.Compare(a, b))
+}
+
+// ReadRange returns up to length bytes starting at offset.
+// Reads past the end of the file are shortened.
+func (s *FileService) ReadRange(id string, offset int64, length int) (*models.FileRange, error) {
+	chunk, err := s.Bytes(id, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	return &models.FileRange{
+		Offset: offset,
+		Length: len(chunk),
+		Hex:    convert.BytesToHex(chunk),
+		ASCII:  bytesToASCII(chunk),
+	}, nil
+}
+
+// Bytes returns a copy of up to length bytes starting at offset, like
+// ReadRange but without encoding them.
+func (s *FileService) Bytes(id string, offset int64, length int) ([]byte, error) {
+	f, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data := f.buf.Bytes()
+	if offset < 0 || offset > int64(len(data)) {
+		return nil, fmt.Errorf("offset %d out of range (size %d)", offset, len(data))
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("invalid length: %d", length)
+	}
+
+	end := min(offset+int64(length), int64(len(data)))
+	return append([]byte(nil), data[offset:end]...), nil
+}
+
+// HexDump renders a page of rows of an opened file as a hex dump, selected
+// with opts.StartRow and opts.MaxRows. Offsets are file offsets.
+func (s *FileService) HexDump(id string, opts models.HexDumpOptions) (*models.HexDump, error) {
+	f, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return HexDumpBytes(f.buf.Bytes(), opts)
+}
+
+// ExecutableInfo parses the ELF/PE/Mach-O header of an opened file.
+// Section and segment offsets can be passed to ReadRange for navigation.
+func (s *FileService) ExecutableInfo(id string) (*models.ExecutableInfo, error) {
+	f, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	info, err := binfmt.Parse(bytes.NewReader(f.buf.Bytes()))
+	s.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ExecutableInfo{
+		Format:    string(info.Format),
+		Type:      info.Type,
+		Arch:      info.Arch,
+		Bits:      info.Bits,
+		Endian:    info.Endian,
+		Entry:     fmt.Sprintf("0x%x", info.Entry),
+		Sections:  make([]models.ExecutableSection, len(info.Sections)),
+		Segments:  make([]models.ExecutableSegment, len(info.Segments)),
+		FatArches: info.FatArches,
+	}
+	for i, sec := range info.Sections {
+		result.Sections[i] = models.ExecutableSection{
+			Name:    sec.Name,
+			Offset:  int64(sec.Offset),
+			Size:    int64(sec.Size),
+			Address: fmt.Sprintf("0x%x", sec.Address),
+			Flags:   sec.Flags,
+		}
+	}
+	for i, seg := range info.Segments {
+		result.Segments[i] = models.ExecutableSegment{
+			Name:     seg.Name,
+			Type:     seg.Type,
+			Offset:   int64(seg.Offset),
+			FileSize: int64(seg.FileSize),
+			Address:  fmt.Sprintf("0x%x", seg.Address),
+			MemSize:  int64(seg.MemSize),
+			Flags:    seg.Flags,
+		}
+	}
+
+	return result, nil
+}
+
+// Overwrite replaces bytes at offset with the given hex data.
+func (s *FileService) Overwrite(id string, offset int, hexData string) (*models.FileInfo, error) {
+	data, err := convert.HexToBytes(hexData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex input: %w", err)
+	}
+	return s.edit(id, func(b *editor.Buffer) error {
+		return b.Overwrite(offset, data)
+	})
+}
+
+// Insert inserts hex data at offset, shifting the following bytes.
+func (s *FileService) Insert(id string, offset int, hexData string) (*models.FileInfo, error) {
+	data, err := convert.HexToBytes(hexData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex input: %w", err)
+	}
+	return s.edit(id, func(b *editor.Buffer) error {
+		return b.Insert(offset, data)
+	})
+}
+
+// Delete removes length bytes starting at offset.
+func (s *FileService) Delete(id string, offset, length int) (*models.FileInfo, error) {
+	return s.edit(id, func(b *editor.Buffer) error {
+		return b.Delete(offset, length)
+	})
+}
+
+// Fill overwrites length bytes starting at offset with a repeating hex pattern.
+func (s *FileService) Fill(id string, offset, length int, hexPattern string) (*models.FileInfo, error) {
+	pattern, err := convert.HexToBytes(hexPattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex pattern: %w", err)
+	}
+	return s.edit(id, func(b *editor.Buffer) error {
+		return b.Fill(offset, length, pattern)
+	})
+}
+
+// Replace substitutes all occurrences of findHex with replaceHex as a single undo step.
+// An empty replaceHex removes the matches.
+func (s *FileService) Replace(id string, findHex, replaceHex string) (*models.ReplaceResult, error) {
+	find, err := convert.HexToBytes(findHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid search pattern: %w", err)
+	}
+	var repl []byte
+	if replaceHex != "" {
+		repl, err = convert.HexToBytes(replaceHex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid replacement: %w", err)
+		}
+	}
+
+	var count int
+	info, err := s.edit(id, func(b *editor.Buffer) error {
+		var err error
+		count, err = b.Replace(find, repl)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &models.ReplaceResult{File: *info, Count: count}, nil
+}
+
+// Undo reverts the most recent edit (or edit group) of a file.
+func (s *FileService) Undo(id string) (*models.FileInfo, error) {
+	return s.edit(id, func(b *editor.Buffer) error {
+		return b.Undo()
+	})
+}
+
+// Redo re-applies the most recently undone edit of a file.
+func (s *FileService) Redo(id string) (*models.FileInfo, error) {
+	return s.edit(id, func(b *editor.Buffer) error {
+		return b.Redo()
+	})
+}
+
+// Save writes the edited content back to disk.
+// The file is written to a temporary file first and then renamed, so a failed
+// write never leaves a truncated file behind.
+func (s *FileService) Save(id string) (*models.FileInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.files[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrFileNotOpen, id)
+	}
+
+	mode := os.FileMode(0o644)
+	if st, err := os.Stat(f.path); err == nil {
+		mode = st.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), "."+filepath.Base(f.path)+".*")
+	if err != nil {
+		return nil, fmt.Errorf("cannot save file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(f.buf.Bytes()); err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("cannot save file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("cannot save file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return nil, fmt.Errorf("cannot save file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return nil, fmt.Errorf("cannot save file: %w", err)
+	}
+
+	if st, err := os.Stat(f.path); err == nil {
+		f.modTime = st.ModTime()
+		f.size = st.Size()
+	}
+	f.notifiedModTime = time.Time{}
+	f.notifiedDeleted = false
+	f.buf.MarkSaved()
+
+	info := f.info()
+	return &info, nil
+}
+
+// Reload re-reads an opened file from disk, discarding unsaved edits and the undo history.
+func (s *FileService) Reload(id string) (*models.FileInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.files[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrFileNotOpen, id)
+	}
+
+	st, err := os.Stat(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot reload file: %w", err)
+	}
+	if st.Size() > MaxFileSize {
+		return nil, fmt.Errorf("file too large: %d bytes (limit %d)", st.Size(), MaxFileSize)
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot reload file: %w", err)
+	}
+
+	f.buf.Reset(data)
+	f.modTime = st.ModTime()
+	f.size = st.Size()
+	f.notifiedModTime = time.Time{}
+	f.notifiedDeleted = false
+
+	info := f.info()
+	return &info, nil
+}
+
+// CheckChanges compares every opened file against its state on disk and returns
+// one event per file that was modified or deleted externally since it was loaded.
+// Each change is reported only once until the file is reloaded or saved.
+func (s *FileService) CheckChanges() []models.FileChangeEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var events []models.FileChangeEvent
+	for _, f := range s.files {
+		st, err := os.Stat(f.path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) && !f.notifiedDeleted {
+				f.notifiedDeleted = true
+				events = append(events, f.changeEvent("deleted"))
+			}
+			continue
+		}
+		f.notifiedDeleted = false
+
+		changed := !st.ModTime().Equal(f.modTime) || st.Size() != f.size
+		if changed && !st.ModTime().Equal(f.notifiedModTime) {
+			f.notifiedModTime = st.ModTime()
+			events = append(events, f.changeEvent("modified"))
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool { return compareIDs(events[i].FileID, events[j].FileID) < 0 })
+	return events
+}
+
+// Watch polls opened files for external modifications until ctx is cancelled,
+// calling notify for every change reported by CheckChanges.
+func (s *FileService) Watch(ctx context.Context, interval time.Duration, notify func(models.FileChangeEvent)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, ev := range s.CheckChanges() {
+				notify(ev)
+			}
+		}
+	}
+}
+
+// SetCursor moves the cursor of a file to offset, which may be the end of
+// the file to append.
+func (s *FileService) SetCursor(id string, offset int64) (*models.FileInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.files[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrFileNotOpen, id)
+	}
+	if offset < 0 || offset > int64(f.buf.Len()) {
+		return nil, fmt.Errorf("offset %d out of range (size %d)", offset, f.buf.Len())
+	}
+	f.cursor = offset
+
+	info := f.info()
+	return &info, nil
+}
+
+// Cursor returns the cursor offset of a file.
+func (s *FileService) Cursor(id string) (int64, error) {
+	f, err := s.get(id)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return f.info().Cursor, nil
+}
+
+// edit runs an editor operation on a file under the write lock.
+func (s *FileService) edit(id string, op func(b *editor.Buffer) error) (*models.FileInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.files[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrFileNotOpen, id)
+	}
+	if err := op(f.buf); err != nil {
+		return nil, err
+	}
+
+	info := f.info()
+	return &info, nil
+}
+
+// get looks up an opened file by ID.
+func (s *FileService) get(id string) (*openFile, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	f, ok := s.files[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrFileNotOpen, id)
+	}
+	return f, nil
+}
+
+// changeEvent builds an external change notification for the file.
+// A change conflicts with the open buffer when it has unsaved edits.
+func (f *openFile) changeEvent(kind string) models.FileChangeEvent {
+	return models.FileChangeEvent{
+		FileID:   f.id,
+		Path:     f.path,
+		Change:   kind,
+		Conflict: f.buf.Modified(),
+	}
+}
+
+// info builds the public descriptor of an opened file.
+func (f *openFile) info() models.FileInfo {
+	return models.FileInfo{
+		ID:        f.id,
+		Path:      f.path,
+		Name:      filepath.Base(f.path),
+		Size:      int64(f.buf.Len()),
+		ModTime:   f.modTime.Format(time.RFC3339),
+		Modified:  f.buf.Modified(),
+		CanUndo:   f.buf.CanUndo(),
+		CanRedo:   f.buf.CanRedo(),
+		UndoLabel: f.buf.UndoLabel(),
+		RedoLabel: f.buf.RedoLabel(),
+		Cursor:    min(f.cursor, int64(f.buf.Len())), // edits may have shortened the file
+	}
+}
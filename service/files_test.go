@@ -0,0 +1,405 @@
+package service
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"hexview/models"
+)
+
+// writeTempFile creates a file with the given content in a test temp directory.
+func writeTempFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	return path
+}
+
+func TestFileService_OpenReadClose(t *testing.T) {
+	s := NewFileService()
+	path := writeTempFile(t, "data.bin", []byte("Hello, hexview"))
+
+	info, err := s.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	if info.Size != 14 || info.Name != "data.bin" {
+		t.Errorf("Unexpected file info: %+v", info)
+	}
+
+	r, err := s.ReadRange(info.ID, 7, 100)
+	if err != nil {
+		t.Fatalf("ReadRange() error: %v", err)
+	}
+	if r.ASCII != "hexview" || r.Length != 7 {
+		t.Errorf("ReadRange() = %+v, want 7 bytes 'hexview'", r)
+	}
+
+	if len(s.List()) != 1 {
+		t.Errorf("Expected 1 open file, got %d", len(s.List()))
+	}
+
+	if err := s.Close(info.ID); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+	if _, err := s.ReadRange(info.ID, 0, 1); !errors.Is(err, ErrFileNotOpen) {
+		t.Errorf("Expected ErrFileNotOpen after close, got %v", err)
+	}
+}
+
+func TestFileService_ListOrder(t *testing.T) {
+	s := NewFileService()
+	path := writeTempFile(t, "data.bin", []byte{0x01})
+	for range 11 {
+		if _, err := s.Open(path); err != nil {
+			t.Fatalf("Open() error: %v", err)
+		}
+	}
+	list := s.List()
+	for i, f := range list {
+		if want := fmt.Sprintf("file-%d", i+1); f.ID != want {
+			t.Fatalf("List()[%d].ID = %s, want %s", i, f.ID, want)
+		}
+	}
+}
+
+func TestFileService_OpenErrors(t *testing.T) {
+	s := NewFileService()
+	if _, err := s.Open(""); err == nil {
+		t.Error("Expected error for empty path")
+	}
+	if _, err := s.Open(filepath.Join(t.TempDir(), "missing.bin")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+	if _, err := s.Open(t.TempDir()); err == nil {
+		t.Error("Expected error for directory")
+	}
+}
+
+func TestFileService_ReadRangeBounds(t *testing.T) {
+	s := NewFileService()
+	info, err := s.Open(writeTempFile(t, "small.bin", []byte{1, 2, 3}))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	if _, err := s.ReadRange(info.ID, 4, 1); err == nil {
+		t.Error("Expected error for offset past end")
+	}
+	if _, err := s.ReadRange(info.ID, -1, 1); err == nil {
+		t.Error("Expected error for negative offset")
+	}
+}
+
+func TestFileService_HexDump(t *testing.T) {
+	s := NewFileService()
+	data := make([]byte, 1000)
+	data[0x123] = 'A'
+	info, err := s.Open(writeTempFile(t, "dump.bin", data))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+
+	dump, err := s.HexDump(info.ID, models.HexDumpOptions{StartRow: 0x12, MaxRows: 2})
+	if err != nil {
+		t.Fatalf("HexDump() error: %v", err)
+	}
+	if dump.Length != 1000 || dump.TotalRows != 63 || len(dump.Rows) != 2 {
+		t.Fatalf("HexDump() = %+v", dump)
+	}
+	if r := dump.Rows[0]; r.Offset != 0x120 || r.Address != "00000120" || r.Cells[3] != "41" || r.ASCII != "...A............" {
+		t.Errorf("row 0x12 = %+v", r)
+	}
+
+	dump, err = s.HexDump(info.ID, models.HexDumpOptions{StartRow: 62})
+	if err != nil || len(dump.Rows) != 1 || len(dump.Rows[0].Cells) != 8 {
+		t.Errorf("last page = %+v, %v", dump, err)
+	}
+	if _, err := s.HexDump("file-99", models.HexDumpOptions{}); !errors.Is(err, ErrFileNotOpen) {
+		t.Errorf("Expected ErrFileNotOpen, got %v", err)
+	}
+}
+
+func TestReadFileLimited(t *testing.T) {
+	path := writeTempFile(t, "limit.bin", make([]byte, 10))
+	if data, err := readFileLimited(path, 10); err != nil || len(data) != 10 {
+		t.Errorf("readFileLimited(10) = %d bytes, %v", len(data), err)
+	}
+	if _, err := readFileLimited(path, 9); err == nil {
+		t.Error("Expected error for file above the limit")
+	}
+}
+
+func TestFileService_ExecutableInfo(t *testing.T) {
+	// Minimal ELF64 x86-64 executable header without sections
+	elf := make([]byte, 64)
+	copy(elf, []byte{0x7f, 'E', 'L', 'F', 2, 1, 1})
+	binary.LittleEndian.PutUint16(elf[16:], 2)
+	binary.LittleEndian.PutUint16(elf[18:], 0x3e)
+	binary.LittleEndian.PutUint32(elf[20:], 1)
+	binary.LittleEndian.PutUint64(elf[24:], 0x401000)
+	binary.LittleEndian.PutUint16(elf[52:], 64)
+
+	s := NewFileService()
+	info, err := s.Open(writeTempFile(t, "app.elf", elf))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+
+	exe, err := s.ExecutableInfo(info.ID)
+	if err != nil {
+		t.Fatalf("ExecutableInfo() error: %v", err)
+	}
+	if exe.Format != "ELF" || exe.Arch != "x86-64" || exe.Entry != "0x401000" {
+		t.Errorf("Unexpected executable info: %+v", exe)
+	}
+
+	text, err := s.Open(writeTempFile(t, "notes.txt", []byte("plain text")))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	if _, err := s.ExecutableInfo(text.ID); err == nil {
+		t.Error("Expected error for non-executable file")
+	}
+}
+
+func TestFileService_EditUndoRedo(t *testing.T) {
+	s := NewFileService()
+	info, err := s.Open(writeTempFile(t, "fw.bin", []byte{0x00, 0x11, 0x22, 0x33}))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	id := info.ID
+
+	if _, err := s.Overwrite(id, 0, "ff"); err != nil {
+		t.Fatalf("Overwrite() error: %v", err)
+	}
+	if _, err := s.Insert(id, 4, "44 55"); err != nil {
+		t.Fatalf("Insert() error: %v", err)
+	}
+	if _, err := s.Delete(id, 1, 1); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	info, err = s.Fill(id, 0, 2, "aa")
+	if err != nil {
+		t.Fatalf("Fill() error: %v", err)
+	}
+	if !info.Modified || !info.CanUndo || info.UndoLabel != "fill" {
+		t.Errorf("Unexpected edit state: %+v", info)
+	}
+
+	r, _ := s.ReadRange(id, 0, 10)
+	if r.Hex != "aaaa334455" {
+		t.Errorf("After edits hex = %s, want aaaa334455", r.Hex)
+	}
+
+	for i := 0; i < 4; i++ {
+		if _, err := s.Undo(id); err != nil {
+			t.Fatalf("Undo() #%d error: %v", i+1, err)
+		}
+	}
+	r, _ = s.ReadRange(id, 0, 10)
+	if r.Hex != "00112233" {
+		t.Errorf("After undo hex = %s, want 00112233", r.Hex)
+	}
+
+	info, err = s.Redo(id)
+	if err != nil {
+		t.Fatalf("Redo() error: %v", err)
+	}
+	if !info.CanUndo || !info.CanRedo {
+		t.Errorf("Expected both undo and redo available: %+v", info)
+	}
+}
+
+func TestFileService_ReplaceAndSave(t *testing.T) {
+	s := NewFileService()
+	path := writeTempFile(t, "cfg.bin", []byte{0xde, 0xad, 0x00, 0xde, 0xad})
+	info, err := s.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+
+	res, err := s.Replace(info.ID, "dead", "beef")
+	if err != nil {
+		t.Fatalf("Replace() error: %v", err)
+	}
+	if res.Count != 2 {
+		t.Errorf("Replace() count = %d, want 2", res.Count)
+	}
+
+	saved, err := s.Save(info.ID)
+	if err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if saved.Modified {
+		t.Error("Expected Modified=false after save")
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+	if string(got) != string([]byte{0xbe, 0xef, 0x00, 0xbe, 0xef}) {
+		t.Errorf("Saved content = %x", got)
+	}
+}
+
+func TestFileService_EditErrors(t *testing.T) {
+	s := NewFileService()
+	info, err := s.Open(writeTempFile(t, "x.bin", []byte{0x00}))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	if _, err := s.Overwrite(info.ID, 0, "zz"); err == nil {
+		t.Error("Expected error for invalid hex")
+	}
+	if _, err := s.Delete(info.ID, 0, 5); err == nil {
+		t.Error("Expected error for out-of-range delete")
+	}
+	if _, err := s.Undo(info.ID); err == nil {
+		t.Error("Expected error when nothing to undo")
+	}
+	if _, err := s.Undo("file-999"); !errors.Is(err, ErrFileNotOpen) {
+		t.Errorf("Expected ErrFileNotOpen, got %v", err)
+	}
+}
+
+func TestFileService_CheckChanges(t *testing.T) {
+	s := NewFileService()
+	path := writeTempFile(t, "image.bin", []byte{0x01, 0x02})
+	info, err := s.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+
+	if ev := s.CheckChanges(); len(ev) != 0 {
+		t.Fatalf("Expected no changes, got %+v", ev)
+	}
+
+	// External rewrite without local edits
+	if err := os.WriteFile(path, []byte{0x01, 0x02, 0x03}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ev := s.CheckChanges()
+	if len(ev) != 1 || ev[0].Change != "modified" || ev[0].Conflict {
+		t.Fatalf("Expected one non-conflicting modification, got %+v", ev)
+	}
+	if ev := s.CheckChanges(); len(ev) != 0 {
+		t.Errorf("Expected change to be reported only once, got %+v", ev)
+	}
+
+	reloaded, err := s.Reload(info.ID)
+	if err != nil {
+		t.Fatalf("Reload() error: %v", err)
+	}
+	if reloaded.Size != 3 {
+		t.Errorf("Reloaded size = %d, want 3", reloaded.Size)
+	}
+
+	// External rewrite while the buffer has unsaved edits
+	if _, err := s.Overwrite(info.ID, 0, "ff"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte{0x09}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	ev = s.CheckChanges()
+	if len(ev) != 1 || !ev[0].Conflict {
+		t.Fatalf("Expected conflicting modification, got %+v", ev)
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	ev = s.CheckChanges()
+	if len(ev) != 1 || ev[0].Change != "deleted" {
+		t.Fatalf("Expected deletion event, got %+v", ev)
+	}
+
+	// Events of more than nine files are in numeric ID order
+	shared := writeTempFile(t, "shared.bin", []byte{0x01})
+	for range 10 {
+		if _, err := s.Open(shared); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Remove(shared); err != nil {
+		t.Fatal(err)
+	}
+	ev = s.CheckChanges()
+	if len(ev) != 10 {
+		t.Fatalf("Expected 10 deletion events, got %+v", ev)
+	}
+	for i, e := range ev {
+		if want := fmt.Sprintf("file-%d", i+2); e.FileID != want {
+			t.Errorf("event %d is for %s, want %s", i, e.FileID, want)
+		}
+	}
+}
+
+func TestFileService_Watch(t *testing.T) {
+	s := NewFileService()
+	path := writeTempFile(t, "watched.bin", []byte{0x01})
+	if _, err := s.Open(path); err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan models.FileChangeEvent, 1)
+	go s.Watch(ctx, 10*time.Millisecond, func(ev models.FileChangeEvent) {
+		events <- ev
+	})
+
+	if err := os.WriteFile(path, []byte{0x01, 0x02}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case ev := <-events:
+		if ev.Path != path {
+			t.Errorf("Event path = %s, want %s", ev.Path, path)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for change event")
+	}
+}
+
+func TestFileService_Cursor(t *testing.T) {
+	s := NewFileService()
+	info, err := s.Open(writeTempFile(t, "cursor.bin", []byte("0123456789")))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+
+	if info, err = s.SetCursor(info.ID, 8); err != nil || info.Cursor != 8 {
+		t.Fatalf("SetCursor(8) = %+v, %v", info, err)
+	}
+	if _, err := s.SetCursor(info.ID, 11); err == nil {
+		t.Error("Expected an error for a cursor past the end")
+	}
+
+	// The cursor stays within the file when it gets shorter
+	if _, err := s.Delete(info.ID, 0, 5); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if cursor, err := s.Cursor(info.ID); err != nil || cursor != 5 {
+		t.Errorf("Cursor() = %d, %v, want 5", cursor, err)
+	}
+	if _, err := s.Cursor("missing"); !errors.Is(err, ErrFileNotOpen) {
+		t.Errorf("Expected ErrFileNotOpen, got %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+// qFormat matches a Qm.n format name: Q15, Q1.14, UQ8.8 or UQ16. A single
+// number is the fraction bits, as in the Q15 and Q31 of DSP libraries.
+// m counts the integer bits without the sign bit (TI notation); signed
+// formats that only fill whole bytes with the sign bit counted in m, as in
+// the Q1.15 and Q1.31 of ARM and CMSIS, are read in ARM notation.
+var qFormat = regexp.MustCompile(`^(?i)(U?)Q(\d{1,2})(?:\.(\d{1,2}))?$`)
+
+// fixedPointFormat is a parsed Qm.n format and byte order.
+type fixedPointFormat struct {
+	intBits, fracBits int
+	signed            bool
+	order             convert.ByteOrder
+	size              int
+}
+
+// parseFixedPointFormat parses a Qm.n format name and byte order (BE, LE,
+// BADC or CDAB) and checks that they describe a whole number of bytes.
+func parseFixedPointFormat(format, order string) (fixedPointFormat, error) {
+	m := qFormat.FindStringSubmatch(strings.TrimSpace(format))
+	if m == nil {
+		return fixedPointFormat{}, convert.NewInputError(convert.CodeUnsupportedType, convert.ErrInvalidQFormat,
+			fmt.Sprintf("%v: %q (want e.g. Q15, Q1.14 or UQ8.8)", convert.ErrInvalidQFormat, format))
+	}
+	o, ok := parseByteOrder(order)
+	if !ok {
+		return fixedPointFormat{}, errUnsupportedType("fixed-point", format+" "+order)
+	}
+	f := fixedPointFormat{signed: m[1] == "", order: o}
+	f.fracBits, _ = strconv.Atoi(m[2])
+	if m[3] != "" {
+		f.intBits = f.fracBits
+		f.fracBits, _ = strconv.Atoi(m[3])
+		if width := f.intBits + f.fracBits + 1; f.signed && f.intBits > 0 && width%8 != 0 && (width-1)%8 == 0 {
+			f.intBits-- // ARM notation: Q1.15 is Q15
+		}
+	}
+
+	// The width is checked by encoding zero
+	zero, err := convert.FixedPointToHex(0, f.intBits, f.fracBits, f.signed, o)
+	if err != nil {
+		return fixedPointFormat{}, err
+	}
+	f.size = len(zero) / 2
+	return f, nil
+}
+
+// result returns an empty result of the format with its range and
+// resolution.
+func (f fixedPointFormat) result() *models.FixedPointResult {
+	lo, hi, lsb := make([]byte, f.size), make([]byte, f.size), make([]byte, f.size)
+	for i := range hi {
+		hi[i] = 0xff
+	}
+	if f.signed {
+		lo[0], hi[0] = 0x80, 0x7f
+	}
+	lsb[f.size-1] = 1
+	exact := func(b []byte) string {
+		s, _ := convert.FormatFixedPoint(b, f.intBits, f.fracBits, f.signed, convert.BigEndian)
+		return s
+	}
+	return &models.FixedPointResult{
+		Format:     convert.QFormatName(f.intBits, f.fracBits, f.signed),
+		Order:      f.order.String(),
+		Size:       f.size,
+		Min:        exact(lo),
+		Max:        exact(hi),
+		Resolution: exact(lsb),
+	}
+}
+
+// value decodes the bytes of one value.
+func (f fixedPointFormat) value(b []byte) models.FixedPointValue {
+	exact, _ := convert.FormatFixedPoint(b, f.intBits, f.fracBits, f.signed, f.order)
+	v, _ := convert.BytesToFixedPoint(b, f.intBits, f.fracBits, f.signed, f.order)
+	return models.FixedPointValue{Hex: convert.BytesToHex(b), Value: exact, Float: v}
+}
+
+// ConvertFixedPoint decodes the whole hex input as consecutive Qm.n
+// fixed-point numbers of format (e.g. Q15, Q1.14 or UQ8.8; signed formats
+// have a sign bit besides the m integer bits) in the given byte order (BE,
+// LE, BADC or CDAB), like ConvertArray. DSP and motor control registers
+// are usually Q15 or Q31. Values are exact decimals, e.g. 4000 as Q15 is
+// 0.5. Trailing bytes too short for a value are reported as the remainder.
+func (c *Converter) ConvertFixedPoint(hexInput, format, order string) (*models.FixedPointResult, error) {
+	f, err := parseFixedPointFormat(format, order)
+	if err != nil {
+		return nil, err
+	}
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+	result := f.result()
+	count := len(data) / f.size
+	result.Remainder = len(data) % f.size
+	n := min(count, MaxArrayValues)
+	result.Truncated = n < count
+	result.Values = make([]models.FixedPointValue, n)
+	for i := range n {
+		result.Values[i] = f.value(data[i*f.size : (i+1)*f.size])
+	}
+	return result, nil
+}
+
+// EncodeFixedPoint is the inverse of ConvertFixedPoint: it returns the
+// bytes of the decimal value in a Qm.n format and byte order, rounded to
+// the nearest multiple of the resolution, e.g. 0.1 as Q15 is 0ccd
+// (0.100006103515625). Values outside the range of the format are an
+// error.
+func (c *Converter) EncodeFixedPoint(value, format, order string) (*models.FixedPointResult, error) {
+	f, err := parseFixedPointFormat(format, order)
+	if err != nil {
+		return nil, err
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return nil, numberError(value, "decimal", true, err)
+	}
+	hexStr, err := convert.FixedPointToHex(v, f.intBits, f.fracBits, f.signed, f.order)
+	if err != nil {
+		return nil, err
+	}
+	b, _ := convert.HexToBytes(hexStr)
+	result := f.result()
+	result.Values = []models.FixedPointValue{f.value(b)}
+	return result, nil
+}
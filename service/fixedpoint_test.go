@@ -0,0 +1,106 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"hexview/convert"
+)
+
+func TestConvertFixedPoint(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		name   string
+		input  string
+		format string
+		order  string
+		want   []string
+	}{
+		{"Q15", "4000 8000 7fff", "Q15", "BE", []string{"0.5", "-1", "0.999969482421875"}},
+		{"Q15 LE", "00c0", "q15", "LE", []string{"-0.5"}},
+		{"Q0.15", "2000", "Q0.15", "BE", []string{"0.25"}},
+		{"Q31", "c0000000", "Q31", "BE", []string{"-0.5"}},
+		{"Q1.14", "6000", "Q1.14", "BE", []string{"1.5"}},
+		{"ARM Q1.15", "4000 8000", "Q1.15", "BE", []string{"0.5", "-1"}},
+		{"ARM Q1.31", "c0000000", "Q1.31", "BE", []string{"-0.5"}},
+		{"ARM Q8.8", "0180", "Q8.8", "BE", []string{"1.5"}},
+		{"UQ8.8", "ff80 0001", "UQ8.8", "BE", []string{"255.5", "0.00390625"}},
+		{"UQ16", "8000", "UQ16", "BE", []string{"0.5"}},
+		{"Q23 24-bit", "c00000", "Q23", "BE", []string{"-0.5"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := c.ConvertFixedPoint(tt.input, tt.format, tt.order)
+			if err != nil {
+				t.Fatalf("ConvertFixedPoint() error: %v", err)
+			}
+			if len(result.Values) != len(tt.want) {
+				t.Fatalf("ConvertFixedPoint() = %+v, want %v", result.Values, tt.want)
+			}
+			for i, v := range result.Values {
+				if v.Value != tt.want[i] {
+					t.Errorf("value %d = %+v, want %s", i, v, tt.want[i])
+				}
+			}
+		})
+	}
+
+	if arm, err := c.ConvertFixedPoint("4000", "Q1.15", "BE"); err != nil || arm.Format != "Q15" || arm.Size != 2 {
+		t.Errorf("ConvertFixedPoint(Q1.15) = %+v, %v, want Q15 in 2 bytes", arm, err)
+	}
+
+	result, _ := c.ConvertFixedPoint("0040 00", "Q15", "LE")
+	if result.Format != "Q15" || result.Size != 2 || result.Remainder != 1 ||
+		result.Min != "-1" || result.Max != "0.999969482421875" || result.Resolution != "0.000030517578125" {
+		t.Errorf("ConvertFixedPoint() = %+v", result)
+	}
+	if v := result.Values[0]; v.Hex != "0040" || v.Float != 0.5 {
+		t.Errorf("ConvertFixedPoint() value = %+v, want 0040 0.5", v)
+	}
+}
+
+func TestEncodeFixedPoint(t *testing.T) {
+	c := NewConverter()
+	result, err := c.EncodeFixedPoint("0.1", "Q15", "BE")
+	if err != nil {
+		t.Fatalf("EncodeFixedPoint() error: %v", err)
+	}
+	if v := result.Values[0]; v.Hex != "0ccd" || v.Value != "0.100006103515625" {
+		t.Errorf("EncodeFixedPoint(0.1) = %+v, want 0ccd 0.100006103515625", v)
+	}
+
+	result, _ = c.EncodeFixedPoint("-0.5", "Q31", "LE")
+	if v := result.Values[0]; v.Hex != "000000c0" || v.Value != "-0.5" {
+		t.Errorf("EncodeFixedPoint(-0.5) = %+v, want 000000c0", v)
+	}
+
+	if _, err := c.EncodeFixedPoint("1", "Q15", "BE"); !errors.Is(err, convert.ErrOverflow) {
+		t.Errorf("EncodeFixedPoint(1 as Q15) error = %v, want ErrOverflow", err)
+	}
+	if _, err := c.EncodeFixedPoint("0.5x", "Q15", "BE"); !errors.Is(err, ErrInvalidNumber) {
+		t.Errorf("EncodeFixedPoint(0.5x) error = %v, want ErrInvalidNumber", err)
+	}
+}
+
+func TestFixedPointFormatErrors(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		name   string
+		format string
+		order  string
+		want   error
+	}{
+		{"not a Q format", "int16", "BE", convert.ErrInvalidQFormat},
+		{"odd width", "Q14", "BE", convert.ErrInvalidQFormat},
+		{"too wide", "Q64", "BE", convert.ErrInvalidQFormat},
+		{"24-bit BADC", "Q23", "BADC", convert.ErrInvalidQFormat},
+		{"unknown order", "Q15", "XY", ErrUnsupportedType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.ConvertFixedPoint("0000", tt.format, tt.order); !errors.Is(err, tt.want) {
+				t.Errorf("ConvertFixedPoint(%s %s) error = %v, want %v", tt.format, tt.order, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"fmt"
+
+	"hexview/format"
+	"hexview/models"
+)
+
+// FormatBytes renders hex input in the style given by opts.
+func (c *Converter) FormatBytes(hexInput string, opts models.FormatOptions) (string, error) {
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return "", err
+	}
+	return formatBytes(data, opts)
+}
+
+// FormatStyles lists the styles accepted by FormatBytes.
+func (c *Converter) FormatStyles() []models.FormatStyle {
+	styles := format.Styles()
+	out := make([]models.FormatStyle, len(styles))
+	for i, s := range styles {
+		out[i] = models.FormatStyle{ID: s.ID, Name: s.Name, Example: s.Example}
+	}
+	return out
+}
+
+// FormatRange renders up to length bytes of an opened file starting at offset.
+func (s *FileService) FormatRange(id string, offset int64, length int, opts models.FormatOptions) (string, error) {
+	f, err := s.get(id)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data := f.buf.Bytes()
+	if offset < 0 || offset > int64(len(data)) {
+		return "", fmt.Errorf("offset %d out of range (size %d)", offset, len(data))
+	}
+	if length < 0 {
+		return "", fmt.Errorf("invalid length: %d", length)
+	}
+
+	end := min(offset+int64(length), int64(len(data)))
+	return formatBytes(data[offset:end], opts)
+}
+
+// formatBytes renders data with the format package.
+func formatBytes(data []byte, opts models.FormatOptions) (string, error) {
+	return format.Bytes(data, format.Options{
+		Style:        opts.Style,
+		Uppercase:    opts.Uppercase,
+		BytesPerLine: opts.BytesPerLine,
+		Name:         opts.Name,
+	})
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"testing"
+
+	"hexview/models"
+)
+
+func TestConverter_FormatBytes(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		input   string
+		opts    models.FormatOptions
+		want    string
+		wantErr bool
+	}{
+		{"0x0102ff", models.FormatOptions{Style: "c-array", Name: "buf"}, "const uint8_t buf[3] = { 0x01, 0x02, 0xff };", false},
+		{"01 02 ff", models.FormatOptions{Style: "hex-0x", Uppercase: true}, "0x01 0x02 0xFF", false},
+		{"0102ff", models.FormatOptions{Style: "decimal"}, "1, 2, 255", false},
+		{"zz", models.FormatOptions{Style: "hex"}, "", true},
+		{"01", models.FormatOptions{Style: "unknown"}, "", true},
+	}
+	for _, tt := range tests {
+		got, err := c.FormatBytes(tt.input, tt.opts)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("FormatBytes(%q, %s) error = %v, wantErr %v", tt.input, tt.opts.Style, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("FormatBytes(%q, %s) = %q, want %q", tt.input, tt.opts.Style, got, tt.want)
+		}
+	}
+
+	if len(c.FormatStyles()) == 0 {
+		t.Error("Expected format styles")
+	}
+}
+
+func TestFileService_FormatRange(t *testing.T) {
+	files := NewFileService()
+	info, err := files.Open(writeTempFile(t, "fmt.bin", []byte{0x10, 0x20, 0x30, 0x40}))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+
+	got, err := files.FormatRange(info.ID, 1, 10, models.FormatOptions{Style: "go-slice"})
+	if err != nil {
+		t.Fatalf("FormatRange() error: %v", err)
+	}
+	if want := "[]byte{0x20, 0x30, 0x40}"; got != want {
+		t.Errorf("FormatRange() = %q, want %q", got, want)
+	}
+	if _, err := files.FormatRange(info.ID, 5, 1, models.FormatOptions{Style: "hex"}); err == nil {
+		t.Error("Expected error for offset past the end")
+	}
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"unicode/utf8"
+)
+
+// FuzzConvertMode feeds arbitrary input to every conversion mode and to
+// ValidateInput. None of them may panic, and located input errors must lie
+// within the input.
+func FuzzConvertMode(f *testing.F) {
+	for _, seed := range []string{"0x4248 0x0000", "d1000 d2000", "-42", "1,5", "1e309", "0b1010", "ff ff", "\xff\xfe"} {
+		f.Add(seed)
+	}
+	c := NewConverter()
+	modes := []string{ModeHex, ModeInt, ModeIntAuto, ModeBinary, ModeFloat, ModeModbus}
+	f.Fuzz(func(t *testing.T, input string) {
+		for _, mode := range modes {
+			if _, _, err := c.ConvertMode(context.Background(), mode, input, ""); err != nil {
+				if d := ErrorDetails(err); d != nil && d.Position+d.Length > utf8.RuneCountInString(input) {
+					t.Errorf("%s error %q at %d+%d outside of %q", mode, d.Message, d.Position, d.Length, input)
+				}
+			}
+			if _, err := c.ValidateInput(input, mode); err != nil {
+				t.Errorf("ValidateInput(%q, %s) error: %v", input, mode, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash/crc32"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+// Hash computes the MD5, SHA-1, SHA-256, SHA-512 and CRC-32 digests of hex
+// input, e.g. to verify a firmware blob against a published hash.
+func (c *Converter) Hash(hexInput string) (*models.HashResult, error) {
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+	return c.HashBytes(data)
+}
+
+// HashBytes computes the digests of raw bytes like Hash.
+func (c *Converter) HashBytes(data []byte) (*models.HashResult, error) {
+	if len(data) == 0 {
+		return nil, errEmptyInput()
+	}
+	md5Sum := md5.Sum(data)
+	sha1Sum := sha1.Sum(data)
+	sha256Sum := sha256.Sum256(data)
+	sha512Sum := sha512.Sum512(data)
+	return &models.HashResult{
+		Length: len(data),
+		MD5:    convert.BytesToHex(md5Sum[:]),
+		SHA1:   convert.BytesToHex(sha1Sum[:]),
+		SHA256: convert.BytesToHex(sha256Sum[:]),
+		SHA512: convert.BytesToHex(sha512Sum[:]),
+		CRC32:  fmt.Sprintf("%08x", crc32.ChecksumIEEE(data)),
+	}, nil
+}
@@ -0,0 +1,39 @@
+package service
+
+import "testing"
+
+func TestHash(t *testing.T) {
+	c := NewConverter()
+	r, err := c.Hash("61 62 63") // "abc"
+	if err != nil {
+		t.Fatalf("Hash() error: %v", err)
+	}
+	want := map[string][2]string{
+		"MD5":    {r.MD5, "900150983cd24fb0d6963f7d28e17f72"},
+		"SHA1":   {r.SHA1, "a9993e364706816aba3e25717850c26c9cd0d89d"},
+		"SHA256": {r.SHA256, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		"SHA512": {r.SHA512, "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f"},
+		"CRC32":  {r.CRC32, "352441c2"},
+	}
+	for name, v := range want {
+		if v[0] != v[1] {
+			t.Errorf("%s = %s, want %s", name, v[0], v[1])
+		}
+	}
+	if r.Length != 3 {
+		t.Errorf("Length = %d, want 3", r.Length)
+	}
+}
+
+func TestHash_Errors(t *testing.T) {
+	c := NewConverter()
+	if _, err := c.Hash(""); err == nil {
+		t.Error("Expected an error for empty input")
+	}
+	if _, err := c.Hash("zz"); err == nil {
+		t.Error("Expected an error for invalid hex")
+	}
+	if _, err := c.HashBytes(nil); err == nil {
+		t.Error("Expected an error for no bytes")
+	}
+}
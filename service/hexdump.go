@@ -0,0 +1,240 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"hexview/convert"
+	"hexview/models"
+	"hexview/sparse"
+	"hexview/textdump"
+)
+
+// Hex dump limits
+const (
+	DefaultHexDumpWidth = 16   // bytes per row
+	MaxHexDumpWidth     = 64   // bytes per row
+	MaxHexDumpRows      = 4096 // rows returned at once; larger dumps are paged with StartRow
+)
+
+// HexDump renders hex or binary input as a hex editor dump with an offset
+// column, 16 hex cells per row and an ASCII gutter. Large inputs are
+// returned a page of rows at a time, selected with opts.StartRow and
+// opts.MaxRows. Text dumps of other tools (format "dump") are rendered at
+// their addresses with their gaps, like HexDumpSparse.
+func (c *Converter) HexDump(input string, opts models.HexDumpOptions) (*models.HexDump, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, errEmptyInput()
+	}
+
+	var data []byte
+	var err error
+	switch opts.Format {
+	case "", "hex":
+		data, err = convert.HexToBytes(input)
+		if err != nil {
+			err = fmt.Errorf("invalid hex input: %w", err)
+		}
+	case "binary":
+		data, err = convert.ParseBinary(input)
+		if err != nil {
+			err = fmt.Errorf("invalid binary input: %w", err)
+		}
+	case "dump":
+		d, err := textdump.Parse(input)
+		if err != nil {
+			return nil, err
+		}
+		buf, err := d.Buffer()
+		if err != nil {
+			return nil, err
+		}
+		return HexDumpSparse(buf, opts)
+	default:
+		return nil, errUnsupportedType("input", opts.Format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return HexDumpBytes(data, opts)
+}
+
+// HexDumpBytes renders data as a hex dump like HexDump; opts.Format is
+// ignored.
+func HexDumpBytes(data []byte, opts models.HexDumpOptions) (*models.HexDump, error) {
+	width, maxRows, err := hexDumpLayout(opts)
+	if err != nil {
+		return nil, err
+	}
+	start := min(int64(opts.StartRow)*int64(width), int64(len(data)))
+	end := min(start+int64(maxRows*width), int64(len(data)))
+	return hexDumpPage(data[start:end], int64(len(data)), width, opts), nil
+}
+
+// HexDumpSparse renders a sparse buffer as a hex dump like HexDumpBytes.
+// Rows start at the first byte of the buffer and addresses are those of
+// the buffer plus opts.BaseAddress. Bytes in a gap are "??" cells, and the
+// rows between two segments that hold no byte are collapsed into one gap
+// row, so a dump spanning gigabytes has rows only for its bytes.
+func HexDumpSparse(buf *sparse.Buffer, opts models.HexDumpOptions) (*models.HexDump, error) {
+	width, maxRows, err := hexDumpLayout(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Runs of rows holding bytes, as row indexes from the first byte
+	type block struct{ first, last uint64 }
+	var blocks []block
+	start, w := buf.Start(), uint64(width)
+	for _, s := range buf.Segments() {
+		first, last := (s.Address-start)/w, (s.End()-1-start)/w
+		if n := len(blocks); n > 0 && first <= blocks[n-1].last+1 {
+			blocks[n-1].last = last
+			continue
+		}
+		blocks = append(blocks, block{first, last})
+	}
+
+	dump := &models.HexDump{
+		Length:   int64(buf.End() - start),
+		Width:    width,
+		StartRow: opts.StartRow,
+		Gaps:     max(len(blocks)-1, 0),
+		Rows:     []models.HexDumpRow{},
+	}
+	row := 0 // index of the next row
+	for i, b := range blocks {
+		if i > 0 {
+			if row >= opts.StartRow && len(dump.Rows) < maxRows {
+				offset := (blocks[i-1].last + 1) * w
+				dump.Rows = append(dump.Rows, models.HexDumpRow{
+					Offset:  int64(offset),
+					Address: fmt.Sprintf("%08x", uint64(opts.BaseAddress)+start+offset),
+					Gap:     int64((b.first - blocks[i-1].last - 1) * w),
+				})
+			}
+			row++
+		}
+		n := int(b.last - b.first + 1)
+		for k := max(opts.StartRow-row, 0); k < n && len(dump.Rows) < maxRows; k++ {
+			offset := (b.first + uint64(k)) * w
+			data, valid := buf.Read(start+offset, int(min(w, buf.End()-start-offset)))
+			r := models.HexDumpRow{
+				Offset:  int64(offset),
+				Address: fmt.Sprintf("%08x", uint64(opts.BaseAddress)+start+offset),
+				Cells:   make([]string, len(data)),
+			}
+			ascii := []byte(bytesToASCII(data))
+			for j, ok := range valid {
+				if ok {
+					r.Cells[j] = convert.BytesToHex(data[j : j+1])
+				} else {
+					r.Cells[j], ascii[j] = "??", ' '
+				}
+			}
+			r.ASCII = string(ascii)
+			dump.Rows = append(dump.Rows, r)
+		}
+		row += n
+	}
+	dump.TotalRows = row
+	return dump, nil
+}
+
+// hexDumpLayout returns the bytes per row and the rows per page of opts.
+func hexDumpLayout(opts models.HexDumpOptions) (width, maxRows int, err error) {
+	width = opts.Width
+	if width == 0 {
+		width = DefaultHexDumpWidth
+	}
+	if width < 1 || width > MaxHexDumpWidth {
+		return 0, 0, fmt.Errorf("%w: width %d, want 1 to %d", ErrOutOfRange, width, MaxHexDumpWidth)
+	}
+	if opts.StartRow < 0 {
+		return 0, 0, fmt.Errorf("%w: start row %d", ErrOutOfRange, opts.StartRow)
+	}
+	maxRows = opts.MaxRows
+	if maxRows <= 0 || maxRows > MaxHexDumpRows {
+		maxRows = MaxHexDumpRows
+	}
+	return width, maxRows, nil
+}
+
+// hexDumpPage renders the rows of page, the bytes of a blob of length bytes
+// starting at row opts.StartRow.
+func hexDumpPage(page []byte, length int64, width int, opts models.HexDumpOptions) *models.HexDump {
+	dump := &models.HexDump{
+		Length:    length,
+		Width:     width,
+		TotalRows: int((length + int64(width) - 1) / int64(width)),
+		StartRow:  opts.StartRow,
+		Rows:      make([]models.HexDumpRow, 0, (len(page)+width-1)/width),
+	}
+	first := int64(opts.StartRow) * int64(width)
+	for start := 0; start < len(page); start += width {
+		chunk := page[start:min(start+width, len(page))]
+		cells := make([]string, len(chunk))
+		for i, b := range chunk {
+			cells[i] = convert.BytesToHex([]byte{b})
+		}
+		offset := first + int64(start)
+		dump.Rows = append(dump.Rows, models.HexDumpRow{
+			Offset:  offset,
+			Address: fmt.Sprintf("%08x", opts.BaseAddress+offset),
+			Cells:   cells,
+			ASCII:   bytesToASCII(chunk),
+		})
+	}
+	return dump
+}
+
+// ParseDump reads the bytes back from a text hex dump of xxd, hexdump -C,
+// od, Wireshark (Copy as Hex Dump) or a Windows debugger (db), with the
+// offset or address of its first byte. Lines the dump skips and bytes the
+// debugger could not read are reported as gaps and filled with zeros. The
+// C arrays and escaped strings Wireshark exports are read too; the bytes of
+// several arrays are concatenated and listed as packets.
+func (c *Converter) ParseDump(text string) (*models.ParsedDump, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, errEmptyInput()
+	}
+	d, err := textdump.Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	result := &models.ParsedDump{
+		Dialect: d.Dialect,
+		Base:    fmt.Sprintf("0x%x", d.Base),
+		Hex:     convert.BytesToHex(d.Data),
+		Length:  len(d.Data),
+		Lines:   d.Lines,
+	}
+	for _, g := range d.Gaps {
+		result.Gaps = append(result.Gaps, models.DumpRange{
+			Offset:  int(g.Offset - d.Base),
+			Address: fmt.Sprintf("0x%x", g.Offset),
+			Length:  int(g.Length),
+		})
+	}
+	buf, err := d.Buffer()
+	if err != nil {
+		return nil, err
+	}
+	result.Segments = []models.DumpRange{}
+	for _, seg := range buf.Segments() {
+		result.Segments = append(result.Segments, models.DumpRange{
+			Offset:  int(seg.Address - d.Base),
+			Address: fmt.Sprintf("0x%x", seg.Address),
+			Length:  len(seg.Data),
+		})
+	}
+	for _, p := range d.Packets {
+		result.Packets = append(result.Packets, models.DumpPacket{
+			Name:   p.Name,
+			Title:  p.Title,
+			Offset: int(p.Offset),
+			Length: int(p.Length),
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"hexview/convert"
+	"hexview/models"
+	"hexview/sparse"
+	"hexview/textdump"
+)
+
+func TestHexDump(t *testing.T) {
+	c := NewConverter()
+	input := "48 65 6c 6c 6f 2c 20 77 6f 72 6c 64 21 0a 00 ff 01 02"
+	dump, err := c.HexDump(input, models.HexDumpOptions{})
+	if err != nil {
+		t.Fatalf("HexDump() error: %v", err)
+	}
+	if dump.Length != 18 || dump.Width != 16 || dump.TotalRows != 2 || len(dump.Rows) != 2 {
+		t.Fatalf("HexDump() = %+v", dump)
+	}
+	first, last := dump.Rows[0], dump.Rows[1]
+	if first.Address != "00000000" || first.ASCII != "Hello, world!..." || strings.Join(first.Cells, " ") != "48 65 6c 6c 6f 2c 20 77 6f 72 6c 64 21 0a 00 ff" {
+		t.Errorf("row 0 = %+v", first)
+	}
+	if last.Offset != 16 || last.Address != "00000010" || len(last.Cells) != 2 || last.ASCII != ".." {
+		t.Errorf("row 1 = %+v", last)
+	}
+}
+
+func TestHexDump_Options(t *testing.T) {
+	c := NewConverter()
+	input := strings.Repeat("00", 100)
+
+	dump, err := c.HexDump(input, models.HexDumpOptions{Width: 8, BaseAddress: 0x08000000, StartRow: 2, MaxRows: 3})
+	if err != nil {
+		t.Fatalf("HexDump() error: %v", err)
+	}
+	if dump.TotalRows != 13 || dump.StartRow != 2 || len(dump.Rows) != 3 {
+		t.Fatalf("HexDump() = %+v", dump)
+	}
+	if r := dump.Rows[0]; r.Offset != 16 || r.Address != "08000010" {
+		t.Errorf("row 2 = %+v", r)
+	}
+
+	dump, err = c.HexDump(input, models.HexDumpOptions{StartRow: 50})
+	if err != nil || len(dump.Rows) != 0 {
+		t.Errorf("HexDump() past the end = %+v, %v", dump, err)
+	}
+
+	dump, err = c.HexDump("01000001 00000000", models.HexDumpOptions{Format: "binary"})
+	if err != nil {
+		t.Fatalf("HexDump(binary) error: %v", err)
+	}
+	if r := dump.Rows[0]; strings.Join(r.Cells, " ") != "41 00" || r.ASCII != "A." {
+		t.Errorf("binary row = %+v", r)
+	}
+}
+
+func TestHexDump_Errors(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		name  string
+		input string
+		opts  models.HexDumpOptions
+		want  error
+	}{
+		{"width", "00", models.HexDumpOptions{Width: 65}, ErrOutOfRange},
+		{"start row", "00", models.HexDumpOptions{StartRow: -1}, ErrOutOfRange},
+		{"format", "00", models.HexDumpOptions{Format: "octal"}, ErrUnsupportedType},
+	}
+	for _, tt := range tests {
+		if _, err := c.HexDump(tt.input, tt.opts); !errors.Is(err, tt.want) {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	if _, err := c.HexDump(" ", models.HexDumpOptions{}); err == nil {
+		t.Error("Expected error for empty input")
+	}
+	if _, err := c.HexDump("zz", models.HexDumpOptions{}); err == nil {
+		t.Error("Expected error for invalid hex")
+	}
+}
+
+func TestHexDumpSparse(t *testing.T) {
+	var buf sparse.Buffer
+	_ = buf.Add(0x08000000, []byte("Hello, world!\n"))
+	_ = buf.Add(0x08000012, []byte{0xaa, 0xbb})
+	_ = buf.Add(0x08100004, []byte{0x01, 0x02})
+	dump, err := HexDumpSparse(&buf, models.HexDumpOptions{Width: 8})
+	if err != nil {
+		t.Fatalf("HexDumpSparse() error: %v", err)
+	}
+	if dump.Length != 0x100006 || dump.TotalRows != 5 || dump.Gaps != 1 || len(dump.Rows) != 5 {
+		t.Fatalf("HexDumpSparse() = %+v", dump)
+	}
+	if r := dump.Rows[1]; r.Address != "08000008" || strings.Join(r.Cells, " ") != "6f 72 6c 64 21 0a ?? ??" || r.ASCII != "orld!.  " {
+		t.Errorf("row 1 = %+v", r)
+	}
+	if r := dump.Rows[2]; r.Offset != 16 || strings.Join(r.Cells, " ") != "?? ?? aa bb ?? ?? ?? ??" {
+		t.Errorf("row 2 = %+v", r)
+	}
+	if r := dump.Rows[3]; r.Address != "08000018" || r.Gap != 0xfffe8 || r.Cells != nil {
+		t.Errorf("gap row = %+v", r)
+	}
+	if r := dump.Rows[4]; r.Address != "08100000" || strings.Join(r.Cells, " ") != "?? ?? ?? ?? 01 02" {
+		t.Errorf("last row = %+v", r)
+	}
+
+	dump, _ = HexDumpSparse(&buf, models.HexDumpOptions{Width: 8, StartRow: 3, MaxRows: 1})
+	if len(dump.Rows) != 1 || dump.Rows[0].Gap == 0 {
+		t.Errorf("HexDumpSparse(row 3) = %+v", dump.Rows)
+	}
+	dump, _ = HexDumpSparse(&sparse.Buffer{}, models.HexDumpOptions{})
+	if dump.TotalRows != 0 || len(dump.Rows) != 0 {
+		t.Errorf("HexDumpSparse(empty) = %+v", dump)
+	}
+}
+
+func TestHexDump_TextDump(t *testing.T) {
+	c := NewConverter()
+	text := "00001000: 4865 6c6c 6f0a  Hello.\n00008000: 0102  ..\n"
+	dump, err := c.HexDump(text, models.HexDumpOptions{Format: "dump"})
+	if err != nil {
+		t.Fatalf("HexDump(dump) error: %v", err)
+	}
+	if dump.TotalRows != 3 || dump.Rows[0].Address != "00001000" || dump.Rows[1].Gap != 0x6ff0 || dump.Rows[2].ASCII != ".." {
+		t.Errorf("HexDump(dump) = %+v", dump)
+	}
+	if _, err := c.HexDump("48656c6c6f", models.HexDumpOptions{Format: "dump"}); !errors.Is(err, textdump.ErrInvalidDump) {
+		t.Errorf("HexDump(hex as dump) error = %v, want ErrInvalidDump", err)
+	}
+}
+
+func TestParseDump(t *testing.T) {
+	c := NewConverter()
+	text := "0:000> db 20001000\n" +
+		"20001000  48 65 6c 6c 6f ?? ?? ??-?? ?? ?? ?? ?? ?? ?? ??  Hello???????????\n"
+	result, err := c.ParseDump(text)
+	if err != nil {
+		t.Fatalf("ParseDump() error: %v", err)
+	}
+	if result.Dialect != "windbg" || result.Base != "0x20001000" || result.Length != 16 || !strings.HasPrefix(result.Hex, "48656c6c6f000000") {
+		t.Errorf("ParseDump() = %+v", result)
+	}
+	if len(result.Gaps) != 1 || result.Gaps[0] != (models.DumpRange{Offset: 5, Address: "0x20001005", Length: 11}) {
+		t.Errorf("ParseDump() gaps = %+v", result.Gaps)
+	}
+	if len(result.Segments) != 1 || result.Segments[0] != (models.DumpRange{Offset: 0, Address: "0x20001000", Length: 5}) {
+		t.Errorf("ParseDump() segments = %+v", result.Segments)
+	}
+
+	result, err = c.ParseDump("/* Frame (2 bytes) */\nstatic const unsigned char pkt1[2] = {\n0x01, 0x02 /* .. */\n};\n" +
+		"static const unsigned char pkt2[1] = {\n0x03 /* . */\n};\n")
+	if err != nil {
+		t.Fatalf("ParseDump(C arrays) error: %v", err)
+	}
+	if result.Dialect != "c-array" || result.Hex != "010203" || len(result.Packets) != 2 ||
+		result.Packets[1] != (models.DumpPacket{Name: "pkt2", Offset: 2, Length: 1}) {
+		t.Errorf("ParseDump(C arrays) = %+v", result)
+	}
+
+	if _, err := c.ParseDump(" \n"); !errors.Is(err, convert.ErrEmptyInput) {
+		t.Errorf("ParseDump(blank) error = %v, want ErrEmptyInput", err)
+	}
+	if _, err := c.ParseDump("48656c6c6f"); !errors.Is(err, textdump.ErrInvalidDump) {
+		t.Errorf("ParseDump(hex) error = %v, want ErrInvalidDump", err)
+	}
+}
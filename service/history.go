@@ -0,0 +1,245 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"hexview/models"
+)
+
+// Conversion modes recorded in the history
+const (
+	ModeHex     = "hex"
+	ModeInt     = "int"
+	ModeIntAuto = "intAuto"
+	ModeBinary  = "binary"
+	ModeFloat   = "float"
+	ModeModbus  = "modbus"
+	ModeEncoded = "encoded" // quoted-printable, percent-encoded, base64 or base32 bytes
+)
+
+// validMode reports whether mode is one of the conversion modes.
+func validMode(mode string) bool {
+	switch mode {
+	case ModeHex, ModeInt, ModeIntAuto, ModeBinary, ModeFloat, ModeModbus, ModeEncoded:
+		return true
+	}
+	return false
+}
+
+const (
+	// MaxHistoryEntries is the number of entries kept; older ones are dropped.
+	MaxHistoryEntries = 1000
+
+	// historyMergeWindow is the time within which a conversion in the same
+	// mode replaces the previous entry, so typing a value records it once.
+	historyMergeWindow = 3 * time.Second
+)
+
+// ErrHistoryEntryNotFound indicates an unknown history entry ID was used
+var ErrHistoryEntryNotFound = errors.New("history entry not found")
+
+// HistoryService records conversions in a JSON file and searches them.
+// With an empty path the history is kept in memory only.
+type HistoryService struct {
+	mu      sync.Mutex
+	path    string
+	entries []models.HistoryEntry // oldest first
+	nextID  int64
+	now     func() time.Time
+}
+
+// historyFile is the on-disk format of the history.
+type historyFile struct {
+	NextID  int64                 `json:"nextId"`
+	Entries []models.HistoryEntry `json:"entries"`
+}
+
+// NewHistoryService creates an empty HistoryService backed by the file at path.
+// Call Load to read the file.
+func NewHistoryService(path string) *HistoryService {
+	return &HistoryService{path: path, nextID: 1, now: time.Now}
+}
+
+// Load reads the history file. A missing file leaves the history empty.
+func (s *HistoryService) Load() error {
+	if s.path == "" {
+		return nil
+	}
+	var f historyFile
+	if err := loadJSON(s.path, &f); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = f.Entries
+	s.nextID = max(f.NextID, 1)
+	return nil
+}
+
+// RecordConversion adds a conversion result to the history.
+func (s *HistoryService) RecordConversion(mode, input, typ string, r *models.ConversionResult) error {
+	return s.record(conversionEntry(mode, input, typ, r))
+}
+
+// RecordModbus adds a Modbus conversion result to the history.
+func (s *HistoryService) RecordModbus(input string, r *models.ModbusResult) error {
+	return s.record(modbusEntry(input, r))
+}
+
+// record stores an entry, replacing the previous one if it was recorded in the
+// same mode within historyMergeWindow.
+func (s *HistoryService) record(e models.HistoryEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	e.Timestamp = now.Format(time.RFC3339)
+	if n := len(s.entries); n > 0 && mergesWith(s.entries[n-1], e, now) {
+		e.ID = s.entries[n-1].ID
+		s.entries[n-1] = e
+		return s.save()
+	}
+
+	e.ID = s.nextID
+	s.nextID++
+	s.entries = append(s.entries, e)
+	if len(s.entries) > MaxHistoryEntries {
+		s.entries = slices.Delete(s.entries, 0, len(s.entries)-MaxHistoryEntries)
+	}
+	return s.save()
+}
+
+// Search returns up to limit entries, newest first, whose input, summary or
+// values contain query (case-insensitive). An empty query matches all entries;
+// a limit <= 0 means no limit.
+func (s *HistoryService) Search(query string, limit int) []models.HistoryEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	result := make([]models.HistoryEntry, 0)
+	for i := len(s.entries) - 1; i >= 0; i-- {
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+		if e := s.entries[i]; query == "" || historyMatch(e, query) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Delete removes an entry.
+func (s *HistoryService) Delete(id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.entries, func(e models.HistoryEntry) bool { return e.ID == id })
+	if i < 0 {
+		return fmt.Errorf("%w: %d", ErrHistoryEntryNotFound, id)
+	}
+	s.entries = slices.Delete(s.entries, i, i+1)
+	return s.save()
+}
+
+// Clear removes all entries.
+func (s *HistoryService) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = nil
+	return s.save()
+}
+
+// save writes the history file. Must be called with s.mu held.
+func (s *HistoryService) save() error {
+	if s.path == "" {
+		return nil
+	}
+	return saveJSON(s.path, historyFile{NextID: s.nextID, Entries: s.entries})
+}
+
+// mergesWith reports whether e, recorded at now, replaces the entry last
+// because it is in the same mode and within historyMergeWindow.
+func mergesWith(last, e models.HistoryEntry, now time.Time) bool {
+	t, err := time.Parse(time.RFC3339, last.Timestamp)
+	return err == nil && last.Mode == e.Mode && now.Sub(t) < historyMergeWindow
+}
+
+// conversionEntry returns the history entry of a conversion result.
+func conversionEntry(mode, input, typ string, r *models.ConversionResult) models.HistoryEntry {
+	summary, values := conversionSummary(r)
+	return models.HistoryEntry{Mode: mode, Input: input, Type: typ, Summary: summary, Values: values}
+}
+
+// modbusEntry returns the history entry of a Modbus conversion result.
+func modbusEntry(input string, r *models.ModbusResult) models.HistoryEntry {
+	var values []string
+	for _, reg := range r.Registers {
+		values = appendUnique(values, strconv.FormatUint(uint64(reg.Unsigned), 10))
+		values = appendUnique(values, strconv.Itoa(int(reg.Signed)))
+	}
+	summary := fmt.Sprintf("%d registers: %s", len(r.Registers), r.RawHex)
+	return models.HistoryEntry{Mode: ModeModbus, Input: input, Summary: summary, Values: values}
+}
+
+// historyMatch reports whether an entry contains the lower case query.
+func historyMatch(e models.HistoryEntry, query string) bool {
+	if strings.Contains(strings.ToLower(e.Input), query) || strings.Contains(strings.ToLower(e.Summary), query) {
+		return true
+	}
+	return slices.ContainsFunc(e.Values, func(v string) bool { return strings.Contains(strings.ToLower(v), query) })
+}
+
+// conversionSummary describes a conversion result by its bytes and the
+// big-endian values of the type matching its length, and lists all its
+// distinct values.
+func conversionSummary(r *models.ConversionResult) (string, []string) {
+	var values []string
+	for _, row := range conversionTables(r)[0].Rows {
+		values = appendUnique(values, row[2])
+	}
+
+	parts := []string{"0x" + r.Bytes}
+	switch n := len(r.Bytes) / 2; {
+	case n >= 8:
+		parts = appendValue(parts, "uint64", r.Uint64BE)
+		parts = appendValue(parts, "int64", r.Int64BE)
+		parts = appendValue(parts, "float64", r.Float64BE)
+	case n >= 4:
+		parts = appendValue(parts, "uint32", r.Uint32BE)
+		parts = appendValue(parts, "int32", r.Int32BE)
+		parts = appendValue(parts, "float32", r.Float32BE)
+	case n >= 2:
+		parts = appendValue(parts, "uint16", r.Uint16BE)
+		parts = appendValue(parts, "int16", r.Int16BE)
+	default:
+		parts = appendValue(parts, "uint8", r.Uint8BE)
+		parts = appendValue(parts, "int8", r.Int8BE)
+		parts = appendValue(parts, "float32", r.Float32BE)
+		parts = appendValue(parts, "float64", r.Float64BE)
+	}
+	return strings.Join(parts, ", "), values
+}
+
+// appendValue appends "name value" to parts if v is set.
+func appendValue[T any](parts []string, name string, v *T) []string {
+	if v == nil {
+		return parts
+	}
+	return append(parts, fmt.Sprintf("%s %v", name, *v))
+}
+
+// appendUnique appends v to list if it is not already contained.
+func appendUnique(list []string, v string) []string {
+	if slices.Contains(list, v) {
+		return list
+	}
+	return append(list, v)
+}
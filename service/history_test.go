@@ -0,0 +1,120 @@
+package service
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock returns a now function advanced by step on every call.
+func fakeClock(step time.Duration) func() time.Time {
+	t := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		t = t.Add(step)
+		return t
+	}
+}
+
+func TestHistoryService_RecordAndSearch(t *testing.T) {
+	c := NewConverter()
+	path := filepath.Join(t.TempDir(), "history.json")
+	h := NewHistoryService(path)
+	h.now = fakeClock(time.Minute)
+
+	r1, _ := c.ConvertHex("42480000")
+	r2, _ := c.ConvertInt("513", "uint16")
+	m, _ := c.ConvertModbusRegisters("0x0010 0x0020")
+	for _, err := range []error{
+		h.RecordConversion(ModeHex, "42480000", "", r1),
+		h.RecordConversion(ModeInt, "513", "uint16", r2),
+		h.RecordModbus("0x0010 0x0020", m),
+	} {
+		if err != nil {
+			t.Fatalf("Record error: %v", err)
+		}
+	}
+
+	all := h.Search("", 0)
+	if len(all) != 3 || all[0].Mode != ModeModbus || all[2].Mode != ModeHex {
+		t.Fatalf("Expected 3 entries newest first, got %+v", all)
+	}
+	if !strings.Contains(all[2].Summary, "float32 50") {
+		t.Errorf("Unexpected summary: %q", all[2].Summary)
+	}
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"513", 1},        // input
+		{"1112014848", 1}, // value of the hex conversion
+		{"FLOAT32", 1},    // summary, case-insensitive
+		{"32", 2},         // register value and hex value
+		{"nothing", 0},
+	}
+	for _, tt := range tests {
+		if got := h.Search(tt.query, 0); len(got) != tt.want {
+			t.Errorf("Search(%q) = %d entries, want %d", tt.query, len(got), tt.want)
+		}
+	}
+	if got := h.Search("", 1); len(got) != 1 {
+		t.Errorf("Search with limit 1 returned %d entries", len(got))
+	}
+
+	reloaded := NewHistoryService(path)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if got := reloaded.Search("", 0); len(got) != 3 || got[0].ID != all[0].ID {
+		t.Errorf("Reloaded history differs: %+v", got)
+	}
+}
+
+func TestHistoryService_MergeWhileTyping(t *testing.T) {
+	c := NewConverter()
+	h := NewHistoryService("")
+	h.now = fakeClock(time.Second)
+
+	for _, input := range []string{"1", "12", "123"} {
+		r, _ := c.ConvertIntAuto(input)
+		h.RecordConversion(ModeIntAuto, input, "", r)
+	}
+	got := h.Search("", 0)
+	if len(got) != 1 || got[0].Input != "123" {
+		t.Errorf("Expected a single merged entry, got %+v", got)
+	}
+
+	h.now = fakeClock(time.Hour)
+	r, _ := c.ConvertIntAuto("7")
+	h.RecordConversion(ModeIntAuto, "7", "", r)
+	if got := h.Search("", 0); len(got) != 2 {
+		t.Errorf("Expected a new entry after the merge window, got %d", len(got))
+	}
+}
+
+func TestHistoryService_DeleteClearTrim(t *testing.T) {
+	c := NewConverter()
+	h := NewHistoryService("")
+	h.now = fakeClock(time.Minute)
+	r, _ := c.ConvertHex("01")
+
+	for i := 0; i < MaxHistoryEntries+5; i++ {
+		h.RecordConversion(ModeHex, "01", "", r)
+	}
+	all := h.Search("", 0)
+	if len(all) != MaxHistoryEntries {
+		t.Fatalf("Expected %d entries, got %d", MaxHistoryEntries, len(all))
+	}
+
+	if err := h.Delete(all[0].ID); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if err := h.Delete(all[0].ID); !errors.Is(err, ErrHistoryEntryNotFound) {
+		t.Errorf("Expected ErrHistoryEntryNotFound, got %v", err)
+	}
+	if err := h.Clear(); err != nil || len(h.Search("", 0)) != 0 {
+		t.Errorf("Clear() failed: %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+// ErrInputTooLarge indicates an input longer than the maximum input size
+var ErrInputTooLarge = errors.New("input too large")
+
+// MaxPreviewSize is the largest preview size; truncated inputs never convert
+// more bytes than this.
+const MaxPreviewSize = 64 << 10
+
+// CheckInputSize returns an input_too_large error if input is longer than
+// limit bytes. A limit of 0 disables the check.
+func CheckInputSize(input string, limit int) error {
+	return CheckSize(len(input), limit)
+}
+
+// CheckSize returns an input_too_large error if n, the length of an input
+// in bytes, is larger than limit. A limit of 0 disables the check.
+func CheckSize(n, limit int) error {
+	if limit <= 0 || n <= limit {
+		return nil
+	}
+	return convert.NewInputError(convert.CodeInputTooLarge, ErrInputTooLarge,
+		fmt.Sprintf("input too large: %d bytes (maximum %d)", n, limit))
+}
+
+// ConvertModeLimited converts input like ConvertMode within the input limits
+// of settings. Input longer than MaxInputSize is rejected, unless
+// TruncateInput is set and the mode is hex or binary: then only the first
+// PreviewSize bytes are converted and the result reports the total length.
+func (c *Converter) ConvertModeLimited(ctx context.Context, mode, input, typ string, settings models.Settings) (*models.ConversionResult, *models.ModbusResult, error) {
+	err := CheckInputSize(input, settings.MaxInputSize)
+	if err == nil {
+		return c.ConvertMode(ctx, mode, input, typ)
+	}
+
+	var result *models.ConversionResult
+	switch {
+	case settings.TruncateInput && mode == ModeHex:
+		result, err = c.ConvertHexPreview(input, settings.PreviewSize)
+	case settings.TruncateInput && mode == ModeBinary:
+		result, err = c.ConvertBinaryPreview(input, settings.PreviewSize)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, nil, nil
+}
+
+// ConvertBytesLimited converts bytes, e.g. a range of an opened file, like
+// ConvertBytes within the input limits of settings. More than MaxInputSize
+// bytes are rejected, unless TruncateInput is set: then only the first
+// PreviewSize bytes are converted and the result reports the total length.
+func (c *Converter) ConvertBytesLimited(data []byte, settings models.Settings) (*models.ConversionResult, error) {
+	if len(data) == 0 {
+		return nil, errEmptyInput()
+	}
+	if err := CheckSize(len(data), settings.MaxInputSize); err == nil {
+		return previewResult(data, 0, canonicalHex), nil
+	} else if !settings.TruncateInput {
+		return nil, err
+	}
+	return previewResult(data, settings.PreviewSize, canonicalHex), nil
+}
+
+// ConvertHexPreview converts like ConvertHex but only the first n bytes of
+// the input. Longer inputs are marked as truncated with their total length.
+func (c *Converter) ConvertHexPreview(hexInput string, n int) (*models.ConversionResult, error) {
+	if hexInput == "" {
+		return nil, errEmptyInput()
+	}
+
+	bytes, err := convert.HexToBytes(hexInput)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex input: %w", err)
+	}
+	return previewResult(bytes, n, canonicalHex), nil
+}
+
+// ConvertBinaryPreview converts like ConvertBinary but only the first n
+// bytes of the input. Longer inputs are marked as truncated with their total
+// length.
+func (c *Converter) ConvertBinaryPreview(binaryInput string, n int) (*models.ConversionResult, error) {
+	if binaryInput == "" {
+		return nil, errEmptyInput()
+	}
+
+	bytes, err := convert.ParseBinary(binaryInput)
+	if err != nil {
+		return nil, fmt.Errorf("invalid binary input: %w", err)
+	}
+	return previewResult(bytes, n, convert.BytesToBinary), nil
+}
+
+// previewResult converts the first n bytes, or all bytes if n is not
+// positive. canonical formats the converted bytes as the canonical input.
+func previewResult(bytes []byte, n int, canonical func([]byte) string) *models.ConversionResult {
+	if n <= 0 || len(bytes) <= n {
+		result := bytesResult(bytes, allSections)
+		result.Canonical = canonical(bytes)
+		return result
+	}
+	result := bytesResult(bytes[:n], allSections)
+	result.Canonical = canonical(bytes[:n])
+	result.Truncated = true
+	result.TotalLength = len(bytes)
+	return result
+}
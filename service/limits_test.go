@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestConvertModeLimited(t *testing.T) {
+	c := NewConverter()
+	settings := DefaultSettings()
+	settings.MaxInputSize = 16
+	settings.PreviewSize = 4
+
+	long := strings.Repeat("42", 10) // 20 characters, 10 bytes
+	tests := []struct {
+		name      string
+		mode      string
+		input     string
+		truncate  bool
+		wantErr   bool
+		wantBytes string
+		wantTotal int
+	}{
+		{"within limit", ModeHex, "42480000", false, false, "42480000", 0},
+		{"too large", ModeHex, long, false, true, "", 0},
+		{"truncated hex", ModeHex, long, true, false, "42424242", 10},
+		{"truncated binary", ModeBinary, strings.Repeat("00000001", 3), true, false, "010101", 0},
+		{"int never truncated", ModeInt, strings.Repeat("1", 17), true, true, "", 0},
+		{"invalid truncated hex", ModeHex, long + "zz", true, true, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings.TruncateInput = tt.truncate
+			result, _, err := c.ConvertModeLimited(context.Background(), tt.mode, tt.input, "", settings)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConvertModeLimited() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if result.Bytes != tt.wantBytes || result.TotalLength != tt.wantTotal || result.Truncated != (tt.wantTotal > 0) {
+				t.Errorf("ConvertModeLimited() = bytes %s, total %d, truncated %v", result.Bytes, result.TotalLength, result.Truncated)
+			}
+		})
+	}
+
+	err := CheckInputSize(long, 16)
+	if !errors.Is(err, ErrInputTooLarge) {
+		t.Errorf("Expected ErrInputTooLarge, got %v", err)
+	}
+	if d := ErrorDetails(err); d == nil || d.Code != "input_too_large" {
+		t.Errorf("ErrorDetails() = %+v", d)
+	}
+	if err := CheckInputSize(long, 0); err != nil {
+		t.Errorf("CheckInputSize() without limit error = %v", err)
+	}
+}
+
+func TestConvertBytesLimited(t *testing.T) {
+	c := NewConverter()
+	settings := DefaultSettings()
+	settings.MaxInputSize = 8
+	settings.PreviewSize = 4
+
+	data := []byte{0x42, 0x48, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06} // a 10-byte file range
+	if _, err := c.ConvertBytesLimited(data, settings); !errors.Is(err, ErrInputTooLarge) {
+		t.Errorf("ConvertBytesLimited() error = %v, want ErrInputTooLarge", err)
+	}
+
+	settings.TruncateInput = true
+	result, err := c.ConvertBytesLimited(data, settings)
+	if err != nil {
+		t.Fatalf("ConvertBytesLimited() error: %v", err)
+	}
+	if result.Bytes != "42480000" || !result.Truncated || result.TotalLength != 10 || result.Canonical != "0x42 0x48 0x00 0x00" {
+		t.Errorf("ConvertBytesLimited() = bytes %s, truncated %v, total %d, canonical %q", result.Bytes, result.Truncated, result.TotalLength, result.Canonical)
+	}
+
+	result, err = c.ConvertBytesLimited(data[:4], settings)
+	if err != nil || result.Truncated || result.Float32BE == nil {
+		t.Errorf("ConvertBytesLimited(4 bytes) = %+v, %v", result, err)
+	}
+	if _, err := c.ConvertBytesLimited(nil, settings); err == nil {
+		t.Error("ConvertBytesLimited(nil) succeeded")
+	}
+}
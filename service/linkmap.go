@@ -0,0 +1,209 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"hexview/binfmt"
+	"hexview/linkmap"
+	"hexview/models"
+)
+
+var (
+	// ErrNoLinkerMap indicates that no linker map file is loaded
+	ErrNoLinkerMap = errors.New("no linker map loaded")
+
+	// ErrNoELFSymbols indicates a file that is not an ELF file or has no
+	// symbol table
+	ErrNoELFSymbols = errors.New("no ELF symbol table")
+)
+
+// FormatELF is the LinkerMapInfo format of symbols read from an ELF file.
+const FormatELF = "elf"
+
+// LinkerMapService holds the linker map file or ELF symbol table addresses
+// are resolved to symbols with.
+type LinkerMapService struct {
+	mu   sync.Mutex
+	m    *linkmap.Map
+	path string
+}
+
+// NewLinkerMapService creates a LinkerMapService without a map.
+func NewLinkerMapService() *LinkerMapService {
+	return &LinkerMapService{}
+}
+
+// Load reads a GNU ld or armlink map file and makes it the map addresses
+// are resolved with. The previous map is kept if the file cannot be read.
+func (s *LinkerMapService) Load(path string) (*models.LinkerMapInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	m, err := linkmap.Parse(f)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m, s.path = m, path
+	return s.summary(), nil
+}
+
+// LoadELF reads the symbol table of an ELF file and makes it the symbols
+// addresses are resolved with, so firmware addresses in crash dumps resolve
+// to function + offset. The previous symbols are kept if the file is not an
+// ELF file or is stripped (ErrNoELFSymbols).
+func (s *LinkerMapService) LoadELF(path string) (*models.LinkerMapInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(f, header); err != nil {
+		return nil, fmt.Errorf("%w: %s is not an ELF file", ErrNoELFSymbols, path)
+	}
+	if format, err := binfmt.Detect(header); err != nil || format != binfmt.FormatELF {
+		return nil, fmt.Errorf("%w: %s is not an ELF file", ErrNoELFSymbols, path)
+	}
+	syms, err := binfmt.ELFSymbols(f)
+	if errors.Is(err, binfmt.ErrNoSymbols) {
+		return nil, fmt.Errorf("%w: %s is stripped", ErrNoELFSymbols, path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	symbols := make([]linkmap.Symbol, len(syms))
+	for i, sym := range syms {
+		symbols[i] = linkmap.Symbol{Name: sym.Name, Address: sym.Address, Size: sym.Size, Section: sym.Section}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m, s.path = linkmap.New(FormatELF, nil, symbols), path
+	return s.summary(), nil
+}
+
+// Info returns the format, symbol count and regions of the loaded map.
+func (s *LinkerMapService) Info() (*models.LinkerMapInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.m == nil {
+		return nil, ErrNoLinkerMap
+	}
+	return s.summary(), nil
+}
+
+// summary describes the loaded map. Regions get an inclusive end, as in the
+// memory map of a profile. The caller holds s.mu.
+func (s *LinkerMapService) summary() *models.LinkerMapInfo {
+	out := &models.LinkerMapInfo{Path: s.path, Format: s.m.Format, Symbols: len(s.m.Symbols)}
+	out.Regions = make([]models.MemoryRegion, 0, len(s.m.Regions))
+	for _, r := range s.m.Regions {
+		if r.Length == 0 {
+			continue
+		}
+		out.Regions = append(out.Regions, models.MemoryRegion{Name: r.Name, Start: r.Origin, End: r.Origin + r.Length - 1})
+	}
+	return out
+}
+
+// Lookup returns the symbol of the loaded map address points into. address
+// is hex with 0x prefix or decimal.
+func (s *LinkerMapService) Lookup(address string) (*models.SymbolMatch, error) {
+	s.mu.Lock()
+	m := s.m
+	s.mu.Unlock()
+	if m == nil {
+		return nil, ErrNoLinkerMap
+	}
+	clean := strings.ReplaceAll(strings.TrimSpace(address), "_", "")
+	addr, err := strconv.ParseUint(clean, 0, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidNumber, address)
+	}
+	match, ok := symbolMatch(m, addr)
+	if !ok {
+		return nil, fmt.Errorf("%w: no symbol at 0x%x", ErrOutOfRange, addr)
+	}
+	return &match, nil
+}
+
+// Apply annotates the unsigned 32- and 64-bit values of result with the
+// symbol of the loaded map they point into, e.g. "g_buffer + 0x10". It does
+// nothing without a map.
+func (s *LinkerMapService) Apply(result *models.ConversionResult) {
+	s.mu.Lock()
+	m := s.m
+	s.mu.Unlock()
+	if result == nil || m == nil {
+		return
+	}
+	v := reflect.ValueOf(result).Elem()
+	for _, f := range resultFields {
+		field := v.Field(f.index)
+		if !memoryMapTypes[f.typ] || field.IsNil() {
+			continue
+		}
+		if match, ok := symbolMatch(m, field.Elem().Uint()); ok {
+			match.Field = f.name
+			result.Symbols = append(result.Symbols, match)
+		}
+	}
+}
+
+// ApplyDump sets the symbol of the loaded map of the address fields of a
+// crash dump, e.g. "HardFault_Handler + 0x12" for the stacked PC. It does
+// nothing without a map.
+func (s *LinkerMapService) ApplyDump(dump *models.CrashDump) {
+	s.mu.Lock()
+	m := s.m
+	s.mu.Unlock()
+	if dump == nil || m == nil {
+		return
+	}
+	for i := range dump.Fields {
+		f := &dump.Fields[i]
+		if !f.Pointer {
+			continue
+		}
+		addr, err := strconv.ParseUint(f.Value, 0, 64)
+		if err != nil {
+			continue
+		}
+		if match, ok := symbolMatch(m, addr); ok {
+			f.Symbol = match.Text
+		}
+	}
+}
+
+// symbolMatch resolves addr to a symbol of m.
+func symbolMatch(m *linkmap.Map, addr uint64) (models.SymbolMatch, bool) {
+	sym, off, ok := m.Lookup(addr)
+	if !ok {
+		return models.SymbolMatch{}, false
+	}
+	text := sym.Name
+	if off != 0 {
+		text = fmt.Sprintf("%s + 0x%x", sym.Name, off)
+	}
+	return models.SymbolMatch{
+		Symbol:  sym.Name,
+		Address: sym.Address,
+		Size:    sym.Size,
+		Offset:  off,
+		Section: sym.Section,
+		Object:  sym.Object,
+		Text:    text,
+	}, true
+}
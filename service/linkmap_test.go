@@ -0,0 +1,103 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"hexview/models"
+)
+
+const testLinkerMap = `Memory Configuration
+
+Name             Origin             Length             Attributes
+FLASH            0x08000000         0x00080000         xr
+RAM              0x20000000         0x00020000         xrw
+*default*        0x00000000         0xffffffff
+
+Linker script and memory map
+
+.text           0x08000000      0x100
+ .text.main     0x08000000       0x40 build/main.o
+                0x08000000                main
+
+.bss            0x20000000      0x104
+ .bss.counter   0x20000000        0x4 build/main.o
+                0x20000000                counter
+ .bss.g_buffer  0x20000004      0x100 build/main.o
+                0x20000004                g_buffer
+`
+
+func TestLinkerMapService(t *testing.T) {
+	s := NewLinkerMapService()
+	if _, err := s.Lookup("0x20000000"); !errors.Is(err, ErrNoLinkerMap) {
+		t.Fatalf("Lookup() without map error = %v, want ErrNoLinkerMap", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "firmware.map")
+	if err := os.WriteFile(path, []byte(testLinkerMap), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	info, err := s.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	wantRegions := []models.MemoryRegion{
+		{Name: "FLASH", Start: 0x08000000, End: 0x0807ffff},
+		{Name: "RAM", Start: 0x20000000, End: 0x2001ffff},
+	}
+	if info.Format != "gnu" || info.Symbols != 3 || !slices.Equal(info.Regions, wantRegions) {
+		t.Errorf("Load() = %+v", info)
+	}
+
+	match, err := s.Lookup("0x2000_0014")
+	if err != nil {
+		t.Fatalf("Lookup() error: %v", err)
+	}
+	want := models.SymbolMatch{Symbol: "g_buffer", Address: 0x20000004, Size: 0x100, Offset: 0x10, Section: ".bss.g_buffer", Object: "main.o", Text: "g_buffer + 0x10"}
+	if *match != want {
+		t.Errorf("Lookup() = %+v, want %+v", *match, want)
+	}
+	if _, err := s.Lookup("0x30000000"); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Lookup() outside symbols error = %v, want ErrOutOfRange", err)
+	}
+	if _, err := s.Lookup("main"); !errors.Is(err, ErrInvalidNumber) {
+		t.Errorf("Lookup(main) error = %v, want ErrInvalidNumber", err)
+	}
+
+	// Files that are not ELF files keep the loaded symbols
+	if _, err := s.LoadELF(path); !errors.Is(err, ErrNoELFSymbols) {
+		t.Errorf("LoadELF() of a map file error = %v, want ErrNoELFSymbols", err)
+	}
+	if info, err := s.Info(); err != nil || info.Format != "gnu" {
+		t.Errorf("Info() after LoadELF() = %+v, %v, want the map file", info, err)
+	}
+
+	c := NewConverter()
+	result, err := c.ConvertHex("20000000")
+	if err != nil {
+		t.Fatalf("ConvertHex() error: %v", err)
+	}
+	s.Apply(result)
+	var got []string
+	for _, m := range result.Symbols {
+		got = append(got, m.Field+" "+m.Text)
+	}
+	if wantText := []string{"uint32BE counter", "uint64BE counter"}; !slices.Equal(got, wantText) {
+		t.Errorf("Symbols of 20000000 = %v, want %v", got, wantText)
+	}
+
+	dump, err := c.DecodeCrashDump(leWords(0, 0, 0, 0, 0, 0x08000011, 0x08000020, 0x01000003), models.CrashCortexM)
+	if err != nil {
+		t.Fatalf("DecodeCrashDump() error: %v", err)
+	}
+	s.ApplyDump(dump)
+	if lr, pc := dumpField(t, dump, "LR"), dumpField(t, dump, "PC"); lr.Symbol != "main + 0x11" || pc.Symbol != "main + 0x20" {
+		t.Errorf("ApplyDump() symbols of LR, PC = %q, %q, want main + 0x11, main + 0x20", lr.Symbol, pc.Symbol)
+	}
+	if r0 := dumpField(t, dump, "R0"); r0.Symbol != "" {
+		t.Errorf("ApplyDump() symbol of R0 = %q, want none", r0.Symbol)
+	}
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"hexview/models"
+)
+
+// ErrInvalidMemoryMap indicates memory map text that cannot be parsed
+var ErrInvalidMemoryMap = errors.New("invalid memory map")
+
+// memoryMapTypes lists the address-like types ApplyMemoryMap annotates.
+var memoryMapTypes = map[string]bool{"Uint32": true, "Uint64": true}
+
+var (
+	// linkerMemory matches a region of a GNU ld MEMORY command, e.g.
+	// "FLASH (rx) : ORIGIN = 0x08000000, LENGTH = 512K".
+	linkerMemory = regexp.MustCompile(`^(\w+)\s*(?:\([^)]*\))?\s*:\s*(?:ORIGIN|org|o)\s*=\s*(\w+)\s*,\s*(?:LENGTH|len|l)\s*=\s*(\w+)`)
+
+	// memoryRange matches "SRAM1 0x20000000 0x2001ffff" with spaces, commas,
+	// semicolons or a dash between the values. Header rows of tables do not
+	// match, as the values must start with a digit.
+	memoryRange = regexp.MustCompile(`^([\w.]+)\s*[,;\t ]\s*(\d\w*)\s*[,;\t -]\s*(\d\w*)$`)
+)
+
+// ApplyMemoryMap annotates the unsigned 32- and 64-bit values of result
+// with the region of the memory map of p they fall in, e.g.
+// "SRAM1 + 0x1f00". Where regions overlap, the smallest one is used, so
+// a peripheral is named rather than the bus it is on.
+func (c *Converter) ApplyMemoryMap(result *models.ConversionResult, p *models.Profile) {
+	if result == nil || p == nil || len(p.MemoryMap) == 0 {
+		return
+	}
+	v := reflect.ValueOf(result).Elem()
+	for _, f := range resultFields {
+		field := v.Field(f.index)
+		if !memoryMapTypes[f.typ] || field.IsNil() {
+			continue
+		}
+		addr := field.Elem().Uint()
+		if r, ok := findRegion(p.MemoryMap, addr); ok {
+			result.Regions = append(result.Regions, models.RegionMatch{
+				Field:  f.name,
+				Region: r.Name,
+				Offset: addr - r.Start,
+				Text:   fmt.Sprintf("%s + 0x%x", r.Name, addr-r.Start),
+			})
+		}
+	}
+}
+
+// findRegion returns the smallest region containing addr.
+func findRegion(regions []models.MemoryRegion, addr uint64) (models.MemoryRegion, bool) {
+	var best models.MemoryRegion
+	found := false
+	for _, r := range regions {
+		if addr < r.Start || addr > r.End {
+			continue
+		}
+		if !found || r.End-r.Start < best.End-best.Start {
+			best, found = r, true
+		}
+	}
+	return best, found
+}
+
+// validateMemoryMap checks that the regions of a profile have names and
+// do not end before they start.
+func validateMemoryMap(p models.Profile) error {
+	for _, r := range p.MemoryMap {
+		if strings.TrimSpace(r.Name) == "" {
+			return fmt.Errorf("%w: memory region at 0x%x has no name", ErrInvalidProfile, r.Start)
+		}
+		if r.End < r.Start {
+			return fmt.Errorf("%w: memory region %q ends before it starts", ErrInvalidProfile, r.Name)
+		}
+	}
+	return nil
+}
+
+// ParseMemoryMap reads memory regions from text, e.g. to load a memory map
+// from a datasheet table or linker script. Each line holds a name, the
+// start and the last address ("SRAM1 0x20000000 0x2001ffff", also with
+// commas or a dash), or is a region of a GNU ld MEMORY command
+// ("FLASH (rx) : ORIGIN = 0x08000000, LENGTH = 512K"). Comments and other
+// lines of a linker script are ignored.
+func ParseMemoryMap(text string) ([]models.MemoryRegion, error) {
+	var regions []models.MemoryRegion
+	for n, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(enumComment.ReplaceAllString(line, ""))
+		line, _, _ = strings.Cut(line, "#")
+		line = strings.TrimSpace(line)
+
+		if m := linkerMemory.FindStringSubmatch(line); m != nil {
+			start, err := parseMemoryNumber(m[2])
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidMemoryMap, n+1, err)
+			}
+			length, err := parseMemoryNumber(m[3])
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidMemoryMap, n+1, err)
+			}
+			if length == 0 {
+				return nil, fmt.Errorf("%w: line %d: region %s is empty", ErrInvalidMemoryMap, n+1, m[1])
+			}
+			regions = append(regions, models.MemoryRegion{Name: m[1], Start: start, End: start + length - 1})
+			continue
+		}
+
+		m := memoryRange.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		start, err := parseMemoryNumber(m[2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidMemoryMap, n+1, err)
+		}
+		end, err := parseMemoryNumber(m[3])
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidMemoryMap, n+1, err)
+		}
+		if end < start {
+			return nil, fmt.Errorf("%w: line %d: region %s ends before it starts", ErrInvalidMemoryMap, n+1, m[1])
+		}
+		regions = append(regions, models.MemoryRegion{Name: m[1], Start: start, End: end})
+	}
+	if len(regions) == 0 {
+		return nil, fmt.Errorf("%w: no regions found", ErrInvalidMemoryMap)
+	}
+	return regions, nil
+}
+
+// parseMemoryNumber parses an address or length in decimal or hex (0x),
+// with an optional K, M or G suffix as in linker scripts.
+func parseMemoryNumber(s string) (uint64, error) {
+	shift := 0
+	switch strings.ToUpper(s[len(s)-1:]) {
+	case "K":
+		shift = 10
+	case "M":
+		shift = 20
+	case "G":
+		shift = 30
+	}
+	if shift != 0 {
+		s = s[:len(s)-1]
+	}
+	v, err := strconv.ParseUint(strings.ReplaceAll(s, "_", ""), 0, 64)
+	if err != nil || v<<shift>>shift != v {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	return v << shift, nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"hexview/models"
+)
+
+func TestApplyMemoryMap(t *testing.T) {
+	profile := &models.Profile{Name: "stm32", MemoryMap: []models.MemoryRegion{
+		{Name: "FLASH", Start: 0x08000000, End: 0x080fffff},
+		{Name: "SRAM", Start: 0x20000000, End: 0x2004ffff},
+		{Name: "SRAM1", Start: 0x20000000, End: 0x2001ffff},
+	}}
+	c := NewConverter()
+	result, err := c.ConvertHex("20001f00")
+	if err != nil {
+		t.Fatalf("ConvertHex() error: %v", err)
+	}
+	c.ApplyMemoryMap(result, profile)
+	want := []models.RegionMatch{
+		{Field: "uint32BE", Region: "SRAM1", Offset: 0x1f00, Text: "SRAM1 + 0x1f00"},
+		{Field: "uint64BE", Region: "SRAM1", Offset: 0x1f00, Text: "SRAM1 + 0x1f00"}, // zero-extended
+	}
+	if !slices.Equal(result.Regions, want) {
+		t.Errorf("Regions of 20001f00 = %+v, want %+v", result.Regions, want)
+	}
+
+	result, _ = c.ConvertHex("0000000008000100")
+	c.ApplyMemoryMap(result, profile)
+	want = []models.RegionMatch{{Field: "uint64BE", Region: "FLASH", Offset: 0x100, Text: "FLASH + 0x100"}}
+	if !slices.Equal(result.Regions, want) {
+		t.Errorf("Regions of 0000000008000100 = %+v, want %+v", result.Regions, want)
+	}
+
+	result, _ = c.ConvertHex("20001f00")
+	c.ApplyMemoryMap(result, &models.Profile{})
+	if result.Regions != nil {
+		t.Errorf("Regions without memory map = %+v, want none", result.Regions)
+	}
+}
+
+func TestParseMemoryMap(t *testing.T) {
+	text := `Region  Start        End
+FLASH   0x0800_0000  0x080F_FFFF
+SRAM1,0x20000000,0x2001ffff   # comment
+CCM 0x10000000 - 0x1000ffff
+
+MEMORY
+{
+  RAM2 (xrw)  : ORIGIN = 0x20020000, LENGTH = 64K  /* SRAM2 */
+  BKPSRAM (rw) : org = 0x40024000, len = 4K
+}`
+	got, err := ParseMemoryMap(text)
+	if err != nil {
+		t.Fatalf("ParseMemoryMap() error: %v", err)
+	}
+	want := []models.MemoryRegion{
+		{Name: "FLASH", Start: 0x08000000, End: 0x080fffff},
+		{Name: "SRAM1", Start: 0x20000000, End: 0x2001ffff},
+		{Name: "CCM", Start: 0x10000000, End: 0x1000ffff},
+		{Name: "RAM2", Start: 0x20020000, End: 0x2002ffff},
+		{Name: "BKPSRAM", Start: 0x40024000, End: 0x40024fff},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("ParseMemoryMap() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseMemoryMap_Errors(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"no regions here",
+		"SRAM 0x2000 0x1000",
+		"RAM (rw) : ORIGIN = 0x0, LENGTH = 0",
+		"RAM 0x10 0xfffffffffffffffff",
+	} {
+		if _, err := ParseMemoryMap(text); !errors.Is(err, ErrInvalidMemoryMap) {
+			t.Errorf("ParseMemoryMap(%q) error = %v, want ErrInvalidMemoryMap", text, err)
+		}
+	}
+}
+
+func TestValidateProfile_MemoryMap(t *testing.T) {
+	for _, r := range []models.MemoryRegion{{Start: 0, End: 1}, {Name: "RAM", Start: 2, End: 1}} {
+		p := models.Profile{Name: "p", Settings: DefaultSettings(), MemoryMap: []models.MemoryRegion{r}}
+		if err := validateProfile(p); !errors.Is(err, ErrInvalidProfile) {
+			t.Errorf("validateProfile(%+v) error = %v, want ErrInvalidProfile", r, err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"hexview/models"
+)
+
+// ErrInvalidAddressing indicates Modbus addressing options out of range
+var ErrInvalidAddressing = errors.New("invalid Modbus addressing")
+
+// MaxModbusAddress is the highest protocol address of a register.
+const MaxModbusAddress = 0xFFFF
+
+// AddressingFromSettings returns the addressing of the Modbus settings.
+func AddressingFromSettings(s models.Settings) models.ModbusAddressing {
+	return models.ModbusAddressing{
+		Start:    s.Modbus.StartAddress,
+		Base:     s.Modbus.AddressBase,
+		Notation: s.Modbus.Notation,
+	}
+}
+
+// validateAddressing checks the addressing of count registers.
+func validateAddressing(a models.ModbusAddressing, count int) error {
+	if a.Base != 0 && a.Base != 1 {
+		return fmt.Errorf("%w: base %d (want 0 or 1)", ErrInvalidAddressing, a.Base)
+	}
+	if a.Notation != models.NotationPlain && a.Notation != models.NotationHolding {
+		return fmt.Errorf("%w: unknown notation %q", ErrInvalidAddressing, a.Notation)
+	}
+	if a.Start < 0 || a.Start > MaxModbusAddress {
+		return fmt.Errorf("%w: start address %d (want 0 to %d)", ErrInvalidAddressing, a.Start, MaxModbusAddress)
+	}
+	if count > 0 && a.Start+count-1 > MaxModbusAddress {
+		return fmt.Errorf("%w: %d registers from address %d exceed address %d", ErrInvalidAddressing, count, a.Start, MaxModbusAddress)
+	}
+	return nil
+}
+
+// registerNumbers returns the numbers of count registers as written in
+// device documentation. In holding register notation all numbers have six
+// digits (400001) if any would not fit into the four digits after the 4.
+func registerNumbers(a models.ModbusAddressing, count int) []int {
+	offset := 0
+	if a.Notation == models.NotationHolding {
+		offset = 40000
+		if a.Start+count-1+a.Base > 9999 {
+			offset = 400000
+		}
+	}
+	numbers := make([]int, count)
+	for i := range numbers {
+		numbers[i] = offset + a.Start + i + a.Base
+	}
+	return numbers
+}
+
+// ApplyAddressing numbers the registers and combined values of result as in
+// device documentation, e.g. 40101 for the first register with start
+// address 100, base 1 and holding register notation.
+func (c *Converter) ApplyAddressing(result *models.ModbusResult, a models.ModbusAddressing) error {
+	if err := validateAddressing(a, len(result.Registers)); err != nil {
+		return err
+	}
+	setAddresses(result, registerNumbers(a, len(result.Registers)))
+	return nil
+}
+
+// setAddresses sets the address of every register of result, and of the
+// combined values starting at it, to addresses. The slices are copied first
+// since results may be shared with the cache.
+func setAddresses(result *models.ModbusResult, addresses []int) {
+	result.Registers = slices.Clone(result.Registers)
+	for i := range result.Registers {
+		result.Registers[i].Address = &addresses[i]
+	}
+	result.Combined32 = slices.Clone(result.Combined32)
+	for i, v := range result.Combined32 {
+		result.Combined32[i].Address = &addresses[v.RegisterStart-1]
+	}
+	result.Combined64 = slices.Clone(result.Combined64)
+	for i, v := range result.Combined64 {
+		result.Combined64[i].Address = &addresses[v.RegisterStart-1]
+	}
+}
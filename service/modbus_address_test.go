@@ -0,0 +1,62 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"hexview/models"
+)
+
+func TestApplyAddressing(t *testing.T) {
+	c := NewCachedConverter(DefaultCacheSize)
+	tests := []struct {
+		name       string
+		addressing models.ModbusAddressing
+		want       []int
+	}{
+		{"1-based", models.ModbusAddressing{Base: 1}, []int{1, 2, 3, 4}},
+		{"0-based with start", models.ModbusAddressing{Start: 100}, []int{100, 101, 102, 103}},
+		{"holding registers", models.ModbusAddressing{Base: 1, Notation: models.NotationHolding}, []int{40001, 40002, 40003, 40004}},
+		{"holding registers with start", models.ModbusAddressing{Start: 99, Base: 1, Notation: models.NotationHolding}, []int{40100, 40101, 40102, 40103}},
+		{"six-digit holding registers", models.ModbusAddressing{Start: 9997, Base: 1, Notation: models.NotationHolding}, []int{409998, 409999, 410000, 410001}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := c.ConvertModbusRegisters("4248 0000 0001 0002")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := c.ApplyAddressing(result, tt.addressing); err != nil {
+				t.Fatal(err)
+			}
+			for i, want := range tt.want {
+				if a := result.Registers[i].Address; a == nil || *a != want {
+					t.Errorf("register %d address %v, want %d", i, a, want)
+				}
+			}
+			if a := result.Combined32[1].Address; a == nil || *a != tt.want[1] {
+				t.Errorf("combined32 address %v, want %d", a, tt.want[1])
+			}
+			if a := result.Combined64[0].Address; a == nil || *a != tt.want[0] {
+				t.Errorf("combined64 address %v, want %d", a, tt.want[0])
+			}
+		})
+	}
+
+	// Addresses are not stored in the cached result
+	result, _ := c.ConvertModbusRegisters("4248 0000 0001 0002")
+	if result.Registers[0].Address != nil || result.Combined32[0].Address != nil {
+		t.Error("addressing changed the cached result")
+	}
+
+	for _, a := range []models.ModbusAddressing{
+		{Base: 2},
+		{Notation: "3x"},
+		{Start: -1},
+		{Start: MaxModbusAddress - 2},
+	} {
+		if err := c.ApplyAddressing(result, a); !errors.Is(err, ErrInvalidAddressing) {
+			t.Errorf("ApplyAddressing(%+v) error = %v, want %v", a, err, ErrInvalidAddressing)
+		}
+	}
+}
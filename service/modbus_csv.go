@@ -0,0 +1,145 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"hexview/export"
+	"hexview/models"
+)
+
+// ErrInvalidCSV indicates Modbus CSV input that is not a list of address,
+// value pairs
+var ErrInvalidCSV = errors.New("invalid Modbus CSV")
+
+// ConvertModbusCSV converts registers read from CSV address/value pairs as
+// exported by modpoll or PLC tools, e.g. "40001,16968". The first two
+// columns are used; a header line is skipped. Addresses are kept in the
+// registers, and 32- and 64-bit values are only combined from consecutive
+// addresses. Values are decimal unless prefixed with 0x.
+func (c *Converter) ConvertModbusCSV(data string) (*models.ModbusResult, error) {
+	addresses, registers, err := parseModbusCSV(data)
+	if err != nil {
+		return nil, err
+	}
+	result := modbusBatch(registers, 0, len(registers), allSections)
+
+	// The combinations are computed over neighboring rows, which are only
+	// neighboring registers if their addresses are
+	consecutive := func(start, count int) bool {
+		for i := start; i < start+count-1; i++ {
+			if addresses[i+1] != addresses[i]+1 {
+				return false
+			}
+		}
+		return true
+	}
+	combined32 := result.Combined32[:0]
+	for _, v := range result.Combined32 {
+		if consecutive(v.RegisterStart-1, 2) {
+			combined32 = append(combined32, v)
+		}
+	}
+	result.Combined32 = combined32
+	combined64 := result.Combined64[:0]
+	for _, v := range result.Combined64 {
+		if consecutive(v.RegisterStart-1, 4) {
+			combined64 = append(combined64, v)
+		}
+	}
+	result.Combined64 = combined64
+	setAddresses(result, addresses)
+	return result, nil
+}
+
+// parseModbusCSV reads the address/value pairs of data. The delimiter is a
+// comma, semicolon or tab, whichever the first line contains.
+func parseModbusCSV(data string) ([]int, []uint16, error) {
+	if strings.TrimSpace(data) == "" {
+		return nil, nil, errEmptyInput()
+	}
+	firstLine, _, _ := strings.Cut(strings.TrimLeft(data, "\r\n"), "\n")
+	r := csv.NewReader(strings.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	for _, delim := range []rune{',', ';', '\t'} {
+		if strings.ContainsRune(firstLine, delim) {
+			r.Comma = delim
+			break
+		}
+	}
+
+	var addresses []int
+	var registers []uint16
+	for first := true; ; first = false {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+		}
+		line, _ := r.FieldPos(0)
+		if len(record) < 2 {
+			return nil, nil, fmt.Errorf("%w: line %d: want address and value", ErrInvalidCSV, line)
+		}
+
+		address, err := strconv.Atoi(strings.Trim(record[0], " []"))
+		if err != nil {
+			if first {
+				continue // header
+			}
+			return nil, nil, fmt.Errorf("%w: line %d: invalid address %q", ErrInvalidCSV, line, record[0])
+		}
+		value, err := parseCSVValue(strings.TrimSpace(record[1]))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: line %d: %v", ErrInvalidCSV, line, err)
+		}
+		addresses = append(addresses, address)
+		registers = append(registers, value)
+	}
+	if len(registers) == 0 {
+		return nil, nil, errNoRegisters()
+	}
+	return addresses, registers, nil
+}
+
+// parseCSVValue parses a register value: decimal, negative decimal for
+// signed registers, or hex with a 0x prefix.
+func parseCSVValue(s string) (uint16, error) {
+	if hexValue, ok := strings.CutPrefix(strings.ToLower(s), "0x"); ok {
+		v, err := strconv.ParseUint(hexValue, 16, 16)
+		if err != nil {
+			return 0, fmt.Errorf("invalid register value %q", s)
+		}
+		return uint16(v), nil
+	}
+	if v, err := strconv.ParseInt(s, 10, 16); err == nil && v < 0 {
+		return uint16(v), nil
+	}
+	v, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid register value %q", s)
+	}
+	return uint16(v), nil
+}
+
+// WriteModbusCSV writes the registers of r and their 32- and 64-bit
+// interpretations as CSV, like ExportModbus does for .csv files.
+func WriteModbusCSV(w io.Writer, r *models.ModbusResult) error {
+	return export.WriteCSV(w, modbusTables(r))
+}
+
+// registerAddress returns the address of the register at index i (0-based)
+// of r as shown in exports: its address if known, otherwise its 1-based
+// index.
+func registerAddress(r *models.ModbusResult, i int) int {
+	if i < len(r.Registers) && r.Registers[i].Address != nil {
+		return *r.Registers[i].Address
+	}
+	return i + 1
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"hexview/convert"
+)
+
+func TestConvertModbusCSV(t *testing.T) {
+	c := NewConverter()
+	input := "Address,Value\n40001,16968\n40002,0\n40003,0x4248\n40005;-1\n"
+	if _, err := c.ConvertModbusCSV(input); !errors.Is(err, ErrInvalidCSV) {
+		t.Errorf("mixed delimiters: error = %v, want %v", err, ErrInvalidCSV)
+	}
+
+	input = "Address;Value\r\n40001;16968\r\n40002;0\r\n40003;0x4248\r\n\r\n40005;-1\r\n"
+	result, err := c.ConvertModbusCSV(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Registers) != 4 || result.RawHex != "4248 0000 4248 ffff" {
+		t.Fatalf("registers %+v, raw %s", result.Registers, result.RawHex)
+	}
+	for i, want := range []int{40001, 40002, 40003, 40005} {
+		if a := result.Registers[i].Address; a == nil || *a != want {
+			t.Errorf("register %d address %v, want %d", i, a, want)
+		}
+	}
+	// 40003 and 40005 are not neighbors
+	if len(result.Combined32) != 2 || result.Combined32[0].Float32BE != "50" || result.Combined32[1].RegisterStart != 2 {
+		t.Errorf("combined32 %+v", result.Combined32)
+	}
+	if len(result.Combined64) != 0 {
+		t.Errorf("combined64 across a gap: %+v", result.Combined64)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteModbusCSV(&buf, result); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Address,Index,Hex", "40005,4,ffff,65535,-1,", "40001-40002,42480000"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("missing %q in CSV:\n%s", want, buf.String())
+		}
+	}
+
+	errTests := []struct {
+		input string
+		want  error
+	}{
+		{"", convert.ErrEmptyInput},
+		{"Address,Value\n", convert.ErrEmptyInput},
+		{"1,2\n2\n", ErrInvalidCSV},
+		{"1,2\nx,3\n", ErrInvalidCSV},
+		{"1,70000\n", ErrInvalidCSV},
+		{"1,0xfffff\n", ErrInvalidCSV},
+	}
+	for _, tt := range errTests {
+		if _, err := c.ConvertModbusCSV(tt.input); !errors.Is(err, tt.want) {
+			t.Errorf("ConvertModbusCSV(%q) error = %v, want %v", tt.input, err, tt.want)
+		}
+	}
+}
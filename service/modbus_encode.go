@@ -0,0 +1,46 @@
+package service
+
+import (
+	"encoding/binary"
+	"strings"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+// EncodeModbusRegisters is the inverse of ConvertModbusRegisters: it returns
+// the 16-bit registers holding value as a typ (int16 to uint64, float16,
+// float32 or float64) in the given byte and word order (BE, LE, BADC or CDAB), e.g.
+// 4248 0000 for the float32 50 in BE order. Converting the RawHex of the
+// result shows the value again in that order.
+func (c *Converter) EncodeModbusRegisters(value, typ, order string) (*models.ModbusEncoding, error) {
+	i := fieldIndex(typ + order)
+	if i < 0 || typ == string(models.Int8) || typ == string(models.Uint8) {
+		return nil, errUnsupportedType("register", typ+" "+order)
+	}
+	bytes, err := fieldBytes(resultFields[i], value)
+	if err != nil {
+		return nil, err
+	}
+
+	enc := &models.ModbusEncoding{
+		Type:      typ,
+		Order:     order,
+		Value:     canonicalValue(bytesResult(bytes, allSections), typ+order),
+		Registers: make([]models.ModbusRegister, len(bytes)/2),
+	}
+	hexParts := make([]string, len(enc.Registers))
+	for j := range enc.Registers {
+		val := binary.BigEndian.Uint16(bytes[2*j:])
+		hexParts[j] = convert.Uint16ToHex(val)
+		enc.Registers[j] = models.ModbusRegister{
+			Index:    j + 1,
+			Hex:      hexParts[j],
+			Unsigned: val,
+			Signed:   int16(val),
+			Binary:   convert.Uint16ToBinary(val),
+		}
+	}
+	enc.RawHex = strings.Join(hexParts, " ")
+	return enc, nil
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEncodeModbusRegisters(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		value, typ, order string
+		want              string
+		wantValue         string
+	}{
+		{"50", "float32", "BE", "4248 0000", "50"},
+		{"50", "float32", "CDAB", "0000 4248", "50"},
+		{"50", "float32", "BADC", "4842 0000", "50"},
+		{"50", "float32", "LE", "0000 4842", "50"},
+		{"-2", "int16", "BE", "fffe", "-2"},
+		{"258", "uint16", "LE", "0201", "258"},
+		{"100000", "uint32", "CDAB", "86a0 0001", "100000"},
+		{"-1.5", "float64", "BE", "bff8 0000 0000 0000", "-1.5"},
+		{"0.1", "float32", "BE", "3dcc cccd", "0.1"},
+		{"1.5", "float16", "BE", "3e00", "1.5"},
+	}
+	for _, tt := range tests {
+		enc, err := c.EncodeModbusRegisters(tt.value, tt.typ, tt.order)
+		if err != nil {
+			t.Errorf("EncodeModbusRegisters(%s, %s, %s) error: %v", tt.value, tt.typ, tt.order, err)
+			continue
+		}
+		if enc.RawHex != tt.want || enc.Value != tt.wantValue {
+			t.Errorf("EncodeModbusRegisters(%s, %s, %s) = %s (%s), want %s (%s)",
+				tt.value, tt.typ, tt.order, enc.RawHex, enc.Value, tt.want, tt.wantValue)
+		}
+
+		// Decoding the registers gives the value back
+		decoded, err := c.ConvertModbusRegisters(enc.RawHex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tt.typ == "float32" {
+			f := decoded.Combined32[0]
+			got := map[string]string{"BE": f.Float32BE, "LE": f.Float32LE, "BADC": f.Float32BADC, "CDAB": f.Float32CDAB}[tt.order]
+			if got != tt.wantValue {
+				t.Errorf("decoded %s float32 %s = %s, want %s", enc.RawHex, tt.order, got, tt.wantValue)
+			}
+		}
+	}
+
+	enc, _ := c.EncodeModbusRegisters("-2", "int16", "BE")
+	if r := enc.Registers[0]; r.Unsigned != 0xfffe || r.Signed != -2 || r.Index != 1 {
+		t.Errorf("register = %+v", r)
+	}
+
+	for _, tt := range []struct{ value, typ, order string }{
+		{"1", "int8", "BE"},
+		{"1", "float128", "BE"},
+		{"1", "int32", "ABCD"},
+	} {
+		if _, err := c.EncodeModbusRegisters(tt.value, tt.typ, tt.order); !errors.Is(err, ErrUnsupportedType) {
+			t.Errorf("EncodeModbusRegisters(%s, %s, %s) error = %v, want %v", tt.value, tt.typ, tt.order, err, ErrUnsupportedType)
+		}
+	}
+	if _, err := c.EncodeModbusRegisters("70000", "uint16", "BE"); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("out of range value: error = %v", err)
+	}
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+// Modbus RTU frames are an address, a function code, up to 252 data bytes
+// and a CRC.
+const (
+	MinModbusRTUFrame = 4
+	MaxModbusRTUFrame = 256
+)
+
+// ErrInvalidModbusFrame indicates bytes that cannot be a Modbus frame
+var ErrInvalidModbusFrame = errors.New("invalid Modbus frame")
+
+// ValidateModbusFrame splits hex input into the fields of a Modbus RTU frame
+// and checks its CRC. A CRC mismatch is reported in the result, not as an
+// error, so the fields of a corrupted frame can still be inspected.
+func (c *Converter) ValidateModbusFrame(hexInput string) (*models.ModbusRTUFrame, error) {
+	frame, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+	if len(frame) < MinModbusRTUFrame || len(frame) > MaxModbusRTUFrame {
+		return nil, fmt.Errorf("%w: %d bytes (want %d to %d)", ErrInvalidModbusFrame, len(frame), MinModbusRTUFrame, MaxModbusRTUFrame)
+	}
+
+	body := frame[:len(frame)-2]
+	crc := binary.LittleEndian.Uint16(frame[len(frame)-2:])
+	expected := convert.CRC16Modbus(body)
+	return &models.ModbusRTUFrame{
+		Address:     int(frame[0]),
+		Function:    int(frame[1]),
+		Exception:   frame[1]&0x80 != 0,
+		Data:        convert.BytesToHex(body[2:]),
+		DataLength:  len(body) - 2,
+		CRC:         fmt.Sprintf("%04x", crc),
+		ExpectedCRC: fmt.Sprintf("%04x", expected),
+		Valid:       crc == expected,
+	}, nil
+}
+
+// modbusFunctions names the public Modbus function codes.
+var modbusFunctions = map[int]string{
+	1:  "Read Coils",
+	2:  "Read Discrete Inputs",
+	3:  "Read Holding Registers",
+	4:  "Read Input Registers",
+	5:  "Write Single Coil",
+	6:  "Write Single Register",
+	7:  "Read Exception Status",
+	8:  "Diagnostics",
+	11: "Get Comm Event Counter",
+	12: "Get Comm Event Log",
+	15: "Write Multiple Coils",
+	16: "Write Multiple Registers",
+	17: "Report Server ID",
+	20: "Read File Record",
+	21: "Write File Record",
+	22: "Mask Write Register",
+	23: "Read/Write Multiple Registers",
+	24: "Read FIFO Queue",
+	43: "Encapsulated Interface Transport",
+}
+
+// modbusExceptions names the exception codes of exception responses.
+var modbusExceptions = map[int]string{
+	1:  "Illegal Function",
+	2:  "Illegal Data Address",
+	3:  "Illegal Data Value",
+	4:  "Server Device Failure",
+	5:  "Acknowledge",
+	6:  "Server Device Busy",
+	8:  "Memory Parity Error",
+	10: "Gateway Path Unavailable",
+	11: "Gateway Target Device Failed to Respond",
+}
+
+// DecodeModbusPDU decodes a Modbus request or response in hex. framing is
+// models.FramingRTU, FramingTCP, FramingPDU for the function code and data
+// only, or empty to detect RTU and TCP frames by their CRC and MBAP header.
+// RTU frames with a wrong CRC are still decoded when the framing is given.
+//
+// Reads and their responses are told apart by their layout: 4 data bytes
+// asking for a valid number of values are a request, a byte count matching
+// the remaining data a response.
+func (c *Converter) DecodeModbusPDU(hexInput, framing string) (*models.ModbusFrameResult, error) {
+	frame, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+	if framing == "" {
+		if framing = detectModbusFraming(frame); framing == "" {
+			return nil, fmt.Errorf("%w: neither an RTU frame with a valid CRC nor a TCP frame, set the framing", ErrInvalidModbusFrame)
+		}
+	}
+
+	result := &models.ModbusFrameResult{Framing: framing}
+	var pdu []byte
+	switch framing {
+	case models.FramingRTU:
+		if len(frame) < MinModbusRTUFrame {
+			return nil, fmt.Errorf("%w: %d bytes (want at least %d)", ErrInvalidModbusFrame, len(frame), MinModbusRTUFrame)
+		}
+		body := frame[:len(frame)-2]
+		valid := binary.LittleEndian.Uint16(frame[len(frame)-2:]) == convert.CRC16Modbus(body)
+		result.SlaveID, result.CRCValid = int(frame[0]), &valid
+		pdu = body[1:]
+	case models.FramingTCP:
+		if len(frame) < 8 {
+			return nil, fmt.Errorf("%w: %d bytes (want at least 8)", ErrInvalidModbusFrame, len(frame))
+		}
+		if p := binary.BigEndian.Uint16(frame[2:]); p != 0 {
+			return nil, fmt.Errorf("%w: protocol ID %d (want 0)", ErrInvalidModbusFrame, p)
+		}
+		if n := int(binary.BigEndian.Uint16(frame[4:])); n != len(frame)-6 {
+			return nil, fmt.Errorf("%w: MBAP length %d, have %d bytes", ErrInvalidModbusFrame, n, len(frame)-6)
+		}
+		id := int(binary.BigEndian.Uint16(frame))
+		result.TransactionID, result.SlaveID = &id, int(frame[6])
+		pdu = frame[7:]
+	case models.FramingPDU:
+		if len(frame) == 0 {
+			return nil, fmt.Errorf("%w: no function code", ErrInvalidModbusFrame)
+		}
+		pdu = frame
+	default:
+		return nil, fmt.Errorf("%w: framing %q (want rtu, tcp or pdu)", ErrInvalidModbusFrame, framing)
+	}
+	decodePDU(result, pdu)
+	return result, nil
+}
+
+// detectModbusFraming returns the framing of frame, or "" if it is neither a
+// TCP frame with a matching MBAP header nor an RTU frame with a valid CRC.
+func detectModbusFraming(frame []byte) string {
+	switch {
+	case len(frame) >= 8 && binary.BigEndian.Uint16(frame[2:]) == 0 && int(binary.BigEndian.Uint16(frame[4:])) == len(frame)-6:
+		return models.FramingTCP
+	case len(frame) >= MinModbusRTUFrame && binary.LittleEndian.Uint16(frame[len(frame)-2:]) == convert.CRC16Modbus(frame[:len(frame)-2]):
+		return models.FramingRTU
+	}
+	return ""
+}
+
+// decodePDU sets the fields of r from a function code followed by its data.
+// Data that does not fit the layout of the function code is only kept as hex.
+func decodePDU(r *models.ModbusFrameResult, pdu []byte) {
+	fc, data := pdu[0], pdu[1:]
+	r.Function = int(fc &^ 0x80)
+	r.FunctionName = modbusFunctions[r.Function]
+	if r.FunctionName == "" {
+		r.FunctionName = "Unknown"
+	}
+	r.Data = convert.BytesToHex(data)
+
+	if fc&0x80 != 0 {
+		r.Exception, r.Direction = true, models.ModbusResponse
+		if len(data) > 0 {
+			r.ExceptionCode = int(data[0])
+			r.ExceptionName = modbusExceptions[r.ExceptionCode]
+		}
+		return
+	}
+
+	// Address and count as sent in requests and write responses
+	addressCount := func() {
+		address, count := int(binary.BigEndian.Uint16(data)), int(binary.BigEndian.Uint16(data[2:]))
+		r.Address, r.Count = &address, &count
+	}
+	// A read request has 4 data bytes and asks for at most maxRead values
+	isReadRequest := func(maxRead int) bool {
+		if len(data) != 4 {
+			return false
+		}
+		n := int(binary.BigEndian.Uint16(data[2:]))
+		return n >= 1 && n <= maxRead
+	}
+	isReadResponse := len(data) > 0 && int(data[0]) == len(data)-1
+	isWriteRequest := len(data) > 4 && int(data[4]) == len(data)-5
+
+	switch r.Function {
+	case 1, 2:
+		switch {
+		case isReadRequest(2000):
+			r.Direction = models.ModbusRequest
+			addressCount()
+		case isReadResponse:
+			r.Direction = models.ModbusResponse
+			r.Coils = modbusCoils(data[1:], 8*(len(data)-1))
+		}
+	case 3, 4:
+		switch {
+		case isReadRequest(125):
+			r.Direction = models.ModbusRequest
+			addressCount()
+		case isReadResponse:
+			r.Direction = models.ModbusResponse
+			r.Registers = modbusRegisters(data[1:], nil)
+		}
+	case 5, 6:
+		// The response echoes the request
+		if len(data) == 4 {
+			r.Direction = models.ModbusRequest
+			address := int(binary.BigEndian.Uint16(data))
+			r.Address = &address
+			if r.Function == 5 {
+				r.Coils = []bool{binary.BigEndian.Uint16(data[2:]) == 0xff00}
+			} else {
+				r.Registers = modbusRegisters(data[2:], r.Address)
+			}
+		}
+	case 15, 16:
+		switch {
+		case len(data) == 4:
+			r.Direction = models.ModbusResponse
+			addressCount()
+		case isWriteRequest:
+			r.Direction = models.ModbusRequest
+			addressCount()
+			if r.Function == 15 {
+				r.Coils = modbusCoils(data[5:], *r.Count)
+			} else {
+				r.Registers = modbusRegisters(data[5:], r.Address)
+			}
+		}
+	}
+}
+
+// modbusRegisters splits data into big-endian registers, numbered from
+// address if it is known. A trailing odd byte is left out.
+func modbusRegisters(data []byte, address *int) []models.ModbusRegister {
+	registers := make([]models.ModbusRegister, len(data)/2)
+	for i := range registers {
+		val := binary.BigEndian.Uint16(data[2*i:])
+		registers[i] = models.ModbusRegister{
+			Index:    i + 1,
+			Hex:      convert.Uint16ToHex(val),
+			Unsigned: val,
+			Signed:   int16(val),
+			Binary:   convert.Uint16ToBinary(val),
+		}
+		if address != nil {
+			a := *address + i
+			registers[i].Address = &a
+		}
+	}
+	return registers
+}
+
+// modbusCoils returns the first n coil states of data, packed least
+// significant bit first.
+func modbusCoils(data []byte, n int) []bool {
+	n = min(n, 8*len(data))
+	coils := make([]bool, n)
+	for i := range coils {
+		coils[i] = data[i/8]>>(i%8)&1 == 1
+	}
+	return coils
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"hexview/models"
+)
+
+func TestValidateModbusFrame(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		name  string
+		input string
+		want  models.ModbusRTUFrame
+	}{
+		{"read request", "01 03 00 00 00 0a c5 cd", models.ModbusRTUFrame{
+			Address: 1, Function: 3, Data: "0000000a", DataLength: 4, CRC: "cdc5", ExpectedCRC: "cdc5", Valid: true,
+		}},
+		{"response", "01 03 04 42 48 00 00 6e 5d", models.ModbusRTUFrame{
+			Address: 1, Function: 3, Data: "0442480000", DataLength: 5, CRC: "5d6e", ExpectedCRC: "5d6e", Valid: true,
+		}},
+		{"exception", "01 83 02 c0 f1", models.ModbusRTUFrame{
+			Address: 1, Function: 0x83, Exception: true, Data: "02", DataLength: 1, CRC: "f1c0", ExpectedCRC: "f1c0", Valid: true,
+		}},
+		{"CRC bytes swapped", "01 03 00 00 00 0a cd c5", models.ModbusRTUFrame{
+			Address: 1, Function: 3, Data: "0000000a", DataLength: 4, CRC: "c5cd", ExpectedCRC: "cdc5",
+		}},
+		{"no data", "11 07 4c 22", models.ModbusRTUFrame{
+			Address: 0x11, Function: 7, DataLength: 0, CRC: "224c", ExpectedCRC: "224c", Valid: true,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.ValidateModbusFrame(tt.input)
+			if err != nil {
+				t.Fatalf("ValidateModbusFrame(%q) error: %v", tt.input, err)
+			}
+			if *got != tt.want {
+				t.Errorf("ValidateModbusFrame(%q) = %+v, want %+v", tt.input, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateModbusFrame_Invalid(t *testing.T) {
+	c := NewConverter()
+	for _, input := range []string{"01 03 c5", "zz"} {
+		if _, err := c.ValidateModbusFrame(input); err == nil {
+			t.Errorf("ValidateModbusFrame(%q) succeeded, want error", input)
+		}
+	}
+	long := make([]byte, 3*(MaxModbusRTUFrame+1))
+	for i := range long {
+		long[i] = "00 "[i%3]
+	}
+	if _, err := c.ValidateModbusFrame(string(long)); !errors.Is(err, ErrInvalidModbusFrame) {
+		t.Errorf("ValidateModbusFrame(257 bytes) error = %v, want ErrInvalidModbusFrame", err)
+	}
+}
+
+func TestDecodeModbusPDU(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		name, input, framing string
+		check                func(t *testing.T, r *models.ModbusFrameResult)
+	}{
+		{"RTU read request", "01 03 00 6b 00 03 74 17", "", func(t *testing.T, r *models.ModbusFrameResult) {
+			if r.Framing != "rtu" || r.SlaveID != 1 || r.CRCValid == nil || !*r.CRCValid {
+				t.Errorf("framing = %s, slave %d, crcValid %v", r.Framing, r.SlaveID, r.CRCValid)
+			}
+			if r.FunctionName != "Read Holding Registers" || r.Direction != "request" || *r.Address != 107 || *r.Count != 3 {
+				t.Errorf("got %s %s address %v count %v", r.FunctionName, r.Direction, r.Address, r.Count)
+			}
+		}},
+		{"RTU read response", "01 03 06 02 2b 00 00 00 64 05 7a", "", func(t *testing.T, r *models.ModbusFrameResult) {
+			if r.Direction != "response" || r.Address != nil || len(r.Registers) != 3 {
+				t.Fatalf("got %s address %v registers %+v", r.Direction, r.Address, r.Registers)
+			}
+			if r.Registers[0].Unsigned != 555 || r.Registers[2].Unsigned != 100 {
+				t.Errorf("registers = %+v, want 555 0 100", r.Registers)
+			}
+		}},
+		{"RTU wrong CRC", "01 03 00 6b 00 03 00 00", "rtu", func(t *testing.T, r *models.ModbusFrameResult) {
+			if r.CRCValid == nil || *r.CRCValid || *r.Count != 3 {
+				t.Errorf("crcValid %v count %v, want decoded frame with invalid CRC", r.CRCValid, r.Count)
+			}
+		}},
+		{"TCP write multiple registers", "00 01 00 00 00 0b 11 10 00 01 00 02 04 00 0a 01 02", "", func(t *testing.T, r *models.ModbusFrameResult) {
+			if r.Framing != "tcp" || *r.TransactionID != 1 || r.SlaveID != 0x11 || r.Direction != "request" {
+				t.Fatalf("got %s transaction %v slave %d %s", r.Framing, r.TransactionID, r.SlaveID, r.Direction)
+			}
+			if len(r.Registers) != 2 || *r.Registers[1].Address != 2 || r.Registers[1].Hex != "0102" {
+				t.Errorf("registers = %+v, want 000a at 1 and 0102 at 2", r.Registers)
+			}
+		}},
+		{"write multiple registers response", "10 00 01 00 02", "pdu", func(t *testing.T, r *models.ModbusFrameResult) {
+			if r.Direction != "response" || *r.Address != 1 || *r.Count != 2 || r.SlaveID != 0 {
+				t.Errorf("got %s address %v count %v", r.Direction, r.Address, r.Count)
+			}
+		}},
+		{"read coils response", "01 03 cd 6b 05", "pdu", func(t *testing.T, r *models.ModbusFrameResult) {
+			want := []bool{true, false, true, true, false, false, true, true, true, true, false, true, false, true, true, false, true, false, true, false, false, false, false, false}
+			if !slices.Equal(r.Coils, want) {
+				t.Errorf("coils = %v, want %v", r.Coils, want)
+			}
+		}},
+		{"write multiple coils", "0f 00 13 00 0a 02 cd 01", "pdu", func(t *testing.T, r *models.ModbusFrameResult) {
+			want := []bool{true, false, true, true, false, false, true, true, true, false}
+			if r.Direction != "request" || !slices.Equal(r.Coils, want) {
+				t.Errorf("%s coils = %v, want %v", r.Direction, r.Coils, want)
+			}
+		}},
+		{"write single coil", "05 00 ac ff 00", "pdu", func(t *testing.T, r *models.ModbusFrameResult) {
+			if r.FunctionName != "Write Single Coil" || *r.Address != 172 || !slices.Equal(r.Coils, []bool{true}) {
+				t.Errorf("got %s address %v coils %v", r.FunctionName, r.Address, r.Coils)
+			}
+		}},
+		{"exception", "01 83 02 c0 f1", "", func(t *testing.T, r *models.ModbusFrameResult) {
+			if !r.Exception || r.Function != 3 || r.ExceptionCode != 2 || r.ExceptionName != "Illegal Data Address" {
+				t.Errorf("got exception %v function %d code %d %s", r.Exception, r.Function, r.ExceptionCode, r.ExceptionName)
+			}
+		}},
+		{"unknown function", "41 01 02", "pdu", func(t *testing.T, r *models.ModbusFrameResult) {
+			if r.FunctionName != "Unknown" || r.Direction != "" || r.Data != "0102" {
+				t.Errorf("got %s %q data %s", r.FunctionName, r.Direction, r.Data)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := c.DecodeModbusPDU(tt.input, tt.framing)
+			if err != nil {
+				t.Fatalf("DecodeModbusPDU(%q, %q) error: %v", tt.input, tt.framing, err)
+			}
+			tt.check(t, r)
+		})
+	}
+}
+
+func TestDecodeModbusPDU_Invalid(t *testing.T) {
+	c := NewConverter()
+	tests := []struct{ input, framing string }{
+		{"01 03 00 6b 00 03 00 00", ""},          // no valid CRC, no MBAP header
+		{"00 01 00 00 00 09 11 03 00 00", "tcp"}, // MBAP length too long
+		{"00 01 00 05 00 04 11 03 00 00", "tcp"}, // protocol ID
+		{"01 03 00", "rtu"},
+		{"03 00 00", "ascii"},
+	}
+	for _, tt := range tests {
+		if _, err := c.DecodeModbusPDU(tt.input, tt.framing); !errors.Is(err, ErrInvalidModbusFrame) {
+			t.Errorf("DecodeModbusPDU(%q, %q) error = %v, want ErrInvalidModbusFrame", tt.input, tt.framing, err)
+		}
+	}
+}
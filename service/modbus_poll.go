@@ -0,0 +1,443 @@
+package service
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"hexview/checksum"
+	"hexview/convert"
+	"hexview/models"
+)
+
+var (
+	// ErrPollNotFound indicates an unknown poll ID was used
+	ErrPollNotFound = errors.New("poll not found")
+
+	// ErrInvalidPoll indicates a poll configuration that cannot be used
+	ErrInvalidPoll = errors.New("invalid poll")
+
+	// ErrModbusResponse indicates a response that does not answer the request
+	ErrModbusResponse = errors.New("invalid Modbus response")
+)
+
+// Poll limits and defaults
+const (
+	MaxPollRegisters  = 125 // registers of a single read request
+	MaxPollSamples    = 100_000
+	minPollInterval   = 50 * time.Millisecond
+	defaultPollPeriod = time.Second
+)
+
+// PollService reads Modbus registers periodically over capture sessions and
+// records the decoded values as time series. Requests and responses are
+// logged in the capture session like any other transmitted frame. Polls keep
+// their samples after being stopped until they are removed.
+type PollService struct {
+	captures *CaptureService
+
+	mu     sync.RWMutex
+	polls  map[string]*poll
+	nextID int
+
+	onSamples func([]models.PollSample)
+	onStop    func(models.PollInfo)
+}
+
+// poll is a running or finished poll with its recorded samples.
+type poll struct {
+	id      string
+	cfg     models.PollConfig
+	started time.Time
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	mu       sync.Mutex
+	running  bool
+	polls    int
+	failures int
+	lastErr  string
+	samples  []timedSample // oldest first, at most MaxPollSamples
+}
+
+// timedSample is a sample with its parsed timestamp for queries.
+type timedSample struct {
+	at time.Time
+	models.PollSample
+}
+
+// NewPollService creates a PollService sending over the sessions of captures.
+func NewPollService(captures *CaptureService) *PollService {
+	return &PollService{captures: captures, polls: make(map[string]*poll)}
+}
+
+// SetHandlers registers callbacks for the samples of every read and for polls
+// that end. Both are called from the poll goroutines. Polls started before
+// the call are not affected.
+func (s *PollService) SetHandlers(onSamples func([]models.PollSample), onStop func(models.PollInfo)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onSamples = onSamples
+	s.onStop = onStop
+}
+
+// Start validates cfg and starts reading the registers every IntervalMs. The
+// poll ends when it is stopped or its capture session ends.
+func (s *PollService) Start(cfg models.PollConfig) (*models.PollInfo, error) {
+	cfg, err := normalizePollConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.captures.running(cfg.CaptureID); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.nextID++
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &poll{
+		id:      fmt.Sprintf("poll-%d", s.nextID),
+		cfg:     cfg,
+		started: time.Now(),
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		running: true,
+	}
+	s.polls[p.id] = p
+	onSamples, onStop := s.onSamples, s.onStop
+	s.mu.Unlock()
+
+	go s.run(ctx, p, onSamples, onStop)
+	info := p.info()
+	return &info, nil
+}
+
+// Stop ends a poll and waits for a pending read. Its samples remain available.
+func (s *PollService) Stop(id string) (*models.PollInfo, error) {
+	p, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+	p.cancel()
+	<-p.done
+	info := p.info()
+	return &info, nil
+}
+
+// StopAll ends all running polls, e.g. on application shutdown.
+func (s *PollService) StopAll() {
+	for _, info := range s.List() {
+		if info.Running {
+			_, _ = s.Stop(info.ID)
+		}
+	}
+}
+
+// Remove stops a poll and discards its samples.
+func (s *PollService) Remove(id string) error {
+	if _, err := s.Stop(id); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.polls, id)
+	return nil
+}
+
+// List returns descriptors of all polls in the order they were started.
+func (s *PollService) List() []models.PollInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]models.PollInfo, 0, len(s.polls))
+	for _, p := range s.polls {
+		list = append(list, p.info())
+	}
+	sort.Slice(list, func(i, j int) bool { return compareIDs(list[i].ID, list[j].ID) < 0 })
+	return list
+}
+
+// Samples returns the recorded samples of a poll matching q, oldest first.
+func (s *PollService) Samples(id string, q models.SampleQuery) ([]models.PollSample, error) {
+	p, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+	var from, to time.Time
+	if q.From != "" {
+		if from, err = time.Parse(time.RFC3339Nano, q.From); err != nil {
+			return nil, fmt.Errorf("invalid from time: %w", err)
+		}
+	}
+	if q.To != "" {
+		if to, err = time.Parse(time.RFC3339Nano, q.To); err != nil {
+			return nil, fmt.Errorf("invalid to time: %w", err)
+		}
+	}
+	names := make(map[string]bool, len(q.Names))
+	for _, n := range q.Names {
+		names[n] = true
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var samples []models.PollSample
+	for _, ts := range p.samples {
+		if (len(names) > 0 && !names[ts.Name]) || (!from.IsZero() && ts.at.Before(from)) || (!to.IsZero() && ts.at.After(to)) {
+			continue
+		}
+		samples = append(samples, ts.PollSample)
+	}
+	if q.Limit > 0 && len(samples) > q.Limit {
+		samples = samples[len(samples)-q.Limit:]
+	}
+	return samples, nil
+}
+
+// ExportSamples writes the samples of a poll matching q to path as CSV, JSON
+// or Markdown, depending on the file extension.
+func (s *PollService) ExportSamples(id string, q models.SampleQuery, path string) error {
+	samples, err := s.Samples(id, q)
+	if err != nil {
+		return err
+	}
+	return writeExport(path, "Poll "+id, samplesTables(samples), samples)
+}
+
+// get looks up a poll by ID.
+func (s *PollService) get(id string) (*poll, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.polls[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrPollNotFound, id)
+	}
+	return p, nil
+}
+
+// run reads the registers every interval until ctx is cancelled or the
+// capture session ends.
+func (s *PollService) run(ctx context.Context, p *poll, onSamples func([]models.PollSample), onStop func(models.PollInfo)) {
+	defer func() {
+		p.mu.Lock()
+		p.running = false
+		p.mu.Unlock()
+		close(p.done)
+		if onStop != nil {
+			onStop(p.info())
+		}
+	}()
+
+	ticker := time.NewTicker(time.Duration(p.cfg.IntervalMs) * time.Millisecond)
+	defer ticker.Stop()
+	for txID := uint16(1); ; txID++ {
+		samples, err := s.read(ctx, p.cfg, txID)
+		if ctx.Err() != nil {
+			return
+		}
+		p.record(samples, err)
+		if err != nil {
+			if _, cerr := s.captures.running(p.cfg.CaptureID); cerr != nil {
+				return
+			}
+		} else if onSamples != nil {
+			onSamples(samples)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// read sends one read request and decodes the points of the response.
+func (s *PollService) read(ctx context.Context, cfg models.PollConfig, txID uint16) ([]models.PollSample, error) {
+	request := modbusReadRequest(cfg, txID)
+	result, err := s.captures.Transmit(ctx, cfg.CaptureID, convert.BytesToHex(request), models.TransmitOptions{TimeoutMs: cfg.TimeoutMs})
+	if err != nil {
+		return nil, err
+	}
+	if result.TimedOut {
+		return nil, fmt.Errorf("no response within %d ms", cfg.TimeoutMs)
+	}
+	response, err := convert.HexToBytes(result.Response)
+	if err != nil {
+		return nil, err
+	}
+	registers, err := parseModbusReadResponse(cfg, txID, response)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Format(time.RFC3339Nano)
+	settings := models.Settings{ByteOrder: cfg.ByteOrder, FloatPrecision: -1}
+	settings.Modbus.AddressBase = cfg.Address
+	samples := make([]models.PollSample, len(cfg.Points))
+	for i, m := range cfg.Points {
+		v := mapRegister(registers, m, settings)
+		samples[i] = models.PollSample{Timestamp: now, Name: m.Name, Value: v.Value, Raw: v.Raw, Unit: v.Unit, Error: v.Error}
+	}
+	return samples, nil
+}
+
+// record stores the samples of a read, or counts the failure.
+func (p *poll) record(samples []models.PollSample, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err != nil {
+		p.failures++
+		p.lastErr = err.Error()
+		return
+	}
+	p.polls++
+	for i := range samples {
+		samples[i].PollID = p.id
+		at, _ := time.Parse(time.RFC3339Nano, samples[i].Timestamp)
+		p.samples = append(p.samples, timedSample{at: at, PollSample: samples[i]})
+	}
+	if over := len(p.samples) - MaxPollSamples; over > 0 {
+		p.samples = append(p.samples[:0], p.samples[over:]...)
+	}
+}
+
+// info builds the model descriptor of a poll.
+func (p *poll) info() models.PollInfo {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return models.PollInfo{
+		ID:        p.id,
+		Config:    p.cfg,
+		Running:   p.running,
+		StartedAt: p.started.Format(time.RFC3339),
+		Polls:     p.polls,
+		Failures:  p.failures,
+		LastError: p.lastErr,
+		Samples:   len(p.samples),
+	}
+}
+
+// normalizePollConfig fills in the defaults of cfg and checks it.
+func normalizePollConfig(cfg models.PollConfig) (models.PollConfig, error) {
+	if cfg.Function == 0 {
+		cfg.Function = 3
+	}
+	if cfg.IntervalMs == 0 {
+		cfg.IntervalMs = int(defaultPollPeriod / time.Millisecond)
+	}
+	if cfg.TimeoutMs <= 0 {
+		cfg.TimeoutMs = int(defaultResponseTimeout / time.Millisecond)
+	}
+	if cfg.ByteOrder == "" {
+		cfg.ByteOrder = "BE"
+	}
+
+	switch {
+	case cfg.Framing != models.FramingRTU && cfg.Framing != models.FramingTCP:
+		return cfg, fmt.Errorf("%w: unknown framing %q (want rtu or tcp)", ErrInvalidPoll, cfg.Framing)
+	case cfg.UnitID < 0 || cfg.UnitID > 255:
+		return cfg, fmt.Errorf("%w: unit ID %d (want 0 to 255)", ErrInvalidPoll, cfg.UnitID)
+	case cfg.Function != 3 && cfg.Function != 4:
+		return cfg, fmt.Errorf("%w: function %d (want 3 or 4)", ErrInvalidPoll, cfg.Function)
+	case cfg.Count < 1 || cfg.Count > MaxPollRegisters:
+		return cfg, fmt.Errorf("%w: %d registers (want 1 to %d)", ErrInvalidPoll, cfg.Count, MaxPollRegisters)
+	case cfg.Address < 0 || cfg.Address+cfg.Count-1 > MaxModbusAddress:
+		return cfg, fmt.Errorf("%w: %d registers from address %d exceed address %d", ErrInvalidPoll, cfg.Count, cfg.Address, MaxModbusAddress)
+	case time.Duration(cfg.IntervalMs)*time.Millisecond < minPollInterval:
+		return cfg, fmt.Errorf("%w: interval %d ms (want at least %d)", ErrInvalidPoll, cfg.IntervalMs, minPollInterval.Milliseconds())
+	case !slices.Contains(byteOrders, cfg.ByteOrder):
+		return cfg, fmt.Errorf("%w: unknown byte order %q", ErrInvalidPoll, cfg.ByteOrder)
+	}
+
+	if len(cfg.Points) == 0 {
+		cfg.Points = make([]models.RegisterMapping, cfg.Count)
+		for i := range cfg.Points {
+			address := cfg.Address + i
+			cfg.Points[i] = models.RegisterMapping{Register: address, Name: strconv.Itoa(address), Type: "uint16"}
+		}
+	}
+	for _, m := range cfg.Points {
+		size, ok := registerTypeSizes[m.Type]
+		if !ok {
+			return cfg, fmt.Errorf("%w: point %q has unknown type %q", ErrInvalidPoll, m.Name, m.Type)
+		}
+		if m.Register < cfg.Address || m.Register+size > cfg.Address+cfg.Count {
+			return cfg, fmt.Errorf("%w: point %q at address %d is not within the read registers", ErrInvalidPoll, m.Name, m.Register)
+		}
+	}
+	return cfg, nil
+}
+
+// modbusReadRequest builds a read holding or input registers request.
+func modbusReadRequest(cfg models.PollConfig, txID uint16) []byte {
+	pdu := []byte{byte(cfg.Function)}
+	pdu = binary.BigEndian.AppendUint16(pdu, uint16(cfg.Address))
+	pdu = binary.BigEndian.AppendUint16(pdu, uint16(cfg.Count))
+
+	if cfg.Framing == models.FramingTCP {
+		frame := binary.BigEndian.AppendUint16(nil, txID)
+		frame = binary.BigEndian.AppendUint16(frame, 0)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(len(pdu)+1))
+		frame = append(frame, byte(cfg.UnitID))
+		return append(frame, pdu...)
+	}
+	frame := append([]byte{byte(cfg.UnitID)}, pdu...)
+	return binary.LittleEndian.AppendUint16(frame, uint16(checksum.CRC16Modbus.Checksum(frame)))
+}
+
+// parseModbusReadResponse checks the framing of a response to
+// modbusReadRequest and returns its registers.
+func parseModbusReadResponse(cfg models.PollConfig, txID uint16, frame []byte) ([]uint16, error) {
+	var pdu []byte
+	if cfg.Framing == models.FramingTCP {
+		if len(frame) < 8 {
+			return nil, fmt.Errorf("%w: %d bytes", ErrModbusResponse, len(frame))
+		}
+		if id := binary.BigEndian.Uint16(frame); id != txID {
+			return nil, fmt.Errorf("%w: transaction %d, want %d", ErrModbusResponse, id, txID)
+		}
+		if n := int(binary.BigEndian.Uint16(frame[4:])); n != len(frame)-6 {
+			return nil, fmt.Errorf("%w: length %d, have %d bytes", ErrModbusResponse, n, len(frame)-6)
+		}
+		if frame[6] != byte(cfg.UnitID) {
+			return nil, fmt.Errorf("%w: unit %d, want %d", ErrModbusResponse, frame[6], cfg.UnitID)
+		}
+		pdu = frame[7:]
+	} else {
+		if len(frame) < 5 {
+			return nil, fmt.Errorf("%w: %d bytes", ErrModbusResponse, len(frame))
+		}
+		body := frame[:len(frame)-2]
+		if crc := binary.LittleEndian.Uint16(frame[len(frame)-2:]); uint64(crc) != checksum.CRC16Modbus.Checksum(body) {
+			return nil, fmt.Errorf("%w: CRC mismatch", ErrModbusResponse)
+		}
+		if frame[0] != byte(cfg.UnitID) {
+			return nil, fmt.Errorf("%w: unit %d, want %d", ErrModbusResponse, frame[0], cfg.UnitID)
+		}
+		pdu = body[1:]
+	}
+
+	if pdu[0] == byte(cfg.Function)|0x80 && len(pdu) >= 2 {
+		return nil, fmt.Errorf("%w: exception code %d", ErrModbusResponse, pdu[1])
+	}
+	if pdu[0] != byte(cfg.Function) || len(pdu) < 2 {
+		return nil, fmt.Errorf("%w: function %d, want %d", ErrModbusResponse, pdu[0], cfg.Function)
+	}
+	if n := int(pdu[1]); n != 2*cfg.Count || len(pdu) != 2+n {
+		return nil, fmt.Errorf("%w: %d data bytes, want %d", ErrModbusResponse, len(pdu)-2, 2*cfg.Count)
+	}
+	registers := make([]uint16, cfg.Count)
+	for i := range registers {
+		registers[i] = binary.BigEndian.Uint16(pdu[2+2*i:])
+	}
+	return registers, nil
+}
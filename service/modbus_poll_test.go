@@ -0,0 +1,221 @@
+package service
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+func TestModbusReadRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  models.PollConfig
+		want string
+	}{
+		{"rtu", models.PollConfig{Framing: models.FramingRTU, UnitID: 1, Function: 3, Address: 0, Count: 10}, "01030000000ac5cd"},
+		{"tcp", models.PollConfig{Framing: models.FramingTCP, UnitID: 1, Function: 4, Address: 100, Count: 2}, "000700000006010400640002"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := convert.BytesToHex(modbusReadRequest(tt.cfg, 7)); got != tt.want {
+				t.Errorf("modbusReadRequest() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseModbusReadResponse(t *testing.T) {
+	rtu := models.PollConfig{Framing: models.FramingRTU, UnitID: 1, Function: 3, Count: 2}
+	tcp := models.PollConfig{Framing: models.FramingTCP, UnitID: 1, Function: 3, Count: 2}
+	tests := []struct {
+		name  string
+		cfg   models.PollConfig
+		frame string
+		want  []uint16
+		err   string
+	}{
+		{"rtu", rtu, "010304424800006e5d", []uint16{0x4248, 0}, ""},
+		{"rtu crc mismatch", rtu, "010304424800006e5e", nil, "CRC mismatch"},
+		{"rtu exception", rtu, "018302c0f1", nil, "exception code 2"},
+		{"tcp", tcp, "0001000000070103044248000a", []uint16{0x4248, 10}, ""},
+		{"tcp transaction", tcp, "0002000000070103044248000a", nil, "transaction 2"},
+		{"tcp short data", tcp, "0001000000050103024248", nil, "2 data bytes, want 4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frame, _ := convert.HexToBytes(tt.frame)
+			got, err := parseModbusReadResponse(tt.cfg, 1, frame)
+			if tt.err != "" {
+				if !errors.Is(err, ErrModbusResponse) || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil || len(got) != len(tt.want) || got[0] != tt.want[0] || got[1] != tt.want[1] {
+				t.Errorf("parseModbusReadResponse() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizePollConfig(t *testing.T) {
+	cfg, err := normalizePollConfig(models.PollConfig{Framing: models.FramingRTU, UnitID: 1, Address: 40, Count: 2})
+	if err != nil {
+		t.Fatalf("normalizePollConfig() error: %v", err)
+	}
+	if cfg.Function != 3 || cfg.IntervalMs != 1000 || cfg.ByteOrder != "BE" || len(cfg.Points) != 2 || cfg.Points[1].Name != "41" {
+		t.Errorf("normalizePollConfig() = %+v", cfg)
+	}
+
+	valid := models.PollConfig{Framing: models.FramingTCP, Address: 0, Count: 2}
+	tests := []struct {
+		name string
+		edit func(*models.PollConfig)
+	}{
+		{"framing", func(c *models.PollConfig) { c.Framing = "ascii" }},
+		{"function", func(c *models.PollConfig) { c.Function = 6 }},
+		{"count", func(c *models.PollConfig) { c.Count = 126 }},
+		{"address", func(c *models.PollConfig) { c.Address = 0xFFFF }},
+		{"interval", func(c *models.PollConfig) { c.IntervalMs = 10 }},
+		{"byte order", func(c *models.PollConfig) { c.ByteOrder = "XY" }},
+		{"point type", func(c *models.PollConfig) { c.Points = []models.RegisterMapping{{Name: "v", Type: "int8"}} }},
+		{"point outside", func(c *models.PollConfig) {
+			c.Points = []models.RegisterMapping{{Register: 1, Name: "v", Type: "float32"}}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.edit(&cfg)
+			if _, err := normalizePollConfig(cfg); !errors.Is(err, ErrInvalidPoll) {
+				t.Errorf("normalizePollConfig() error = %v, want ErrInvalidPoll", err)
+			}
+		})
+	}
+}
+
+// serveModbusTCP answers every read request on conn with registers.
+func serveModbusTCP(conn net.Conn, registers []uint16) {
+	buf := make([]byte, 12)
+	for {
+		if _, err := conn.Read(buf); err != nil {
+			return
+		}
+		data := []byte{buf[7], byte(2 * len(registers))}
+		for _, r := range registers {
+			data = binary.BigEndian.AppendUint16(data, r)
+		}
+		frame := append([]byte{buf[0], buf[1], 0, 0, 0, byte(len(data) + 1), buf[6]}, data...)
+		if _, err := conn.Write(frame); err != nil {
+			return
+		}
+	}
+}
+
+func TestPollService(t *testing.T) {
+	captures := NewCaptureService()
+	local, remote := net.Pipe()
+	defer remote.Close()
+	capture := captures.start("tcp-client", "device", "", local)
+	go serveModbusTCP(remote, []uint16{0x4248, 0x0000, 0x00eb})
+
+	polls := NewPollService(captures)
+	received := make(chan []models.PollSample, 8)
+	stopped := make(chan models.PollInfo, 1)
+	polls.SetHandlers(
+		func(s []models.PollSample) { received <- s },
+		func(info models.PollInfo) { stopped <- info },
+	)
+	info, err := polls.Start(models.PollConfig{
+		CaptureID:  capture.ID,
+		Framing:    models.FramingTCP,
+		UnitID:     1,
+		Address:    100,
+		Count:      3,
+		IntervalMs: 50,
+		Points: []models.RegisterMapping{
+			{Register: 100, Name: "setpoint", Type: "float32"},
+			{Register: 102, Name: "voltage", Type: "uint16", Scale: 0.1, Unit: "V"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+
+	for range 2 {
+		select {
+		case samples := <-received:
+			if len(samples) != 2 || samples[0].Value != "50" || samples[1].Value != "23.5" || samples[1].PollID != info.ID {
+				t.Fatalf("samples = %+v", samples)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("no samples received")
+		}
+	}
+	stoppedInfo, err := polls.Stop(info.ID)
+	if err != nil {
+		t.Fatalf("Stop() error: %v", err)
+	}
+	if stoppedInfo.Running || stoppedInfo.Polls < 2 || stoppedInfo.Failures != 0 {
+		t.Errorf("info after stop = %+v", stoppedInfo)
+	}
+	if ev := <-stopped; ev.ID != info.ID {
+		t.Errorf("stop event = %+v", ev)
+	}
+
+	samples, err := polls.Samples(info.ID, models.SampleQuery{Names: []string{"voltage"}, Limit: 1})
+	if err != nil {
+		t.Fatalf("Samples() error: %v", err)
+	}
+	if len(samples) != 1 || samples[0].Name != "voltage" || samples[0].Raw != "235" {
+		t.Errorf("Samples() = %+v", samples)
+	}
+	future := time.Now().Add(time.Hour).Format(time.RFC3339)
+	if samples, _ := polls.Samples(info.ID, models.SampleQuery{From: future}); len(samples) != 0 {
+		t.Errorf("Samples() from %s = %+v, want none", future, samples)
+	}
+
+	path := filepath.Join(t.TempDir(), "trend.csv")
+	if err := polls.ExportSamples(info.ID, models.SampleQuery{Names: []string{"setpoint"}}, path); err != nil {
+		t.Fatalf("ExportSamples() error: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "Timestamp,Name,Value") || !strings.Contains(string(data), ",setpoint,50,") {
+		t.Errorf("exported CSV = %s", data)
+	}
+
+	if err := polls.Remove(info.ID); err != nil {
+		t.Fatalf("Remove() error: %v", err)
+	}
+	if _, err := polls.Samples(info.ID, models.SampleQuery{}); !errors.Is(err, ErrPollNotFound) {
+		t.Errorf("Samples() after Remove error = %v, want ErrPollNotFound", err)
+	}
+}
+
+func TestPollService_StartErrors(t *testing.T) {
+	captures := NewCaptureService()
+	local, remote := net.Pipe()
+	capture := captures.start("tcp-client", "device", "", local)
+	remote.Close()
+
+	polls := NewPollService(captures)
+	if _, err := polls.Start(models.PollConfig{CaptureID: "capture-9", Framing: models.FramingTCP, Count: 1}); !errors.Is(err, ErrCaptureNotFound) {
+		t.Errorf("Start() error = %v, want ErrCaptureNotFound", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for captures.List()[0].Running && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, err := polls.Start(models.PollConfig{CaptureID: capture.ID, Framing: models.FramingTCP, Count: 1}); err == nil {
+		t.Error("Start() on a stopped capture succeeded")
+	}
+}
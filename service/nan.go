@@ -0,0 +1,61 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"hexview/models"
+)
+
+// floatLayouts maps the float groups of a result to their width and
+// mantissa width in bits.
+var floatLayouts = map[string]struct{ size, mant int }{
+	"Float16": {16, 10},
+	"Float32": {32, 23},
+	"Float64": {64, 52},
+}
+
+// ApplyNaNs describes the NaN float values of result: whether they are quiet
+// or signaling and their payload in hex, which a plain "NaN" hides.
+func (c *Converter) ApplyNaNs(result *models.ConversionResult) {
+	if result == nil {
+		return
+	}
+	v := reflect.ValueOf(result).Elem()
+	for _, f := range resultFields {
+		layout, ok := floatLayouts[f.typ]
+		field := v.Field(f.index)
+		if !ok || field.IsNil() || field.Elem().String() != "NaN" {
+			continue
+		}
+		// Float hex fields hold the bits of the value, most significant first
+		bits, err := strconv.ParseUint(v.Field(f.hexIndex).String(), 16, 64)
+		if err != nil {
+			continue
+		}
+		result.NaNs = append(result.NaNs, nanPayload(f.name, bits, layout.size, layout.mant))
+	}
+}
+
+// nanPayload splits the bits of a size-bit NaN with a mantissa of mantBits
+// bits into sign, quiet bit and payload.
+func nanPayload(field string, bits uint64, size, mantBits int) models.NaNPayload {
+	quiet := uint64(1) << (mantBits - 1)
+	n := models.NaNPayload{
+		Field:     field,
+		Signaling: bits&quiet == 0,
+		Negative:  bits>>(size-1)&1 == 1,
+		Payload:   fmt.Sprintf("0x%0*x", (mantBits+2)/4, bits&(quiet-1)),
+	}
+	kind := "qNaN"
+	if n.Signaling {
+		kind = "sNaN"
+	}
+	sign := ""
+	if n.Negative {
+		sign = "-"
+	}
+	n.Text = fmt.Sprintf("%s%s(%s)", sign, kind, n.Payload)
+	return n
+}
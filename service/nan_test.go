@@ -0,0 +1,45 @@
+package service
+
+import (
+	"slices"
+	"testing"
+
+	"hexview/models"
+)
+
+func TestApplyNaNs(t *testing.T) {
+	c := NewConverter()
+	result, err := c.ConvertHex("7fa00001")
+	if err != nil {
+		t.Fatalf("ConvertHex() error: %v", err)
+	}
+	c.ApplyNaNs(result)
+	want := []models.NaNPayload{
+		// 7fa0 and 0100 as float16 are ordinary numbers
+		{Field: "float32BE", Signaling: true, Payload: "0x200001", Text: "sNaN(0x200001)"},
+	}
+	if !slices.Equal(result.NaNs, want) {
+		t.Errorf("NaNs of 7fa00001 = %+v, want %+v", result.NaNs, want)
+	}
+
+	result, _ = c.ConvertHex("00 00 00 00 00 00 f8 ff")
+	c.ApplyNaNs(result)
+	want = []models.NaNPayload{
+		{Field: "float64LE", Negative: true, Payload: "0x0000000000000", Text: "-qNaN(0x0000000000000)"},
+	}
+	if !slices.Equal(result.NaNs, want) {
+		t.Errorf("NaNs of 00..f8ff = %+v, want %+v", result.NaNs, want)
+	}
+
+	result, _ = c.ConvertHex("7e01")
+	c.ApplyNaNs(result)
+	if len(result.NaNs) != 2 || result.NaNs[0].Text != "qNaN(0x001)" {
+		t.Errorf("NaNs of 7e01 = %+v, want qNaN(0x001) for float16BE and BADC", result.NaNs)
+	}
+
+	result, _ = c.ConvertHex("3f800000")
+	c.ApplyNaNs(result)
+	if result.NaNs != nil {
+		t.Errorf("NaNs of 3f800000 = %+v, want none", result.NaNs)
+	}
+}
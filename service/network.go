@@ -0,0 +1,34 @@
+package service
+
+import (
+	"bytes"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+// macAddress reads 6 bytes as a MAC address with its vendor and address
+// bits; nil for other lengths.
+func macAddress(b []byte) *models.MACAddress {
+	addr, err := convert.BytesToMAC(b)
+	if err != nil {
+		return nil
+	}
+	mac := &models.MACAddress{
+		Address:   addr,
+		Multicast: b[0]&0x01 != 0,
+		Local:     b[0]&0x02 != 0,
+	}
+	switch {
+	case bytes.Equal(b, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}):
+		mac.Note = "broadcast"
+	case b[0] == 0x33 && b[1] == 0x33:
+		mac.Note = "IPv6 multicast"
+	case b[0] == 0x02 && b[1] == 0x42:
+		mac.Note = "Docker container"
+	}
+	if mac.Note == "" {
+		mac.Vendor, _ = convert.LookupOUI(b)
+	}
+	return mac
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+// Operations of CalculateOffset
+const (
+	OffsetIndex     = "index"     // base + index × stride
+	OffsetAlignUp   = "alignUp"   // next multiple of align at or above base
+	OffsetAlignDown = "alignDown" // multiple of align at or below base
+	OffsetDiff      = "diff"      // target - base
+)
+
+// CalculateOffset does the address arithmetic of reading memory dumps:
+// the address of element index of an array of stride-byte elements at
+// base, base aligned up or down to a multiple of align, or the distance
+// from base to target. Addresses must fit 64 bits; the result is given in
+// hex and decimal together with its distance from base.
+func (c *Converter) CalculateOffset(req models.OffsetRequest) (*models.OffsetResult, error) {
+	base, err := offsetOperand(req.Base, "base address", false)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.OffsetResult{Op: req.Op}
+	var addr *big.Int
+	switch req.Op {
+	case OffsetIndex:
+		index, err := offsetOperand(req.Index, "index", true)
+		if err != nil {
+			return nil, err
+		}
+		stride, err := offsetOperand(req.Stride, "stride", false)
+		if err != nil {
+			return nil, err
+		}
+		addr = new(big.Int).Mul(index, stride)
+		addr.Add(addr, base)
+
+	case OffsetAlignUp, OffsetAlignDown:
+		align, err := offsetOperand(req.Align, "alignment", false)
+		if err != nil {
+			return nil, err
+		}
+		if align.Sign() == 0 {
+			return nil, convert.NewInputError(convert.CodeOutOfRange, ErrOutOfRange, "alignment must not be zero")
+		}
+		rem := new(big.Int).Mod(base, align)
+		addr = new(big.Int).Sub(base, rem)
+		if req.Op == OffsetAlignUp && rem.Sign() != 0 {
+			addr.Add(addr, align)
+		}
+		aligned := rem.Sign() == 0
+		result.Aligned = &aligned
+
+	case OffsetDiff:
+		if addr, err = offsetOperand(req.Target, "target address", false); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, errUnsupportedType("offset operation", req.Op)
+	}
+
+	if _, hi := intRange(64, false); addr.Sign() < 0 || addr.Cmp(hi) > 0 {
+		return nil, convert.NewInputError(convert.CodeOutOfRange, ErrOutOfRange,
+			fmt.Sprintf("address %s does not fit 64 bits", addressValue(addr).Hex))
+	}
+	result.Base = addressValue(base)
+	result.Result = addressValue(addr)
+	result.Delta = addressValue(new(big.Int).Sub(addr, base))
+	return result, nil
+}
+
+// offsetOperand parses a decimal or 0x hex operand of CalculateOffset.
+// Only an index may be negative; everything else must fit 64 bits.
+func offsetOperand(s, label string, signed bool) (*big.Int, error) {
+	t := strings.ReplaceAll(strings.TrimSpace(s), "_", "")
+	if t == "" {
+		return nil, convert.NewInputError(convert.CodeEmptyInput, convert.ErrEmptyInput, "missing "+label)
+	}
+	digits, base := strings.TrimPrefix(t, "-"), 10
+	if len(digits) > 2 && (digits[:2] == "0x" || digits[:2] == "0X") {
+		digits, base = digits[2:], 16
+	}
+	v, ok := new(big.Int).SetString(digits, base)
+	if !ok || digits[0] == '+' || digits[0] == '-' {
+		return nil, fmt.Errorf("%w: invalid %s: %s", ErrInvalidNumber, label, s)
+	}
+	if strings.HasPrefix(t, "-") {
+		v.Neg(v)
+	}
+	lo, hi := intRange(64, false)
+	if signed {
+		lo, hi = intRange(64, true)
+	}
+	if v.Cmp(lo) < 0 || v.Cmp(hi) > 0 {
+		return nil, fmt.Errorf("%w: %s %s does not fit 64 bits", ErrOutOfRange, label, s)
+	}
+	return v, nil
+}
+
+// addressValue formats an address or byte count in hex and decimal.
+func addressValue(v *big.Int) models.AddressValue {
+	return models.AddressValue{
+		Hex:     fmt.Sprintf("%#x", v),
+		Decimal: v.String(),
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"hexview/models"
+)
+
+func TestCalculateOffset(t *testing.T) {
+	c := NewConverter()
+	yes, no := true, false
+	tests := []struct {
+		name    string
+		req     models.OffsetRequest
+		result  string
+		delta   string
+		aligned *bool
+	}{
+		{"index", models.OffsetRequest{Op: OffsetIndex, Base: "0x20000000", Index: "3", Stride: "12"}, "0x20000024", "0x24", nil},
+		{"negative index", models.OffsetRequest{Op: OffsetIndex, Base: "0x100", Index: "-2", Stride: "0x10"}, "0xe0", "-0x20", nil},
+		{"align up", models.OffsetRequest{Op: OffsetAlignUp, Base: "0x1001", Align: "16"}, "0x1010", "0xf", &no},
+		{"align up aligned", models.OffsetRequest{Op: OffsetAlignUp, Base: "4096", Align: "0x1000"}, "0x1000", "0x0", &yes},
+		{"align down", models.OffsetRequest{Op: OffsetAlignDown, Base: "0x1fff", Align: "0x1000"}, "0x1000", "-0xfff", &no},
+		{"diff", models.OffsetRequest{Op: OffsetDiff, Base: "0x0800_0000", Target: "0x08001234"}, "0x8001234", "0x1234", nil},
+		{"diff backwards", models.OffsetRequest{Op: OffsetDiff, Base: "100", Target: "0"}, "0x0", "-0x64", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := c.CalculateOffset(tt.req)
+			if err != nil {
+				t.Fatalf("CalculateOffset(%+v) error: %v", tt.req, err)
+			}
+			if r.Result.Hex != tt.result || r.Delta.Hex != tt.delta {
+				t.Errorf("CalculateOffset(%+v) = %s (delta %s), want %s (delta %s)", tt.req, r.Result.Hex, r.Delta.Hex, tt.result, tt.delta)
+			}
+			if (r.Aligned == nil) != (tt.aligned == nil) || r.Aligned != nil && *r.Aligned != *tt.aligned {
+				t.Errorf("Aligned = %v, want %v", r.Aligned, tt.aligned)
+			}
+		})
+	}
+
+	r, _ := c.CalculateOffset(models.OffsetRequest{Op: OffsetIndex, Base: "0x10", Index: "1", Stride: "0x10"})
+	if r.Result.Decimal != "32" || r.Base.Decimal != "16" {
+		t.Errorf("Decimal = %s, base %s, want 32, 16", r.Result.Decimal, r.Base.Decimal)
+	}
+}
+
+func TestCalculateOffset_Errors(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		name string
+		req  models.OffsetRequest
+		want error
+	}{
+		{"unknown op", models.OffsetRequest{Op: "mul", Base: "1"}, ErrUnsupportedType},
+		{"invalid base", models.OffsetRequest{Op: OffsetDiff, Base: "0xzz", Target: "1"}, ErrInvalidNumber},
+		{"negative base", models.OffsetRequest{Op: OffsetDiff, Base: "-1", Target: "1"}, ErrOutOfRange},
+		{"zero alignment", models.OffsetRequest{Op: OffsetAlignUp, Base: "1", Align: "0"}, ErrOutOfRange},
+		{"below zero", models.OffsetRequest{Op: OffsetIndex, Base: "0x10", Index: "-2", Stride: "16"}, ErrOutOfRange},
+		{"beyond 64 bits", models.OffsetRequest{Op: OffsetAlignUp, Base: "0xffffffffffffffff", Align: "2"}, ErrOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.CalculateOffset(tt.req); !errors.Is(err, tt.want) {
+				t.Errorf("CalculateOffset(%+v) error = %v, want %v", tt.req, err, tt.want)
+			}
+		})
+	}
+	if _, err := c.CalculateOffset(models.OffsetRequest{Op: OffsetIndex, Base: "0", Stride: "4"}); err == nil {
+		t.Error("Expected an error for a missing index")
+	}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"hexview/models"
+)
+
+// ErrOperationNotFound indicates an unknown or finished operation ID was used
+var ErrOperationNotFound = errors.New("operation not found")
+
+// Operation kinds
+const (
+	OpModbus    = "modbus"
+	OpTransmit  = "transmit"
+	OpReplay    = "replay"
+	OpFavorites = "favorites"
+	OpBulk      = "bulk"
+)
+
+// OperationService tracks long-running calls whose result is returned to the
+// caller directly, so they can be cancelled from elsewhere while the caller
+// waits. For operations delivering results incrementally use StreamService.
+type OperationService struct {
+	mu       sync.Mutex
+	ops      map[string]*operation
+	nextID   int
+	onChange func(models.Operation)
+}
+
+// operation holds the state of a running call.
+type operation struct {
+	info   models.Operation
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewOperationService creates a new OperationService instance.
+func NewOperationService() *OperationService {
+	return &OperationService{ops: make(map[string]*operation)}
+}
+
+// SetHandler sets the callback notified when an operation starts and ends.
+func (s *OperationService) SetHandler(onChange func(models.Operation)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onChange = onChange
+}
+
+// RunOperation runs fn as an operation of the given kind and returns its
+// result. The context passed to fn is cancelled by Cancel or CancelAll, in
+// which case the error of the context is returned.
+func RunOperation[T any](s *OperationService, kind string, fn func(ctx context.Context) (T, error)) (T, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	op := &operation{
+		info:   models.Operation{Kind: kind, Started: time.Now().Format(time.RFC3339)},
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	s.mu.Lock()
+	s.nextID++
+	op.info.ID = fmt.Sprintf("op-%d", s.nextID)
+	s.ops[op.info.ID] = op
+	s.mu.Unlock()
+	s.notify(op.info)
+
+	result, err := fn(ctx)
+
+	s.mu.Lock()
+	delete(s.ops, op.info.ID)
+	s.mu.Unlock()
+	close(op.done)
+
+	op.info.Done = true
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		op.info.Cancelled = true
+		if err == nil {
+			err = ctxErr
+		}
+	}
+	s.notify(op.info)
+	return result, err
+}
+
+// List returns the running operations ordered by start.
+func (s *OperationService) List() []models.Operation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := make([]models.Operation, 0, len(s.ops))
+	for _, op := range s.ops {
+		list = append(list, op.info)
+	}
+	slices.SortFunc(list, func(a, b models.Operation) int {
+		if d := len(a.ID) - len(b.ID); d != 0 {
+			return d
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return list
+}
+
+// Cancel cancels a running operation. The call running it returns the
+// error of its context.
+func (s *OperationService) Cancel(id string) error {
+	s.mu.Lock()
+	op, ok := s.ops[id]
+	s.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrOperationNotFound, id)
+	}
+	op.cancel()
+	return nil
+}
+
+// CancelAll cancels all running operations and waits for them to finish.
+func (s *OperationService) CancelAll() {
+	s.mu.Lock()
+	running := make([]*operation, 0, len(s.ops))
+	for _, op := range s.ops {
+		running = append(running, op)
+	}
+	s.mu.Unlock()
+
+	for _, op := range running {
+		op.cancel()
+		<-op.done
+	}
+}
+
+// notify calls the change handler, if any.
+func (s *OperationService) notify(info models.Operation) {
+	s.mu.Lock()
+	onChange := s.onChange
+	s.mu.Unlock()
+	if onChange != nil {
+		onChange(info)
+	}
+}
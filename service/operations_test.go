@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"hexview/models"
+)
+
+func TestOperationService_Cancel(t *testing.T) {
+	s := NewOperationService()
+	var mu sync.Mutex
+	var events []models.Operation
+	s.SetHandler(func(op models.Operation) {
+		mu.Lock()
+		events = append(events, op)
+		mu.Unlock()
+	})
+
+	started := make(chan struct{})
+	go func() {
+		<-started
+		list := s.List()
+		if len(list) != 1 || list[0].Kind != OpReplay {
+			t.Errorf("List() while running = %+v", list)
+		}
+		if err := s.Cancel(list[0].ID); err != nil {
+			t.Errorf("Cancel() error: %v", err)
+		}
+	}()
+
+	result, err := RunOperation(s, OpReplay, func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		return 1, nil
+	})
+	if !errors.Is(err, context.Canceled) || result != 1 {
+		t.Errorf("RunOperation() = %d, %v, want context.Canceled", result, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 2 || events[0].Done || !events[1].Done || !events[1].Cancelled || events[0].ID != events[1].ID {
+		t.Errorf("Unexpected events: %+v", events)
+	}
+	if len(s.List()) != 0 {
+		t.Error("Finished operation still listed")
+	}
+	if err := s.Cancel(events[0].ID); !errors.Is(err, ErrOperationNotFound) {
+		t.Errorf("Expected ErrOperationNotFound, got %v", err)
+	}
+}
+
+func TestOperationService_Result(t *testing.T) {
+	s := NewOperationService()
+	want := errors.New("failed")
+	if _, err := RunOperation(s, OpModbus, func(ctx context.Context) (string, error) { return "", want }); err != want {
+		t.Errorf("RunOperation() error = %v, want %v", err, want)
+	}
+	got, err := RunOperation(s, OpModbus, func(ctx context.Context) (string, error) { return "ok", nil })
+	if err != nil || got != "ok" {
+		t.Errorf("RunOperation() = %q, %v", got, err)
+	}
+}
+
+func TestOperationService_CancelAll(t *testing.T) {
+	s := NewOperationService()
+	started := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		_, err := RunOperation(s, OpFavorites, func(ctx context.Context) (any, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+		done <- err
+	}()
+	<-started
+	s.CancelAll()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"hexview/models"
+)
+
+// MaxPCMChannels is the largest number of interleaved channels ConvertPCM
+// accepts.
+const MaxPCMChannels = 32
+
+// pcmFormat matches sample formats like s16le, u8 or pcm_s24be.
+var pcmFormat = regexp.MustCompile(`^(?:pcm_)?([su])(8|16|24)(le|be)?$`)
+
+// ConvertPCM decodes hex input as interleaved PCM audio samples of format
+// (s8, u8, s16le, s16be, u16le, ..., s24be; the byte order defaults to LE)
+// with channels channels (1 if zero) and reports per-channel levels: min,
+// max, peak, RMS, DC offset, clipped samples and the largest jump between
+// consecutive samples, which helps to find glitches in audio DMA buffers.
+func (c *Converter) ConvertPCM(hexInput, format string, channels int) (*models.PCMResult, error) {
+	m := pcmFormat.FindStringSubmatch(strings.ToLower(strings.TrimSpace(format)))
+	if m == nil {
+		return nil, errUnsupportedType("PCM", format)
+	}
+	if channels == 0 {
+		channels = 1
+	}
+	if channels < 1 || channels > MaxPCMChannels {
+		return nil, fmt.Errorf("%w: %d channels, want 1 to %d", ErrOutOfRange, channels, MaxPCMChannels)
+	}
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+
+	bits, _ := strconv.Atoi(m[2])
+	result := &models.PCMResult{
+		Bits:     bits,
+		Signed:   m[1] == "s",
+		Order:    "LE",
+		Channels: channels,
+	}
+	if m[3] == "be" {
+		result.Order = "BE"
+	}
+	result.Format = m[1] + m[2]
+	if bits > 8 {
+		result.Format += strings.ToLower(result.Order)
+	}
+
+	size := bits / 8
+	frameSize := size * channels
+	result.Frames = len(data) / frameSize
+	result.Remainder = len(data) % frameSize
+
+	samples := make([]int32, result.Frames*channels)
+	for i := range samples {
+		samples[i] = pcmSample(data[i*size:(i+1)*size], result.Signed, result.Order == "BE")
+	}
+	n := min(len(samples), MaxArrayValues)
+	result.Samples = samples[:n]
+	result.Truncated = n < len(samples)
+
+	result.Stats = make([]models.PCMStats, 0, channels)
+	for ch := 0; ch < channels && result.Frames > 0; ch++ {
+		result.Stats = append(result.Stats, pcmStats(samples, ch, channels, bits, result.Signed))
+	}
+	return result, nil
+}
+
+// pcmSample reads a sample of len(b) bytes.
+func pcmSample(b []byte, signed, bigEndian bool) int32 {
+	var v uint32
+	for i := range b {
+		j := len(b) - 1 - i
+		if bigEndian {
+			j = i
+		}
+		v = v<<8 | uint32(b[j])
+	}
+	if signed {
+		shift := 32 - 8*len(b)
+		return int32(v<<shift) >> shift
+	}
+	return int32(v)
+}
+
+// pcmStats computes the levels of channel ch of interleaved samples.
+func pcmStats(samples []int32, ch, channels, bits int, signed bool) models.PCMStats {
+	fullScale := float64(int64(1) << (bits - 1))
+	lo, hi := -int32(fullScale), int32(fullScale)-1
+	mid := 0.0
+	if !signed {
+		lo, hi, mid = 0, int32(2*fullScale)-1, fullScale
+	}
+
+	s := models.PCMStats{Channel: ch, Min: samples[ch], Max: samples[ch]}
+	var sum, sumSq float64
+	frames := 0
+	for i := ch; i < len(samples); i += channels {
+		v := samples[i]
+		s.Min, s.Max = min(s.Min, v), max(s.Max, v)
+		if v == lo || v == hi {
+			s.Clipped++
+		}
+		level := (float64(v) - mid) / fullScale
+		sum += level
+		sumSq += level * level
+		s.Peak = max(s.Peak, math.Abs(level))
+		if i >= channels {
+			if jump := int64(v) - int64(samples[i-channels]); abs64(jump) > s.MaxJump {
+				s.MaxJump, s.MaxJumpFrame = abs64(jump), i/channels
+			}
+		}
+		frames++
+	}
+	s.DCOffset = sum / float64(frames)
+	s.RMS = math.Sqrt(sumSq / float64(frames))
+	s.PeakDBFS, s.RMSDBFS = dbfs(s.Peak), dbfs(s.RMS)
+	return s
+}
+
+// dbfs returns a level in decibels relative to full scale, or nil for
+// silence.
+func dbfs(level float64) *float64 {
+	if level == 0 {
+		return nil
+	}
+	db := 20 * math.Log10(level)
+	return &db
+}
+
+// abs64 returns the absolute value of v.
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
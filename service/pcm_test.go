@@ -0,0 +1,97 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestConvertPCM(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		format    string
+		channels  int
+		want      []int32
+		remainder int
+	}{
+		{"s16le", "0100 ffff 0080 ff7f", "s16le", 1, []int32{1, -1, -32768, 32767}, 0},
+		{"s16be", "0100 ffff", "S16BE", 1, []int32{256, -1}, 0},
+		{"u8", "00 80 ff", "u8", 1, []int32{0, 128, 255}, 0},
+		{"s8", "80 7f", "s8", 1, []int32{-128, 127}, 0},
+		{"s24le", "ffff7f 000080 010000", "s24le", 1, []int32{8388607, -8388608, 1}, 0},
+		{"u24be with remainder", "800000 ff", "pcm_u24be", 1, []int32{8388608}, 1},
+		{"stereo", "0100 0200 0300", "s16le", 2, []int32{1, 2}, 2},
+		{"default byte order", "0100", "s16", 0, []int32{1}, 0},
+	}
+	c := NewConverter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := c.ConvertPCM(tt.input, tt.format, tt.channels)
+			if err != nil {
+				t.Fatalf("ConvertPCM() error: %v", err)
+			}
+			if len(result.Samples) != len(tt.want) || result.Remainder != tt.remainder {
+				t.Fatalf("ConvertPCM() = %+v, want samples %v remainder %d", result, tt.want, tt.remainder)
+			}
+			for i, v := range tt.want {
+				if result.Samples[i] != v {
+					t.Errorf("sample %d = %d, want %d", i, result.Samples[i], v)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertPCM_Stats(t *testing.T) {
+	c := NewConverter()
+
+	// Square wave at half scale on the left channel, silence on the right,
+	// with a glitch to full scale in the third frame
+	result, err := c.ConvertPCM("0040 0000 00c0 0000 ff7f 0000 00c0 0000", "s16le", 2)
+	if err != nil {
+		t.Fatalf("ConvertPCM() error: %v", err)
+	}
+	if result.Frames != 4 || len(result.Stats) != 2 || result.Format != "s16le" {
+		t.Fatalf("ConvertPCM() = %+v", result)
+	}
+	left, right := result.Stats[0], result.Stats[1]
+	if left.Min != -16384 || left.Max != 32767 || left.Clipped != 1 {
+		t.Errorf("left = %+v", left)
+	}
+	if left.MaxJump != 49151 || left.MaxJumpFrame != 2 {
+		t.Errorf("left jump = %d at frame %d, want 49151 at frame 2", left.MaxJump, left.MaxJumpFrame)
+	}
+	if left.PeakDBFS == nil || math.Abs(*left.PeakDBFS) > 0.001 {
+		t.Errorf("left peak = %v dBFS, want 0", left.PeakDBFS)
+	}
+	if right.RMS != 0 || right.RMSDBFS != nil || right.MaxJump != 0 {
+		t.Errorf("right = %+v, want silence", right)
+	}
+
+	// Unsigned samples are centered on the midpoint
+	result, err = c.ConvertPCM("80 c0 80 40", "u8", 1)
+	if err != nil {
+		t.Fatalf("ConvertPCM() error: %v", err)
+	}
+	s := result.Stats[0]
+	if s.DCOffset != 0 || s.Peak != 0.5 || math.Abs(s.RMS-math.Sqrt(0.125)) > 1e-9 {
+		t.Errorf("u8 stats = %+v", s)
+	}
+	if s.RMSDBFS == nil || math.Abs(*s.RMSDBFS-(-9.0309)) > 0.001 {
+		t.Errorf("u8 RMS = %v dBFS, want -9.03", s.RMSDBFS)
+	}
+}
+
+func TestConvertPCM_Errors(t *testing.T) {
+	c := NewConverter()
+	if _, err := c.ConvertPCM("0100", "s32le", 1); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("s32le: error = %v, want %v", err, ErrUnsupportedType)
+	}
+	if _, err := c.ConvertPCM("0100", "s16le", 33); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("33 channels: error = %v, want %v", err, ErrOutOfRange)
+	}
+	if _, err := c.ConvertPCM("", "s16le", 1); err == nil {
+		t.Error("Expected error for empty input")
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+
+	"hexview/models"
+	"hexview/pixel"
+)
+
+// DefaultPixelPreviewSize is the largest side of a pixel preview in pixels.
+const DefaultPixelPreviewSize = 256
+
+// PreviewPixels decodes hex input as pixel data of opts.Format and
+// opts.Width pixels per row and returns a preview bitmap, so framebuffer
+// dumps become recognizable. Images larger than opts.MaxSize are shown with
+// every n-th pixel of every n-th row.
+func (c *Converter) PreviewPixels(hexInput string, opts models.PixelOptions) (*models.PixelPreview, error) {
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+	return c.PreviewPixelBytes(data, opts)
+}
+
+// PreviewPixelBytes decodes data as pixel data like PreviewPixels, e.g. a
+// range of an opened file.
+func (c *Converter) PreviewPixelBytes(data []byte, opts models.PixelOptions) (*models.PixelPreview, error) {
+	f, err := pixel.ParseFormat(opts.Format)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Offset < 0 || opts.Offset >= len(data) {
+		return nil, fmt.Errorf("%w: offset %d in %d bytes", ErrOutOfRange, opts.Offset, len(data))
+	}
+	maxSize := opts.MaxSize
+	if maxSize <= 0 {
+		maxSize = DefaultPixelPreviewSize
+	}
+
+	data = data[opts.Offset:]
+	img, err := pixel.Decode(data, f, opts.Width, opts.Stride)
+	if err != nil {
+		return nil, err
+	}
+	stride := opts.Stride
+	if stride == 0 {
+		stride = f.RowBytes(opts.Width)
+	}
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+
+	step := (max(width, height) + maxSize - 1) / maxSize
+	preview := img
+	if step > 1 {
+		preview = image.NewRGBA(image.Rect(0, 0, (width+step-1)/step, (height+step-1)/step))
+		for y := 0; y < preview.Bounds().Dy(); y++ {
+			for x := 0; x < preview.Bounds().Dx(); x++ {
+				preview.SetRGBA(x, y, img.RGBAAt(x*step, y*step))
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, preview); err != nil {
+		return nil, err
+	}
+	return &models.PixelPreview{
+		Format:        f.Name,
+		Width:         width,
+		Height:        height,
+		Stride:        stride,
+		Remainder:     len(data) - f.Size(width, height, stride),
+		PreviewWidth:  preview.Bounds().Dx(),
+		PreviewHeight: preview.Bounds().Dy(),
+		Step:          step,
+		PNG:           "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()),
+	}, nil
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"hexview/models"
+	"hexview/pixel"
+)
+
+func TestPreviewPixels(t *testing.T) {
+	c := NewConverter()
+
+	// 2x2 RGB565 red/green/blue/white with a 2-byte header and a stray byte
+	input := "aaaa 00f8 e007 1f00 ffff 01"
+	p, err := c.PreviewPixels(input, models.PixelOptions{Format: "rgb565", Width: 2, Offset: 2})
+	if err != nil {
+		t.Fatalf("PreviewPixels() error: %v", err)
+	}
+	if p.Width != 2 || p.Height != 2 || p.Stride != 4 || p.Remainder != 1 || p.Step != 1 || p.PreviewWidth != 2 {
+		t.Errorf("PreviewPixels() = %+v", p)
+	}
+
+	img := decodePreview(t, p.PNG)
+	if r, g, b, _ := img.At(1, 0).RGBA(); r != 0 || g != 0xffff || b != 0 {
+		t.Errorf("pixel (1,0) = %d %d %d, want green", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestPreviewPixels_Downscale(t *testing.T) {
+	c := NewConverter()
+	input := strings.Repeat("00", 100*30)
+	p, err := c.PreviewPixels(input, models.PixelOptions{Format: "gray8", Width: 100, MaxSize: 32})
+	if err != nil {
+		t.Fatalf("PreviewPixels() error: %v", err)
+	}
+	if p.Height != 30 || p.Step != 4 || p.PreviewWidth != 25 || p.PreviewHeight != 8 {
+		t.Errorf("PreviewPixels() = %+v", p)
+	}
+	if b := decodePreview(t, p.PNG).Bounds(); b.Dx() != 25 || b.Dy() != 8 {
+		t.Errorf("PNG bounds = %v", b)
+	}
+}
+
+func TestPreviewPixels_Errors(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		name string
+		opts models.PixelOptions
+		want error
+	}{
+		{"format", models.PixelOptions{Format: "yuv", Width: 1}, pixel.ErrUnknownFormat},
+		{"width", models.PixelOptions{Format: "gray8"}, pixel.ErrInvalidGeometry},
+		{"offset", models.PixelOptions{Format: "gray8", Width: 1, Offset: 4}, ErrOutOfRange},
+	}
+	for _, tt := range tests {
+		if _, err := c.PreviewPixels("0102", tt.opts); !errors.Is(err, tt.want) {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+// decodePreview decodes the PNG data URL of a preview.
+func decodePreview(t *testing.T, url string) image.Image {
+	t.Helper()
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(url, "data:image/png;base64,"))
+	if err != nil {
+		t.Fatalf("invalid base64: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("invalid PNG: %v", err)
+	}
+	return img
+}
+
+func TestPreviewPixels_NV12(t *testing.T) {
+	c := NewConverter()
+	// 4x2 luma, 4x1 chroma and 3 bytes of the next frame
+	p, err := c.PreviewPixels("eb eb eb eb 10 10 10 10 80 80 80 80 00 00 00", models.PixelOptions{Format: "nv12", Width: 4})
+	if err != nil {
+		t.Fatalf("PreviewPixels() error: %v", err)
+	}
+	if p.Height != 2 || p.Remainder != 3 {
+		t.Errorf("PreviewPixels() = %+v, want height 2 remainder 3", p)
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"math"
+
+	"hexview/models"
+)
+
+// Plot limits
+const (
+	DefaultPlotPoints = 1000
+	MaxPlotPoints     = 10000
+)
+
+// PlotArray decodes hex input like ConvertArray and returns the values as a
+// series of at most opts.MaxPoints points for charting, e.g. an ADC capture.
+func (c *Converter) PlotArray(hexInput string, opts models.PlotOptions) (*models.PlotSeries, error) {
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+	return c.PlotBytes(data, 0, opts)
+}
+
+// PlotBytes returns data decoded as an array as a series for charting, like
+// PlotArray. offset is the position of data in its source, e.g. an opened
+// file, and is added to the offsets of the points.
+func (c *Converter) PlotBytes(data []byte, offset int64, opts models.PlotOptions) (*models.PlotSeries, error) {
+	order := opts.Order
+	if order == "" {
+		order = "BE"
+	}
+	element, err := arrayElementOf(opts.Type, order)
+	if err != nil {
+		return nil, err
+	}
+	size := element.size
+	maxPoints := opts.MaxPoints
+	if maxPoints <= 0 {
+		maxPoints = DefaultPlotPoints
+	}
+	maxPoints = min(maxPoints, MaxPlotPoints)
+
+	count := len(data) / size
+	series := &models.PlotSeries{
+		Type:     opts.Type,
+		Order:    order,
+		Offset:   offset,
+		Size:     size,
+		Count:    count,
+		PerPoint: max(1, (count+maxPoints-1)/maxPoints),
+		Points:   make([]models.PlotPoint, 0, min(count, maxPoints)),
+	}
+	for start := 0; start < count; start += series.PerPoint {
+		p := models.PlotPoint{Index: start, Offset: offset + int64(start*size), Min: math.Inf(1), Max: math.Inf(-1)}
+		for i := start; i < min(start+series.PerPoint, count); i++ {
+			v := element.number(data[i*size : (i+1)*size])
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			p.Min = min(p.Min, v)
+			p.Max = max(p.Max, v)
+		}
+		if p.Min <= p.Max {
+			series.Points = append(series.Points, p)
+		}
+	}
+	return series, nil
+}
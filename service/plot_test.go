@@ -0,0 +1,55 @@
+package service
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"hexview/models"
+)
+
+func TestPlotBytes(t *testing.T) {
+	// A triangle wave of 10 int16 LE values: 0 1 2 3 4 5 4 3 2 1
+	var data []byte
+	for _, v := range []int16{0, 1, 2, 3, 4, 5, 4, 3, 2, 1} {
+		data = binary.LittleEndian.AppendUint16(data, uint16(v))
+	}
+	c := NewConverter()
+
+	series, err := c.PlotBytes(data, 0x100, models.PlotOptions{Type: "int16", Order: "LE", MaxPoints: 4})
+	if err != nil {
+		t.Fatalf("PlotBytes() error: %v", err)
+	}
+	want := []models.PlotPoint{
+		{Index: 0, Offset: 0x100, Min: 0, Max: 2},
+		{Index: 3, Offset: 0x106, Min: 3, Max: 5},
+		{Index: 6, Offset: 0x10c, Min: 2, Max: 4},
+		{Index: 9, Offset: 0x112, Min: 1, Max: 1},
+	}
+	if series.Count != 10 || series.PerPoint != 3 || series.Size != 2 || len(series.Points) != len(want) {
+		t.Fatalf("PlotBytes() = %+v", series)
+	}
+	for i, p := range series.Points {
+		if p != want[i] {
+			t.Errorf("point %d = %+v, want %+v", i, p, want[i])
+		}
+	}
+
+	series, _ = c.PlotBytes(data, 0, models.PlotOptions{Type: "uint8"})
+	if series.Order != "BE" || series.PerPoint != 1 || len(series.Points) != 20 {
+		t.Errorf("PlotBytes() without downsampling = %+v", series)
+	}
+}
+
+func TestPlotArray_SkipsNaN(t *testing.T) {
+	input := "3f800000 7fc00000 40000000" // 1, NaN, 2
+	series, err := NewConverter().PlotArray(input, models.PlotOptions{Type: "float32"})
+	if err != nil {
+		t.Fatalf("PlotArray() error: %v", err)
+	}
+	if len(series.Points) != 2 || series.Points[1].Index != 2 || series.Points[1].Max != 2 {
+		t.Errorf("PlotArray() = %+v, want the NaN left out", series)
+	}
+	if _, err := NewConverter().PlotArray(input, models.PlotOptions{Type: "int24"}); err == nil {
+		t.Error("PlotArray() with unknown type succeeded")
+	}
+}
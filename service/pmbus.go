@@ -0,0 +1,100 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+// ErrInvalidPMBus indicates input or a VOUT_MODE that does not fit the
+// PMBus format
+var ErrInvalidPMBus = errors.New("invalid PMBus data")
+
+// ConvertPMBus decodes PMBus telemetry from hex input. The linear formats
+// read one value per 16-bit word in order (LE as sent on the bus, or BE for
+// words written most significant byte first). models.PMBusLinear16 takes its
+// exponent from voutMode, the value of the VOUT_MODE command, which must
+// select linear mode. models.PMBusBlock reads an SMBus block: a byte count
+// followed by the data.
+func (c *Converter) ConvertPMBus(hexInput, format, order string, voutMode int) (*models.PMBusResult, error) {
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+	if format == models.PMBusBlock {
+		return decodeSMBusBlock(data)
+	}
+	if format != models.PMBusLinear11 && format != models.PMBusLinear16 {
+		return nil, fmt.Errorf("%w: unknown format %q (want %s, %s or %s)", ErrInvalidPMBus, format, models.PMBusLinear11, models.PMBusLinear16, models.PMBusBlock)
+	}
+	if order != "BE" && order != "LE" {
+		return nil, fmt.Errorf("%w: byte order %q (want BE or LE)", ErrInvalidPMBus, order)
+	}
+	if len(data)%2 != 0 {
+		return nil, fmt.Errorf("%w: %d bytes are not whole 16-bit words", ErrInvalidPMBus, len(data))
+	}
+
+	exponent := 0
+	if format == models.PMBusLinear16 {
+		if voutMode < 0 || voutMode > 0xff {
+			return nil, fmt.Errorf("%w: VOUT_MODE %d out of range", ErrInvalidPMBus, voutMode)
+		}
+		if mode := voutMode >> 5; mode != 0 {
+			return nil, fmt.Errorf("%w: VOUT_MODE 0x%02x selects mode %d, not linear", ErrInvalidPMBus, voutMode, mode)
+		}
+		exponent = signExtend5(voutMode)
+	}
+
+	result := &models.PMBusResult{Format: format, Order: order}
+	for i := 0; i < len(data); i += 2 {
+		word := uint16(data[i])<<8 | uint16(data[i+1])
+		if order == "LE" {
+			word = uint16(data[i+1])<<8 | uint16(data[i])
+		}
+		v := models.PMBusValue{Hex: fmt.Sprintf("%04x", word), Mantissa: int(word), Exponent: exponent}
+		if format == models.PMBusLinear11 {
+			v.Exponent = signExtend5(int(word >> 11))
+			v.Mantissa = int(word&0x7ff) - int(word&0x400)<<1
+		}
+		v.Value = strconv.FormatFloat(math.Ldexp(float64(v.Mantissa), v.Exponent), 'g', -1, 64)
+		result.Values = append(result.Values, v)
+	}
+	return result, nil
+}
+
+// decodeSMBusBlock splits an SMBus block read into byte count, data and
+// an optional trailing PEC byte.
+func decodeSMBusBlock(data []byte) (*models.PMBusResult, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: block read without byte count", ErrInvalidPMBus)
+	}
+	count := int(data[0])
+	block := data[1:]
+	result := &models.PMBusResult{Format: models.PMBusBlock, Count: count}
+	switch {
+	case len(block) < count:
+		result.Warning = fmt.Sprintf("byte count %d, but only %d data bytes", count, len(block))
+	case len(block) == count+1:
+		result.PEC = fmt.Sprintf("%02x", block[count])
+		block = block[:count]
+	case len(block) > count:
+		result.Warning = fmt.Sprintf("%d bytes after the block ignored", len(block)-count)
+		block = block[:count]
+	}
+	result.Data = convert.BytesToHex(block)
+	result.ASCII = bytesToASCII(block)
+	return result, nil
+}
+
+// signExtend5 returns the low 5 bits of v as a two's complement number.
+func signExtend5(v int) int {
+	v &= 0x1f
+	if v&0x10 != 0 {
+		v -= 0x20
+	}
+	return v
+}
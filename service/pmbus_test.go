@@ -0,0 +1,95 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"hexview/models"
+)
+
+func TestConvertPMBus(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		name     string
+		input    string
+		format   string
+		order    string
+		voutMode int
+		want     []string
+	}{
+		{"linear11 LE", "c0d3", models.PMBusLinear11, "LE", 0, []string{"15"}},
+		{"linear11 BE", "d3c0", models.PMBusLinear11, "BE", 0, []string{"15"}},
+		{"linear11 negative", "ff07 ffff", models.PMBusLinear11, "LE", 0, []string{"-1", "-0.5"}},
+		{"linear16", "6606 0018", models.PMBusLinear16, "LE", 0x17, []string{"3.19921875", "12"}},
+		{"linear16 exponent -12", "1000", models.PMBusLinear16, "BE", 0x14, []string{"1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := c.ConvertPMBus(tt.input, tt.format, tt.order, tt.voutMode)
+			if err != nil {
+				t.Fatalf("ConvertPMBus() error: %v", err)
+			}
+			if len(result.Values) != len(tt.want) {
+				t.Fatalf("ConvertPMBus() = %+v, want %v", result.Values, tt.want)
+			}
+			for i, v := range result.Values {
+				if v.Value != tt.want[i] {
+					t.Errorf("value %d = %+v, want %s", i, v, tt.want[i])
+				}
+			}
+		})
+	}
+
+	result, _ := c.ConvertPMBus("c0d3", models.PMBusLinear11, "LE", 0)
+	if v := result.Values[0]; v.Hex != "d3c0" || v.Mantissa != 960 || v.Exponent != -6 {
+		t.Errorf("ConvertPMBus() = %+v, want d3c0 960 * 2^-6", v)
+	}
+}
+
+func TestConvertPMBus_Block(t *testing.T) {
+	c := NewConverter()
+	result, err := c.ConvertPMBus("05 41 43 4d 45 21 7f", models.PMBusBlock, "", 0)
+	if err != nil {
+		t.Fatalf("ConvertPMBus() error: %v", err)
+	}
+	if result.Count != 5 || result.Data != "41434d4521" || result.ASCII != "ACME!" || result.PEC != "7f" || result.Warning != "" {
+		t.Errorf("ConvertPMBus() = %+v", result)
+	}
+
+	result, err = c.ConvertPMBus("05 41 43", models.PMBusBlock, "", 0)
+	if err != nil {
+		t.Fatalf("ConvertPMBus() error: %v", err)
+	}
+	if result.Data != "4143" || result.Warning == "" {
+		t.Errorf("short block = %+v, want warning", result)
+	}
+}
+
+func TestConvertPMBus_Errors(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		name     string
+		input    string
+		format   string
+		order    string
+		voutMode int
+	}{
+		{"unknown format", "0000", "direct", "LE", 0},
+		{"odd length", "000000", models.PMBusLinear11, "LE", 0},
+		{"bad order", "0000", models.PMBusLinear11, "CDAB", 0},
+		{"vid mode", "0000", models.PMBusLinear16, "LE", 0x20},
+		{"vout mode range", "0000", models.PMBusLinear16, "LE", 256},
+		{"empty block", "", models.PMBusBlock, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.ConvertPMBus(tt.input, tt.format, tt.order, tt.voutMode)
+			if tt.input != "" && !errors.Is(err, ErrInvalidPMBus) {
+				t.Errorf("ConvertPMBus() error = %v, want ErrInvalidPMBus", err)
+			}
+			if err == nil {
+				t.Error("ConvertPMBus() error = nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+var (
+	// ErrProfileNotFound indicates an unknown profile name was used
+	ErrProfileNotFound = errors.New("profile not found")
+
+	// ErrInvalidProfile indicates a profile with missing or out of range values
+	ErrInvalidProfile = errors.New("invalid profile")
+)
+
+// ProfileSections lists the result sections a profile can enable.
+var ProfileSections = []string{"signed", "unsigned", "float", "binary", "ascii", "modbus32", "modbus64", "scripts"}
+
+// registerTypeSizes maps register map types to their number of registers.
+var registerTypeSizes = map[string]int{
+	"int16": 1, "uint16": 1,
+	"int32": 2, "uint32": 2, "float32": 2,
+	"int64": 4, "uint64": 4, "float64": 4,
+}
+
+// ProfileService stores named conversion profiles in a JSON file and tracks
+// the active one. With an empty path profiles are kept in memory only.
+type ProfileService struct {
+	mu       sync.Mutex
+	path     string
+	active   string
+	profiles []models.Profile
+}
+
+// NewProfileService creates an empty ProfileService backed by the file at
+// path. Call Load to read the file.
+func NewProfileService(path string) *ProfileService {
+	return &ProfileService{path: path}
+}
+
+// Load reads the profiles file. A missing file leaves the list empty.
+func (s *ProfileService) Load() error {
+	if s.path == "" {
+		return nil
+	}
+	var f models.ProfileList
+	if err := loadJSON(s.path, &f); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.profiles = f.Profiles
+	s.active = f.Active
+	if s.find(s.active) < 0 {
+		s.active = ""
+	}
+	return nil
+}
+
+// List returns all profiles sorted by name and the name of the active one.
+func (s *ProfileService) List() models.ProfileList {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	profiles := append(make([]models.Profile, 0, len(s.profiles)), s.profiles...)
+	slices.SortFunc(profiles, func(a, b models.Profile) int { return strings.Compare(a.Name, b.Name) })
+	return models.ProfileList{Active: s.active, Profiles: profiles}
+}
+
+// Get returns the named profile.
+func (s *ProfileService) Get(name string) (*models.Profile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.find(name)
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+	p := s.profiles[i]
+	return &p, nil
+}
+
+// Active returns the active profile, or nil if none is selected.
+func (s *ProfileService) Active() *models.Profile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.find(s.active)
+	if i < 0 {
+		return nil
+	}
+	p := s.profiles[i]
+	return &p
+}
+
+// Save validates and stores a profile, replacing a profile with the same name.
+func (s *ProfileService) Save(p models.Profile) (*models.Profile, error) {
+	p.Name = strings.TrimSpace(p.Name)
+	if err := validateProfile(p); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i := s.find(p.Name); i >= 0 {
+		s.profiles[i] = p
+	} else {
+		s.profiles = append(s.profiles, p)
+	}
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Delete removes a profile. Deleting the active profile deactivates it.
+func (s *ProfileService) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.find(name)
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+	s.profiles = slices.Delete(s.profiles, i, i+1)
+	if s.active == name {
+		s.active = ""
+	}
+	return s.save()
+}
+
+// Activate selects the named profile and returns it. An empty name
+// deactivates the current profile and returns nil.
+func (s *ProfileService) Activate(name string) (*models.Profile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var active *models.Profile
+	if name != "" {
+		i := s.find(name)
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+		}
+		p := s.profiles[i]
+		active = &p
+	}
+	s.active = name
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+	return active, nil
+}
+
+// find returns the index of the named profile or -1. The caller must hold s.mu.
+func (s *ProfileService) find(name string) int {
+	if name == "" {
+		return -1
+	}
+	return slices.IndexFunc(s.profiles, func(p models.Profile) bool { return p.Name == name })
+}
+
+// save writes the profiles file. The caller must hold s.mu.
+func (s *ProfileService) save() error {
+	if s.path == "" {
+		return nil
+	}
+	if err := saveJSON(s.path, models.ProfileList{Active: s.active, Profiles: s.profiles}); err != nil {
+		return fmt.Errorf("cannot save profiles: %w", err)
+	}
+	return nil
+}
+
+// validateProfile checks the name, settings, sections, register map, enums
+// and memory map of a profile.
+func validateProfile(p models.Profile) error {
+	if p.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidProfile)
+	}
+	if err := validateSettings(p.Settings); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidProfile, err)
+	}
+	for _, section := range p.Sections {
+		if !slices.Contains(ProfileSections, section) {
+			return fmt.Errorf("%w: unknown section %q", ErrInvalidProfile, section)
+		}
+	}
+	if err := validateEnums(p); err != nil {
+		return err
+	}
+	if err := validateMemoryMap(p); err != nil {
+		return err
+	}
+	for _, r := range p.Registers {
+		if strings.TrimSpace(r.Name) == "" {
+			return fmt.Errorf("%w: register %d has no name", ErrInvalidProfile, r.Register)
+		}
+		if _, ok := registerTypeSizes[r.Type]; !ok {
+			return fmt.Errorf("%w: register %q has unknown type %q", ErrInvalidProfile, r.Name, r.Type)
+		}
+		if r.Register < p.Settings.Modbus.AddressBase {
+			return fmt.Errorf("%w: register %q number %d is below the address base", ErrInvalidProfile, r.Name, r.Register)
+		}
+	}
+	return nil
+}
+
+// ApplyProfile decodes the register map of p from the registers of result
+// and stores the values in result.Mapped, named by the entry's enum if it
+// has one. Entries that do not fit into the registers report an error.
+func (c *Converter) ApplyProfile(result *models.ModbusResult, p *models.Profile) {
+	if result == nil || p == nil || len(p.Registers) == 0 {
+		return
+	}
+	registers := make([]uint16, len(result.Registers))
+	for i, r := range result.Registers {
+		registers[i] = r.Unsigned
+	}
+
+	result.Mapped = make([]models.MappedRegister, len(p.Registers))
+	for i, m := range p.Registers {
+		result.Mapped[i] = mapRegister(registers, m, p.Settings)
+		mapEnum(&result.Mapped[i], m, p.Enums)
+	}
+}
+
+// mapRegister decodes one register map entry.
+func mapRegister(registers []uint16, m models.RegisterMapping, settings models.Settings) models.MappedRegister {
+	out := models.MappedRegister{Register: m.Register, Name: m.Name, Type: m.Type, Unit: m.Unit}
+	size, ok := registerTypeSizes[m.Type]
+	if !ok {
+		out.Error = fmt.Sprintf("unknown type %q", m.Type)
+		return out
+	}
+	start := m.Register - settings.Modbus.AddressBase
+	if start < 0 || start+size > len(registers) {
+		out.Error = fmt.Sprintf("needs registers %d to %d, have %d", m.Register, m.Register+size-1, len(registers))
+		return out
+	}
+
+	data := make([]byte, 0, size*2)
+	for _, r := range registers[start : start+size] {
+		data = binary.BigEndian.AppendUint16(data, r)
+	}
+	out.Hex = convert.BytesToHex(data)
+	data = toBigEndian(data, settings.ByteOrder)
+
+	var raw float64
+	switch m.Type {
+	case "int16":
+		v := int16(binary.BigEndian.Uint16(data))
+		raw, out.Raw = float64(v), strconv.FormatInt(int64(v), 10)
+	case "uint16":
+		v := binary.BigEndian.Uint16(data)
+		raw, out.Raw = float64(v), strconv.FormatUint(uint64(v), 10)
+	case "int32":
+		v := int32(binary.BigEndian.Uint32(data))
+		raw, out.Raw = float64(v), strconv.FormatInt(int64(v), 10)
+	case "uint32":
+		v := binary.BigEndian.Uint32(data)
+		raw, out.Raw = float64(v), strconv.FormatUint(uint64(v), 10)
+	case "float32":
+		v := math.Float32frombits(binary.BigEndian.Uint32(data))
+		raw, out.Raw = float64(v), formatFloat32(v)
+	case "int64":
+		v := int64(binary.BigEndian.Uint64(data))
+		raw, out.Raw = float64(v), strconv.FormatInt(v, 10)
+	case "uint64":
+		v := binary.BigEndian.Uint64(data)
+		raw, out.Raw = float64(v), strconv.FormatUint(v, 10)
+	case "float64":
+		v := math.Float64frombits(binary.BigEndian.Uint64(data))
+		raw, out.Raw = v, formatFloat64(v)
+	}
+
+	if m.Scale == 0 && m.Offset == 0 {
+		out.Value = out.Raw
+		return out
+	}
+	scale := m.Scale
+	if scale == 0 {
+		scale = 1
+	}
+	out.Value = formatScaled(raw*scale+m.Offset, settings.FloatPrecision)
+	return out
+}
+
+// formatScaled formats a scaled value with precision decimal places. With
+// a precision of -1 it uses 12 significant digits, which hides the rounding
+// errors of decimal scale factors like 0.1.
+func formatScaled(v float64, precision int) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return formatFloat64(v)
+	}
+	if precision >= 0 {
+		return strconv.FormatFloat(v, 'f', precision, 64)
+	}
+	return strconv.FormatFloat(v, 'g', 12, 64)
+}
+
+// toBigEndian reorders data stored in byteOrder (BE, LE, BADC or CDAB) to
+// big-endian.
+func toBigEndian(data []byte, byteOrder string) []byte {
+	out := slices.Clone(data)
+	switch byteOrder {
+	case "LE":
+		slices.Reverse(out)
+	case "BADC":
+		for i := 0; i+1 < len(out); i += 2 {
+			out[i], out[i+1] = out[i+1], out[i]
+		}
+	case "CDAB":
+		for i, j := 0, len(out)-2; i < j; i, j = i+2, j-2 {
+			out[i], out[i+1], out[j], out[j+1] = out[j], out[j+1], out[i], out[i+1]
+		}
+	}
+	return out
+}
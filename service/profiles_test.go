@@ -0,0 +1,161 @@
+package service
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"hexview/models"
+)
+
+func TestProfileService_SaveActivateLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	s := NewProfileService(path)
+	if err := s.Load(); err != nil {
+		t.Fatalf("Load() of missing file error: %v", err)
+	}
+
+	inverter := models.Profile{Name: " inverter ", Settings: DefaultSettings(), Sections: []string{"float", "modbus32"}}
+	inverter.Settings.ByteOrder = "CDAB"
+	if _, err := s.Save(inverter); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if _, err := s.Save(models.Profile{Name: "meter", Settings: DefaultSettings()}); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if s.Active() != nil {
+		t.Error("No profile should be active before Activate")
+	}
+	if p, err := s.Activate("inverter"); err != nil || p.Settings.ByteOrder != "CDAB" {
+		t.Fatalf("Activate() = %+v, %v", p, err)
+	}
+
+	reloaded := NewProfileService(path)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	list := reloaded.List()
+	if list.Active != "inverter" || len(list.Profiles) != 2 || list.Profiles[0].Name != "inverter" {
+		t.Fatalf("List() after reload = %+v", list)
+	}
+
+	if err := reloaded.Delete("inverter"); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if reloaded.Active() != nil {
+		t.Error("Deleting the active profile should deactivate it")
+	}
+	if _, err := reloaded.Activate("inverter"); !errors.Is(err, ErrProfileNotFound) {
+		t.Errorf("Expected ErrProfileNotFound, got %v", err)
+	}
+	if p, err := reloaded.Activate(""); err != nil || p != nil {
+		t.Errorf("Activate(\"\") = %+v, %v", p, err)
+	}
+}
+
+func TestProfileService_Validation(t *testing.T) {
+	valid := func(mod func(p *models.Profile)) models.Profile {
+		p := models.Profile{Name: "p", Settings: DefaultSettings()}
+		mod(&p)
+		return p
+	}
+	tests := []struct {
+		name    string
+		profile models.Profile
+	}{
+		{"empty name", valid(func(p *models.Profile) { p.Name = "  " })},
+		{"invalid settings", valid(func(p *models.Profile) { p.Settings.ByteOrder = "XY" })},
+		{"unknown section", valid(func(p *models.Profile) { p.Sections = []string{"colors"} })},
+		{"unknown register type", valid(func(p *models.Profile) {
+			p.Registers = []models.RegisterMapping{{Register: 1, Name: "x", Type: "int128"}}
+		})},
+		{"unnamed register", valid(func(p *models.Profile) {
+			p.Registers = []models.RegisterMapping{{Register: 1, Type: "int16"}}
+		})},
+		{"register below address base", valid(func(p *models.Profile) {
+			p.Registers = []models.RegisterMapping{{Register: 0, Name: "x", Type: "int16"}}
+		})},
+	}
+	s := NewProfileService("")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Save(tt.profile); !errors.Is(err, ErrInvalidProfile) {
+				t.Errorf("Expected ErrInvalidProfile, got %v", err)
+			}
+		})
+	}
+}
+
+func TestApplyProfile(t *testing.T) {
+	c := NewConverter()
+	result, err := c.ConvertModbusRegisters("0x00eb 0x41bc 0x0000 0xfff6")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := &models.Profile{Settings: DefaultSettings(), Registers: []models.RegisterMapping{
+		{Register: 1, Name: "temperature", Type: "int16", Scale: 0.1, Unit: "°C"},
+		{Register: 2, Name: "power", Type: "float32"},
+		{Register: 4, Name: "delta", Type: "int16", Offset: 100},
+		{Register: 4, Name: "energy", Type: "uint32"},
+	}}
+	c.ApplyProfile(result, p)
+
+	want := []struct{ raw, value, err string }{
+		{"235", "23.5", ""},
+		{"23.5", "23.5", ""},
+		{"-10", "90", ""},
+		{"", "", "needs registers 4 to 5, have 4"},
+	}
+	if len(result.Mapped) != len(want) {
+		t.Fatalf("Mapped = %+v", result.Mapped)
+	}
+	for i, w := range want {
+		got := result.Mapped[i]
+		if got.Raw != w.raw || got.Value != w.value || got.Error != w.err {
+			t.Errorf("Mapped[%d] = %+v, want raw %q value %q error %q", i, got, w.raw, w.value, w.err)
+		}
+	}
+}
+
+func TestApplyProfile_WordOrder(t *testing.T) {
+	c := NewConverter()
+	result, _ := c.ConvertModbusRegisters("0x0000 0x41bc")
+	tests := []struct {
+		order string
+		want  string
+	}{
+		{"CDAB", "23.5"},
+		{"BE", "2.3581e-41"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.order, func(t *testing.T) {
+			p := &models.Profile{Settings: DefaultSettings()}
+			p.Settings.ByteOrder = tt.order
+			p.Settings.Modbus.AddressBase = 0
+			p.Registers = []models.RegisterMapping{{Register: 0, Name: "power", Type: "float32"}}
+			c.ApplyProfile(result, p)
+			if got := result.Mapped[0].Value; got != tt.want {
+				t.Errorf("Value = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToBigEndian(t *testing.T) {
+	data := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	tests := []struct {
+		order string
+		want  []byte
+	}{
+		{"BE", []byte{1, 2, 3, 4, 5, 6, 7, 8}},
+		{"LE", []byte{8, 7, 6, 5, 4, 3, 2, 1}},
+		{"BADC", []byte{2, 1, 4, 3, 6, 5, 8, 7}},
+		{"CDAB", []byte{7, 8, 5, 6, 3, 4, 1, 2}},
+	}
+	for _, tt := range tests {
+		if got := toBigEndian(data, tt.order); string(got) != string(tt.want) {
+			t.Errorf("toBigEndian(%s) = %v, want %v", tt.order, got, tt.want)
+		}
+	}
+}
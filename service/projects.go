@@ -0,0 +1,149 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"hexview/models"
+	"hexview/structdecode"
+)
+
+// ProjectVersion is the version of the project file format written by
+// SaveProject. Files of newer versions are rejected.
+const ProjectVersion = 1
+
+// ProjectExtension is the extension of project files.
+const ProjectExtension = ".hexproj"
+
+// ErrInvalidProject indicates a project file that cannot be read or holds
+// invalid artifacts
+var ErrInvalidProject = errors.New("invalid project")
+
+// SaveProject validates a project and writes it to path. Paths of files,
+// the SVD file and the linker map below the directory of the project file
+// are stored relative to it, so the directory can be copied or shared as a
+// whole.
+func SaveProject(path string, p models.Project) error {
+	p.Name = strings.TrimSpace(p.Name)
+	if err := validateProject(p); err != nil {
+		return err
+	}
+	p.Version = ProjectVersion
+	mapProjectPaths(&p, func(file string) string { return relativePath(filepath.Dir(path), file) })
+	if err := saveJSON(path, p); err != nil {
+		return fmt.Errorf("cannot save project: %w", err)
+	}
+	return nil
+}
+
+// LoadProject reads and validates the project file at path. Relative paths
+// are resolved against its directory.
+func LoadProject(path string) (*models.Project, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var p models.Project
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidProject, filepath.Base(path), err)
+	}
+	if p.Version < 1 || p.Version > ProjectVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidProject, p.Version)
+	}
+	if err := validateProject(p); err != nil {
+		return nil, err
+	}
+	mapProjectPaths(&p, func(file string) string {
+		if filepath.IsAbs(file) {
+			return file
+		}
+		return filepath.Join(filepath.Dir(path), filepath.FromSlash(file))
+	})
+	return &p, nil
+}
+
+// validateProject checks the name, files, schemas, bookmarks and profiles
+// of a project.
+func validateProject(p models.Project) error {
+	if p.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidProject)
+	}
+	for _, f := range p.Files {
+		if strings.TrimSpace(f.Path) == "" {
+			return fmt.Errorf("%w: file %q has no path", ErrInvalidProject, f.Name)
+		}
+	}
+	if p.LinkerMap != "" && p.ELFSymbols != "" {
+		return fmt.Errorf("%w: both a linker map and ELF symbols", ErrInvalidProject)
+	}
+
+	schemas := make(map[string]bool, len(p.Schemas))
+	for _, s := range p.Schemas {
+		if s.Name == "" {
+			return fmt.Errorf("%w: schema has no name", ErrInvalidProject)
+		}
+		if schemas[s.Name] {
+			return fmt.Errorf("%w: duplicate schema %q", ErrInvalidProject, s.Name)
+		}
+		schemas[s.Name] = true
+		if _, err := structdecode.Parse(s.Layout); err != nil {
+			return fmt.Errorf("%w: schema %q: %w", ErrInvalidProject, s.Name, err)
+		}
+	}
+
+	for _, fav := range p.Bookmarks {
+		if err := validateFavorite(fav); err != nil {
+			return fmt.Errorf("%w: bookmark %q: %w", ErrInvalidProject, fav.Label, err)
+		}
+	}
+
+	profiles := make(map[string]bool, len(p.Profiles))
+	for _, profile := range p.Profiles {
+		if err := validateProfile(profile); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidProject, err)
+		}
+		if profiles[profile.Name] {
+			return fmt.Errorf("%w: duplicate profile %q", ErrInvalidProject, profile.Name)
+		}
+		profiles[profile.Name] = true
+	}
+	if p.ActiveProfile != "" && !profiles[p.ActiveProfile] {
+		return fmt.Errorf("%w: active profile %q is not in the project", ErrInvalidProject, p.ActiveProfile)
+	}
+	return nil
+}
+
+// mapProjectPaths replaces every path of a project with fn(path).
+func mapProjectPaths(p *models.Project, fn func(string) string) {
+	p.Files = slices.Clone(p.Files)
+	for i := range p.Files {
+		p.Files[i].Path = fn(p.Files[i].Path)
+	}
+	for _, path := range []*string{&p.SVD, &p.LinkerMap, &p.ELFSymbols} {
+		if *path != "" {
+			*path = fn(*path)
+		}
+	}
+}
+
+// relativePath returns file relative to dir with forward slashes if it is
+// below dir, and file unchanged otherwise.
+func relativePath(dir, file string) string {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return file
+	}
+	if dir, err = filepath.Abs(dir); err != nil {
+		return file
+	}
+	rel, err := filepath.Rel(dir, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return file
+	}
+	return filepath.ToSlash(rel)
+}
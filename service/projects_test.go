@@ -0,0 +1,136 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"hexview/models"
+)
+
+func testProject(dir string) models.Project {
+	return models.Project{
+		Name: "Inverter commissioning",
+		Files: []models.ProjectFile{
+			{Path: filepath.Join(dir, "dumps", "ram.bin"), Name: "RAM"},
+			{Path: filepath.Join(filepath.Dir(dir), "shared", "eeprom.bin")},
+		},
+		SVD:       filepath.Join(dir, "STM32F407.svd"),
+		LinkerMap: filepath.Join(dir, "build", "firmware.map"),
+		Schemas:   []models.ProjectSchema{{Name: "header", Layout: "magic u32 be\nlength u16"}},
+		Bookmarks: []models.Favorite{{ID: 7, Label: "Setpoint", Mode: ModeInt, Input: "513", Type: "uint16"}},
+		Profiles: []models.Profile{{
+			Name:      "inverter",
+			Settings:  DefaultSettings(),
+			Registers: []models.RegisterMapping{{Register: 1, Name: "voltage", Type: "uint16", Scale: 0.1, Unit: "V"}},
+			MemoryMap: []models.MemoryRegion{{Name: "SRAM1", Start: 0x20000000, End: 0x2001ffff}},
+		}},
+		ActiveProfile: "inverter",
+	}
+}
+
+func TestSaveLoadProject(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "project")
+	path := filepath.Join(dir, "inverter"+ProjectExtension)
+	project := testProject(dir)
+
+	if err := SaveProject(path, project); err != nil {
+		t.Fatalf("SaveProject() error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stored models.Project
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored.Version != ProjectVersion {
+		t.Errorf("Version = %d, want %d", stored.Version, ProjectVersion)
+	}
+	if stored.Files[0].Path != "dumps/ram.bin" || stored.SVD != "STM32F407.svd" || stored.LinkerMap != "build/firmware.map" {
+		t.Errorf("Paths below the project are not relative: %+v", stored)
+	}
+	if stored.Files[1].Path != project.Files[1].Path {
+		t.Errorf("Path outside the project = %q, want %q", stored.Files[1].Path, project.Files[1].Path)
+	}
+
+	loaded, err := LoadProject(path)
+	if err != nil {
+		t.Fatalf("LoadProject() error: %v", err)
+	}
+	for i, f := range loaded.Files {
+		if f.Path != project.Files[i].Path {
+			t.Errorf("Files[%d].Path = %q, want %q", i, f.Path, project.Files[i].Path)
+		}
+	}
+	if loaded.SVD != project.SVD || loaded.LinkerMap != project.LinkerMap {
+		t.Errorf("SVD, LinkerMap = %q, %q, want %q, %q", loaded.SVD, loaded.LinkerMap, project.SVD, project.LinkerMap)
+	}
+	if len(loaded.Profiles) != 1 || len(loaded.Profiles[0].Registers) != 1 || loaded.ActiveProfile != "inverter" {
+		t.Errorf("Unexpected profiles: %+v", loaded.Profiles)
+	}
+	if len(loaded.Schemas) != 1 || len(loaded.Bookmarks) != 1 || loaded.Bookmarks[0].Label != "Setpoint" {
+		t.Errorf("Unexpected schemas or bookmarks: %+v, %+v", loaded.Schemas, loaded.Bookmarks)
+	}
+}
+
+func TestSaveProject_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name   string
+		modify func(*models.Project)
+	}{
+		{"no name", func(p *models.Project) { p.Name = " " }},
+		{"file without path", func(p *models.Project) { p.Files = append(p.Files, models.ProjectFile{Name: "empty"}) }},
+		{"linker map and elf", func(p *models.Project) { p.ELFSymbols = "firmware.elf" }},
+		{"schema without name", func(p *models.Project) { p.Schemas[0].Name = "" }},
+		{"duplicate schema", func(p *models.Project) { p.Schemas = append(p.Schemas, p.Schemas[0]) }},
+		{"invalid layout", func(p *models.Project) { p.Schemas[0].Layout = "magic u33" }},
+		{"invalid bookmark", func(p *models.Project) { p.Bookmarks[0].Mode = "bogus" }},
+		{"invalid profile", func(p *models.Project) { p.Profiles[0].Registers[0].Type = "int128" }},
+		{"duplicate profile", func(p *models.Project) { p.Profiles = append(p.Profiles, p.Profiles[0]) }},
+		{"unknown active profile", func(p *models.Project) { p.ActiveProfile = "boiler" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testProject(dir)
+			tt.modify(&p)
+			path := filepath.Join(dir, "invalid"+ProjectExtension)
+			if err := SaveProject(path, p); !errors.Is(err, ErrInvalidProject) {
+				t.Errorf("SaveProject() error = %v, want ErrInvalidProject", err)
+			}
+		})
+	}
+}
+
+func TestLoadProject_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"not json", "{"},
+		{"no version", `{"name": "old"}`},
+		{"newer version", `{"version": 99, "name": "future"}`},
+		{"invalid bookmark", `{"version": 1, "name": "p", "bookmarks": [{"label": "x", "mode": "hex"}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "p"+ProjectExtension)
+			if err := os.WriteFile(path, []byte(tt.data), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadProject(path); !errors.Is(err, ErrInvalidProject) {
+				t.Errorf("LoadProject() error = %v, want ErrInvalidProject", err)
+			}
+		})
+	}
+
+	if _, err := LoadProject(filepath.Join(dir, "missing"+ProjectExtension)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("LoadProject(missing) error = %v, want os.ErrNotExist", err)
+	}
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"hexview/models"
+)
+
+// ErrModbusV2 indicates a v2 result was requested for Modbus input, which
+// has a result model of its own
+var ErrModbusV2 = errors.New("no v2 conversion result for Modbus input")
+
+// byteOrders are the byte order suffixes of ConversionResult fields.
+var byteOrders = []string{"BADC", "CDAB", "BE", "LE"}
+
+// resultField is a value field of the flat ConversionResult, e.g. Int32LE,
+// with the indexes of its hex and binary fields (Int32LEHex, Int32LEBin).
+type resultField struct {
+	index, hexIndex, binIndex int
+	name                      string // JSON name, e.g. int32LE
+	typ, order                string // field name of the ConversionResultV2 group and byte order
+}
+
+// resultFields lists the value fields of ConversionResult in declaration
+// order. They are found by name, so new types or byte orders only need
+// matching fields in both result models.
+var resultFields = func() []resultField {
+	var fields []resultField
+	flat := reflect.TypeFor[models.ConversionResult]()
+	grouped := reflect.TypeFor[models.ConversionResultV2]()
+	for i := 0; i < flat.NumField(); i++ {
+		f := flat.Field(i)
+		if f.Type.Kind() != reflect.Pointer {
+			continue
+		}
+		for _, order := range byteOrders {
+			typ, ok := strings.CutSuffix(f.Name, order)
+			if !ok {
+				continue
+			}
+			hex, hasHex := flat.FieldByName(f.Name + "Hex")
+			bin, hasBin := flat.FieldByName(f.Name + "Bin")
+			if _, hasGroup := grouped.FieldByName(typ); hasHex && hasBin && hasGroup {
+				name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+				fields = append(fields, resultField{
+					index: i, hexIndex: hex.Index[0], binIndex: bin.Index[0],
+					name: name, typ: typ, order: order,
+				})
+			}
+			break
+		}
+	}
+	return fields
+}()
+
+// GroupResult converts a flat result to the grouped v2 schema.
+func GroupResult(r *models.ConversionResult) *models.ConversionResultV2 {
+	g := &models.ConversionResultV2{
+		Version:       models.ResultVersion2,
+		Binary:        r.Binary,
+		Bytes:         r.Bytes,
+		ASCII:         r.ASCII,
+		Base64:        r.Base64,
+		Base32:        r.Base32,
+		BCD:           r.BCD,
+		BCDUnpacked:   r.BCDUnpacked,
+		IPv4:          r.IPv4,
+		IPv6:          r.IPv6,
+		MAC:           r.MAC,
+		Text:          r.Text,
+		Strings:       r.Strings,
+		Canonical:     r.Canonical,
+		InputEncoding: r.InputEncoding,
+		Truncated:     r.Truncated,
+		TotalLength:   r.TotalLength,
+		Enums:         r.Enums,
+		Regions:       r.Regions,
+		Symbols:       r.Symbols,
+		NaNs:          r.NaNs,
+		Rounding:      r.Rounding,
+		Scripts:       r.Scripts,
+		Sections:      r.Sections,
+	}
+
+	if r.BitFlagsBE != nil || r.BitFlagsLE != nil {
+		g.BitFlags = map[string]*models.BitFlags{"BE": r.BitFlagsBE, "LE": r.BitFlagsLE}
+	}
+
+	src := reflect.ValueOf(r).Elem()
+	dst := reflect.ValueOf(g).Elem()
+	for _, f := range resultFields {
+		v := src.Field(f.index)
+		if v.IsNil() {
+			continue
+		}
+		group := dst.FieldByName(f.typ)
+		if group.IsNil() {
+			group.Set(reflect.MakeMap(group.Type()))
+		}
+		tv := models.TypedValue{
+			Value: fmt.Sprint(v.Elem().Interface()),
+			Hex:   src.Field(f.hexIndex).String(),
+			Bin:   src.Field(f.binIndex).String(),
+		}
+		group.SetMapIndex(reflect.ValueOf(f.order), reflect.ValueOf(tv))
+	}
+	return g
+}
+
+// FlattenResult converts a grouped v2 result back to the flat schema of the
+// existing bindings. Values the flat schema has no field for are dropped.
+func FlattenResult(g *models.ConversionResultV2) (*models.ConversionResult, error) {
+	r := &models.ConversionResult{
+		Binary:        g.Binary,
+		Bytes:         g.Bytes,
+		ASCII:         g.ASCII,
+		Base64:        g.Base64,
+		Base32:        g.Base32,
+		BCD:           g.BCD,
+		BCDUnpacked:   g.BCDUnpacked,
+		IPv4:          g.IPv4,
+		IPv6:          g.IPv6,
+		MAC:           g.MAC,
+		Text:          g.Text,
+		Strings:       g.Strings,
+		Canonical:     g.Canonical,
+		InputEncoding: g.InputEncoding,
+		Truncated:     g.Truncated,
+		TotalLength:   g.TotalLength,
+		Enums:         g.Enums,
+		Regions:       g.Regions,
+		Symbols:       g.Symbols,
+		NaNs:          g.NaNs,
+		Rounding:      g.Rounding,
+		Scripts:       g.Scripts,
+		Sections:      g.Sections,
+	}
+
+	r.BitFlagsBE, r.BitFlagsLE = g.BitFlags["BE"], g.BitFlags["LE"]
+
+	src := reflect.ValueOf(g).Elem()
+	dst := reflect.ValueOf(r).Elem()
+	for _, f := range resultFields {
+		tv, ok := src.FieldByName(f.typ).Interface().(map[string]models.TypedValue)[f.order]
+		if !ok {
+			continue
+		}
+		field := dst.Field(f.index)
+		v := reflect.New(field.Type().Elem())
+		if err := setValue(v.Elem(), tv.Value); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", f.typ, f.order, err)
+		}
+		field.Set(v)
+		dst.Field(f.hexIndex).SetString(tv.Hex)
+		dst.Field(f.binIndex).SetString(tv.Bin)
+	}
+	return r, nil
+}
+
+// setValue parses s into v, an integer or a string (floats).
+func setValue(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	default:
+		v.SetString(s)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"hexview/models"
+)
+
+func TestGroupResult(t *testing.T) {
+	c := NewConverter()
+	r, err := c.ConvertHex("42480000")
+	if err != nil {
+		t.Fatalf("ConvertHex() error: %v", err)
+	}
+	g := GroupResult(r)
+
+	if g.Version != models.ResultVersion2 || g.Bytes != "42480000" {
+		t.Errorf("Unexpected header: version %d, bytes %s", g.Version, g.Bytes)
+	}
+	if g.Int8 != nil {
+		t.Errorf("Expected no int8 values, got %v", g.Int8)
+	}
+	want := map[string]models.TypedValue{
+		"BE":   {Value: "1112014848", Hex: "42480000", Bin: "01000010 01001000 00000000 00000000"},
+		"LE":   {Value: "18498", Hex: "00004842", Bin: "00000000 00000000 01001000 01000010"},
+		"BADC": {Value: "1212284928", Hex: "48420000", Bin: "01001000 01000010 00000000 00000000"},
+		"CDAB": {Value: "16968", Hex: "00004248", Bin: "00000000 00000000 01000010 01001000"},
+	}
+	if !reflect.DeepEqual(g.Uint32, want) {
+		t.Errorf("Uint32 = %v, want %v", g.Uint32, want)
+	}
+	if v := g.Float32["BE"]; v.Value != "50" || v.Hex != "42480000" {
+		t.Errorf("Float32[BE] = %+v", v)
+	}
+}
+
+func TestFlattenResult(t *testing.T) {
+	c := NewConverter()
+	for _, input := range []string{"ff", "0102", "42480000", "4048f5c3c28f5c29", "7ff8000000000001"} {
+		r, err := c.ConvertHex(input)
+		if err != nil {
+			t.Fatalf("ConvertHex(%s) error: %v", input, err)
+		}
+		got, err := FlattenResult(GroupResult(r))
+		if err != nil {
+			t.Fatalf("FlattenResult(%s) error: %v", input, err)
+		}
+		if !reflect.DeepEqual(got, r) {
+			t.Errorf("FlattenResult(GroupResult(%s)) = %+v, want %+v", input, got, r)
+		}
+	}
+
+	bad := &models.ConversionResultV2{Int8: map[string]models.TypedValue{"BE": {Value: "300"}}}
+	if _, err := FlattenResult(bad); err == nil {
+		t.Error("Expected error for out of range int8 value")
+	}
+}
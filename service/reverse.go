@@ -0,0 +1,165 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+// ErrUnknownField indicates a field name that is not a value of
+// ConversionResult
+var ErrUnknownField = errors.New("unknown result field")
+
+// ConvertField is the reverse of a conversion: it finds the bytes for which
+// the field of ConversionResult named by its JSON name (e.g. "float32LE")
+// has value and converts them like hex input, so editing any value updates
+// all others. "bytes" and "binary" take hex and binary input.
+func (c *Converter) ConvertField(field, value string) (*models.ConversionResult, error) {
+	switch field {
+	case "bytes":
+		return c.ConvertHex(value)
+	case "binary":
+		return c.ConvertBinary(value)
+	}
+
+	i := fieldIndex(field)
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownField, field)
+	}
+	bytes, err := fieldBytes(resultFields[i], value)
+	if err != nil {
+		return nil, err
+	}
+	result := bytesResult(bytes, allSections)
+	result.Canonical = canonicalHex(bytes)
+	return result, nil
+}
+
+// fieldIndex returns the index in resultFields of the field with JSON name
+// name, or -1.
+func fieldIndex(name string) int {
+	for i, f := range resultFields {
+		if f.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// fieldBytes returns the bytes that read as value in the type and byte
+// order of f.
+func fieldBytes(f resultField, value string) ([]byte, error) {
+	s := strings.TrimSpace(value)
+	if s == "" {
+		return nil, errEmptyInput()
+	}
+	order, _ := parseByteOrder(f.order)
+	label := strings.ToLower(f.typ)
+
+	switch f.typ {
+	case "Float16":
+		v, err := strconv.ParseFloat(s, 32)
+		if err == nil && !math.IsInf(v, 0) && convert.Float16bits(float32(v))&0x7fff == 0x7c00 {
+			err = strconv.ErrRange // beyond ±65504
+		}
+		if err != nil {
+			return nil, numberError(value, label, true, err)
+		}
+		return convert.Float16ToBytes(float32(v), order), nil
+	case "Float32":
+		v, err := strconv.ParseFloat(s, 32)
+		if err != nil {
+			return nil, numberError(value, label, true, err)
+		}
+		return convert.Float32ToBytes(float32(v), order), nil
+	case "Float64":
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, numberError(value, label, true, err)
+		}
+		return convert.Float64ToBytes(v, order), nil
+	}
+
+	typ := reflect.TypeFor[models.ConversionResult]().Field(f.index).Type.Elem()
+	if strings.HasPrefix(f.typ, "OnesComplement") || strings.HasPrefix(f.typ, "SignMagnitude") {
+		v, err := strconv.ParseInt(s, 10, typ.Bits())
+		if err != nil {
+			return nil, numberError(value, label, false, err)
+		}
+		return legacySignedBytes(f.typ, v, typ.Bits(), order)
+	}
+	switch typ.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(s, 10, typ.Bits())
+		if err != nil {
+			return nil, numberError(value, label, false, err)
+		}
+		switch typ.Bits() {
+		case 8:
+			return convert.Int8ToBytes(int8(v)), nil
+		case 16:
+			return convert.Int16ToBytes(int16(v), order), nil
+		case 32:
+			return convert.Int32ToBytes(int32(v), order), nil
+		}
+		return convert.Int64ToBytes(v, order), nil
+	default:
+		v, err := strconv.ParseUint(s, 10, typ.Bits())
+		if err != nil {
+			return nil, numberError(value, label, false, err)
+		}
+		switch typ.Bits() {
+		case 8:
+			return convert.Uint8ToBytes(uint8(v)), nil
+		case 16:
+			return convert.Uint16ToBytes(uint16(v), order), nil
+		case 32:
+			return convert.Uint32ToBytes(uint32(v), order), nil
+		}
+		return convert.Uint64ToBytes(v, order), nil
+	}
+}
+
+// legacySignedBytes encodes v as a one's complement or sign-magnitude
+// integer of the type typ (e.g. OnesComplement16) in order.
+func legacySignedBytes(typ string, v int64, bits int, order convert.ByteOrder) ([]byte, error) {
+	var h string
+	var err error
+	ones := strings.HasPrefix(typ, "OnesComplement")
+	switch {
+	case bits == 8 && ones:
+		h, err = convert.OnesComplement8ToHex(int8(v))
+	case bits == 8:
+		h, err = convert.SignMagnitude8ToHex(int8(v))
+	case bits == 16 && ones:
+		h, err = convert.OnesComplement16ToHex(int16(v))
+	case bits == 16:
+		h, err = convert.SignMagnitude16ToHex(int16(v))
+	case bits == 32 && ones:
+		h, err = convert.OnesComplement32ToHex(int32(v))
+	case bits == 32:
+		h, err = convert.SignMagnitude32ToHex(int32(v))
+	case ones:
+		h, err = convert.OnesComplement64ToHex(v)
+	default:
+		h, err = convert.SignMagnitude64ToHex(v)
+	}
+	if err != nil {
+		return nil, err
+	}
+	b, err := convert.HexToBytes(h)
+	if err != nil {
+		return nil, err
+	}
+	if order == convert.LittleEndian {
+		slices.Reverse(b)
+	}
+	return b, nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"hexview/convert"
+)
+
+func TestConvertField(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		field, value, want string
+	}{
+		{"float32LE", "50", "00004842"},
+		{"float32CDAB", "50", "00004248"},
+		{"float64BE", "-1.5", "bff8000000000000"},
+		{"float16LE", "1.5", "003e"},
+		{"int16BE", "-2", "fffe"},
+		{"uint16LE", "258", "0201"},
+		{"int8LE", "-1", "ff"},
+		{"uint32BADC", "16909060", "02010403"},
+		{"int64CDAB", " 72623859790382856 ", "0304010207080506"},
+		{"bytes", "0x01 0x02", "0102"},
+		{"binary", "00000011", "03"},
+	}
+	for _, tt := range tests {
+		result, err := c.ConvertField(tt.field, tt.value)
+		if err != nil {
+			t.Errorf("ConvertField(%s, %q) error: %v", tt.field, tt.value, err)
+			continue
+		}
+		if result.Bytes != tt.want {
+			t.Errorf("ConvertField(%s, %q) = %s, want %s", tt.field, tt.value, result.Bytes, tt.want)
+		}
+	}
+
+	errTests := []struct {
+		field, value string
+		want         error
+	}{
+		{"int32", "1", ErrUnknownField},
+		{"uint8BE", "256", ErrOutOfRange},
+		{"uint16LE", "-1", ErrInvalidNumber},
+		{"float32BE", "1.5x", ErrInvalidNumber},
+		{"float16BE", "70000", ErrOutOfRange},
+		{"int16BE", " ", convert.ErrEmptyInput},
+	}
+	for _, tt := range errTests {
+		if _, err := c.ConvertField(tt.field, tt.value); !errors.Is(err, tt.want) {
+			t.Errorf("ConvertField(%s, %q) error = %v, want %v", tt.field, tt.value, err, tt.want)
+		}
+	}
+}
+
+// TestConvertFieldRoundTrip checks that every value of a result converts
+// back to bytes with the same value.
+func TestConvertFieldRoundTrip(t *testing.T) {
+	c := NewConverter()
+	for _, input := range []string{"9c", "8001", "c0490fdb", "0102030405060708"} {
+		result, err := c.ConvertHex(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := reflect.ValueOf(result).Elem()
+		for _, f := range resultFields {
+			v := r.Field(f.index)
+			if v.IsNil() {
+				continue
+			}
+			value := fmt.Sprint(v.Elem().Interface())
+			back, err := c.ConvertField(f.name, value)
+			if err != nil {
+				t.Errorf("%s: ConvertField(%s, %s) error: %v", input, f.name, value, err)
+				continue
+			}
+			if got := reflect.ValueOf(back).Elem().Field(f.index); got.IsNil() || fmt.Sprint(got.Elem().Interface()) != value {
+				t.Errorf("%s: ConvertField(%s, %s) gives bytes %s", input, f.name, value, back.Bytes)
+			}
+		}
+	}
+}
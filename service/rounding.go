@@ -0,0 +1,47 @@
+package service
+
+import (
+	"math"
+	"math/big"
+	"strings"
+
+	"hexview/models"
+)
+
+// floatRounding reports how a decimal float input is stored as float32 and
+// float64, e.g. that 0.1 is 0.100000001490116119384765625 as float32. It
+// returns nil for inputs that are not a plain number, like NaN or Inf.
+func floatRounding(input string) []models.FloatRounding {
+	s := strings.TrimSpace(input)
+	if strings.Contains(s, "/") {
+		return nil // big.Rat would read fractions, ConvertFloat does not
+	}
+	exact, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil
+	}
+	f32, _ := exact.Float32()
+	f64, _ := exact.Float64()
+	return []models.FloatRounding{
+		storedFloat(string(models.Float32), float64(f32), exact),
+		storedFloat(string(models.Float64), f64, exact),
+	}
+}
+
+// storedFloat compares the stored value v of a float type with the exact
+// input value.
+func storedFloat(typ string, v float64, exact *big.Rat) models.FloatRounding {
+	r := models.FloatRounding{Type: typ}
+	if math.IsInf(v, 0) {
+		r.Stored = formatFloat64(v)
+		return r
+	}
+	// A float is a binary fraction, so it has a finite decimal expansion of
+	// at most 767 significant digits
+	r.Stored = new(big.Float).SetFloat64(v).Text('g', 800)
+	diff := new(big.Rat).SetFloat64(v)
+	diff.Sub(diff, exact)
+	r.Exact = diff.Sign() == 0
+	r.Error = new(big.Float).SetRat(diff).Text('g', 10)
+	return r
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"testing"
+
+	"hexview/models"
+)
+
+func TestFloatRounding(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []models.FloatRounding
+	}{
+		{"0.1", "0.1", []models.FloatRounding{
+			{Type: "float32", Stored: "0.100000001490116119384765625", Error: "1.490116119e-09"},
+			{Type: "float64", Stored: "0.1000000000000000055511151231257827021181583404541015625", Error: "5.551115123e-18"},
+		}},
+		{"exact", " -2.5 ", []models.FloatRounding{
+			{Type: "float32", Stored: "-2.5", Error: "0", Exact: true},
+			{Type: "float64", Stored: "-2.5", Error: "0", Exact: true},
+		}},
+		{"rounds down", "16777217", []models.FloatRounding{
+			{Type: "float32", Stored: "16777216", Error: "-1"},
+			{Type: "float64", Stored: "16777217", Error: "0", Exact: true},
+		}},
+		{"float32 overflow", "1e39", []models.FloatRounding{
+			{Type: "float32", Stored: "+Inf"},
+			{Type: "float64", Stored: "999999999999999939709166371603178586112", Error: "-6.029083363e+22"},
+		}},
+		{"nan", "NaN", nil},
+		{"fraction", "1/3", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := floatRounding(tt.input)
+			if len(got) != len(tt.want) {
+				t.Fatalf("floatRounding(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("floatRounding(%q)[%d] = %+v, want %+v", tt.input, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestConvertFloat_Rounding(t *testing.T) {
+	c := NewConverter()
+	result, err := c.ConvertFloat("0.1", models.Float32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Rounding) != 2 || result.Rounding[0].Stored != "0.100000001490116119384765625" {
+		t.Errorf("Rounding = %+v, want float32 and float64 stored values", result.Rounding)
+	}
+	if g := GroupResult(result); len(g.Rounding) != 2 {
+		t.Errorf("GroupResult().Rounding = %+v, want it kept", g.Rounding)
+	}
+}
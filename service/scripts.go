@@ -0,0 +1,319 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"hexview/convert"
+	"hexview/models"
+	"hexview/script"
+)
+
+const (
+	// scriptExt is the file extension of user scripts.
+	scriptExt = ".star"
+
+	// MaxModbusScriptRows limits the windows a script interprets in one result.
+	MaxModbusScriptRows = 1024
+)
+
+var (
+	// ErrScriptNotFound indicates an unknown script name was used
+	ErrScriptNotFound = errors.New("script not found")
+
+	// ErrInvalidScriptName indicates a script name that cannot be used as a file name
+	ErrInvalidScriptName = errors.New("invalid script name")
+)
+
+// scriptNamePattern restricts script names to safe file names.
+var scriptNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// ScriptService manages user scripts stored as *.star files in a directory.
+// Each successfully compiled script adds a section to conversion results,
+// or a table to Modbus results if it interprets register windows.
+// With an empty dir scripts are kept in memory only.
+type ScriptService struct {
+	mu      sync.Mutex
+	dir     string
+	scripts map[string]*userScript
+}
+
+// userScript is a loaded script. compiled is nil if the source has errors.
+type userScript struct {
+	source   string
+	compiled *script.Script
+	err      error
+}
+
+// NewScriptService creates an empty ScriptService for the scripts in dir.
+// Call Load to read them.
+func NewScriptService(dir string) *ScriptService {
+	return &ScriptService{dir: dir, scripts: make(map[string]*userScript)}
+}
+
+// Load (re)reads all scripts from the directory. Scripts that do not compile
+// are kept so they can be fixed, and are reported as errors. A missing
+// directory is not an error.
+func (s *ScriptService) Load() []error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.scripts)
+	if s.dir == "" {
+		return nil
+	}
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return []error{fmt.Errorf("cannot read script directory: %w", err)}
+	}
+
+	var errs []error
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), scriptExt)
+		if e.IsDir() || !ok || !scriptNamePattern.MatchString(name) {
+			continue
+		}
+		src, err := os.ReadFile(filepath.Join(s.dir, e.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		us := compileScript(name, string(src))
+		if us.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.Name(), us.err))
+		}
+		s.scripts[name] = us
+	}
+	return errs
+}
+
+// Dir returns the directory scripts are stored in.
+func (s *ScriptService) Dir() string {
+	return s.dir
+}
+
+// List returns all scripts sorted by name.
+func (s *ScriptService) List() []models.ScriptInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]models.ScriptInfo, 0, len(s.scripts))
+	for name, us := range s.scripts {
+		info := models.ScriptInfo{Name: name, Path: s.path(name)}
+		if us.err != nil {
+			info.Error = us.err.Error()
+		}
+		list = append(list, info)
+	}
+	slices.SortFunc(list, func(a, b models.ScriptInfo) int { return strings.Compare(a.Name, b.Name) })
+	return list
+}
+
+// Source returns the source code of a script.
+func (s *ScriptService) Source(name string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	us, ok := s.scripts[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrScriptNotFound, name)
+	}
+	return us.source, nil
+}
+
+// Save compiles source and stores it under name, replacing an existing
+// script. Scripts that do not compile are rejected.
+func (s *ScriptService) Save(name, source string) (*models.ScriptInfo, error) {
+	if !scriptNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("%w: %q (use letters, digits, '-' and '_')", ErrInvalidScriptName, name)
+	}
+	us := compileScript(name, source)
+	if us.err != nil {
+		return nil, us.err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.dir != "" {
+		if err := os.MkdirAll(s.dir, 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(s.path(name), []byte(source), 0o644); err != nil {
+			return nil, err
+		}
+	}
+	s.scripts[name] = us
+	return &models.ScriptInfo{Name: name, Path: s.path(name)}, nil
+}
+
+// Delete removes a script and its file.
+func (s *ScriptService) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.scripts[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrScriptNotFound, name)
+	}
+	if s.dir != "" {
+		if err := os.Remove(s.path(name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	delete(s.scripts, name)
+	return nil
+}
+
+// Run runs a single script on hex input.
+func (s *ScriptService) Run(name, hexInput string) (*models.ScriptSection, error) {
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	us, ok := s.scripts[name]
+	s.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrScriptNotFound, name)
+	}
+	section := runScript(name, us, data)
+	return &section, nil
+}
+
+// Apply runs all compiled scripts defining decode(data) on the bytes of
+// result and appends their sections in name order. Failing scripts add a
+// section with an error.
+func (s *ScriptService) Apply(result *models.ConversionResult) {
+	if result == nil || result.Bytes == "" {
+		return
+	}
+	names, scripts := s.compiled(func(c *script.Script) bool { return c.HasDecode() })
+	if len(names) == 0 {
+		return
+	}
+
+	data, err := convert.HexToBytes(result.Bytes)
+	if err != nil {
+		return
+	}
+	for _, name := range names {
+		result.Scripts = append(result.Scripts, runScript(name, scripts[name], data))
+	}
+}
+
+// ApplyModbus runs all compiled scripts defining modbus(data) on every
+// window of consecutive registers of result and appends their tables in
+// name order, like the 32- and 64-bit combinations. At most
+// MaxModbusScriptRows windows are interpreted per script.
+func (s *ScriptService) ApplyModbus(result *models.ModbusResult) {
+	if result == nil || len(result.Registers) == 0 {
+		return
+	}
+	names, scripts := s.compiled(func(c *script.Script) bool { return c.Window() > 0 })
+	for _, name := range names {
+		result.Scripts = append(result.Scripts, runModbusScript(name, scripts[name].compiled, result.Registers))
+	}
+}
+
+// compiled returns the compiled scripts matching keep and their sorted names.
+func (s *ScriptService) compiled(keep func(*script.Script) bool) ([]string, map[string]*userScript) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var names []string
+	scripts := make(map[string]*userScript)
+	for name, us := range s.scripts {
+		if us.compiled != nil && keep(us.compiled) {
+			names = append(names, name)
+			scripts[name] = us
+		}
+	}
+	slices.Sort(names)
+	return names, scripts
+}
+
+// path returns the file of a script, or "" when scripts are kept in memory.
+func (s *ScriptService) path(name string) string {
+	if s.dir == "" {
+		return ""
+	}
+	return filepath.Join(s.dir, name+scriptExt)
+}
+
+// compileScript compiles source, recording the error on failure.
+func compileScript(name, source string) *userScript {
+	compiled, err := script.Compile(name, source)
+	return &userScript{source: source, compiled: compiled, err: err}
+}
+
+// runScript runs a compiled script on data and converts its values to a section.
+func runScript(name string, us *userScript, data []byte) models.ScriptSection {
+	section := models.ScriptSection{Name: name}
+	if us.err != nil {
+		section.Error = us.err.Error()
+		return section
+	}
+	values, err := us.compiled.Run(data)
+	if err != nil {
+		section.Error = err.Error()
+		return section
+	}
+	section.Values = scriptValues(values)
+	return section
+}
+
+// runModbusScript runs modbus(data) on every window of consecutive registers.
+// Windows spanning a gap in the register addresses are skipped.
+func runModbusScript(name string, compiled *script.Script, registers []models.ModbusRegister) models.ModbusScriptTable {
+	window := compiled.Window()
+	table := models.ModbusScriptTable{Name: name, Window: window}
+	for i := 0; i+window <= len(registers) && len(table.Rows) < MaxModbusScriptRows; i++ {
+		regs := registers[i : i+window]
+		if !consecutiveRegisters(regs) {
+			continue
+		}
+		data := make([]byte, 0, 2*window)
+		for _, r := range regs {
+			data = append(data, byte(r.Unsigned>>8), byte(r.Unsigned))
+		}
+		row := models.ModbusScriptRow{RegisterStart: i + 1, Address: regs[0].Address}
+		values, err := compiled.RunModbus(data)
+		if err != nil {
+			row.Error = err.Error()
+		} else {
+			row.Values = scriptValues(values)
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
+
+// consecutiveRegisters reports whether the addresses of regs, if known, are
+// consecutive.
+func consecutiveRegisters(regs []models.ModbusRegister) bool {
+	for i := 1; i < len(regs); i++ {
+		prev, cur := regs[i-1].Address, regs[i].Address
+		if prev != nil && cur != nil && *cur != *prev+1 {
+			return false
+		}
+	}
+	return true
+}
+
+// scriptValues converts the values of a script run to their models.
+func scriptValues(values []script.Value) []models.ScriptValue {
+	out := make([]models.ScriptValue, len(values))
+	for i, v := range values {
+		out[i] = models.ScriptValue{Name: v.Name, Value: v.Value}
+	}
+	return out
+}
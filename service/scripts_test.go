@@ -0,0 +1,135 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testScript = `
+def decode(data):
+    return {"first": unpack("B", data)[0], "length": len(data)}
+`
+
+func TestScriptService(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "broken.star"), []byte("def decode(data)\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o644)
+
+	s := NewScriptService(dir)
+	if errs := s.Load(); len(errs) != 1 {
+		t.Fatalf("Load() errors = %v, want one compile error", errs)
+	}
+	if _, err := s.Save("first", testScript); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "first.star")); err != nil {
+		t.Fatalf("script file not written: %v", err)
+	}
+
+	list := s.List()
+	if len(list) != 2 || list[0].Name != "broken" || list[0].Error == "" || list[1].Name != "first" || list[1].Error != "" {
+		t.Fatalf("List() = %+v", list)
+	}
+
+	section, err := s.Run("first", "2a 00")
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if len(section.Values) != 2 || section.Values[0].Value != "42" || section.Values[1].Value != "2" {
+		t.Errorf("Run() = %+v", section)
+	}
+
+	result, err := NewConverter().ConvertHex("2a00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Apply(result)
+	if len(result.Scripts) != 1 || result.Scripts[0].Name != "first" || result.Scripts[0].Values[0].Value != "42" {
+		t.Errorf("Apply() sections = %+v", result.Scripts)
+	}
+
+	reloaded := NewScriptService(dir)
+	reloaded.Load()
+	if src, err := reloaded.Source("first"); err != nil || src != testScript {
+		t.Errorf("Source() after reload = %q, %v", src, err)
+	}
+
+	if err := s.Delete("first"); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "first.star")); !os.IsNotExist(err) {
+		t.Error("script file not removed")
+	}
+	if err := s.Delete("first"); !errors.Is(err, ErrScriptNotFound) {
+		t.Errorf("Expected ErrScriptNotFound, got %v", err)
+	}
+}
+
+func TestScriptService_SaveErrors(t *testing.T) {
+	s := NewScriptService("")
+	tests := []struct {
+		name   string
+		script string
+		source string
+	}{
+		{"path traversal", "../evil", testScript},
+		{"empty name", "", testScript},
+		{"compile error", "ok", "decode = 1\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Save(tt.script, tt.source); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+	if len(s.List()) != 0 {
+		t.Error("rejected scripts must not be stored")
+	}
+	if _, err := s.Run("missing", "00"); !errors.Is(err, ErrScriptNotFound) {
+		t.Errorf("Expected ErrScriptNotFound, got %v", err)
+	}
+}
+
+func TestScriptService_ApplyRuntimeError(t *testing.T) {
+	s := NewScriptService("")
+	s.Save("wide", "def decode(data):\n    return {'v': unpack('>Q', data)[0]}\n")
+
+	result, _ := NewConverter().ConvertHex("01")
+	s.Apply(result)
+	if len(result.Scripts) != 1 || result.Scripts[0].Error == "" {
+		t.Errorf("Expected section with error, got %+v", result.Scripts)
+	}
+}
+
+func TestScriptService_ApplyModbus(t *testing.T) {
+	s := NewScriptService("")
+	s.Save("decoder", testScript)
+	s.Save("meter", "window = 2\ndef modbus(data):\n    high, low = unpack('>HH', data)\n    return {'kWh': high * 1000 + low}\n")
+
+	result, err := NewConverter().ConvertModbusCSV("1,1\n2,500\n3,2\n5,7\n6,8\n")
+	if err != nil {
+		t.Fatalf("ConvertModbusCSV() error: %v", err)
+	}
+	s.ApplyModbus(result)
+	if len(result.Scripts) != 1 {
+		t.Fatalf("Scripts = %+v, want only the meter table", result.Scripts)
+	}
+	table := result.Scripts[0]
+	// The window from address 3 to 5 spans a gap and is skipped
+	want := []struct {
+		address int
+		value   string
+	}{{1, "1500"}, {2, "500002"}, {5, "7008"}}
+	if table.Name != "meter" || table.Window != 2 || len(table.Rows) != len(want) {
+		t.Fatalf("table = %+v", table)
+	}
+	for i, w := range want {
+		row := table.Rows[i]
+		if row.Address == nil || *row.Address != w.address || len(row.Values) != 1 || row.Values[0].Value != w.value {
+			t.Errorf("row %d = %+v, want address %d value %s", i, row, w.address, w.value)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+// ErrUnknownSection indicates an unknown result section was requested
+var ErrUnknownSection = errors.New("unknown section")
+
+// Result sections computed on demand by ConvertHexSections and
+// ConvertModbusSections. Bytes, binary, ASCII, big- and little-endian
+// integers and single registers are always computed.
+const (
+	SectionMidEndian = "midEndian" // BADC and CDAB integers and floats
+	SectionFloat     = "float"     // float32 and float64 interpretations
+	SectionModbus32  = "modbus32"  // 32-bit register combinations
+	SectionModbus64  = "modbus64"  // 64-bit register combinations
+
+	// One's complement and sign-magnitude integers of legacy protocols
+	SectionLegacySigned = "legacySigned"
+
+	// The bits of inputs up to 64 bits, e.g. of a status register
+	SectionBitFlags = "bitFlags"
+)
+
+// Sections lists the result sections that can be computed on demand.
+var Sections = []string{SectionMidEndian, SectionFloat, SectionModbus32, SectionModbus64, SectionLegacySigned, SectionBitFlags}
+
+// sectionSet is a set of on-demand sections, one bit per entry of Sections.
+type sectionSet uint8
+
+const (
+	conversionSections = 1<<0 | 1<<1 | 1<<4 | 1<<5 // sections of a ConversionResult
+	modbusSections     = 1<<2 | 1<<3               // sections of a ModbusResult
+
+	allSections sectionSet = conversionSections | modbusSections
+)
+
+// parseSections returns the set of the named sections.
+func parseSections(names []string) (sectionSet, error) {
+	var set sectionSet
+	for _, name := range names {
+		i := indexOf(Sections, name)
+		if i < 0 {
+			return 0, fmt.Errorf("%w: %q", ErrUnknownSection, name)
+		}
+		set |= 1 << i
+	}
+	return set, nil
+}
+
+// has reports whether the set contains the named section.
+func (s sectionSet) has(name string) bool {
+	i := indexOf(Sections, name)
+	return i >= 0 && s&(1<<i) != 0
+}
+
+// names returns the sections of the set in the order of Sections.
+func (s sectionSet) names() []string {
+	names := make([]string, 0, len(Sections))
+	for i, name := range Sections {
+		if s&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ConvertHexSections converts hex input like ConvertHex but computes only
+// the requested on-demand sections, e.g. none when just the integers are
+// shown. result.Sections lists the computed ones, so the remaining sections
+// can be requested later.
+func (c *Converter) ConvertHexSections(hexInput string, sections []string) (*models.ConversionResult, error) {
+	set, err := parseSections(sections)
+	if err != nil {
+		return nil, err
+	}
+	if hexInput == "" {
+		return nil, errEmptyInput()
+	}
+	bytes, err := convert.HexToBytes(hexInput)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex input: %w", err)
+	}
+	result := bytesResult(bytes, set)
+	result.Canonical = canonicalHex(bytes)
+	result.Sections = (set & conversionSections).names()
+	return result, nil
+}
+
+// ConvertModbusSections converts registers like ConvertModbusRegisters but
+// computes the 32- and 64-bit combinations only if requested.
+// result.Sections lists the computed ones.
+func (c *Converter) ConvertModbusSections(input string, sections []string) (*models.ModbusResult, error) {
+	set, err := parseSections(sections)
+	if err != nil {
+		return nil, err
+	}
+	if input == "" {
+		return nil, errEmptyInput()
+	}
+	registers, err := parseModbusInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(registers) == 0 {
+		return nil, errNoRegisters()
+	}
+	result := modbusBatch(registers, 0, len(registers), set)
+	result.Sections = (set & modbusSections).names()
+	return result, nil
+}
+
+// indexOf returns the index of s in list, or -1.
+func indexOf(list []string, s string) int {
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestConvertHexSections(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		name      string
+		sections  []string
+		wantMid   bool
+		wantFloat bool
+	}{
+		{"none", nil, false, false},
+		{"mid-endian", []string{SectionMidEndian}, true, false},
+		{"float", []string{SectionFloat}, false, true},
+		{"all", []string{SectionFloat, SectionMidEndian, SectionModbus32}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := c.ConvertHexSections("42480000", tt.sections)
+			if err != nil {
+				t.Fatalf("ConvertHexSections() error = %v", err)
+			}
+			if r.Uint32BE == nil || *r.Uint32BE != 0x42480000 || r.Int32LE == nil {
+				t.Error("Expected big- and little-endian integers to be always computed")
+			}
+			if got := r.Uint32CDAB != nil; got != tt.wantMid {
+				t.Errorf("mid-endian computed = %v, want %v", got, tt.wantMid)
+			}
+			if got := r.Float32BE != nil; got != tt.wantFloat {
+				t.Errorf("float computed = %v, want %v", got, tt.wantFloat)
+			}
+			if got := r.Float32BADC != nil; got != (tt.wantMid && tt.wantFloat) {
+				t.Errorf("mid-endian float computed = %v", got)
+			}
+			if slices.Contains(r.Sections, SectionModbus32) {
+				t.Errorf("Sections = %v, must only list conversion sections", r.Sections)
+			}
+		})
+	}
+
+	// Requesting all sections matches ConvertHex
+	full, _ := c.ConvertHex("4248000041bc0000")
+	lazy, _ := c.ConvertHexSections("4248000041bc0000", []string{SectionMidEndian, SectionFloat})
+	if *full.Float64CDAB != *lazy.Float64CDAB || *full.Int64BADC != *lazy.Int64BADC {
+		t.Error("ConvertHexSections with all sections differs from ConvertHex")
+	}
+
+	if _, err := c.ConvertHexSections("42", []string{"classification"}); !errors.Is(err, ErrUnknownSection) {
+		t.Errorf("Expected ErrUnknownSection, got %v", err)
+	}
+}
+
+func TestConvertModbusSections(t *testing.T) {
+	c := NewConverter()
+	r, err := c.ConvertModbusSections("0x4248 0x0000 0x0000 0x0000", []string{SectionModbus32})
+	if err != nil {
+		t.Fatalf("ConvertModbusSections() error = %v", err)
+	}
+	if len(r.Registers) != 4 || len(r.Combined32) != 3 || len(r.Combined64) != 0 {
+		t.Errorf("Got %d registers, %d 32-bit and %d 64-bit combinations, want 4, 3, 0",
+			len(r.Registers), len(r.Combined32), len(r.Combined64))
+	}
+	if !slices.Equal(r.Sections, []string{SectionModbus32}) {
+		t.Errorf("Sections = %v", r.Sections)
+	}
+
+	if _, err := c.ConvertModbusSections("", nil); err == nil {
+		t.Error("Expected error for empty input")
+	}
+}
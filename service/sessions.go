@@ -0,0 +1,384 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"hexview/editor"
+	"hexview/models"
+)
+
+// ErrSessionNotFound indicates an unknown session ID was used
+var ErrSessionNotFound = errors.New("session not found")
+
+const (
+	// MaxSessionHistory is the number of history entries kept per session.
+	MaxSessionHistory = 100
+
+	// MaxInputUndo is the number of input changes that can be undone per session.
+	MaxInputUndo = 100
+
+	// inputMergeWindow is the time within which single character edits are
+	// merged into one undo step, so undo does not go back one keystroke at a time.
+	inputMergeWindow = time.Second
+)
+
+// SessionService holds the conversion tabs, each with its own input,
+// settings, opened file and history, in a JSON file.
+// With an empty path sessions are kept in memory only.
+type SessionService struct {
+	mu       sync.Mutex
+	path     string
+	sessions []models.Session // in tab order
+	inputs   map[string]*inputHistory
+	nextID   int
+	now      func() time.Time
+}
+
+// inputHistory holds the undo and redo stacks of the input of a session.
+// They are kept in memory only.
+type inputHistory struct {
+	undo, redo []inputState
+	changed    time.Time // time of the last edit, zero after undo and redo
+}
+
+// inputState is the input of a session in its mode.
+type inputState struct {
+	mode, input, typ string
+}
+
+// sessionsFile is the on-disk format of the sessions.
+type sessionsFile struct {
+	NextID   int              `json:"nextId"`
+	Sessions []models.Session `json:"sessions"`
+}
+
+// NewSessionService creates an empty SessionService backed by the file at
+// path. Call Load to read the file.
+func NewSessionService(path string) *SessionService {
+	return &SessionService{path: path, inputs: make(map[string]*inputHistory), now: time.Now}
+}
+
+// Load reads the sessions file. A missing file leaves the list empty.
+// File IDs of the previous run are cleared; the caller reopens FilePath and
+// calls SetFile to restore them.
+func (s *SessionService) Load() error {
+	if s.path == "" {
+		return nil
+	}
+	var f sessionsFile
+	if err := loadJSON(s.path, &f); err != nil {
+		return err
+	}
+	for i := range f.Sessions {
+		f.Sessions[i].FileID = ""
+		f.Sessions[i].CanUndo, f.Sessions[i].CanRedo = false, false
+		if f.Sessions[i].History == nil {
+			f.Sessions[i].History = []models.HistoryEntry{}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions = f.Sessions
+	s.inputs = make(map[string]*inputHistory)
+	s.nextID = f.NextID
+	return nil
+}
+
+// List returns all sessions in tab order.
+func (s *SessionService) List() []models.Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := make([]models.Session, len(s.sessions))
+	for i, session := range s.sessions {
+		list[i] = s.snapshot(session)
+	}
+	return list
+}
+
+// Get returns a session.
+func (s *SessionService) Get(id string) (*models.Session, error) {
+	return s.update(id, false, func(*models.Session) error { return nil })
+}
+
+// Create adds a session after the existing ones. An empty name is replaced by
+// "Tab N"; settings are usually the current global settings.
+func (s *SessionService) Create(name string, settings models.Settings) (*models.Session, error) {
+	if err := validateSettings(settings); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	session := models.Session{
+		ID:       fmt.Sprintf("session-%d", s.nextID),
+		Name:     strings.TrimSpace(name),
+		Mode:     ModeHex,
+		Settings: settings,
+		History:  []models.HistoryEntry{},
+	}
+	if session.Name == "" {
+		session.Name = fmt.Sprintf("Tab %d", s.nextID)
+	}
+	s.sessions = append(s.sessions, session)
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+	result := s.snapshot(session)
+	return &result, nil
+}
+
+// Update replaces the name, mode, input, type and settings of a session.
+// The opened file and the history are kept; an empty name keeps the old one.
+func (s *SessionService) Update(session models.Session) (*models.Session, error) {
+	if !validMode(session.Mode) {
+		return nil, fmt.Errorf("unknown mode: %s", session.Mode)
+	}
+	if err := validateSettings(session.Settings); err != nil {
+		return nil, err
+	}
+	return s.update(session.ID, true, func(cur *models.Session) error {
+		if name := strings.TrimSpace(session.Name); name != "" {
+			cur.Name = name
+		}
+		s.setInput(cur, inputState{session.Mode, session.Input, session.Type})
+		cur.Settings = session.Settings
+		return nil
+	})
+}
+
+// SetInput stores the input of a session in the given mode. The previous
+// input can be restored with UndoInput.
+func (s *SessionService) SetInput(id, mode, input, typ string) (*models.Session, error) {
+	if !validMode(mode) {
+		return nil, fmt.Errorf("unknown mode: %s", mode)
+	}
+	return s.update(id, true, func(cur *models.Session) error {
+		s.setInput(cur, inputState{mode, input, typ})
+		return nil
+	})
+}
+
+// UndoInput restores the input of a session before its last change.
+func (s *SessionService) UndoInput(id string) (*models.Session, error) {
+	return s.update(id, true, func(cur *models.Session) error {
+		h := s.inputHistory(cur.ID)
+		if len(h.undo) == 0 {
+			return editor.ErrNothingToUndo
+		}
+		h.redo = append(h.redo, currentInput(cur))
+		applyInput(cur, h.undo[len(h.undo)-1])
+		h.undo = h.undo[:len(h.undo)-1]
+		h.changed = time.Time{}
+		return nil
+	})
+}
+
+// RedoInput reapplies the input change last reverted by UndoInput.
+func (s *SessionService) RedoInput(id string) (*models.Session, error) {
+	return s.update(id, true, func(cur *models.Session) error {
+		h := s.inputHistory(cur.ID)
+		if len(h.redo) == 0 {
+			return editor.ErrNothingToRedo
+		}
+		h.undo = append(h.undo, currentInput(cur))
+		applyInput(cur, h.redo[len(h.redo)-1])
+		h.redo = h.redo[:len(h.redo)-1]
+		h.changed = time.Time{}
+		return nil
+	})
+}
+
+// SetFile records the file opened in a session; nil clears it.
+func (s *SessionService) SetFile(id string, file *models.FileInfo) (*models.Session, error) {
+	return s.update(id, true, func(cur *models.Session) error {
+		cur.FileID, cur.FilePath = "", ""
+		if file != nil {
+			cur.FileID, cur.FilePath = file.ID, file.Path
+		}
+		return nil
+	})
+}
+
+// RecordConversion adds a conversion result to the history of a session.
+func (s *SessionService) RecordConversion(id, mode, input, typ string, r *models.ConversionResult) (*models.Session, error) {
+	return s.record(id, conversionEntry(mode, input, typ, r))
+}
+
+// RecordModbus adds a Modbus conversion result to the history of a session.
+func (s *SessionService) RecordModbus(id, input string, r *models.ModbusResult) (*models.Session, error) {
+	return s.record(id, modbusEntry(input, r))
+}
+
+// record adds an entry to the history of a session. Like HistoryService it
+// replaces the previous entry if it was recorded in the same mode within
+// historyMergeWindow, and keeps at most MaxSessionHistory entries.
+func (s *SessionService) record(id string, e models.HistoryEntry) (*models.Session, error) {
+	return s.update(id, true, func(cur *models.Session) error {
+		now := s.now()
+		e.Timestamp = now.Format(time.RFC3339)
+		n := len(cur.History)
+		if n > 0 && mergesWith(cur.History[n-1], e, now) {
+			e.ID = cur.History[n-1].ID
+			cur.History[n-1] = e
+			return nil
+		}
+		e.ID = 1
+		if n > 0 {
+			e.ID = cur.History[n-1].ID + 1
+		}
+		cur.History = append(cur.History, e)
+		if len(cur.History) > MaxSessionHistory {
+			cur.History = slices.Delete(cur.History, 0, len(cur.History)-MaxSessionHistory)
+		}
+		return nil
+	})
+}
+
+// Move places a session at index in the tab order.
+func (s *SessionService) Move(id string, index int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, err := s.index(id)
+	if err != nil {
+		return err
+	}
+	session := s.sessions[i]
+	s.sessions = slices.Delete(s.sessions, i, i+1)
+	index = min(max(index, 0), len(s.sessions))
+	s.sessions = slices.Insert(s.sessions, index, session)
+	return s.save()
+}
+
+// Close removes a session and returns it, so the caller can release its file.
+func (s *SessionService) Close(id string) (*models.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, err := s.index(id)
+	if err != nil {
+		return nil, err
+	}
+	session := s.sessions[i]
+	s.sessions = slices.Delete(s.sessions, i, i+1)
+	delete(s.inputs, id)
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// update calls fn with the session id and returns a copy of the result.
+// The sessions are saved if persist is set.
+func (s *SessionService) update(id string, persist bool, fn func(*models.Session) error) (*models.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, err := s.index(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := fn(&s.sessions[i]); err != nil {
+		return nil, err
+	}
+	if persist {
+		if err := s.save(); err != nil {
+			return nil, err
+		}
+	}
+	result := s.snapshot(s.sessions[i])
+	return &result, nil
+}
+
+// index returns the position of a session. Must be called with s.mu held.
+func (s *SessionService) index(id string) (int, error) {
+	i := slices.IndexFunc(s.sessions, func(session models.Session) bool { return session.ID == id })
+	if i < 0 {
+		return 0, fmt.Errorf("%w: %s", ErrSessionNotFound, id)
+	}
+	return i, nil
+}
+
+// save writes the sessions file. Must be called with s.mu held.
+func (s *SessionService) save() error {
+	if s.path == "" {
+		return nil
+	}
+	return saveJSON(s.path, sessionsFile{NextID: s.nextID, Sessions: s.sessions})
+}
+
+// setInput changes the input of cur and pushes the previous input onto the
+// undo stack, unless the change continues typing within inputMergeWindow.
+// Must be called with s.mu held.
+func (s *SessionService) setInput(cur *models.Session, next inputState) {
+	prev := currentInput(cur)
+	if prev == next {
+		return
+	}
+	h := s.inputHistory(cur.ID)
+	now := s.now()
+	typing := len(h.undo) > 0 && prev.mode == next.mode && prev.typ == next.typ &&
+		now.Sub(h.changed) < inputMergeWindow && singleCharEdit(prev.input, next.input)
+	if !typing {
+		h.undo = append(h.undo, prev)
+		if len(h.undo) > MaxInputUndo {
+			h.undo = slices.Delete(h.undo, 0, len(h.undo)-MaxInputUndo)
+		}
+	}
+	h.redo = nil
+	h.changed = now
+	applyInput(cur, next)
+}
+
+// inputHistory returns the undo and redo stacks of a session, creating them
+// if needed. Must be called with s.mu held.
+func (s *SessionService) inputHistory(id string) *inputHistory {
+	h, ok := s.inputs[id]
+	if !ok {
+		h = &inputHistory{}
+		s.inputs[id] = h
+	}
+	return h
+}
+
+// snapshot returns a copy of session that does not share its history, with
+// the undo state filled in. Must be called with s.mu held.
+func (s *SessionService) snapshot(session models.Session) models.Session {
+	session.History = slices.Clone(session.History)
+	if h, ok := s.inputs[session.ID]; ok {
+		session.CanUndo, session.CanRedo = len(h.undo) > 0, len(h.redo) > 0
+	}
+	return session
+}
+
+// currentInput returns the input of a session.
+func currentInput(session *models.Session) inputState {
+	return inputState{session.Mode, session.Input, session.Type}
+}
+
+// applyInput sets the input of a session.
+func applyInput(session *models.Session, in inputState) {
+	session.Mode, session.Input, session.Type = in.mode, in.input, in.typ
+}
+
+// singleCharEdit reports whether next is prev with one character inserted
+// or deleted, as when typing. A paste over the input, even of text of about
+// the same length, is not.
+func singleCharEdit(prev, next string) bool {
+	a, b := []rune(prev), []rune(next)
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	if len(b)-len(a) != 1 {
+		return false
+	}
+	i := 0
+	for i < len(a) && a[i] == b[i] {
+		i++
+	}
+	return string(a[i:]) == string(b[i+1:])
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"hexview/editor"
+	"hexview/models"
+)
+
+func TestSessionService(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	s := NewSessionService(path)
+	s.now = fakeClock(time.Minute)
+	c := NewConverter()
+
+	first, err := s.Create("", DefaultSettings())
+	if err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	if first.ID != "session-1" || first.Name != "Tab 1" || first.Mode != ModeHex {
+		t.Errorf("Unexpected session: %+v", first)
+	}
+	second, _ := s.Create(" Inverter ", DefaultSettings())
+
+	// Sessions keep their own input, settings and history
+	second.Mode, second.Input, second.Type = ModeInt, "513", "uint16"
+	second.Settings.ByteOrder = "LE"
+	if _, err := s.Update(*second); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	r, _ := c.ConvertInt("513", "uint16")
+	if _, err := s.RecordConversion(second.ID, ModeInt, "513", "uint16", r); err != nil {
+		t.Fatalf("RecordConversion() error: %v", err)
+	}
+	m, _ := c.ConvertModbusRegisters("0x0010")
+	if _, err := s.RecordModbus(first.ID, "0x0010", m); err != nil {
+		t.Fatalf("RecordModbus() error: %v", err)
+	}
+	if _, err := s.SetFile(first.ID, &models.FileInfo{ID: "file-1", Path: "/tmp/fw.bin"}); err != nil {
+		t.Fatalf("SetFile() error: %v", err)
+	}
+
+	got, _ := s.Get(second.ID)
+	if got.Name != "Inverter" || got.Input != "513" || got.Settings.ByteOrder != "LE" || len(got.History) != 1 {
+		t.Errorf("Unexpected second session: %+v", got)
+	}
+	if got, _ := s.Get(first.ID); got.Settings.ByteOrder != "BE" || len(got.History) != 1 || got.History[0].Mode != ModeModbus {
+		t.Errorf("Unexpected first session: %+v", got)
+	}
+
+	if err := s.Move(second.ID, 0); err != nil {
+		t.Fatalf("Move() error: %v", err)
+	}
+
+	// File IDs are not valid after a restart, the path is kept to reopen the file
+	reloaded := NewSessionService(path)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	list := reloaded.List()
+	if len(list) != 2 || list[0].ID != second.ID || list[1].FileID != "" || list[1].FilePath != "/tmp/fw.bin" {
+		t.Errorf("Reloaded sessions differ: %+v", list)
+	}
+
+	closed, err := reloaded.Close(first.ID)
+	if err != nil || closed.ID != first.ID {
+		t.Fatalf("Close() = %+v, %v", closed, err)
+	}
+	if _, err := reloaded.Get(first.ID); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("Expected ErrSessionNotFound, got %v", err)
+	}
+	if next, _ := reloaded.Create("", DefaultSettings()); next.ID != "session-3" {
+		t.Errorf("Expected IDs to continue after reload, got %s", next.ID)
+	}
+}
+
+func TestSessionService_History(t *testing.T) {
+	s := NewSessionService("")
+	s.now = fakeClock(time.Second)
+	session, _ := s.Create("", DefaultSettings())
+	c := NewConverter()
+
+	// Typing a value within the merge window records it once
+	for _, input := range []string{"4", "42", "4248"} {
+		r, _ := c.ConvertHex(input)
+		if _, err := s.RecordConversion(session.ID, ModeHex, input, "", r); err != nil {
+			t.Fatalf("RecordConversion() error: %v", err)
+		}
+	}
+	got, _ := s.Get(session.ID)
+	if len(got.History) != 1 || got.History[0].Input != "4248" {
+		t.Errorf("Expected one merged entry, got %+v", got.History)
+	}
+
+	s.now = fakeClock(time.Minute)
+	r, _ := c.ConvertHex("01")
+	for range MaxSessionHistory + 5 {
+		s.RecordConversion(session.ID, ModeHex, "01", "", r)
+	}
+	got, _ = s.Get(session.ID)
+	if len(got.History) != MaxSessionHistory || got.History[0].ID != 7 {
+		t.Errorf("Expected %d entries starting at ID 7, got %d starting at %d",
+			MaxSessionHistory, len(got.History), got.History[0].ID)
+	}
+}
+
+func TestSessionService_Invalid(t *testing.T) {
+	s := NewSessionService("")
+	session, _ := s.Create("", DefaultSettings())
+
+	bad := DefaultSettings()
+	bad.DumpWidth = 0
+	if _, err := s.Create("", bad); !errors.Is(err, ErrInvalidSettings) {
+		t.Errorf("Create() with invalid settings error = %v", err)
+	}
+	if _, err := s.SetInput(session.ID, "octal", "17", ""); err == nil {
+		t.Error("SetInput() with unknown mode expected error")
+	}
+	if _, err := s.SetInput("session-9", ModeHex, "01", ""); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("SetInput() on unknown session error = %v", err)
+	}
+}
+
+func TestSessionService_UndoInput(t *testing.T) {
+	s := NewSessionService("")
+	s.now = fakeClock(100 * time.Millisecond)
+	session, _ := s.Create("", DefaultSettings())
+	id := session.ID
+
+	// Typing is merged into one step, a paste is a step of its own
+	for _, input := range []string{"0", "0x", "0x1", "0x12"} {
+		s.SetInput(id, ModeModbus, input, "")
+	}
+	s.SetInput(id, ModeModbus, "0x1234 0x5678 0x9abc", "")
+	got, _ := s.SetInput(id, ModeModbus, "", "")
+	if !got.CanUndo || got.CanRedo {
+		t.Errorf("CanUndo = %v, CanRedo = %v after edits", got.CanUndo, got.CanRedo)
+	}
+
+	steps := []struct{ mode, input string }{
+		{ModeModbus, "0x1234 0x5678 0x9abc"},
+		{ModeModbus, "0x12"},
+		{ModeHex, ""},
+	}
+	for _, want := range steps {
+		got, err := s.UndoInput(id)
+		if err != nil {
+			t.Fatalf("UndoInput() error: %v", err)
+		}
+		if got.Mode != want.mode || got.Input != want.input {
+			t.Errorf("UndoInput() = %s %q, want %s %q", got.Mode, got.Input, want.mode, want.input)
+		}
+	}
+	if _, err := s.UndoInput(id); !errors.Is(err, editor.ErrNothingToUndo) {
+		t.Errorf("Expected ErrNothingToUndo, got %v", err)
+	}
+
+	got, _ = s.RedoInput(id)
+	got, _ = s.RedoInput(id)
+	if got.Input != "0x1234 0x5678 0x9abc" || !got.CanRedo {
+		t.Errorf("RedoInput() = %+v", got)
+	}
+
+	// A paste of the same length within the merge window is a step of its own
+	s.SetInput(id, ModeModbus, "0x1234 0x5678", "")
+	s.SetInput(id, ModeModbus, "0xabcd 0xef01", "")
+	got, _ = s.SetInput(id, ModeModbus, "0xabcd 0xef0", "")
+	if got, _ = s.UndoInput(id); got.Input != "0x1234 0x5678" {
+		t.Errorf("UndoInput() after same-length paste = %q, want %q", got.Input, "0x1234 0x5678")
+	}
+
+	// A new edit after undo drops the redo stack
+	got, _ = s.SetInput(id, ModeModbus, "0x0001", "")
+	if got.CanRedo {
+		t.Error("Expected redo stack to be cleared by a new edit")
+	}
+	if _, err := s.RedoInput(id); !errors.Is(err, editor.ErrNothingToRedo) {
+		t.Errorf("Expected ErrNothingToRedo, got %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"hexview/models"
+)
+
+// ErrInvalidSettings indicates a settings value is out of range
+var ErrInvalidSettings = errors.New("invalid settings")
+
+// DefaultSettings returns the settings used before the user changes anything.
+func DefaultSettings() models.Settings {
+	return models.Settings{
+		ByteOrder:      "BE",
+		FloatPrecision: -1,
+		NaNPayloads:    true,
+		DumpWidth:      16,
+		DropThreshold:  1024,
+		MaxInputSize:   1 << 20,
+		PreviewSize:    4096,
+		Modbus: models.ModbusSettings{
+			AddressBase: 1,
+			Show32:      true,
+			Show64:      true,
+		},
+	}
+}
+
+// SettingsService stores user preferences in a JSON file.
+// With an empty path settings are kept in memory only.
+type SettingsService struct {
+	mu       sync.RWMutex
+	path     string
+	settings models.Settings
+}
+
+// NewSettingsService creates a SettingsService backed by the file at path,
+// starting with the default settings. Call Load to read the file.
+func NewSettingsService(path string) *SettingsService {
+	return &SettingsService{path: path, settings: DefaultSettings()}
+}
+
+// Load reads the settings file. A missing file keeps the defaults; fields
+// absent from the file keep their default values.
+func (s *SettingsService) Load() error {
+	if s.path == "" {
+		return nil
+	}
+	settings := DefaultSettings()
+	if err := loadJSON(s.path, &settings); err != nil {
+		return err
+	}
+	if err := validateSettings(settings); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settings = settings
+	return nil
+}
+
+// Get returns the current settings.
+func (s *SettingsService) Get() models.Settings {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.settings
+}
+
+// Update validates and stores new settings.
+func (s *SettingsService) Update(settings models.Settings) (models.Settings, error) {
+	if err := validateSettings(settings); err != nil {
+		return s.Get(), err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.path != "" {
+		if err := saveJSON(s.path, settings); err != nil {
+			return s.settings, fmt.Errorf("cannot save settings: %w", err)
+		}
+	}
+	s.settings = settings
+	return settings, nil
+}
+
+// Reset restores and stores the default settings.
+func (s *SettingsService) Reset() (models.Settings, error) {
+	return s.Update(DefaultSettings())
+}
+
+// validateSettings checks all settings values for valid ranges.
+func validateSettings(s models.Settings) error {
+	switch s.ByteOrder {
+	case "BE", "LE", "BADC", "CDAB":
+	default:
+		return fmt.Errorf("%w: byte order %q (want BE, LE, BADC or CDAB)", ErrInvalidSettings, s.ByteOrder)
+	}
+	if s.FloatPrecision < -1 || s.FloatPrecision > 17 {
+		return fmt.Errorf("%w: float precision %d (want -1 to 17)", ErrInvalidSettings, s.FloatPrecision)
+	}
+	if s.DumpWidth < 1 || s.DumpWidth > 64 {
+		return fmt.Errorf("%w: dump width %d (want 1 to 64)", ErrInvalidSettings, s.DumpWidth)
+	}
+	if s.DropThreshold < 0 || s.DropThreshold > MaxDropConvertSize {
+		return fmt.Errorf("%w: drop threshold %d (want 0 to %d)", ErrInvalidSettings, s.DropThreshold, MaxDropConvertSize)
+	}
+	if s.MaxInputSize < 0 {
+		return fmt.Errorf("%w: maximum input size %d (want 0 or more)", ErrInvalidSettings, s.MaxInputSize)
+	}
+	if s.PreviewSize < 1 || s.PreviewSize > MaxPreviewSize {
+		return fmt.Errorf("%w: preview size %d (want 1 to %d)", ErrInvalidSettings, s.PreviewSize, MaxPreviewSize)
+	}
+	if s.Modbus.AddressBase != 0 && s.Modbus.AddressBase != 1 {
+		return fmt.Errorf("%w: Modbus address base %d (want 0 or 1)", ErrInvalidSettings, s.Modbus.AddressBase)
+	}
+	if err := validateAddressing(AddressingFromSettings(s), 0); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSettings, err)
+	}
+	return nil
+}
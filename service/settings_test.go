@@ -0,0 +1,91 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSettingsService_UpdateAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hexview", "settings.json")
+	s := NewSettingsService(path)
+	if err := s.Load(); err != nil {
+		t.Fatalf("Load() of missing file error: %v", err)
+	}
+	if s.Get() != DefaultSettings() {
+		t.Errorf("Expected defaults, got %+v", s.Get())
+	}
+
+	want := DefaultSettings()
+	want.ByteOrder = "CDAB"
+	want.HexUppercase = true
+	want.Modbus.AddressBase = 0
+	if _, err := s.Update(want); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+
+	reloaded := NewSettingsService(path)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if got := reloaded.Get(); got != want {
+		t.Errorf("Reloaded settings %+v, want %+v", got, want)
+	}
+
+	if got, err := reloaded.Reset(); err != nil || got != DefaultSettings() {
+		t.Errorf("Reset() = %+v, %v", got, err)
+	}
+}
+
+func TestSettingsService_PartialFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	os.WriteFile(path, []byte(`{"dumpWidth": 32}`), 0o644)
+
+	s := NewSettingsService(path)
+	if err := s.Load(); err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	got := s.Get()
+	if got.DumpWidth != 32 || got.ByteOrder != "BE" || got.Modbus.AddressBase != 1 {
+		t.Errorf("Expected defaults for missing fields, got %+v", got)
+	}
+}
+
+func TestSettingsService_Invalid(t *testing.T) {
+	s := NewSettingsService("")
+	bad := DefaultSettings()
+	bad.ByteOrder = "XY"
+	if _, err := s.Update(bad); !errors.Is(err, ErrInvalidSettings) {
+		t.Errorf("Expected ErrInvalidSettings, got %v", err)
+	}
+	bad = DefaultSettings()
+	bad.DumpWidth = 0
+	if _, err := s.Update(bad); !errors.Is(err, ErrInvalidSettings) {
+		t.Errorf("Expected ErrInvalidSettings for dump width, got %v", err)
+	}
+	bad = DefaultSettings()
+	bad.DropThreshold = MaxDropConvertSize + 1
+	if _, err := s.Update(bad); !errors.Is(err, ErrInvalidSettings) {
+		t.Errorf("Expected ErrInvalidSettings for drop threshold, got %v", err)
+	}
+	bad = DefaultSettings()
+	bad.PreviewSize = 0
+	if _, err := s.Update(bad); !errors.Is(err, ErrInvalidSettings) {
+		t.Errorf("Expected ErrInvalidSettings for preview size, got %v", err)
+	}
+	bad = DefaultSettings()
+	bad.Modbus.Notation = "3x"
+	if _, err := s.Update(bad); !errors.Is(err, ErrInvalidSettings) {
+		t.Errorf("Expected ErrInvalidSettings for Modbus notation, got %v", err)
+	}
+	if s.Get() != DefaultSettings() {
+		t.Error("Invalid update must not change settings")
+	}
+
+	path := filepath.Join(t.TempDir(), "settings.json")
+	os.WriteFile(path, []byte(`{"byteOrder": `), 0o644)
+	if err := NewSettingsService(path).Load(); err == nil {
+		t.Error("Expected error for malformed file")
+	}
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"fmt"
+
+	"hexview/models"
+	"hexview/sqlite"
+)
+
+// DecodeSQLiteHeader decodes the 100-byte SQLite database header from hex input.
+func (c *Converter) DecodeSQLiteHeader(hexInput string) (*models.SQLiteHeader, error) {
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := sqlite.ParseHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	return sqliteHeaderModel(h), nil
+}
+
+// DecodeSQLitePage decodes a b-tree page header from hex input that starts at the
+// beginning of a page. headerOffset is 100 for page 1 and 0 for all other pages.
+func (c *Converter) DecodeSQLitePage(hexInput string, headerOffset int) (*models.SQLitePage, error) {
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := sqlite.ParsePage(data, headerOffset)
+	if err != nil {
+		return nil, err
+	}
+	return sqlitePageModel(p, 0, 0, headerOffset), nil
+}
+
+// SQLiteHeader decodes the database header of an opened SQLite file.
+func (s *FileService) SQLiteHeader(id string) (*models.SQLiteHeader, error) {
+	f, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	h, err := sqlite.ParseHeader(f.buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return sqliteHeaderModel(h), nil
+}
+
+// SQLitePage decodes the b-tree page header of a 1-based page of an opened SQLite file.
+// The page size is taken from the database header.
+func (s *FileService) SQLitePage(id string, pageNumber uint32) (*models.SQLitePage, error) {
+	f, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data := f.buf.Bytes()
+	h, err := sqlite.ParseHeader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := sqlite.PageOffset(pageNumber, h.PageSize)
+	if pageNumber == 0 || offset >= int64(len(data)) {
+		return nil, fmt.Errorf("page %d out of range (file holds %d pages)", pageNumber, int64(len(data))/int64(h.PageSize))
+	}
+	end := min(offset+int64(h.PageSize), int64(len(data)))
+
+	headerOffset := 0
+	if pageNumber == 1 {
+		headerOffset = sqlite.HeaderSize
+	}
+	p, err := sqlite.ParsePage(data[offset:end], headerOffset)
+	if err != nil {
+		return nil, fmt.Errorf("page %d: %w", pageNumber, err)
+	}
+	return sqlitePageModel(p, pageNumber, offset, headerOffset), nil
+}
+
+// sqliteHeaderModel converts a decoded header to its model representation.
+func sqliteHeaderModel(h *sqlite.Header) *models.SQLiteHeader {
+	return &models.SQLiteHeader{
+		PageSize:           h.PageSize,
+		WriteVersion:       int(h.WriteVersion),
+		ReadVersion:        int(h.ReadVersion),
+		ReservedSpace:      int(h.ReservedSpace),
+		ChangeCounter:      h.ChangeCounter,
+		PageCount:          h.PageCount,
+		FirstFreelistTrunk: h.FirstFreelistTrunk,
+		FreelistPages:      h.FreelistPages,
+		SchemaCookie:       h.SchemaCookie,
+		SchemaFormat:       h.SchemaFormat,
+		DefaultCacheSize:   h.DefaultCacheSize,
+		LargestRootPage:    h.LargestRootPage,
+		TextEncoding:       h.EncodingName(),
+		UserVersion:        h.UserVersion,
+		IncrementalVacuum:  h.IncrementalVacuum,
+		ApplicationID:      h.ApplicationID,
+		SQLiteVersion:      h.VersionString(),
+		Warnings:           h.Warnings,
+	}
+}
+
+// sqlitePageModel converts a decoded page header to its model representation.
+// pageOffset is the absolute offset of the page start.
+func sqlitePageModel(p *sqlite.PageHeader, pageNumber uint32, pageOffset int64, headerOffset int) *models.SQLitePage {
+	result := &models.SQLitePage{
+		PageNumber:       pageNumber,
+		Offset:           pageOffset,
+		HeaderOffset:     pageOffset + int64(headerOffset),
+		Type:             p.Type.String(),
+		FirstFreeblock:   int(p.FirstFreeblock),
+		CellCount:        int(p.CellCount),
+		CellContentStart: p.CellContentStart,
+		FragmentedBytes:  int(p.FragmentedBytes),
+		RightMostPointer: p.RightMostPointer,
+		CellOffsets:      make([]int64, len(p.CellPointers)),
+	}
+	for i, ptr := range p.CellPointers {
+		result.CellOffsets[i] = pageOffset + int64(ptr)
+	}
+	return result
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"hexview/convert"
+	"hexview/sqlite"
+)
+
+// buildSQLiteDB returns a two-page database with 512-byte pages. Page 1 is an
+// empty leaf table page; page 2 is a leaf table page with one cell at 500.
+func buildSQLiteDB() []byte {
+	data := make([]byte, 1024)
+	be := binary.BigEndian
+	copy(data, sqlite.Magic)
+	be.PutUint16(data[16:], 512)
+	data[21], data[22], data[23] = 64, 32, 32
+	be.PutUint32(data[28:], 2)
+	be.PutUint32(data[56:], 1)
+	be.PutUint32(data[96:], 3045001)
+
+	data[100] = 0x0d
+	data[512] = 0x0d
+	be.PutUint16(data[512+3:], 1)
+	be.PutUint16(data[512+5:], 500)
+	be.PutUint16(data[512+8:], 500)
+	return data
+}
+
+func TestDecodeSQLite(t *testing.T) {
+	c := NewConverter()
+	hexInput := convert.BytesToHex(buildSQLiteDB())
+
+	h, err := c.DecodeSQLiteHeader(hexInput)
+	if err != nil {
+		t.Fatalf("DecodeSQLiteHeader() error: %v", err)
+	}
+	if h.PageSize != 512 || h.TextEncoding != "UTF-8" || h.SQLiteVersion != "3.45.1" {
+		t.Errorf("Unexpected header: %+v", h)
+	}
+
+	p, err := c.DecodeSQLitePage(hexInput, 100)
+	if err != nil {
+		t.Fatalf("DecodeSQLitePage() error: %v", err)
+	}
+	if p.Type != "leaf table" || p.HeaderOffset != 100 || p.CellCount != 0 {
+		t.Errorf("Unexpected page: %+v", p)
+	}
+
+	if _, err := c.DecodeSQLiteHeader("00112233"); err == nil {
+		t.Error("Expected error for non-SQLite input")
+	}
+	if _, err := c.DecodeSQLitePage("", 0); err == nil {
+		t.Error("Expected error for empty input")
+	}
+}
+
+func TestFileService_SQLitePage(t *testing.T) {
+	s := NewFileService()
+	info, err := s.Open(writeTempFile(t, "app.db", buildSQLiteDB()))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+
+	h, err := s.SQLiteHeader(info.ID)
+	if err != nil {
+		t.Fatalf("SQLiteHeader() error: %v", err)
+	}
+	if h.PageCount != 2 {
+		t.Errorf("Expected 2 pages, got %d", h.PageCount)
+	}
+
+	p, err := s.SQLitePage(info.ID, 2)
+	if err != nil {
+		t.Fatalf("SQLitePage() error: %v", err)
+	}
+	if p.Offset != 512 || p.HeaderOffset != 512 || p.CellCount != 1 {
+		t.Errorf("Unexpected page: %+v", p)
+	}
+	if len(p.CellOffsets) != 1 || p.CellOffsets[0] != 1012 {
+		t.Errorf("Expected cell at file offset 1012, got %v", p.CellOffsets)
+	}
+
+	for _, n := range []uint32{0, 3} {
+		if _, err := s.SQLitePage(info.ID, n); err == nil {
+			t.Errorf("SQLitePage(%d) expected error", n)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+// Stream kinds
+const (
+	StreamFileSearch = "file-search"
+	StreamModbus     = "modbus"
+)
+
+const (
+	// searchChunkSize is the number of bytes scanned per file search batch.
+	searchChunkSize = 1 << 20
+
+	// MaxSearchMatches limits the number of matches a file search reports.
+	MaxSearchMatches = 100000
+
+	// modbusStreamBatch is the number of registers per streamed Modbus batch.
+	modbusStreamBatch = 1000
+)
+
+var (
+	// ErrStreamNotFound indicates an unknown stream ID was used
+	ErrStreamNotFound = errors.New("stream not found")
+
+	// ErrTooManyMatches indicates a search stopped after MaxSearchMatches matches
+	ErrTooManyMatches = errors.New("too many matches")
+)
+
+// Emit reports a batch of results and the progress of a streamed operation.
+type Emit func(items any, processed, total int64)
+
+// StreamService runs long-running operations in the background and delivers
+// their results incrementally, so the UI can render progressively.
+// Streams are addressed by an ID assigned on Start.
+type StreamService struct {
+	mu      sync.Mutex
+	streams map[string]*stream
+	nextID  int
+	onEvent func(models.StreamEvent)
+}
+
+// stream holds the state of a running operation.
+type stream struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewStreamService creates a new StreamService instance.
+func NewStreamService() *StreamService {
+	return &StreamService{streams: make(map[string]*stream)}
+}
+
+// SetHandler sets the callback receiving stream events. It is called from the
+// stream's goroutine; events of one stream are delivered in order.
+func (s *StreamService) SetHandler(onEvent func(models.StreamEvent)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onEvent = onEvent
+}
+
+// Start runs op in the background and returns the stream ID. Every call of
+// the op's emit function produces one event; a final event with Done set
+// reports the error, if any.
+func (s *StreamService) Start(kind string, op func(ctx context.Context, emit Emit) error) string {
+	ctx, cancel := context.WithCancel(context.Background())
+	st := &stream{cancel: cancel, done: make(chan struct{})}
+
+	s.mu.Lock()
+	s.nextID++
+	id := fmt.Sprintf("stream-%d", s.nextID)
+	s.streams[id] = st
+	s.mu.Unlock()
+
+	go func() {
+		defer close(st.done)
+		defer cancel()
+
+		ev := models.StreamEvent{StreamID: id, Kind: kind}
+		err := op(ctx, func(items any, processed, total int64) {
+			ev.Seq++
+			ev.Items = items
+			ev.Processed = processed
+			ev.Total = total
+			s.emit(ev)
+		})
+
+		s.mu.Lock()
+		delete(s.streams, id)
+		s.mu.Unlock()
+
+		ev.Seq++
+		ev.Items = nil
+		ev.Done = true
+		if ctx.Err() != nil {
+			ev.Cancelled = true
+		} else if err != nil {
+			ev.Error = err.Error()
+		}
+		s.emit(ev)
+	}()
+	return id
+}
+
+// Cancel stops a running stream. Its final event has Cancelled set.
+func (s *StreamService) Cancel(id string) error {
+	s.mu.Lock()
+	st, ok := s.streams[id]
+	s.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrStreamNotFound, id)
+	}
+	st.cancel()
+	return nil
+}
+
+// CancelAll stops all running streams and waits for them to finish.
+func (s *StreamService) CancelAll() {
+	s.mu.Lock()
+	running := make([]*stream, 0, len(s.streams))
+	for _, st := range s.streams {
+		running = append(running, st)
+	}
+	s.mu.Unlock()
+
+	for _, st := range running {
+		st.cancel()
+		<-st.done
+	}
+}
+
+// emit delivers ev to the handler, if set.
+func (s *StreamService) emit(ev models.StreamEvent) {
+	s.mu.Lock()
+	onEvent := s.onEvent
+	s.mu.Unlock()
+	if onEvent != nil {
+		onEvent(ev)
+	}
+}
+
+// Search scans an opened file for all occurrences of patternHex, emitting a
+// models.SearchMatches batch per scanned chunk. Edits made while the search
+// runs may be partially reflected in the results.
+func (s *FileService) Search(ctx context.Context, id, patternHex string, emit Emit) error {
+	pattern, err := convert.HexToBytes(patternHex)
+	if err != nil {
+		return fmt.Errorf("invalid search pattern: %w", err)
+	}
+	if len(pattern) == 0 {
+		return fmt.Errorf("empty search pattern")
+	}
+	f, err := s.get(id)
+	if err != nil {
+		return err
+	}
+
+	found := 0
+	for pos := 0; ; pos += searchChunkSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		s.mu.RLock()
+		data := f.buf.Bytes()
+		total := len(data)
+		if pos >= total {
+			s.mu.RUnlock()
+			return nil
+		}
+		// Extend the window so matches crossing the chunk end are found
+		// exactly once.
+		window := data[pos:min(pos+searchChunkSize+len(pattern)-1, total)]
+		var offsets []int64
+		for i := 0; found+len(offsets) < MaxSearchMatches; {
+			j := bytes.Index(window[i:], pattern)
+			if j < 0 {
+				break
+			}
+			offsets = append(offsets, int64(pos+i+j))
+			i += j + 1
+		}
+		s.mu.RUnlock()
+
+		found += len(offsets)
+		emit(models.SearchMatches{FileID: id, Offsets: offsets}, int64(min(pos+searchChunkSize, total)), int64(total))
+		if found >= MaxSearchMatches {
+			return fmt.Errorf("%w: stopped after %d", ErrTooManyMatches, MaxSearchMatches)
+		}
+	}
+}
+
+// StreamModbusRegisters converts register values like ConvertModbusRegisters,
+// emitting one partial models.ModbusResult per batch of registers.
+func (c *Converter) StreamModbusRegisters(ctx context.Context, input string, emit Emit) error {
+	if input == "" {
+		return errEmptyInput()
+	}
+	registers, err := parseModbusInput(input)
+	if err != nil {
+		return err
+	}
+	if len(registers) == 0 {
+		return errNoRegisters()
+	}
+
+	total := int64(len(registers))
+	for lo := 0; lo < len(registers); lo += modbusStreamBatch {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		hi := min(lo+modbusStreamBatch, len(registers))
+		emit(modbusBatch(registers, lo, hi, allSections), int64(hi), total)
+	}
+	return nil
+}
+
+// ConvertModbusRegistersContext returns the same result as
+// ConvertModbusRegisters but converts in batches, stopping with the error of
+// ctx once it is cancelled. Use it for inputs with many registers.
+func (c *Converter) ConvertModbusRegistersContext(ctx context.Context, input string) (*models.ModbusResult, error) {
+	return cached(c, ModeModbus, input, "", func() (*models.ModbusResult, error) {
+		result := &models.ModbusResult{
+			Registers:  make([]models.ModbusRegister, 0),
+			Combined32: make([]models.ModbusCombined32, 0),
+			Combined64: make([]models.ModbusCombined64, 0),
+		}
+		var rawHex []string
+		var ascii strings.Builder
+		err := c.StreamModbusRegisters(ctx, input, func(items any, _, _ int64) {
+			batch := items.(*models.ModbusResult)
+			result.Registers = append(result.Registers, batch.Registers...)
+			result.Combined32 = append(result.Combined32, batch.Combined32...)
+			result.Combined64 = append(result.Combined64, batch.Combined64...)
+			rawHex = append(rawHex, batch.RawHex)
+			ascii.WriteString(batch.ASCII)
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.RawHex = strings.Join(rawHex, " ")
+		result.ASCII = ascii.String()
+		return result, nil
+	})
+}
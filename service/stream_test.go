@@ -0,0 +1,174 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"hexview/models"
+)
+
+// collect runs op as a stream and returns all its events.
+func collect(t *testing.T, s *StreamService, kind string, op func(ctx context.Context, emit Emit) error) []models.StreamEvent {
+	t.Helper()
+	events := make(chan models.StreamEvent, 1024)
+	s.SetHandler(func(ev models.StreamEvent) { events <- ev })
+	s.Start(kind, op)
+
+	var got []models.StreamEvent
+	for ev := range events {
+		got = append(got, ev)
+		if ev.Done {
+			return got
+		}
+	}
+	return got
+}
+
+func TestFileService_Search(t *testing.T) {
+	// Place matches on both sides of and across the first chunk boundary.
+	data := make([]byte, searchChunkSize+100)
+	for _, off := range []int{0, 10, searchChunkSize - 1, searchChunkSize + 50} {
+		copy(data[off:], []byte{0xde, 0xad})
+	}
+	path := writeTempFile(t, "search.bin", data)
+
+	files := NewFileService()
+	info, err := files.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+
+	events := collect(t, NewStreamService(), StreamFileSearch, func(ctx context.Context, emit Emit) error {
+		return files.Search(ctx, info.ID, "dead", emit)
+	})
+	if len(events) != 3 {
+		t.Fatalf("Expected 2 batches and a final event, got %d events", len(events))
+	}
+
+	var offsets []int64
+	for _, ev := range events[:2] {
+		offsets = append(offsets, ev.Items.(models.SearchMatches).Offsets...)
+	}
+	want := []int64{0, 10, searchChunkSize - 1, searchChunkSize + 50}
+	if len(offsets) != len(want) {
+		t.Fatalf("Expected offsets %v, got %v", want, offsets)
+	}
+	for i := range want {
+		if offsets[i] != want[i] {
+			t.Errorf("Expected offsets %v, got %v", want, offsets)
+			break
+		}
+	}
+
+	final := events[2]
+	if !final.Done || final.Error != "" || final.Processed != int64(len(data)) || final.Seq != 3 {
+		t.Errorf("Unexpected final event: %+v", final)
+	}
+}
+
+func TestFileService_SearchErrors(t *testing.T) {
+	files := NewFileService()
+	info, _ := files.Open(writeTempFile(t, "small.bin", bytes.Repeat([]byte{0}, 16)))
+	noop := func(any, int64, int64) {}
+
+	if err := files.Search(context.Background(), info.ID, "zz", noop); err == nil {
+		t.Error("Expected error for invalid pattern")
+	}
+	if err := files.Search(context.Background(), info.ID, "", noop); err == nil {
+		t.Error("Expected error for empty pattern")
+	}
+	if err := files.Search(context.Background(), "file-99", "00", noop); !errors.Is(err, ErrFileNotOpen) {
+		t.Errorf("Expected ErrFileNotOpen, got %v", err)
+	}
+
+	events := collect(t, NewStreamService(), StreamFileSearch, func(ctx context.Context, emit Emit) error {
+		return files.Search(ctx, "file-99", "00", emit)
+	})
+	if final := events[len(events)-1]; !final.Done || !strings.Contains(final.Error, "file not open") {
+		t.Errorf("Expected error in final event, got %+v", final)
+	}
+}
+
+func TestConverter_StreamModbusRegisters(t *testing.T) {
+	c := NewConverter()
+	input := strings.TrimSpace(strings.Repeat("0x0001 ", modbusStreamBatch+3))
+
+	full, err := c.ConvertModbusRegisters(input)
+	if err != nil {
+		t.Fatalf("ConvertModbusRegisters() error: %v", err)
+	}
+
+	events := collect(t, NewStreamService(), StreamModbus, func(ctx context.Context, emit Emit) error {
+		return c.StreamModbusRegisters(ctx, input, emit)
+	})
+	if len(events) != 3 {
+		t.Fatalf("Expected 2 batches and a final event, got %d events", len(events))
+	}
+
+	var regs, c32, c64 int
+	for _, ev := range events[:2] {
+		r := ev.Items.(*models.ModbusResult)
+		regs += len(r.Registers)
+		c32 += len(r.Combined32)
+		c64 += len(r.Combined64)
+	}
+	if regs != len(full.Registers) || c32 != len(full.Combined32) || c64 != len(full.Combined64) {
+		t.Errorf("Streamed %d/%d/%d values, want %d/%d/%d", regs, c32, c64,
+			len(full.Registers), len(full.Combined32), len(full.Combined64))
+	}
+	second := events[1].Items.(*models.ModbusResult)
+	if second.Registers[0].Index != modbusStreamBatch+1 || events[1].Processed != events[1].Total {
+		t.Errorf("Unexpected second batch: index %d, progress %d/%d",
+			second.Registers[0].Index, events[1].Processed, events[1].Total)
+	}
+}
+
+func TestConverter_ConvertModbusRegistersContext(t *testing.T) {
+	c := NewConverter()
+	input := strings.TrimSpace(strings.Repeat("0x4142 ", 2*modbusStreamBatch+5))
+
+	want, err := c.ConvertModbusRegisters(input)
+	if err != nil {
+		t.Fatalf("ConvertModbusRegisters() error: %v", err)
+	}
+	got, err := c.ConvertModbusRegistersContext(context.Background(), input)
+	if err != nil {
+		t.Fatalf("ConvertModbusRegistersContext() error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Error("ConvertModbusRegistersContext() differs from ConvertModbusRegisters()")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.ConvertModbusRegistersContext(ctx, input); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if _, err := c.ConvertModbusRegistersContext(context.Background(), "zz"); err == nil {
+		t.Error("Expected error for invalid input")
+	}
+}
+
+func TestStreamService_Cancel(t *testing.T) {
+	s := NewStreamService()
+	events := make(chan models.StreamEvent, 1)
+	s.SetHandler(func(ev models.StreamEvent) { events <- ev })
+
+	id := s.Start("test", func(ctx context.Context, emit Emit) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if err := s.Cancel(id); err != nil {
+		t.Fatalf("Cancel() error: %v", err)
+	}
+	if ev := <-events; !ev.Done || !ev.Cancelled || ev.Error != "" {
+		t.Errorf("Unexpected final event: %+v", ev)
+	}
+	if err := s.Cancel(id); !errors.Is(err, ErrStreamNotFound) {
+		t.Errorf("Expected ErrStreamNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"encoding/binary"
+	"fmt"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+// ConvertString encodes text as UTF-8, UTF-16BE and UTF-16LE and lists its
+// code points, the reverse of reading text from hex. Code points are listed
+// for the first MaxTextPreview characters.
+func (c *Converter) ConvertString(text string) (*models.StringConversion, error) {
+	if text == "" {
+		return nil, errEmptyInput()
+	}
+
+	data := []byte(text)
+	units := utf16.Encode([]rune(text))
+	be := make([]byte, 0, 2*len(units))
+	le := make([]byte, 0, 2*len(units))
+	for _, u := range units {
+		be = binary.BigEndian.AppendUint16(be, u)
+		le = binary.LittleEndian.AppendUint16(le, u)
+	}
+
+	result := &models.StringConversion{
+		Text:       text,
+		Hex:        convert.BytesToHex(data),
+		Binary:     convert.BytesToBinary(data),
+		Length:     len(data),
+		Characters: utf8.RuneCountInString(text),
+		ASCII:      !hasNonASCII(data),
+		UTF16BE:    convert.BytesToHex(be),
+		UTF16LE:    convert.BytesToHex(le),
+	}
+	for i := 0; i < len(text); {
+		if len(result.CodePoints) == MaxTextPreview {
+			result.Truncated = true
+			break
+		}
+		// Invalid bytes decode to U+FFFD with a size of 1
+		r, size := utf8.DecodeRuneInString(text[i:])
+		result.CodePoints = append(result.CodePoints, models.CodePoint{
+			Char:      string(r),
+			CodePoint: fmt.Sprintf("U+%04X", r),
+			UTF8:      convert.BytesToHex(data[i : i+size]),
+		})
+		i += size
+	}
+	return result, nil
+}
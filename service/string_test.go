@@ -0,0 +1,64 @@
+package service
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"hexview/models"
+)
+
+func TestConvertString(t *testing.T) {
+	c := NewConverter()
+	got, err := c.ConvertString("Hé€😀")
+	if err != nil {
+		t.Fatalf("ConvertString() error: %v", err)
+	}
+	want := models.StringConversion{
+		Text:       "Hé€😀",
+		Hex:        "48c3a9e282acf09f9880",
+		Length:     10,
+		Characters: 4,
+		UTF16BE:    "004800e920acd83dde00",
+		UTF16LE:    "4800e900ac203dd800de",
+	}
+	if got.Hex != want.Hex || got.Length != want.Length || got.Characters != want.Characters || got.ASCII ||
+		got.UTF16BE != want.UTF16BE || got.UTF16LE != want.UTF16LE {
+		t.Errorf("ConvertString() = %+v, want %+v", got, want)
+	}
+	if !strings.HasPrefix(got.Binary, "01001000 11000011") {
+		t.Errorf("Binary = %s, want UTF-8 bytes", got.Binary)
+	}
+	wantCodePoints := []models.CodePoint{
+		{Char: "H", CodePoint: "U+0048", UTF8: "48"},
+		{Char: "é", CodePoint: "U+00E9", UTF8: "c3a9"},
+		{Char: "€", CodePoint: "U+20AC", UTF8: "e282ac"},
+		{Char: "😀", CodePoint: "U+1F600", UTF8: "f09f9880"},
+	}
+	if !slices.Equal(got.CodePoints, wantCodePoints) {
+		t.Errorf("CodePoints = %+v, want %+v", got.CodePoints, wantCodePoints)
+	}
+}
+
+func TestConvertString_Edges(t *testing.T) {
+	c := NewConverter()
+	if _, err := c.ConvertString(""); err == nil {
+		t.Error("ConvertString(\"\") succeeded, want error")
+	}
+
+	got, _ := c.ConvertString("AB")
+	if !got.ASCII || got.UTF16LE != "41004200" {
+		t.Errorf("ConvertString(AB) = %+v, want ASCII", got)
+	}
+
+	// An invalid byte is listed as U+FFFD with its own byte
+	got, _ = c.ConvertString("a\xffb")
+	if len(got.CodePoints) != 3 || got.CodePoints[1].UTF8 != "ff" || got.CodePoints[1].CodePoint != "U+FFFD" {
+		t.Errorf("CodePoints of a\\xffb = %+v", got.CodePoints)
+	}
+
+	got, _ = c.ConvertString(strings.Repeat("x", MaxTextPreview+1))
+	if len(got.CodePoints) != MaxTextPreview || !got.Truncated || got.Characters != MaxTextPreview+1 {
+		t.Errorf("long text: %d code points, truncated %v", len(got.CodePoints), got.Truncated)
+	}
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"hexview/convert"
+	"hexview/models"
+	"hexview/structdecode"
+)
+
+// DecodeStruct applies a layout written in JSON or the structdecode DSL
+// (e.g. "magic u32 be" and "name char[8]", one field per line) to hex
+// input and returns its named, typed fields. Numbers are little-endian
+// unless the layout or field says "be".
+func (c *Converter) DecodeStruct(hexInput, layout string) (*models.StructResult, error) {
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+	l, err := structdecode.Parse(layout)
+	if err != nil {
+		return nil, err
+	}
+	values, err := structdecode.Decode(data, l)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.StructResult{
+		Layout:    l.Name,
+		Size:      l.Size(),
+		Fields:    make([]models.StructField, len(values)),
+		Remaining: max(len(data)-l.Size(), 0),
+	}
+	for i, v := range values {
+		result.Fields[i] = models.StructField{
+			Name:   v.Field.Name,
+			Type:   v.Field.Type,
+			Endian: v.Endian,
+			Offset: v.Offset,
+			Size:   len(v.Raw),
+			Value:  v.Value,
+			Hex:    convert.BytesToHex(v.Raw),
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"hexview/structdecode"
+)
+
+func TestDecodeStruct(t *testing.T) {
+	c := NewConverter()
+	r, err := c.DecodeStruct("0102 0000a040 4f4b ff", "struct msg\nid u16 be\nvalue f32\ntag char[2]")
+	if err != nil {
+		t.Fatalf("DecodeStruct() error: %v", err)
+	}
+	if r.Layout != "msg" || r.Size != 8 || r.Remaining != 1 || len(r.Fields) != 3 {
+		t.Fatalf("DecodeStruct() = %+v", r)
+	}
+	want := []struct{ name, value, hex, endian string }{
+		{"id", "258", "0102", "be"},
+		{"value", "5", "0000a040", "le"},
+		{"tag", "OK", "4f4b", ""},
+	}
+	for i, w := range want {
+		f := r.Fields[i]
+		if f.Name != w.name || f.Value != w.value || f.Hex != w.hex || f.Endian != w.endian {
+			t.Errorf("field %d = %+v, want %s = %s (%s, %s)", i, f, w.name, w.value, w.hex, w.endian)
+		}
+	}
+}
+
+func TestDecodeStruct_Errors(t *testing.T) {
+	c := NewConverter()
+	if _, err := c.DecodeStruct("01", "x u16"); !errors.Is(err, structdecode.ErrShortData) {
+		t.Errorf("Expected ErrShortData, got %v", err)
+	}
+	if _, err := c.DecodeStruct("0102", "x u12"); !errors.Is(err, structdecode.ErrInvalidLayout) {
+		t.Errorf("Expected ErrInvalidLayout, got %v", err)
+	}
+	if _, err := c.DecodeStruct("", "x u8"); err == nil {
+		t.Error("Expected an error for empty input")
+	}
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"hexview/models"
+	"hexview/svd"
+)
+
+// ErrNoSVD indicates that no SVD file is loaded
+var ErrNoSVD = errors.New("no SVD file loaded")
+
+// SVDService holds the CMSIS-SVD device description registers are decoded
+// with.
+type SVDService struct {
+	mu     sync.Mutex
+	device *svd.Device
+	path   string
+}
+
+// NewSVDService creates an SVDService without a device.
+func NewSVDService() *SVDService {
+	return &SVDService{}
+}
+
+// Load reads an SVD file and makes it the device registers are decoded
+// with. The previous device is kept if the file cannot be read.
+func (s *SVDService) Load(path string) (*models.SVDDevice, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	dev, err := svd.Parse(f)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.device, s.path = dev, path
+	return s.summary(), nil
+}
+
+// Device returns the peripherals and registers of the loaded device.
+func (s *SVDService) Device() (*models.SVDDevice, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.device == nil {
+		return nil, ErrNoSVD
+	}
+	return s.summary(), nil
+}
+
+// summary lists the loaded device. The caller holds s.mu.
+func (s *SVDService) summary() *models.SVDDevice {
+	out := &models.SVDDevice{Name: s.device.Name, Description: s.device.Description, Path: s.path}
+	out.Peripherals = make([]models.SVDPeripheral, len(s.device.Peripherals))
+	for i, p := range s.device.Peripherals {
+		mp := models.SVDPeripheral{Name: p.Name, Description: p.Description, BaseAddress: fmt.Sprintf("0x%08x", p.BaseAddress)}
+		mp.Registers = make([]string, len(p.Registers))
+		for j, r := range p.Registers {
+			mp.Registers[j] = r.Name
+		}
+		out.Peripherals[i] = mp
+	}
+	return out
+}
+
+// DecodeRegister splits value into the bitfields of a register of the
+// loaded device. value is hex with 0x prefix, binary with 0b or decimal.
+func (s *SVDService) DecodeRegister(peripheral, register, value string) (*models.SVDRegisterValue, error) {
+	s.mu.Lock()
+	dev := s.device
+	s.mu.Unlock()
+	if dev == nil {
+		return nil, ErrNoSVD
+	}
+	reg, err := dev.Register(peripheral, register)
+	if err != nil {
+		return nil, err
+	}
+
+	clean := strings.ReplaceAll(strings.TrimSpace(value), "_", "")
+	v, err := strconv.ParseUint(clean, 0, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidNumber, value)
+	}
+	if reg.Size < 64 && v>>reg.Size != 0 {
+		return nil, fmt.Errorf("%w: %s has %d bits", ErrOutOfRange, reg.Name, reg.Size)
+	}
+
+	digits := (reg.Size + 3) / 4
+	p, _ := dev.Peripheral(peripheral)
+	out := &models.SVDRegisterValue{
+		Peripheral:  p.Name,
+		Register:    reg.Name,
+		Description: reg.Description,
+		Address:     fmt.Sprintf("0x%08x", reg.Address),
+		Size:        reg.Size,
+		Value:       fmt.Sprintf("0x%0*x", digits, v),
+		ResetValue:  fmt.Sprintf("0x%0*x", digits, reg.ResetValue),
+		Fields:      make([]models.SVDFieldValue, 0, len(reg.Fields)),
+	}
+	for _, f := range reg.Decode(v) {
+		out.Fields = append(out.Fields, models.SVDFieldValue{
+			Name:        f.Field.Name,
+			Bits:        f.Field.Bits(),
+			Value:       f.Value,
+			Hex:         fmt.Sprintf("0x%x", f.Value),
+			Enum:        f.Enum,
+			Description: f.Field.Description,
+			Access:      f.Field.Access,
+		})
+	}
+	if rest := reg.Unassigned(v); rest != 0 {
+		out.Unassigned = fmt.Sprintf("0x%0*x", digits, rest)
+	}
+	return out, nil
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"hexview/svd"
+)
+
+const testSVD = `<device>
+  <name>TESTMCU</name>
+  <peripherals>
+    <peripheral>
+      <name>TIM2</name>
+      <baseAddress>0x40000000</baseAddress>
+      <registers>
+        <register>
+          <name>CR1</name>
+          <addressOffset>0x0</addressOffset>
+          <size>16</size>
+          <fields>
+            <field><name>CEN</name><bitOffset>0</bitOffset><bitWidth>1</bitWidth><access>read-write</access></field>
+            <field>
+              <name>DIR</name><bitOffset>4</bitOffset><bitWidth>1</bitWidth>
+              <enumeratedValues>
+                <enumeratedValue><name>Up</name><value>0</value></enumeratedValue>
+                <enumeratedValue><name>Down</name><value>1</value></enumeratedValue>
+              </enumeratedValues>
+            </field>
+          </fields>
+        </register>
+      </registers>
+    </peripheral>
+  </peripherals>
+</device>`
+
+func TestSVDService(t *testing.T) {
+	s := NewSVDService()
+	if _, err := s.DecodeRegister("TIM2", "CR1", "1"); !errors.Is(err, ErrNoSVD) {
+		t.Fatalf("DecodeRegister() without device error = %v, want ErrNoSVD", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "test.svd")
+	if err := os.WriteFile(path, []byte(testSVD), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dev, err := s.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if dev.Name != "TESTMCU" || len(dev.Peripherals) != 1 || dev.Peripherals[0].BaseAddress != "0x40000000" || dev.Peripherals[0].Registers[0] != "CR1" {
+		t.Errorf("Load() = %+v", dev)
+	}
+
+	v, err := s.DecodeRegister("tim2", "cr1", "0x0111")
+	if err != nil {
+		t.Fatalf("DecodeRegister() error: %v", err)
+	}
+	if v.Register != "CR1" || v.Value != "0x0111" || v.Address != "0x40000000" || v.Unassigned != "0x0100" || len(v.Fields) != 2 {
+		t.Fatalf("DecodeRegister() = %+v", v)
+	}
+	if f := v.Fields[0]; f.Name != "CEN" || f.Bits != "[0]" || f.Value != 1 || f.Access != "read-write" {
+		t.Errorf("CEN = %+v", f)
+	}
+	if f := v.Fields[1]; f.Name != "DIR" || f.Enum != "Down" {
+		t.Errorf("DIR = %+v", f)
+	}
+
+	tests := []struct {
+		name       string
+		peripheral string
+		register   string
+		value      string
+		want       error
+	}{
+		{"unknown register", "TIM2", "CR2", "0", svd.ErrNotFound},
+		{"invalid value", "TIM2", "CR1", "0xzz", ErrInvalidNumber},
+		{"too wide", "TIM2", "CR1", "0x10000", ErrOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.DecodeRegister(tt.peripheral, tt.register, tt.value); !errors.Is(err, tt.want) {
+				t.Errorf("DecodeRegister() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := s.Load(filepath.Join(t.TempDir(), "missing.svd")); err == nil {
+		t.Error("Load(missing) error = nil")
+	}
+	if dev, err := s.Device(); err != nil || dev.Name != "TESTMCU" {
+		t.Errorf("Device() after failed load = %+v, %v", dev, err)
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/bits"
+	"slices"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+// ErrLengthMismatch indicates inputs that must have the same length but do
+// not
+var ErrLengthMismatch = errors.New("inputs differ in length")
+
+// swapTransform rearranges bytes in place. group is the number of bytes it
+// works on at a time; the input length must be a multiple of it.
+type swapTransform struct {
+	name        string
+	description string
+	group       int
+	apply       func(b []byte)
+}
+
+// swapTransforms lists the transforms CompareSwaps tries, plain byte orders
+// first. All of them are their own inverse.
+var swapTransforms = []swapTransform{
+	{"none", "bytes unchanged", 1, func([]byte) {}},
+	{"swap16", "swap the bytes of each 16-bit word (BADC)", 2, reverseGroups(2)},
+	{"swap32", "reverse the bytes of each 32-bit value (DCBA)", 4, reverseGroups(4)},
+	{"swap64", "reverse the bytes of each 64-bit value", 8, reverseGroups(8)},
+	{"wordswap32", "swap the 16-bit words of each 32-bit value (CDAB)", 4, swapWords},
+	{"reverse", "reverse all bytes", 1, slices.Reverse[[]byte]},
+	{"bitrev8", "reverse the bits of each byte", 1, reverseBits},
+	{"bitrev", "reverse all bits", 1, func(b []byte) { slices.Reverse(b); reverseBits(b) }},
+	{"nibbleswap", "swap the nibbles of each byte", 1, func(b []byte) {
+		for i, v := range b {
+			b[i] = v<<4 | v>>4
+		}
+	}},
+	{"swap16+bitrev8", "swap16, then reverse the bits of each byte", 2, func(b []byte) { reverseGroups(2)(b); reverseBits(b) }},
+	{"swap32+bitrev8", "swap32, then reverse the bits of each byte", 4, func(b []byte) { reverseGroups(4)(b); reverseBits(b) }},
+	{"wordswap32+bitrev8", "wordswap32, then reverse the bits of each byte", 4, func(b []byte) { swapWords(b); reverseBits(b) }},
+}
+
+// CompareSwaps reports which byte-order transforms (byte swaps, word swaps,
+// bit reversal) turn the observed hex value into the expected one, to find
+// the swap a decoder is missing. Every transform is its own inverse, so the
+// inputs can be given either way round. Transforms that work on groups of
+// bytes are left out when the length is not a multiple of the group.
+func (c *Converter) CompareSwaps(observedHex, expectedHex string) (*models.SwapComparison, error) {
+	observed, err := parseHexBlob(observedHex)
+	if err != nil {
+		return nil, fmt.Errorf("observed: %w", err)
+	}
+	expected, err := parseHexBlob(expectedHex)
+	if err != nil {
+		return nil, fmt.Errorf("expected: %w", err)
+	}
+	if len(observed) != len(expected) {
+		return nil, fmt.Errorf("%w: observed has %d bytes, expected %d", ErrLengthMismatch, len(observed), len(expected))
+	}
+
+	result := &models.SwapComparison{
+		Observed: convert.BytesToHex(observed),
+		Expected: convert.BytesToHex(expected),
+		Matches:  []string{},
+	}
+	for _, t := range swapTransforms {
+		if len(observed)%t.group != 0 {
+			continue
+		}
+		b := slices.Clone(observed)
+		t.apply(b)
+		match := bytes.Equal(b, expected)
+		if match {
+			result.Matches = append(result.Matches, t.name)
+		}
+		result.Transforms = append(result.Transforms, models.SwapTransform{
+			Name:        t.name,
+			Description: t.description,
+			Result:      convert.BytesToHex(b),
+			Match:       match,
+		})
+	}
+	return result, nil
+}
+
+// reverseGroups returns a transform that reverses each n bytes.
+func reverseGroups(n int) func(b []byte) {
+	return func(b []byte) {
+		for i := 0; i+n <= len(b); i += n {
+			slices.Reverse(b[i : i+n])
+		}
+	}
+}
+
+// swapWords swaps the 16-bit words of each 32-bit value.
+func swapWords(b []byte) {
+	for i := 0; i+4 <= len(b); i += 4 {
+		b[i], b[i+1], b[i+2], b[i+3] = b[i+2], b[i+3], b[i], b[i+1]
+	}
+}
+
+// reverseBits reverses the bits of each byte.
+func reverseBits(b []byte) {
+	for i, v := range b {
+		b[i] = bits.Reverse8(v)
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCompareSwaps(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		name               string
+		observed, expected string
+		want               []string
+	}{
+		{"same", "12345678", "12345678", []string{"none"}},
+		{"swap16", "3412 7856", "1234 5678", []string{"swap16"}},
+		{"swap32 is reverse", "78563412", "12345678", []string{"swap32", "reverse"}},
+		{"word swap", "56781234", "12345678", []string{"wordswap32"}},
+		{"swap64", "0807060504030201", "0102030405060708", []string{"swap64", "reverse"}},
+		{"two float32 swapped", "0000c03f 00002040", "3fc00000 40200000", []string{"swap32"}},
+		{"bit order", "80 40", "01 02", []string{"bitrev8"}},
+		{"all bits", "0001", "8000", []string{"swap16+bitrev8", "bitrev"}},
+		{"nibbles", "21 43", "12 34", []string{"nibbleswap"}},
+		{"no match", "0102", "0304", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.CompareSwaps(tt.observed, tt.expected)
+			if err != nil {
+				t.Fatalf("CompareSwaps(%s, %s) error: %v", tt.observed, tt.expected, err)
+			}
+			slices.Sort(got.Matches)
+			slices.Sort(tt.want)
+			if !slices.Equal(got.Matches, tt.want) {
+				t.Errorf("CompareSwaps(%s, %s) matches = %v, want %v", tt.observed, tt.expected, got.Matches, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareSwaps_Groups(t *testing.T) {
+	c := NewConverter()
+	got, err := c.CompareSwaps("010203", "030201")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tr := range got.Transforms {
+		if tr.Name == "swap16" || tr.Name == "swap32" {
+			t.Errorf("transform %s tried on 3 bytes", tr.Name)
+		}
+	}
+	if !slices.Equal(got.Matches, []string{"reverse"}) {
+		t.Errorf("matches = %v, want [reverse]", got.Matches)
+	}
+
+	if _, err := c.CompareSwaps("0102", "010203"); !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("CompareSwaps() of different lengths error = %v, want ErrLengthMismatch", err)
+	}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"hexview/models"
+)
+
+const (
+	// MinTextLength is the number of characters a buffer without byte-order
+	// mark needs to be reported as text
+	MinTextLength = 4
+
+	// MaxTextPreview is the number of characters of the decoded text that
+	// are returned
+	MaxTextPreview = 256
+)
+
+// byteOrderMarks lists the BOMs of the Unicode encodings. UTF-32LE comes
+// before UTF-16LE, whose BOM it starts with.
+var byteOrderMarks = []struct {
+	bom      []byte
+	encoding string
+}{
+	{[]byte{0xEF, 0xBB, 0xBF}, models.EncodingUTF8},
+	{[]byte{0xFF, 0xFE, 0x00, 0x00}, models.EncodingUTF32LE},
+	{[]byte{0x00, 0x00, 0xFE, 0xFF}, models.EncodingUTF32BE},
+	{[]byte{0xFF, 0xFE}, models.EncodingUTF16LE},
+	{[]byte{0xFE, 0xFF}, models.EncodingUTF16BE},
+}
+
+// sniffText returns the text encoding data appears to use, or nil if it
+// does not look like text. A byte-order mark decides the encoding; without
+// one, data must decode without errors to at least MinTextLength printable
+// characters, ignoring NUL padding at the end. UTF-16 without BOM is only
+// reported if most characters are Latin-1, as almost any even-length buffer
+// decodes to printable CJK.
+func sniffText(data []byte) *models.TextEncoding {
+	for _, m := range byteOrderMarks {
+		if bytes.HasPrefix(data, m.bom) {
+			runes, _ := decodeText(data[len(m.bom):], m.encoding)
+			return textEncoding(m.encoding, true, runes)
+		}
+	}
+
+	encodings := []string{models.EncodingUTF8, models.EncodingUTF32LE, models.EncodingUTF32BE, models.EncodingUTF16LE, models.EncodingUTF16BE}
+	for _, enc := range encodings {
+		runes, ok := decodeText(data, enc)
+		for len(runes) > 0 && runes[len(runes)-1] == 0 {
+			runes = runes[:len(runes)-1]
+		}
+		if !ok || len(runes) < MinTextLength || !printableText(runes) {
+			continue
+		}
+		if enc == models.EncodingUTF16LE || enc == models.EncodingUTF16BE {
+			latin1 := 0
+			for _, r := range runes {
+				if r < 0x100 {
+					latin1++
+				}
+			}
+			if 2*latin1 < len(runes) {
+				continue
+			}
+		}
+		if enc == models.EncodingUTF8 && !hasNonASCII(data) {
+			enc = models.EncodingASCII
+		}
+		return textEncoding(enc, false, runes)
+	}
+	return nil
+}
+
+// decodeText decodes data in one of the Unicode encodings. Invalid
+// sequences become U+FFFD and make ok false.
+func decodeText(data []byte, encoding string) (runes []rune, ok bool) {
+	switch encoding {
+	case models.EncodingUTF8:
+		return []rune(string(data)), utf8.Valid(data)
+	case models.EncodingUTF16LE, models.EncodingUTF16BE:
+		var order binary.ByteOrder = binary.LittleEndian
+		if encoding == models.EncodingUTF16BE {
+			order = binary.BigEndian
+		}
+		ok = len(data)%2 == 0
+		for i := 0; i+1 < len(data); i += 2 {
+			r := rune(order.Uint16(data[i:]))
+			if utf16.IsSurrogate(r) {
+				r2 := utf8.RuneError
+				if i+3 < len(data) {
+					r2 = rune(order.Uint16(data[i+2:]))
+				}
+				if r = utf16.DecodeRune(r, r2); r == utf8.RuneError {
+					ok = false
+				} else {
+					i += 2
+				}
+			}
+			runes = append(runes, r)
+		}
+		return runes, ok
+	default:
+		var order binary.ByteOrder = binary.LittleEndian
+		if encoding == models.EncodingUTF32BE {
+			order = binary.BigEndian
+		}
+		ok = len(data)%4 == 0
+		for i := 0; i+3 < len(data); i += 4 {
+			r := rune(order.Uint32(data[i:]))
+			if !utf8.ValidRune(r) {
+				r, ok = utf8.RuneError, false
+			}
+			runes = append(runes, r)
+		}
+		return runes, ok
+	}
+}
+
+// printableText reports whether runes are printable characters or
+// whitespace.
+func printableText(runes []rune) bool {
+	for _, r := range runes {
+		if !unicode.IsPrint(r) && r != '\t' && r != '\n' && r != '\r' {
+			return false
+		}
+	}
+	return true
+}
+
+// hasNonASCII reports whether data has bytes above 0x7F.
+func hasNonASCII(data []byte) bool {
+	for _, b := range data {
+		if b >= utf8.RuneSelf {
+			return true
+		}
+	}
+	return false
+}
+
+// textEncoding builds the result for text decoded as encoding.
+func textEncoding(encoding string, bom bool, runes []rune) *models.TextEncoding {
+	t := &models.TextEncoding{Encoding: encoding, BOM: bom}
+	if bom {
+		t.Summary = encoding + " text with byte-order mark"
+	} else {
+		t.Summary = "looks like " + encoding + " text"
+	}
+	t.Text, t.Truncated = previewText(runes)
+	return t
+}
+
+// wideEncodings lists the encodings decodeStrings decodes data in, with the
+// size of their code units.
+var wideEncodings = []struct {
+	encoding string
+	unit     int
+}{
+	{models.EncodingUTF16LE, 2},
+	{models.EncodingUTF16BE, 2},
+	{models.EncodingUTF32LE, 4},
+	{models.EncodingUTF32BE, 4},
+}
+
+// decodeStrings decodes data as UTF-16 and UTF-32 text, whether or not it
+// looks like text. Invalid sequences and a trailing partial code unit become
+// U+FFFD; NUL terminators and padding at the end are left out. Encodings
+// whose code unit is longer than data are skipped.
+func decodeStrings(data []byte) []models.DecodedString {
+	var strs []models.DecodedString
+	for _, e := range wideEncodings {
+		if len(data) < e.unit {
+			continue
+		}
+		runes, ok := decodeText(data, e.encoding)
+		if len(data)%e.unit != 0 {
+			runes = append(runes, utf8.RuneError)
+		}
+		for len(runes) > 0 && runes[len(runes)-1] == 0 {
+			runes = runes[:len(runes)-1]
+		}
+		s := models.DecodedString{Encoding: e.encoding, Valid: ok}
+		s.Text, s.Truncated = previewText(runes)
+		strs = append(strs, s)
+	}
+	return strs
+}
+
+// previewText returns the first MaxTextPreview runes as text with control
+// characters other than whitespace replaced by '.', and whether runes were
+// left out.
+func previewText(runes []rune) (string, bool) {
+	truncated := len(runes) > MaxTextPreview
+	if truncated {
+		runes = runes[:MaxTextPreview]
+	}
+	var sb strings.Builder
+	for _, r := range runes {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			r = '.'
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String(), truncated
+}
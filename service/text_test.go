@@ -0,0 +1,120 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+func TestSniffText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		encoding string // empty if the input is not text
+		bom      bool
+		text     string
+	}{
+		{"ascii", "48656c6c6f", models.EncodingASCII, false, "Hello"},
+		{"ascii nul padded", "48656c6c6f000000", models.EncodingASCII, false, "Hello"},
+		{"utf-8", "4772c3bc c39f65", models.EncodingUTF8, false, "Grüße"},
+		{"utf-8 bom", "efbbbf 4869", models.EncodingUTF8, true, "Hi"},
+		{"utf-16le", "48006900210021000000", models.EncodingUTF16LE, false, "Hi!!"},
+		{"utf-16be", "0048 0069 0021 0021", models.EncodingUTF16BE, false, "Hi!!"},
+		{"utf-16le bom", "fffe 4800 6900", models.EncodingUTF16LE, true, "Hi"},
+		{"utf-16be bom surrogates", "feff d83d de00", models.EncodingUTF16BE, true, "😀"},
+		{"utf-32le bom", "fffe0000 48000000", models.EncodingUTF32LE, true, "H"},
+		{"utf-32be", "00000041 00000042 00000043 00000044", models.EncodingUTF32BE, false, "ABCD"},
+		{"too short", "4869", "", false, ""},
+		{"binary", "42480000", "", false, ""},
+		{"invalid utf-8", "48656cff6c6f", "", false, ""},
+		{"cjk without bom", "4e2d 6587 5b57 7b26", "", false, ""},
+		{"control characters", "48 01 02 03 04", "", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := convert.HexToBytes(tt.input)
+			if err != nil {
+				t.Fatalf("HexToBytes() error: %v", err)
+			}
+			got := sniffText(data)
+			if tt.encoding == "" {
+				if got != nil {
+					t.Errorf("sniffText() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Encoding != tt.encoding || got.BOM != tt.bom || got.Text != tt.text {
+				t.Fatalf("sniffText() = %+v, want %s %q", got, tt.encoding, tt.text)
+			}
+			if !strings.Contains(got.Summary, tt.encoding) {
+				t.Errorf("Summary = %q", got.Summary)
+			}
+		})
+	}
+}
+
+func TestConvertHex_Text(t *testing.T) {
+	c := NewConverter()
+	result, err := c.ConvertHex("5400650073007400")
+	if err != nil {
+		t.Fatalf("ConvertHex() error: %v", err)
+	}
+	if result.Text == nil || result.Text.Summary != "looks like UTF-16LE text" {
+		t.Errorf("Text = %+v", result.Text)
+	}
+	if g := GroupResult(result); g.Text != result.Text {
+		t.Errorf("GroupResult() Text = %+v", g.Text)
+	}
+	if len(result.Strings) != 4 || result.Strings[0].Text != "Test" {
+		t.Errorf("Strings = %+v, want UTF-16LE Test first", result.Strings)
+	}
+
+	long := strings.Repeat("41", MaxTextPreview+1)
+	if result, _ := c.ConvertHex(long); result.Text == nil || !result.Text.Truncated || len(result.Text.Text) != MaxTextPreview {
+		t.Errorf("Text of %d characters = %+v", MaxTextPreview+1, result.Text)
+	}
+}
+
+func TestDecodeStrings(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []models.DecodedString
+	}{
+		{"wide string", "5400650073007400 0000 0000", []models.DecodedString{
+			{Encoding: models.EncodingUTF16LE, Text: "Test", Valid: true},
+			{Encoding: models.EncodingUTF16BE, Text: "吀攀猀琀", Valid: true},
+			{Encoding: models.EncodingUTF32LE, Text: "��", Valid: false},
+			{Encoding: models.EncodingUTF32BE, Text: "��", Valid: false},
+		}},
+		{"lone surrogate and odd length", "3dd8 4100 42", []models.DecodedString{
+			{Encoding: models.EncodingUTF16LE, Text: "�A�", Valid: false},
+			{Encoding: models.EncodingUTF16BE, Text: "㷘䄀�", Valid: false},
+			{Encoding: models.EncodingUTF32LE, Text: "��", Valid: false},
+			{Encoding: models.EncodingUTF32BE, Text: "��", Valid: false},
+		}},
+		{"utf-32", "00000041 0001f600", []models.DecodedString{
+			{Encoding: models.EncodingUTF16LE, Text: ".䄀Āö", Valid: true},
+			{Encoding: models.EncodingUTF16BE, Text: ".A.", Valid: true},
+			{Encoding: models.EncodingUTF32LE, Text: "��", Valid: false},
+			{Encoding: models.EncodingUTF32BE, Text: "A😀", Valid: true},
+		}},
+		{"one byte", "41", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _ := convert.HexToBytes(tt.input)
+			got := decodeStrings(data)
+			if len(got) != len(tt.want) {
+				t.Fatalf("decodeStrings(%s) = %+v, want %+v", tt.input, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("decodeStrings(%s)[%d] = %+v, want %+v", tt.input, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"hexview/disasm"
+	"hexview/models"
+)
+
+// maxTriageFindings limits the findings listed for a buffer.
+const maxTriageFindings = 4096
+
+// addressRange is a typical address range of a Linux process or kernel.
+type addressRange struct {
+	start, end uint64 // end is inclusive
+	region     string
+}
+
+// linuxRanges lists where Linux places code, data and stacks with ASLR, so
+// that words in these ranges are likely pointers. The ranges are those of
+// the default layout with 48-bit virtual addresses on 64-bit systems; they
+// end below the top pages, so small negative numbers are not taken for
+// kernel pointers.
+var linuxRanges = map[disasm.Arch][]addressRange{
+	disasm.ArchX86_64: {
+		{0x5500_0000_0000, 0x56ff_ffff_ffff, "PIE executable or heap"},
+		{0x7f00_0000_0000, 0x7fef_ffff_ffff, "shared library or mmap"},
+		{0x7ff0_0000_0000, 0x7fff_ffff_ffff, "stack"},
+		{0xffff_8880_0000_0000, 0xffff_c87f_ffff_ffff, "kernel direct map (slab)"},
+		{0xffff_c900_0000_0000, 0xffff_e8ff_ffff_ffff, "kernel vmalloc or task stack"},
+		{0xffff_ffff_8000_0000, 0xffff_ffff_ff5f_ffff, "kernel text or module"},
+	},
+	disasm.ArchARM64: {
+		{0xaaaa_0000_0000, 0xaaab_ffff_ffff, "PIE executable or heap"},
+		{0xffff_0000_0000, 0xffff_ffff_ffff, "stack, shared library or mmap"},
+		{0xffff_0000_0000_0000, 0xffff_7fff_ffff_ffff, "kernel linear map (slab)"},
+		{0xffff_8000_0000_0000, 0xffff_fbff_ffff_ffff, "kernel text, module or vmalloc"},
+	},
+	disasm.ArchX86_32: {
+		{0x0804_8000, 0x08ff_ffff, "non-PIE executable"},
+		{0x5600_0000, 0x59ff_ffff, "PIE executable or heap"},
+		{0xb700_0000, 0xb7ff_ffff, "shared library"},
+		{0xbf00_0000, 0xbfff_ffff, "stack"},
+		{0xf700_0000, 0xf7ff_ffff, "shared library (32-bit process on a 64-bit kernel)"},
+		{0xff80_0000, 0xffff_dfff, "stack (32-bit process on a 64-bit kernel)"},
+	},
+	disasm.ArchARM: {
+		{0x7f00_0000, 0x7fff_ffff, "PIE executable or heap"},
+		{0xb600_0000, 0xb6ff_ffff, "shared library or mmap"},
+		{0xbe00_0000, 0xbeff_ffff, "stack"},
+		{0xbf00_0000, 0xbfff_ffff, "kernel module"},
+		{0xc000_0000, 0xc0ff_ffff, "kernel text"},
+	},
+}
+
+// signedRanges are the arm64 ranges signedPointer accepts for the address
+// of a signed or tagged pointer. The kernel range is that of the image and
+// modules without KASLR, which return addresses signed by the kernel point
+// into; the whole kernel half would match any word with bit 55 set.
+var signedRanges = []addressRange{
+	{0xaaaa_0000_0000, 0xaaab_ffff_ffff, "PIE executable or heap"},
+	{0xffff_0000_0000, 0xffff_ffff_ffff, "stack, shared library or mmap"},
+	{0xffff_8000_0000_0000, 0xffff_8000_ffff_ffff, "kernel text or module"},
+}
+
+// knownCookies are canary values that are not random, by word size.
+var knownCookies = map[int]map[uint64]string{
+	4: {
+		0x000aff0d: "StackGuard terminator canary (NUL, CR, LF, 0xff)",
+		0xbb40e64e: "default /GS security cookie: __security_init_cookie did not run",
+	},
+	8: {
+		0x00002b992ddfa232: "default /GS security cookie: __security_init_cookie did not run",
+	},
+}
+
+// TriageBuffer reads a little-endian memory dump, e.g. a stack, as words of
+// arch (x86-32, x86-64, arm or arm64) and flags those that look like stack
+// canaries, pointers into the typical ASLR ranges of a Linux process or
+// kernel, or arm64 pointers with a pointer authentication code or top-byte
+// tag. The heuristics mark candidates for a closer look; random data also
+// matches them now and then.
+func (c *Converter) TriageBuffer(hexInput, arch string) (*models.TriageResult, error) {
+	a, err := disasm.ParseArch(arch)
+	if err != nil {
+		return nil, err
+	}
+	size := 8
+	switch a {
+	case disasm.ArchX86_64, disasm.ArchARM64:
+	case disasm.ArchX86_32, disasm.ArchARM:
+		size = 4
+	default:
+		return nil, fmt.Errorf("%w: %s (want x86-32, x86-64, arm or arm64)", disasm.ErrUnsupportedArch, arch)
+	}
+	data, err := parseHexBlob(hexInput)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.TriageResult{
+		Arch:      string(a),
+		WordSize:  size,
+		Words:     len(data) / size,
+		Remainder: len(data) % size,
+		Findings:  []models.TriageFinding{},
+	}
+	for i := range result.Words {
+		b := data[i*size : (i+1)*size]
+		var v uint64
+		if size == 8 {
+			v = binary.LittleEndian.Uint64(b)
+		} else {
+			v = uint64(binary.LittleEndian.Uint32(b))
+		}
+		f, ok := triageWord(a, v, size)
+		if !ok {
+			continue
+		}
+		if len(result.Findings) == maxTriageFindings {
+			result.Truncated = true
+			break
+		}
+		f.Offset = i * size
+		f.Value = fmt.Sprintf("0x%0*x", 2*size, v)
+		result.Findings = append(result.Findings, f)
+	}
+	return result, nil
+}
+
+// triageWord classifies a word of size bytes.
+func triageWord(a disasm.Arch, v uint64, size int) (models.TriageFinding, bool) {
+	if note, ok := knownCookies[size][v]; ok {
+		return models.TriageFinding{Kind: models.TriageCanary, Note: note}, true
+	}
+	if region, ok := findRange(a, v); ok {
+		return models.TriageFinding{Kind: models.TriagePointer, Region: region, Note: "pointer into " + region}, true
+	}
+	if a == disasm.ArchARM64 {
+		if f, ok := signedPointer(v); ok {
+			return f, true
+		}
+	}
+	if looksLikeCanary(v, size) {
+		return models.TriageFinding{
+			Kind: models.TriageCanary,
+			Note: "possible stack canary: random bytes with the lowest byte zero, as glibc and the Linux kernel generate them",
+		}, true
+	}
+	return models.TriageFinding{}, false
+}
+
+// findRange returns the region of the address ranges of a that contains v.
+func findRange(a disasm.Arch, v uint64) (string, bool) {
+	for _, r := range linuxRanges[a] {
+		if v >= r.start && v <= r.end {
+			return r.region, true
+		}
+	}
+	return "", false
+}
+
+// signedPointer recognizes an arm64 pointer whose upper bits hold a pointer
+// authentication code or a top-byte tag. User pointers have bit 55 clear;
+// with top-byte ignore (TBI), which Linux enables for user data, bits 63:56
+// are a tag (MTE uses 59:56, HWASan all 8, Android's heap 0xb4) and the
+// PAC is in bits 54:48. Kernel pointers have bit 55 set and the PAC in bits
+// 63:56 and 54:48.
+func signedPointer(v uint64) (models.TriageFinding, bool) {
+	const low = 1<<48 - 1
+	kernel := v>>55&1 == 1
+	addr := v & low
+	if kernel {
+		addr |= 0xffff << 48
+	}
+	if addr == v {
+		return models.TriageFinding{}, false
+	}
+	var region string
+	for _, r := range signedRanges {
+		if addr >= r.start && addr <= r.end {
+			region = r.region
+		}
+	}
+	if region == "" {
+		return models.TriageFinding{}, false
+	}
+
+	f := models.TriageFinding{Region: region, Address: fmt.Sprintf("0x%016x", addr)}
+	pac := v >> 48 & 0x7f
+	switch {
+	case kernel:
+		f.Kind = models.TriagePAC
+		f.Note = fmt.Sprintf("kernel pointer into %s signed with PAC 0x%04x", region, (v^addr)>>48)
+	case pac == 0:
+		f.Kind = models.TriageTag
+		f.Note = fmt.Sprintf("pointer into %s with top-byte tag 0x%02x (MTE tag %d)", region, v>>56, v>>56&0xf)
+	default:
+		f.Kind = models.TriagePAC
+		f.Note = fmt.Sprintf("pointer into %s signed with PAC 0x%02x", region, pac)
+		if tag := v >> 56; tag != 0 {
+			f.Note += fmt.Sprintf(", top-byte tag 0x%02x", tag)
+		}
+	}
+	return f, true
+}
+
+// looksLikeCanary reports whether v has the lowest byte zero, which stops
+// string functions from leaking or overwriting a canary, and random other
+// bytes: mostly distinct, at most one zero, not all printable ASCII and, on
+// 64-bit, not the zero or all-ones upper bits of a canonical address.
+func looksLikeCanary(v uint64, size int) bool {
+	if v&0xff != 0 {
+		return false
+	}
+	seen := map[byte]bool{}
+	zeros, printable := 0, 0
+	for i := 1; i < size; i++ {
+		b := byte(v >> (8 * i))
+		seen[b] = true
+		if b == 0 {
+			zeros++
+		}
+		if b >= 0x20 && b < 0x7f {
+			printable++
+		}
+	}
+	if size == 4 {
+		return zeros == 0 && len(seen) == 3 && printable < 3
+	}
+	top := v >> 48
+	return zeros <= 1 && len(seen) >= 5 && printable < 7 && top != 0 && top != 0xffff
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"hexview/disasm"
+	"hexview/models"
+)
+
+// leQuads returns 64-bit words as little-endian hex.
+func leQuads(words ...uint64) string {
+	b := make([]byte, 8*len(words))
+	for i, w := range words {
+		binary.LittleEndian.PutUint64(b[8*i:], w)
+	}
+	return hex.EncodeToString(b)
+}
+
+func TestTriageBuffer(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		name   string
+		input  string
+		arch   string
+		kind   string
+		region string
+		addr   string
+	}{
+		{"glibc canary", leQuads(0x9c3f5be1d27a8400), "x86-64", models.TriageCanary, "", ""},
+		{"stack pointer", leQuads(0x00007ffd4c2a1e80), "x86-64", models.TriagePointer, "stack", ""},
+		{"libc pointer", leQuads(0x00007f3a1c229d90), "amd64", models.TriagePointer, "shared library or mmap", ""},
+		{"PIE pointer", leQuads(0x000055d4e31a2169), "x86-64", models.TriagePointer, "PIE executable or heap", ""},
+		{"kernel text", leQuads(0xffffffff81a0c3e2), "x86-64", models.TriagePointer, "kernel text or module", ""},
+		{"/GS default cookie", leQuads(0x00002b992ddfa232), "x86-64", models.TriageCanary, "", ""},
+		{"arm64 PAC", leQuads(0x003baaaad5c90a14), "arm64", models.TriagePAC, "PIE executable or heap", "0x0000aaaad5c90a14"},
+		{"arm64 MTE tag", leQuads(0x0b00ffff8c2e1f40), "arm64", models.TriageTag, "stack, shared library or mmap", "0x0000ffff8c2e1f40"},
+		{"arm64 PAC and Android tag", leQuads(0xb45effff8c2e1f40), "arm64", models.TriagePAC, "stack, shared library or mmap", "0x0000ffff8c2e1f40"},
+		{"arm64 kernel PAC", leQuads(0x9fd98000081a2b3c), "arm64", models.TriagePAC, "kernel text or module", "0xffff8000081a2b3c"},
+		{"x86 canary", leWords(0x5be18400), "x86", models.TriageCanary, "", ""},
+		{"x86 terminator canary", leWords(0x000aff0d), "x86", models.TriageCanary, "", ""},
+		{"x86 stack", leWords(0xbfd3a2c0), "x86-32", models.TriagePointer, "stack", ""},
+		{"arm library", leWords(0xb6f2a1c4), "arm", models.TriagePointer, "shared library or mmap", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := c.TriageBuffer(tt.input, tt.arch)
+			if err != nil {
+				t.Fatalf("TriageBuffer() error: %v", err)
+			}
+			if len(result.Findings) != 1 {
+				t.Fatalf("TriageBuffer() findings = %+v, want 1", result.Findings)
+			}
+			f := result.Findings[0]
+			if f.Kind != tt.kind || f.Region != tt.region || f.Address != tt.addr {
+				t.Errorf("TriageBuffer() = %+v, want %s %q %s", f, tt.kind, tt.region, tt.addr)
+			}
+		})
+	}
+}
+
+func TestTriageBuffer_NoFindings(t *testing.T) {
+	c := NewConverter()
+	for _, input := range []string{
+		leQuads(0, 1, 0xffffffffffffffff, 0xfffffffffffffff0), // small and negative numbers
+		leQuads(0x4141414141414100),                           // ASCII
+		leQuads(0x0000000012345600),                           // canary bytes with canonical upper bits
+		leQuads(0x3ff0000000000000),                           // float64 1.0
+	} {
+		result, err := c.TriageBuffer(input, "x86-64")
+		if err != nil {
+			t.Fatalf("TriageBuffer(%s) error: %v", input, err)
+		}
+		if len(result.Findings) != 0 {
+			t.Errorf("TriageBuffer(%s) = %+v, want no findings", input, result.Findings)
+		}
+	}
+
+	result, _ := c.TriageBuffer(leWords(0xffffffff, 0x00000100, 0x41424300), "x86")
+	if len(result.Findings) != 0 {
+		t.Errorf("TriageBuffer(x86) = %+v, want no findings", result.Findings)
+	}
+}
+
+func TestTriageBuffer_Layout(t *testing.T) {
+	c := NewConverter()
+	input := leQuads(0x0000000000000001, 0x9c3f5be1d27a8400, 0x00007ffd4c2a1e80) + "aabb"
+	result, err := c.TriageBuffer(input, "x86-64")
+	if err != nil {
+		t.Fatalf("TriageBuffer() error: %v", err)
+	}
+	if result.Arch != "x86-64" || result.WordSize != 8 || result.Words != 3 || result.Remainder != 2 || len(result.Findings) != 2 {
+		t.Fatalf("TriageBuffer() = %+v", result)
+	}
+	if f := result.Findings[0]; f.Offset != 8 || f.Value != "0x9c3f5be1d27a8400" {
+		t.Errorf("first finding = %+v, want canary at 8", f)
+	}
+	if f := result.Findings[1]; f.Offset != 16 || f.Value != "0x00007ffd4c2a1e80" {
+		t.Errorf("second finding = %+v, want pointer at 16", f)
+	}
+
+	if _, err := c.TriageBuffer(input, "riscv64"); !errors.Is(err, disasm.ErrUnsupportedArch) {
+		t.Errorf("TriageBuffer(riscv64) error = %v, want ErrUnsupportedArch", err)
+	}
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"hexview/convert"
+	"hexview/models"
+)
+
+// MaxPreviewItems is the number of bytes or registers shown in the
+// normalized preview of ValidateInput.
+const MaxPreviewItems = 32
+
+// Input formats detected by ValidateInput
+const (
+	FormatPrefixed   = "prefixed"   // 0x41 0x42 or 0x4142
+	FormatSpaced     = "spaced"     // 41 42
+	FormatColon      = "colon"      // 41:42
+	FormatComma      = "comma"      // 41,42
+	FormatDash       = "dash"       // 41-42
+	FormatContinuous = "continuous" // 4142
+	FormatDecimal    = "decimal"    // 1234 or 1.5
+	FormatScientific = "scientific" // 1.5e3
+	FormatHex        = "hex"        // Modbus registers in hex
+	FormatMixed      = "mixed"      // Modbus registers in hex and decimal (d prefix)
+)
+
+// ValidateInput checks input for the given conversion mode without
+// performing the conversion. It detects the notation and returns a short
+// normalized preview, so a UI can give feedback while the user types.
+// Invalid input is reported in the result; only an unknown mode is an error.
+func (c *Converter) ValidateInput(input, mode string) (*models.InputValidation, error) {
+	var v *models.InputValidation
+	var err error
+	switch mode {
+	case ModeHex:
+		v, err = validateHex(input)
+	case ModeBinary:
+		v, err = validateBinary(input)
+	case ModeInt:
+		v, err = validateInt(input)
+	case ModeFloat:
+		v, err = validateFloat(input, input)
+	case ModeIntAuto:
+		if normalized := strings.ReplaceAll(input, ",", "."); strings.Contains(normalized, ".") {
+			v, err = validateFloat(input, normalized)
+		} else {
+			v, err = validateInt(input)
+		}
+	case ModeModbus:
+		v, err = validateModbus(input)
+	case ModeEncoded:
+		v, err = validateEncoded(input)
+	default:
+		return nil, fmt.Errorf("unknown mode: %s", mode)
+	}
+	if err != nil {
+		details := ErrorDetails(err)
+		if details == nil {
+			details = &models.InputError{Message: err.Error(), Position: -1}
+		}
+		return &models.InputValidation{Error: details}, nil
+	}
+	v.Valid = true
+	return v, nil
+}
+
+func validateHex(input string) (*models.InputValidation, error) {
+	if input == "" {
+		return nil, errEmptyInput()
+	}
+	data, err := convert.ParseHex(input)
+	if err != nil {
+		return nil, err
+	}
+	return &models.InputValidation{Format: hexFormat(input), Normalized: previewBytes(data, "%02x"), Length: len(data)}, nil
+}
+
+func validateBinary(input string) (*models.InputValidation, error) {
+	if input == "" {
+		return nil, errEmptyInput()
+	}
+	data, err := convert.ParseBinary(input)
+	if err != nil {
+		return nil, err
+	}
+	return &models.InputValidation{Format: hexFormat(input), Normalized: previewBytes(data, "%08b"), Length: len(data)}, nil
+}
+
+// validateInt accepts any value that fits into int64 or uint64.
+func validateInt(input string) (*models.InputValidation, error) {
+	if input == "" {
+		return nil, errEmptyInput()
+	}
+	var normalized string
+	var i int64
+	if _, err := fmt.Sscanf(input, "%d", &i); err == nil {
+		normalized = strconv.FormatInt(i, 10)
+	} else {
+		var u uint64
+		if _, errU := fmt.Sscanf(input, "%d", &u); errU != nil {
+			return nil, numberError(input, "decimal", false, err)
+		}
+		normalized = strconv.FormatUint(u, 10)
+	}
+	return &models.InputValidation{Format: FormatDecimal, Normalized: normalized, Length: intByteLength(normalized)}, nil
+}
+
+// validateFloat parses normalized, which has the same length as input.
+func validateFloat(input, normalized string) (*models.InputValidation, error) {
+	if input == "" {
+		return nil, errEmptyInput()
+	}
+	var f float64
+	if _, err := fmt.Sscanf(normalized, "%f", &f); err != nil {
+		return nil, numberError(normalized, "float", true, err)
+	}
+	format := FormatDecimal
+	if strings.ContainsAny(normalized, "eE") {
+		format = FormatScientific
+	}
+	length := 8
+	if float64(float32(f)) == f {
+		length = 4
+	}
+	return &models.InputValidation{Format: format, Normalized: strconv.FormatFloat(f, 'g', -1, 64), Length: length}, nil
+}
+
+func validateModbus(input string) (*models.InputValidation, error) {
+	if input == "" {
+		return nil, errEmptyInput()
+	}
+	registers, err := parseModbusInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(registers) == 0 {
+		return nil, errNoRegisters()
+	}
+
+	var hexRegs, decRegs bool
+	for _, part := range strings.FieldsFunc(input, isModbusSeparator) {
+		if len(part) > 1 && (part[0] == 'd' || part[0] == 'D') {
+			decRegs = true
+		} else {
+			hexRegs = true
+		}
+	}
+	format := FormatHex
+	switch {
+	case hexRegs && decRegs:
+		format = FormatMixed
+	case decRegs:
+		format = FormatDecimal
+	}
+
+	parts := make([]string, 0, min(len(registers), MaxPreviewItems))
+	for _, r := range registers[:min(len(registers), MaxPreviewItems)] {
+		parts = append(parts, fmt.Sprintf("0x%04x", r))
+	}
+	normalized := strings.Join(parts, " ")
+	if len(registers) > MaxPreviewItems {
+		normalized += " …"
+	}
+	return &models.InputValidation{Format: format, Normalized: normalized, Length: len(registers)}, nil
+}
+
+// isModbusSeparator reports whether r separates Modbus register values.
+func isModbusSeparator(r rune) bool {
+	return r == ',' || r == ';' || r == ':' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
+
+// hexFormat classifies the notation of hex or binary input.
+func hexFormat(input string) string {
+	s := strings.TrimSpace(input)
+	switch {
+	case strings.Contains(s, "0x") || strings.Contains(s, "0X"):
+		return FormatPrefixed
+	case strings.Contains(s, ":"):
+		return FormatColon
+	case strings.Contains(s, ","):
+		return FormatComma
+	case strings.Contains(s, "-"):
+		return FormatDash
+	case strings.ContainsAny(s, " \t\r\n"):
+		return FormatSpaced
+	default:
+		return FormatContinuous
+	}
+}
+
+// previewBytes formats up to MaxPreviewItems bytes separated by spaces.
+func previewBytes(data []byte, verb string) string {
+	var sb strings.Builder
+	for i, b := range data[:min(len(data), MaxPreviewItems)] {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		fmt.Fprintf(&sb, verb, b)
+	}
+	if len(data) > MaxPreviewItems {
+		sb.WriteString(" …")
+	}
+	return sb.String()
+}
+
+// intByteLength returns the smallest integer size in bytes (1, 2, 4 or 8)
+// holding the decimal value s as a signed or unsigned integer.
+func intByteLength(s string) int {
+	for _, size := range []int{1, 2, 4} {
+		if _, err := strconv.ParseInt(s, 10, size*8); err == nil {
+			return size
+		}
+		if _, err := strconv.ParseUint(s, 10, size*8); err == nil {
+			return size
+		}
+	}
+	return 8
+}
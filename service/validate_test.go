@@ -0,0 +1,94 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateInput(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		name       string
+		input      string
+		mode       string
+		format     string
+		normalized string
+		length     int
+	}{
+		{"hex continuous", "48656c", ModeHex, FormatContinuous, "48 65 6c", 3},
+		{"hex prefixed", "0xAB 0xCD", ModeHex, FormatPrefixed, "ab cd", 2},
+		{"hex colon", "de:ad", ModeHex, FormatColon, "de ad", 2},
+		{"hex odd length", "123", ModeHex, FormatContinuous, "01 23", 2},
+		{"binary", "1010 1", ModeBinary, FormatSpaced, "00010101", 1},
+		{"int", " -129", ModeInt, FormatDecimal, "-129", 2},
+		{"large uint", "18446744073709551615", ModeInt, FormatDecimal, "18446744073709551615", 8},
+		{"float", "0.5", ModeFloat, FormatDecimal, "0.5", 4},
+		{"float64 only", "0.1", ModeFloat, FormatDecimal, "0.1", 8},
+		{"scientific", "1.5e3", ModeFloat, FormatScientific, "1500", 4},
+		{"auto int", "255", ModeIntAuto, FormatDecimal, "255", 1},
+		{"auto float with comma", "2,5", ModeIntAuto, FormatDecimal, "2.5", 4},
+		{"modbus hex", "0x1234, 5678", ModeModbus, FormatHex, "0x1234 0x5678", 2},
+		{"modbus decimal", "d1000 d2", ModeModbus, FormatDecimal, "0x03e8 0x0002", 2},
+		{"modbus mixed", "d1000 ff", ModeModbus, FormatMixed, "0x03e8 0x00ff", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := c.ValidateInput(tt.input, tt.mode)
+			if err != nil {
+				t.Fatalf("ValidateInput() error: %v", err)
+			}
+			if !v.Valid || v.Error != nil {
+				t.Fatalf("ValidateInput() invalid: %+v", v.Error)
+			}
+			if v.Format != tt.format || v.Normalized != tt.normalized || v.Length != tt.length {
+				t.Errorf("ValidateInput() = %+v, want format %q normalized %q length %d", v, tt.format, tt.normalized, tt.length)
+			}
+		})
+	}
+}
+
+func TestValidateInput_Invalid(t *testing.T) {
+	c := NewConverter()
+	tests := []struct {
+		input    string
+		mode     string
+		code     string
+		position int
+	}{
+		{"", ModeHex, "empty_input", -1},
+		{"12 zz", ModeHex, "invalid_hex_char", 3},
+		{"102", ModeBinary, "invalid_binary_char", 2},
+		{"abc", ModeInt, "invalid_number", 0},
+		{"99999999999999999999", ModeInt, "out_of_range", 0},
+		{"x.5", ModeIntAuto, "invalid_number", 0},
+		{"1 10000", ModeModbus, "out_of_range", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode+" "+tt.input, func(t *testing.T) {
+			v, err := c.ValidateInput(tt.input, tt.mode)
+			if err != nil {
+				t.Fatalf("ValidateInput() error: %v", err)
+			}
+			if v.Valid || v.Error == nil {
+				t.Fatalf("ValidateInput() = %+v, want invalid", v)
+			}
+			if v.Error.Code != tt.code || v.Error.Position != tt.position {
+				t.Errorf("Error = %+v, want code %s position %d", v.Error, tt.code, tt.position)
+			}
+		})
+	}
+
+	if _, err := c.ValidateInput("00", "octal"); err == nil {
+		t.Error("Expected error for unknown mode")
+	}
+}
+
+func TestValidateInput_PreviewTruncated(t *testing.T) {
+	v, err := NewConverter().ValidateInput(strings.Repeat("ab", MaxPreviewItems+1), ModeHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Length != MaxPreviewItems+1 || !strings.HasSuffix(v.Normalized, "ab …") {
+		t.Errorf("ValidateInput() = %+v", v)
+	}
+}
@@ -0,0 +1,189 @@
+// Package sparse holds buffers whose bytes are known only in some address
+// ranges, e.g. memory dumps with unreadable pages or firmware images in
+// which only the programmed regions are listed. A Buffer is a sorted list
+// of non-overlapping segments; the ranges between them are gaps.
+//
+// Example usage:
+//
+//	var b sparse.Buffer
+//	_ = b.Add(0x08000000, []byte{0x00, 0x50, 0x00, 0x20})
+//	_ = b.Add(0x08000100, []byte{0xde, 0xad})
+//	fmt.Println(b.Gaps()) // [{0x8000004 252}]
+package sparse
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// ErrOverlap indicates bytes added at addresses the buffer already holds
+var ErrOverlap = errors.New("overlapping segments")
+
+// ErrAddressRange indicates bytes that would reach the end of the 64-bit
+// address space
+var ErrAddressRange = errors.New("segment past the end of the address space")
+
+// Segment is a run of known bytes starting at Address.
+type Segment struct {
+	Address uint64
+	Data    []byte
+}
+
+// End returns the address after the last byte of s.
+func (s Segment) End() uint64 {
+	return s.Address + uint64(len(s.Data))
+}
+
+// Range is an address range, e.g. a gap between segments.
+type Range struct {
+	Start  uint64
+	Length uint64
+}
+
+// String formats r as start and length, e.g. "{0x8000004 252}".
+func (r Range) String() string {
+	return fmt.Sprintf("{0x%x %d}", r.Start, r.Length)
+}
+
+// Buffer is a sparse buffer. The zero value is empty and ready to use.
+type Buffer struct {
+	segs []Segment // sorted by address, not touching
+}
+
+// FromBytes returns a buffer holding data at base, with the ranges of
+// gaps left out, e.g. to turn a dump that fills gaps with zeros into a
+// sparse buffer. Gaps outside data are ignored.
+func FromBytes(base uint64, data []byte, gaps []Range) (*Buffer, error) {
+	b := &Buffer{}
+	pos := uint64(0)
+	for _, g := range gaps {
+		start := min(max(g.Start, base)-base, uint64(len(data)))
+		end := min(max(g.Start+g.Length, base)-base, uint64(len(data)))
+		if start < pos || start == end {
+			continue
+		}
+		if err := b.Add(base+pos, data[pos:start]); err != nil {
+			return nil, err
+		}
+		pos = end
+	}
+	if err := b.Add(base+pos, data[pos:]); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Add copies data into the buffer at addr. Data that touches a segment is
+// merged with it; data overlapping one is an ErrOverlap.
+func (b *Buffer) Add(addr uint64, data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if uint64(len(data)) > math.MaxUint64-addr {
+		return fmt.Errorf("%w: %d bytes at 0x%x", ErrAddressRange, len(data), addr)
+	}
+	end := addr + uint64(len(data))
+	i, _ := slices.BinarySearchFunc(b.segs, addr, func(s Segment, a uint64) int {
+		switch {
+		case s.Address < a:
+			return -1
+		case s.Address > a:
+			return 1
+		}
+		return 0
+	})
+	if i > 0 && b.segs[i-1].End() > addr {
+		return fmt.Errorf("%w: 0x%x is in the segment at 0x%x", ErrOverlap, addr, b.segs[i-1].Address)
+	}
+	if i < len(b.segs) && b.segs[i].Address < end {
+		return fmt.Errorf("%w: the segment at 0x%x starts before 0x%x", ErrOverlap, b.segs[i].Address, end)
+	}
+
+	seg := Segment{Address: addr, Data: slices.Clone(data)}
+	if i < len(b.segs) && b.segs[i].Address == end {
+		seg.Data = append(seg.Data, b.segs[i].Data...)
+		b.segs = slices.Delete(b.segs, i, i+1)
+	}
+	if i > 0 && b.segs[i-1].End() == addr {
+		b.segs[i-1].Data = append(b.segs[i-1].Data, seg.Data...)
+		return nil
+	}
+	b.segs = slices.Insert(b.segs, i, seg)
+	return nil
+}
+
+// Segments returns the segments sorted by address. They share their data
+// with the buffer.
+func (b *Buffer) Segments() []Segment {
+	return b.segs
+}
+
+// Gaps returns the ranges between the segments.
+func (b *Buffer) Gaps() []Range {
+	var gaps []Range
+	for i := 1; i < len(b.segs); i++ {
+		start := b.segs[i-1].End()
+		gaps = append(gaps, Range{Start: start, Length: b.segs[i].Address - start})
+	}
+	return gaps
+}
+
+// Start returns the address of the first byte, 0 for an empty buffer.
+func (b *Buffer) Start() uint64 {
+	if len(b.segs) == 0 {
+		return 0
+	}
+	return b.segs[0].Address
+}
+
+// End returns the address after the last byte, 0 for an empty buffer.
+func (b *Buffer) End() uint64 {
+	if len(b.segs) == 0 {
+		return 0
+	}
+	return b.segs[len(b.segs)-1].End()
+}
+
+// Size returns the number of bytes held, without gaps.
+func (b *Buffer) Size() int {
+	n := 0
+	for _, s := range b.segs {
+		n += len(s.Data)
+	}
+	return n
+}
+
+// Read returns the n bytes from addr and which of them the buffer holds.
+// Bytes in gaps or outside the buffer are zero with valid false.
+func (b *Buffer) Read(addr uint64, n int) (data []byte, valid []bool) {
+	data, valid = make([]byte, n), make([]bool, n)
+	end := addr + uint64(n)
+	for _, s := range b.segs {
+		if s.End() <= addr || s.Address >= end {
+			continue
+		}
+		from, to := max(s.Address, addr), min(s.End(), end)
+		copy(data[from-addr:to-addr], s.Data[from-s.Address:to-s.Address])
+		for i := from - addr; i < to-addr; i++ {
+			valid[i] = true
+		}
+	}
+	return data, valid
+}
+
+// Bytes returns the bytes from Start to End with the gaps filled with
+// fill. Callers check End - Start first, as gaps may span gigabytes.
+func (b *Buffer) Bytes(fill byte) []byte {
+	out := make([]byte, 0, b.End()-b.Start())
+	for i, s := range b.segs {
+		if i > 0 {
+			for range s.Address - b.segs[i-1].End() {
+				out = append(out, fill)
+			}
+		}
+		out = append(out, s.Data...)
+	}
+	return out
+}
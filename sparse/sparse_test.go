@@ -0,0 +1,84 @@
+package sparse
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBuffer_Add(t *testing.T) {
+	var b Buffer
+	for _, s := range []Segment{
+		{0x1000, []byte{1, 2}},
+		{0x0800, []byte{9}},
+		{0x1004, []byte{5, 6}},
+		{0x1002, []byte{3, 4}}, // fills the gap, merging three segments
+		{0x2000, nil},
+	} {
+		if err := b.Add(s.Address, s.Data); err != nil {
+			t.Fatalf("Add(0x%x) error: %v", s.Address, err)
+		}
+	}
+	want := []Segment{{0x0800, []byte{9}}, {0x1000, []byte{1, 2, 3, 4, 5, 6}}}
+	if !reflect.DeepEqual(b.Segments(), want) {
+		t.Errorf("Segments() = %v, want %v", b.Segments(), want)
+	}
+	if gaps := b.Gaps(); !reflect.DeepEqual(gaps, []Range{{0x0801, 0x7ff}}) {
+		t.Errorf("Gaps() = %v", gaps)
+	}
+	if b.Start() != 0x0800 || b.End() != 0x1006 || b.Size() != 7 {
+		t.Errorf("Start, End, Size = 0x%x, 0x%x, %d", b.Start(), b.End(), b.Size())
+	}
+
+	tests := []struct {
+		name string
+		addr uint64
+		data []byte
+		want error
+	}{
+		{"inside", 0x1003, []byte{0}, ErrOverlap},
+		{"ends inside", 0x0fff, []byte{0, 0}, ErrOverlap},
+		{"covers", 0x07ff, []byte{0, 0, 0}, ErrOverlap},
+		{"end of address space", 0xffffffff_ffffffff, []byte{0}, ErrAddressRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := b.Add(tt.addr, tt.data); !errors.Is(err, tt.want) {
+				t.Errorf("Add(0x%x) error = %v, want %v", tt.addr, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuffer_Read(t *testing.T) {
+	var b Buffer
+	_ = b.Add(10, []byte{1, 2})
+	_ = b.Add(14, []byte{3})
+	data, valid := b.Read(9, 7)
+	if !reflect.DeepEqual(data, []byte{0, 1, 2, 0, 0, 3, 0}) {
+		t.Errorf("Read() data = %v", data)
+	}
+	if !reflect.DeepEqual(valid, []bool{false, true, true, false, false, true, false}) {
+		t.Errorf("Read() valid = %v", valid)
+	}
+	if got := b.Bytes(0xff); !reflect.DeepEqual(got, []byte{1, 2, 0xff, 0xff, 3}) {
+		t.Errorf("Bytes() = %v", got)
+	}
+
+	var empty Buffer
+	if empty.Start() != 0 || empty.End() != 0 || len(empty.Bytes(0)) != 0 || empty.Gaps() != nil {
+		t.Error("empty buffer is not empty")
+	}
+}
+
+func TestFromBytes(t *testing.T) {
+	data := []byte{1, 2, 0, 0, 5, 0, 7}
+	b, err := FromBytes(0x100, data, []Range{{0x102, 2}, {0x105, 1}, {0x200, 4}})
+	if err != nil {
+		t.Fatalf("FromBytes() error: %v", err)
+	}
+	want := []Segment{{0x100, []byte{1, 2}}, {0x104, []byte{5}}, {0x106, []byte{7}}}
+	if !reflect.DeepEqual(b.Segments(), want) {
+		t.Errorf("FromBytes() = %v, want %v", b.Segments(), want)
+	}
+}
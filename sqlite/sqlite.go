@@ -0,0 +1,231 @@
+// Package sqlite decodes the on-disk structures of SQLite database files.
+//
+// It covers the 100-byte database header at the start of page 1 and the b-tree
+// page header (plus cell pointer array) found at the start of every b-tree page.
+// No SQL engine is involved; the decoder only interprets raw bytes, which makes
+// it suitable for inspecting damaged or partially recovered files.
+//
+// Example usage:
+//
+//	data, _ := os.ReadFile("app.db")
+//	hdr, _ := sqlite.ParseHeader(data)
+//	page2 := data[hdr.PageSize : 2*hdr.PageSize]
+//	page, _ := sqlite.ParsePage(page2, 0)
+//	fmt.Println(page.Type, page.CellCount)
+package sqlite
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// HeaderSize is the size of the database header at the start of page 1
+const HeaderSize = 100
+
+// Magic is the header string identifying an SQLite 3 database
+const Magic = "SQLite format 3\x00"
+
+// Error definitions for SQLite decoding
+var (
+	// ErrNotSQLite indicates the data does not start with the SQLite magic string
+	ErrNotSQLite = errors.New("not an SQLite database header")
+
+	// ErrInvalidPage indicates the data does not hold a valid b-tree page header
+	ErrInvalidPage = errors.New("invalid b-tree page header")
+)
+
+// PageType is the b-tree page type flag (first byte of the page header)
+type PageType byte
+
+// B-tree page types
+const (
+	PageInteriorIndex PageType = 0x02
+	PageInteriorTable PageType = 0x05
+	PageLeafIndex     PageType = 0x0a
+	PageLeafTable     PageType = 0x0d
+)
+
+// String returns the descriptive name of the page type.
+func (t PageType) String() string {
+	switch t {
+	case PageInteriorIndex:
+		return "interior index"
+	case PageInteriorTable:
+		return "interior table"
+	case PageLeafIndex:
+		return "leaf index"
+	case PageLeafTable:
+		return "leaf table"
+	default:
+		return fmt.Sprintf("unknown (0x%02x)", byte(t))
+	}
+}
+
+// Interior reports whether the page is an interior page, which carries a right-most pointer.
+func (t PageType) Interior() bool {
+	return t == PageInteriorIndex || t == PageInteriorTable
+}
+
+// Header is the decoded 100-byte database header.
+type Header struct {
+	PageSize            int // in bytes; the stored value 1 means 65536
+	WriteVersion        byte
+	ReadVersion         byte
+	ReservedSpace       byte
+	MaxPayloadFraction  byte
+	MinPayloadFraction  byte
+	LeafPayloadFraction byte
+	ChangeCounter       uint32
+	PageCount           uint32
+	FirstFreelistTrunk  uint32
+	FreelistPages       uint32
+	SchemaCookie        uint32
+	SchemaFormat        uint32
+	DefaultCacheSize    uint32
+	LargestRootPage     uint32 // non-zero in auto-vacuum mode
+	TextEncoding        uint32
+	UserVersion         uint32
+	IncrementalVacuum   bool
+	ApplicationID       uint32
+	VersionValidFor     uint32
+	SQLiteVersion       uint32
+	Warnings            []string
+}
+
+// EncodingName returns the name of the database text encoding.
+func (h *Header) EncodingName() string {
+	switch h.TextEncoding {
+	case 1:
+		return "UTF-8"
+	case 2:
+		return "UTF-16le"
+	case 3:
+		return "UTF-16be"
+	default:
+		return fmt.Sprintf("unknown (%d)", h.TextEncoding)
+	}
+}
+
+// VersionString formats the SQLite library version number, e.g. 3045001 as "3.45.1".
+func (h *Header) VersionString() string {
+	v := h.SQLiteVersion
+	return fmt.Sprintf("%d.%d.%d", v/1000000, v/1000%1000, v%1000)
+}
+
+// PageHeader is a decoded b-tree page header.
+type PageHeader struct {
+	Type             PageType
+	FirstFreeblock   uint16
+	CellCount        uint16
+	CellContentStart int // the stored value 0 means 65536
+	FragmentedBytes  byte
+	RightMostPointer uint32   // interior pages only
+	HeaderSize       int      // 8 for leaf pages, 12 for interior pages
+	CellPointers     []uint16 // offsets of the cells relative to the page start
+}
+
+// ParseHeader decodes the database header at the start of data.
+func ParseHeader(data []byte) (*Header, error) {
+	if len(data) < HeaderSize || !bytes.HasPrefix(data, []byte(Magic)) {
+		return nil, ErrNotSQLite
+	}
+
+	be := binary.BigEndian
+	h := &Header{
+		PageSize:            int(be.Uint16(data[16:])),
+		WriteVersion:        data[18],
+		ReadVersion:         data[19],
+		ReservedSpace:       data[20],
+		MaxPayloadFraction:  data[21],
+		MinPayloadFraction:  data[22],
+		LeafPayloadFraction: data[23],
+		ChangeCounter:       be.Uint32(data[24:]),
+		PageCount:           be.Uint32(data[28:]),
+		FirstFreelistTrunk:  be.Uint32(data[32:]),
+		FreelistPages:       be.Uint32(data[36:]),
+		SchemaCookie:        be.Uint32(data[40:]),
+		SchemaFormat:        be.Uint32(data[44:]),
+		DefaultCacheSize:    be.Uint32(data[48:]),
+		LargestRootPage:     be.Uint32(data[52:]),
+		TextEncoding:        be.Uint32(data[56:]),
+		UserVersion:         be.Uint32(data[60:]),
+		IncrementalVacuum:   be.Uint32(data[64:]) != 0,
+		ApplicationID:       be.Uint32(data[68:]),
+		VersionValidFor:     be.Uint32(data[92:]),
+		SQLiteVersion:       be.Uint32(data[96:]),
+	}
+	if h.PageSize == 1 {
+		h.PageSize = 65536
+	}
+
+	if h.PageSize < 512 || h.PageSize&(h.PageSize-1) != 0 {
+		h.warn("page size %d is not a power of two between 512 and 65536", h.PageSize)
+	}
+	if h.MaxPayloadFraction != 64 || h.MinPayloadFraction != 32 || h.LeafPayloadFraction != 32 {
+		h.warn("unexpected payload fractions %d/%d/%d (want 64/32/32)",
+			h.MaxPayloadFraction, h.MinPayloadFraction, h.LeafPayloadFraction)
+	}
+	if h.TextEncoding < 1 || h.TextEncoding > 3 {
+		h.warn("unknown text encoding %d", h.TextEncoding)
+	}
+	if h.VersionValidFor != h.ChangeCounter {
+		h.warn("page count may be stale (version-valid-for %d != change counter %d)", h.VersionValidFor, h.ChangeCounter)
+	}
+	return h, nil
+}
+
+// ParsePage decodes the b-tree page header located at headerOffset within page.
+// page must start at the beginning of the page; headerOffset is 100 for page 1
+// and 0 for all other pages.
+func ParsePage(page []byte, headerOffset int) (*PageHeader, error) {
+	if headerOffset < 0 || headerOffset+8 > len(page) {
+		return nil, fmt.Errorf("%w: truncated at offset %d", ErrInvalidPage, headerOffset)
+	}
+
+	be := binary.BigEndian
+	b := page[headerOffset:]
+	p := &PageHeader{
+		Type:             PageType(b[0]),
+		FirstFreeblock:   be.Uint16(b[1:]),
+		CellCount:        be.Uint16(b[3:]),
+		CellContentStart: int(be.Uint16(b[5:])),
+		FragmentedBytes:  b[7],
+		HeaderSize:       8,
+	}
+	switch p.Type {
+	case PageInteriorIndex, PageInteriorTable, PageLeafIndex, PageLeafTable:
+	default:
+		return nil, fmt.Errorf("%w: page type 0x%02x", ErrInvalidPage, b[0])
+	}
+	if p.CellContentStart == 0 {
+		p.CellContentStart = 65536
+	}
+
+	if p.Type.Interior() {
+		if len(b) < 12 {
+			return nil, fmt.Errorf("%w: truncated interior header", ErrInvalidPage)
+		}
+		p.RightMostPointer = be.Uint32(b[8:])
+		p.HeaderSize = 12
+	}
+
+	ptrs := b[p.HeaderSize:]
+	n := min(int(p.CellCount), len(ptrs)/2)
+	p.CellPointers = make([]uint16, n)
+	for i := range p.CellPointers {
+		p.CellPointers[i] = be.Uint16(ptrs[i*2:])
+	}
+	return p, nil
+}
+
+// PageOffset returns the file offset of the given 1-based page number.
+func PageOffset(pageNumber uint32, pageSize int) int64 {
+	return int64(pageNumber-1) * int64(pageSize)
+}
+
+// warn records a non-fatal header inconsistency.
+func (h *Header) warn(format string, args ...any) {
+	h.Warnings = append(h.Warnings, fmt.Sprintf(format, args...))
+}
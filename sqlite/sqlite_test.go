@@ -0,0 +1,126 @@
+package sqlite
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// buildDB assembles a two-page database with 1024-byte pages: page 1 is a leaf
+// table page holding two cells, page 2 an interior table page.
+func buildDB() []byte {
+	const pageSize = 1024
+	data := make([]byte, 2*pageSize)
+	be := binary.BigEndian
+
+	copy(data, Magic)
+	be.PutUint16(data[16:], pageSize)
+	data[18], data[19] = 1, 1
+	data[21], data[22], data[23] = 64, 32, 32
+	be.PutUint32(data[24:], 7) // change counter
+	be.PutUint32(data[28:], 2) // page count
+	be.PutUint32(data[44:], 4) // schema format
+	be.PutUint32(data[56:], 1) // UTF-8
+	be.PutUint32(data[92:], 7) // version-valid-for
+	be.PutUint32(data[96:], 3045001)
+
+	// Page 1 b-tree header at offset 100
+	p1 := data[100:]
+	p1[0] = byte(PageLeafTable)
+	be.PutUint16(p1[3:], 2)    // cells
+	be.PutUint16(p1[5:], 1000) // content start
+	be.PutUint16(p1[8:], 1000)
+	be.PutUint16(p1[10:], 1012)
+
+	// Page 2 interior header at offset 1024
+	p2 := data[pageSize:]
+	p2[0] = byte(PageInteriorTable)
+	be.PutUint16(p2[3:], 1)
+	be.PutUint16(p2[5:], 0) // 65536
+	be.PutUint32(p2[8:], 5)
+	be.PutUint16(p2[12:], 1020)
+	return data
+}
+
+func TestParseHeader(t *testing.T) {
+	h, err := ParseHeader(buildDB())
+	if err != nil {
+		t.Fatalf("ParseHeader() error = %v", err)
+	}
+	if h.PageSize != 1024 || h.PageCount != 2 || h.ChangeCounter != 7 {
+		t.Errorf("unexpected header: %+v", h)
+	}
+	if h.EncodingName() != "UTF-8" {
+		t.Errorf("EncodingName() = %q", h.EncodingName())
+	}
+	if h.VersionString() != "3.45.1" {
+		t.Errorf("VersionString() = %q", h.VersionString())
+	}
+	if len(h.Warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", h.Warnings)
+	}
+
+	data := buildDB()
+	binary.BigEndian.PutUint16(data[16:], 1)
+	data[21] = 10
+	h, _ = ParseHeader(data)
+	if h.PageSize != 65536 {
+		t.Errorf("PageSize = %d, want 65536", h.PageSize)
+	}
+	if len(h.Warnings) != 1 {
+		t.Errorf("expected payload fraction warning, got %v", h.Warnings)
+	}
+
+	if _, err := ParseHeader([]byte("SQLite format 2")); !errors.Is(err, ErrNotSQLite) {
+		t.Errorf("expected ErrNotSQLite, got %v", err)
+	}
+}
+
+func TestParsePage(t *testing.T) {
+	data := buildDB()
+
+	tests := []struct {
+		name         string
+		page         []byte
+		headerOffset int
+		wantType     PageType
+		wantCells    []uint16
+		wantRight    uint32
+		wantContent  int
+	}{
+		{"page 1 leaf", data[:1024], 100, PageLeafTable, []uint16{1000, 1012}, 0, 1000},
+		{"page 2 interior", data[1024:], 0, PageInteriorTable, []uint16{1020}, 5, 65536},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ParsePage(tt.page, tt.headerOffset)
+			if err != nil {
+				t.Fatalf("ParsePage() error = %v", err)
+			}
+			if p.Type != tt.wantType || p.RightMostPointer != tt.wantRight || p.CellContentStart != tt.wantContent {
+				t.Errorf("unexpected page header: %+v", p)
+			}
+			if len(p.CellPointers) != len(tt.wantCells) {
+				t.Fatalf("CellPointers = %v, want %v", p.CellPointers, tt.wantCells)
+			}
+			for i, c := range tt.wantCells {
+				if p.CellPointers[i] != c {
+					t.Errorf("CellPointers[%d] = %d, want %d", i, p.CellPointers[i], c)
+				}
+			}
+		})
+	}
+
+	if _, err := ParsePage(make([]byte, 16), 0); !errors.Is(err, ErrInvalidPage) {
+		t.Errorf("expected ErrInvalidPage for zeroed page, got %v", err)
+	}
+	if _, err := ParsePage(make([]byte, 4), 0); !errors.Is(err, ErrInvalidPage) {
+		t.Errorf("expected ErrInvalidPage for truncated page, got %v", err)
+	}
+}
+
+func TestPageTypeString(t *testing.T) {
+	if PageLeafIndex.String() != "leaf index" || PageType(0x42).String() != "unknown (0x42)" {
+		t.Error("unexpected page type names")
+	}
+}
@@ -0,0 +1,373 @@
+// Package structdecode decodes binary data with user-defined layouts, so
+// packets and C structs read from a device can be inspected field by field.
+//
+// A layout is written in JSON or in a small DSL with one field per line:
+// its name, its type with an optional [N] for arrays and the size of text
+// and byte fields, and an optional byte order. Fields follow each other
+// unless an @offset starts the line; "#" starts a comment.
+//
+//	struct header
+//	endian le
+//	magic    u32 be
+//	version  u8
+//	flags    u16
+//	name     char[8]
+//	_        pad[2]
+//	@0x14 crc u32
+//
+// Example usage:
+//
+//	layout, _ := structdecode.Parse(text)
+//	values, _ := structdecode.Decode(data, layout)
+//	for _, v := range values {
+//		fmt.Println(v.Field.Name, v.Value)
+//	}
+package structdecode
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"hexview/convert"
+)
+
+var (
+	// ErrInvalidLayout indicates a layout that cannot be parsed or has an
+	// invalid field
+	ErrInvalidLayout = errors.New("invalid layout")
+
+	// ErrShortData indicates data that ends before a field of the layout
+	ErrShortData = errors.New("data too short for layout")
+)
+
+// Layout is a struct layout.
+type Layout struct {
+	Name   string  `json:"name,omitempty"`
+	Endian string  `json:"endian,omitempty"` // le or be of fields without their own; le if empty
+	Fields []Field `json:"fields"`
+}
+
+// Field is a field of a layout.
+type Field struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`             // e.g. u16, int32, float32, bool, char, bytes or pad
+	Endian string `json:"endian,omitempty"` // le or be; the layout's if empty
+	Offset *int   `json:"offset,omitempty"` // byte offset; right after the previous field if nil
+	Count  int    `json:"count,omitempty"`  // array length of number fields; 1 if zero
+	Size   int    `json:"size,omitempty"`   // bytes of char, bytes and pad fields
+}
+
+// Value is a decoded field.
+type Value struct {
+	Field  *Field
+	Offset int
+	Endian string // le or be the numbers were read in; empty for bytes, chars and 1-byte types
+	Raw    []byte
+	Value  string // numbers in decimal, arrays as [1, 2], char as text, bytes in hex
+}
+
+// kind is how a type is decoded.
+type kind int
+
+const (
+	kindUint kind = iota
+	kindInt
+	kindFloat
+	kindBool
+	kindChar
+	kindBytes
+	kindPad
+)
+
+// typeInfo describes a canonical type name.
+type typeInfo struct {
+	kind kind
+	size int // bytes of an element; 0 for char, bytes and pad
+}
+
+// types maps the canonical type names to their decoding.
+var types = map[string]typeInfo{
+	"u8": {kindUint, 1}, "u16": {kindUint, 2}, "u32": {kindUint, 4}, "u64": {kindUint, 8},
+	"i8": {kindInt, 1}, "i16": {kindInt, 2}, "i32": {kindInt, 4}, "i64": {kindInt, 8},
+	"f16": {kindFloat, 2}, "f32": {kindFloat, 4}, "f64": {kindFloat, 8},
+	"bool":  {kindBool, 1},
+	"char":  {kindChar, 0},
+	"bytes": {kindBytes, 0},
+	"pad":   {kindPad, 0},
+}
+
+// aliases maps other common type names to the canonical ones.
+var aliases = map[string]string{
+	"byte": "u8", "uchar": "u8", "half": "f16", "float": "f32", "double": "f64",
+	"string": "char", "str": "char", "raw": "bytes", "padding": "pad", "reserved": "pad",
+}
+
+// canonicalType returns the canonical name of a type, accepting C and Go
+// spellings such as uint16_t, int32 and float64.
+func canonicalType(t string) (string, bool) {
+	t = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(t)), "_t")
+	if a, ok := aliases[t]; ok {
+		t = a
+	}
+	for _, p := range [][2]string{{"uint", "u"}, {"int", "i"}, {"float", "f"}} {
+		if bits, ok := strings.CutPrefix(t, p[0]); ok && bits != "" {
+			t = p[1] + bits
+			break
+		}
+	}
+	_, ok := types[t]
+	return t, ok
+}
+
+// Parse reads a layout in JSON, if text starts with "{", or in the DSL.
+func Parse(text string) (*Layout, error) {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "{") {
+		var l Layout
+		if err := json.Unmarshal([]byte(text), &l); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidLayout, err)
+		}
+		if err := l.Validate(); err != nil {
+			return nil, err
+		}
+		return &l, nil
+	}
+	return parseDSL(text)
+}
+
+// parseDSL reads a layout written one field per line.
+func parseDSL(text string) (*Layout, error) {
+	l := &Layout{}
+	for i, line := range strings.Split(text, "\n") {
+		if c := strings.Index(line, "#"); c >= 0 {
+			line = line[:c]
+		}
+		tokens := strings.Fields(line)
+		if len(tokens) == 0 {
+			continue
+		}
+		fail := func(format string, args ...any) error {
+			return fmt.Errorf("%w: line %d: %s", ErrInvalidLayout, i+1, fmt.Sprintf(format, args...))
+		}
+
+		switch tokens[0] {
+		case "struct":
+			if len(tokens) != 2 {
+				return nil, fail("want struct <name>")
+			}
+			l.Name = tokens[1]
+			continue
+		case "endian":
+			if len(tokens) != 2 {
+				return nil, fail("want endian le or be")
+			}
+			l.Endian = tokens[1]
+			continue
+		}
+
+		var f Field
+		if at, ok := strings.CutPrefix(tokens[0], "@"); ok {
+			off, err := strconv.ParseInt(at, 0, 32)
+			if err != nil {
+				return nil, fail("invalid offset %s", tokens[0])
+			}
+			o := int(off)
+			f.Offset = &o
+			tokens = tokens[1:]
+		}
+		if len(tokens) < 2 || len(tokens) > 3 {
+			return nil, fail("want [@offset] <name> <type>[N] [le|be]")
+		}
+		f.Name, f.Type = tokens[0], tokens[1]
+		if len(tokens) == 3 {
+			f.Endian = tokens[2]
+		}
+		if open := strings.Index(f.Type, "["); open >= 0 {
+			if !strings.HasSuffix(f.Type, "]") {
+				return nil, fail("invalid type %s", f.Type)
+			}
+			n, err := strconv.ParseInt(f.Type[open+1:len(f.Type)-1], 0, 32)
+			if err != nil || n <= 0 {
+				return nil, fail("invalid length in %s", f.Type)
+			}
+			f.Type = f.Type[:open]
+			if t, ok := canonicalType(f.Type); ok && types[t].size == 0 {
+				f.Size = int(n)
+			} else {
+				f.Count = int(n)
+			}
+		}
+		l.Fields = append(l.Fields, f)
+	}
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Validate checks the types, byte orders and sizes of the fields.
+func (l *Layout) Validate() error {
+	if _, err := byteOrder(l.Endian); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidLayout, err)
+	}
+	if len(l.Fields) == 0 {
+		return fmt.Errorf("%w: no fields", ErrInvalidLayout)
+	}
+	for _, f := range l.Fields {
+		t, ok := canonicalType(f.Type)
+		switch {
+		case f.Name == "":
+			return fmt.Errorf("%w: field of type %s without a name", ErrInvalidLayout, f.Type)
+		case !ok:
+			return fmt.Errorf("%w: %s: unknown type %s", ErrInvalidLayout, f.Name, f.Type)
+		case f.Offset != nil && *f.Offset < 0:
+			return fmt.Errorf("%w: %s: negative offset", ErrInvalidLayout, f.Name)
+		case f.Count < 0 || f.Size < 0:
+			return fmt.Errorf("%w: %s: negative length", ErrInvalidLayout, f.Name)
+		case types[t].size == 0 && f.Size == 0:
+			return fmt.Errorf("%w: %s: %s needs a size", ErrInvalidLayout, f.Name, f.Type)
+		case types[t].size != 0 && f.Size != 0:
+			return fmt.Errorf("%w: %s: size only applies to char, bytes and pad, use count", ErrInvalidLayout, f.Name)
+		}
+		if _, err := byteOrder(f.Endian); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidLayout, f.Name, err)
+		}
+	}
+	return nil
+}
+
+// byteOrder returns the byte order of an endian name, or nil if it is empty.
+func byteOrder(endian string) (binary.ByteOrder, error) {
+	switch strings.ToLower(endian) {
+	case "":
+		return nil, nil
+	case "le", "little":
+		return binary.LittleEndian, nil
+	case "be", "big":
+		return binary.BigEndian, nil
+	}
+	return nil, fmt.Errorf("unknown byte order %s", endian)
+}
+
+// Decode applies a layout to data. Pad fields are skipped; fields may
+// overlap, e.g. to read a union both ways. If the data ends early, the
+// fields before are returned with ErrShortData.
+func Decode(data []byte, l *Layout) ([]Value, error) {
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+	order, _ := byteOrder(l.Endian)
+	if order == nil {
+		order = binary.LittleEndian
+	}
+
+	var values []Value
+	pos := 0
+	for i := range l.Fields {
+		f := &l.Fields[i]
+		t, _ := canonicalType(f.Type)
+		info := types[t]
+		if f.Offset != nil {
+			pos = *f.Offset
+		}
+		size := f.Size
+		if info.size != 0 {
+			size = info.size * max(f.Count, 1)
+		}
+		if pos+size > len(data) {
+			return values, fmt.Errorf("%w: %s needs bytes %d to %d, got %d", ErrShortData, f.Name, pos, pos+size, len(data))
+		}
+		raw := data[pos : pos+size]
+		start := pos
+		pos += size
+		if info.kind == kindPad {
+			continue
+		}
+
+		fieldOrder, _ := byteOrder(f.Endian)
+		if fieldOrder == nil {
+			fieldOrder = order
+		}
+		v := Value{Field: f, Offset: start, Raw: raw}
+		switch info.kind {
+		case kindChar:
+			text, _, _ := strings.Cut(string(raw), "\x00")
+			v.Value = strings.ToValidUTF8(text, "�")
+		case kindBytes:
+			v.Value = convert.BytesToHex(raw)
+		default:
+			switch {
+			case info.size == 1:
+			case fieldOrder == binary.BigEndian:
+				v.Endian = "be"
+			default:
+				v.Endian = "le"
+			}
+			elems := make([]string, 0, max(f.Count, 1))
+			for e := 0; e < size; e += info.size {
+				elems = append(elems, element(raw[e:e+info.size], info, fieldOrder))
+			}
+			v.Value = elems[0]
+			if f.Count > 0 {
+				v.Value = "[" + strings.Join(elems, ", ") + "]"
+			}
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// element formats one number or bool.
+func element(b []byte, info typeInfo, order binary.ByteOrder) string {
+	var u uint64
+	switch info.size {
+	case 1:
+		u = uint64(b[0])
+	case 2:
+		u = uint64(order.Uint16(b))
+	case 4:
+		u = uint64(order.Uint32(b))
+	case 8:
+		u = order.Uint64(b)
+	}
+	switch info.kind {
+	case kindInt:
+		shift := 64 - 8*info.size
+		return strconv.FormatInt(int64(u<<shift)>>shift, 10)
+	case kindFloat:
+		switch info.size {
+		case 2:
+			return strconv.FormatFloat(float64(convert.Float16frombits(uint16(u))), 'g', -1, 32)
+		case 4:
+			return strconv.FormatFloat(float64(math.Float32frombits(uint32(u))), 'g', -1, 32)
+		}
+		return strconv.FormatFloat(math.Float64frombits(u), 'g', -1, 64)
+	case kindBool:
+		return strconv.FormatBool(u != 0)
+	}
+	return strconv.FormatUint(u, 10)
+}
+
+// Size returns the bytes the layout covers: the end of its last-ending
+// field.
+func (l *Layout) Size() int {
+	end, pos := 0, 0
+	for _, f := range l.Fields {
+		if f.Offset != nil {
+			pos = *f.Offset
+		}
+		t, _ := canonicalType(f.Type)
+		if size := types[t].size; size != 0 {
+			pos += size * max(f.Count, 1)
+		} else {
+			pos += f.Size
+		}
+		end = max(end, pos)
+	}
+	return end
+}
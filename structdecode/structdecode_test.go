@@ -0,0 +1,140 @@
+package structdecode
+
+import (
+	"errors"
+	"testing"
+)
+
+const testLayout = `
+struct header     # a packet header
+endian le
+magic    u32 be
+version  uint8_t
+flags    u16
+temp     float32
+name     char[6]
+_        pad[1]
+samples  i16[2] be
+@0x0c    raw bytes[2]
+`
+
+func TestParseDSL(t *testing.T) {
+	l, err := Parse(testLayout)
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if l.Name != "header" || l.Endian != "le" || len(l.Fields) != 8 {
+		t.Fatalf("Parse() = %+v", l)
+	}
+	if f := l.Fields[4]; f.Type != "char" || f.Size != 6 || f.Count != 0 {
+		t.Errorf("name = %+v, want char of size 6", f)
+	}
+	if f := l.Fields[6]; f.Type != "i16" || f.Count != 2 || f.Endian != "be" {
+		t.Errorf("samples = %+v, want 2 × i16 be", f)
+	}
+	if f := l.Fields[7]; f.Offset == nil || *f.Offset != 12 {
+		t.Errorf("raw offset = %v, want 12", f.Offset)
+	}
+	if got := l.Size(); got != 22 {
+		t.Errorf("Size() = %d, want 22", got)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	l, err := Parse(testLayout)
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	data := []byte{
+		0xca, 0xfe, 0xba, 0xbe, // magic
+		0x02,       // version
+		0x01, 0x80, // flags
+		0x00, 0x00, 0xc0, 0x3f, // temp 1.5
+		'h', 'e', 'l', 'l', 'o', 0, // name
+		0xff,                   // pad
+		0xff, 0xfe, 0x00, 0x07, // samples
+		0xaa, 0xbb, // trailing bytes
+	}
+	values, err := Decode(data, l)
+	if err != nil {
+		t.Fatalf("Decode() error: %v", err)
+	}
+	want := []struct {
+		name, value, endian string
+		offset              int
+	}{
+		{"magic", "3405691582", "be", 0},
+		{"version", "2", "", 4},
+		{"flags", "32769", "le", 5},
+		{"temp", "1.5", "le", 7},
+		{"name", "hello", "", 11},
+		{"samples", "[-2, 7]", "be", 18},
+		{"raw", "656c", "", 12},
+	}
+	if len(values) != len(want) {
+		t.Fatalf("Decode() returned %d values, want %d", len(values), len(want))
+	}
+	for i, w := range want {
+		v := values[i]
+		if v.Field.Name != w.name || v.Value != w.value || v.Endian != w.endian || v.Offset != w.offset {
+			t.Errorf("value %d = %s %s %s @%d, want %s %s %s @%d",
+				i, v.Field.Name, v.Value, v.Endian, v.Offset, w.name, w.value, w.endian, w.offset)
+		}
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	l, err := Parse(`{"name": "reg", "endian": "be", "fields": [
+		{"name": "id", "type": "uint16"},
+		{"name": "value", "type": "double", "offset": 4},
+		{"name": "ok", "type": "bool", "endian": "le"}
+	]}`)
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	values, err := Decode([]byte{0x01, 0x02, 0, 0, 0x3f, 0xf0, 0, 0, 0, 0, 0, 0, 0x01}, l)
+	if err != nil {
+		t.Fatalf("Decode() error: %v", err)
+	}
+	got := []string{values[0].Value, values[1].Value, values[2].Value}
+	if got[0] != "258" || got[1] != "1" || got[2] != "true" {
+		t.Errorf("Decode() = %v, want [258 1 true]", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout string
+	}{
+		{"empty", "# nothing\n"},
+		{"unknown type", "x u24"},
+		{"char without size", "s char"},
+		{"size of a number", `{"fields": [{"name": "x", "type": "u8", "size": 2}]}`},
+		{"bad endian", "x u16 middle"},
+		{"bad layout endian", "endian pdp\nx u16"},
+		{"bad offset", "@zz x u8"},
+		{"bad length", "x u8[0]"},
+		{"too many tokens", "x u8 le extra"},
+		{"missing name", `{"fields": [{"type": "u8"}]}`},
+		{"invalid JSON", `{"fields": [}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.layout); !errors.Is(err, ErrInvalidLayout) {
+				t.Errorf("Parse(%q) error = %v, want ErrInvalidLayout", tt.layout, err)
+			}
+		})
+	}
+}
+
+func TestDecodeShortData(t *testing.T) {
+	l, _ := Parse("a u16\nb u32")
+	values, err := Decode([]byte{1, 0, 2}, l)
+	if !errors.Is(err, ErrShortData) {
+		t.Fatalf("Decode() error = %v, want ErrShortData", err)
+	}
+	if len(values) != 1 || values[0].Value != "1" {
+		t.Errorf("Decode() = %+v, want the first field", values)
+	}
+}
@@ -0,0 +1,621 @@
+// Package svd reads CMSIS-SVD device descriptions and decodes register
+// values into their named bitfields.
+//
+// Peripherals derived from another one inherit its registers, register
+// size is inherited from the peripheral and device, and dim arrays of
+// registers, clusters and fields are expanded (e.g. "CCR%s" with dim 4
+// becomes CCR0 to CCR3). Registers in clusters are named "CLUSTER.REGISTER".
+//
+// Example usage:
+//
+//	dev, _ := svd.Parse(file)
+//	reg, _ := dev.Register("GPIOA", "MODER")
+//	for _, f := range reg.Decode(0xa8000000) {
+//		fmt.Println(f.Field.Name, f.Value, f.Enum)
+//	}
+package svd
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidSVD indicates a file that is not a valid SVD description
+	ErrInvalidSVD = errors.New("invalid SVD")
+
+	// ErrNotFound indicates an unknown peripheral or register
+	ErrNotFound = errors.New("not found")
+)
+
+// Device is a parsed SVD device.
+type Device struct {
+	Name        string
+	Description string
+	Peripherals []Peripheral
+}
+
+// Peripheral is a peripheral with its registers, sorted as in the file.
+type Peripheral struct {
+	Name        string
+	Description string
+	BaseAddress uint64
+	Registers   []Register
+}
+
+// Register is a register of a peripheral.
+type Register struct {
+	Name        string
+	Description string
+	Offset      uint64 // address offset from the peripheral base
+	Address     uint64 // absolute address
+	Size        int    // bits
+	Access      string
+	ResetValue  uint64
+	Fields      []Field
+}
+
+// Field is a bitfield of a register.
+type Field struct {
+	Name        string
+	Description string
+	Offset      int // least significant bit
+	Width       int
+	Access      string
+	Values      []EnumValue
+}
+
+// EnumValue names a value of a field. Bits cleared in Mask are "don't care".
+type EnumValue struct {
+	Name        string
+	Description string
+	Value       uint64
+	Mask        uint64
+	Default     bool // names all values without an entry of their own
+}
+
+// FieldValue is a field of a decoded register value.
+type FieldValue struct {
+	Field *Field
+	Value uint64
+	Enum  string // name of the value, "" if it has none
+}
+
+// Bits formats the bit range of the field, e.g. "[7:4]" or "[3]".
+func (f Field) Bits() string {
+	if f.Width == 1 {
+		return fmt.Sprintf("[%d]", f.Offset)
+	}
+	return fmt.Sprintf("[%d:%d]", f.Offset+f.Width-1, f.Offset)
+}
+
+// Mask returns the bits of the field within the register.
+func (f Field) Mask() uint64 {
+	return widthMask(f.Width) << f.Offset
+}
+
+// valueName looks up the enumerated value name of v.
+func (f Field) valueName(v uint64) string {
+	def := ""
+	for _, e := range f.Values {
+		if e.Default {
+			def = e.Name
+			continue
+		}
+		if v&e.Mask == e.Value&e.Mask {
+			return e.Name
+		}
+	}
+	return def
+}
+
+// Decode splits a register value into its fields.
+func (r *Register) Decode(value uint64) []FieldValue {
+	out := make([]FieldValue, len(r.Fields))
+	for i := range r.Fields {
+		f := &r.Fields[i]
+		v := value >> f.Offset & widthMask(f.Width)
+		out[i] = FieldValue{Field: f, Value: v, Enum: f.valueName(v)}
+	}
+	return out
+}
+
+// Unassigned returns the bits of value set outside of all fields.
+func (r *Register) Unassigned(value uint64) uint64 {
+	var mask uint64
+	for _, f := range r.Fields {
+		mask |= f.Mask()
+	}
+	return value &^ mask & widthMask(r.Size)
+}
+
+// Peripheral finds a peripheral by name, ignoring case.
+func (d *Device) Peripheral(name string) (*Peripheral, error) {
+	for i := range d.Peripherals {
+		if strings.EqualFold(d.Peripherals[i].Name, name) {
+			return &d.Peripherals[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: peripheral %q", ErrNotFound, name)
+}
+
+// Register finds a register by peripheral and register name, ignoring case.
+func (d *Device) Register(peripheral, register string) (*Register, error) {
+	p, err := d.Peripheral(peripheral)
+	if err != nil {
+		return nil, err
+	}
+	for i := range p.Registers {
+		if strings.EqualFold(p.Registers[i].Name, register) {
+			return &p.Registers[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: register %q in %s", ErrNotFound, register, p.Name)
+}
+
+// widthMask returns a mask with the low width bits set.
+func widthMask(width int) uint64 {
+	if width >= 64 {
+		return ^uint64(0)
+	}
+	return uint64(1)<<width - 1
+}
+
+// XML elements of the SVD schema. Numbers are kept as strings, as SVD
+// writes them in decimal, hex (0x) or binary (#) notation.
+type (
+	xmlDevice struct {
+		Name        string          `xml:"name"`
+		Description string          `xml:"description"`
+		Size        string          `xml:"size"`
+		Access      string          `xml:"access"`
+		ResetValue  string          `xml:"resetValue"`
+		Peripherals []xmlPeripheral `xml:"peripherals>peripheral"`
+	}
+	xmlPeripheral struct {
+		DerivedFrom string `xml:"derivedFrom,attr"`
+		xmlDim
+		Name        string        `xml:"name"`
+		Description string        `xml:"description"`
+		BaseAddress string        `xml:"baseAddress"`
+		Size        string        `xml:"size"`
+		Access      string        `xml:"access"`
+		ResetValue  string        `xml:"resetValue"`
+		Registers   []xmlRegister `xml:"registers>register"`
+		Clusters    []xmlCluster  `xml:"registers>cluster"`
+	}
+	xmlCluster struct {
+		xmlDim
+		Name          string        `xml:"name"`
+		AddressOffset string        `xml:"addressOffset"`
+		Registers     []xmlRegister `xml:"register"`
+		Clusters      []xmlCluster  `xml:"cluster"`
+	}
+	xmlRegister struct {
+		xmlDim
+		Name          string     `xml:"name"`
+		Description   string     `xml:"description"`
+		AddressOffset string     `xml:"addressOffset"`
+		Size          string     `xml:"size"`
+		Access        string     `xml:"access"`
+		ResetValue    string     `xml:"resetValue"`
+		Fields        []xmlField `xml:"fields>field"`
+	}
+	xmlField struct {
+		xmlDim
+		Name             string          `xml:"name"`
+		Description      string          `xml:"description"`
+		BitOffset        string          `xml:"bitOffset"`
+		BitWidth         string          `xml:"bitWidth"`
+		LSB              string          `xml:"lsb"`
+		MSB              string          `xml:"msb"`
+		BitRange         string          `xml:"bitRange"`
+		Access           string          `xml:"access"`
+		EnumeratedValues []xmlEnumValues `xml:"enumeratedValues"`
+	}
+	xmlEnumValues struct {
+		Usage  string         `xml:"usage"`
+		Values []xmlEnumValue `xml:"enumeratedValue"`
+	}
+	xmlEnumValue struct {
+		Name        string `xml:"name"`
+		Description string `xml:"description"`
+		Value       string `xml:"value"`
+		IsDefault   string `xml:"isDefault"`
+	}
+	xmlDim struct {
+		Dim          string `xml:"dim"`
+		DimIncrement string `xml:"dimIncrement"`
+		DimIndex     string `xml:"dimIndex"`
+	}
+)
+
+// defaults are the register properties inherited from device and peripheral.
+type defaults struct {
+	size       int
+	access     string
+	resetValue uint64
+}
+
+// Parse reads an SVD file.
+func Parse(r io.Reader) (*Device, error) {
+	var x xmlDevice
+	if err := xml.NewDecoder(r).Decode(&x); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSVD, err)
+	}
+	if len(x.Peripherals) == 0 {
+		return nil, fmt.Errorf("%w: no peripherals", ErrInvalidSVD)
+	}
+
+	dev := &Device{Name: x.Name, Description: clean(x.Description)}
+	def, err := inherit(defaults{size: 32}, x.Size, x.Access, x.ResetValue)
+	if err != nil {
+		return nil, fmt.Errorf("%w: device: %w", ErrInvalidSVD, err)
+	}
+
+	byName := make(map[string]*xmlPeripheral)
+	for i := range x.Peripherals {
+		byName[x.Peripherals[i].Name] = &x.Peripherals[i]
+	}
+	for _, xp := range x.Peripherals {
+		if xp.DerivedFrom != "" {
+			base, ok := byName[xp.DerivedFrom]
+			if !ok {
+				return nil, fmt.Errorf("%w: peripheral %s derived from unknown %s", ErrInvalidSVD, xp.Name, xp.DerivedFrom)
+			}
+			if len(xp.Registers) == 0 && len(xp.Clusters) == 0 {
+				xp.Registers, xp.Clusters = base.Registers, base.Clusters
+			}
+			if xp.Description == "" {
+				xp.Description = base.Description
+			}
+		}
+		ps, err := parsePeripheral(xp, def)
+		if err != nil {
+			return nil, fmt.Errorf("%w: peripheral %s: %w", ErrInvalidSVD, xp.Name, err)
+		}
+		dev.Peripherals = append(dev.Peripherals, ps...)
+	}
+	return dev, nil
+}
+
+// parsePeripheral converts a peripheral, which may be a dim array.
+func parsePeripheral(xp xmlPeripheral, def defaults) ([]Peripheral, error) {
+	base, err := parseNumber(xp.BaseAddress)
+	if err != nil {
+		return nil, fmt.Errorf("base address: %w", err)
+	}
+	def, err = inherit(def, xp.Size, xp.Access, xp.ResetValue)
+	if err != nil {
+		return nil, err
+	}
+	var regs []Register
+	for _, xr := range xp.Registers {
+		rs, err := parseRegister(xr, "", 0, def)
+		if err != nil {
+			return nil, err
+		}
+		regs = append(regs, rs...)
+	}
+	for _, xc := range xp.Clusters {
+		rs, err := parseCluster(xc, "", 0, def)
+		if err != nil {
+			return nil, err
+		}
+		regs = append(regs, rs...)
+	}
+
+	names, offsets, err := expandDim(xp.Name, xp.xmlDim)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Peripheral, len(names))
+	for i, name := range names {
+		p := Peripheral{Name: name, Description: clean(xp.Description), BaseAddress: base + offsets[i]}
+		p.Registers = make([]Register, len(regs))
+		for j, r := range regs {
+			r.Address = p.BaseAddress + r.Offset
+			p.Registers[j] = r
+		}
+		out[i] = p
+	}
+	return out, nil
+}
+
+// parseCluster converts the registers of a cluster, prefixing their names.
+func parseCluster(xc xmlCluster, prefix string, offset uint64, def defaults) ([]Register, error) {
+	clusterOffset, err := parseNumber(xc.AddressOffset)
+	if err != nil {
+		return nil, fmt.Errorf("cluster %s offset: %w", xc.Name, err)
+	}
+	names, offsets, err := expandDim(xc.Name, xc.xmlDim)
+	if err != nil {
+		return nil, err
+	}
+	var out []Register
+	for i, name := range names {
+		p := prefix + name + "."
+		o := offset + clusterOffset + offsets[i]
+		for _, xr := range xc.Registers {
+			rs, err := parseRegister(xr, p, o, def)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, rs...)
+		}
+		for _, nested := range xc.Clusters {
+			rs, err := parseCluster(nested, p, o, def)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, rs...)
+		}
+	}
+	return out, nil
+}
+
+// parseRegister converts a register, which may be a dim array.
+func parseRegister(xr xmlRegister, prefix string, offset uint64, def defaults) ([]Register, error) {
+	regOffset, err := parseNumber(xr.AddressOffset)
+	if err != nil {
+		return nil, fmt.Errorf("register %s offset: %w", xr.Name, err)
+	}
+	def, err = inherit(def, xr.Size, xr.Access, xr.ResetValue)
+	if err != nil {
+		return nil, fmt.Errorf("register %s: %w", xr.Name, err)
+	}
+	var fields []Field
+	for _, xf := range xr.Fields {
+		fs, err := parseField(xf, def.access)
+		if err != nil {
+			return nil, fmt.Errorf("register %s: %w", xr.Name, err)
+		}
+		fields = append(fields, fs...)
+	}
+
+	names, offsets, err := expandDim(xr.Name, xr.xmlDim)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Register, len(names))
+	for i, name := range names {
+		out[i] = Register{
+			Name:        prefix + name,
+			Description: clean(xr.Description),
+			Offset:      offset + regOffset + offsets[i],
+			Size:        def.size,
+			Access:      def.access,
+			ResetValue:  def.resetValue,
+			Fields:      fields,
+		}
+	}
+	return out, nil
+}
+
+// parseField converts a field, which may be a dim array.
+func parseField(xf xmlField, access string) ([]Field, error) {
+	var lsb, msb uint64
+	var err error
+	switch {
+	case xf.BitRange != "":
+		r := strings.Trim(strings.TrimSpace(xf.BitRange), "[]")
+		m, l, ok := strings.Cut(r, ":")
+		if !ok {
+			return nil, fmt.Errorf("field %s: bit range %q", xf.Name, xf.BitRange)
+		}
+		if msb, err = parseNumber(m); err == nil {
+			lsb, err = parseNumber(l)
+		}
+	case xf.LSB != "" || xf.MSB != "":
+		if lsb, err = parseNumber(xf.LSB); err == nil {
+			msb, err = parseNumber(xf.MSB)
+		}
+	default:
+		var width uint64
+		if lsb, err = parseNumber(xf.BitOffset); err == nil {
+			width, err = parseNumber(xf.BitWidth)
+			msb = lsb + width - 1
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("field %s: %w", xf.Name, err)
+	}
+	if msb < lsb || msb > 63 {
+		return nil, fmt.Errorf("field %s: bits %d to %d", xf.Name, lsb, msb)
+	}
+	if xf.Access != "" {
+		access = xf.Access
+	}
+
+	var values []EnumValue
+	for _, ev := range xf.EnumeratedValues {
+		if ev.Usage == "write" && len(xf.EnumeratedValues) > 1 {
+			continue // decoding reads the register
+		}
+		for _, v := range ev.Values {
+			e := EnumValue{Name: v.Name, Description: clean(v.Description), Default: v.IsDefault == "true" || v.IsDefault == "1"}
+			if !e.Default {
+				if e.Value, e.Mask, err = parseEnumValue(v.Value); err != nil {
+					return nil, fmt.Errorf("field %s value %s: %w", xf.Name, v.Name, err)
+				}
+			}
+			values = append(values, e)
+		}
+	}
+
+	names, offsets, err := expandDim(xf.Name, xf.xmlDim)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Field, len(names))
+	for i, name := range names {
+		out[i] = Field{
+			Name:        name,
+			Description: clean(xf.Description),
+			Offset:      int(lsb + offsets[i]),
+			Width:       int(msb - lsb + 1),
+			Access:      access,
+			Values:      values,
+		}
+	}
+	return out, nil
+}
+
+// inherit overrides defaults with the properties set on an element.
+func inherit(def defaults, size, access, resetValue string) (defaults, error) {
+	if size != "" {
+		v, err := parseNumber(size)
+		if err != nil || v == 0 || v > 64 {
+			return def, fmt.Errorf("size %q", size)
+		}
+		def.size = int(v)
+	}
+	if access != "" {
+		def.access = access
+	}
+	if resetValue != "" {
+		v, err := parseNumber(resetValue)
+		if err != nil {
+			return def, fmt.Errorf("reset value: %w", err)
+		}
+		def.resetValue = v
+	}
+	return def, nil
+}
+
+// expandDim returns the names and address offsets of a dim array, or name
+// and 0 for a single element. "%s" in the name is replaced by the index;
+// arrays written as "NAME[%s]" keep the brackets.
+func expandDim(name string, d xmlDim) ([]string, []uint64, error) {
+	if d.Dim == "" {
+		return []string{name}, []uint64{0}, nil
+	}
+	n, err := parseNumber(d.Dim)
+	if err != nil || n == 0 || n > 1<<16 {
+		return nil, nil, fmt.Errorf("%s: dim %q", name, d.Dim)
+	}
+	inc, err := parseNumber(d.DimIncrement)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: dim increment: %w", name, err)
+	}
+	indices, err := dimIndices(d.DimIndex, int(n))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", name, err)
+	}
+	names := make([]string, n)
+	offsets := make([]uint64, n)
+	for i := range names {
+		names[i] = strings.ReplaceAll(name, "%s", indices[i])
+		offsets[i] = uint64(i) * inc
+	}
+	return names, offsets, nil
+}
+
+// dimIndices expands a dimIndex, "0-3" or "A,B,C", to n indices. An empty
+// dimIndex counts from 0.
+func dimIndices(s string, n int) ([]string, error) {
+	s = strings.TrimSpace(s)
+	var out []string
+	switch {
+	case s == "":
+		for i := range n {
+			out = append(out, strconv.Itoa(i))
+		}
+	case strings.Contains(s, ","):
+		for _, part := range strings.Split(s, ",") {
+			out = append(out, strings.TrimSpace(part))
+		}
+	default:
+		from, to, ok := strings.Cut(s, "-")
+		first, err1 := strconv.Atoi(from)
+		last, err2 := strconv.Atoi(to)
+		if !ok || err1 != nil || err2 != nil {
+			return nil, fmt.Errorf("dim index %q", s)
+		}
+		for i := first; i <= last; i++ {
+			out = append(out, strconv.Itoa(i))
+		}
+	}
+	if len(out) != n {
+		return nil, fmt.Errorf("dim index %q does not have %d entries", s, n)
+	}
+	return out, nil
+}
+
+// parseNumber parses an SVD scaledNonNegativeInteger: decimal, hex with
+// 0x or binary with # prefix, optionally followed by k, M, G or T.
+func parseNumber(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New("missing number")
+	}
+	var scale uint
+	switch s[len(s)-1] {
+	case 'k', 'K':
+		scale = 10
+	case 'm', 'M':
+		scale = 20
+	case 'g', 'G':
+		scale = 30
+	case 't', 'T':
+		scale = 40
+	}
+	if scale > 0 {
+		s = s[:len(s)-1]
+	}
+	var v uint64
+	var err error
+	switch {
+	case strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X"):
+		v, err = strconv.ParseUint(s[2:], 16, 64)
+	case strings.HasPrefix(s, "#"):
+		v, err = strconv.ParseUint(s[1:], 2, 64)
+	default:
+		v, err = strconv.ParseUint(s, 10, 64)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	if scale > 0 {
+		if bits.Len64(v)+int(scale) > 64 {
+			return 0, fmt.Errorf("number %q out of range", s)
+		}
+		v <<= scale
+	}
+	return v, nil
+}
+
+// parseEnumValue parses an enumerated value. Binary values may contain x
+// for bits that do not matter, e.g. "#1x0"; they are cleared in mask.
+func parseEnumValue(s string) (value, mask uint64, err error) {
+	s = strings.TrimSpace(s)
+	if bin, ok := strings.CutPrefix(s, "#"); ok && strings.ContainsAny(bin, "xX") {
+		for _, c := range bin {
+			value <<= 1
+			mask <<= 1
+			switch c {
+			case '0':
+				mask |= 1
+			case '1':
+				value |= 1
+				mask |= 1
+			case 'x', 'X':
+			default:
+				return 0, 0, fmt.Errorf("invalid value %q", s)
+			}
+		}
+		return value, mask, nil
+	}
+	value, err = parseNumber(s)
+	return value, ^uint64(0), err
+}
+
+// clean collapses the whitespace of multi-line descriptions.
+func clean(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
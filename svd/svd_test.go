@@ -0,0 +1,176 @@
+package svd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testSVD = `<?xml version="1.0" encoding="utf-8"?>
+<device schemaVersion="1.3">
+  <name>TESTMCU</name>
+  <description>Test
+    device</description>
+  <size>32</size>
+  <peripherals>
+    <peripheral>
+      <name>GPIOA</name>
+      <baseAddress>0x40020000</baseAddress>
+      <registers>
+        <register>
+          <name>MODER</name>
+          <description>mode register</description>
+          <addressOffset>0x00</addressOffset>
+          <resetValue>0xA8000000</resetValue>
+          <fields>
+            <field>
+              <name>MODER%s</name>
+              <dim>16</dim>
+              <dimIncrement>2</dimIncrement>
+              <bitOffset>0</bitOffset>
+              <bitWidth>2</bitWidth>
+              <enumeratedValues>
+                <enumeratedValue><name>Input</name><value>0</value></enumeratedValue>
+                <enumeratedValue><name>Output</name><value>1</value></enumeratedValue>
+                <enumeratedValue><name>Alternate</name><value>2</value></enumeratedValue>
+                <enumeratedValue><name>Analog</name><value>3</value></enumeratedValue>
+              </enumeratedValues>
+            </field>
+          </fields>
+        </register>
+        <register>
+          <name>CR</name>
+          <addressOffset>0x04</addressOffset>
+          <size>16</size>
+          <fields>
+            <field><name>EN</name><bitRange>[0:0]</bitRange></field>
+            <field>
+              <name>PRESC</name><lsb>4</lsb><msb>6</msb>
+              <enumeratedValues>
+                <enumeratedValue><name>Div1</name><value>#000</value></enumeratedValue>
+                <enumeratedValue><name>Div2</name><value>#1x1</value></enumeratedValue>
+                <enumeratedValue><name>Other</name><isDefault>true</isDefault></enumeratedValue>
+              </enumeratedValues>
+            </field>
+          </fields>
+        </register>
+        <cluster>
+          <name>CH%s</name>
+          <dim>2</dim>
+          <dimIncrement>0x10</dimIncrement>
+          <addressOffset>0x20</addressOffset>
+          <register><name>CFG</name><addressOffset>0x4</addressOffset></register>
+        </cluster>
+      </registers>
+    </peripheral>
+    <peripheral derivedFrom="GPIOA">
+      <name>GPIOB</name>
+      <baseAddress>0x40020400</baseAddress>
+    </peripheral>
+  </peripherals>
+</device>`
+
+func TestParse(t *testing.T) {
+	dev, err := Parse(strings.NewReader(testSVD))
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if dev.Name != "TESTMCU" || dev.Description != "Test device" || len(dev.Peripherals) != 2 {
+		t.Fatalf("Parse() = %+v", dev)
+	}
+
+	moder, err := dev.Register("gpiob", "moder")
+	if err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+	if moder.Address != 0x40020400 || moder.Size != 32 || moder.ResetValue != 0xA8000000 || len(moder.Fields) != 16 {
+		t.Errorf("GPIOB.MODER = %+v", moder)
+	}
+	if f := moder.Fields[15]; f.Name != "MODER15" || f.Bits() != "[31:30]" {
+		t.Errorf("MODER15 = %s %s", f.Name, f.Bits())
+	}
+
+	cfg, err := dev.Register("GPIOA", "CH1.CFG")
+	if err != nil {
+		t.Fatalf("Register(CH1.CFG) error: %v", err)
+	}
+	if cfg.Address != 0x40020034 {
+		t.Errorf("CH1.CFG address = 0x%x, want 0x40020034", cfg.Address)
+	}
+
+	if _, err := dev.Register("GPIOA", "ODR"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Register(ODR) error = %v, want ErrNotFound", err)
+	}
+	if _, err := dev.Peripheral("USART1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Peripheral(USART1) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRegister_Decode(t *testing.T) {
+	dev, err := Parse(strings.NewReader(testSVD))
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	moder, _ := dev.Register("GPIOA", "MODER")
+	fields := moder.Decode(0xA8000001)
+	if fields[0].Value != 1 || fields[0].Enum != "Output" || fields[15].Value != 2 || fields[15].Enum != "Alternate" {
+		t.Errorf("Decode() = %+v ... %+v", fields[0], fields[15])
+	}
+
+	cr, _ := dev.Register("GPIOA", "CR")
+	tests := []struct {
+		value uint64
+		presc string
+	}{
+		{0x01, "Div1"},
+		{0x51, "Div2"},
+		{0x71, "Div2"},
+		{0x21, "Other"},
+	}
+	for _, tt := range tests {
+		fields := cr.Decode(tt.value)
+		if fields[0].Value != 1 || fields[1].Enum != tt.presc {
+			t.Errorf("Decode(0x%x) = %+v, want PRESC %s", tt.value, fields, tt.presc)
+		}
+	}
+	if got := cr.Unassigned(0x8f01); got != 0x8f00 {
+		t.Errorf("Unassigned() = 0x%x, want 0x8f00", got)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		svd  string
+	}{
+		{"not xml", "hello"},
+		{"no peripherals", "<device><name>X</name></device>"},
+		{"bad base", "<device><peripherals><peripheral><name>P</name><baseAddress>zz</baseAddress></peripheral></peripherals></device>"},
+		{"unknown derived", `<device><peripherals><peripheral derivedFrom="Q"><name>P</name><baseAddress>0</baseAddress></peripheral></peripherals></device>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(tt.svd)); !errors.Is(err, ErrInvalidSVD) {
+				t.Errorf("Parse() error = %v, want ErrInvalidSVD", err)
+			}
+		})
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		in   string
+		want uint64
+	}{
+		{"42", 42},
+		{"0x2A", 42},
+		{"#101010", 42},
+		{"4k", 4096},
+		{"0x1K", 0x400},
+	}
+	for _, tt := range tests {
+		if got, err := parseNumber(tt.in); err != nil || got != tt.want {
+			t.Errorf("parseNumber(%q) = %d, %v, want %d", tt.in, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package textdump
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Packet is one array of a C array export, e.g. a frame or the data
+// Wireshark reassembled from several frames.
+type Packet struct {
+	Name   string // variable name, e.g. "pkt1"
+	Title  string // comment above the array, e.g. "Frame (74 bytes)"
+	Offset uint64 // of the first byte in Data
+	Length uint64
+}
+
+var (
+	// cArrayDecl matches the declaration of a byte array up to its opening
+	// brace, e.g. "pkt1[74] = {".
+	cArrayDecl = regexp.MustCompile(`(\w+)\s*\[\s*(\d*)\s*\]\s*=\s*\{`)
+
+	// cArrayTitle matches the comment line Wireshark writes above an array.
+	cArrayTitle = regexp.MustCompile(`^/\*\s*(.*?)\s*\*/$`)
+)
+
+// isCArray reports whether text declares a byte array or is a bare brace
+// list.
+func isCArray(text string) bool {
+	return strings.HasPrefix(text, "{") || cArrayDecl.MatchString(text)
+}
+
+// parseCArrays reads the arrays of a C array export, as Wireshark writes
+// them with File > Export Packet Dissections > As C Arrays:
+//
+//	/* Frame (74 bytes) */
+//	static const unsigned char pkt1[74] = {
+//	0x00, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e, 0x00, 0x50, /* .+<M^.P */
+//	...
+//	};
+//
+// The bytes of all arrays are concatenated in Data; Packets tells them
+// apart. Elements may be hex, octal or decimal; comments are skipped.
+func parseCArrays(text string) (*Dump, error) {
+	dump := &Dump{Dialect: DialectCArray}
+	rest := text
+	if strings.HasPrefix(rest, "{") {
+		data, lines, _, err := arrayBytes(rest[1:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidDump, err)
+		}
+		dump.add(Packet{}, data, lines)
+		return dump, nil
+	}
+
+	for {
+		m := cArrayDecl.FindStringSubmatchIndex(rest)
+		if m == nil {
+			break
+		}
+		p := Packet{Name: rest[m[2]:m[3]]}
+		before := strings.TrimRight(rest[:strings.LastIndexByte(rest[:m[0]], '\n')+1], " \t\n")
+		if t := cArrayTitle.FindStringSubmatch(strings.TrimSpace(before[strings.LastIndexByte(before, '\n')+1:])); t != nil {
+			p.Title = t[1]
+		}
+
+		data, lines, n, err := arrayBytes(rest[m[1]:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidDump, p.Name, err)
+		}
+		if declared := rest[m[4]:m[5]]; declared != "" && declared != strconv.Itoa(len(data)) {
+			return nil, fmt.Errorf("%w: %s has %d bytes, declared as %s", ErrInvalidDump, p.Name, len(data), declared)
+		}
+		if uint64(len(dump.Data)+len(data)) > MaxSize {
+			return nil, fmt.Errorf("%w: more than %d MiB of arrays", ErrInvalidDump, MaxSize>>20)
+		}
+		dump.add(p, data, lines)
+		rest = rest[m[1]+n:]
+	}
+	if len(dump.Packets) == 0 {
+		return nil, fmt.Errorf("%w: no C array found", ErrInvalidDump)
+	}
+	return dump, nil
+}
+
+// add appends the bytes of an array.
+func (d *Dump) add(p Packet, data []byte, lines int) {
+	p.Offset, p.Length = uint64(len(d.Data)), uint64(len(data))
+	d.Packets = append(d.Packets, p)
+	d.Data = append(d.Data, data...)
+	d.Lines += lines
+}
+
+// arrayBytes reads the elements of an array literal up to its closing
+// brace. It returns the bytes, the number of lines holding them and the
+// length of s read.
+func arrayBytes(s string) (data []byte, lines, n int, err error) {
+	lastLine := -1
+	line := 0
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == '}':
+			return data, lines, i + 1, nil
+		case c == '\n':
+			line++
+			i++
+		case c == ',' || c == ' ' || c == '\t':
+			i++
+		case strings.HasPrefix(s[i:], "/*"):
+			end := strings.Index(s[i+2:], "*/")
+			if end < 0 {
+				return nil, 0, 0, errors.New("unterminated comment")
+			}
+			line += strings.Count(s[i:i+end+4], "\n")
+			i += end + 4
+		case strings.HasPrefix(s[i:], "//"):
+			end := strings.IndexByte(s[i:], '\n')
+			if end < 0 {
+				end = len(s) - i
+			}
+			i += end
+		default:
+			j := i
+			for j < len(s) && isAlnum(s[j]) {
+				j++
+			}
+			tok := s[i:j]
+			v, err := strconv.ParseUint(tok, 0, 8)
+			if err != nil {
+				return nil, 0, 0, fmt.Errorf("%q is not a byte", s[i:max(j, i+1)])
+			}
+			data = append(data, byte(v))
+			if line != lastLine {
+				lines++
+				lastLine = line
+			}
+			i = j
+		}
+	}
+	return nil, 0, 0, errors.New("missing closing brace")
+}
+
+// isAlnum reports whether c is an ASCII letter or digit.
+func isAlnum(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// parseEscaped reads one or more C string literals, as Wireshark copies
+// bytes with Copy > as Escaped String ("\x00\x1a\x2b"). Literals may be
+// separated by whitespace and line continuations. Besides \x escapes, the
+// octal and single-character escapes of C are read; other characters stand
+// for their own bytes.
+func parseEscaped(text string) (*Dump, error) {
+	dump := &Dump{Dialect: DialectEscaped}
+	for i := 0; i < len(text); {
+		switch c := text[i]; c {
+		case ' ', '\t', '\r', '\n', '\\':
+			i++
+		case '"':
+			data, n, err := stringBytes(text[i+1:])
+			if err != nil {
+				return nil, fmt.Errorf("%w: string %d: %w", ErrInvalidDump, dump.Lines+1, err)
+			}
+			dump.Data = append(dump.Data, data...)
+			dump.Lines++
+			i += 1 + n
+		default:
+			return nil, fmt.Errorf("%w: unexpected %q between strings", ErrInvalidDump, c)
+		}
+	}
+	if len(dump.Data) > MaxSize {
+		return nil, fmt.Errorf("%w: more than %d MiB of strings", ErrInvalidDump, MaxSize>>20)
+	}
+	return dump, nil
+}
+
+// simpleEscapes are the single-character escapes of C.
+var simpleEscapes = map[byte]byte{
+	'a': '\a', 'b': '\b', 'f': '\f', 'n': '\n', 'r': '\r', 't': '\t', 'v': '\v',
+	'\\': '\\', '"': '"', '\'': '\'', '?': '?',
+}
+
+// stringBytes reads the body of a string literal up to its closing quote.
+// It returns the bytes and the length of s read.
+func stringBytes(s string) ([]byte, int, error) {
+	var data []byte
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '"':
+			return data, i + 1, nil
+		case c == '\n':
+			return nil, 0, errors.New("missing closing quote")
+		case c != '\\':
+			data = append(data, c)
+			i++
+		case i+1 == len(s):
+			return nil, 0, errors.New("escape at the end")
+		case s[i+1] == '\n':
+			i += 2 // line continuation
+		case s[i+1] == 'x':
+			j := i + 2
+			for j < len(s) && j < i+4 && strings.IndexByte("0123456789abcdefABCDEF", s[j]) >= 0 {
+				j++
+			}
+			if j == i+2 {
+				return nil, 0, errors.New(`\x without hex digits`)
+			}
+			v, _ := strconv.ParseUint(s[i+2:j], 16, 8)
+			data = append(data, byte(v))
+			i = j
+		case s[i+1] >= '0' && s[i+1] <= '7':
+			j := i + 1
+			for j < len(s) && j < i+4 && s[j] >= '0' && s[j] <= '7' {
+				j++
+			}
+			v, err := strconv.ParseUint(s[i+1:j], 8, 8)
+			if err != nil {
+				return nil, 0, fmt.Errorf(`\%s is not a byte`, s[i+1:j])
+			}
+			data = append(data, byte(v))
+			i = j
+		default:
+			b, ok := simpleEscapes[s[i+1]]
+			if !ok {
+				return nil, 0, fmt.Errorf(`unknown escape \%c`, s[i+1])
+			}
+			data = append(data, b)
+			i += 2
+		}
+	}
+	return nil, 0, errors.New("missing closing quote")
+}
@@ -0,0 +1,399 @@
+// Package textdump reads the text hex dumps of common tools back into
+// bytes: xxd, hexdump -C, od, Wireshark's "Copy as Hex Dump" and the db
+// command of the Windows debuggers (WinDbg, cdb). It also reads the other
+// text exports of Wireshark: C arrays (Export Packet Dissections > As C
+// Arrays) and escaped strings (Copy > as Escaped String). The hex stream
+// of Copy > as a Hex Stream is plain hex, which convert.ParseHex reads.
+//
+// The dialect is recognized from the first dump line; lines that are not
+// dump lines, such as debugger prompts or packet headings, are skipped.
+// The offset of each line is checked against the bytes before it: a line
+// that starts further on leaves a gap, a line that starts earlier is an
+// error. "*" lines, which hexdump, od and xxd -a print instead of repeated
+// lines, are expanded. Bytes the debugger could not read ("??") are gaps
+// too. Gaps are filled with zeros, so Data is contiguous from Base.
+//
+// Example usage:
+//
+//	d, _ := textdump.Parse("00000010: 4865 6c6c 6f0a  Hello.")
+//	fmt.Printf("%s at 0x%x: %q\n", d.Dialect, d.Base, d.Data) // xxd at 0x10: "Hello\n"
+package textdump
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"hexview/sparse"
+)
+
+// Dump dialects
+const (
+	DialectXXD       = "xxd"
+	DialectHexdump   = "hexdump"   // hexdump -C
+	DialectOD        = "od"        // od with -t x1, x2, x4, x8, o1, o2 or o4
+	DialectWireshark = "wireshark" // Copy as Hex Dump, also the print format
+	DialectWinDbg    = "windbg"    // db
+	DialectCArray    = "c-array"   // Wireshark's C arrays, or any C byte array literal
+	DialectEscaped   = "escaped"   // C string literals with \x escapes
+)
+
+// MaxSize limits the bytes a dump may span from its first to its last
+// offset, including gaps.
+const MaxSize = 64 << 20
+
+// ErrInvalidDump indicates text that is not a hex dump of a known dialect
+// or has offsets that do not fit its bytes
+var ErrInvalidDump = errors.New("invalid hex dump")
+
+// Dump is a parsed hex dump.
+type Dump struct {
+	Dialect string
+	Base    uint64   // offset or address of the first byte
+	Data    []byte   // from Base, with gaps filled with zeros
+	Gaps    []Gap    // sorted by offset
+	Lines   int      // dump lines read, including "*" lines
+	Packets []Packet // arrays of a C array export, in order
+}
+
+// Gap is a range of a dump without bytes.
+type Gap struct {
+	Offset uint64 // absolute, like Base
+	Length uint64
+}
+
+// dialect describes the lines of a dump dialect.
+type dialect struct {
+	name   string
+	line   *regexp.Regexp // a dump line of the dialect
+	ascii  byte           // character starting the ASCII column, 0 if it follows spaces
+	midGap bool           // two spaces may separate the 8th and 9th byte
+	dash   bool           // "-" may separate the 8th and 9th byte
+}
+
+// dialects in the order they are tried.
+var dialects = []dialect{
+	{name: DialectWinDbg, line: regexp.MustCompile("^[0-9a-fA-F]{8}(?:`?[0-9a-fA-F]{8})?  (?:[0-9a-fA-F?]{2}[ -])*[0-9a-fA-F?]{2}"), dash: true},
+	{name: DialectXXD, line: regexp.MustCompile(`^[0-9a-fA-F]+: [0-9a-fA-F]{2}`)},
+	{name: DialectHexdump, line: regexp.MustCompile(`^[0-9a-fA-F]{8,16}  [0-9a-fA-F]{2}( |$)`), ascii: '|', midGap: true},
+	{name: DialectWireshark, line: regexp.MustCompile(`^[0-9a-fA-F]{4,6} {2,3}[0-9a-fA-F]{2}( |$)`), midGap: true},
+	{name: DialectOD, line: regexp.MustCompile(`^[0-9a-fA-F]{6,} [0-9a-fA-F]{2,}( |$)`), ascii: '>'},
+}
+
+// windbgOnly matches what tells a WinDbg line from a hexdump -C line.
+var windbgOnly = regexp.MustCompile("^[0-9a-fA-F]{8}`|[0-9a-fA-F?]{2}-[0-9a-fA-F?]{2}|\\?\\?")
+
+// bareOffset matches the last line of hexdump and od dumps, the offset
+// after the last byte.
+var bareOffset = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+
+// line is a dump line split into its offset and bytes.
+type line struct {
+	num     int // 1-based line number in the text
+	offset  string
+	data    []byte
+	unread  []bool // bytes shown as ?? by WinDbg
+	repeat  bool   // "*"
+	endOnly bool   // bare offset
+}
+
+// Parse reads a hex dump of a known dialect, C arrays or escaped strings.
+func Parse(text string) (*Dump, error) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	switch trimmed := strings.TrimSpace(text); {
+	case strings.HasPrefix(trimmed, `"`):
+		return parseEscaped(trimmed)
+	case isCArray(trimmed):
+		return parseCArrays(trimmed)
+	}
+
+	rows := strings.Split(text, "\n")
+	d, ok := detect(rows)
+	if !ok {
+		return nil, fmt.Errorf("%w: no xxd, hexdump -C, od, Wireshark or WinDbg dump lines found", ErrInvalidDump)
+	}
+
+	var lines []line
+	for i, row := range rows {
+		row = strings.TrimRight(row, " \t")
+		switch {
+		case strings.TrimSpace(row) == "*":
+			lines = append(lines, line{num: i + 1, repeat: true})
+		case bareOffset.MatchString(row) && (d.name == DialectHexdump || d.name == DialectOD):
+			lines = append(lines, line{num: i + 1, offset: row, endOnly: true})
+		case d.line.MatchString(row):
+			l, err := d.parseLine(row)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidDump, i+1, err)
+			}
+			l.num = i + 1
+			lines = append(lines, l)
+		}
+	}
+
+	radix := 16
+	if d.name == DialectOD {
+		radix = odRadix(lines)
+	}
+	return assemble(d.name, lines, radix)
+}
+
+// detect returns the dialect of the first dump line.
+func detect(rows []string) (dialect, bool) {
+	for _, row := range rows {
+		row = strings.TrimRight(row, " \t")
+		for _, d := range dialects {
+			if !d.line.MatchString(row) {
+				continue
+			}
+			if d.name == DialectWinDbg && !windbgOnly.MatchString(row) {
+				continue
+			}
+			return d, true
+		}
+	}
+	return dialect{}, false
+}
+
+// parseLine splits a dump line into its offset and bytes. The bytes end
+// where the separators no longer fit the dialect, so an ASCII column is
+// not read as hex even if it looks like hex.
+func (d dialect) parseLine(row string) (line, error) {
+	var l line
+	var rest string
+	if d.name == DialectXXD {
+		l.offset, rest, _ = strings.Cut(row, ":")
+	} else {
+		l.offset, rest, _ = strings.Cut(row, " ")
+		l.offset = strings.ReplaceAll(l.offset, "`", "")
+	}
+	if d.ascii != 0 {
+		if i := strings.IndexByte(rest, d.ascii); i >= 0 {
+			rest = rest[:i]
+		}
+	}
+
+	var tokens []string
+	i := len(rest) - len(strings.TrimLeft(rest, " "))
+	for i < len(rest) {
+		j := i
+		for j < len(rest) && rest[j] != ' ' && !(d.dash && rest[j] == '-') {
+			j++
+		}
+		tok := rest[i:j]
+		if !validToken(d.name, tok, tokens) {
+			break
+		}
+		tokens = append(tokens, tok)
+
+		k := j
+		for k < len(rest) && (rest[k] == ' ' || d.dash && rest[k] == '-') {
+			k++
+		}
+		sep := rest[j:k]
+		bytes := len(tokens) * len(tokens[0]) / 2
+		if sep != " " && !(d.dash && sep == "-" && bytes == 8) && !(d.midGap && sep == "  " && bytes == 8) {
+			break
+		}
+		i = k
+	}
+	if len(tokens) == 0 {
+		return l, errors.New("no bytes")
+	}
+
+	for _, tok := range tokens {
+		switch {
+		case tok == "??":
+			l.data = append(l.data, 0)
+			l.unread = append(l.unread, true)
+			continue
+		case d.name == DialectOD:
+			b, err := odWord(tok)
+			if err != nil {
+				return l, err
+			}
+			l.data = append(l.data, b...)
+		default:
+			b, err := hex.DecodeString(tok)
+			if err != nil {
+				return l, fmt.Errorf("group %q: %w", tok, err)
+			}
+			l.data = append(l.data, b...)
+		}
+		l.unread = append(l.unread, make([]bool, len(l.data)-len(l.unread))...)
+	}
+	return l, nil
+}
+
+// validToken reports whether tok continues the groups of a line: hex of
+// the width of the first group (WinDbg ?? for unreadable bytes), or for
+// xxd a shorter last group.
+func validToken(dialect, tok string, before []string) bool {
+	if tok == "" {
+		return false
+	}
+	if dialect == DialectWinDbg && tok == "??" {
+		return true
+	}
+	for _, c := range tok {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	if len(before) == 0 {
+		return dialect == DialectOD || len(tok)%2 == 0
+	}
+	width := len(before[0])
+	last := before[len(before)-1]
+	if dialect == DialectXXD && len(last) < width {
+		return false // nothing follows a short last group
+	}
+	return len(tok) == width || dialect == DialectXXD && len(tok) < width && len(tok)%2 == 0
+}
+
+// odWord decodes an od group by its width: 2 (x1), 3 (o1), 4 (x2), 6
+// (o2), 8 (x4), 11 (o4) or 16 (x8) digits. Words are little-endian, as od
+// prints them in host order.
+func odWord(tok string) ([]byte, error) {
+	size, base := 0, 16
+	switch len(tok) {
+	case 2:
+		size = 1
+	case 3:
+		size, base = 1, 8
+	case 4:
+		size = 2
+	case 6:
+		size, base = 2, 8
+	case 8:
+		size = 4
+	case 11:
+		size, base = 4, 8
+	case 16:
+		size = 8
+	default:
+		return nil, fmt.Errorf("od group %q has an unknown width", tok)
+	}
+	v, err := strconv.ParseUint(tok, base, 8*size)
+	if err != nil {
+		return nil, fmt.Errorf("od group %q: %w", tok, err)
+	}
+	b := make([]byte, size)
+	for i := range b {
+		b[i] = byte(v >> (8 * i))
+	}
+	return b, nil
+}
+
+// odRadix returns the radix of od offsets (-A o, x or d): the first of
+// octal, the od default, hex and decimal in which the offsets of
+// consecutive lines are as far apart as the bytes of the lines.
+func odRadix(lines []line) int {
+	for _, radix := range []int{8, 16, 10} {
+		ok := true
+		for i := 0; i+1 < len(lines) && ok; i++ {
+			a, b := lines[i], lines[i+1]
+			if a.repeat || b.repeat || a.endOnly {
+				continue
+			}
+			x, err1 := strconv.ParseUint(a.offset, radix, 64)
+			y, err2 := strconv.ParseUint(b.offset, radix, 64)
+			ok = err1 == nil && err2 == nil && y == x+uint64(len(a.data))
+		}
+		if ok {
+			for _, l := range lines {
+				if _, err := strconv.ParseUint(l.offset, radix, 64); l.offset != "" && err != nil {
+					ok = false
+				}
+			}
+		}
+		if ok {
+			return radix
+		}
+	}
+	return 8
+}
+
+// assemble places the bytes of the lines at their offsets.
+func assemble(name string, lines []line, radix int) (*Dump, error) {
+	dump := &Dump{Dialect: name}
+	var end uint64 // offset after the last byte
+	var prev []byte
+	repeat := false
+	started := false
+
+	for _, l := range lines {
+		dump.Lines++
+		if l.repeat {
+			repeat = started
+			continue
+		}
+		off, err := strconv.ParseUint(l.offset, radix, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: offset %q: %w", ErrInvalidDump, l.num, l.offset, err)
+		}
+		if !started {
+			if l.endOnly {
+				continue
+			}
+			dump.Base, end, started = off, off, true
+		}
+		if off < end {
+			return nil, fmt.Errorf("%w: line %d: offset 0x%x is before the end of the previous line at 0x%x", ErrInvalidDump, l.num, off, end)
+		}
+		if off-dump.Base > MaxSize {
+			return nil, fmt.Errorf("%w: line %d: offset 0x%x is more than %d MiB after the start", ErrInvalidDump, l.num, off, MaxSize>>20)
+		}
+
+		if repeat && len(prev) > 0 {
+			// The previous line repeats up to this one
+			for end+uint64(len(prev)) <= off {
+				dump.Data = append(dump.Data, prev...)
+				end += uint64(len(prev))
+			}
+			repeat = false
+		}
+		if off > end {
+			dump.addGap(end, off-end)
+			dump.Data = append(dump.Data, make([]byte, off-end)...)
+			end = off
+		}
+		if l.endOnly {
+			continue
+		}
+
+		for i, unread := range l.unread {
+			if unread {
+				dump.addGap(end+uint64(i), 1)
+			}
+		}
+		dump.Data = append(dump.Data, l.data...)
+		end += uint64(len(l.data))
+		prev = l.data
+	}
+	if !started {
+		return nil, fmt.Errorf("%w: no bytes found", ErrInvalidDump)
+	}
+	return dump, nil
+}
+
+// addGap adds a gap, merged with the last one if they touch.
+func (d *Dump) addGap(offset, length uint64) {
+	if n := len(d.Gaps); n > 0 && d.Gaps[n-1].Offset+d.Gaps[n-1].Length == offset {
+		d.Gaps[n-1].Length += length
+		return
+	}
+	d.Gaps = append(d.Gaps, Gap{Offset: offset, Length: length})
+}
+
+// Buffer returns the bytes of the dump as a sparse buffer, without the gaps.
+func (d *Dump) Buffer() (*sparse.Buffer, error) {
+	gaps := make([]sparse.Range, len(d.Gaps))
+	for i, g := range d.Gaps {
+		gaps[i] = sparse.Range{Start: g.Offset, Length: g.Length}
+	}
+	return sparse.FromBytes(d.Base, d.Data, gaps)
+}
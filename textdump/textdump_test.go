@@ -0,0 +1,232 @@
+package textdump
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Dumps of "Hello, World!\n" followed by the bytes 00 to 0f, as each tool
+// prints them
+const (
+	testXXD = `00000000: 4865 6c6c 6f2c 2057 6f72 6c64 210a 0001  Hello, World!...
+00000010: 0203 0405 0607 0809 0a0b 0c0d 0e0f       ..............
+`
+	testHexdump = `00000000  48 65 6c 6c 6f 2c 20 57  6f 72 6c 64 21 0a 00 01  |Hello, World!...|
+00000010  02 03 04 05 06 07 08 09  0a 0b 0c 0d 0e 0f        |..............|
+0000001e
+`
+	testOD = `0000000 48 65 6c 6c 6f 2c 20 57 6f 72 6c 64 21 0a 00 01
+0000020 02 03 04 05 06 07 08 09 0a 0b 0c 0d 0e 0f
+0000036
+`
+	testODWords = `0000000 062510 066154 026157 053440 071157 062154 005041 000400
+0000020 001402 002404 003406 004410 005412 006414 007416
+0000036
+`
+	testWireshark = `0000   48 65 6c 6c 6f 2c 20 57 6f 72 6c 64 21 0a 00 01   Hello, World!...
+0010   02 03 04 05 06 07 08 09 0a 0b 0c 0d 0e 0f         ..............
+`
+	testWinDbg = "0:000> db 00007ff6`12340000 L1e\n" +
+		"00007ff6`12340000  48 65 6c 6c 6f 2c 20 57-6f 72 6c 64 21 0a 00 01  Hello, World!...\n" +
+		"00007ff6`12340010  02 03 04 05 06 07 08 09-0a 0b 0c 0d 0e 0f        ..............\n"
+)
+
+var testData = []byte("Hello, World!\n\x00\x01\x02\x03\x04\x05\x06\x07\x08\x09\x0a\x0b\x0c\x0d\x0e\x0f")
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		dialect string
+		base    uint64
+	}{
+		{"xxd", testXXD, DialectXXD, 0},
+		{"hexdump -C", testHexdump, DialectHexdump, 0},
+		{"od -t x1", testOD, DialectOD, 0},
+		{"od words", testODWords, DialectOD, 0},
+		{"wireshark", testWireshark, DialectWireshark, 0},
+		{"windbg", testWinDbg, DialectWinDbg, 0x00007ff612340000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := Parse(tt.text)
+			if err != nil {
+				t.Fatalf("Parse() error: %v", err)
+			}
+			if d.Dialect != tt.dialect || d.Base != tt.base || len(d.Gaps) != 0 {
+				t.Errorf("Parse() = %s at 0x%x, gaps %v, want %s at 0x%x", d.Dialect, d.Base, d.Gaps, tt.dialect, tt.base)
+			}
+			if string(d.Data) != string(testData) {
+				t.Errorf("Parse() data = %q, want %q", d.Data, testData)
+			}
+		})
+	}
+}
+
+func TestParse_HexLookingASCII(t *testing.T) {
+	// The ASCII column of a short line is all hex digits
+	d, err := Parse("00000000: 6361 6665  cafe\n")
+	if err != nil || string(d.Data) != "cafe" {
+		t.Errorf("xxd = %q, %v, want cafe", d.Data, err)
+	}
+	d, err = Parse("0000   63 61 66 65   cafe\n")
+	if err != nil || string(d.Data) != "cafe" {
+		t.Errorf("wireshark = %q, %v, want cafe", d.Data, err)
+	}
+	d, err = Parse("00000000  63 61 66 65 62 61 62 65  63 61 66 65 62 61 62 65  |cafebabecafebabe|\n")
+	if err != nil || string(d.Data) != "cafebabecafebabe" {
+		t.Errorf("hexdump = %q, %v, want cafebabecafebabe", d.Data, err)
+	}
+}
+
+func TestParse_Repeats(t *testing.T) {
+	text := `00000000  00 00 00 00 00 00 00 00  00 00 00 00 00 00 00 00  |................|
+*
+00000030  ff ff                                             |..|
+00000032
+`
+	d, err := Parse(text)
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if len(d.Data) != 0x32 || d.Data[0x2f] != 0 || d.Data[0x30] != 0xff || len(d.Gaps) != 0 || d.Lines != 4 {
+		t.Errorf("Parse() = %d bytes, gaps %v, %d lines", len(d.Data), d.Gaps, d.Lines)
+	}
+
+	text = `0000000 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00
+*
+0000040 01
+0000041
+`
+	d, err = Parse(text)
+	if err != nil || len(d.Data) != 0x21 || d.Data[0x20] != 1 {
+		t.Errorf("od = %d bytes, %v", len(d.Data), err)
+	}
+}
+
+func TestParse_Gaps(t *testing.T) {
+	text := `00001000: 0102 0304  ....
+00001010: 0506  ..
+`
+	d, err := Parse(text)
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if d.Base != 0x1000 || len(d.Data) != 0x12 || d.Data[0x10] != 5 {
+		t.Errorf("Parse() = %d bytes at 0x%x", len(d.Data), d.Base)
+	}
+	if want := []Gap{{Offset: 0x1004, Length: 12}}; !reflect.DeepEqual(d.Gaps, want) {
+		t.Errorf("Parse() gaps = %v, want %v", d.Gaps, want)
+	}
+	b, err := d.Buffer()
+	if err != nil {
+		t.Fatalf("Buffer() error: %v", err)
+	}
+	if segs := b.Segments(); len(segs) != 2 || segs[0].Address != 0x1000 || segs[1].Address != 0x1010 || b.Size() != 6 {
+		t.Errorf("Buffer() = %v", segs)
+	}
+
+	// Unreadable memory
+	text = "20001000  01 02 ?? ?? ?? ?? ?? ??-?? ?? ?? ?? ?? ?? ?? ??  ..??????????????\n" +
+		"20001010  ?? ?? 03 04                                      ??..\n"
+	d, err = Parse(text)
+	if err != nil {
+		t.Fatalf("Parse(windbg) error: %v", err)
+	}
+	if d.Dialect != DialectWinDbg || len(d.Data) != 0x14 || d.Data[0x13] != 4 {
+		t.Errorf("Parse(windbg) = %s, %d bytes", d.Dialect, len(d.Data))
+	}
+	if want := []Gap{{Offset: 0x20001002, Length: 16}}; !reflect.DeepEqual(d.Gaps, want) {
+		t.Errorf("Parse(windbg) gaps = %v, want %v", d.Gaps, want)
+	}
+}
+
+func TestParse_CArrays(t *testing.T) {
+	// A frame and the TCP payload Wireshark reassembled, whose ASCII
+	// comments hold a brace and hex digits
+	text := `/* Frame (16 bytes) */
+static const unsigned char pkt1[16] = {
+0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x2c, 0x20, 0x7d, /* Hello, } */
+0x63, 0x61, 0x66, 0x65, 0x00, 0x01, 0x02, 0x03  /* cafe.... */
+};
+
+/* Reassembled TCP (3 bytes) */
+static const unsigned char pkt1_1[3] = {
+0xde, 0xad, 0xbe /* ... */
+};
+`
+	d, err := Parse(text)
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if d.Dialect != DialectCArray || string(d.Data) != "Hello, }cafe\x00\x01\x02\x03\xde\xad\xbe" || d.Lines != 3 {
+		t.Errorf("Parse() = %s %q, %d lines", d.Dialect, d.Data, d.Lines)
+	}
+	want := []Packet{
+		{Name: "pkt1", Title: "Frame (16 bytes)", Offset: 0, Length: 16},
+		{Name: "pkt1_1", Title: "Reassembled TCP (3 bytes)", Offset: 16, Length: 3},
+	}
+	if !reflect.DeepEqual(d.Packets, want) {
+		t.Errorf("Parse() packets = %+v, want %+v", d.Packets, want)
+	}
+
+	d, err = Parse("const uint8_t buf[] = { 0x01, 2, 010, // flags\n 0xFF };")
+	if err != nil || string(d.Data) != "\x01\x02\x08\xff" || d.Packets[0].Name != "buf" {
+		t.Errorf("Parse(source array) = %q, %v", d.Data, err)
+	}
+	d, err = Parse("{0xde, 0xad}")
+	if err != nil || string(d.Data) != "\xde\xad" || len(d.Packets) != 1 {
+		t.Errorf("Parse(brace list) = %q, %v", d.Data, err)
+	}
+}
+
+func TestParse_Escaped(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"wireshark", `"\x48\x65\x6c\x6c\x6f\x00\xff"`, "Hello\x00\xff"},
+		{"continued", "\"\\x01\\x02\" \\\r\n\"\\x03\"\n", "\x01\x02\x03"},
+		{"c escapes", `"A\tB\n\0\101\\\""`, "A\tB\n\x00A\\\""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := Parse(tt.text)
+			if err != nil {
+				t.Fatalf("Parse() error: %v", err)
+			}
+			if d.Dialect != DialectEscaped || string(d.Data) != tt.want {
+				t.Errorf("Parse() = %s %q, want %q", d.Dialect, d.Data, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"not a dump", "hello world"},
+		{"plain hex", "48656c6c6f"},
+		{"overlap", "00000010: 0102 0304  ....\n00000012: 0506  ..\n"},
+		{"out of order", "0010   01 02   ..\n0000   03 04   ..\n"},
+		{"too large", "00000000: 0102  ..\nffff0000: 0304  ..\n"},
+		{"odd group", "00000000: 012  ..\n"},
+		{"array length", "static const unsigned char pkt1[3] = {\n0x01, 0x02 /* .. */\n};"},
+		{"array element", "unsigned char pkt1[] = { 0x100 };"},
+		{"unclosed array", "unsigned char pkt1[] = { 0x01, 0x02"},
+		{"unclosed string", `"\x01\x02`},
+		{"text after string", `"\x01" x`},
+		{"bad escape", `"\q"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.text); !errors.Is(err, ErrInvalidDump) {
+				t.Errorf("Parse() error = %v, want ErrInvalidDump", err)
+			}
+		})
+	}
+}